@@ -0,0 +1,105 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	chainAliasPrefix = []byte("chain")
+	routeAliasPrefix = []byte("route")
+
+	_ AliasStore = &aliasStore{}
+)
+
+// AliasStore persists the chain and HTTP route aliases that were added through
+// the admin API so that they can be re-applied when the node restarts.
+type AliasStore interface {
+	// PutChainAlias records that [alias] refers to [chainID]
+	PutChainAlias(chainID ids.ID, alias string) error
+	// HasChainAlias returns true if [alias] is a persisted chain alias
+	HasChainAlias(alias string) (bool, error)
+	// DeleteChainAlias removes the persisted chain alias [alias]
+	DeleteChainAlias(alias string) error
+	// ChainAliases returns the persisted chain aliases, keyed by chain ID
+	ChainAliases() (map[ids.ID][]string, error)
+
+	// PutRouteAlias records that [alias] refers to the HTTP route [endpoint]
+	PutRouteAlias(endpoint, alias string) error
+	// HasRouteAlias returns true if [alias] is a persisted route alias
+	HasRouteAlias(alias string) (bool, error)
+	// DeleteRouteAlias removes the persisted route alias [alias]
+	DeleteRouteAlias(alias string) error
+	// RouteAliases returns the persisted route aliases, keyed by endpoint
+	RouteAliases() (map[string][]string, error)
+}
+
+type aliasStore struct {
+	// Alias --> Chain ID
+	chainDB database.Database
+	// Alias --> Endpoint
+	routeDB database.Database
+}
+
+// NewAliasStore returns an AliasStore that persists aliases in [db]
+func NewAliasStore(db database.Database) AliasStore {
+	return &aliasStore{
+		chainDB: prefixdb.New(chainAliasPrefix, db),
+		routeDB: prefixdb.New(routeAliasPrefix, db),
+	}
+}
+
+func (s *aliasStore) PutChainAlias(chainID ids.ID, alias string) error {
+	return s.chainDB.Put([]byte(alias), chainID[:])
+}
+
+func (s *aliasStore) HasChainAlias(alias string) (bool, error) {
+	return s.chainDB.Has([]byte(alias))
+}
+
+func (s *aliasStore) DeleteChainAlias(alias string) error {
+	return s.chainDB.Delete([]byte(alias))
+}
+
+func (s *aliasStore) ChainAliases() (map[ids.ID][]string, error) {
+	it := s.chainDB.NewIterator()
+	defer it.Release()
+
+	aliases := make(map[ids.ID][]string)
+	for it.Next() {
+		chainID, err := ids.ToID(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		aliases[chainID] = append(aliases[chainID], string(it.Key()))
+	}
+	return aliases, it.Error()
+}
+
+func (s *aliasStore) PutRouteAlias(endpoint, alias string) error {
+	return s.routeDB.Put([]byte(alias), []byte(endpoint))
+}
+
+func (s *aliasStore) HasRouteAlias(alias string) (bool, error) {
+	return s.routeDB.Has([]byte(alias))
+}
+
+func (s *aliasStore) DeleteRouteAlias(alias string) error {
+	return s.routeDB.Delete([]byte(alias))
+}
+
+func (s *aliasStore) RouteAliases() (map[string][]string, error) {
+	it := s.routeDB.NewIterator()
+	defer it.Release()
+
+	aliases := make(map[string][]string)
+	for it.Next() {
+		endpoint := string(it.Value())
+		aliases[endpoint] = append(aliases[endpoint], string(it.Key()))
+	}
+	return aliases, it.Error()
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestAliasStore(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	store := NewAliasStore(db)
+
+	chainID := ids.GenerateTestID()
+	assert.NoError(store.PutChainAlias(chainID, "chain-1"))
+	assert.NoError(store.PutChainAlias(chainID, "chain-2"))
+	assert.NoError(store.PutRouteAlias("bc/X", "x"))
+
+	exists, err := store.HasChainAlias("chain-1")
+	assert.NoError(err)
+	assert.True(exists)
+
+	exists, err = store.HasChainAlias("x")
+	assert.NoError(err)
+	assert.False(exists)
+
+	exists, err = store.HasRouteAlias("x")
+	assert.NoError(err)
+	assert.True(exists)
+
+	// The aliases should be read back from a store over the same database
+	store = NewAliasStore(db)
+
+	chainAliases, err := store.ChainAliases()
+	assert.NoError(err)
+	assert.Equal(map[ids.ID][]string{chainID: {"chain-1", "chain-2"}}, chainAliases)
+
+	routeAliases, err := store.RouteAliases()
+	assert.NoError(err)
+	assert.Equal(map[string][]string{"bc/X": {"x"}}, routeAliases)
+
+	assert.NoError(store.DeleteChainAlias("chain-1"))
+	assert.NoError(store.DeleteRouteAlias("x"))
+
+	chainAliases, err = store.ChainAliases()
+	assert.NoError(err)
+	assert.Equal(map[ids.ID][]string{chainID: {"chain-2"}}, chainAliases)
+
+	routeAliases, err = store.RouteAliases()
+	assert.NoError(err)
+	assert.Empty(routeAliases)
+}
@@ -21,14 +21,23 @@ type Client interface {
 	StopCPUProfiler(context.Context, ...rpc.Option) error
 	MemoryProfile(context.Context, ...rpc.Option) error
 	LockProfile(context.Context, ...rpc.Option) error
+	ListProfiles(context.Context, ...rpc.Option) ([]ProfileSnapshot, error)
 	Alias(ctx context.Context, endpoint string, alias string, options ...rpc.Option) error
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) error
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
+	ListAliases(context.Context, ...rpc.Option) (map[ids.ID][]string, map[string][]string, error)
+	RemoveAlias(ctx context.Context, alias string, options ...rpc.Option) error
+	RemoveChainAlias(ctx context.Context, alias string, options ...rpc.Option) error
 	Stacktrace(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	ReloadConfig(ctx context.Context, options ...rpc.Option) (*ReloadConfigReply, error)
+	Backup(ctx context.Context, args *BackupArgs, options ...rpc.Option) (*BackupReply, error)
+	TrackSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) error
+	UntrackSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) error
+	UpdateChainConfig(ctx context.Context, chain string, config []byte, options ...rpc.Option) error
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -60,6 +69,12 @@ func (c *client) LockProfile(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "lockProfile", struct{}{}, &api.EmptyReply{}, options...)
 }
 
+func (c *client) ListProfiles(ctx context.Context, options ...rpc.Option) ([]ProfileSnapshot, error) {
+	res := &ListProfilesReply{}
+	err := c.requester.SendRequest(ctx, "listProfiles", struct{}{}, res, options...)
+	return res.Profiles, err
+}
+
 func (c *client) Alias(ctx context.Context, endpoint, alias string, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "alias", &AliasArgs{
 		Endpoint: endpoint,
@@ -82,6 +97,24 @@ func (c *client) GetChainAliases(ctx context.Context, chain string, options ...r
 	return res.Aliases, err
 }
 
+func (c *client) ListAliases(ctx context.Context, options ...rpc.Option) (map[ids.ID][]string, map[string][]string, error) {
+	res := &ListAliasesReply{}
+	err := c.requester.SendRequest(ctx, "listAliases", struct{}{}, res, options...)
+	return res.ChainAliases, res.RouteAliases, err
+}
+
+func (c *client) RemoveAlias(ctx context.Context, alias string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "removeAlias", &RemoveAliasArgs{
+		Alias: alias,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) RemoveChainAlias(ctx context.Context, alias string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "removeChainAlias", &RemoveAliasArgs{
+		Alias: alias,
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) Stacktrace(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "stacktrace", struct{}{}, &api.EmptyReply{}, options...)
 }
@@ -140,3 +173,34 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) ReloadConfig(ctx context.Context, options ...rpc.Option) (*ReloadConfigReply, error) {
+	res := &ReloadConfigReply{}
+	err := c.requester.SendRequest(ctx, "reloadConfig", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) Backup(ctx context.Context, args *BackupArgs, options ...rpc.Option) (*BackupReply, error) {
+	res := &BackupReply{}
+	err := c.requester.SendRequest(ctx, "backup", args, res, options...)
+	return res, err
+}
+
+func (c *client) TrackSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "trackSubnet", &SubnetArgs{
+		SubnetID: subnetID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UntrackSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "untrackSubnet", &SubnetArgs{
+		SubnetID: subnetID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UpdateChainConfig(ctx context.Context, chain string, config []byte, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "updateChainConfig", &UpdateChainConfigArgs{
+		Chain:  chain,
+		Config: string(config),
+	}, &api.EmptyReply{}, options...)
+}
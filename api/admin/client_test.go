@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -64,6 +65,15 @@ func (mc *mockClient) SendRequest(ctx context.Context, method string, params int
 	case *GetLoggerLevelReply:
 		response := mc.response.(*GetLoggerLevelReply)
 		*p = *response
+	case *ListProfilesReply:
+		response := mc.response.(*ListProfilesReply)
+		*p = *response
+	case *ReloadConfigReply:
+		response := mc.response.(*ReloadConfigReply)
+		*p = *response
+	case *BackupReply:
+		response := mc.response.(*BackupReply)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -137,6 +147,33 @@ func TestLockProfile(t *testing.T) {
 	}
 }
 
+func TestListProfiles(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := []ProfileSnapshot{
+			{
+				Name:      "cpu.profile",
+				Size:      1024,
+				Timestamp: time.Unix(1, 0),
+			},
+		}
+		mockClient := client{requester: NewMockClient(&ListProfilesReply{
+			Profiles: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.ListProfiles(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ListProfilesReply{}, errors.New("some error"))}
+
+		_, err := mockClient.ListProfiles(context.Background())
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
 func TestAlias(t *testing.T) {
 	tests := GetSuccessResponseTests()
 
@@ -385,3 +422,98 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestReloadConfig(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &ReloadConfigReply{
+			Applied:         []string{"log-level"},
+			RequiresRestart: []string{"http-port"},
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.ReloadConfig(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ReloadConfigReply{}, errors.New("some error"))}
+
+		_, err := mockClient.ReloadConfig(context.Background())
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestBackup(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &BackupReply{
+			Location:     "/backups/1.backup",
+			Keys:         2,
+			ExcludedKeys: 1,
+			Size:         100,
+			Checksum:     ids.GenerateTestID(),
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.Backup(context.Background(), &BackupArgs{Name: "1.backup"})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&BackupReply{}, errors.New("some error"))}
+
+		_, err := mockClient.Backup(context.Background(), &BackupArgs{})
+
+		assert.EqualError(t, err, "some error")
+	})
+}
+
+func TestTrackSubnet(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.TrackSubnet(context.Background(), ids.GenerateTestID())
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+}
+
+func TestUntrackSubnet(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.UntrackSubnet(context.Background(), ids.GenerateTestID())
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+}
+
+func TestUpdateChainConfig(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.UpdateChainConfig(context.Background(), "C", []byte(`{"pruning-enabled":false}`))
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+}
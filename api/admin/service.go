@@ -5,14 +5,15 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"path"
-
-	"github.com/gorilla/rpc/v2"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database/backup"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -32,10 +33,29 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong      = errors.New("alias length is too long")
+	errUnknownRouteAlias = errors.New("alias isn't a persisted route alias")
+	errUnknownChainAlias = errors.New("alias isn't a persisted chain alias")
+	errNoLogLevel        = errors.New("need to specify either displayLevel or logLevel")
+	errNoConfigReloader  = errors.New("config reloading isn't supported")
+	errNoBackuper        = errors.New("backups aren't supported")
 )
 
+// ConfigReloader applies changes to the node's config without restarting it
+type ConfigReloader interface {
+	// ReloadConfig returns the keys that were applied and the keys that
+	// changed but require a restart to take effect.
+	ReloadConfig() (applied []string, requiresRestart []string, err error)
+}
+
+// Backuper writes backups of the node's database while the node is running
+type Backuper interface {
+	// Backup writes an archive of the node's database to the file [name] in
+	// the backup directory and uploads it if [upload] is non-nil. Returns the
+	// location of the archive.
+	Backup(name string, config backup.Config, upload *backup.S3Config) (string, backup.Report, error)
+}
+
 type Config struct {
 	Log          logging.Logger
 	ProfileDir   string
@@ -45,6 +65,12 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	AliasStore   AliasStore
+	Reloader     ConfigReloader
+	Backuper     Backuper
+
+	// Directory that the continuous profiler writes profiles to
+	ContinuousProfileDir string
 }
 
 // Admin is the API service for node admin management
@@ -56,7 +82,7 @@ type Admin struct {
 // NewService returns a new admin API service.
 // All of the fields in [config] must be set.
 func NewService(config Config) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := json.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -97,6 +123,38 @@ func (service *Admin) LockProfile(_ *http.Request, _ *struct{}, _ *api.EmptyRepl
 	return service.profiler.LockProfile()
 }
 
+// ProfileSnapshot describes a profile written by the continuous profiler
+type ProfileSnapshot struct {
+	Name      string      `json:"name"`
+	Size      json.Uint64 `json:"size"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// ListProfilesReply are the results from calling ListProfiles
+type ListProfilesReply struct {
+	Profiles []ProfileSnapshot `json:"profiles"`
+}
+
+// ListProfiles returns the profiles written by the continuous profiler, from
+// most recent to least recent
+func (service *Admin) ListProfiles(_ *http.Request, _ *struct{}, reply *ListProfilesReply) error {
+	service.Log.Debug("Admin: ListProfiles called")
+
+	snapshots, err := profiler.ListSnapshots(service.ContinuousProfileDir)
+	if err != nil {
+		return fmt.Errorf("couldn't list profiles: %w", err)
+	}
+	reply.Profiles = make([]ProfileSnapshot, len(snapshots))
+	for i, snapshot := range snapshots {
+		reply.Profiles[i] = ProfileSnapshot{
+			Name:      snapshot.Name,
+			Size:      json.Uint64(snapshot.Size),
+			Timestamp: snapshot.ModTime,
+		}
+	}
+	return nil
+}
+
 // AliasArgs are the arguments for calling Alias
 type AliasArgs struct {
 	Endpoint string `json:"endpoint"`
@@ -111,7 +169,10 @@ func (service *Admin) Alias(_ *http.Request, args *AliasArgs, _ *api.EmptyReply)
 		return errAliasTooLong
 	}
 
-	return service.HTTPServer.AddAliasesWithReadLock(args.Endpoint, args.Alias)
+	if err := service.HTTPServer.AddAliasesWithReadLock(args.Endpoint, args.Alias); err != nil {
+		return err
+	}
+	return service.AliasStore.PutRouteAlias(args.Endpoint, args.Alias)
 }
 
 // AliasChainArgs are the arguments for calling AliasChain
@@ -138,7 +199,79 @@ func (service *Admin) AliasChain(_ *http.Request, args *AliasChainArgs, _ *api.E
 
 	endpoint := path.Join(constants.ChainAliasPrefix, chainID.String())
 	alias := path.Join(constants.ChainAliasPrefix, args.Alias)
-	return service.HTTPServer.AddAliasesWithReadLock(endpoint, alias)
+	if err := service.HTTPServer.AddAliasesWithReadLock(endpoint, alias); err != nil {
+		return err
+	}
+	return service.AliasStore.PutChainAlias(chainID, args.Alias)
+}
+
+// ListAliasesReply are the aliases that were added through this API
+type ListAliasesReply struct {
+	// Chain ID --> Aliases of the chain
+	ChainAliases map[ids.ID][]string `json:"chainAliases"`
+	// Endpoint --> Aliases of the endpoint
+	RouteAliases map[string][]string `json:"routeAliases"`
+}
+
+// ListAliases returns the chain and HTTP route aliases that were added through
+// this API. These aliases are re-applied when the node restarts.
+func (service *Admin) ListAliases(_ *http.Request, _ *struct{}, reply *ListAliasesReply) error {
+	service.Log.Debug("Admin: ListAliases called")
+
+	var err error
+	reply.ChainAliases, err = service.AliasStore.ChainAliases()
+	if err != nil {
+		return err
+	}
+	reply.RouteAliases, err = service.AliasStore.RouteAliases()
+	return err
+}
+
+// RemoveAliasArgs are the arguments for calling RemoveAlias and
+// RemoveChainAlias
+type RemoveAliasArgs struct {
+	Alias string `json:"alias"`
+}
+
+// RemoveAlias removes an HTTP route alias that was added with Alias
+func (service *Admin) RemoveAlias(_ *http.Request, args *RemoveAliasArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: RemoveAlias called with Alias: %s", args.Alias)
+
+	exists, err := service.AliasStore.HasRouteAlias(args.Alias)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errUnknownRouteAlias
+	}
+
+	if err := service.HTTPServer.RemoveAliasesWithReadLock(args.Alias); err != nil {
+		return err
+	}
+	return service.AliasStore.DeleteRouteAlias(args.Alias)
+}
+
+// RemoveChainAlias removes a chain alias that was added with AliasChain
+func (service *Admin) RemoveChainAlias(_ *http.Request, args *RemoveAliasArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: RemoveChainAlias called with Alias: %s", args.Alias)
+
+	exists, err := service.AliasStore.HasChainAlias(args.Alias)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errUnknownChainAlias
+	}
+
+	if err := service.ChainManager.RemoveAlias(args.Alias); err != nil {
+		return err
+	}
+
+	alias := path.Join(constants.ChainAliasPrefix, args.Alias)
+	if err := service.HTTPServer.RemoveAliasesWithReadLock(alias); err != nil {
+		return err
+	}
+	return service.AliasStore.DeleteChainAlias(args.Alias)
 }
 
 // GetChainAliasesArgs are the arguments for calling GetChainAliases
@@ -182,6 +315,8 @@ type SetLoggerLevelArgs struct {
 // SetLoggerLevel sets the log level and/or display level for loggers.
 // If len([args.LoggerName]) == 0, sets the log/display level of all loggers.
 // Otherwise, sets the log/display level of the loggers named in that argument.
+// The logger of a chain can be named by any of the chain's aliases, and the
+// networking logs to the "network" logger.
 // Sets the log level of these loggers to args.LogLevel.
 // If args.LogLevel == nil, doesn't set the log level of these loggers.
 // If args.LogLevel != nil, must be a valid string representation of a log level.
@@ -195,15 +330,7 @@ func (service *Admin) SetLoggerLevel(_ *http.Request, args *SetLoggerLevelArgs,
 		return errNoLogLevel
 	}
 
-	var loggerNames []string
-	if len(args.LoggerName) > 0 {
-		loggerNames = []string{args.LoggerName}
-	} else {
-		// Empty name means all loggers
-		loggerNames = service.LogFactory.GetLoggerNames()
-	}
-
-	for _, name := range loggerNames {
+	for _, name := range service.loggerNames(args.LoggerName) {
 		if args.LogLevel != nil {
 			if err := service.LogFactory.SetLogLevel(name, *args.LogLevel); err != nil {
 				return err
@@ -233,19 +360,13 @@ type GetLoggerLevelReply struct {
 	LoggerLevels map[string]LogAndDisplayLevels `json:"loggerLevels"`
 }
 
-// GetLogLevel returns the log level and display level of all loggers.
+// GetLoggerLevel returns the log level and display level of the logger named
+// [args.LoggerName]. If len([args.LoggerName]) == 0, lists the levels of all
+// loggers.
 func (service *Admin) GetLoggerLevel(_ *http.Request, args *GetLoggerLevelArgs, reply *GetLoggerLevelReply) error {
 	service.Log.Debug("Admin: GetLoggerLevels called with LoggerName: %q", args.LoggerName)
 	reply.LoggerLevels = make(map[string]LogAndDisplayLevels)
-	var loggerNames []string
-	// Empty name means all loggers
-	if len(args.LoggerName) > 0 {
-		loggerNames = []string{args.LoggerName}
-	} else {
-		loggerNames = service.LogFactory.GetLoggerNames()
-	}
-
-	for _, name := range loggerNames {
+	for _, name := range service.loggerNames(args.LoggerName) {
 		logLevel, err := service.LogFactory.GetLogLevel(name)
 		if err != nil {
 			return err
@@ -262,6 +383,25 @@ func (service *Admin) GetLoggerLevel(_ *http.Request, args *GetLoggerLevelArgs,
 	return nil
 }
 
+// loggerNames returns the names of the loggers that [name] refers to. An empty
+// name refers to every logger. The logger of a chain can be referred to by any
+// of the chain's aliases.
+func (service *Admin) loggerNames(name string) []string {
+	loggerNames := service.LogFactory.GetLoggerNames()
+	if len(name) == 0 {
+		return loggerNames
+	}
+	for _, loggerName := range loggerNames {
+		if loggerName == name {
+			return []string{name}
+		}
+	}
+	if chainID, err := service.ChainManager.Lookup(name); err == nil {
+		return []string{service.ChainManager.PrimaryAliasOrDefault(chainID)}
+	}
+	return []string{name}
+}
+
 // GetConfig returns the config that the node was started with.
 func (service *Admin) GetConfig(_ *http.Request, args *struct{}, reply *interface{}) error {
 	service.Log.Debug("Admin: GetConfig called")
@@ -269,6 +409,130 @@ func (service *Admin) GetConfig(_ *http.Request, args *struct{}, reply *interfac
 	return nil
 }
 
+// ReloadConfigReply are the results of reloading the node's config
+type ReloadConfigReply struct {
+	// Config keys whose new values were applied
+	Applied []string `json:"applied"`
+	// Config keys whose values changed but require a restart to take effect
+	RequiresRestart []string `json:"requiresRestart"`
+}
+
+// ReloadConfig re-reads the node's config and applies the reloadable subset of
+// it. This has the same effect as sending SIGHUP to the node.
+func (service *Admin) ReloadConfig(_ *http.Request, _ *struct{}, reply *ReloadConfigReply) error {
+	service.Log.Debug("Admin: ReloadConfig called")
+
+	if service.Reloader == nil {
+		return errNoConfigReloader
+	}
+
+	var err error
+	reply.Applied, reply.RequiresRestart, err = service.Reloader.ReloadConfig()
+	return err
+}
+
+// BackupArgs are the arguments for calling Backup
+type BackupArgs struct {
+	// Name of the file in the node's backup directory that the backup is
+	// written to. Defaults to a name derived from the current time.
+	Name string `json:"name"`
+	// If true, the indices of accepted containers aren't included in the
+	// backup
+	ExcludeIndices bool `json:"excludeIndices"`
+	// If non-empty, URL of an object of an S3-compatible endpoint, in path
+	// style, that the backup is uploaded to. The credentials are read from the
+	// node's AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+	// environment variables.
+	UploadURL string `json:"uploadURL"`
+	// Region of the bucket that the backup is uploaded to
+	Region string `json:"region"`
+}
+
+// BackupReply describes the backup that was written
+type BackupReply struct {
+	// Path or URL of the backup
+	Location string `json:"location"`
+	// Number of keys that were included in the backup
+	Keys json.Uint64 `json:"keys"`
+	// Number of keys that were excluded from the backup
+	ExcludedKeys json.Uint64 `json:"excludedKeys"`
+	// Size of the backup, in bytes
+	Size json.Uint64 `json:"size"`
+	// Checksum of the backup
+	Checksum ids.ID `json:"checksum"`
+}
+
+// Backup writes a consistent backup of the node's database while the node
+// keeps running. The backup can be restored by starting a node with an empty
+// database and the --db-restore-file flag.
+func (service *Admin) Backup(_ *http.Request, args *BackupArgs, reply *BackupReply) error {
+	service.Log.Debug("Admin: Backup called with Name: %q, UploadURL: %q", args.Name, args.UploadURL)
+
+	if service.Backuper == nil {
+		return errNoBackuper
+	}
+
+	var upload *backup.S3Config
+	if len(args.UploadURL) != 0 {
+		upload = &backup.S3Config{
+			URL:    args.UploadURL,
+			Region: args.Region,
+		}
+	}
+	location, report, err := service.Backuper.Backup(
+		args.Name,
+		backup.Config{ExcludeIndices: args.ExcludeIndices},
+		upload,
+	)
+	if err != nil {
+		return err
+	}
+	reply.Location = location
+	reply.Keys = json.Uint64(report.Keys)
+	reply.ExcludedKeys = json.Uint64(report.ExcludedKeys)
+	reply.Size = json.Uint64(report.Size)
+	reply.Checksum = report.Checksum
+	return nil
+}
+
+// SubnetArgs are the arguments for calls that act on a subnet
+type SubnetArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// TrackSubnet starts running the chains of the provided subnet. The chains are
+// created and begin bootstrapping without restarting the node.
+func (service *Admin) TrackSubnet(_ *http.Request, args *SubnetArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: TrackSubnet called with SubnetID: %s", args.SubnetID)
+
+	return service.ChainManager.TrackSubnet(args.SubnetID)
+}
+
+// UntrackSubnet shuts down the chains of the provided subnet. A subnet that
+// was untracked can't be tracked again until the node is restarted.
+func (service *Admin) UntrackSubnet(_ *http.Request, args *SubnetArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: UntrackSubnet called with SubnetID: %s", args.SubnetID)
+
+	return service.ChainManager.UntrackSubnet(args.SubnetID)
+}
+
+// UpdateChainConfigArgs are the arguments for calling UpdateChainConfig
+type UpdateChainConfigArgs struct {
+	// Alias or ID of the chain
+	Chain string `json:"chain"`
+	// New config of the chain, in the format that the chain's VM expects
+	Config string `json:"config"`
+}
+
+// UpdateChainConfig replaces the config of a chain. If the chain is running,
+// the new config is delivered to its VM without restarting the chain. The new
+// config isn't persisted when the node restarts.
+func (service *Admin) UpdateChainConfig(_ *http.Request, args *UpdateChainConfigArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: UpdateChainConfig called with Chain: %s", args.Chain)
+
+	return service.ChainManager.UpdateChainConfig(args.Chain, []byte(args.Config))
+}
+
 // LoadVMsReply contains the response metadata for LoadVMs
 type LoadVMsReply struct {
 	// VMs and their aliases which were successfully loaded
@@ -11,6 +11,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms"
@@ -120,3 +124,138 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 
 	assert.Equal(t, err, errOops)
 }
+
+func TestRemoveAliasNotPersisted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := logging.NewMockLogger(ctrl)
+	mockLog.EXPECT().Debug(gomock.Any(), gomock.Any()).Times(2)
+
+	admin := &Admin{Config: Config{
+		Log:        mockLog,
+		AliasStore: NewAliasStore(memdb.New()),
+	}}
+
+	err := admin.RemoveAlias(nil, &RemoveAliasArgs{Alias: "x"}, &api.EmptyReply{})
+	assert.Equal(t, errUnknownRouteAlias, err)
+
+	err = admin.RemoveChainAlias(nil, &RemoveAliasArgs{Alias: "x"}, &api.EmptyReply{})
+	assert.Equal(t, errUnknownChainAlias, err)
+}
+
+func TestRemoveAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := logging.NewMockLogger(ctrl)
+	mockLog.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLog.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	mockServer := server.NewMockPathAdderWithReadLock(ctrl)
+
+	admin := &Admin{Config: Config{
+		Log:        mockLog,
+		HTTPServer: mockServer,
+		AliasStore: NewAliasStore(memdb.New()),
+	}}
+
+	mockServer.EXPECT().AddAliasesWithReadLock("bc/X", "x").Return(nil)
+	err := admin.Alias(nil, &AliasArgs{Endpoint: "bc/X", Alias: "x"}, &api.EmptyReply{})
+	assert.NoError(t, err)
+
+	reply := ListAliasesReply{}
+	assert.NoError(t, admin.ListAliases(nil, nil, &reply))
+	assert.Equal(t, map[string][]string{"bc/X": {"x"}}, reply.RouteAliases)
+
+	mockServer.EXPECT().RemoveAliasesWithReadLock("x").Return(nil)
+	err = admin.RemoveAlias(nil, &RemoveAliasArgs{Alias: "x"}, &api.EmptyReply{})
+	assert.NoError(t, err)
+
+	reply = ListAliasesReply{}
+	assert.NoError(t, admin.ListAliases(nil, nil, &reply))
+	assert.Empty(t, reply.RouteAliases)
+}
+
+type testChainManager struct {
+	chains.MockManager
+	aliaser ids.Aliaser
+}
+
+func (m *testChainManager) Lookup(alias string) (ids.ID, error) {
+	return m.aliaser.Lookup(alias)
+}
+
+func (m *testChainManager) PrimaryAliasOrDefault(id ids.ID) string {
+	return m.aliaser.PrimaryAliasOrDefault(id)
+}
+
+func TestSetLoggerLevelChainAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := logging.NewMockLogger(ctrl)
+	mockLog.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLog.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	chainManager := &testChainManager{aliaser: ids.NewAliaser()}
+
+	logFactory := logging.NewFactory(logging.Config{
+		RotatingWriterConfig: logging.RotatingWriterConfig{
+			Directory: t.TempDir(),
+		},
+		LogLevel:     logging.Info,
+		DisplayLevel: logging.Info,
+	})
+	defer logFactory.Close()
+
+	_, err := logFactory.Make("network")
+	assert.NoError(t, err)
+	_, err = logFactory.MakeChain("X")
+	assert.NoError(t, err)
+
+	admin := &Admin{Config: Config{
+		Log:          mockLog,
+		LogFactory:   logFactory,
+		ChainManager: chainManager,
+	}}
+
+	// The chain's logger can be referred to by its ID
+	chainID := ids.GenerateTestID()
+	assert.NoError(t, chainManager.aliaser.Alias(chainID, "X"))
+	assert.NoError(t, chainManager.aliaser.Alias(chainID, chainID.String()))
+
+	debug := logging.Debug
+	err = admin.SetLoggerLevel(nil, &SetLoggerLevelArgs{
+		LoggerName: chainID.String(),
+		LogLevel:   &debug,
+	}, &api.EmptyReply{})
+	assert.NoError(t, err)
+
+	reply := GetLoggerLevelReply{}
+	assert.NoError(t, admin.GetLoggerLevel(nil, &GetLoggerLevelArgs{}, &reply))
+	assert.Equal(t, map[string]LogAndDisplayLevels{
+		"X": {
+			LogLevel:     logging.Debug,
+			DisplayLevel: logging.Info,
+		},
+		"network": {
+			LogLevel:     logging.Info,
+			DisplayLevel: logging.Info,
+		},
+	}, reply.LoggerLevels)
+
+	// Loggers are looked up by name before chain aliases are considered
+	err = admin.SetLoggerLevel(nil, &SetLoggerLevelArgs{
+		LoggerName:   "network",
+		DisplayLevel: &debug,
+	}, &api.EmptyReply{})
+	assert.NoError(t, err)
+
+	reply = GetLoggerLevelReply{}
+	assert.NoError(t, admin.GetLoggerLevel(nil, &GetLoggerLevelArgs{LoggerName: "network"}, &reply))
+	assert.Equal(t, map[string]LogAndDisplayLevels{
+		"network": {
+			LogLevel:     logging.Info,
+			DisplayLevel: logging.Debug,
+		},
+	}, reply.LoggerLevels)
+}
@@ -4,10 +4,13 @@
 package auth
 
 import (
+	"bytes"
 	"crypto/rand"
 	"encoding/base64"
+	stdjson "encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"path"
 	"strings"
@@ -16,12 +19,16 @@ import (
 
 	"github.com/golang-jwt/jwt"
 
-	"github.com/gorilla/rpc/v2"
+	"golang.org/x/crypto/argon2"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/password"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 const (
@@ -35,6 +42,17 @@ const (
 	defaultTokenLifespan = time.Hour * 12
 
 	maxEndpoints = 128
+	maxScopes    = 128
+
+	// signingKeyLen is the number of bytes in the key tokens are signed with
+	signingKeyLen = 32
+	// signingKeySaltLen is the number of bytes of salt used to derive the
+	// signing key from the password
+	signingKeySaltLen = 16
+
+	// maxInspectedBodySize is the number of bytes of a request body that are
+	// read to find the JSON-RPC method being called
+	maxInspectedBodySize = 64 * units.KiB
 )
 
 var (
@@ -47,30 +65,54 @@ var (
 	errInvalidSigningMethod        = errors.New("auth token didn't specify the HS256 signing method correctly")
 	errTokenRevoked                = errors.New("the provided auth token was revoked")
 	errTokenInsufficientPermission = errors.New("the provided auth token does not allow access to this endpoint")
+	errTokenInsufficientScope      = errors.New("the provided auth token does not allow calling this method")
 	errWrongPassword               = errors.New("incorrect password")
 	errSamePassword                = errors.New("new password can't be same as old password")
 	errNoPassword                  = errors.New("no password")
 	errNoEndpoints                 = errors.New("must name at least one endpoint")
 	errTooManyEndpoints            = fmt.Errorf("can only name at most %d endpoints", maxEndpoints)
+	errTooManyScopes               = fmt.Errorf("can only name at most %d scopes", maxScopes)
+
+	passwordKey       = []byte("password")
+	signingKeySaltKey = []byte("signingKeySalt")
+	revokedPrefix     = []byte("revoked")
 
 	_ Auth = &auth{}
 )
 
+// RevokedToken is a token that was revoked before it expired
+type RevokedToken struct {
+	// ID of the revoked token
+	ID string
+	// Unix time, in seconds, that the revoked token expires at
+	ExpiresAt int64
+}
+
 type Auth interface {
 	// Create and return a new token that allows access to each API endpoint for
 	// [duration] such that the API's path ends with an element of [endpoints].
 	// If one of the elements of [endpoints] is "*", all APIs are accessible.
-	NewToken(pw string, duration time.Duration, endpoints []string) (string, error)
+	// If [scopes] is non-empty, the token may only be used to call the API
+	// methods named by [scopes]. Each scope is either an API namespace, such as
+	// "info", or a single method, such as "info.getNodeID".
+	NewToken(pw string, duration time.Duration, endpoints, scopes []string) (string, error)
 
 	// Revokes [token]; it will not be accepted as authorization for future API
-	// calls. If the token is invalid, this is a no-op.  If a token is revoked
-	// and then the password is changed, and then changed back to the current
-	// password, the token will be un-revoked. Therefore, passwords shouldn't be
-	// re-used before previously revoked tokens have expired.
+	// calls. If the token is invalid, this is a no-op. The revocation is
+	// persisted until the token expires.
 	RevokeToken(pw, token string) error
 
-	// Authenticates [token] for access to [url].
-	AuthenticateToken(token, url string) error
+	// Returns the tokens that were revoked and haven't expired yet.
+	RevokedTokens(pw string) ([]RevokedToken, error)
+
+	// Authenticates [token] for calling [method] at [url]. [method] is empty if
+	// the request isn't a JSON-RPC call, in which case only tokens without
+	// scopes are accepted.
+	AuthenticateToken(token, url, method string) error
+
+	// Returns the ID of [token] if it's validly signed, unexpired and not
+	// revoked. The token isn't checked against any endpoint.
+	TokenID(token string) (string, error)
 
 	// Change the password required to create and revoke tokens.
 	// [oldPW] is the current password.
@@ -98,29 +140,53 @@ type auth struct {
 	lock sync.RWMutex
 	// Can be changed via API call.
 	password password.Hash
-	// Set of token IDs that have been revoked
-	revoked map[string]struct{}
+	// Key that tokens are signed with. It's derived from the password and a
+	// random salt, so it can't be recovered from [db] without the password.
+	signingKey []byte
+	// Token ID --> Unix time the revoked token expires at
+	revoked map[string]int64
+
+	// Persists the hash of [password] and the salt of [signingKey] so that
+	// tokens remain valid across restarts
+	db database.Database
+	// Persists [revoked]
+	revokedDB database.Database
 }
 
-func New(log logging.Logger, endpoint, pw string) (Auth, error) {
-	a := &auth{
-		log:      log,
-		endpoint: endpoint,
-		revoked:  make(map[string]struct{}),
+// New returns a new Auth that persists its password hash and revoked tokens in
+// [db]. If [db] holds the hash of [pw], tokens issued before the node restarted
+// remain valid.
+func New(log logging.Logger, endpoint, pw string, db database.Database) (Auth, error) {
+	a := newAuth(log, endpoint, db)
+	if err := a.loadPassword(pw); err != nil {
+		return nil, err
 	}
-	return a, a.password.Set(pw)
+	return a, a.loadRevoked()
 }
 
-func NewFromHash(log logging.Logger, endpoint string, pw password.Hash) Auth {
+// NewFromHash returns a new Auth that doesn't persist anything. Tokens are
+// signed with a random key.
+func NewFromHash(log logging.Logger, endpoint string, pw password.Hash) (Auth, error) {
+	a := newAuth(log, endpoint, memdb.New())
+	a.password = pw
+	a.signingKey = make([]byte, signingKeyLen)
+	if _, err := rand.Read(a.signingKey); err != nil {
+		return nil, fmt.Errorf("failed to generate the token signing key due to %w", err)
+	}
+	return a, nil
+}
+
+func newAuth(log logging.Logger, endpoint string, db database.Database) *auth {
 	return &auth{
-		log:      log,
-		endpoint: endpoint,
-		password: pw,
-		revoked:  make(map[string]struct{}),
+		log:       log,
+		endpoint:  endpoint,
+		revoked:   make(map[string]int64),
+		db:        db,
+		revokedDB: prefixdb.New(revokedPrefix, db),
 	}
 }
 
-func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (string, error) {
+func (a *auth) NewToken(pw string, duration time.Duration, endpoints, scopes []string) (string, error) {
 	if pw == "" {
 		return "", errNoPassword
 	}
@@ -129,6 +195,9 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 	} else if l > maxEndpoints {
 		return "", errTooManyEndpoints
 	}
+	if len(scopes) > maxScopes {
+		return "", errTooManyScopes
+	}
 
 	a.lock.RLock()
 	defer a.lock.RUnlock()
@@ -156,6 +225,7 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 			ExpiresAt: a.clock.Time().Add(duration).Unix(),
 			Id:        id,
 		},
+		Scopes: scopes,
 	}
 	if canAccessAll {
 		claims.Endpoints = []string{"*"}
@@ -163,7 +233,7 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 		claims.Endpoints = endpoints
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
-	return token.SignedString(a.password.Password[:]) // Sign the token and return its string repr.
+	return token.SignedString(a.signingKey) // Sign the token and return its string repr.
 }
 
 func (a *auth) RevokeToken(tokenStr, pw string) error {
@@ -196,30 +266,53 @@ func (a *auth) RevokeToken(tokenStr, pw string) error {
 	if !ok {
 		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
 	}
-	a.revoked[claims.Id] = struct{}{}
+	if err := a.pruneRevoked(); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(a.revokedDB, []byte(claims.Id), uint64(claims.ExpiresAt)); err != nil {
+		return err
+	}
+	a.revoked[claims.Id] = claims.ExpiresAt
 	return nil
 }
 
-func (a *auth) AuthenticateToken(tokenStr, url string) error {
+func (a *auth) RevokedTokens(pw string) ([]RevokedToken, error) {
+	if pw == "" {
+		return nil, errNoPassword
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return nil, errWrongPassword
+	}
+	if err := a.pruneRevoked(); err != nil {
+		return nil, err
+	}
+
+	tokens := make([]RevokedToken, 0, len(a.revoked))
+	for id, expiresAt := range a.revoked {
+		tokens = append(tokens, RevokedToken{
+			ID:        id,
+			ExpiresAt: expiresAt,
+		})
+	}
+	return tokens, nil
+}
+
+func (a *auth) AuthenticateToken(tokenStr, url, method string) error {
 	a.lock.RLock()
 	defer a.lock.RUnlock()
 
-	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
-	if err != nil { // Probably because signature wrong
+	claims, err := a.parseToken(tokenStr)
+	if err != nil {
 		return err
 	}
 
 	// Make sure this token gives access to the requested endpoint
-	claims, ok := token.Claims.(*endpointClaims)
-	if !ok {
-		// Error is intentionally dropped here as there is nothing left to do
-		// with it.
-		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
-	}
-
-	_, revoked := a.revoked[claims.Id]
-	if revoked {
-		return errTokenRevoked
+	if !claims.allowsMethod(method) {
+		return errTokenInsufficientScope
 	}
 
 	for _, endpoint := range claims.Endpoints {
@@ -230,6 +323,39 @@ func (a *auth) AuthenticateToken(tokenStr, url string) error {
 	return errTokenInsufficientPermission
 }
 
+func (a *auth) TokenID(tokenStr string) (string, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	claims, err := a.parseToken(tokenStr)
+	if err != nil {
+		return "", err
+	}
+	return claims.Id, nil
+}
+
+// parseToken returns the claims of [tokenStr] if it's validly signed,
+// unexpired and not revoked.
+// Assumes [a.lock] is held.
+func (a *auth) parseToken(tokenStr string) (*endpointClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
+	if err != nil { // Probably because signature wrong
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*endpointClaims)
+	if !ok {
+		// Error is intentionally dropped here as there is nothing left to do
+		// with it.
+		return nil, fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+	}
+
+	if _, revoked := a.revoked[claims.Id]; revoked {
+		return nil, errTokenRevoked
+	}
+	return claims, nil
+}
+
 func (a *auth) ChangePassword(oldPW, newPW string) error {
 	if oldPW == newPW {
 		return errSamePassword
@@ -244,18 +370,13 @@ func (a *auth) ChangePassword(oldPW, newPW string) error {
 	if err := password.IsValid(newPW, password.OK); err != nil {
 		return err
 	}
-	if err := a.password.Set(newPW); err != nil {
-		return err
-	}
-
-	// All the revoked tokens are now invalid; no need to mark specifically as
-	// revoked.
-	a.revoked = make(map[string]struct{})
-	return nil
+	// Changing the signing key invalidates every previously issued token.
+	// Revoked tokens are still kept until they expire.
+	return a.setPassword(newPW)
 }
 
 func (a *auth) CreateHandler() (http.Handler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	codec := json.NewCodec()
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -288,7 +409,13 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 		// Returns actual auth token. Slice guaranteed to not go OOB
 		tokenStr := rawHeader[len(headerValStart):]
 
-		if err := a.AuthenticateToken(tokenStr, r.URL.Path); err != nil {
+		method, err := readMethod(r)
+		if err != nil {
+			writeUnauthorizedResponse(w, err)
+			return
+		}
+
+		if err := a.AuthenticateToken(tokenStr, r.URL.Path, method); err != nil {
 			writeUnauthorizedResponse(w, err)
 			return
 		}
@@ -302,5 +429,134 @@ func (a *auth) getTokenKey(t *jwt.Token) (interface{}, error) {
 	if t.Method != jwt.SigningMethodHS256 {
 		return nil, errInvalidSigningMethod
 	}
-	return a.password.Password[:], nil
+	return a.signingKey, nil
+}
+
+// loadPassword sets the password to [pw]. If the persisted hash is of [pw], the
+// persisted signing key salt is re-used so that previously issued tokens remain
+// valid.
+func (a *auth) loadPassword(pw string) error {
+	hashBytes, err := a.db.Get(passwordKey)
+	switch {
+	case err == database.ErrNotFound:
+	case err != nil:
+		return err
+	case len(hashBytes) == len(a.password.Password)+len(a.password.Salt):
+		copy(a.password.Password[:], hashBytes)
+		copy(a.password.Salt[:], hashBytes[len(a.password.Password):])
+		if !a.password.Check(pw) {
+			break
+		}
+
+		salt, err := a.db.Get(signingKeySaltKey)
+		switch {
+		case err == database.ErrNotFound:
+		case err != nil:
+			return err
+		case len(salt) == signingKeySaltLen:
+			a.signingKey = deriveSigningKey(pw, salt)
+			return nil
+		}
+	}
+	return a.setPassword(pw)
+}
+
+// setPassword sets the password to [pw] and derives a new signing key from it.
+// The password hash and the salt of the signing key are persisted.
+// Assumes [a.lock] is held or that [a] isn't being used concurrently.
+func (a *auth) setPassword(pw string) error {
+	if err := a.password.Set(pw); err != nil {
+		return err
+	}
+	salt := make([]byte, signingKeySaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	a.signingKey = deriveSigningKey(pw, salt)
+
+	hashBytes := make([]byte, 0, len(a.password.Password)+len(a.password.Salt))
+	hashBytes = append(hashBytes, a.password.Password[:]...)
+	hashBytes = append(hashBytes, a.password.Salt[:]...)
+	if err := a.db.Put(passwordKey, hashBytes); err != nil {
+		return err
+	}
+	return a.db.Put(signingKeySaltKey, salt)
+}
+
+// deriveSigningKey returns the key that tokens are signed with when the
+// password is [pw]. The salt is distinct from the one used to hash the
+// password, so the persisted password hash can't be used to forge tokens.
+func deriveSigningKey(pw string, salt []byte) []byte {
+	return argon2.IDKey([]byte(pw), salt, 1, 64*1024, 4, signingKeyLen)
+}
+
+// loadRevoked reads the revoked tokens from [a.revokedDB], dropping any that
+// have expired.
+func (a *auth) loadRevoked() error {
+	it := a.revokedDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		expiresAt, err := database.ParseUInt64(it.Value())
+		if err != nil {
+			return err
+		}
+		a.revoked[string(it.Key())] = int64(expiresAt)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return a.pruneRevoked()
+}
+
+// pruneRevoked removes the revoked tokens that have expired, as they will no
+// longer be accepted anyway.
+// Assumes [a.lock] is held or that [a] isn't being used concurrently.
+func (a *auth) pruneRevoked() error {
+	now := a.clock.Unix()
+	for id, expiresAt := range a.revoked {
+		if expiresAt > int64(now) {
+			continue
+		}
+		if err := a.revokedDB.Delete([]byte(id)); err != nil {
+			return err
+		}
+		delete(a.revoked, id)
+	}
+	return nil
+}
+
+// readMethod returns the JSON-RPC method named by the body of [r]. If the body
+// isn't a JSON-RPC request, or is too large to be inspected, the empty string
+// is returned. The body of [r] is left unconsumed.
+func readMethod(r *http.Request) (string, error) {
+	if r.Body == nil {
+		return "", nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxInspectedBodySize+1))
+	if err != nil {
+		return "", err
+	}
+	r.Body = &restoredBody{
+		Reader: io.MultiReader(bytes.NewReader(body), r.Body),
+		Closer: r.Body,
+	}
+	if len(body) > maxInspectedBodySize {
+		return "", nil
+	}
+
+	var request struct {
+		Method string `json:"method"`
+	}
+	if err := stdjson.Unmarshal(body, &request); err != nil {
+		return "", nil
+	}
+	return request.Method, nil
+}
+
+// restoredBody is a request body that has been partially read and then
+// prepended with the bytes that were read
+type restoredBody struct {
+	io.Reader
+	io.Closer
 }
@@ -18,6 +18,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/password"
 )
@@ -37,32 +39,38 @@ func init() {
 // Always returns 200 (http.StatusOK)
 var dummyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
 
+func newTestAuth(t *testing.T) *auth {
+	a, err := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	assert.NoError(t, err)
+	return a.(*auth)
+}
+
 func TestNewTokenWrongPassword(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
-	_, err := auth.NewToken("", defaultTokenLifespan, []string{"endpoint1, endpoint2"})
+	_, err := auth.NewToken("", defaultTokenLifespan, []string{"endpoint1, endpoint2"}, nil)
 	assert.Error(t, err, "should have failed because password is wrong")
 
-	_, err = auth.NewToken("notThePassword", defaultTokenLifespan, []string{"endpoint1, endpoint2"})
+	_, err = auth.NewToken("notThePassword", defaultTokenLifespan, []string{"endpoint1, endpoint2"}, nil)
 	assert.Error(t, err, "should have failed because password is wrong")
 }
 
 func TestNewTokenHappyPath(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	now := time.Now()
 	auth.clock.Set(now)
 
 	// Make a token
 	endpoints := []string{"endpoint1", "endpoint2", "endpoint3"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	// Parse the token
 	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, func(*jwt.Token) (interface{}, error) {
 		auth.lock.RLock()
 		defer auth.lock.RUnlock()
-		return auth.password.Password[:], nil
+		return auth.signingKey, nil
 	})
 	assert.NoError(t, err, "couldn't parse new token")
 
@@ -75,11 +83,11 @@ func TestNewTokenHappyPath(t *testing.T) {
 }
 
 func TestTokenHasWrongSig(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"endpoint1", "endpoint2", "endpoint3"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	// Try to parse the token using the wrong password
@@ -100,7 +108,7 @@ func TestTokenHasWrongSig(t *testing.T) {
 }
 
 func TestChangePassword(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	password2 := "fejhkefjhefjhefhje" // #nosec G101
 	var err error
@@ -128,11 +136,11 @@ func TestChangePassword(t *testing.T) {
 }
 
 func TestRevokeToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	err = auth.RevokeToken(tokenStr, testPassword)
@@ -140,12 +148,32 @@ func TestRevokeToken(t *testing.T) {
 	assert.Len(t, auth.revoked, 1, "revoked token list is incorrect")
 }
 
+func TestTokenID(t *testing.T) {
+	assert := assert.New(t)
+
+	auth := newTestAuth(t)
+
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"/ext/info"}, nil)
+	assert.NoError(err)
+
+	id, err := auth.TokenID(tokenStr)
+	assert.NoError(err)
+	assert.NotEmpty(id)
+
+	_, err = auth.TokenID("not a token")
+	assert.Error(err)
+
+	assert.NoError(auth.RevokeToken(tokenStr, testPassword))
+	_, err = auth.TokenID(tokenStr)
+	assert.ErrorIs(err, errTokenRevoked)
+}
+
 func TestWrapHandlerHappyPath(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -160,11 +188,11 @@ func TestWrapHandlerHappyPath(t *testing.T) {
 }
 
 func TestWrapHandlerRevokedToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	err = auth.RevokeToken(tokenStr, testPassword)
@@ -184,13 +212,13 @@ func TestWrapHandlerRevokedToken(t *testing.T) {
 }
 
 func TestWrapHandlerExpiredToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	auth.clock.Set(time.Now().Add(-2 * defaultTokenLifespan))
 
 	// Make a token that expired well in the past
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -207,7 +235,7 @@ func TestWrapHandlerExpiredToken(t *testing.T) {
 }
 
 func TestWrapHandlerNoAuthToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -222,11 +250,11 @@ func TestWrapHandlerNoAuthToken(t *testing.T) {
 }
 
 func TestWrapHandlerUnauthorizedEndpoint(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	unauthorizedEndpoints := []string{"/ext/bc/X", "/ext/metrics", "", "/foo", "/ext/info/foo"}
@@ -244,11 +272,11 @@ func TestWrapHandlerUnauthorizedEndpoint(t *testing.T) {
 }
 
 func TestWrapHandlerAuthEndpoint(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics", "", "/foo", "/ext/info/foo"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -260,11 +288,11 @@ func TestWrapHandlerAuthEndpoint(t *testing.T) {
 }
 
 func TestWrapHandlerAccessAll(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token that allows access to all endpoints
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics", "", "/foo", "/ext/foo/info"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"*"})
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, []string{"*"}, nil)
 	assert.NoError(t, err)
 
 	wrappedHandler := auth.WrapHandler(dummyHandler)
@@ -285,11 +313,11 @@ func TestWriteUnauthorizedResponse(t *testing.T) {
 }
 
 func TestWrapHandlerMutatedRevokedToken(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
-	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints)
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
 	assert.NoError(t, err)
 
 	err = auth.RevokeToken(tokenStr, testPassword)
@@ -309,7 +337,7 @@ func TestWrapHandlerMutatedRevokedToken(t *testing.T) {
 }
 
 func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
-	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword).(*auth)
+	auth := newTestAuth(t)
 
 	// Make a token
 	endpoints := []string{"/ext/info", "/ext/bc/X", "/ext/metrics"}
@@ -327,7 +355,7 @@ func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
 		Endpoints: endpoints,
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS512, &claims)
-	tokenStr, err := token.SignedString(auth.password.Password[:])
+	tokenStr, err := token.SignedString(auth.signingKey)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -344,3 +372,119 @@ func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
 		assert.Regexp(t, unAuthorizedResponseRegex, rr.Body.String())
 	}
 }
+
+func TestRevokedTokensPersisted(t *testing.T) {
+	db := memdb.New()
+	a, err := New(logging.NoLog{}, "auth", testPassword, db)
+	assert.NoError(t, err)
+
+	endpoints := []string{"/ext/info"}
+	tokenStr, err := a.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
+	assert.NoError(t, err)
+
+	err = a.RevokeToken(tokenStr, testPassword)
+	assert.NoError(t, err)
+
+	validTokenStr, err := a.NewToken(testPassword, defaultTokenLifespan, endpoints, nil)
+	assert.NoError(t, err)
+
+	// The revocation should survive a restart
+	a, err = New(logging.NoLog{}, "auth", testPassword, db)
+	assert.NoError(t, err)
+
+	err = a.AuthenticateToken(validTokenStr, "/ext/info", "")
+	assert.NoError(t, err)
+
+	err = a.AuthenticateToken(tokenStr, "/ext/info", "")
+	assert.ErrorIs(t, err, errTokenRevoked)
+
+	_, err = a.RevokedTokens("notThePassword")
+	assert.ErrorIs(t, err, errWrongPassword)
+
+	revoked, err := a.RevokedTokens(testPassword)
+	assert.NoError(t, err)
+	assert.Len(t, revoked, 1)
+
+	// Once the token expires, it is dropped from the revocation list
+	a.(*auth).clock.Set(time.Now().Add(2 * defaultTokenLifespan))
+	revoked, err = a.RevokedTokens(testPassword)
+	assert.NoError(t, err)
+	assert.Empty(t, revoked)
+
+	isEmpty, err := database.IsEmpty(a.(*auth).revokedDB)
+	assert.NoError(t, err)
+	assert.True(t, isEmpty)
+
+	// Changing the password on restart invalidates previously issued tokens
+	a, err = New(logging.NoLog{}, "auth", "fejhkefjhefjhefhje", db)
+	assert.NoError(t, err)
+
+	err = a.AuthenticateToken(tokenStr, "/ext/info", "")
+	assert.Error(t, err)
+}
+
+func TestSigningKeyNotPersisted(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	a, err := New(logging.NoLog{}, "auth", testPassword, db)
+	assert.NoError(err)
+
+	tokenStr, err := a.NewToken(testPassword, defaultTokenLifespan, []string{"/ext/info"}, nil)
+	assert.NoError(err)
+
+	// Nothing that is persisted can be used to sign tokens
+	it := db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		_, err = jwt.ParseWithClaims(tokenStr, &endpointClaims{}, func(*jwt.Token) (interface{}, error) {
+			return it.Value(), nil
+		})
+		assert.Error(err)
+	}
+	assert.NoError(it.Error())
+
+	// The signing key is re-derived after a restart
+	a, err = New(logging.NoLog{}, "auth", testPassword, db)
+	assert.NoError(err)
+	assert.NoError(a.AuthenticateToken(tokenStr, "/ext/info", ""))
+
+	// Changing the password invalidates previously issued tokens
+	assert.NoError(a.ChangePassword(testPassword, "fejhkefjhefjhefhje"))
+	assert.Error(a.AuthenticateToken(tokenStr, "/ext/info", ""))
+}
+
+func TestWrapHandlerScopes(t *testing.T) {
+	auth := newTestAuth(t)
+
+	// Make a token that may only call read-only methods
+	endpoints := []string{"/ext/info", "/ext/health"}
+	scopes := []string{"health", "info.getNodeID"}
+	tokenStr, err := auth.NewToken(testPassword, defaultTokenLifespan, endpoints, scopes)
+	assert.NoError(t, err)
+
+	wrappedHandler := auth.WrapHandler(dummyHandler)
+
+	tests := []struct {
+		endpoint string
+		body     string
+		code     int
+	}{
+		{"/ext/info", `{"jsonrpc":"2.0","id":1,"method":"info.getNodeID"}`, http.StatusOK},
+		{"/ext/info", `{"jsonrpc":"2.0","id":1,"method":"info.peers"}`, http.StatusUnauthorized},
+		{"/ext/health", `{"jsonrpc":"2.0","id":1,"method":"health.health"}`, http.StatusOK},
+		// Scoped tokens can't be used for requests that aren't JSON-RPC calls
+		{"/ext/health", "", http.StatusUnauthorized},
+		{"/ext/health", `{"jsonrpc":"2.0","id":1,"method":"health.health","params":"` + strings.Repeat("a", maxInspectedBodySize) + `"}`, http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://127.0.0.1:9650%s", test.endpoint), strings.NewReader(test.body))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+		rr := httptest.NewRecorder()
+		wrappedHandler.ServeHTTP(rr, req)
+		assert.Equal(t, test.code, rr.Code, test.body)
+		if test.code == http.StatusUnauthorized {
+			assert.Contains(t, rr.Body.String(), errTokenInsufficientScope.Error())
+		}
+	}
+}
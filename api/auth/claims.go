@@ -4,6 +4,8 @@
 package auth
 
 import (
+	"strings"
+
 	"github.com/golang-jwt/jwt"
 )
 
@@ -15,4 +17,33 @@ type endpointClaims struct {
 	// If endpoints has an element "*", allows access to all API endpoints
 	// In this case, "*" should be the only element of [endpoints]
 	Endpoints []string `json:"endpoints,omitempty"`
+
+	// Each element is either an API namespace, such as "info", or a single API
+	// method, such as "info.getNodeID", that the token allows to be called.
+	// If scopes is empty, any method may be called and the token may be used
+	// for requests that aren't JSON-RPC calls.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// allowsMethod returns true if the token's scopes allow [method] to be called.
+// [method] is empty if the request isn't a JSON-RPC call, which is only allowed
+// if the token has no scopes.
+func (c *endpointClaims) allowsMethod(method string) bool {
+	if len(c.Scopes) == 0 {
+		return true
+	}
+	if method == "" {
+		return false
+	}
+
+	namespace := method
+	if i := strings.Index(method, "."); i >= 0 {
+		namespace = method[:i]
+	}
+	for _, scope := range c.Scopes {
+		if scope == method || scope == namespace {
+			return true
+		}
+	}
+	return false
 }
@@ -5,8 +5,10 @@ package auth
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/utils/json"
 )
 
 // Service that serves the Auth API functionality.
@@ -26,6 +28,15 @@ type NewTokenArgs struct {
 	// allows access to all API endpoints. [Endpoints] must have between 1 and
 	// [maxEndpoints] elements
 	Endpoints []string `json:"endpoints"`
+	// API namespaces or methods that may be called with this token e.g. if
+	// scopes is ["health", "info.getNodeID"] then the token holder can call any
+	// method of the health API and the getNodeID method of the info API. If
+	// [Scopes] is empty, any method may be called. [Scopes] must have at most
+	// [maxScopes] elements
+	Scopes []string `json:"scopes"`
+	// Number of seconds until the token expires. If 0, the token expires after
+	// [defaultTokenLifespan].
+	Lifespan json.Uint64 `json:"lifespan"`
 }
 
 type Token struct {
@@ -35,8 +46,13 @@ type Token struct {
 func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *Token) error {
 	s.auth.log.Debug("Auth: NewToken called")
 
+	lifespan := defaultTokenLifespan
+	if args.Lifespan != 0 {
+		lifespan = time.Duration(args.Lifespan) * time.Second
+	}
+
 	var err error
-	reply.Token, err = s.auth.NewToken(args.Password.Password, defaultTokenLifespan, args.Endpoints)
+	reply.Token, err = s.auth.NewToken(args.Password.Password, lifespan, args.Endpoints, args.Scopes)
 	return err
 }
 
@@ -51,6 +67,33 @@ func (s *Service) RevokeToken(_ *http.Request, args *RevokeTokenArgs, _ *api.Emp
 	return s.auth.RevokeToken(args.Token.Token, args.Password.Password)
 }
 
+type RevokedTokenReply struct {
+	ID        string      `json:"id"`        // The ID of the revoked token
+	ExpiresAt json.Uint64 `json:"expiresAt"` // Unix time the token expires at
+}
+
+type ListRevokedTokensReply struct {
+	Tokens []RevokedTokenReply `json:"tokens"`
+}
+
+func (s *Service) ListRevokedTokens(_ *http.Request, args *Password, reply *ListRevokedTokensReply) error {
+	s.auth.log.Debug("Auth: ListRevokedTokens called")
+
+	tokens, err := s.auth.RevokedTokens(args.Password)
+	if err != nil {
+		return err
+	}
+
+	reply.Tokens = make([]RevokedTokenReply, len(tokens))
+	for i, token := range tokens {
+		reply.Tokens[i] = RevokedTokenReply{
+			ID:        token.ID,
+			ExpiresAt: json.Uint64(token.ExpiresAt),
+		}
+	}
+	return nil
+}
+
 type ChangePasswordArgs struct {
 	OldPassword string `json:"oldPassword"` // Current authorization password
 	NewPassword string `json:"newPassword"` // New authorization password
@@ -145,3 +145,44 @@ type GetUTXOsReply struct {
 	// Encoding specifies the encoding format the UTXOs are returned in
 	Encoding formatting.Encoding `json:"encoding"`
 }
+
+const (
+	// UTXOUnspent is the status of a UTXO that can currently be spent
+	UTXOUnspent = "unspent"
+	// UTXOSpent is the status of a UTXO that was produced by an accepted
+	// transaction and has since been consumed
+	UTXOSpent = "spent"
+	// UTXOUnknown is the status of a UTXO that was never produced by an
+	// accepted transaction
+	UTXOUnknown = "unknown"
+)
+
+// GetUTXOsByIDArgs are arguments for passing into GetUTXOsByID.
+// Gets the UTXOs with the IDs in [UTXOIDs], regardless of whether they are
+// still spendable. Each UTXO ID is formatted as "txID:outputIndex".
+type GetUTXOsByIDArgs struct {
+	UTXOIDs  []string            `json:"utxoIDs"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// UTXOByID is a UTXO returned by GetUTXOsByID. [UTXO] is only populated if
+// [Status] is [UTXOUnspent].
+type UTXOByID struct {
+	UTXOID string `json:"utxoID"`
+	UTXO   string `json:"utxo"`
+	Status string `json:"status"`
+}
+
+// GetUTXOsByIDReply defines the GetUTXOsByID replies returned from the API.
+// [UTXOs] is in the same order as the requested UTXO IDs.
+type GetUTXOsByIDReply struct {
+	UTXOs    []UTXOByID          `json:"utxos"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// EstimateFeeReply defines the EstimateFee replies returned from the API
+type EstimateFeeReply struct {
+	// Amount of [AssetID] that must be burned by the tx
+	Fee     json.Uint64 `json:"fee"`
+	AssetID ids.ID      `json:"assetID"`
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+	"path"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/pubsub"
+	"github.com/ava-labs/avalanchego/utils/constants"
+
+	avmpb "github.com/ava-labs/avalanchego/proto/pb/avm"
+)
+
+const defaultAVMChain = "X"
+
+var _ avmpb.AVMServer = &avmServer{}
+
+type avmServer struct {
+	avmpb.UnsafeAVMServer
+	bridge
+}
+
+// NewAVMServer returns the gRPC mirror of the AVM API served by [handler].
+func NewAVMServer(handler http.Handler) avmpb.AVMServer {
+	return &avmServer{bridge: bridge{handler: handler}}
+}
+
+// avmEndpoint returns the API endpoint of [chain], defaulting to the X-chain.
+func avmEndpoint(chain string) string {
+	if chain == "" {
+		chain = defaultAVMChain
+	}
+	return path.Join(constants.ChainAliasPrefix, chain)
+}
+
+func (s *avmServer) GetTx(ctx context.Context, req *avmpb.GetTxRequest) (*avmpb.GetTxResponse, error) {
+	resp := &avmpb.GetTxResponse{}
+	return resp, s.call(ctx, avmEndpoint(req.Chain), "avm.getTx", req, resp)
+}
+
+func (s *avmServer) GetTxStatus(ctx context.Context, req *avmpb.GetTxStatusRequest) (*avmpb.GetTxStatusResponse, error) {
+	resp := &avmpb.GetTxStatusResponse{}
+	return resp, s.call(ctx, avmEndpoint(req.Chain), "avm.getTxStatus", req, resp)
+}
+
+func (s *avmServer) IssueTx(ctx context.Context, req *avmpb.IssueTxRequest) (*avmpb.IssueTxResponse, error) {
+	resp := &avmpb.IssueTxResponse{}
+	return resp, s.call(ctx, avmEndpoint(req.Chain), "avm.issueTx", req, resp)
+}
+
+func (s *avmServer) GetBalance(ctx context.Context, req *avmpb.GetBalanceRequest) (*avmpb.GetBalanceResponse, error) {
+	resp := &avmpb.GetBalanceResponse{}
+	return resp, s.call(ctx, avmEndpoint(req.Chain), "avm.getBalance", req, resp)
+}
+
+func (s *avmServer) GetAllBalances(ctx context.Context, req *avmpb.GetAllBalancesRequest) (*avmpb.GetAllBalancesResponse, error) {
+	resp := &avmpb.GetAllBalancesResponse{}
+	return resp, s.call(ctx, avmEndpoint(req.Chain), "avm.getAllBalances", req, resp)
+}
+
+func (s *avmServer) GetAssetDescription(ctx context.Context, req *avmpb.GetAssetDescriptionRequest) (*avmpb.GetAssetDescriptionResponse, error) {
+	resp := &avmpb.GetAssetDescriptionResponse{}
+	return resp, s.call(ctx, avmEndpoint(req.Chain), "avm.getAssetDescription", req, resp)
+}
+
+func (s *avmServer) SubscribeAcceptedTxs(req *avmpb.SubscribeAcceptedTxsRequest, stream avmpb.AVM_SubscribeAcceptedTxsServer) error {
+	return s.subscribe(
+		stream.Context(),
+		avmEndpoint(req.Chain),
+		pubsub.AcceptedTxs,
+		func() proto.Message { return &avmpb.AcceptedTx{} },
+		func(msg proto.Message) error { return stream.Send(msg.(*avmpb.AcceptedTx)) },
+	)
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package grpcapi exposes the node's JSON-RPC APIs as gRPC services. Each gRPC
+// call is translated into the equivalent JSON-RPC call and dispatched through
+// the node's HTTP handler, so that both transports share the same routing,
+// locking, authorization and rate limiting.
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	baseURL = "/ext"
+
+	authHeaderKey = "authorization"
+
+	// JSON-RPC error codes defined by the spec
+	invalidParamsCode  = -32602
+	methodNotFoundCode = -32601
+)
+
+var (
+	marshaler   = protojson.MarshalOptions{}
+	unmarshaler = protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	}
+)
+
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      uint64          `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonRPCResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *jsonRPCError   `json:"error"`
+}
+
+// bridge translates gRPC calls into JSON-RPC calls.
+type bridge struct {
+	handler http.Handler
+}
+
+// call issues the JSON-RPC [method] to the API served at [endpoint] with
+// [args] as its parameters and unmarshals the result into [reply].
+func (b *bridge) call(ctx context.Context, endpoint, method string, args, reply proto.Message) error {
+	params, err := marshaler.Marshal(args)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "couldn't marshal arguments: %s", err)
+	}
+	body, err := json.Marshal(jsonRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "couldn't marshal request: %s", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/%s", baseURL, endpoint),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return status.Errorf(codes.Internal, "couldn't create request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if auth := md.Get(authHeaderKey); len(auth) > 0 {
+			req.Header.Set(authHeaderKey, auth[0])
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		req.RemoteAddr = p.Addr.String()
+	}
+
+	w := newResponseWriter()
+	b.handler.ServeHTTP(w, req)
+
+	// The JSON-RPC server reports some errors with a non-200 status, so the
+	// body is inspected for an error before the status is.
+	resp := jsonRPCResponse{}
+	unmarshalErr := json.Unmarshal(w.body.Bytes(), &resp)
+	switch {
+	case unmarshalErr == nil && resp.Error != nil:
+		return status.Error(jsonRPCErrorToCode(resp.Error.Code), resp.Error.Message)
+	case w.status != http.StatusOK:
+		return status.Error(httpStatusToCode(w.status), w.body.String())
+	case unmarshalErr != nil:
+		return status.Errorf(codes.Internal, "couldn't unmarshal response: %s", unmarshalErr)
+	}
+	if err := unmarshaler.Unmarshal(resp.Result, reply); err != nil {
+		return status.Errorf(codes.Internal, "couldn't unmarshal result: %s", err)
+	}
+	return nil
+}
+
+func httpStatusToCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+func jsonRPCErrorToCode(code int) codes.Code {
+	switch code {
+	case invalidParamsCode:
+		return codes.InvalidArgument
+	case methodNotFoundCode:
+		return codes.Unimplemented
+	default:
+		return codes.Unknown
+	}
+}
+
+// responseWriter buffers the response of an HTTP handler in memory.
+type responseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseWriter() *responseWriter {
+	return &responseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (w *responseWriter) Header() http.Header { return w.header }
+
+func (w *responseWriter) Write(b []byte) (int, error) { return w.body.Write(b) }
+
+func (w *responseWriter) WriteHeader(status int) { w.status = status }
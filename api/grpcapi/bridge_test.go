@@ -0,0 +1,201 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/pubsub"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	infopb "github.com/ava-labs/avalanchego/proto/pb/info"
+	platformvmpb "github.com/ava-labs/avalanchego/proto/pb/platformvm"
+)
+
+var errTest = errors.New("non-nil error")
+
+type testInfoService struct{}
+
+type TestGetBlockchainIDArgs struct {
+	Alias string `json:"alias"`
+}
+
+type TestGetBlockchainIDReply struct {
+	BlockchainID string `json:"blockchainID"`
+}
+
+func (*testInfoService) GetBlockchainID(_ *http.Request, args *TestGetBlockchainIDArgs, reply *TestGetBlockchainIDReply) error {
+	if args.Alias != "X" {
+		return errTest
+	}
+	reply.BlockchainID = "2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM"
+	return nil
+}
+
+type TestNetworkIDReply struct {
+	NetworkID json.Uint32 `json:"networkID"`
+}
+
+func (*testInfoService) GetNetworkID(_ *http.Request, _ *struct{}, reply *TestNetworkIDReply) error {
+	reply.NetworkID = 5
+	return nil
+}
+
+func newTestHandler(t *testing.T) http.Handler {
+	server := rpc.NewServer()
+	server.RegisterCodec(json.NewCodec(), "application/json")
+	assert.NoError(t, server.RegisterService(&testInfoService{}, "info"))
+
+	mux := http.NewServeMux()
+	mux.Handle(baseURL+"/"+infoEndpoint, server)
+	mux.Handle(baseURL+"/throttled", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	return mux
+}
+
+type testBlockNotification struct {
+	BlockID  ids.ID      `json:"blockID"`
+	ParentID ids.ID      `json:"parentID"`
+	Height   json.Uint64 `json:"height"`
+	TxIDs    []ids.ID    `json:"txIDs"`
+}
+
+func TestBridgeCall(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewInfoServer(newTestHandler(t))
+
+	idResp, err := s.GetBlockchainID(context.Background(), &infopb.GetBlockchainIDRequest{Alias: "X"})
+	assert.NoError(err)
+	assert.Equal("2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM", idResp.BlockchainId)
+
+	networkResp, err := s.GetNetworkID(context.Background(), &infopb.GetNetworkIDRequest{})
+	assert.NoError(err)
+	assert.EqualValues(5, networkResp.NetworkId)
+}
+
+func TestBridgeCallErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := newTestHandler(t)
+	s := NewInfoServer(handler)
+
+	_, err := s.GetBlockchainID(context.Background(), &infopb.GetBlockchainIDRequest{Alias: "Y"})
+	assert.Equal(codes.Unknown, status.Code(err))
+
+	_, err = s.GetNodeID(context.Background(), &infopb.GetNodeIDRequest{})
+	assert.Equal(codes.Unknown, status.Code(err))
+
+	b := bridge{handler: handler}
+	err = b.call(context.Background(), "throttled", "info.getNodeID", &infopb.GetNodeIDRequest{}, &infopb.GetNodeIDResponse{})
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func TestAVMEndpoint(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("bc/X", avmEndpoint(""))
+	assert.Equal("bc/myChain", avmEndpoint("myChain"))
+}
+
+func TestBridgeSubscribe(t *testing.T) {
+	assert := assert.New(t)
+
+	server := pubsub.New(0, logging.NoLog{}, pubsub.AcceptedBlocks, pubsub.AcceptedTxs)
+	mux := http.NewServeMux()
+	mux.Handle(baseURL+"/"+platformEndpoint+"/"+eventsEndpoint, server)
+	b := bridge{handler: mux}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	blks := make(chan *platformvmpb.AcceptedBlock)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- b.subscribe(
+			ctx,
+			platformEndpoint,
+			pubsub.AcceptedBlocks,
+			func() proto.Message { return &platformvmpb.AcceptedBlock{} },
+			func(msg proto.Message) error {
+				blks <- msg.(*platformvmpb.AcceptedBlock)
+				return nil
+			},
+		)
+	}()
+
+	notification := &testBlockNotification{
+		BlockID:  ids.GenerateTestID(),
+		ParentID: ids.GenerateTestID(),
+		Height:   5,
+		TxIDs:    []ids.ID{ids.GenerateTestID()},
+	}
+
+	// The subscription is made asynchronously, so notifications published
+	// before it's registered aren't received.
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	var blk *platformvmpb.AcceptedBlock
+	for blk == nil {
+		select {
+		case blk = <-blks:
+		case <-ticker.C:
+			server.Broadcast(pubsub.AcceptedTxs, notification)
+			server.Broadcast(pubsub.AcceptedBlocks, notification)
+		}
+	}
+	assert.Equal(notification.BlockID.String(), blk.BlockId)
+	assert.Equal(notification.ParentID.String(), blk.ParentId)
+	assert.EqualValues(5, blk.Height)
+	assert.Equal([]string{notification.TxIDs[0].String()}, blk.TxIds)
+
+	cancel()
+	for {
+		select {
+		case <-blks:
+			continue
+		case err := <-errs:
+			assert.Equal(codes.Canceled, status.Code(err))
+		}
+		break
+	}
+}
+
+func TestBridgeSubscribeErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	server := pubsub.New(0, logging.NoLog{}, pubsub.AcceptedTxs)
+	mux := http.NewServeMux()
+	mux.Handle(baseURL+"/"+platformEndpoint+"/"+eventsEndpoint, server)
+	mux.Handle(baseURL+"/throttled/"+eventsEndpoint, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	b := bridge{handler: mux}
+
+	newMsg := func() proto.Message { return &platformvmpb.AcceptedBlock{} }
+	send := func(proto.Message) error { return nil }
+
+	err := b.subscribe(context.Background(), "throttled", pubsub.AcceptedBlocks, newMsg, send)
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+
+	err = b.subscribe(context.Background(), "bc/unknown", pubsub.AcceptedBlocks, newMsg, send)
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	err = b.subscribe(context.Background(), platformEndpoint, pubsub.AcceptedBlocks, newMsg, send)
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	healthpb "github.com/ava-labs/avalanchego/proto/pb/health"
+)
+
+const (
+	healthEndpoint = "health"
+
+	defaultWatchInterval = 10 * time.Second
+	minWatchInterval     = time.Second
+)
+
+var _ healthpb.HealthServer = &healthServer{}
+
+type healthServer struct {
+	healthpb.UnsafeHealthServer
+	bridge
+}
+
+// NewHealthServer returns the gRPC mirror of the health API served by
+// [handler].
+func NewHealthServer(handler http.Handler) healthpb.HealthServer {
+	return &healthServer{bridge: bridge{handler: handler}}
+}
+
+func (s *healthServer) Readiness(ctx context.Context, req *healthpb.HealthRequest) (*healthpb.HealthResponse, error) {
+	resp := &healthpb.HealthResponse{}
+	return resp, s.call(ctx, healthEndpoint, "health.readiness", req, resp)
+}
+
+func (s *healthServer) Health(ctx context.Context, req *healthpb.HealthRequest) (*healthpb.HealthResponse, error) {
+	resp := &healthpb.HealthResponse{}
+	return resp, s.call(ctx, healthEndpoint, "health.health", req, resp)
+}
+
+func (s *healthServer) Liveness(ctx context.Context, req *healthpb.HealthRequest) (*healthpb.HealthResponse, error) {
+	resp := &healthpb.HealthResponse{}
+	return resp, s.call(ctx, healthEndpoint, "health.liveness", req, resp)
+}
+
+func (s *healthServer) Watch(req *healthpb.WatchRequest, stream healthpb.Health_WatchServer) error {
+	interval := time.Duration(req.IntervalMs) * time.Millisecond
+	switch {
+	case interval == 0:
+		interval = defaultWatchInterval
+	case interval < minWatchInterval:
+		interval = minWatchInterval
+	}
+
+	ctx := stream.Context()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		resp, err := s.Health(ctx, &healthpb.HealthRequest{})
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+
+	infopb "github.com/ava-labs/avalanchego/proto/pb/info"
+)
+
+const infoEndpoint = "info"
+
+var _ infopb.InfoServer = &infoServer{}
+
+type infoServer struct {
+	infopb.UnsafeInfoServer
+	bridge
+}
+
+// NewInfoServer returns the gRPC mirror of the info API served by [handler].
+func NewInfoServer(handler http.Handler) infopb.InfoServer {
+	return &infoServer{bridge: bridge{handler: handler}}
+}
+
+func (s *infoServer) GetNodeVersion(ctx context.Context, req *infopb.GetNodeVersionRequest) (*infopb.GetNodeVersionResponse, error) {
+	resp := &infopb.GetNodeVersionResponse{}
+	return resp, s.call(ctx, infoEndpoint, "info.getNodeVersion", req, resp)
+}
+
+func (s *infoServer) GetNodeID(ctx context.Context, req *infopb.GetNodeIDRequest) (*infopb.GetNodeIDResponse, error) {
+	resp := &infopb.GetNodeIDResponse{}
+	return resp, s.call(ctx, infoEndpoint, "info.getNodeID", req, resp)
+}
+
+func (s *infoServer) GetNodeIP(ctx context.Context, req *infopb.GetNodeIPRequest) (*infopb.GetNodeIPResponse, error) {
+	resp := &infopb.GetNodeIPResponse{}
+	return resp, s.call(ctx, infoEndpoint, "info.getNodeIP", req, resp)
+}
+
+func (s *infoServer) GetNetworkID(ctx context.Context, req *infopb.GetNetworkIDRequest) (*infopb.GetNetworkIDResponse, error) {
+	resp := &infopb.GetNetworkIDResponse{}
+	return resp, s.call(ctx, infoEndpoint, "info.getNetworkID", req, resp)
+}
+
+func (s *infoServer) GetNetworkName(ctx context.Context, req *infopb.GetNetworkNameRequest) (*infopb.GetNetworkNameResponse, error) {
+	resp := &infopb.GetNetworkNameResponse{}
+	return resp, s.call(ctx, infoEndpoint, "info.getNetworkName", req, resp)
+}
+
+func (s *infoServer) GetBlockchainID(ctx context.Context, req *infopb.GetBlockchainIDRequest) (*infopb.GetBlockchainIDResponse, error) {
+	resp := &infopb.GetBlockchainIDResponse{}
+	return resp, s.call(ctx, infoEndpoint, "info.getBlockchainID", req, resp)
+}
+
+func (s *infoServer) Peers(ctx context.Context, req *infopb.PeersRequest) (*infopb.PeersResponse, error) {
+	resp := &infopb.PeersResponse{}
+	return resp, s.call(ctx, infoEndpoint, "info.peers", req, resp)
+}
+
+func (s *infoServer) IsBootstrapped(ctx context.Context, req *infopb.IsBootstrappedRequest) (*infopb.IsBootstrappedResponse, error) {
+	resp := &infopb.IsBootstrappedResponse{}
+	return resp, s.call(ctx, infoEndpoint, "info.isBootstrapped", req, resp)
+}
+
+func (s *infoServer) GetTxFee(ctx context.Context, req *infopb.GetTxFeeRequest) (*infopb.GetTxFeeResponse, error) {
+	resp := &infopb.GetTxFeeResponse{}
+	return resp, s.call(ctx, infoEndpoint, "info.getTxFee", req, resp)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcapi
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/pubsub"
+
+	platformvmpb "github.com/ava-labs/avalanchego/proto/pb/platformvm"
+)
+
+const platformEndpoint = "bc/P"
+
+var _ platformvmpb.PlatformServer = &platformServer{}
+
+type platformServer struct {
+	platformvmpb.UnsafePlatformServer
+	bridge
+}
+
+// NewPlatformServer returns the gRPC mirror of the P-chain API served by
+// [handler].
+func NewPlatformServer(handler http.Handler) platformvmpb.PlatformServer {
+	return &platformServer{bridge: bridge{handler: handler}}
+}
+
+func (s *platformServer) GetHeight(ctx context.Context, req *platformvmpb.GetHeightRequest) (*platformvmpb.GetHeightResponse, error) {
+	resp := &platformvmpb.GetHeightResponse{}
+	return resp, s.call(ctx, platformEndpoint, "platform.getHeight", req, resp)
+}
+
+func (s *platformServer) GetTimestamp(ctx context.Context, req *platformvmpb.GetTimestampRequest) (*platformvmpb.GetTimestampResponse, error) {
+	resp := &platformvmpb.GetTimestampResponse{}
+	return resp, s.call(ctx, platformEndpoint, "platform.getTimestamp", req, resp)
+}
+
+func (s *platformServer) GetBalance(ctx context.Context, req *platformvmpb.GetBalanceRequest) (*platformvmpb.GetBalanceResponse, error) {
+	resp := &platformvmpb.GetBalanceResponse{}
+	return resp, s.call(ctx, platformEndpoint, "platform.getBalance", req, resp)
+}
+
+func (s *platformServer) GetCurrentValidators(ctx context.Context, req *platformvmpb.GetCurrentValidatorsRequest) (*platformvmpb.GetCurrentValidatorsResponse, error) {
+	resp := &platformvmpb.GetCurrentValidatorsResponse{}
+	return resp, s.call(ctx, platformEndpoint, "platform.getCurrentValidators", req, resp)
+}
+
+func (s *platformServer) GetTx(ctx context.Context, req *platformvmpb.GetTxRequest) (*platformvmpb.GetTxResponse, error) {
+	resp := &platformvmpb.GetTxResponse{}
+	return resp, s.call(ctx, platformEndpoint, "platform.getTx", req, resp)
+}
+
+func (s *platformServer) GetTxStatus(ctx context.Context, req *platformvmpb.GetTxStatusRequest) (*platformvmpb.GetTxStatusResponse, error) {
+	resp := &platformvmpb.GetTxStatusResponse{}
+	return resp, s.call(ctx, platformEndpoint, "platform.getTxStatus", req, resp)
+}
+
+func (s *platformServer) IssueTx(ctx context.Context, req *platformvmpb.IssueTxRequest) (*platformvmpb.IssueTxResponse, error) {
+	resp := &platformvmpb.IssueTxResponse{}
+	return resp, s.call(ctx, platformEndpoint, "platform.issueTx", req, resp)
+}
+
+func (s *platformServer) SubscribeAcceptedBlocks(_ *platformvmpb.SubscribeAcceptedBlocksRequest, stream platformvmpb.Platform_SubscribeAcceptedBlocksServer) error {
+	return s.subscribe(
+		stream.Context(),
+		platformEndpoint,
+		pubsub.AcceptedBlocks,
+		func() proto.Message { return &platformvmpb.AcceptedBlock{} },
+		func(msg proto.Message) error { return stream.Send(msg.(*platformvmpb.AcceptedBlock)) },
+	)
+}
+
+func (s *platformServer) SubscribeAcceptedTxs(_ *platformvmpb.SubscribeAcceptedTxsRequest, stream platformvmpb.Platform_SubscribeAcceptedTxsServer) error {
+	return s.subscribe(
+		stream.Context(),
+		platformEndpoint,
+		pubsub.AcceptedTxs,
+		func() proto.Message { return &platformvmpb.AcceptedTx{} },
+		func(msg proto.Message) error { return stream.Send(msg.(*platformvmpb.AcceptedTx)) },
+	)
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/pubsub"
+)
+
+const eventsEndpoint = "events"
+
+var errAlreadyHijacked = errors.New("the connection was already hijacked")
+
+// notification is a message received from a pubsub endpoint. It's either a
+// notification on a topic or an error in response to a command.
+type notification struct {
+	Topic   pubsub.Topic    `json:"topic"`
+	Message json.RawMessage `json:"message"`
+	Error   string          `json:"error"`
+}
+
+// subscribe subscribes to [topic] of the pubsub server served at
+// [endpoint]/events and calls [send] with each notification, unmarshalled into
+// a message returned by [newMsg], until [ctx] is done or the subscription is
+// closed by the server.
+//
+// Like JSON-RPC calls, the subscription is dispatched through the node's HTTP
+// handler. The websocket connection is served over an in-memory pipe instead
+// of a network connection.
+func (b *bridge) subscribe(
+	ctx context.Context,
+	endpoint string,
+	topic pubsub.Topic,
+	newMsg func() proto.Message,
+	send func(proto.Message) error,
+) error {
+	clientConn, serverConn := net.Pipe()
+	go b.serveConn(ctx, serverConn)
+
+	header := http.Header{}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if auth := md.Get(authHeaderKey); len(auth) > 0 {
+			header.Set(authHeaderKey, auth[0])
+		}
+	}
+	dialer := websocket.Dialer{
+		NetDialContext: func(context.Context, string, string) (net.Conn, error) {
+			return clientConn, nil
+		},
+	}
+	url := fmt.Sprintf("ws://localhost%s/%s/%s", baseURL, endpoint, eventsEndpoint)
+	conn, resp, err := dialer.DialContext(ctx, url, header)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		_ = clientConn.Close()
+		if resp != nil {
+			return status.Errorf(httpStatusToCode(resp.StatusCode), "couldn't subscribe to %s: %s", topic, resp.Status)
+		}
+		return status.Errorf(codes.Unavailable, "couldn't subscribe to %s: %s", topic, err)
+	}
+	defer conn.Close()
+
+	// Closing the connection stops the read below
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	err = conn.WriteJSON(&pubsub.Command{
+		Subscribe: &pubsub.Subscribe{
+			Topics: []pubsub.Topic{topic},
+		},
+	})
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "couldn't subscribe to %s: %s", topic, err)
+	}
+
+	for {
+		n := notification{}
+		if err := conn.ReadJSON(&n); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return status.FromContextError(ctxErr).Err()
+			}
+			return status.Errorf(codes.Unavailable, "subscription to %s closed: %s", topic, err)
+		}
+		if n.Error != "" {
+			return status.Errorf(codes.InvalidArgument, "couldn't subscribe to %s: %s", topic, n.Error)
+		}
+		if n.Topic != topic {
+			continue
+		}
+
+		msg := newMsg()
+		if err := unmarshaler.Unmarshal(n.Message, msg); err != nil {
+			return status.Errorf(codes.Internal, "couldn't unmarshal notification: %s", err)
+		}
+		if err := send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// serveConn serves the websocket handshake read from [conn] with the node's
+// HTTP handler. If the handler doesn't take over [conn], its response is
+// written to [conn] and [conn] is closed.
+func (b *bridge) serveConn(ctx context.Context, conn net.Conn) {
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+	req, err := http.ReadRequest(rw.Reader)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	req = req.WithContext(ctx)
+	if p, ok := peer.FromContext(ctx); ok {
+		req.RemoteAddr = p.Addr.String()
+	}
+
+	w := &hijackableResponseWriter{
+		responseWriter: newResponseWriter(),
+		conn:           conn,
+		rw:             rw,
+	}
+	b.handler.ServeHTTP(w, req)
+	if w.hijacked {
+		return
+	}
+
+	resp := &http.Response{
+		StatusCode:    w.status,
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        w.header,
+		ContentLength: int64(w.body.Len()),
+		Body:          io.NopCloser(&w.body),
+	}
+	if w.status == http.StatusOK {
+		// The request was handled, but not as a websocket handshake
+		resp.StatusCode = http.StatusBadRequest
+	}
+	_ = resp.Write(rw)
+	_ = rw.Flush()
+	_ = conn.Close()
+}
+
+// hijackableResponseWriter buffers the response of an HTTP handler, unless the
+// handler takes over the connection.
+type hijackableResponseWriter struct {
+	*responseWriter
+
+	conn     net.Conn
+	rw       *bufio.ReadWriter
+	hijacked bool
+}
+
+func (w *hijackableResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if w.hijacked {
+		return nil, nil, errAlreadyHijacked
+	}
+	w.hijacked = true
+	return w.conn, w.rw, nil
+}
@@ -8,8 +8,6 @@ import (
 
 	stdjson "encoding/json"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -17,23 +15,17 @@ import (
 // NewGetAndPostHandler returns a health handler that supports GET and jsonrpc
 // POST requests.
 func NewGetAndPostHandler(log logging.Logger, reporter Reporter) (http.Handler, error) {
-	newServer := rpc.NewServer()
+	newServer := json.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 
-	getHandler := NewGetHandler(reporter.Health)
-
 	// If a GET request is sent, we respond with a 200 if the node is healthy or
 	// a 503 if the node isn't healthy.
-	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			newServer.ServeHTTP(w, r)
-			return
-		}
-
-		getHandler.ServeHTTP(w, r)
-	})
+	handler := &getAndPostHandler{
+		get:    NewGetHandler(reporter.Health),
+		server: newServer,
+	}
 
 	err := newServer.RegisterService(
 		&Service{
@@ -45,6 +37,26 @@ func NewGetAndPostHandler(log logging.Logger, reporter Reporter) (http.Handler,
 	return handler, err
 }
 
+// getAndPostHandler serves GET requests with [get] and all other requests
+// with the JSON RPC [server]
+type getAndPostHandler struct {
+	get    http.Handler
+	server *json.Server
+}
+
+func (h *getAndPostHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.server.ServeHTTP(w, r)
+		return
+	}
+
+	h.get.ServeHTTP(w, r)
+}
+
+func (h *getAndPostHandler) Services() map[string]interface{} {
+	return h.server.Services()
+}
+
 // NewGetHandler return a health handler that supports GET requests reporting
 // the result of the provided [reporter].
 func NewGetHandler(reporter func() (map[string]Result, bool)) http.Handler {
@@ -29,6 +29,9 @@ type Registerer interface {
 	RegisterReadinessCheck(name string, checker Checker) error
 	RegisterHealthCheck(name string, checker Checker) error
 	RegisterLivenessCheck(name string, checker Checker) error
+
+	// DeregisterHealthCheck removes the health check registered as [name].
+	DeregisterHealthCheck(name string) error
 }
 
 // Reporter returns the current health status.
@@ -77,6 +80,10 @@ func (h *health) RegisterLivenessCheck(name string, checker Checker) error {
 	return h.liveness.RegisterCheck(name, checker)
 }
 
+func (h *health) DeregisterHealthCheck(name string) error {
+	return h.health.DeregisterCheck(name)
+}
+
 func (h *health) Readiness() (map[string]Result, bool) {
 	results, healthy := h.readiness.Results()
 	if healthy {
@@ -79,6 +79,39 @@ func TestDuplicatedRegistations(t *testing.T) {
 	assert.ErrorIs(err, errDuplicateCheck)
 }
 
+func TestDeregisterHealthCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	check := CheckerFunc(func() (interface{}, error) {
+		return "", errors.New("unhealthy")
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	assert.NoError(err)
+
+	err = h.DeregisterHealthCheck("check")
+	assert.ErrorIs(err, errUnknownCheck)
+
+	err = h.RegisterHealthCheck("check", check)
+	assert.NoError(err)
+
+	h.Start(checkFreq)
+	defer h.Stop()
+
+	awaitHealthy(h, false)
+
+	err = h.DeregisterHealthCheck("check")
+	assert.NoError(err)
+
+	awaitHealthy(h, true)
+	results, _ := h.Health()
+	assert.NotContains(results, "check")
+
+	// The check can be registered again once it has been removed
+	err = h.RegisterHealthCheck("check", check)
+	assert.NoError(err)
+}
+
 func TestDefaultFailing(t *testing.T) {
 	assert := assert.New(t)
 
@@ -14,7 +14,10 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 )
 
-var errDuplicateCheck = errors.New("duplicated check")
+var (
+	errDuplicateCheck = errors.New("duplicated check")
+	errUnknownCheck   = errors.New("unknown check")
+)
 
 type worker struct {
 	metrics    *metrics
@@ -58,6 +61,25 @@ func (w *worker) RegisterCheck(name string, checker Checker) error {
 	return nil
 }
 
+func (w *worker) DeregisterCheck(name string) error {
+	w.checksLock.Lock()
+	defer w.checksLock.Unlock()
+
+	if _, ok := w.checks[name]; !ok {
+		return fmt.Errorf("%w: %q", errUnknownCheck, name)
+	}
+
+	w.resultsLock.Lock()
+	defer w.resultsLock.Unlock()
+
+	delete(w.checks, name)
+	if w.results[name].Error != nil {
+		w.metrics.failingChecks.Dec()
+	}
+	delete(w.results, name)
+	return nil
+}
+
 func (w *worker) RegisterMonotonicCheck(name string, checker Checker) error {
 	var result utils.AtomicInterface
 	return w.RegisterCheck(name, CheckerFunc(func() (interface{}, error) {
@@ -151,7 +173,11 @@ func (w *worker) runCheck(wg *sync.WaitGroup, name string, check Checker) {
 
 	w.resultsLock.Lock()
 	defer w.resultsLock.Unlock()
-	prevResult := w.results[name]
+	prevResult, ok := w.results[name]
+	if !ok {
+		// The check was deregistered while it was running
+		return
+	}
 	if err != nil {
 		errString := err.Error()
 		result.Error = &errString
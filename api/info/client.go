@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 )
 
 var _ Client = &client{}
@@ -15,7 +16,7 @@ var _ Client = &client{}
 // Client interface for an Info API Client
 type Client interface {
 	GetNodeVersion(context.Context, ...rpc.Option) (*GetNodeVersionReply, error)
-	GetNodeID(context.Context, ...rpc.Option) (ids.NodeID, error)
+	GetNodeID(context.Context, ...rpc.Option) (ids.NodeID, *signer.ProofOfPossession, *signer.NodeAuthorization, error)
 	GetNodeIP(context.Context, ...rpc.Option) (string, error)
 	GetNetworkID(context.Context, ...rpc.Option) (uint32, error)
 	GetNetworkName(context.Context, ...rpc.Option) (string, error)
@@ -25,6 +26,7 @@ type Client interface {
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Uptime(context.Context, ...rpc.Option) (*UptimeResponse, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
+	GetVMDetails(context.Context, ...rpc.Option) (map[ids.ID]VMDetails, error)
 }
 
 // Client implementation for an Info API Client
@@ -46,10 +48,10 @@ func (c *client) GetNodeVersion(ctx context.Context, options ...rpc.Option) (*Ge
 	return res, err
 }
 
-func (c *client) GetNodeID(ctx context.Context, options ...rpc.Option) (ids.NodeID, error) {
+func (c *client) GetNodeID(ctx context.Context, options ...rpc.Option) (ids.NodeID, *signer.ProofOfPossession, *signer.NodeAuthorization, error) {
 	res := &GetNodeIDReply{}
 	err := c.requester.SendRequest(ctx, "getNodeID", struct{}{}, res, options...)
-	return res.NodeID, err
+	return res.NodeID, res.NodePOP, res.NodeAuthorization, err
 }
 
 func (c *client) GetNodeIP(ctx context.Context, options ...rpc.Option) (string, error) {
@@ -109,3 +111,9 @@ func (c *client) GetVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID]
 	err := c.requester.SendRequest(ctx, "getVMs", struct{}{}, res, options...)
 	return res.VMs, err
 }
+
+func (c *client) GetVMDetails(ctx context.Context, options ...rpc.Option) (map[ids.ID]VMDetails, error) {
+	res := &GetVMsReply{}
+	err := c.requester.SendRequest(ctx, "getVMs", struct{}{}, res, options...)
+	return res.Details, err
+}
@@ -220,6 +220,36 @@ func (_m *Client) GetTxFee(_a0 context.Context, _a1 ...rpc.Option) (*info.GetTxF
 	return r0, r1
 }
 
+// GetVMDetails provides a mock function with given fields: _a0, _a1
+func (_m *Client) GetVMDetails(_a0 context.Context, _a1 ...rpc.Option) (map[ids.ID]info.VMDetails, error) {
+	_va := make([]interface{}, len(_a1))
+	for _i := range _a1 {
+		_va[_i] = _a1[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 map[ids.ID]info.VMDetails
+	if rf, ok := ret.Get(0).(func(context.Context, ...rpc.Option) map[ids.ID]info.VMDetails); ok {
+		r0 = rf(_a0, _a1...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[ids.ID]info.VMDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...rpc.Option) error); ok {
+		r1 = rf(_a0, _a1...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVMs provides a mock function with given fields: _a0, _a1
 func (_m *Client) GetVMs(_a0 context.Context, _a1 ...rpc.Option) (map[ids.ID][]string, error) {
 	_va := make([]interface{}, len(_a1))
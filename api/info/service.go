@@ -4,11 +4,12 @@
 package info
 
 import (
+	"crypto"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
-
-	"github.com/gorilla/rpc/v2"
+	"time"
 
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
@@ -23,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 )
 
 var (
@@ -45,6 +47,9 @@ type Info struct {
 type Parameters struct {
 	Version               *version.Application
 	NodeID                ids.NodeID
+	NodePOP               *signer.ProofOfPossession
+	StakingCert           *x509.Certificate
+	StakingSigner         crypto.Signer
 	NetworkID             uint32
 	TxFee                 uint64
 	CreateAssetTxFee      uint64
@@ -64,7 +69,7 @@ func NewService(
 	validators validators.Set,
 	benchlist benchlist.Manager,
 ) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := json.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -109,14 +114,31 @@ func (service *Info) GetNodeVersion(_ *http.Request, _ *struct{}, reply *GetNode
 
 // GetNodeIDReply are the results from calling GetNodeID
 type GetNodeIDReply struct {
-	NodeID ids.NodeID `json:"nodeID"`
+	NodeID            ids.NodeID                `json:"nodeID"`
+	NodePOP           *signer.ProofOfPossession `json:"nodePOP"`
+	NodeAuthorization *signer.NodeAuthorization `json:"nodeAuthorization"`
 }
 
-// GetNodeID returns the node ID of this node
+// GetNodeID returns the node ID of this node, along with the proof of
+// possession of its BLS signing key and the node's authorization, as of now,
+// to register the key on the P-chain
 func (service *Info) GetNodeID(_ *http.Request, _ *struct{}, reply *GetNodeIDReply) error {
 	service.log.Debug("Info: GetNodeID called")
 
+	nodeAuth, err := signer.NewNodeAuthorization(
+		service.StakingCert,
+		service.StakingSigner,
+		service.NetworkID,
+		service.NodePOP.Key(),
+		uint64(time.Now().Unix()),
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't authorize BLS key: %w", err)
+	}
+
 	reply.NodeID = service.NodeID
+	reply.NodePOP = service.NodePOP
+	reply.NodeAuthorization = nodeAuth
 	return nil
 }
 
@@ -291,9 +313,23 @@ func (service *Info) GetTxFee(_ *http.Request, args *struct{}, reply *GetTxFeeRe
 	return nil
 }
 
+// VMDetails describes a virtual machine installed on the node
+type VMDetails struct {
+	// Version reported by the VM
+	Version string `json:"version"`
+	// Version of the rpcchainvm protocol the VM plugin speaks. Omitted if the
+	// VM isn't a plugin.
+	RPCChainVMProtocolVersion *json.Uint32 `json:"rpcChainVMProtocolVersion,omitempty"`
+	// Optional features the VM supports. Omitted if the VM doesn't report
+	// them.
+	Features *vms.Features `json:"features,omitempty"`
+}
+
 // GetVMsReply contains the response metadata for GetVMs
 type GetVMsReply struct {
 	VMs map[ids.ID][]string `json:"vms"`
+	// Details of the VMs that reported them
+	Details map[ids.ID]VMDetails `json:"details"`
 }
 
 // GetVMs lists the virtual machines installed on the node
@@ -307,5 +343,22 @@ func (service *Info) GetVMs(_ *http.Request, _ *struct{}, reply *GetVMsReply) er
 	}
 
 	reply.VMs, err = ids.GetRelevantAliases(service.VMManager, vmIDs)
-	return err
+	if err != nil {
+		return err
+	}
+
+	infos := service.VMManager.Infos()
+	reply.Details = make(map[ids.ID]VMDetails, len(infos))
+	for vmID, info := range infos {
+		details := VMDetails{
+			Version:  info.Version,
+			Features: info.Features,
+		}
+		if info.ProtocolVersion != 0 {
+			protocolVersion := json.Uint32(info.ProtocolVersion)
+			details.RPCChainVMProtocolVersion = &protocolVersion
+		}
+		reply.Details[vmID] = details
+	}
+	return nil
 }
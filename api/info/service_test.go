@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms"
 )
@@ -65,11 +66,23 @@ func TestGetVMsSuccess(t *testing.T) {
 	resources.mockVMManager.EXPECT().ListFactories().Times(1).Return(vmIDs, nil)
 	resources.mockVMManager.EXPECT().Aliases(id1).Times(1).Return(alias1, nil)
 	resources.mockVMManager.EXPECT().Aliases(id2).Times(1).Return(alias2, nil)
+	// id1 is a plugin, id2 runs in-process.
+	features := &vms.Features{StateSync: true}
+	resources.mockVMManager.EXPECT().Infos().Times(1).Return(map[ids.ID]vms.Info{
+		id1: {Version: "v1.0.0", ProtocolVersion: 15},
+		id2: {Version: "v2.0.0", Features: features},
+	})
+	protocolVersion := json.Uint32(15)
+	expectedDetails := map[ids.ID]VMDetails{
+		id1: {Version: "v1.0.0", RPCChainVMProtocolVersion: &protocolVersion},
+		id2: {Version: "v2.0.0", Features: features},
+	}
 
 	reply := GetVMsReply{}
 	err := resources.info.GetVMs(nil, nil, &reply)
 
 	assert.Equal(t, expectedVMRegistry, reply.VMs)
+	assert.Equal(t, expectedDetails, reply.Details)
 	assert.Equal(t, err, nil)
 }
 
@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	errInvalidRate  = errors.New("rate limit must be positive")
+	errInvalidBurst = errors.New("burst must be positive")
+	errInvalidQuota = errors.New("quota window must be positive")
+)
+
+// Bucket describes a token bucket that is allocated per client.
+type Bucket struct {
+	// Number of requests per second a single client can sustain.
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	// Maximum number of requests a single client can make at once.
+	Burst int `json:"burst"`
+}
+
+func (b Bucket) verify() error {
+	switch {
+	case b.RequestsPerSecond <= 0:
+		return errInvalidRate
+	case b.Burst <= 0:
+		return errInvalidBurst
+	default:
+		return nil
+	}
+}
+
+// Quota describes the total number of requests a single client can make within
+// a fixed window.
+type Quota struct {
+	// Number of requests a single client can make per window. If 0, requests
+	// aren't limited by a quota.
+	Requests uint64 `json:"requests"`
+	// Duration of a window. A client's window starts at its first request.
+	Window time.Duration `json:"window"`
+}
+
+func (q Quota) verify() error {
+	if q.Requests > 0 && q.Window <= 0 {
+		return errInvalidQuota
+	}
+	return nil
+}
+
+type Config struct {
+	// Enabled is true if API requests should be rate limited.
+	Enabled bool `json:"enabled"`
+
+	// Default is the bucket that every API request is charged against.
+	Default Bucket `json:"default"`
+
+	// Expensive is an additional bucket that requests to [ExpensiveMethods]
+	// are charged against.
+	Expensive Bucket `json:"expensive"`
+
+	// ExpensiveMethods are the JSON-RPC methods, such as "platform.getStake",
+	// that are charged against the [Expensive] bucket.
+	ExpensiveMethods []string `json:"expensiveMethods"`
+
+	// Quota is the total number of requests that every client can make per
+	// window, in addition to the limits of the buckets.
+	Quota Quota `json:"quota"`
+
+	// ClientIdleTimeout is how long a client's buckets are retained after its
+	// last request.
+	ClientIdleTimeout time.Duration `json:"clientIdleTimeout"`
+}
+
+func (c *Config) Verify() error {
+	if !c.Enabled {
+		return nil
+	}
+	if err := c.Default.verify(); err != nil {
+		return err
+	}
+	if err := c.Expensive.verify(); err != nil {
+		return err
+	}
+	return c.Quota.verify()
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	defaultBucketLabel   = "default"
+	expensiveBucketLabel = "expensive"
+	quotaLabel           = "quota"
+)
+
+type metrics struct {
+	throttled *prometheus.CounterVec
+	clients   prometheus.Gauge
+}
+
+func (m *metrics) Initialize(namespace string, registerer prometheus.Registerer) error {
+	m.throttled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "throttled",
+			Help:      "Number of API requests rejected due to rate limiting",
+		},
+		[]string{"bucket"},
+	)
+	m.clients = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "clients",
+		Help:      "Number of API clients currently being tracked by the rate limiter",
+	})
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.throttled),
+		registerer.Register(m.clients),
+	)
+	return errs.Err
+}
@@ -0,0 +1,297 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	authHeaderKey      = "Authorization"
+	authHeaderValStart = "Bearer "
+	retryAfterHeader   = "Retry-After"
+
+	defaultClientIdleTimeout = 10 * time.Minute
+
+	// maxInspectedBodySize is the number of bytes of a request body that are
+	// read to find the JSON-RPC method being called
+	maxInspectedBodySize = 64 * units.KiB
+)
+
+var _ Limiter = &limiter{}
+
+// TokenIdentifier returns the ID of [token] if it's a valid auth token.
+type TokenIdentifier func(token string) (string, error)
+
+// Limiter rate limits API requests per client. Clients are identified by the
+// valid auth token they provide, if any, and otherwise by their IP address.
+type Limiter interface {
+	server.Wrapper
+
+	// SetConfig replaces the rate limits described by [config]. Clients that
+	// are already being tracked are moved to the new limits immediately.
+	// [config.Enabled] is ignored.
+	SetConfig(config Config) error
+}
+
+type clientBuckets struct {
+	def       *rate.Limiter
+	expensive *rate.Limiter
+	lastSeen  time.Time
+
+	// Start of the client's current quota window and the number of requests
+	// made during it
+	quotaStart time.Time
+	quotaUsed  uint64
+}
+
+type limiter struct {
+	// Used to mock time.
+	clock mockable.Clock
+
+	log     logging.Logger
+	metrics metrics
+	// identifyToken is nil if auth tokens aren't used, in which case every
+	// client is identified by its IP address
+	identifyToken TokenIdentifier
+
+	lock   sync.Mutex
+	config Config
+	// Lowercase names of the methods that are charged against the expensive
+	// bucket. The map is replaced, rather than modified, when the config
+	// changes.
+	expensiveMethods map[string]struct{}
+	// client key -> that client's buckets
+	clients     map[string]*clientBuckets
+	lastCleanup time.Time
+}
+
+// New returns a Limiter that enforces the rate limits described by [config].
+// [identifyToken] may be nil if auth tokens aren't used.
+func New(
+	log logging.Logger,
+	config Config,
+	identifyToken TokenIdentifier,
+	namespace string,
+	registerer prometheus.Registerer,
+) (Limiter, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	if config.ClientIdleTimeout <= 0 {
+		config.ClientIdleTimeout = defaultClientIdleTimeout
+	}
+
+	l := &limiter{
+		log:              log,
+		identifyToken:    identifyToken,
+		config:           config,
+		expensiveMethods: newMethodSet(config.ExpensiveMethods),
+		clients:          make(map[string]*clientBuckets),
+	}
+	return l, l.metrics.Initialize(namespace, registerer)
+}
+
+func (l *limiter) SetConfig(config Config) error {
+	config.Enabled = true
+	if err := config.Verify(); err != nil {
+		return err
+	}
+	if config.ClientIdleTimeout <= 0 {
+		config.ClientIdleTimeout = defaultClientIdleTimeout
+	}
+	expensiveMethods := newMethodSet(config.ExpensiveMethods)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.config = config
+	l.expensiveMethods = expensiveMethods
+
+	now := l.clock.Time()
+	for _, buckets := range l.clients {
+		buckets.def.SetLimitAt(now, rate.Limit(config.Default.RequestsPerSecond))
+		buckets.def.SetBurstAt(now, config.Default.Burst)
+		buckets.expensive.SetLimitAt(now, rate.Limit(config.Expensive.RequestsPerSecond))
+		buckets.expensive.SetBurstAt(now, config.Expensive.Burst)
+	}
+	return nil
+}
+
+func (l *limiter) WrapHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expensive := l.isExpensive(r)
+		delay, bucket := l.reserve(l.clientKey(r), expensive)
+		if delay > 0 {
+			l.metrics.throttled.WithLabelValues(bucket).Inc()
+			// Retry-After is specified in whole seconds, so round up.
+			retryAfter := int64(math.Ceil(delay.Seconds()))
+			w.Header().Set(retryAfterHeader, strconv.FormatInt(retryAfter, 10))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// reserve attempts to charge a request by the client identified by [key]. If
+// the request should be rejected, the amount of time the client should wait
+// before retrying is returned along with the bucket, or quota, that was
+// exhausted.
+func (l *limiter) reserve(key string, expensive bool) (time.Duration, string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Time()
+	l.cleanup(now)
+
+	buckets, ok := l.clients[key]
+	if !ok {
+		buckets = &clientBuckets{
+			def:       rate.NewLimiter(rate.Limit(l.config.Default.RequestsPerSecond), l.config.Default.Burst),
+			expensive: rate.NewLimiter(rate.Limit(l.config.Expensive.RequestsPerSecond), l.config.Expensive.Burst),
+		}
+		l.clients[key] = buckets
+		l.metrics.clients.Set(float64(len(l.clients)))
+	}
+	buckets.lastSeen = now
+
+	quota := l.config.Quota
+	if quota.Requests > 0 {
+		if !now.Before(buckets.quotaStart.Add(quota.Window)) {
+			buckets.quotaStart = now
+			buckets.quotaUsed = 0
+		}
+		if buckets.quotaUsed >= quota.Requests {
+			return buckets.quotaStart.Add(quota.Window).Sub(now), quotaLabel
+		}
+	}
+
+	defReservation := buckets.def.ReserveN(now, 1)
+	if delay := defReservation.DelayFrom(now); delay > 0 {
+		defReservation.CancelAt(now)
+		return delay, defaultBucketLabel
+	}
+	if expensive {
+		expensiveReservation := buckets.expensive.ReserveN(now, 1)
+		if delay := expensiveReservation.DelayFrom(now); delay > 0 {
+			expensiveReservation.CancelAt(now)
+			defReservation.CancelAt(now)
+			return delay, expensiveBucketLabel
+		}
+	}
+	buckets.quotaUsed++
+	return 0, ""
+}
+
+// cleanup removes the buckets of clients that haven't made a request
+// recently. Clients whose quota window hasn't ended are retained so that
+// their quota isn't reset.
+//
+// Assumes [l.lock] is held.
+func (l *limiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < l.config.ClientIdleTimeout {
+		return
+	}
+	l.lastCleanup = now
+
+	quota := l.config.Quota
+	for key, buckets := range l.clients {
+		if now.Sub(buckets.lastSeen) < l.config.ClientIdleTimeout {
+			continue
+		}
+		if quota.Requests > 0 && now.Before(buckets.quotaStart.Add(quota.Window)) {
+			continue
+		}
+		delete(l.clients, key)
+	}
+	l.metrics.clients.Set(float64(len(l.clients)))
+}
+
+// isExpensive returns true if [r] is a JSON-RPC request for one of the
+// configured expensive methods. Requests whose body is too large to be
+// inspected are assumed to be expensive. The request body is restored so that
+// it can be read by the wrapped handler.
+func (l *limiter) isExpensive(r *http.Request) bool {
+	l.lock.Lock()
+	expensiveMethods := l.expensiveMethods
+	l.lock.Unlock()
+
+	if len(expensiveMethods) == 0 || r.Body == nil || r.Method != http.MethodPost {
+		return false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxInspectedBodySize+1))
+	r.Body = &restoredBody{
+		Reader: io.MultiReader(bytes.NewReader(body), r.Body),
+		Closer: r.Body,
+	}
+	if err != nil {
+		l.log.Debug("failed to read API request body: %s", err)
+		return false
+	}
+	if len(body) > maxInspectedBodySize {
+		return true
+	}
+
+	request := struct {
+		Method string `json:"method"`
+	}{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return false
+	}
+	_, expensive := expensiveMethods[strings.ToLower(request.Method)]
+	return expensive
+}
+
+// newMethodSet returns the lowercase names of [methods].
+func newMethodSet(methods []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		set[strings.ToLower(method)] = struct{}{}
+	}
+	return set
+}
+
+// restoredBody is a request body that has been partially read and then
+// prepended with the bytes that were read
+type restoredBody struct {
+	io.Reader
+	io.Closer
+}
+
+// clientKey returns the identifier of the client that sent [r]. Requests with
+// a missing or invalid auth token are identified by their IP address so that
+// clients can't avoid rate limiting by sending a different token every time.
+func (l *limiter) clientKey(r *http.Request) string {
+	rawHeader := r.Header.Get(authHeaderKey)
+	if l.identifyToken != nil && strings.HasPrefix(rawHeader, authHeaderValStart) {
+		if tokenID, err := l.identifyToken(rawHeader[len(authHeaderValStart):]); err == nil {
+			return "token:" + tokenID
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "ip:" + r.RemoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var testConfig = Config{
+	Enabled: true,
+	Default: Bucket{
+		RequestsPerSecond: 1,
+		Burst:             2,
+	},
+	Expensive: Bucket{
+		RequestsPerSecond: 1,
+		Burst:             1,
+	},
+	ExpensiveMethods: []string{"platform.getStake"},
+}
+
+func newTestLimiter(t *testing.T) *limiter {
+	l, err := New(logging.NoLog{}, testConfig, nil, "", prometheus.NewRegistry())
+	assert.NoError(t, err)
+	return l.(*limiter)
+}
+
+func newRequest(remoteAddr, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/ext/bc/P", strings.NewReader(body))
+	r.RemoteAddr = remoteAddr
+	return r
+}
+
+func TestLimiterDefaultBucket(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newTestLimiter(t)
+	l.clock.Set(time.Unix(0, 0))
+	handler := l.WrapHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	for i := 0; i < testConfig.Default.Burst; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest("1.2.3.4:5", ""))
+		assert.Equal(http.StatusOK, w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("1.2.3.4:6", ""))
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+	assert.Equal("1", w.Header().Get(retryAfterHeader))
+
+	// A different client should have its own bucket
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("5.6.7.8:5", ""))
+	assert.Equal(http.StatusOK, w.Code)
+
+	// After the bucket refills the client should be allowed again
+	l.clock.Set(time.Unix(1, 0))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("1.2.3.4:5", ""))
+	assert.Equal(http.StatusOK, w.Code)
+}
+
+func TestLimiterExpensiveBucket(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newTestLimiter(t)
+	l.clock.Set(time.Unix(0, 0))
+
+	var bodies []string
+	handler := l.WrapHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(err)
+		bodies = append(bodies, string(body))
+	}))
+
+	getStake := `{"jsonrpc":"2.0","id":1,"method":"platform.getStake","params":{}}`
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("1.2.3.4:5", getStake))
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal([]string{getStake}, bodies)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("1.2.3.4:5", getStake))
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+
+	// Cheap requests are still allowed because the rejected expensive request
+	// wasn't charged against the default bucket.
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("1.2.3.4:5", `{"method":"platform.getHeight"}`))
+	assert.Equal(http.StatusOK, w.Code)
+}
+
+func TestLimiterSetConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newTestLimiter(t)
+	l.clock.Set(time.Unix(0, 0))
+	handler := l.WrapHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	getStake := `{"method":"platform.getStake"}`
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("1.2.3.4:5", getStake))
+	assert.Equal(http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("1.2.3.4:5", getStake))
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+
+	config := testConfig
+	config.Expensive.RequestsPerSecond = 10
+	config.ExpensiveMethods = []string{"platform.getCurrentValidators"}
+	assert.NoError(l.SetConfig(config))
+
+	// The existing client's expensive bucket should refill at the new rate,
+	// and platform.getStake should no longer be considered expensive.
+	l.clock.Set(time.Unix(0, int64(100*time.Millisecond)))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("1.2.3.4:5", `{"method":"platform.getCurrentValidators"}`))
+	assert.Equal(http.StatusOK, w.Code)
+	assert.False(l.isExpensive(newRequest("1.2.3.4:5", getStake)))
+
+	config.Default.Burst = 0
+	assert.ErrorIs(l.SetConfig(config), errInvalidBurst)
+}
+
+func TestLimiterQuota(t *testing.T) {
+	assert := assert.New(t)
+
+	config := testConfig
+	config.Quota = Quota{
+		Requests: 3,
+		Window:   time.Hour,
+	}
+	config.ClientIdleTimeout = time.Minute
+	l, err := New(logging.NoLog{}, config, nil, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	ll := l.(*limiter)
+	ll.clock.Set(time.Unix(0, 0))
+	handler := l.WrapHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	// Requests rejected by a bucket aren't charged against the quota
+	for i := 0; i < config.Default.Burst+1; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), newRequest("1.2.3.4:5", ""))
+	}
+	assert.EqualValues(2, ll.clients["ip:1.2.3.4"].quotaUsed)
+
+	// Waiting past the idle timeout doesn't reset the quota
+	ll.clock.Set(time.Unix(0, 0).Add(10 * time.Minute))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("1.2.3.4:5", ""))
+	assert.Equal(http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("1.2.3.4:5", ""))
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+	assert.Equal("3000", w.Header().Get(retryAfterHeader))
+
+	// A different client should have its own quota
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("5.6.7.8:5", ""))
+	assert.Equal(http.StatusOK, w.Code)
+
+	// The quota is reset once the window ends
+	ll.clock.Set(time.Unix(0, 0).Add(time.Hour))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest("1.2.3.4:5", ""))
+	assert.Equal(http.StatusOK, w.Code)
+}
+
+func TestClientKey(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newTestLimiter(t)
+	r := newRequest("1.2.3.4:5", "")
+	assert.Equal("ip:1.2.3.4", l.clientKey(r))
+
+	// Tokens are ignored if they can't be validated
+	r.Header.Set(authHeaderKey, authHeaderValStart+"token")
+	assert.Equal("ip:1.2.3.4", l.clientKey(r))
+
+	l.identifyToken = func(token string) (string, error) {
+		if token != "token" {
+			return "", errInvalidBurst
+		}
+		return "id", nil
+	}
+	assert.Equal("token:id", l.clientKey(r))
+
+	// Invalid tokens don't get their own bucket
+	r.Header.Set(authHeaderKey, authHeaderValStart+"random")
+	assert.Equal("ip:1.2.3.4", l.clientKey(r))
+}
+
+func TestIsExpensiveLargeBody(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newTestLimiter(t)
+	body := `{"method":"platform.getHeight","params":"` + strings.Repeat("a", maxInspectedBodySize) + `"}`
+	r := newRequest("1.2.3.4:5", body)
+	assert.True(l.isExpensive(r))
+
+	// The whole body is still passed to the handler
+	restored, err := io.ReadAll(r.Body)
+	assert.NoError(err)
+	assert.Equal(body, string(restored))
+	assert.NoError(r.Body.Close())
+}
+
+func TestConfigVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(testConfig.Verify())
+	assert.NoError((&Config{}).Verify())
+
+	config := testConfig
+	config.Default.Burst = 0
+	assert.ErrorIs(config.Verify(), errInvalidBurst)
+
+	config = testConfig
+	config.Expensive.RequestsPerSecond = 0
+	assert.ErrorIs(config.Verify(), errInvalidRate)
+
+	config = testConfig
+	config.Quota.Requests = 1
+	assert.ErrorIs(config.Verify(), errInvalidQuota)
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const (
+	routeLabel = "route"
+	// Label of the requests to chains that aren't given their own label
+	otherChainsLabel = "other"
+)
+
+// DefaultLatencyBuckets are the default bucket boundaries, in nanoseconds, of
+// the API request duration histogram.
+var DefaultLatencyBuckets = []float64{
+	float64(5 * time.Millisecond),
+	float64(10 * time.Millisecond),
+	float64(25 * time.Millisecond),
+	float64(50 * time.Millisecond),
+	float64(100 * time.Millisecond),
+	float64(250 * time.Millisecond),
+	float64(500 * time.Millisecond),
+	float64(time.Second),
+	float64(2500 * time.Millisecond),
+	float64(5 * time.Second),
+	float64(10 * time.Second),
+}
+
+// MetricsConfig configures the metrics reported by the API server
+type MetricsConfig struct {
+	// Bucket boundaries, in nanoseconds, of the request duration histogram.
+	// If empty, DefaultLatencyBuckets is used.
+	LatencyBuckets []float64 `json:"latencyBuckets"`
+
+	// Max number of chains whose requests are reported under their own route
+	// label. Requests to any other chain are reported under the "other" label.
+	// If 0, every chain is given its own label.
+	MaxChainLabels int `json:"maxChainLabels"`
+}
+
+type metrics struct {
+	maxChainLabels int
+
+	lock sync.Mutex
+	// Chain ID --> Label that requests to the chain are reported under
+	chainLabels map[ids.ID]string
+	// Number of chains that have been given their own label
+	numChainLabels int
+
+	requestDuration *prometheus.HistogramVec
+}
+
+func (m *metrics) Initialize(namespace string, registerer prometheus.Registerer, config MetricsConfig) error {
+	buckets := config.LatencyBuckets
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+
+	m.maxChainLabels = config.MaxChainLabels
+	m.chainLabels = make(map[ids.ID]string)
+	m.requestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration",
+			Help:      "Time (in ns) spent handling API requests",
+			Buckets:   buckets,
+		},
+		[]string{routeLabel},
+	)
+	return registerer.Register(m.requestDuration)
+}
+
+// chainLabel returns the label to report requests to [chainID] under. The
+// first [maxChainLabels] chains are labeled by [base]. Any other chain is
+// labeled "other".
+func (m *metrics) chainLabel(chainID ids.ID, base string) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if label, ok := m.chainLabels[chainID]; ok {
+		return label
+	}
+
+	label := base
+	if m.maxChainLabels != 0 && m.numChainLabels >= m.maxChainLabels {
+		label = otherChainsLabel
+	} else {
+		m.numChainLabels++
+	}
+	m.chainLabels[chainID] = label
+	return label
+}
+
+// wrap returns a handler that reports the duration of the requests it handles
+// under [label].
+func (m *metrics) wrap(handler http.Handler, label string) http.Handler {
+	observer := m.requestDuration.WithLabelValues(label)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		handler.ServeHTTP(w, r)
+		observer.Observe(float64(time.Since(start)))
+	})
+}
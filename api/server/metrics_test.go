@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestMetricsChainLabel(t *testing.T) {
+	assert := assert.New(t)
+
+	m := metrics{}
+	assert.NoError(m.Initialize("", prometheus.NewRegistry(), MetricsConfig{
+		MaxChainLabels: 2,
+	}))
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+	chainID2 := ids.GenerateTestID()
+	assert.Equal("bc/0", m.chainLabel(chainID0, "bc/0"))
+	assert.Equal("bc/1", m.chainLabel(chainID1, "bc/1"))
+	// Chains past the limit share a label
+	assert.Equal(otherChainsLabel, m.chainLabel(chainID2, "bc/2"))
+	// Chains keep the label they were first given
+	assert.Equal("bc/0", m.chainLabel(chainID0, "bc/0"))
+	assert.Equal(otherChainsLabel, m.chainLabel(chainID2, "bc/2"))
+}
+
+func TestMetricsUnlimitedChainLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	m := metrics{}
+	assert.NoError(m.Initialize("", prometheus.NewRegistry(), MetricsConfig{}))
+
+	for i := 0; i < 100; i++ {
+		assert.Equal("bc", m.chainLabel(ids.GenerateTestID(), "bc"))
+	}
+}
+
+func TestMetricsWrap(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := prometheus.NewRegistry()
+	m := metrics{}
+	assert.NoError(m.Initialize("api", registry, MetricsConfig{
+		LatencyBuckets: []float64{1, 2, 3},
+	}))
+
+	handler := m.wrap(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), "info")
+	for i := 0; i < 3; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/ext/info", nil))
+	}
+
+	families, err := registry.Gather()
+	assert.NoError(err)
+	assert.Len(families, 1)
+	assert.Equal("api_request_duration", families[0].GetName())
+	assert.Len(families[0].Metric, 1)
+
+	metric := families[0].Metric[0]
+	assert.Equal(routeLabel, metric.Label[0].GetName())
+	assert.Equal("info", metric.Label[0].GetValue())
+	assert.EqualValues(3, metric.Histogram.GetSampleCount())
+	assert.Len(metric.Histogram.Bucket, 3)
+}
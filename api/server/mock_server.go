@@ -6,6 +6,7 @@ package server
 
 import (
 	io "io"
+	http "net/http"
 	reflect "reflect"
 	sync "sync"
 	time "time"
@@ -15,6 +16,8 @@ import (
 	common "github.com/ava-labs/avalanchego/snow/engine/common"
 	logging "github.com/ava-labs/avalanchego/utils/logging"
 	gomock "github.com/golang/mock/gomock"
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	grpc "google.golang.org/grpc"
 )
 
 // MockPathAdder is a mock of PathAdder interface.
@@ -116,17 +119,35 @@ func (mr *MockPathAdderWithReadLockMockRecorder) AddAliasesWithReadLock(endpoint
 }
 
 // AddRouteWithReadLock mocks base method.
-func (m *MockPathAdderWithReadLock) AddRouteWithReadLock(handler *common.HTTPHandler, lock *sync.RWMutex, base, endpoint string, loggingWriter io.Writer) error {
+func (m *MockPathAdderWithReadLock) AddRouteWithReadLock(handler *common.HTTPHandler, lock *sync.RWMutex, base, endpoint string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddRouteWithReadLock", handler, lock, base, endpoint, loggingWriter)
+	ret := m.ctrl.Call(m, "AddRouteWithReadLock", handler, lock, base, endpoint)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddRouteWithReadLock indicates an expected call of AddRouteWithReadLock.
-func (mr *MockPathAdderWithReadLockMockRecorder) AddRouteWithReadLock(handler, lock, base, endpoint, loggingWriter interface{}) *gomock.Call {
+func (mr *MockPathAdderWithReadLockMockRecorder) AddRouteWithReadLock(handler, lock, base, endpoint interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRouteWithReadLock", reflect.TypeOf((*MockPathAdderWithReadLock)(nil).AddRouteWithReadLock), handler, lock, base, endpoint, loggingWriter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRouteWithReadLock", reflect.TypeOf((*MockPathAdderWithReadLock)(nil).AddRouteWithReadLock), handler, lock, base, endpoint)
+}
+
+// RemoveAliasesWithReadLock mocks base method.
+func (m *MockPathAdderWithReadLock) RemoveAliasesWithReadLock(aliases ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range aliases {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveAliasesWithReadLock", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAliasesWithReadLock indicates an expected call of RemoveAliasesWithReadLock.
+func (mr *MockPathAdderWithReadLockMockRecorder) RemoveAliasesWithReadLock(aliases ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAliasesWithReadLock", reflect.TypeOf((*MockPathAdderWithReadLock)(nil).RemoveAliasesWithReadLock), aliases...)
 }
 
 // MockServer is a mock of Server interface.
@@ -260,20 +281,36 @@ func (mr *MockServerMockRecorder) DispatchTLS(certBytes, keyBytes interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchTLS", reflect.TypeOf((*MockServer)(nil).DispatchTLS), certBytes, keyBytes)
 }
 
+// Handler mocks base method.
+func (m *MockServer) Handler() http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handler")
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// Handler indicates an expected call of Handler.
+func (mr *MockServerMockRecorder) Handler() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handler", reflect.TypeOf((*MockServer)(nil).Handler))
+}
+
 // Initialize mocks base method.
-func (m *MockServer) Initialize(log logging.Logger, factory logging.Factory, host string, port uint16, allowedOrigins []string, shutdownTimeout time.Duration, nodeID ids.NodeID, wrappers ...Wrapper) {
+func (m *MockServer) Initialize(log logging.Logger, factory logging.Factory, host string, port uint16, allowedOrigins []string, shutdownTimeout time.Duration, nodeID ids.NodeID, registerer prometheus.Registerer, metricsConfig MetricsConfig, wrappers ...Wrapper) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID}
+	varargs := []interface{}{log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID, registerer, metricsConfig}
 	for _, a := range wrappers {
 		varargs = append(varargs, a)
 	}
-	m.ctrl.Call(m, "Initialize", varargs...)
+	ret := m.ctrl.Call(m, "Initialize", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Initialize indicates an expected call of Initialize.
-func (mr *MockServerMockRecorder) Initialize(log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID interface{}, wrappers ...interface{}) *gomock.Call {
+func (mr *MockServerMockRecorder) Initialize(log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID, registerer, metricsConfig interface{}, wrappers ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID}, wrappers...)
+	varargs := append([]interface{}{log, factory, host, port, allowedOrigins, shutdownTimeout, nodeID, registerer, metricsConfig}, wrappers...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Initialize", reflect.TypeOf((*MockServer)(nil).Initialize), varargs...)
 }
 
@@ -289,6 +326,36 @@ func (mr *MockServerMockRecorder) RegisterChain(chainName, engine interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterChain", reflect.TypeOf((*MockServer)(nil).RegisterChain), chainName, engine)
 }
 
+// RegisterService mocks base method.
+func (m *MockServer) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterService", desc, impl)
+}
+
+// RegisterService indicates an expected call of RegisterService.
+func (mr *MockServerMockRecorder) RegisterService(desc, impl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterService", reflect.TypeOf((*MockServer)(nil).RegisterService), desc, impl)
+}
+
+// RemoveAliasesWithReadLock mocks base method.
+func (m *MockServer) RemoveAliasesWithReadLock(aliases ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range aliases {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveAliasesWithReadLock", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAliasesWithReadLock indicates an expected call of RemoveAliasesWithReadLock.
+func (mr *MockServerMockRecorder) RemoveAliasesWithReadLock(aliases ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAliasesWithReadLock", reflect.TypeOf((*MockServer)(nil).RemoveAliasesWithReadLock), aliases...)
+}
+
 // Shutdown mocks base method.
 func (m *MockServer) Shutdown() error {
 	m.ctrl.T.Helper()
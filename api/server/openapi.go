@@ -0,0 +1,469 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	stdjson "encoding/json"
+
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	// The OpenAPI description is served at [baseURL]/[openAPIEndpoint]
+	openAPIEndpoint = "openapi"
+
+	openAPIVersion = "3.0.3"
+	openAPITitle   = "AvalancheGo API"
+	errorSchema    = "Error"
+)
+
+var (
+	typeOfError         = reflect.TypeOf((*error)(nil)).Elem()
+	typeOfRequest       = reflect.TypeOf((*http.Request)(nil))
+	typeOfMarshaler     = reflect.TypeOf((*stdjson.Marshaler)(nil)).Elem()
+	typeOfTextMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// openAPI describes the JSON RPC services served by the API server as an
+// OpenAPI document. The document is built from the same method reflection that
+// the JSON RPC servers use to dispatch requests, so only routes whose handler
+// implements json.ServiceDescriber are described. In particular, the handlers
+// of VMs that run as plugins can't be described.
+type openAPI struct {
+	lock sync.RWMutex
+	// Maps the path of a route to the services that it serves
+	routes map[string]json.ServiceDescriber
+}
+
+func newOpenAPI() *openAPI {
+	return &openAPI{
+		routes: make(map[string]json.ServiceDescriber),
+	}
+}
+
+// add records the services of [handler], which is served at [route], if
+// [handler] is able to describe them
+func (o *openAPI) add(route string, handler http.Handler) {
+	describer, ok := handler.(json.ServiceDescriber)
+	if !ok {
+		return
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.routes[route] = describer
+}
+
+func (o *openAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = stdjson.NewEncoder(w).Encode(o.document())
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       openAPIInfo                `json:"info"`
+	Paths      map[string]openAPIPathItem `json:"paths"`
+	Components openAPIComponents          `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIPathItem struct {
+	Post openAPIOperation `json:"post"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Tags        []string                   `json:"tags"`
+	RequestBody openAPIRequestBody         `json:"requestBody"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `json:"schemas"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+}
+
+// document returns the OpenAPI description of every recorded route. As every
+// method of a JSON RPC service is served on the same path, each method is
+// described under the path of its route with the name of the method as the
+// fragment. e.g. "/ext/info#info.getNodeID"
+func (o *openAPI) document() *openAPIDocument {
+	o.lock.RLock()
+	routes := make([]string, 0, len(o.routes))
+	describers := make(map[string]json.ServiceDescriber, len(o.routes))
+	for route, describer := range o.routes {
+		routes = append(routes, route)
+		describers[route] = describer
+	}
+	o.lock.RUnlock()
+
+	// Routes and services are iterated in order so that the names of the
+	// schemas are deterministic
+	sort.Strings(routes)
+
+	g := newSchemaGenerator()
+	doc := &openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: openAPIInfo{
+			Title:   openAPITitle,
+			Version: version.Current.String(),
+		},
+		Paths: make(map[string]openAPIPathItem),
+	}
+	for _, route := range routes {
+		services := describers[route].Services()
+		serviceNames := make([]string, 0, len(services))
+		for name := range services {
+			serviceNames = append(serviceNames, name)
+		}
+		sort.Strings(serviceNames)
+
+		for _, serviceName := range serviceNames {
+			for _, m := range serviceMethods(services[serviceName]) {
+				method := fmt.Sprintf("%s.%s", serviceName, m.name)
+				doc.Paths[fmt.Sprintf("%s#%s", route, method)] = openAPIPathItem{
+					Post: g.operation(route, method, m),
+				}
+			}
+		}
+	}
+	doc.Components.Schemas = g.schemas
+	return doc
+}
+
+type serviceMethod struct {
+	// name is the name of the method as it must be called, which starts with a
+	// lowercase letter
+	name      string
+	argsType  reflect.Type
+	replyType reflect.Type
+}
+
+// serviceMethods returns the methods of [receiver] that a JSON RPC server
+// dispatches to, sorted by name. These are the exported methods of the form:
+//
+//	func (t *T) Method(r *http.Request, args *Args, reply *Reply) error
+func serviceMethods(receiver interface{}) []serviceMethod {
+	receiverType := reflect.TypeOf(receiver)
+	methods := []serviceMethod(nil)
+	for i := 0; i < receiverType.NumMethod(); i++ {
+		method := receiverType.Method(i)
+		methodType := method.Type
+		switch {
+		case method.PkgPath != "",
+			methodType.NumIn() != 4,
+			methodType.In(1) != typeOfRequest,
+			methodType.In(2).Kind() != reflect.Ptr,
+			!isExportedOrBuiltin(methodType.In(2)),
+			methodType.In(3).Kind() != reflect.Ptr,
+			!isExportedOrBuiltin(methodType.In(3)),
+			methodType.NumOut() != 1,
+			methodType.Out(0) != typeOfError:
+			continue
+		}
+
+		firstRune, runeLen := utf8.DecodeRuneInString(method.Name)
+		methods = append(methods, serviceMethod{
+			name:      string(unicode.ToLower(firstRune)) + method.Name[runeLen:],
+			argsType:  methodType.In(2).Elem(),
+			replyType: methodType.In(3).Elem(),
+		})
+	}
+	// reflect returns methods in lexicographic order of their exported names,
+	// which may differ from the order of the lowercased names
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].name < methods[j].name
+	})
+	return methods
+}
+
+func isExportedOrBuiltin(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	firstRune, _ := utf8.DecodeRuneInString(t.Name())
+	return t.PkgPath() == "" || unicode.IsUpper(firstRune)
+}
+
+// schemaGenerator converts Go types into the schemas of their JSON encodings.
+// Named struct types are added to [schemas] and referenced, which allows
+// recursive types to be described.
+type schemaGenerator struct {
+	schemas map[string]*openAPISchema
+	// Maps a named struct type to the name of its schema
+	names map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas: map[string]*openAPISchema{
+			errorSchema: {
+				Type: "object",
+				Properties: map[string]*openAPISchema{
+					"code":    {Type: "integer"},
+					"message": {Type: "string"},
+					"data":    {},
+				},
+				Required: []string{"code", "message"},
+			},
+		},
+		names: make(map[reflect.Type]string),
+	}
+}
+
+// operation returns the description of calling [method] on the JSON RPC
+// server at [route]
+func (g *schemaGenerator) operation(route, method string, m serviceMethod) openAPIOperation {
+	request := &openAPISchema{
+		Type: "object",
+		Properties: map[string]*openAPISchema{
+			"jsonrpc": {Type: "string", Enum: []string{"2.0"}},
+			"id":      {},
+			"method":  {Type: "string", Enum: []string{method}},
+			"params":  g.schema(m.argsType),
+		},
+		Required: []string{"jsonrpc", "method"},
+	}
+	response := &openAPISchema{
+		Type: "object",
+		Properties: map[string]*openAPISchema{
+			"jsonrpc": {Type: "string", Enum: []string{"2.0"}},
+			"id":      {},
+			"result":  g.schema(m.replyType),
+			"error":   {Ref: "#/components/schemas/" + errorSchema},
+		},
+		Required: []string{"jsonrpc"},
+	}
+	return openAPIOperation{
+		OperationID: method,
+		Tags:        []string{route},
+		RequestBody: openAPIRequestBody{
+			Required: true,
+			Content: map[string]openAPIMediaType{
+				"application/json": {Schema: request},
+			},
+		},
+		Responses: map[string]openAPIResponse{
+			"200": {
+				Description: "JSON RPC response",
+				Content: map[string]openAPIMediaType{
+					"application/json": {Schema: response},
+				},
+			},
+		},
+	}
+}
+
+// schema returns the schema of the JSON encoding of values of type [t]
+func (g *schemaGenerator) schema(t reflect.Type) *openAPISchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Implements(typeOfMarshaler) || reflect.PtrTo(t).Implements(typeOfMarshaler) {
+		return marshalerSchema(t)
+	}
+	if t.Implements(typeOfTextMarshaler) || reflect.PtrTo(t).Implements(typeOfTextMarshaler) {
+		return &openAPISchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &openAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &openAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Slice:
+		// Byte slices are encoded as base64 strings
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Array:
+		return &openAPISchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &openAPISchema{Ref: "#/components/schemas/" + g.structName(t)}
+	default:
+		// Interfaces can hold values of any type
+		return &openAPISchema{}
+	}
+}
+
+// structName returns the name of the schema of the named struct type [t],
+// generating the schema if it hasn't been generated yet
+func (g *schemaGenerator) structName(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	baseName := fmt.Sprintf("%s.%s", path.Base(t.PkgPath()), t.Name())
+	name := baseName
+	for i := 2; g.schemas[name] != nil; i++ {
+		name = fmt.Sprintf("%s%d", baseName, i)
+	}
+
+	// The name is reserved before the fields are described so that recursive
+	// types reference the schema being generated
+	g.names[t] = name
+	g.schemas[name] = &openAPISchema{}
+	*g.schemas[name] = *g.structSchema(t)
+	return name
+}
+
+// structSchema returns the schema of the struct type [t], following the field
+// naming and embedding rules of encoding/json
+func (g *schemaGenerator) structSchema(t reflect.Type) *openAPISchema {
+	s := &openAPISchema{
+		Type:       "object",
+		Properties: make(map[string]*openAPISchema),
+	}
+	g.addFields(s, t)
+	return s
+}
+
+func (g *schemaGenerator) addFields(s *openAPISchema, t reflect.Type) {
+	// Fields of embedded structs are added after the direct fields, as the
+	// direct fields take precedence
+	embedded := []reflect.Type(nil)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options := tag, ""
+		if comma := strings.Index(tag, ","); comma != -1 {
+			name, options = tag[:comma], tag[comma+1:]
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			embedded = append(embedded, fieldType)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := s.Properties[name]; ok {
+			continue
+		}
+
+		fieldSchema := g.schema(field.Type)
+		for _, option := range strings.Split(options, ",") {
+			if option == "string" {
+				fieldSchema = &openAPISchema{Type: "string"}
+			}
+		}
+		s.Properties[name] = fieldSchema
+	}
+
+	for _, embeddedType := range embedded {
+		embeddedSchema := &openAPISchema{Properties: make(map[string]*openAPISchema)}
+		g.addFields(embeddedSchema, embeddedType)
+		for name, fieldSchema := range embeddedSchema.Properties {
+			if _, ok := s.Properties[name]; !ok {
+				s.Properties[name] = fieldSchema
+			}
+		}
+	}
+}
+
+// marshalerSchema returns the schema of a type that implements its own JSON
+// encoding. As the encoding can't be inspected, the schema is inferred from the
+// encoding of the zero value of [t].
+func marshalerSchema(t reflect.Type) (s *openAPISchema) {
+	defer func() {
+		// Marshalling a zero value may panic, in which case nothing is known
+		// about the encoding
+		if r := recover(); r != nil {
+			s = &openAPISchema{}
+		}
+	}()
+
+	b, err := stdjson.Marshal(reflect.New(t).Interface())
+	if err != nil || len(b) == 0 {
+		return &openAPISchema{}
+	}
+	switch b[0] {
+	case '"':
+		return &openAPISchema{Type: "string"}
+	case '[':
+		return &openAPISchema{Type: "array", Items: &openAPISchema{}}
+	case '{':
+		return &openAPISchema{Type: "object"}
+	case 't', 'f':
+		return &openAPISchema{Type: "boolean"}
+	case 'n':
+		return &openAPISchema{}
+	default:
+		if strings.ContainsAny(string(b), ".eE") {
+			return &openAPISchema{Type: "number"}
+		}
+		return &openAPISchema{Type: "integer"}
+	}
+}
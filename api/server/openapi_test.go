@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	stdjson "encoding/json"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+type openAPITestEmbedded struct {
+	Name   string `json:"name"`
+	Shared int    `json:"shared"`
+}
+
+type OpenAPITestArgs struct {
+	openAPITestEmbedded
+	Shared  string            `json:"shared"`
+	ID      ids.ID            `json:"id"`
+	Amount  json.Uint64       `json:"amount"`
+	Bytes   []byte            `json:"bytes"`
+	Counts  map[string]uint32 `json:"counts"`
+	Skipped bool              `json:"-"`
+	Next    *OpenAPITestArgs  `json:"next,omitempty"`
+	private bool
+}
+
+type OpenAPITestReply struct {
+	IDs []ids.ID `json:"ids"`
+}
+
+type openAPITestService struct{}
+
+func (*openAPITestService) GetThings(_ *http.Request, _ *OpenAPITestArgs, _ *OpenAPITestReply) error {
+	return nil
+}
+
+func (*openAPITestService) NotAMethod(_ *OpenAPITestArgs) error { return nil }
+
+func TestOpenAPIDocument(t *testing.T) {
+	assert := assert.New(t)
+
+	server := json.NewServer()
+	server.RegisterCodec(json.NewCodec(), "application/json")
+	assert.NoError(server.RegisterService(&openAPITestService{}, "test"))
+
+	o := newOpenAPI()
+	o.add("/ext/test", server)
+	// Handlers that can't describe their services are ignored
+	o.add("/ext/other", http.NotFoundHandler())
+
+	doc := o.document()
+	assert.Len(doc.Paths, 1)
+	item, ok := doc.Paths["/ext/test#test.getThings"]
+	assert.True(ok)
+	assert.Equal("test.getThings", item.Post.OperationID)
+
+	request := item.Post.RequestBody.Content["application/json"].Schema
+	assert.Equal([]string{"test.getThings"}, request.Properties["method"].Enum)
+	assert.Equal("#/components/schemas/server.OpenAPITestArgs", request.Properties["params"].Ref)
+
+	response := item.Post.Responses["200"].Content["application/json"].Schema
+	assert.Equal("#/components/schemas/server.OpenAPITestReply", response.Properties["result"].Ref)
+
+	args := doc.Components.Schemas["server.OpenAPITestArgs"]
+	assert.Equal("object", args.Type)
+	assert.Len(args.Properties, 7)
+	assert.Equal("string", args.Properties["name"].Type)
+	assert.Equal("string", args.Properties["shared"].Type)
+	assert.Equal("string", args.Properties["id"].Type)
+	assert.Equal("string", args.Properties["amount"].Type)
+	assert.Equal("byte", args.Properties["bytes"].Format)
+	assert.Equal("object", args.Properties["counts"].Type)
+	assert.Equal("integer", args.Properties["counts"].AdditionalProperties.Type)
+	assert.Equal("#/components/schemas/server.OpenAPITestArgs", args.Properties["next"].Ref)
+	assert.NotContains(args.Properties, "Skipped")
+	assert.NotContains(args.Properties, "private")
+
+	reply := doc.Components.Schemas["server.OpenAPITestReply"]
+	assert.Equal("array", reply.Properties["ids"].Type)
+	assert.Equal("string", reply.Properties["ids"].Items.Type)
+}
+
+func TestOpenAPIServeHTTP(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newOpenAPI()
+
+	w := httptest.NewRecorder()
+	o.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/ext/openapi", nil))
+	assert.Equal(http.StatusMethodNotAllowed, w.Code)
+
+	w = httptest.NewRecorder()
+	o.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ext/openapi", nil))
+	assert.Equal(http.StatusOK, w.Code)
+
+	doc := openAPIDocument{}
+	assert.NoError(stdjson.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(openAPIVersion, doc.OpenAPI)
+	assert.Contains(doc.Components.Schemas, errorSchema)
+}
@@ -135,3 +135,53 @@ func (r *router) AddAlias(base string, aliases ...string) error {
 	}
 	return err
 }
+
+// RemoveAlias removes [aliases] and the routes that they map to. Only aliases
+// added with AddAlias can be removed.
+func (r *router) RemoveAlias(aliases ...string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.routeLock.Lock()
+	defer r.routeLock.Unlock()
+
+	for _, alias := range aliases {
+		if !r.reservedRoutes[alias] {
+			return fmt.Errorf("couldn't remove alias %s as it isn't an alias", alias)
+		}
+	}
+
+	for _, alias := range aliases {
+		delete(r.reservedRoutes, alias)
+		delete(r.routes, alias)
+		for base, baseAliases := range r.aliases {
+			for i, baseAlias := range baseAliases {
+				if baseAlias != alias {
+					continue
+				}
+				baseAliases = append(baseAliases[:i], baseAliases[i+1:]...)
+				if len(baseAliases) == 0 {
+					delete(r.aliases, base)
+				} else {
+					r.aliases[base] = baseAliases
+				}
+				break
+			}
+		}
+	}
+
+	// Routes can't be removed from a mux.Router, so a new one is built with
+	// the remaining routes.
+	muxRouter := mux.NewRouter()
+	for base, endpoints := range r.routes {
+		for endpoint, handler := range endpoints {
+			url := base + endpoint
+			if route := muxRouter.Handle(url, handler); route != nil {
+				route.Name(url)
+			} else {
+				return fmt.Errorf("failed to create new route for %s", url)
+			}
+		}
+	}
+	r.router = muxRouter
+	return nil
+}
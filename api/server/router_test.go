@@ -5,6 +5,8 @@ package server
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 )
 
@@ -75,3 +77,51 @@ func TestBlock(t *testing.T) {
 		t.Fatalf("Permanently locked %s", "1")
 	}
 }
+
+func TestRemoveAlias(t *testing.T) {
+	r := newRouter()
+
+	handler := &testHandler{}
+	if err := r.AddRouter("/base", "/endpoint", handler); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddAlias("/base", "/alias1", "/alias2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.RemoveAlias("/alias1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.GetHandler("/alias1", "/endpoint"); err == nil {
+		t.Fatalf("Should have removed %s", "/alias1")
+	}
+	if _, err := r.GetHandler("/alias2", "/endpoint"); err != nil {
+		t.Fatalf("Shouldn't have removed %s", "/alias2")
+	}
+	if _, err := r.GetHandler("/base", "/endpoint"); err != nil {
+		t.Fatalf("Shouldn't have removed %s", "/base")
+	}
+
+	// Requests to the removed alias are no longer routed to the handler
+	r.ServeHTTP(httptest.NewRecorder(), &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/alias1/endpoint"}})
+	if handler.called {
+		t.Fatalf("Shouldn't have routed to removed alias")
+	}
+	r.ServeHTTP(httptest.NewRecorder(), &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/alias2/endpoint"}})
+	if !handler.called {
+		t.Fatalf("Should have routed to alias")
+	}
+
+	// Routes and unknown aliases can't be removed
+	if err := r.RemoveAlias("/base"); err == nil {
+		t.Fatalf("Shouldn't have removed route %s", "/base")
+	}
+	if err := r.RemoveAlias("/alias1"); err == nil {
+		t.Fatalf("Shouldn't have removed unknown alias %s", "/alias1")
+	}
+
+	// The removed alias can be reused
+	if err := r.AddAlias("/base", "/alias1"); err != nil {
+		t.Fatal(err)
+	}
+}
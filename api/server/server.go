@@ -17,8 +17,16 @@ import (
 
 	"github.com/NYTimes/gziphandler"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/rs/cors"
 
+	"github.com/soheilhy/cmux"
+
+	"golang.org/x/net/http2"
+
+	"google.golang.org/grpc"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -52,6 +60,10 @@ type PathAdderWithReadLock interface {
 	// AddAliasesWithReadLock registers aliases to the server assuming the http read
 	// lock is currently held.
 	AddAliasesWithReadLock(endpoint string, aliases ...string) error
+
+	// RemoveAliasesWithReadLock removes aliases from the server assuming the
+	// http read lock is currently held.
+	RemoveAliasesWithReadLock(aliases ...string) error
 }
 
 // Server maintains the HTTP router
@@ -66,7 +78,9 @@ type Server interface {
 		allowedOrigins []string,
 		shutdownTimeout time.Duration,
 		nodeID ids.NodeID,
-		wrappers ...Wrapper)
+		registerer prometheus.Registerer,
+		metricsConfig MetricsConfig,
+		wrappers ...Wrapper) error
 	// Dispatch starts the API server
 	Dispatch() error
 	// DispatchTLS starts the API server with the provided TLS certificate
@@ -85,6 +99,13 @@ type Server interface {
 		ctx *snow.ConsensusContext,
 		base, endpoint string,
 	) error
+	// RegisterService registers a gRPC service that is served on the same
+	// listener as the HTTP API. Must be called before the server is
+	// dispatched.
+	RegisterService(desc *grpc.ServiceDesc, impl interface{})
+	// Handler returns the handler that serves all of the HTTP API routes,
+	// including any registered wrappers.
+	Handler() http.Handler
 	// Shutdown this server
 	Shutdown() error
 }
@@ -105,7 +126,17 @@ type server struct {
 	// Maps endpoints to handlers
 	router *router
 
-	srv *http.Server
+	// Reports the duration of requests to each route
+	metrics metrics
+
+	// Describes the JSON RPC services served by the routes
+	openAPI *openAPI
+
+	// Serves gRPC requests that are multiplexed on the HTTP listener
+	grpcServer *grpc.Server
+
+	srv      *http.Server
+	listener net.Listener
 }
 
 // New returns an instance of a Server.
@@ -121,14 +152,18 @@ func (s *server) Initialize(
 	allowedOrigins []string,
 	shutdownTimeout time.Duration,
 	nodeID ids.NodeID,
+	registerer prometheus.Registerer,
+	metricsConfig MetricsConfig,
 	wrappers ...Wrapper,
-) {
+) error {
 	s.log = log
 	s.factory = factory
 	s.listenHost = host
 	s.listenPort = port
 	s.shutdownTimeout = shutdownTimeout
 	s.router = newRouter()
+	s.openAPI = newOpenAPI()
+	s.grpcServer = grpc.NewServer()
 
 	s.log.Info("API created with allowed origins: %v", allowedOrigins)
 
@@ -148,6 +183,18 @@ func (s *server) Initialize(
 	for _, wrapper := range wrappers {
 		s.handler = wrapper.WrapHandler(s.handler)
 	}
+	if err := s.metrics.Initialize("api", registerer, metricsConfig); err != nil {
+		return err
+	}
+	return s.addRoute(
+		&common.HTTPHandler{
+			LockOptions: common.NoLock,
+			Handler:     s.openAPI,
+		},
+		nil,
+		openAPIEndpoint,
+		"",
+	)
 }
 
 func (s *server) Dispatch() error {
@@ -164,8 +211,7 @@ func (s *server) Dispatch() error {
 		s.log.Info("HTTP API server listening on \"%s:%d\"", s.listenHost, ipPort.Port)
 	}
 
-	s.srv = &http.Server{Handler: s.handler}
-	return s.srv.Serve(listener)
+	return s.serve(listener)
 }
 
 func (s *server) DispatchTLS(certBytes, keyBytes []byte) error {
@@ -177,6 +223,8 @@ func (s *server) DispatchTLS(certBytes, keyBytes []byte) error {
 	config := &tls.Config{
 		MinVersion:   tls.VersionTLS12,
 		Certificates: []tls.Certificate{cert},
+		// HTTP/2 is advertised so that gRPC clients can connect over TLS.
+		NextProtos: []string{http2.NextProtoTLS, "http/1.1"},
 	}
 
 	listener, err := tls.Listen("tcp", listenAddress, config)
@@ -191,8 +239,53 @@ func (s *server) DispatchTLS(certBytes, keyBytes []byte) error {
 		s.log.Info("HTTPS API server listening on \"%s:%d\"", s.listenHost, ipPort.Port)
 	}
 
-	s.srv = &http.Server{Addr: listenAddress, Handler: s.handler}
-	return s.srv.Serve(listener)
+	return s.serve(listener)
+}
+
+// serve multiplexes [listener] between the gRPC server and the HTTP server.
+// gRPC requests are identified by their content-type, all other HTTP/2
+// requests and all HTTP/1 requests are sent to the HTTP handler.
+func (s *server) serve(listener net.Listener) error {
+	m := cmux.New(listener)
+	grpcListener := m.MatchWithWriters(
+		cmux.HTTP2MatchHeaderFieldSendSettings("content-type", "application/grpc"),
+	)
+	http2Listener := m.Match(cmux.HTTP2())
+	httpListener := m.Match(cmux.Any())
+
+	s.listener = listener
+	s.srv = &http.Server{Handler: s.handler}
+
+	go func() {
+		if err := s.grpcServer.Serve(grpcListener); err != nil {
+			s.log.Debug("gRPC API server stopped: %s", err)
+		}
+	}()
+	go s.serveHTTP2(http2Listener)
+	go func() {
+		if err := s.srv.Serve(httpListener); err != nil && err != http.ErrServerClosed {
+			s.log.Debug("HTTP API server stopped: %s", err)
+		}
+	}()
+	return m.Serve()
+}
+
+// serveHTTP2 serves the HTTP API to clients that connect with HTTP/2 rather
+// than HTTP/1.
+func (s *server) serveHTTP2(listener net.Listener) {
+	h2Server := &http2.Server{}
+	opts := &http2.ServeConnOpts{
+		BaseConfig: s.srv,
+		Handler:    s.handler,
+	}
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			s.log.Debug("HTTP/2 API server stopped: %s", err)
+			return
+		}
+		go h2Server.ServeConn(conn, opts)
+	}
 }
 
 func (s *server) RegisterChain(chainName string, engine common.Engine) {
@@ -243,7 +336,12 @@ func (s *server) AddChainRoute(handler *common.HTTPHandler, ctx *snow.ConsensusC
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	h = rejectMiddleware(h, ctx)
-	return s.router.AddRouter(url, endpoint, h)
+	h = s.metrics.wrap(h, s.metrics.chainLabel(ctx.ChainID, base))
+	if err := s.router.AddRouter(url, endpoint, h); err != nil {
+		return err
+	}
+	s.openAPI.add(url+endpoint, handler.Handler)
+	return nil
 }
 
 func (s *server) AddRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base, endpoint string) error {
@@ -264,7 +362,12 @@ func (s *server) addRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base,
 	if err != nil {
 		return err
 	}
-	return s.router.AddRouter(url, endpoint, h)
+	h = s.metrics.wrap(h, base)
+	if err := s.router.AddRouter(url, endpoint, h); err != nil {
+		return err
+	}
+	s.openAPI.add(url+endpoint, handler.Handler)
+	return nil
 }
 
 // Wraps a handler by grabbing and releasing a lock before calling the handler.
@@ -322,6 +425,26 @@ func (s *server) AddAliasesWithReadLock(endpoint string, aliases ...string) erro
 	return s.AddAliases(endpoint, aliases...)
 }
 
+func (s *server) RemoveAliasesWithReadLock(aliases ...string) error {
+	// See AddAliasesWithReadLock
+	s.router.lock.RUnlock()
+	defer s.router.lock.RLock()
+
+	urls := make([]string, len(aliases))
+	for i, alias := range aliases {
+		urls[i] = fmt.Sprintf("%s/%s", baseURL, alias)
+	}
+	return s.router.RemoveAlias(urls...)
+}
+
+func (s *server) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	s.grpcServer.RegisterService(desc, impl)
+}
+
+func (s *server) Handler() http.Handler {
+	return s.handler
+}
+
 func (s *server) Shutdown() error {
 	if s.srv == nil {
 		return nil
@@ -333,6 +456,9 @@ func (s *server) Shutdown() error {
 
 	// If shutdown times out, make sure the server is still shutdown.
 	_ = s.srv.Close()
+	s.grpcServer.Stop()
+	// Closing the listener causes the multiplexer to stop serving.
+	_ = s.listener.Close()
 	return err
 }
 
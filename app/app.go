@@ -26,6 +26,13 @@ type App interface {
 	ExitCode() (int, error)
 }
 
+// Reloader is an App that can apply changes to its config without restarting.
+type Reloader interface {
+	// Reload re-reads the application's config and applies the changes that
+	// don't require a restart.
+	Reload() error
+}
+
 func Run(app App) int {
 	// start running the application
 	if err := app.Start(); err != nil {
@@ -46,12 +53,26 @@ func Run(app App) int {
 		return nil
 	})
 
+	// if supported, reload the application's config on SIGHUP
+	reloadSignals := make(chan os.Signal, 1)
+	if reloader, ok := app.(Reloader); ok {
+		signal.Notify(reloadSignals, syscall.SIGHUP)
+		go func() {
+			for range reloadSignals {
+				// Errors are reported by the application
+				_ = reloader.Reload()
+			}
+		}()
+	}
+
 	// wait for the app to exit and get the exit code response
 	exitCode, err := app.ExitCode()
 
-	// shut down the signal go routine
+	// shut down the signal go routines
 	signal.Stop(signals)
 	close(signals)
+	signal.Stop(reloadSignals)
+	close(reloadSignals)
 
 	// if there was an error closing or running the application, report that error
 	if eg.Wait() != nil || err != nil {
@@ -29,7 +29,8 @@ var (
 	stakingPortName = fmt.Sprintf("%s-staking", constants.AppName)
 	httpPortName    = fmt.Sprintf("%s-http", constants.AppName)
 
-	_ app.App = &process{}
+	_ app.App      = &process{}
+	_ app.Reloader = &process{}
 )
 
 // process is a wrapper around a node that runs in this process
@@ -176,6 +177,15 @@ func (p *process) Stop() error {
 	return nil
 }
 
+// Reload applies the reloadable subset of the node's config.
+func (p *process) Reload() error {
+	_, _, err := p.node.ReloadConfig()
+	if err != nil {
+		p.node.Log.Warn("failed to reload config: %s", err)
+	}
+	return err
+}
+
 // ExitCode returns the exit code that the node is reporting. This function
 // blocks until the node has been shut down.
 func (p *process) ExitCode() (int, error) {
@@ -0,0 +1,17 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package prune
+
+type Config struct {
+	// Directory of the database. Used to report the size of the database.
+	DatabasePath string
+
+	// Genesis of the network the database belongs to. Used to find the chains
+	// of the primary network.
+	GenesisBytes []byte
+
+	// If true, the node is configured to index accepted containers, so the
+	// indices are never removed.
+	IndexingEnabled bool
+}
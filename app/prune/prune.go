@@ -0,0 +1,376 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package prune
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/proposervm/state"
+)
+
+// maxBatchSize is the number of bytes of deletions that are buffered before
+// they are written to the database.
+const maxBatchSize = 1024 * 1024
+
+// The prefixes below must match the layout of the database written by the
+// node, the chain manager, the job queues, the indexer and the proposervm.
+var (
+	vmDBPrefix = []byte("vm")
+
+	bootstrappingDBPrefixes = [][]byte{
+		[]byte("bs"),        // snowman blocks
+		[]byte("vertex_bs"), // avalanche vertices
+		[]byte("tx_bs"),     // avalanche transactions
+	}
+	jobsPrefix           = []byte("jobs")
+	runnableJobIDsPrefix = []byte("runnable")
+	dependenciesPrefix   = []byte("dependencies")
+
+	proposerVMDBPrefix    = []byte("proposervm")
+	proposerVMChainPrefix = []byte("chain")
+	proposerVMBlockPrefix = []byte("block")
+
+	indexerDBPrefix         = []byte{0x00}
+	isIncompleteIndexPrefix = byte(0x04)
+	indexPrefixes           = []struct {
+		prefix byte
+		name   string
+	}{
+		{prefix: 0x01, name: "tx"},
+		{prefix: 0x02, name: "vtx"},
+		{prefix: 0x03, name: "block"},
+	}
+)
+
+// PrefixReport describes the data that was removed from a part of the
+// database.
+type PrefixReport struct {
+	// Path of prefixes that the data was removed from
+	Name string
+	// Number of keys that were removed
+	Keys int
+	// Number of bytes of keys and values that were removed
+	Bytes int
+}
+
+// Report describes the result of pruning a database.
+type Report struct {
+	// Parts of the database that had data removed
+	Prefixes []PrefixReport
+	// Size of the database directory, in bytes, before and after pruning
+	SizeBefore int64
+	SizeAfter  int64
+}
+
+type chain struct {
+	name string
+	id   ids.ID
+}
+
+// Prune removes unreferenced historical state from the databases managed by
+// [dbManager] and then compacts the current database. The databases must not be
+// in use by a running node. The following is removed:
+//   - proposervm blocks that conflict with the accepted chain
+//   - bootstrapping job queue entries that no longer refer to a queued job
+//   - indices that were marked incomplete, if indexing is disabled
+func Prune(log logging.Logger, dbManager manager.Manager, config Config) (*Report, error) {
+	genesisState, err := genesis.Parse(config.GenesisBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse genesis: %w", err)
+	}
+	chains := []chain{{
+		name: "P-Chain",
+		id:   constants.PlatformChainID,
+	}}
+	for _, tx := range genesisState.Chains {
+		createChainTx, ok := tx.Unsigned.(*txs.CreateChainTx)
+		if !ok {
+			return nil, fmt.Errorf("expected *txs.CreateChainTx but got %T", tx.Unsigned)
+		}
+		chains = append(chains, chain{
+			name: createChainTx.ChainName,
+			id:   tx.ID(),
+		})
+	}
+
+	report := &Report{}
+	report.SizeBefore, err = dirSize(config.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pruner{
+		log:    log,
+		report: report,
+	}
+	if err := p.prune(dbManager, chains, config.IndexingEnabled); err != nil {
+		return nil, err
+	}
+
+	report.SizeAfter, err = dirSize(config.DatabasePath)
+	return report, err
+}
+
+type pruner struct {
+	log    logging.Logger
+	report *Report
+}
+
+func (p *pruner) prune(dbManager manager.Manager, chains []chain, indexingEnabled bool) error {
+	db := dbManager.Current().Database
+	indexerDB := prefixdb.New(indexerDBPrefix, db)
+	for _, chain := range chains {
+		p.log.Info("pruning %s (%s)", chain.name, chain.id)
+
+		chainDBManager := dbManager.NewPrefixDBManager(chain.id[:])
+		chainDB := chainDBManager.Current().Database
+		vmDB := chainDBManager.NewPrefixDBManager(vmDBPrefix).Current().Database
+		if err := p.pruneProposerVMBlocks(chain.name, vmDB); err != nil {
+			return fmt.Errorf("couldn't prune proposervm blocks of %s: %w", chain.name, err)
+		}
+
+		for _, prefix := range bootstrappingDBPrefixes {
+			name := fmt.Sprintf("%s/%s", chain.name, prefix)
+			if err := p.pruneJobs(name, prefixdb.New(prefix, chainDB)); err != nil {
+				return fmt.Errorf("couldn't prune jobs of %s: %w", name, err)
+			}
+		}
+
+		if !indexingEnabled {
+			if err := p.pruneIndices(chain, indexerDB); err != nil {
+				return fmt.Errorf("couldn't prune indices of %s: %w", chain.name, err)
+			}
+		}
+	}
+
+	p.log.Info("compacting database")
+	return db.Compact(nil, nil)
+}
+
+// pruneProposerVMBlocks removes the proposervm blocks that can never be
+// accepted. A block can never be accepted if its parent is an accepted block
+// other than the last accepted block, or if its parent can never be accepted.
+// Blocks that extend the last accepted block are kept, as they may still be
+// accepted.
+func (p *pruner) pruneProposerVMBlocks(chainName string, vmDB database.Database) error {
+	proposerDB := prefixdb.New(proposerVMDBPrefix, vmDB)
+	chainState := state.NewChainState(prefixdb.New(proposerVMChainPrefix, proposerDB))
+	blockDB := prefixdb.New(proposerVMBlockPrefix, proposerDB)
+	blockState := state.NewBlockState(blockDB)
+
+	lastAcceptedID, err := chainState.GetLastAccepted()
+	if err == database.ErrNotFound {
+		// No post-fork blocks have been accepted, so there is nothing that
+		// conflicts with the accepted chain.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Blocks that aren't accepted mapped to their parents
+	parents := make(map[ids.ID]ids.ID)
+	it := blockDB.NewIterator()
+	for it.Next() {
+		blkID, err := ids.ToID(it.Key())
+		if err != nil {
+			it.Release()
+			return err
+		}
+		blk, status, err := blockState.GetBlock(blkID)
+		if err != nil {
+			it.Release()
+			return err
+		}
+		if status != choices.Accepted {
+			parents[blkID] = blk.ParentID()
+		}
+	}
+	err = it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+
+	orphaned := make(map[ids.ID]bool, len(parents))
+	var isOrphaned func(blkID ids.ID) (bool, error)
+	isOrphaned = func(blkID ids.ID) (bool, error) {
+		if orphan, ok := orphaned[blkID]; ok {
+			return orphan, nil
+		}
+
+		parentID := parents[blkID]
+		var orphan bool
+		if _, ok := parents[parentID]; ok {
+			var err error
+			orphan, err = isOrphaned(parentID)
+			if err != nil {
+				return false, err
+			}
+		} else {
+			_, status, err := blockState.GetBlock(parentID)
+			switch {
+			case err == database.ErrNotFound:
+				// The parent is a pre-fork block, so the block is kept.
+			case err != nil:
+				return false, err
+			default:
+				orphan = status == choices.Accepted && parentID != lastAcceptedID
+			}
+		}
+		orphaned[blkID] = orphan
+		return orphan, nil
+	}
+
+	r := p.newRemover(fmt.Sprintf("%s/%s/%s/%s", chainName, vmDBPrefix, proposerVMDBPrefix, proposerVMBlockPrefix), blockDB)
+	for blkID := range parents {
+		orphan, err := isOrphaned(blkID)
+		if err != nil {
+			return err
+		}
+		if !orphan {
+			continue
+		}
+		blkWrapperBytes, err := blockDB.Get(blkID[:])
+		if err != nil {
+			return err
+		}
+		if err := r.delete(blkID[:], blkWrapperBytes); err != nil {
+			return err
+		}
+	}
+	return r.finish()
+}
+
+// pruneJobs removes the runnable job IDs and dependencies of the job queue in
+// [queueDB] if the queue doesn't contain any jobs. Such entries can't refer to
+// a job that will be executed. Missing job IDs are kept, as they are fetched
+// when bootstrapping resumes.
+func (p *pruner) pruneJobs(name string, queueDB database.Database) error {
+	isEmpty, err := database.IsEmpty(prefixdb.New(jobsPrefix, queueDB))
+	if err != nil || !isEmpty {
+		return err
+	}
+
+	for _, prefix := range [][]byte{runnableJobIDsPrefix, dependenciesPrefix} {
+		if err := p.clear(fmt.Sprintf("%s/%s", name, prefix), prefixdb.New(prefix, queueDB)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneIndices removes the indices of [chain] if they were marked incomplete.
+// The markers themselves are kept so that the indexer continues to treat the
+// indices as incomplete.
+func (p *pruner) pruneIndices(chain chain, indexerDB database.Database) error {
+	key := make([]byte, hashing.HashLen+1)
+	copy(key, chain.id[:])
+	key[hashing.HashLen] = isIncompleteIndexPrefix
+	isIncomplete, err := indexerDB.Has(key)
+	if err != nil || !isIncomplete {
+		return err
+	}
+
+	for _, index := range indexPrefixes {
+		prefix := make([]byte, hashing.HashLen+1)
+		copy(prefix, chain.id[:])
+		prefix[hashing.HashLen] = index.prefix
+		name := fmt.Sprintf("index/%s/%s", chain.name, index.name)
+		if err := p.clear(name, prefixdb.New(prefix, indexerDB)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clear removes all the keys in [db].
+func (p *pruner) clear(name string, db database.Database) error {
+	r := p.newRemover(name, db)
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		if err := r.delete(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return r.finish()
+}
+
+// remover batches the removal of keys from a part of the database and records
+// the removal in the report.
+type remover struct {
+	pruner *pruner
+	batch  database.Batch
+	report PrefixReport
+}
+
+func (p *pruner) newRemover(name string, db database.Database) *remover {
+	return &remover{
+		pruner: p,
+		batch:  db.NewBatch(),
+		report: PrefixReport{Name: name},
+	}
+}
+
+func (r *remover) delete(key, value []byte) error {
+	if err := r.batch.Delete(key); err != nil {
+		return err
+	}
+	r.report.Keys++
+	r.report.Bytes += len(key) + len(value)
+
+	if r.batch.Size() < maxBatchSize {
+		return nil
+	}
+	if err := r.batch.Write(); err != nil {
+		return err
+	}
+	r.batch.Reset()
+	return nil
+}
+
+func (r *remover) finish() error {
+	if r.report.Keys == 0 {
+		return nil
+	}
+	if err := r.batch.Write(); err != nil {
+		return err
+	}
+
+	r.pruner.log.Info("removed %d keys (%d bytes) from %s", r.report.Keys, r.report.Bytes, r.report.Name)
+	r.pruner.report.Prefixes = append(r.pruner.report.Prefixes, r.report)
+	return nil
+}
+
+// dirSize returns the total size, in bytes, of the files in [dir].
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package prune
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/proposervm/block"
+	"github.com/ava-labs/avalanchego/vms/proposervm/state"
+)
+
+func TestPrune(t *testing.T) {
+	assert := assert.New(t)
+
+	dbManager := manager.NewMemDB(version.CurrentDatabase)
+	chainID := ids.GenerateTestID()
+	chainDBManager := dbManager.NewPrefixDBManager(chainID[:])
+	chainDB := chainDBManager.Current().Database
+	vmDB := chainDBManager.NewPrefixDBManager(vmDBPrefix).Current().Database
+	proposerDB := prefixdb.New(proposerVMDBPrefix, vmDB)
+	blockDB := prefixdb.New(proposerVMBlockPrefix, proposerDB)
+	blockState := state.NewBlockState(blockDB)
+	chainState := state.NewChainState(prefixdb.New(proposerVMChainPrefix, proposerDB))
+
+	nextBlockBytes := byte(0)
+	putBlock := func(parentID ids.ID, status choices.Status) ids.ID {
+		nextBlockBytes++
+		blk, err := block.BuildUnsigned(parentID, time.Time{}, 0, []byte{nextBlockBytes})
+		assert.NoError(err)
+		assert.NoError(blockState.PutBlock(blk, status))
+		return blk.ID()
+	}
+
+	// preFork <- accepted0 <- accepted1 <- extendsLastAccepted
+	//   ^           ^
+	//   |           +-- conflicting <- conflictingChild
+	//   +-- unknownParent
+	preForkID := ids.GenerateTestID()
+	accepted0 := putBlock(preForkID, choices.Accepted)
+	accepted1 := putBlock(accepted0, choices.Accepted)
+	extendsLastAccepted := putBlock(accepted1, choices.Processing)
+	conflicting := putBlock(accepted0, choices.Processing)
+	conflictingChild := putBlock(conflicting, choices.Processing)
+	unknownParent := putBlock(preForkID, choices.Processing)
+	assert.NoError(chainState.SetLastAccepted(accepted1))
+
+	// The snowman job queue is empty, so its runnable job IDs and dependencies
+	// are orphaned.
+	key := []byte{1}
+	emptyQueueDB := prefixdb.New([]byte("bs"), chainDB)
+	assert.NoError(prefixdb.New(runnableJobIDsPrefix, emptyQueueDB).Put(key, nil))
+	assert.NoError(prefixdb.New(dependenciesPrefix, emptyQueueDB).Put(key, nil))
+
+	// The vertex job queue still has a job to run.
+	queueDB := prefixdb.New([]byte("vertex_bs"), chainDB)
+	assert.NoError(prefixdb.New(jobsPrefix, queueDB).Put(key, nil))
+	assert.NoError(prefixdb.New(runnableJobIDsPrefix, queueDB).Put(key, nil))
+
+	// The block index of the chain is incomplete.
+	indexerDB := prefixdb.New(indexerDBPrefix, dbManager.Current().Database)
+	isIncompleteKey := make([]byte, hashing.HashLen+1)
+	copy(isIncompleteKey, chainID[:])
+	isIncompleteKey[hashing.HashLen] = isIncompleteIndexPrefix
+	assert.NoError(indexerDB.Put(isIncompleteKey, nil))
+	blockIndexPrefix := make([]byte, hashing.HashLen+1)
+	copy(blockIndexPrefix, chainID[:])
+	blockIndexPrefix[hashing.HashLen] = 0x03
+	blockIndexDB := prefixdb.New(blockIndexPrefix, indexerDB)
+	assert.NoError(blockIndexDB.Put(key, nil))
+
+	report := &Report{}
+	p := &pruner{
+		log:    logging.NoLog{},
+		report: report,
+	}
+	assert.NoError(p.prune(dbManager, []chain{{name: "test", id: chainID}}, false))
+
+	for _, blkID := range []ids.ID{accepted0, accepted1, extendsLastAccepted, unknownParent} {
+		has, err := blockDB.Has(blkID[:])
+		assert.NoError(err)
+		assert.True(has)
+	}
+	for _, blkID := range []ids.ID{conflicting, conflictingChild} {
+		has, err := blockDB.Has(blkID[:])
+		assert.NoError(err)
+		assert.False(has)
+	}
+
+	isEmpty, err := database.IsEmpty(prefixdb.New(runnableJobIDsPrefix, emptyQueueDB))
+	assert.NoError(err)
+	assert.True(isEmpty)
+	isEmpty, err = database.IsEmpty(prefixdb.New(dependenciesPrefix, emptyQueueDB))
+	assert.NoError(err)
+	assert.True(isEmpty)
+	isEmpty, err = database.IsEmpty(prefixdb.New(runnableJobIDsPrefix, queueDB))
+	assert.NoError(err)
+	assert.False(isEmpty)
+
+	has, err := indexerDB.Has(isIncompleteKey)
+	assert.NoError(err)
+	assert.True(has)
+	isEmpty, err = database.IsEmpty(blockIndexDB)
+	assert.NoError(err)
+	assert.True(isEmpty)
+
+	names := make([]string, len(report.Prefixes))
+	for i, prefixReport := range report.Prefixes {
+		names[i] = prefixReport.Name
+	}
+	assert.Equal([]string{
+		"test/vm/proposervm/block",
+		"test/bs/runnable",
+		"test/bs/dependencies",
+		"index/test/block",
+	}, names)
+	assert.Equal(2, report.Prefixes[0].Keys)
+}
+
+func TestPruneKeepsIndicesWhenIndexing(t *testing.T) {
+	assert := assert.New(t)
+
+	dbManager := manager.NewMemDB(version.CurrentDatabase)
+	chainID := ids.GenerateTestID()
+
+	indexerDB := prefixdb.New(indexerDBPrefix, dbManager.Current().Database)
+	isIncompleteKey := make([]byte, hashing.HashLen+1)
+	copy(isIncompleteKey, chainID[:])
+	isIncompleteKey[hashing.HashLen] = isIncompleteIndexPrefix
+	assert.NoError(indexerDB.Put(isIncompleteKey, nil))
+	txIndexPrefix := make([]byte, hashing.HashLen+1)
+	copy(txIndexPrefix, chainID[:])
+	txIndexPrefix[hashing.HashLen] = 0x01
+	txIndexDB := prefixdb.New(txIndexPrefix, indexerDB)
+	assert.NoError(txIndexDB.Put([]byte{1}, nil))
+
+	report := &Report{}
+	p := &pruner{
+		log:    logging.NoLog{},
+		report: report,
+	}
+	assert.NoError(p.prune(dbManager, []chain{{name: "test", id: chainID}}, true))
+
+	isEmpty, err := database.IsEmpty(txIndexDB)
+	assert.NoError(err)
+	assert.False(isEmpty)
+	assert.Empty(report.Prefixes)
+}
@@ -5,6 +5,7 @@ package atomic
 
 import (
 	"bytes"
+	"encoding/binary"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/codec"
@@ -14,10 +15,20 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 const (
 	codecVersion = 0
+
+	// numLockShards is the number of independently locked maps that the
+	// shared memory locks are spread over. Requests for different shared
+	// memory spaces rarely contend on the same shard.
+	numLockShards = 16
+
+	// sharedIDPreimageLen is the length of the serialized pair of chainIDs
+	// that a shared ID is the hash of: codec version + chainID + chainID
+	sharedIDPreimageLen = wrappers.ShortLen + 2*hashing.HashLen
 )
 
 type rcLock struct {
@@ -25,13 +36,17 @@ type rcLock struct {
 	count int
 }
 
-// Memory is the interface for shared memory inside a subnet
-type Memory struct {
+type lockShard struct {
 	lock  sync.Mutex
-	log   logging.Logger
-	codec codec.Manager
 	locks map[ids.ID]*rcLock
-	db    database.Database
+}
+
+// Memory is the interface for shared memory inside a subnet
+type Memory struct {
+	log        logging.Logger
+	codec      codec.Manager
+	lockShards [numLockShards]lockShard
+	db         database.Database
 }
 
 // Initialize the SharedMemory
@@ -44,7 +59,9 @@ func (m *Memory) Initialize(log logging.Logger, db database.Database) error {
 
 	m.log = log
 	m.codec = manager
-	m.locks = make(map[ids.ID]*rcLock)
+	for i := range m.lockShards {
+		m.lockShards[i].locks = make(map[ids.ID]*rcLock)
+	}
 	m.db = db
 	return nil
 }
@@ -72,41 +89,52 @@ func (m *Memory) ReleaseSharedDatabase(sharedID ids.ID) {
 }
 
 func (m *Memory) makeLock(sharedID ids.ID) *sync.Mutex {
-	m.lock.Lock()
-	defer m.lock.Unlock()
+	shard := m.lockShard(sharedID)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
 
-	rc, exists := m.locks[sharedID]
+	rc, exists := shard.locks[sharedID]
 	if !exists {
 		rc = &rcLock{}
-		m.locks[sharedID] = rc
+		shard.locks[sharedID] = rc
 	}
 	rc.count++
 	return &rc.lock
 }
 
 func (m *Memory) releaseLock(sharedID ids.ID) *sync.Mutex {
-	m.lock.Lock()
-	defer m.lock.Unlock()
+	shard := m.lockShard(sharedID)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
 
-	rc, exists := m.locks[sharedID]
+	rc, exists := shard.locks[sharedID]
 	if !exists {
 		panic("Attemping to free an unknown lock")
 	}
 	rc.count--
 	if rc.count == 0 {
-		delete(m.locks, sharedID)
+		delete(shard.locks, sharedID)
 	}
 	return &rc.lock
 }
 
+// lockShard returns the shard that tracks the lock of [sharedID]. Shared IDs
+// are hashes, so the first byte is uniformly distributed.
+func (m *Memory) lockShard(sharedID ids.ID) *lockShard {
+	return &m.lockShards[int(sharedID[0])%numLockShards]
+}
+
 // sharedID calculates the ID of the shared memory space
 func (m *Memory) sharedID(id1, id2 ids.ID) ids.ID {
 	if bytes.Compare(id1[:], id2[:]) == 1 {
 		id1, id2 = id2, id1
 	}
 
-	combinedBytes, err := m.codec.Marshal(codecVersion, [2]ids.ID{id1, id2})
-	m.log.AssertNoError(err)
-
+	// This is equivalent to marshalling [2]ids.ID{id1, id2} with the codec,
+	// but avoids using reflection on every request.
+	combinedBytes := make([]byte, sharedIDPreimageLen)
+	binary.BigEndian.PutUint16(combinedBytes, codecVersion)
+	copy(combinedBytes[wrappers.ShortLen:], id1[:])
+	copy(combinedBytes[wrappers.ShortLen+hashing.HashLen:], id2[:])
 	return hashing.ComputeHash256Array(combinedBytes)
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
@@ -76,3 +77,39 @@ func TestMemoryUnknownFree(t *testing.T) {
 
 	m.releaseLock(sharedID)
 }
+
+func TestMemorySharedIDMatchesCodec(t *testing.T) {
+	m := Memory{}
+	err := m.Initialize(logging.NoLog{}, memdb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	combinedBytes, err := m.codec.Marshal(codecVersion, [2]ids.ID{blockchainID0, blockchainID1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSharedID := hashing.ComputeHash256Array(combinedBytes)
+	if sharedID := m.sharedID(blockchainID1, blockchainID0); sharedID != expectedSharedID {
+		t.Fatalf("SharedMemory.sharedID should have returned %s but returned %s", expectedSharedID, sharedID)
+	}
+}
+
+// BenchmarkMemoryLocks measures locking and releasing the shared databases of
+// disjoint shared memory spaces from many goroutines at once.
+func BenchmarkMemoryLocks(b *testing.B) {
+	m := Memory{}
+	if err := m.Initialize(logging.NoLog{}, memdb.New()); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		sharedID := m.sharedID(ids.GenerateTestID(), ids.GenerateTestID())
+		for pb.Next() {
+			_ = m.GetSharedDatabase(m.db, sharedID)
+			m.ReleaseSharedDatabase(sharedID)
+		}
+	})
+}
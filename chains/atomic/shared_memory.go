@@ -108,6 +108,12 @@ func (sm *sharedMemory) Apply(requests map[ids.ID]*Requests, batches ...database
 	sharedIDs := make([]ids.ID, 0, len(requests))
 	sharedOperations := make(map[ids.ID]*Requests, len(requests))
 	for peerChainID, request := range requests {
+		// Requests without any operations don't need to grab the shared
+		// memory lock of the peer chain
+		if len(request.RemoveRequests) == 0 && len(request.PutRequests) == 0 {
+			continue
+		}
+
 		sharedID := sm.m.sharedID(sm.thisChainID, peerChainID)
 		sharedIDs = append(sharedIDs, sharedID)
 
@@ -116,31 +122,16 @@ func (sm *sharedMemory) Apply(requests map[ids.ID]*Requests, batches ...database
 	}
 	ids.SortIDs(sharedIDs)
 
-	// Make sure all operations are committed atomically
+	// Make sure all operations are committed atomically. All of the
+	// operations across every peer chain are written in a single batch.
 	vdb := versiondb.New(sm.m.db)
 
 	for _, sharedID := range sharedIDs {
-		req := sharedOperations[sharedID]
-
 		db := sm.m.GetSharedDatabase(vdb, sharedID)
 		defer sm.m.ReleaseSharedDatabase(sharedID)
 
-		s := state{
-			c: sm.m.codec,
-		}
-
-		s.valueDB, s.indexDB = inbound.getValueAndIndexDB(sm.thisChainID, req.peerChainID, db)
-		for _, removeRequest := range req.RemoveRequests {
-			if err := s.RemoveValue(removeRequest); err != nil {
-				return err
-			}
-		}
-
-		s.valueDB, s.indexDB = outbound.getValueAndIndexDB(sm.thisChainID, req.peerChainID, db)
-		for _, putRequest := range req.PutRequests {
-			if err := s.SetValue(putRequest); err != nil {
-				return err
-			}
+		if err := sm.apply(db, sharedOperations[sharedID]); err != nil {
+			return err
 		}
 	}
 
@@ -151,3 +142,26 @@ func (sm *sharedMemory) Apply(requests map[ids.ID]*Requests, batches ...database
 
 	return WriteAll(batch, batches...)
 }
+
+// apply writes the removes and puts of [req] into [db], which must be the
+// locked shared database of this chain and [req.peerChainID]
+func (sm *sharedMemory) apply(db database.Database, req *Requests) error {
+	s := state{
+		c: sm.m.codec,
+	}
+
+	s.valueDB, s.indexDB = inbound.getValueAndIndexDB(sm.thisChainID, req.peerChainID, db)
+	for _, removeRequest := range req.RemoveRequests {
+		if err := s.RemoveValue(removeRequest); err != nil {
+			return err
+		}
+	}
+
+	s.valueDB, s.indexDB = outbound.getValueAndIndexDB(sm.thisChainID, req.peerChainID, db)
+	for _, putRequest := range req.PutRequests {
+		if err := s.SetValue(putRequest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,6 +4,8 @@
 package atomic
 
 import (
+	"encoding/binary"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 func TestSharedMemory(t *testing.T) {
@@ -35,3 +38,89 @@ func TestSharedMemory(t *testing.T) {
 		test(t, chainID0, chainID1, sm0, sm1, testDB)
 	}
 }
+
+func TestSharedMemoryConcurrentApply(t *testing.T) {
+	assert := assert.New(t)
+
+	m := Memory{}
+	err := m.Initialize(logging.NoLog{}, memdb.New())
+	assert.NoError(err)
+
+	const numChains = 8
+	chainIDs := make([]ids.ID, numChains)
+	sms := make([]SharedMemory, numChains)
+	for i := range chainIDs {
+		chainIDs[i] = ids.GenerateTestID()
+		sms[i] = m.NewSharedMemory(chainIDs[i])
+	}
+
+	// Every chain concurrently exports a value to every other chain
+	errs := make(chan error, numChains)
+	for i, sm := range sms {
+		requests := make(map[ids.ID]*Requests, numChains-1)
+		for j, peerChainID := range chainIDs {
+			if i == j {
+				continue
+			}
+			requests[peerChainID] = &Requests{PutRequests: []*Element{{
+				Key:   []byte{byte(j)},
+				Value: []byte{byte(i)},
+			}}}
+		}
+
+		go func(sm SharedMemory) {
+			errs <- sm.Apply(requests)
+		}(sm)
+	}
+	for range sms {
+		assert.NoError(<-errs)
+	}
+
+	for i, sm := range sms {
+		for j, peerChainID := range chainIDs {
+			if i == j {
+				continue
+			}
+			values, err := sm.Get(peerChainID, [][]byte{{byte(i)}})
+			assert.NoError(err)
+			assert.Equal([][]byte{{byte(j)}}, values)
+		}
+	}
+}
+
+// BenchmarkSharedMemoryApply measures applying requests from many chains at
+// once, each exporting to its own peer chain, so that the shared memory spaces
+// are disjoint and only contend on the locks of the shared memory itself.
+func BenchmarkSharedMemoryApply(b *testing.B) {
+	m := Memory{}
+	if err := m.Initialize(logging.NoLog{}, memdb.New()); err != nil {
+		b.Fatal(err)
+	}
+
+	var (
+		lock    sync.Mutex
+		counter uint64
+	)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		sm := m.NewSharedMemory(ids.GenerateTestID())
+		peerChainID := ids.GenerateTestID()
+		for pb.Next() {
+			lock.Lock()
+			counter++
+			key := make([]byte, wrappers.LongLen)
+			binary.BigEndian.PutUint64(key, counter)
+			lock.Unlock()
+
+			err := sm.Apply(map[ids.ID]*Requests{peerChainID: {
+				PutRequests: []*Element{{
+					Key:   key,
+					Value: key,
+				}},
+			}})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
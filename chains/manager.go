@@ -65,15 +65,22 @@ var (
 	errCreatePlatformVM = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped  = errors.New("chains not bootstrapped")
 
+	errAllSubnetsTracked         = errors.New("all subnets are tracked when staking is disabled")
+	errPrimaryNetworkTracked     = errors.New("the primary network is always tracked")
+	errSubnetAlreadyTracked      = errors.New("subnet is already tracked")
+	errSubnetNotTracked          = errors.New("subnet isn't tracked")
+	errSubnetPreviouslyUntracked = errors.New("subnet was untracked since the node started; restart the node to track it again")
+	errNoTrackedSubnetsHandler   = errors.New("the platform chain hasn't been created yet")
+
 	_ Manager = &manager{}
 )
 
 // Manager manages the chains running on this node.
 // It can:
-//   * Create a chain
-//   * Add a registrant. When a chain is created, each registrant calls
+//   - Create a chain
+//   - Add a registrant. When a chain is created, each registrant calls
 //     RegisterChain with the new chain as the argument.
-//   * Manage the aliases of chains
+//   - Manage the aliases of chains
 type Manager interface {
 	ids.Aliaser
 
@@ -102,9 +109,40 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Set the handler that is notified when a subnet is tracked or untracked
+	// while the node is running.
+	SetTrackedSubnetsHandler(TrackedSubnetsHandler)
+
+	// Returns true iff this node runs the chains of the given subnet
+	TracksSubnet(subnetID ids.ID) bool
+
+	// Start running the chains of the given subnet
+	TrackSubnet(subnetID ids.ID) error
+
+	// Stop running the chains of the given subnet
+	UntrackSubnet(subnetID ids.ID) error
+
+	// Replace the config of the chain with the given alias and deliver it to
+	// the chain's VM if the chain is running
+	UpdateChainConfig(alias string, configBytes []byte) error
+
 	Shutdown()
 }
 
+// TrackedSubnetsHandler is notified when the set of subnets this node tracks
+// changes while the node is running. It is implemented by the P-chain, which
+// maintains the validator sets of the tracked subnets and creates their chains.
+type TrackedSubnetsHandler interface {
+	// TrackSubnet is called after [subnetID] is added to the tracked subnets.
+	// The handler is expected to create the chains of the subnet.
+	TrackSubnet(subnetID ids.ID) error
+
+	// UntrackSubnet is called after [subnetID] is removed from the tracked
+	// subnets. The chains of the subnet are shut down by the chain manager
+	// once this returns.
+	UntrackSubnet(subnetID ids.ID) error
+}
+
 // ChainParameters defines the chain being created
 type ChainParameters struct {
 	// The ID of the chain being created.
@@ -126,6 +164,9 @@ type chain struct {
 	Engine  common.Engine
 	Handler handler.Handler
 	Beacons validators.Set
+
+	// The VM of the chain, before it is wrapped by the chain manager
+	VM interface{}
 }
 
 // ChainConfig is configuration settings for the current execution.
@@ -169,6 +210,9 @@ type ManagerConfig struct {
 	ShutdownNodeFunc func(exitCode int)
 	MeterVMEnabled   bool // Should each VM be wrapped with a MeterVM
 	Metrics          metrics.MultiGatherer
+	// Bucket boundaries, in nanoseconds, of each chain's consensus latency
+	// histograms. If empty, only average latencies are reported.
+	ConsensusLatencyBuckets []float64
 
 	ConsensusGossipFrequency time.Duration
 
@@ -190,6 +234,10 @@ type ManagerConfig struct {
 	ResourceTracker timetracker.ResourceTracker
 
 	StateSyncBeacons []ids.NodeID
+
+	// True iff the node didn't shut down cleanly the last time it ran. If so,
+	// the bootstrapping queues of each chain are repaired before it's started.
+	UncleanShutdown bool
 }
 
 type manager struct {
@@ -212,19 +260,50 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]handler.Handler
+	// Key: Chain's ID
+	// Value: The VM of the chain, before it is wrapped by the chain manager
+	vms map[ids.ID]interface{}
+
+	// Guards ManagerConfig.ChainConfigs, which is updated while the node is
+	// running
+	chainConfigsLock sync.RWMutex
 
 	// snowman++ related interface to allow validators retrival
 	validatorState validators.State
+
+	// Serializes changes to the set of tracked subnets
+	trackingLock          sync.Mutex
+	trackedSubnetsHandler TrackedSubnetsHandler
+
+	trackedSubnetsLock sync.RWMutex
+	// Subnets, other than the primary network, whose chains are run by this
+	// node. Only used when staking is enabled.
+	trackedSubnets ids.Set
+	// Subnets that were untracked while the node was running. The API routes
+	// and metrics of their chains can't be removed, so these subnets can't be
+	// tracked again until the node is restarted.
+	untrackedSubnets ids.Set
 }
 
 // New returns a new Manager
 func New(config *ManagerConfig) Manager {
-	return &manager{
+	m := &manager{
 		Aliaser:       ids.NewAliaser(),
 		ManagerConfig: *config,
 		subnets:       make(map[ids.ID]Subnet),
 		chains:        make(map[ids.ID]handler.Handler),
-	}
+		vms:           make(map[ids.ID]interface{}),
+	}
+	// Copy the chain configs so that updating them at runtime doesn't modify
+	// the provided config.
+	m.ChainConfigs = make(map[string]ChainConfig, len(config.ChainConfigs))
+	for alias, chainConfig := range config.ChainConfigs {
+		m.ChainConfigs[alias] = chainConfig
+	}
+	// Copy the whitelisted subnets so that tracking subnets at runtime doesn't
+	// modify the provided config.
+	m.trackedSubnets.Union(config.WhitelistedSubnets)
+	return m
 }
 
 // Router that this chain manager is using to route consensus messages to chains
@@ -242,7 +321,7 @@ func (m *manager) CreateChain(chain ChainParameters) {
 // Create a chain, this is only called from the P-chain thread, except for
 // creating the P-chain.
 func (m *manager) ForceCreateChain(chainParams ChainParameters) {
-	if m.StakingEnabled && chainParams.SubnetID != constants.PrimaryNetworkID && !m.WhitelistedSubnets.Contains(chainParams.SubnetID) {
+	if !m.TracksSubnet(chainParams.SubnetID) {
 		m.Log.Debug("Skipped creating non-whitelisted chain:\n"+
 			"    ID: %s\n"+
 			"    VMID:%s",
@@ -294,6 +373,7 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
+	m.vms[chainParams.ID] = chain.VM
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -378,6 +458,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 		DecisionAcceptor:  m.DecisionAcceptorGroup,
 		ConsensusAcceptor: m.ConsensusAcceptorGroup,
 		Registerer:        consensusMetrics,
+		LatencyBuckets:    m.ConsensusLatencyBuckets,
 	}
 	// We set the state to Initializing here because failing to set the state
 	// before it's first access would cause a panic.
@@ -487,6 +568,8 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 		return nil, errUnknownVMType
 	}
 
+	chain.VM = vm
+
 	// Register the chain with the timeout manager
 	if err := m.TimeoutManager.RegisterChain(ctx); err != nil {
 		return nil, err
@@ -541,6 +624,14 @@ func (m *manager) createAvalancheChain(
 	if err != nil {
 		return nil, err
 	}
+	if m.UncleanShutdown {
+		if err := vtxBlocker.Repair(ctx.Log); err != nil {
+			return nil, fmt.Errorf("couldn't repair vertex queue: %w", err)
+		}
+		if err := txBlocker.Repair(ctx.Log); err != nil {
+			return nil, fmt.Errorf("couldn't repair tx queue: %w", err)
+		}
+	}
 
 	// The channel through which a VM may send messages to the consensus engine
 	// VM uses this channel to notify engine that a block is ready to be made
@@ -727,6 +818,11 @@ func (m *manager) createSnowmanChain(
 	if err != nil {
 		return nil, err
 	}
+	if m.UncleanShutdown {
+		if err := blocked.Repair(ctx.Log); err != nil {
+			return nil, fmt.Errorf("couldn't repair block queue: %w", err)
+		}
+	}
 
 	// The channel through which a VM may send messages to the consensus engine
 	// VM uses this channel to notify engine that a block is ready to be made
@@ -970,6 +1066,178 @@ func (m *manager) registerBootstrappedHealthChecks() error {
 	return nil
 }
 
+func (m *manager) SetTrackedSubnetsHandler(handler TrackedSubnetsHandler) {
+	m.trackingLock.Lock()
+	defer m.trackingLock.Unlock()
+
+	m.trackedSubnetsHandler = handler
+}
+
+func (m *manager) TracksSubnet(subnetID ids.ID) bool {
+	if !m.StakingEnabled || subnetID == constants.PrimaryNetworkID {
+		return true
+	}
+
+	m.trackedSubnetsLock.RLock()
+	defer m.trackedSubnetsLock.RUnlock()
+
+	return m.trackedSubnets.Contains(subnetID)
+}
+
+// TrackSubnet starts tracking [subnetID]. The P-chain is notified so that it
+// can create the chains of the subnet, and the network reconnects to the
+// validators of the subnet so that they learn this node now tracks it.
+func (m *manager) TrackSubnet(subnetID ids.ID) error {
+	m.trackingLock.Lock()
+	defer m.trackingLock.Unlock()
+
+	switch {
+	case !m.StakingEnabled:
+		return errAllSubnetsTracked
+	case subnetID == constants.PrimaryNetworkID:
+		return errPrimaryNetworkTracked
+	case m.TracksSubnet(subnetID):
+		return fmt.Errorf("%w: %s", errSubnetAlreadyTracked, subnetID)
+	case m.trackedSubnetsHandler == nil:
+		return errNoTrackedSubnetsHandler
+	}
+
+	m.trackedSubnetsLock.Lock()
+	if m.untrackedSubnets.Contains(subnetID) {
+		m.trackedSubnetsLock.Unlock()
+		return fmt.Errorf("%w: %s", errSubnetPreviouslyUntracked, subnetID)
+	}
+	m.trackedSubnets.Add(subnetID)
+	m.trackedSubnetsLock.Unlock()
+
+	m.Log.Info("tracking subnet %s", subnetID)
+
+	m.ManagerConfig.Router.TrackSubnet(subnetID)
+	if err := m.trackedSubnetsHandler.TrackSubnet(subnetID); err != nil {
+		m.ManagerConfig.Router.UntrackSubnet(subnetID)
+
+		m.trackedSubnetsLock.Lock()
+		m.trackedSubnets.Remove(subnetID)
+		m.trackedSubnetsLock.Unlock()
+		return fmt.Errorf("couldn't track subnet %s: %w", subnetID, err)
+	}
+	m.Net.TrackSubnet(subnetID)
+	return nil
+}
+
+// UntrackSubnet stops tracking [subnetID] and shuts down the chains of the
+// subnet.
+func (m *manager) UntrackSubnet(subnetID ids.ID) error {
+	m.trackingLock.Lock()
+	defer m.trackingLock.Unlock()
+
+	switch {
+	case !m.StakingEnabled:
+		return errAllSubnetsTracked
+	case subnetID == constants.PrimaryNetworkID:
+		return errPrimaryNetworkTracked
+	case !m.TracksSubnet(subnetID):
+		return fmt.Errorf("%w: %s", errSubnetNotTracked, subnetID)
+	}
+
+	m.Log.Info("untracking subnet %s", subnetID)
+
+	m.trackedSubnetsLock.Lock()
+	m.trackedSubnets.Remove(subnetID)
+	m.untrackedSubnets.Add(subnetID)
+	m.trackedSubnetsLock.Unlock()
+
+	m.ManagerConfig.Router.UntrackSubnet(subnetID)
+	if m.trackedSubnetsHandler != nil {
+		if err := m.trackedSubnetsHandler.UntrackSubnet(subnetID); err != nil {
+			m.Log.Warn("failed to untrack subnet %s on the platform chain: %s", subnetID, err)
+		}
+	}
+
+	m.chainsLock.Lock()
+	chains := []handler.Handler(nil)
+	for chainID, chain := range m.chains {
+		if chain.Context().SubnetID == subnetID {
+			chains = append(chains, chain)
+			delete(m.chains, chainID)
+			delete(m.vms, chainID)
+		}
+	}
+	m.chainsLock.Unlock()
+
+	for _, chain := range chains {
+		chainID := chain.Context().ChainID
+		chainAlias := m.PrimaryAliasOrDefault(chainID)
+		m.Log.Info("shutting down chain %s", chainAlias)
+
+		// Stopping the handler removes the chain from the router.
+		chain.Stop()
+
+		if err := m.Health.DeregisterHealthCheck(chainAlias); err != nil {
+			m.Log.Warn("couldn't deregister health check of chain %s: %s", chainAlias, err)
+		}
+		if err := m.ConsensusAcceptorGroup.DeregisterAcceptor(chainID, "gossip"); err != nil {
+			m.Log.Warn("couldn't deregister gossip acceptor of chain %s: %s", chainAlias, err)
+		}
+		m.RemoveAliases(chainID)
+	}
+
+	m.Net.UntrackSubnet(subnetID)
+	return nil
+}
+
+// UpdateChainConfig replaces the config of the chain with alias [alias]. If the
+// chain is running, the new config is delivered to its VM, which must implement
+// common.ConfigUpdater, and nothing is replaced if the VM rejects it. The new
+// config is used if the chain is created later, but it isn't persisted when
+// the node restarts.
+func (m *manager) UpdateChainConfig(alias string, configBytes []byte) error {
+	chainID, err := m.Lookup(alias)
+	key := alias
+	if err == nil {
+		// The config is stored under the chain's ID, which takes precedence
+		// over its aliases when the chain is created.
+		key = chainID.String()
+
+		m.chainsLock.Lock()
+		chain, running := m.chains[chainID]
+		vm := m.vms[chainID]
+		m.chainsLock.Unlock()
+
+		if running {
+			updater, ok := vm.(common.ConfigUpdater)
+			if !ok {
+				return fmt.Errorf("%w: can't update config of chain %s", common.ErrConfigUpdaterNotImplemented, alias)
+			}
+
+			ctx := chain.Context()
+			ctx.Lock.Lock()
+			err := updater.UpdateConfig(configBytes)
+			ctx.Lock.Unlock()
+			if err != nil {
+				return fmt.Errorf("couldn't update config of chain %s: %w", alias, err)
+			}
+			m.Log.Info("updated config of chain %s", alias)
+		}
+	}
+
+	m.chainConfigsLock.Lock()
+	defer m.chainConfigsLock.Unlock()
+
+	chainConfig, ok := m.ChainConfigs[key]
+	if !ok && err == nil {
+		// Keep the upgrade bytes that were provided under one of the chain's
+		// aliases.
+		chainConfig, err = m.lockedChainConfig(chainID)
+		if err != nil {
+			return err
+		}
+	}
+	chainConfig.Config = configBytes
+	m.ChainConfigs[key] = chainConfig
+	return nil
+}
+
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
 	m.Log.Info("shutting down chain manager")
@@ -1002,6 +1270,14 @@ func (m *manager) isChainWithAlias(aliases ...string) (string, bool) {
 // getChainConfig returns value of a entry by looking at ID key and alias key
 // it first searches ID key, then falls back to it's corresponding primary alias
 func (m *manager) getChainConfig(id ids.ID) (ChainConfig, error) {
+	m.chainConfigsLock.RLock()
+	defer m.chainConfigsLock.RUnlock()
+
+	return m.lockedChainConfig(id)
+}
+
+// lockedChainConfig is getChainConfig, assuming [chainConfigsLock] is held.
+func (m *manager) lockedChainConfig(id ids.ID) (ChainConfig, error) {
 	if val, ok := m.ManagerConfig.ChainConfigs[id.String()]; ok {
 		return val, nil
 	}
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestTrackSubnetInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	trackedSubnetID := ids.GenerateTestID()
+	untrackedSubnetID := ids.GenerateTestID()
+	whitelistedSubnets := ids.Set{}
+	whitelistedSubnets.Add(trackedSubnetID)
+
+	m := New(&ManagerConfig{
+		StakingEnabled:     true,
+		Log:                logging.NoLog{},
+		WhitelistedSubnets: whitelistedSubnets,
+	})
+	assert.True(m.TracksSubnet(constants.PrimaryNetworkID))
+	assert.True(m.TracksSubnet(trackedSubnetID))
+	assert.False(m.TracksSubnet(untrackedSubnetID))
+
+	assert.ErrorIs(m.TrackSubnet(constants.PrimaryNetworkID), errPrimaryNetworkTracked)
+	assert.ErrorIs(m.TrackSubnet(trackedSubnetID), errSubnetAlreadyTracked)
+	assert.ErrorIs(m.TrackSubnet(untrackedSubnetID), errNoTrackedSubnetsHandler)
+	assert.False(m.TracksSubnet(untrackedSubnetID))
+
+	assert.ErrorIs(m.UntrackSubnet(constants.PrimaryNetworkID), errPrimaryNetworkTracked)
+	assert.ErrorIs(m.UntrackSubnet(untrackedSubnetID), errSubnetNotTracked)
+
+	// Tracking subnets at runtime must not modify the provided config
+	assert.Equal(1, whitelistedSubnets.Len())
+}
+
+func TestTrackSubnetStakingDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	m := New(&ManagerConfig{
+		Log: logging.NoLog{},
+	})
+	subnetID := ids.GenerateTestID()
+	assert.True(m.TracksSubnet(subnetID))
+	assert.ErrorIs(m.TrackSubnet(subnetID), errAllSubnetsTracked)
+	assert.ErrorIs(m.UntrackSubnet(subnetID), errAllSubnetsTracked)
+}
+
+type testHandler struct {
+	handler.Handler
+	ctx *snow.ConsensusContext
+}
+
+func (h *testHandler) Context() *snow.ConsensusContext { return h.ctx }
+
+type testConfigUpdater struct {
+	configBytes []byte
+	err         error
+}
+
+func (u *testConfigUpdater) UpdateConfig(configBytes []byte) error {
+	if u.err == nil {
+		u.configBytes = configBytes
+	}
+	return u.err
+}
+
+func TestUpdateChainConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	chainID := ids.GenerateTestID()
+	chainConfigs := map[string]ChainConfig{
+		"C": {
+			Config:  []byte("config"),
+			Upgrade: []byte("upgrade"),
+		},
+	}
+	m := New(&ManagerConfig{
+		Log:          logging.NoLog{},
+		ChainConfigs: chainConfigs,
+	}).(*manager)
+	assert.NoError(m.Alias(chainID, chainID.String()))
+	assert.NoError(m.Alias(chainID, "C"))
+
+	// The chain isn't running, so the config is only stored
+	assert.NoError(m.UpdateChainConfig("C", []byte("config2")))
+	chainConfig, err := m.getChainConfig(chainID)
+	assert.NoError(err)
+	assert.Equal([]byte("config2"), chainConfig.Config)
+	assert.Equal([]byte("upgrade"), chainConfig.Upgrade)
+
+	// Updating configs at runtime must not modify the provided config
+	assert.Equal([]byte("config"), chainConfigs["C"].Config)
+
+	ctx := snow.DefaultConsensusContextTest()
+	m.chains[chainID] = &testHandler{ctx: ctx}
+	m.vms[chainID] = &common.TestVM{}
+	err = m.UpdateChainConfig(chainID.String(), []byte("config3"))
+	assert.ErrorIs(err, common.ErrConfigUpdaterNotImplemented)
+
+	vm := &testConfigUpdater{}
+	m.vms[chainID] = vm
+	assert.NoError(m.UpdateChainConfig("C", []byte("config3")))
+	assert.Equal([]byte("config3"), vm.configBytes)
+	chainConfig, err = m.getChainConfig(chainID)
+	assert.NoError(err)
+	assert.Equal([]byte("config3"), chainConfig.Config)
+
+	// A config that the VM rejects isn't stored
+	vm.err = errors.New("invalid config")
+	err = m.UpdateChainConfig("C", []byte("config4"))
+	assert.ErrorIs(err, vm.err)
+	assert.Equal([]byte("config3"), vm.configBytes)
+	chainConfig, err = m.getChainConfig(chainID)
+	assert.NoError(err)
+	assert.Equal([]byte("config3"), chainConfig.Config)
+
+	// Configs of unknown chains are stored under the provided alias
+	assert.NoError(m.UpdateChainConfig("D", []byte("config")))
+	assert.Equal([]byte("config"), m.ChainConfigs["D"].Config)
+}
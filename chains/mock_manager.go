@@ -22,11 +22,19 @@ func (mm MockManager) Aliases(ids.ID) ([]string, error)    { return nil, nil }
 func (mm MockManager) PrimaryAlias(ids.ID) (string, error) { return "", nil }
 func (mm MockManager) PrimaryAliasOrDefault(ids.ID) string { return "" }
 func (mm MockManager) Alias(ids.ID, string) error          { return nil }
+func (mm MockManager) RemoveAlias(string) error            { return nil }
 func (mm MockManager) RemoveAliases(ids.ID)                {}
 func (mm MockManager) Shutdown()                           {}
 func (mm MockManager) SubnetID(ids.ID) (ids.ID, error)     { return ids.ID{}, nil }
 func (mm MockManager) IsBootstrapped(ids.ID) bool          { return false }
 
+func (mm MockManager) SetTrackedSubnetsHandler(TrackedSubnetsHandler) {}
+func (mm MockManager) TracksSubnet(ids.ID) bool                       { return false }
+func (mm MockManager) TrackSubnet(ids.ID) error                       { return nil }
+func (mm MockManager) UntrackSubnet(ids.ID) error                     { return nil }
+
+func (mm MockManager) UpdateChainConfig(string, []byte) error { return nil }
+
 func (mm MockManager) Lookup(s string) (ids.ID, error) {
 	id, err := ids.FromString(s)
 	if err == nil {
@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codecfuzz
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/reflectcodec"
+)
+
+var (
+	errNotIntrospectable = errors.New("codec doesn't expose its registered types")
+	errMismatchedBytes   = errors.New("re-marshalled bytes differ from the original bytes")
+)
+
+// RegisteredTypes returns the types registered with the codec of [m] that is
+// associated with [version].
+func RegisteredTypes(m codec.Manager, version uint16) ([]reflect.Type, error) {
+	c, exists := m.Codec(version)
+	if !exists {
+		return nil, fmt.Errorf("unknown codec version %d", version)
+	}
+	introspector, ok := c.(codec.Introspector)
+	if !ok {
+		return nil, fmt.Errorf("%w: version %d", errNotIntrospectable, version)
+	}
+	return introspector.RegisteredTypes(), nil
+}
+
+// RoundTrip marshals [value] with [version] of [m], unmarshals the result into
+// a new value of the same type and verifies that marshalling the new value
+// produces the same bytes.
+func RoundTrip(m codec.Manager, version uint16, value interface{}) error {
+	originalBytes, err := m.Marshal(version, value)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal %T: %w", value, err)
+	}
+
+	parsed := reflect.New(reflect.TypeOf(value))
+	parsedVersion, err := m.Unmarshal(originalBytes, parsed.Interface())
+	if err != nil {
+		return fmt.Errorf("couldn't unmarshal %T: %w", value, err)
+	}
+	if parsedVersion != version {
+		return fmt.Errorf("unmarshalled %T with version %d but expected %d", value, parsedVersion, version)
+	}
+
+	parsedBytes, err := m.Marshal(version, parsed.Elem().Interface())
+	if err != nil {
+		return fmt.Errorf("couldn't re-marshal %T: %w", value, err)
+	}
+	if !bytes.Equal(originalBytes, parsedBytes) {
+		return fmt.Errorf("%w for %T: 0x%x != 0x%x", errMismatchedBytes, value, originalBytes, parsedBytes)
+	}
+	return nil
+}
+
+// Check generates a random instance of every type registered with every
+// version of [m], along with any [extraTypes], and verifies that each instance
+// round-trips. [seed] determines the generated instances.
+func Check(m codec.Manager, seed int64, extraTypes ...reflect.Type) error {
+	rng := rand.New(rand.NewSource(seed)) // #nosec G404
+	for _, version := range m.Versions() {
+		types, err := RegisteredTypes(m, version)
+		if err != nil {
+			return err
+		}
+
+		generator := NewGenerator(rng, []string{reflectcodec.DefaultTagName}, types)
+		for _, t := range append(types, extraTypes...) {
+			value, err := generator.Generate(t)
+			if err != nil {
+				return fmt.Errorf("couldn't generate %s for version %d: %w", t, version, err)
+			}
+			if err := RoundTrip(m, version, value.Interface()); err != nil {
+				return fmt.Errorf("version %d: %w", version, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Fuzz registers a fuzz target that checks that random instances of every type
+// registered with [m], along with any [extraTypes], round-trip. When run as a
+// regular test, the seed corpus is checked.
+func Fuzz(f *testing.F, m codec.Manager, extraTypes ...reflect.Type) {
+	for seed := int64(0); seed < 16; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		if err := Check(m, seed, extraTypes...); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
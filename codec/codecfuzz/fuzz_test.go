@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codecfuzz
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/codec/reflectcodec"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type container struct {
+	Foo     codec.Foo            `serialize:"true"`
+	Foos    []codec.Foo          `serialize:"true"`
+	Bytes   []byte               `serialize:"true" len:"1"`
+	Ptr     *codec.MyInnerStruct `serialize:"true"`
+	Array   [2]uint32            `serialize:"true"`
+	Number  int16                `serialize:"true"`
+	Ignored int
+}
+
+type mapContainer struct {
+	Map map[string][]uint64 `serialize:"true"`
+}
+
+func newManager(t *testing.T, c linearcodec.Codec) codec.Manager {
+	assert := assert.New(t)
+
+	assert.NoError(c.RegisterType(&codec.MyInnerStruct{}))
+	assert.NoError(c.RegisterType(&codec.MyInnerStruct2{}))
+	assert.NoError(c.RegisterType(&container{}))
+
+	m := codec.NewDefaultManager()
+	assert.NoError(m.RegisterCodec(0, c))
+	return m
+}
+
+func TestGenerate(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newManager(t, linearcodec.NewDefault())
+	types, err := RegisteredTypes(m, 0)
+	assert.NoError(err)
+
+	g := NewGenerator(rand.New(rand.NewSource(0)), []string{reflectcodec.DefaultTagName}, types) // #nosec G404
+	for i := 0; i < 100; i++ {
+		value, err := g.Generate(reflect.TypeOf(container{}))
+		assert.NoError(err)
+
+		c := value.Interface().(container)
+		assert.NotNil(c.Foo)
+		assert.NotNil(c.Ptr)
+		assert.LessOrEqual(len(c.Foos), DefaultMaxLen)
+		assert.LessOrEqual(len(c.Bytes), 1)
+		assert.Zero(c.Ignored)
+	}
+}
+
+func TestGenerateNoImplementation(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewGenerator(rand.New(rand.NewSource(0)), []string{reflectcodec.DefaultTagName}, nil) // #nosec G404
+	_, err := g.Generate(reflect.TypeOf(container{}))
+	assert.ErrorIs(err, errNoImplementation)
+}
+
+func TestGenerateUnsupportedKind(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewGenerator(rand.New(rand.NewSource(0)), []string{reflectcodec.DefaultTagName}, nil) // #nosec G404
+	_, err := g.Generate(reflect.TypeOf(float64(0)))
+	assert.ErrorIs(err, errUnsupportedKind)
+}
+
+func TestCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newManager(t, linearcodec.NewDefault())
+	for seed := int64(0); seed < 100; seed++ {
+		assert.NoError(Check(m, seed))
+	}
+}
+
+func TestCheckVarint(t *testing.T) {
+	assert := assert.New(t)
+
+	m := newManager(t, linearcodec.NewDefaultVarint())
+	for seed := int64(0); seed < 100; seed++ {
+		assert.NoError(Check(m, seed, reflect.TypeOf(mapContainer{})))
+	}
+}
+
+func FuzzLinearCodec(f *testing.F) {
+	c := linearcodec.NewDefault()
+	m := codec.NewDefaultManager()
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&codec.MyInnerStruct{}),
+		c.RegisterType(&codec.MyInnerStruct2{}),
+		c.RegisterType(&container{}),
+		m.RegisterCodec(0, c),
+	)
+	if errs.Errored() {
+		f.Fatal(errs.Err)
+	}
+
+	Fuzz(f, m)
+}
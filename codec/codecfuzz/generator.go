@@ -0,0 +1,189 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codecfuzz
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+
+	"github.com/ava-labs/avalanchego/codec/reflectcodec"
+)
+
+const (
+	// DefaultMaxLen is the default maximum number of elements in a generated
+	// slice or map.
+	DefaultMaxLen = 3
+
+	// DefaultMaxDepth is the default depth after which generated slices and
+	// maps are left empty.
+	DefaultMaxDepth = 16
+
+	// maxStrLen is the maximum number of bytes in a generated string.
+	maxStrLen = 16
+)
+
+var (
+	errMaxDepth         = errors.New("exceeded maximum depth")
+	errNoImplementation = errors.New("no registered type implements interface")
+	errUnsupportedKind  = errors.New("unsupported kind")
+)
+
+// Generator creates random values that can be serialized by a reflection based
+// codec.
+type Generator struct {
+	rng      *rand.Rand
+	fielder  reflectcodec.StructFielder
+	types    []reflect.Type
+	maxLen   uint32
+	maxDepth int
+}
+
+// NewGenerator returns a new generator that populates the fields tagged with
+// one of [tagNames]. Interfaces are populated with one of [types], which should
+// be the types registered with the codec.
+func NewGenerator(rng *rand.Rand, tagNames []string, types []reflect.Type) *Generator {
+	return &Generator{
+		rng:      rng,
+		fielder:  reflectcodec.NewStructFielder(tagNames, DefaultMaxLen),
+		types:    types,
+		maxLen:   DefaultMaxLen,
+		maxDepth: DefaultMaxDepth,
+	}
+}
+
+// Generate returns a random value of type [t].
+//
+// Pointers and interfaces are always populated, as the codec can't serialize
+// nil values. Slices, maps and strings are kept short so that the serialized
+// value stays well below the codec's size limits.
+func (g *Generator) Generate(t reflect.Type) (reflect.Value, error) {
+	value := reflect.New(t).Elem()
+	return value, g.generate(value, g.maxLen, 0)
+}
+
+func (g *Generator) generate(value reflect.Value, maxLen uint32, depth int) error {
+	// Interfaces may be populated with types that contain the same interface,
+	// so the depth must be bounded to guarantee termination.
+	if depth > 2*g.maxDepth {
+		return errMaxDepth
+	}
+
+	switch value.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// SetUint truncates the value to the size of the field
+		value.SetUint(g.rng.Uint64())
+		return nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(int64(g.rng.Uint64()))
+		return nil
+	case reflect.Bool:
+		value.SetBool(g.rng.Intn(2) == 1)
+		return nil
+	case reflect.String:
+		str := make([]byte, g.rng.Intn(maxStrLen+1))
+		_, _ = g.rng.Read(str)
+		value.SetString(string(str))
+		return nil
+	case reflect.Slice:
+		numElts := g.length(maxLen, depth)
+		slice := reflect.MakeSlice(value.Type(), numElts, numElts)
+		for i := 0; i < numElts; i++ {
+			if err := g.generate(slice.Index(i), g.maxLen, depth+1); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+		return nil
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := g.generate(value.Index(i), g.maxLen, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		mapType := value.Type()
+		numElts := g.length(maxLen, depth)
+		m := reflect.MakeMapWithSize(mapType, numElts)
+		for i := 0; i < numElts; i++ {
+			key := reflect.New(mapType.Key()).Elem()
+			if err := g.generate(key, g.maxLen, depth+1); err != nil {
+				return err
+			}
+			elem := reflect.New(mapType.Elem()).Elem()
+			if err := g.generate(elem, g.maxLen, depth+1); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, elem)
+		}
+		value.Set(m)
+		return nil
+	case reflect.Struct:
+		serializedFields, err := g.fielder.GetSerializedFields(value.Type())
+		if err != nil {
+			return err
+		}
+		for _, fieldDesc := range serializedFields {
+			if err := g.generate(value.Field(fieldDesc.Index), fieldDesc.MaxSliceLen, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Ptr:
+		ptr := reflect.New(value.Type().Elem())
+		if err := g.generate(ptr.Elem(), maxLen, depth+1); err != nil {
+			return err
+		}
+		value.Set(ptr)
+		return nil
+	case reflect.Interface:
+		return g.generateInterface(value, depth)
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedKind, value.Kind())
+	}
+}
+
+// generateInterface populates [value] with a random registered type that
+// implements its interface. If populating a type fails, for example because
+// it recursively contains the interface, the remaining types are tried.
+func (g *Generator) generateInterface(value reflect.Value, depth int) error {
+	interfaceType := value.Type()
+	var implementations []reflect.Type
+	for _, t := range g.types {
+		if t.Implements(interfaceType) {
+			implementations = append(implementations, t)
+		}
+	}
+	if len(implementations) == 0 {
+		return fmt.Errorf("%w %s", errNoImplementation, interfaceType)
+	}
+
+	g.rng.Shuffle(len(implementations), func(i, j int) {
+		implementations[i], implementations[j] = implementations[j], implementations[i]
+	})
+
+	var err error
+	for _, t := range implementations {
+		implementation := reflect.New(t).Elem()
+		if err = g.generate(implementation, g.maxLen, depth+1); err == nil {
+			value.Set(implementation)
+			return nil
+		}
+	}
+	return err
+}
+
+// length returns a random length for a slice or map that may contain at most
+// [maxLen] elements. Past [g.maxDepth], the length is always 0.
+func (g *Generator) length(maxLen uint32, depth int) int {
+	if depth >= g.maxDepth {
+		return 0
+	}
+	if maxLen > g.maxLen {
+		maxLen = g.maxLen
+	}
+	return g.rng.Intn(int(maxLen) + 1)
+}
@@ -6,6 +6,7 @@ package hierarchycodec
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/codec"
@@ -23,12 +24,14 @@ var (
 	_ codec.Codec        = &hierarchyCodec{}
 	_ codec.Registry     = &hierarchyCodec{}
 	_ codec.GeneralCodec = &hierarchyCodec{}
+	_ codec.Introspector = &hierarchyCodec{}
 )
 
 // Codec marshals and unmarshals
 type Codec interface {
 	codec.Registry
 	codec.Codec
+	codec.Introspector
 	SkipRegistrations(int)
 	NextGroup()
 }
@@ -101,6 +104,28 @@ func (c *hierarchyCodec) RegisterType(val interface{}) error {
 	return nil
 }
 
+func (c *hierarchyCodec) RegisteredTypes() []reflect.Type {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	typeIDs := make([]typeID, 0, len(c.typeIDToType))
+	for typeID := range c.typeIDToType {
+		typeIDs = append(typeIDs, typeID)
+	}
+	sort.Slice(typeIDs, func(i, j int) bool {
+		if typeIDs[i].groupID != typeIDs[j].groupID {
+			return typeIDs[i].groupID < typeIDs[j].groupID
+		}
+		return typeIDs[i].typeID < typeIDs[j].typeID
+	})
+
+	types := make([]reflect.Type, len(typeIDs))
+	for i, typeID := range typeIDs {
+		types[i] = c.typeIDToType[typeID]
+	}
+	return types
+}
+
 func (c *hierarchyCodec) PackPrefix(p *wrappers.Packer, valueType reflect.Type) error {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
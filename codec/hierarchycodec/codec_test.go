@@ -4,8 +4,11 @@
 package hierarchycodec
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/ava-labs/avalanchego/codec"
 )
 
@@ -16,9 +19,34 @@ func TestVectors(t *testing.T) {
 	}
 }
 
+func TestFixedWidthVectors(t *testing.T) {
+	for _, test := range codec.FixedWidthTests {
+		c := NewDefault()
+		test(c, t)
+	}
+}
+
 func TestMultipleTags(t *testing.T) {
 	for _, test := range codec.MultipleTagsTests {
 		c := New([]string{"tag1", "tag2"}, defaultMaxSliceLength)
 		test(c, t)
 	}
 }
+
+func TestRegisteredTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewDefault()
+	c.SkipRegistrations(1)
+	assert.NoError(c.RegisterType(&codec.MyInnerStruct2{}))
+	c.NextGroup()
+	assert.NoError(c.RegisterType(&codec.MyInnerStruct{}))
+
+	assert.Equal(
+		[]reflect.Type{
+			reflect.TypeOf(&codec.MyInnerStruct2{}),
+			reflect.TypeOf(&codec.MyInnerStruct{}),
+		},
+		c.RegisteredTypes(),
+	)
+}
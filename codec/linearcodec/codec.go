@@ -5,7 +5,9 @@ package linearcodec
 
 import (
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/codec"
@@ -23,12 +25,14 @@ var (
 	_ codec.Codec        = &linearCodec{}
 	_ codec.Registry     = &linearCodec{}
 	_ codec.GeneralCodec = &linearCodec{}
+	_ codec.Introspector = &linearCodec{}
 )
 
 // Codec marshals and unmarshals
 type Codec interface {
 	codec.Registry
 	codec.Codec
+	codec.Introspector
 	SkipRegistrations(int)
 }
 
@@ -36,6 +40,7 @@ type Codec interface {
 type linearCodec struct {
 	codec.Codec
 
+	varints      bool
 	lock         sync.RWMutex
 	nextTypeID   uint32
 	typeIDToType map[uint32]reflect.Type
@@ -54,6 +59,20 @@ func New(tagNames []string, maxSliceLen uint32) Codec {
 	return hCodec
 }
 
+// NewVarint returns a new, concurrency-safe codec that packs integers, lengths
+// and type IDs as varints and supports maps. Its encoding isn't compatible with
+// codecs returned by New, so it must be registered under a different version.
+func NewVarint(tagNames []string, maxSliceLen uint32) Codec {
+	hCodec := &linearCodec{
+		varints:      true,
+		nextTypeID:   0,
+		typeIDToType: map[uint32]reflect.Type{},
+		typeToTypeID: map[reflect.Type]uint32{},
+	}
+	hCodec.Codec = reflectcodec.NewVarint(hCodec, tagNames, maxSliceLen)
+	return hCodec
+}
+
 // NewDefault is a convenience constructor; it returns a new codec with reasonable default values
 func NewDefault() Codec { return New([]string{reflectcodec.DefaultTagName}, defaultMaxSliceLength) }
 
@@ -62,6 +81,12 @@ func NewCustomMaxLength(maxSliceLen uint32) Codec {
 	return New([]string{reflectcodec.DefaultTagName}, maxSliceLen)
 }
 
+// NewDefaultVarint is a convenience constructor; it returns a new varint codec
+// with reasonable default values
+func NewDefaultVarint() Codec {
+	return NewVarint([]string{reflectcodec.DefaultTagName}, defaultMaxSliceLength)
+}
+
 // Skip some number of type IDs
 func (c *linearCodec) SkipRegistrations(num int) {
 	c.lock.Lock()
@@ -86,6 +111,25 @@ func (c *linearCodec) RegisterType(val interface{}) error {
 	return nil
 }
 
+func (c *linearCodec) RegisteredTypes() []reflect.Type {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	typeIDs := make([]uint32, 0, len(c.typeIDToType))
+	for typeID := range c.typeIDToType {
+		typeIDs = append(typeIDs, typeID)
+	}
+	sort.Slice(typeIDs, func(i, j int) bool {
+		return typeIDs[i] < typeIDs[j]
+	})
+
+	types := make([]reflect.Type, len(typeIDs))
+	for i, typeID := range typeIDs {
+		types[i] = c.typeIDToType[typeID]
+	}
+	return types
+}
+
 func (c *linearCodec) PackPrefix(p *wrappers.Packer, valueType reflect.Type) error {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -94,7 +138,12 @@ func (c *linearCodec) PackPrefix(p *wrappers.Packer, valueType reflect.Type) err
 	if !ok {
 		return fmt.Errorf("can't marshal unregistered type %q", valueType)
 	}
-	p.PackInt(typeID) // Pack type ID so we know what to unmarshal this into
+	// Pack type ID so we know what to unmarshal this into
+	if c.varints {
+		p.PackUvarint(uint64(typeID))
+	} else {
+		p.PackInt(typeID)
+	}
 	return p.Err
 }
 
@@ -102,7 +151,17 @@ func (c *linearCodec) UnpackPrefix(p *wrappers.Packer, valueType reflect.Type) (
 	c.lock.RLock()
 	defer c.lock.RUnlock()
 
-	typeID := p.UnpackInt() // Get the type ID
+	// Get the type ID
+	var typeID uint32
+	if c.varints {
+		typeID64 := p.UnpackUvarint()
+		if typeID64 > math.MaxUint32 {
+			return reflect.Value{}, fmt.Errorf("couldn't unmarshal interface: type ID %d out of range", typeID64)
+		}
+		typeID = uint32(typeID64)
+	} else {
+		typeID = p.UnpackInt()
+	}
 	if p.Err != nil {
 		return reflect.Value{}, fmt.Errorf("couldn't unmarshal interface: %w", p.Err)
 	}
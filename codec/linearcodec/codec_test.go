@@ -4,8 +4,11 @@
 package linearcodec
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/ava-labs/avalanchego/codec"
 )
 
@@ -16,9 +19,51 @@ func TestVectors(t *testing.T) {
 	}
 }
 
+func TestFixedWidthVectors(t *testing.T) {
+	for _, test := range codec.FixedWidthTests {
+		c := NewDefault()
+		test(c, t)
+	}
+}
+
 func TestMultipleTags(t *testing.T) {
 	for _, test := range codec.MultipleTagsTests {
 		c := New([]string{"tag1", "tag2"}, defaultMaxSliceLength)
 		test(c, t)
 	}
 }
+
+func TestVarintVectors(t *testing.T) {
+	for _, test := range codec.Tests {
+		c := NewDefaultVarint()
+		test(c, t)
+	}
+	for _, test := range codec.VarintTests {
+		c := NewDefaultVarint()
+		test(c, t)
+	}
+}
+
+func TestVarintMultipleTags(t *testing.T) {
+	for _, test := range codec.MultipleTagsTests {
+		c := NewVarint([]string{"tag1", "tag2"}, defaultMaxSliceLength)
+		test(c, t)
+	}
+}
+
+func TestRegisteredTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewDefault()
+	assert.NoError(c.RegisterType(&codec.MyInnerStruct{}))
+	c.SkipRegistrations(1)
+	assert.NoError(c.RegisterType(&codec.MyInnerStruct2{}))
+
+	assert.Equal(
+		[]reflect.Type{
+			reflect.TypeOf(&codec.MyInnerStruct{}),
+			reflect.TypeOf(&codec.MyInnerStruct2{}),
+		},
+		c.RegisteredTypes(),
+	)
+}
@@ -6,6 +6,7 @@ package codec
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/utils/units"
@@ -50,6 +51,12 @@ type Manager interface {
 	// be a pointer or an interface. Returns the version of the codec that
 	// produces the given bytes.
 	Unmarshal(source []byte, destination interface{}) (version uint16, err error)
+
+	// Versions returns the registered codec versions in increasing order.
+	Versions() []uint16
+
+	// Codec returns the codec associated with the given version, if any.
+	Codec(version uint16) (Codec, bool)
 }
 
 // NewManager returns a new codec manager.
@@ -89,6 +96,28 @@ func (m *manager) SetMaxSize(size int) {
 	m.lock.Unlock()
 }
 
+func (m *manager) Versions() []uint16 {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	versions := make([]uint16, 0, len(m.codecs))
+	for version := range m.codecs {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] < versions[j]
+	})
+	return versions
+}
+
+func (m *manager) Codec(version uint16) (Codec, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	c, exists := m.codecs[version]
+	return c, exists
+}
+
 // To marshal an interface, [value] must be a pointer to the interface.
 func (m *manager) Marshal(version uint16, value interface{}) ([]byte, error) {
 	if value == nil {
@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import "github.com/ava-labs/avalanchego/utils/wrappers"
+
+// Version returns the codec version that [bytes] were marshalled with
+func Version(bytes []byte) (uint16, error) {
+	p := wrappers.Packer{Bytes: bytes}
+	version := p.UnpackShort()
+	if p.Errored() {
+		return 0, errCantUnpackVersion
+	}
+	return version, nil
+}
+
+// Migrate re-marshals [source], which may have been marshalled with any
+// version registered in [m], with [version]. [dest] must be a pointer to a
+// value of the type that [source] was marshalled from, and is populated with
+// the unmarshalled value. If [source] already uses [version], it's returned
+// unchanged.
+func Migrate(m Manager, version uint16, source []byte, dest interface{}) ([]byte, error) {
+	sourceVersion, err := m.Unmarshal(source, dest)
+	if err != nil {
+		return nil, err
+	}
+	if sourceVersion == version {
+		return source, nil
+	}
+	return m.Marshal(version, dest)
+}
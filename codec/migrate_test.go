@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+)
+
+func TestMigrate(t *testing.T) {
+	assert := assert.New(t)
+
+	type utxo struct {
+		Amount   uint64   `serialize:"true"`
+		Locktime uint64   `serialize:"true"`
+		Owners   [][]byte `serialize:"true"`
+	}
+
+	const (
+		fixedVersion  = 0
+		varintVersion = 1
+	)
+	manager := codec.NewDefaultManager()
+	assert.NoError(manager.RegisterCodec(fixedVersion, linearcodec.NewDefault()))
+	assert.NoError(manager.RegisterCodec(varintVersion, linearcodec.NewDefaultVarint()))
+
+	val := utxo{
+		Amount:   1000,
+		Locktime: 0,
+		Owners:   [][]byte{make([]byte, 20)},
+	}
+	fixedBytes, err := manager.Marshal(fixedVersion, val)
+	assert.NoError(err)
+
+	migrated := utxo{}
+	varintBytes, err := codec.Migrate(manager, varintVersion, fixedBytes, &migrated)
+	assert.NoError(err)
+	assert.Equal(val, migrated)
+	assert.Less(len(varintBytes), len(fixedBytes))
+
+	version, err := codec.Version(varintBytes)
+	assert.NoError(err)
+	assert.EqualValues(varintVersion, version)
+
+	// Both versions can be unmarshalled by the same manager
+	unmarshaled := utxo{}
+	version, err = manager.Unmarshal(varintBytes, &unmarshaled)
+	assert.NoError(err)
+	assert.EqualValues(varintVersion, version)
+	assert.Equal(val, unmarshaled)
+
+	// Migrating to the same version is a no-op
+	sameBytes, err := codec.Migrate(manager, varintVersion, varintBytes, &utxo{})
+	assert.NoError(err)
+	assert.Equal(varintBytes, sameBytes)
+
+	_, err = codec.Version(nil)
+	assert.Error(err)
+}
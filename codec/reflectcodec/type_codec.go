@@ -4,10 +4,12 @@
 package reflectcodec
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math"
 	"reflect"
+	"sort"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -23,6 +25,8 @@ var (
 	errUnmarshalNil = errors.New("can't unmarshal nil")
 	errNeedPointer  = errors.New("argument to unmarshal must be a pointer")
 	errExtraSpace   = errors.New("trailing buffer space")
+	errOutOfRange   = errors.New("value out of range")
+	errUnsortedMap  = errors.New("map keys aren't sorted and unique")
 )
 
 var _ codec.Codec = &genericCodec{}
@@ -56,10 +60,14 @@ type TypeCodec interface {
 // 5) To unmarshal an interface,  you must call codec.RegisterType([instance of the type that fulfills the interface]).
 // 6) Serialized fields must be exported
 // 7) nil slices are marshaled as empty slices
+// 8) If the codec uses varints, integers and lengths are packed as varints and
+//    maps may be serialized. Map entries are sorted by the bytes of their keys,
+//    so every map has exactly one byte representation.
 type genericCodec struct {
 	typer       TypeCodec
 	maxSliceLen uint32
 	fielder     StructFielder
+	varints     bool
 }
 
 // New returns a new, concurrency-safe codec
@@ -71,6 +79,17 @@ func New(typer TypeCodec, tagNames []string, maxSliceLen uint32) codec.Codec {
 	}
 }
 
+// NewVarint returns a new, concurrency-safe codec that packs integers and
+// lengths as varints and supports maps
+func NewVarint(typer TypeCodec, tagNames []string, maxSliceLen uint32) codec.Codec {
+	return &genericCodec{
+		typer:       typer,
+		maxSliceLen: maxSliceLen,
+		fielder:     NewStructFielder(tagNames, maxSliceLen),
+		varints:     true,
+	}
+}
+
 // To marshal an interface, [value] must be a pointer to the interface
 func (c *genericCodec) MarshalInto(value interface{}, p *wrappers.Packer) error {
 	if value == nil {
@@ -99,26 +118,14 @@ func (c *genericCodec) marshal(value reflect.Value, p *wrappers.Packer, maxSlice
 	case reflect.Int8:
 		p.PackByte(uint8(value.Int()))
 		return p.Err
-	case reflect.Uint16:
-		p.PackShort(uint16(value.Uint()))
-		return p.Err
-	case reflect.Int16:
-		p.PackShort(uint16(value.Int()))
-		return p.Err
-	case reflect.Uint32:
-		p.PackInt(uint32(value.Uint()))
-		return p.Err
-	case reflect.Int32:
-		p.PackInt(uint32(value.Int()))
-		return p.Err
-	case reflect.Uint64:
-		p.PackLong(value.Uint())
+	case reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		c.packUint(p, value.Uint(), value.Type().Size())
 		return p.Err
-	case reflect.Int64:
-		p.PackLong(uint64(value.Int()))
+	case reflect.Int16, reflect.Int32, reflect.Int64:
+		c.packInt(p, value.Int(), value.Type().Size())
 		return p.Err
 	case reflect.String:
-		p.PackStr(value.String())
+		c.packStr(p, value.String())
 		return p.Err
 	case reflect.Bool:
 		p.PackBool(value.Bool())
@@ -142,7 +149,7 @@ func (c *genericCodec) marshal(value reflect.Value, p *wrappers.Packer, maxSlice
 				numElts,
 				maxSliceLen)
 		}
-		p.PackInt(uint32(numElts)) // pack # elements
+		c.packLen(p, numElts) // pack # elements
 		if p.Err != nil {
 			return p.Err
 		}
@@ -174,6 +181,11 @@ func (c *genericCodec) marshal(value reflect.Value, p *wrappers.Packer, maxSlice
 			}
 		}
 		return nil
+	case reflect.Map:
+		if !c.varints {
+			return fmt.Errorf("can't marshal unknown kind %s", valueKind)
+		}
+		return c.marshalMap(value, p, maxSliceLen)
 	case reflect.Struct:
 		serializedFields, err := c.fielder.GetSerializedFields(value.Type())
 		if err != nil {
@@ -229,41 +241,25 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 			return fmt.Errorf("couldn't unmarshal int8: %w", p.Err)
 		}
 		return nil
-	case reflect.Uint16:
-		value.SetUint(uint64(p.UnpackShort()))
-		if p.Err != nil {
-			return fmt.Errorf("couldn't unmarshal uint16: %w", p.Err)
-		}
-		return nil
-	case reflect.Int16:
-		value.SetInt(int64(p.UnpackShort()))
-		if p.Err != nil {
-			return fmt.Errorf("couldn't unmarshal int16: %w", p.Err)
-		}
-		return nil
-	case reflect.Uint32:
-		value.SetUint(uint64(p.UnpackInt()))
-		if p.Err != nil {
-			return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+	case reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		val := c.unpackUint(p, value.Type().Size())
+		if p.Err == nil && value.OverflowUint(val) {
+			p.Add(errOutOfRange)
 		}
-		return nil
-	case reflect.Int32:
-		value.SetInt(int64(p.UnpackInt()))
 		if p.Err != nil {
-			return fmt.Errorf("couldn't unmarshal int32: %w", p.Err)
+			return fmt.Errorf("couldn't unmarshal %s: %w", value.Kind(), p.Err)
 		}
+		value.SetUint(val)
 		return nil
-	case reflect.Uint64:
-		value.SetUint(p.UnpackLong())
-		if p.Err != nil {
-			return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	case reflect.Int16, reflect.Int32, reflect.Int64:
+		val := c.unpackInt(p, value.Type().Size())
+		if p.Err == nil && value.OverflowInt(val) {
+			p.Add(errOutOfRange)
 		}
-		return nil
-	case reflect.Int64:
-		value.SetInt(int64(p.UnpackLong()))
 		if p.Err != nil {
-			return fmt.Errorf("couldn't unmarshal int64: %w", p.Err)
+			return fmt.Errorf("couldn't unmarshal %s: %w", value.Kind(), p.Err)
 		}
+		value.SetInt(val)
 		return nil
 	case reflect.Bool:
 		value.SetBool(p.UnpackBool())
@@ -272,7 +268,7 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 		}
 		return nil
 	case reflect.Slice:
-		numElts32 := p.UnpackInt()
+		numElts32 := c.unpackLen(p)
 		if p.Err != nil {
 			return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
 		}
@@ -322,7 +318,7 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 		}
 		return nil
 	case reflect.String:
-		value.SetString(p.UnpackStr())
+		value.SetString(c.unpackStr(p))
 		if p.Err != nil {
 			return fmt.Errorf("couldn't unmarshal string: %w", p.Err)
 		}
@@ -339,6 +335,14 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 		// And assign the filled struct to the value
 		value.Set(intfImplementor)
 		return nil
+	case reflect.Map:
+		if !c.varints {
+			return fmt.Errorf("can't unmarshal unknown type %s", value.Kind().String())
+		}
+		if err := c.unmarshalMap(p, value, maxSliceLen); err != nil {
+			return fmt.Errorf("couldn't unmarshal map: %w", err)
+		}
+		return nil
 	case reflect.Struct:
 		// Get indices of fields that will be unmarshaled into
 		serializedFieldIndices, err := c.fielder.GetSerializedFields(value.Type())
@@ -370,3 +374,183 @@ func (c *genericCodec) unmarshal(p *wrappers.Packer, value reflect.Value, maxSli
 		return fmt.Errorf("can't unmarshal unknown type %s", value.Kind().String())
 	}
 }
+
+// marshalMap writes the byte representation of the map [value] to [p]. Entries
+// are sorted by the bytes of their keys.
+func (c *genericCodec) marshalMap(value reflect.Value, p *wrappers.Packer, maxSliceLen uint32) error {
+	numElts := value.Len()
+	if uint32(numElts) > maxSliceLen {
+		return fmt.Errorf("map length, %d, exceeds maximum length, %d",
+			numElts,
+			maxSliceLen)
+	}
+
+	type entry struct {
+		key   []byte
+		value reflect.Value
+	}
+	entries := make([]entry, 0, numElts)
+	iter := value.MapRange()
+	for iter.Next() {
+		keyPacker := wrappers.Packer{MaxSize: p.MaxSize}
+		if err := c.marshal(iter.Key(), &keyPacker, c.maxSliceLen); err != nil {
+			return err
+		}
+		entries = append(entries, entry{
+			key:   keyPacker.Bytes,
+			value: iter.Value(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	c.packLen(p, numElts)
+	for _, entry := range entries {
+		p.PackFixedBytes(entry.key)
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.marshal(entry.value, p, c.maxSliceLen); err != nil {
+			return err
+		}
+	}
+	return p.Err
+}
+
+// unmarshalMap unmarshals a map from p.Bytes into [value]. The keys must be
+// sorted by their bytes and unique.
+func (c *genericCodec) unmarshalMap(p *wrappers.Packer, value reflect.Value, maxSliceLen uint32) error {
+	numElts32 := c.unpackLen(p)
+	if p.Err != nil {
+		return p.Err
+	}
+	if numElts32 > maxSliceLen {
+		return fmt.Errorf("map length, %d, exceeds maximum length, %d",
+			numElts32,
+			maxSliceLen)
+	}
+	numElts := int(numElts32)
+
+	mapType := value.Type()
+	m := reflect.MakeMapWithSize(mapType, numElts)
+	var previousKey []byte
+	for i := 0; i < numElts; i++ {
+		keyStart := p.Offset
+		key := reflect.New(mapType.Key()).Elem()
+		if err := c.unmarshal(p, key, c.maxSliceLen); err != nil {
+			return err
+		}
+		keyBytes := p.Bytes[keyStart:p.Offset]
+		if i > 0 && bytes.Compare(previousKey, keyBytes) >= 0 {
+			return errUnsortedMap
+		}
+		previousKey = keyBytes
+
+		elem := reflect.New(mapType.Elem()).Elem()
+		if err := c.unmarshal(p, elem, c.maxSliceLen); err != nil {
+			return err
+		}
+		m.SetMapIndex(key, elem)
+	}
+	value.Set(m)
+	return nil
+}
+
+// packUint packs [val], which is [size] bytes wide
+func (c *genericCodec) packUint(p *wrappers.Packer, val uint64, size uintptr) {
+	switch {
+	case c.varints:
+		p.PackUvarint(val)
+	case size == wrappers.ShortLen:
+		p.PackShort(uint16(val))
+	case size == wrappers.IntLen:
+		p.PackInt(uint32(val))
+	default:
+		p.PackLong(val)
+	}
+}
+
+// unpackUint unpacks an unsigned integer that is [size] bytes wide
+func (c *genericCodec) unpackUint(p *wrappers.Packer, size uintptr) uint64 {
+	switch {
+	case c.varints:
+		return p.UnpackUvarint()
+	case size == wrappers.ShortLen:
+		return uint64(p.UnpackShort())
+	case size == wrappers.IntLen:
+		return uint64(p.UnpackInt())
+	default:
+		return p.UnpackLong()
+	}
+}
+
+// packInt packs [val], which is [size] bytes wide
+func (c *genericCodec) packInt(p *wrappers.Packer, val int64, size uintptr) {
+	if c.varints {
+		p.PackVarint(val)
+		return
+	}
+	c.packUint(p, uint64(val), size)
+}
+
+// unpackInt unpacks a signed integer that is [size] bytes wide
+func (c *genericCodec) unpackInt(p *wrappers.Packer, size uintptr) int64 {
+	switch {
+	case c.varints:
+		return p.UnpackVarint()
+	case size == wrappers.ShortLen:
+		return int64(int16(p.UnpackShort()))
+	case size == wrappers.IntLen:
+		return int64(int32(p.UnpackInt()))
+	default:
+		return int64(p.UnpackLong())
+	}
+}
+
+// packLen packs the length of a slice or map
+func (c *genericCodec) packLen(p *wrappers.Packer, length int) {
+	if c.varints {
+		p.PackUvarint(uint64(length))
+		return
+	}
+	p.PackInt(uint32(length))
+}
+
+// unpackLen unpacks the length of a slice or map
+func (c *genericCodec) unpackLen(p *wrappers.Packer) uint32 {
+	if !c.varints {
+		return p.UnpackInt()
+	}
+	length := p.UnpackUvarint()
+	if length > math.MaxUint32 {
+		p.Add(errOutOfRange)
+		return 0
+	}
+	return uint32(length)
+}
+
+func (c *genericCodec) packStr(p *wrappers.Packer, str string) {
+	if !c.varints {
+		p.PackStr(str)
+		return
+	}
+	if len(str) > wrappers.MaxStringLen {
+		p.Add(errOutOfRange)
+		return
+	}
+	p.PackUvarint(uint64(len(str)))
+	p.PackFixedBytes([]byte(str))
+}
+
+func (c *genericCodec) unpackStr(p *wrappers.Packer) string {
+	if !c.varints {
+		return p.UnpackStr()
+	}
+	strLen := p.UnpackUvarint()
+	if strLen > wrappers.MaxStringLen {
+		p.Add(errOutOfRange)
+		return ""
+	}
+	return string(p.UnpackFixedBytes(int(strLen)))
+}
@@ -3,7 +3,15 @@
 
 package codec
 
+import "reflect"
+
 // Registry registers new types that can be marshaled into
 type Registry interface {
 	RegisterType(interface{}) error
 }
+
+// Introspector exposes the types that have been registered with a codec
+type Introspector interface {
+	// RegisteredTypes returns the registered types, ordered by type ID
+	RegisteredTypes() []reflect.Type
+}
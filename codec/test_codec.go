@@ -33,14 +33,29 @@ var Tests = []func(c GeneralCodec, t testing.TB){
 	TestNilSlice,
 	TestSerializeUnexportedField,
 	TestSerializeOfNoSerializeField,
-	TestNilSliceSerialization,
-	TestEmptySliceSerialization,
-	TestSliceWithEmptySerialization,
 	TestRestrictedSlice,
 	TestExtraSpace,
 	TestSliceLengthOverflow,
 }
 
+// FixedWidthTests check the bytes produced by codecs that pack integers and
+// lengths with a fixed width
+var FixedWidthTests = []func(c GeneralCodec, t testing.TB){
+	TestNilSliceSerialization,
+	TestEmptySliceSerialization,
+	TestSliceWithEmptySerialization,
+}
+
+// VarintTests check the bytes produced by codecs that pack integers, lengths
+// and type IDs as varints
+var VarintTests = []func(c GeneralCodec, t testing.TB){
+	TestVarintSerialization,
+	TestVarintNonMinimal,
+	TestVarintOutOfRange,
+	TestMap,
+	TestMapCanonical,
+}
+
 var MultipleTagsTests = []func(c GeneralCodec, t testing.TB){
 	TestMultipleTags,
 }
@@ -1049,3 +1064,168 @@ func TestMultipleTags(codec GeneralCodec, t testing.TB) {
 		assert.True(t, len(output.NoTags) == 0)
 	}
 }
+
+type VarintStruct struct {
+	Uint16 uint16            `serialize:"true"`
+	Uint32 uint32            `serialize:"true"`
+	Uint64 uint64            `serialize:"true"`
+	Int64  int64             `serialize:"true"`
+	Str    string            `serialize:"true"`
+	Bytes  []byte            `serialize:"true"`
+	Foo    Foo               `serialize:"true"`
+	Map    map[uint32]string `serialize:"true"`
+}
+
+func TestVarintSerialization(codec GeneralCodec, t testing.TB) {
+	assert := assert.New(t)
+
+	assert.NoError(codec.RegisterType(&MyInnerStruct{}))
+
+	manager := NewDefaultManager()
+	assert.NoError(manager.RegisterCodec(0, codec))
+
+	val := &VarintStruct{
+		Uint16: 1,
+		Uint32: 300,
+		Uint64: math.MaxUint64,
+		Int64:  -1,
+		Str:    "hi",
+		Bytes:  []byte{0xff},
+		Foo:    &MyInnerStruct{Str: "a"},
+		Map: map[uint32]string{
+			128: "b",
+			1:   "c",
+		},
+	}
+	expected := []byte{
+		// Codec version
+		0x00, 0x00,
+		// Uint16
+		0x01,
+		// Uint32
+		0xac, 0x02,
+		// Uint64
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01,
+		// Int64
+		0x01,
+		// Str
+		0x02, 'h', 'i',
+		// Bytes
+		0x01, 0xff,
+		// Foo: type ID and then the string
+		0x00, 0x01, 'a',
+		// Map: length and then entries sorted by their key bytes
+		0x02,
+		0x01, 0x01, 'c',
+		0x80, 0x01, 0x01, 'b',
+	}
+	result, err := manager.Marshal(0, val)
+	assert.NoError(err)
+	assert.Equal(expected, result)
+
+	unmarshaled := &VarintStruct{}
+	version, err := manager.Unmarshal(result, unmarshaled)
+	assert.NoError(err)
+	assert.EqualValues(0, version)
+	assert.Equal(val, unmarshaled)
+}
+
+// Ensure values must be minimally encoded so they only have one representation
+func TestVarintNonMinimal(codec GeneralCodec, t testing.TB) {
+	assert := assert.New(t)
+
+	manager := NewDefaultManager()
+	assert.NoError(manager.RegisterCodec(0, codec))
+
+	var val uint64
+	_, err := manager.Unmarshal([]byte{0x00, 0x00, 0x81, 0x00}, &val)
+	assert.Error(err)
+
+	_, err = manager.Unmarshal([]byte{0x00, 0x00, 0x01}, &val)
+	assert.NoError(err)
+	assert.EqualValues(1, val)
+}
+
+// Ensure varints that don't fit into the destination error
+func TestVarintOutOfRange(codec GeneralCodec, t testing.TB) {
+	assert := assert.New(t)
+
+	manager := NewDefaultManager()
+	assert.NoError(manager.RegisterCodec(0, codec))
+
+	bytes, err := manager.Marshal(0, uint32(math.MaxUint16+1))
+	assert.NoError(err)
+
+	var val uint16
+	_, err = manager.Unmarshal(bytes, &val)
+	assert.Error(err)
+
+	bytes, err = manager.Marshal(0, int64(math.MinInt32-1))
+	assert.NoError(err)
+
+	var signedVal int32
+	_, err = manager.Unmarshal(bytes, &signedVal)
+	assert.Error(err)
+}
+
+func TestMap(codec GeneralCodec, t testing.TB) {
+	assert := assert.New(t)
+
+	manager := NewDefaultManager()
+	assert.NoError(manager.RegisterCodec(0, codec))
+
+	val := map[string][]uint64{
+		"one":   {1},
+		"two":   {2, 2},
+		"three": {3, 3, 3},
+		"empty": nil,
+	}
+	bytes, err := manager.Marshal(0, val)
+	assert.NoError(err)
+
+	// Marshalling the same map must always produce the same bytes
+	for i := 0; i < 10; i++ {
+		otherBytes, err := manager.Marshal(0, val)
+		assert.NoError(err)
+		assert.Equal(bytes, otherBytes)
+	}
+
+	unmarshaled := map[string][]uint64{}
+	_, err = manager.Unmarshal(bytes, &unmarshaled)
+	assert.NoError(err)
+	assert.Len(unmarshaled, len(val))
+	for key, expected := range val {
+		assert.Len(unmarshaled[key], len(expected))
+		for i, elem := range expected {
+			assert.Equal(elem, unmarshaled[key][i])
+		}
+	}
+}
+
+// Ensure maps with unsorted or duplicated keys can't be unmarshalled
+func TestMapCanonical(codec GeneralCodec, t testing.TB) {
+	assert := assert.New(t)
+
+	manager := NewDefaultManager()
+	assert.NoError(manager.RegisterCodec(0, codec))
+
+	tests := map[string][]byte{
+		"unsorted": {
+			0x00, 0x00, // Codec version
+			0x02,       // Length
+			0x02, 0x01, // 2 -> 1
+			0x01, 0x01, // 1 -> 1
+		},
+		"duplicated": {
+			0x00, 0x00, // Codec version
+			0x02,       // Length
+			0x01, 0x01, // 1 -> 1
+			0x01, 0x02, // 1 -> 2
+		},
+	}
+	for name, bytes := range tests {
+		val := map[uint32]uint32{}
+		_, err := manager.Unmarshal(bytes, &val)
+		assert.Error(err, name)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/viper"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/filesystem"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/registry"
+
+	pchaingenesis "github.com/ava-labs/avalanchego/vms/platformvm/genesis"
+	pchaintxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// CheckResult is the outcome of checking the config of a node
+type CheckResult struct {
+	// Errors found in the config
+	Errors []error
+	// Unchecked describes the parts of the config that couldn't be checked
+	// without starting the node
+	Unchecked []string
+}
+
+// genesisChain is a chain created in the genesis of the primary network.
+type genesisChain struct {
+	id          ids.ID
+	name        string
+	vmID        ids.ID
+	genesisData []byte
+	aliases     []string
+}
+
+// CheckConfig validates the flags, chain configs, subnet configs and genesis
+// that a node would be started with [v], without starting the node.
+//
+// The genesis data and the chain config of each chain in the genesis are
+// checked by an uninitialized instance of the chain's VM, if the VM
+// implements common.ConfigChecker. [vmFactories] are the factories of the VMs
+// built into the node. The other VMs are loaded from the plugin directory, the
+// same way the node loads them.
+//
+// Unlike GetNodeConfig, checking doesn't stop at the first error and doesn't
+// resolve the node's public IP. Every error that is found is returned.
+func CheckConfig(v *viper.Viper, vmFactories map[ids.ID]vms.Factory) CheckResult {
+	result := CheckResult{}
+
+	runnerConfig, err := GetRunnerConfig(v)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("process config: %w", err))
+	}
+
+	// The genesis, chain configs, subnet configs and VM aliases are checked
+	// separately so that each error is reported, even though GetNodeConfig
+	// would only report the first of them.
+	vmManager, err := getVMManager(v)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("VM aliases: %w", err))
+		// Plugins named after the ID of their VM can still be loaded
+		vmManager = vms.NewManager()
+	}
+	networkID, err := constants.NetworkID(v.GetString(NetworkNameKey))
+	if err == nil {
+		pluginDir := filepath.Join(runnerConfig.BuildDir, pluginsDirName)
+		checkGenesisAndChainConfigs(v, networkID, vmManager, pluginDir, vmFactories, &result)
+	}
+	checkSubnetConfigs(v, &result)
+
+	// The public IP isn't resolved, as that may require network access that
+	// the node has but the check doesn't.
+	_, err = getNodeConfig(v, runnerConfig.BuildDir, nodeConfigOptions{})
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("node config: %w", err))
+	}
+	return result
+}
+
+func checkGenesisAndChainConfigs(
+	v *viper.Viper,
+	networkID uint32,
+	vmManager vms.Manager,
+	pluginDir string,
+	vmFactories map[ids.ID]vms.Factory,
+	result *CheckResult,
+) {
+	genesisBytes, _, err := getGenesisData(v, networkID)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("genesis: %w", err))
+		return
+	}
+	genesisChains, err := getGenesisChains(genesisBytes)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("genesis: %w", err))
+		return
+	}
+
+	chainConfigs, err := getChainConfigs(v)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("chain configs: %w", err))
+	}
+
+	factories, err := getVMFactories(vmManager, pluginDir, vmFactories)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("plugins: %w", err))
+	}
+
+	checkedConfigs := make(map[string]struct{}, len(chainConfigs))
+	for _, chain := range genesisChains {
+		configName, configBytes := findChainConfig(chainConfigs, chain)
+		if len(configName) != 0 {
+			checkedConfigs[configName] = struct{}{}
+		}
+
+		if chain.vmID == constants.PlatformVMID {
+			// The genesis data of the P-chain was already parsed
+			if len(configBytes) != 0 {
+				result.Unchecked = append(result.Unchecked, fmt.Sprintf("config of %s (%s): the P-chain doesn't check its config", configName, chain.id))
+			}
+			continue
+		}
+
+		factory, ok := factories[chain.vmID]
+		if !ok {
+			result.Errors = append(result.Errors, fmt.Errorf("%s (%s): VM %s %w", chain.name, chain.id, chain.vmID, vms.ErrNotFound))
+			continue
+		}
+
+		// The genesis data is checked on its own so that an error in it isn't
+		// reported as an error in the config.
+		checked, err := checkChain(factory, chain.genesisData, nil)
+		if !checked {
+			result.Unchecked = append(result.Unchecked, fmt.Sprintf("genesis and config of %s (%s): VM %s doesn't check them", chain.name, chain.id, chain.vmID))
+			continue
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("genesis of %s (%s): %w", chain.name, chain.id, err))
+			continue
+		}
+		if len(configBytes) == 0 {
+			continue
+		}
+		if _, err := checkChain(factory, chain.genesisData, configBytes); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("config of %s (%s): %w", configName, chain.id, err))
+		}
+	}
+
+	// Configs of the chains of subnets can only be checked once the chains
+	// are known, which requires the P-chain to be bootstrapped.
+	uncheckedConfigs := []string(nil)
+	for name := range chainConfigs {
+		if _, ok := checkedConfigs[name]; !ok {
+			uncheckedConfigs = append(uncheckedConfigs, name)
+		}
+	}
+	sort.Strings(uncheckedConfigs)
+	for _, name := range uncheckedConfigs {
+		result.Unchecked = append(result.Unchecked, fmt.Sprintf("config of %s: the chain isn't created in the genesis", name))
+	}
+}
+
+func checkSubnetConfigs(v *viper.Viper, result *CheckResult) {
+	subnetIDs, err := getWhitelistedSubnets(v)
+	if err != nil {
+		// Reported by getNodeConfig
+		return
+	}
+
+	for _, subnetID := range subnetIDs.List() {
+		if _, err := getSubnetConfigs(v, []ids.ID{subnetID}); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("config of subnet %s: %w", subnetID, err))
+		}
+	}
+}
+
+// getGenesisChains returns the P-chain and the chains created in
+// [genesisBytes].
+func getGenesisChains(genesisBytes []byte) ([]genesisChain, error) {
+	genesisState, err := pchaingenesis.Parse(genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+	_, chainAliases, err := genesis.Aliases(genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisChains := []genesisChain{{
+		id:      constants.PlatformChainID,
+		name:    "P-Chain",
+		vmID:    constants.PlatformVMID,
+		aliases: chainAliases[constants.PlatformChainID],
+	}}
+	for _, tx := range genesisState.Chains {
+		createChainTx, ok := tx.Unsigned.(*pchaintxs.CreateChainTx)
+		if !ok {
+			return nil, fmt.Errorf("expected *txs.CreateChainTx but got %T", tx.Unsigned)
+		}
+		chainID := tx.ID()
+		genesisChains = append(genesisChains, genesisChain{
+			id:          chainID,
+			name:        createChainTx.ChainName,
+			vmID:        createChainTx.VMID,
+			genesisData: createChainTx.GenesisData,
+			aliases:     chainAliases[chainID],
+		})
+	}
+	return genesisChains, nil
+}
+
+// findChainConfig returns the name and the bytes of the config of [chain],
+// looked up by the chain's ID and then by its aliases, the same way the chain
+// manager looks it up. Returns an empty name if the chain has no config.
+func findChainConfig(chainConfigs map[string]chains.ChainConfig, chain genesisChain) (string, []byte) {
+	names := append([]string{chain.id.String()}, chain.aliases...)
+	for _, name := range names {
+		if chainConfig, ok := chainConfigs[name]; ok {
+			return name, chainConfig.Config
+		}
+	}
+	return "", nil
+}
+
+// getVMFactories returns [vmFactories] and the factories of the VMs in
+// [pluginDir] that aren't built into the node.
+func getVMFactories(vmManager vms.Manager, pluginDir string, vmFactories map[ids.ID]vms.Factory) (map[ids.ID]vms.Factory, error) {
+	factories := make(map[ids.ID]vms.Factory, len(vmFactories))
+	for vmID, factory := range vmFactories {
+		factories[vmID] = factory
+	}
+
+	vmGetter := registry.NewVMGetter(registry.VMGetterConfig{
+		FileReader:      filesystem.NewReader(),
+		Manager:         vmManager,
+		PluginDirectory: pluginDir,
+		CPUTracker:      noProcessTracker{},
+	})
+	_, pluginFactories, err := vmGetter.Get()
+	if err != nil {
+		return factories, err
+	}
+	for vmID, factory := range pluginFactories {
+		if _, ok := factories[vmID]; !ok {
+			factories[vmID] = factory
+		}
+	}
+	return factories, nil
+}
+
+// checkChain checks [genesisBytes] and [configBytes] with an uninitialized
+// instance of the VM created by [factory]. Returns false if the VM can't check
+// them.
+func checkChain(factory vms.Factory, genesisBytes []byte, configBytes []byte) (bool, error) {
+	vm, err := factory.New(nil)
+	if err != nil {
+		return true, fmt.Errorf("couldn't create VM: %w", err)
+	}
+	if commonVM, ok := vm.(common.VM); ok {
+		defer func() {
+			_ = commonVM.Shutdown()
+		}()
+	}
+
+	checker, ok := vm.(common.ConfigChecker)
+	if !ok {
+		return false, nil
+	}
+	err = checker.CheckConfig(genesisBytes, configBytes)
+	if errors.Is(err, common.ErrConfigCheckerNotImplemented) {
+		return false, nil
+	}
+	return true, err
+}
+
+// noProcessTracker doesn't track the processes of the plugins that are
+// started to check their configs.
+type noProcessTracker struct{}
+
+func (noProcessTracker) TrackProcess(int)   {}
+func (noProcessTracker) UntrackProcess(int) {}
@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/vms"
+)
+
+var errTestConfig = errors.New("invalid config")
+
+type testVMFactory struct {
+	vm interface{}
+}
+
+func (f testVMFactory) New(*snow.Context) (interface{}, error) { return f.vm, nil }
+
+// testConfigChecker rejects every non-empty config
+type testConfigChecker struct{}
+
+func (testConfigChecker) CheckConfig(_ []byte, configBytes []byte) error {
+	if len(configBytes) != 0 {
+		return errTestConfig
+	}
+	return nil
+}
+
+func testVMFactories() map[ids.ID]vms.Factory {
+	return map[ids.ID]vms.Factory{
+		constants.AVMID: testVMFactory{vm: testConfigChecker{}},
+		constants.EVMID: testVMFactory{vm: testConfigChecker{}},
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	buildDir := filepath.Join(root, "build")
+	assert.NoError(os.MkdirAll(filepath.Join(buildDir, pluginsDirName), perms.ReadWriteExecute))
+	configJSON := fmt.Sprintf(`{%q: "local", %q: false}`,
+		NetworkNameKey,
+		StakingEnabledKey,
+	)
+	v := setupViper(setupConfigJSON(t, root, configJSON))
+	v.Set(BuildDirKey, buildDir)
+	result := CheckConfig(v, testVMFactories())
+	assert.Empty(result.Errors)
+	assert.Empty(result.Unchecked)
+}
+
+func TestCheckConfigReportsAllErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	buildDir := filepath.Join(root, "build")
+	assert.NoError(os.MkdirAll(filepath.Join(buildDir, pluginsDirName), perms.ReadWriteExecute))
+	chainConfigDir := filepath.Join(root, "chains")
+	setupFile(t, filepath.Join(chainConfigDir, "C"), "config.json", `{"pruning-enabled": "yes"}`)
+	setupFile(t, filepath.Join(chainConfigDir, "X"), "config.json", `{"index-transactions": true}`)
+	setupFile(t, filepath.Join(chainConfigDir, "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i"), "config.json", `{}`)
+	subnetConfigDir := filepath.Join(root, "subnets")
+	setupFile(t, subnetConfigDir, "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json", `{"consensusParameters":{"k": 1, "alpha": 5}}`)
+
+	configJSON := fmt.Sprintf(`{%q: "local", %q: false, %q: %q, %q: %q, %q: "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i", %q: 100}`,
+		NetworkNameKey,
+		StakingEnabledKey,
+		ChainConfigDirKey, chainConfigDir,
+		SubnetConfigDirKey, subnetConfigDir,
+		WhitelistedSubnetsKey,
+		SnowQuorumSizeKey,
+	)
+	v := setupViper(setupConfigJSON(t, root, configJSON))
+	v.Set(BuildDirKey, buildDir)
+	vmFactories := testVMFactories()
+	// The X-chain's VM doesn't check its config
+	vmFactories[constants.AVMID] = testVMFactory{vm: struct{}{}}
+	result := CheckConfig(v, vmFactories)
+	assert.Len(result.Errors, 3)
+	assert.ErrorIs(result.Errors[0], errTestConfig)
+	assert.Contains(result.Errors[0].Error(), "config of C")
+	assert.Contains(result.Errors[1].Error(), "config of subnet")
+	assert.Contains(result.Errors[2].Error(), "node config")
+
+	assert.Len(result.Unchecked, 2)
+	assert.Contains(result.Unchecked[0], "genesis and config of X")
+	assert.Contains(result.Unchecked[1], "config of 2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"math"
 	"net"
 	"os"
@@ -18,6 +19,9 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/ava-labs/avalanchego/api/ratelimit"
+	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/app/prune"
 	"github.com/ava-labs/avalanchego/app/runner"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
@@ -36,14 +40,17 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/dynamicip"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/password"
+	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/storage"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 )
 
 const (
@@ -72,6 +79,7 @@ var (
 	errCannotWhitelistPrimaryNetwork = errors.New("cannot whitelist primary network")
 	errStakingKeyContentUnset        = fmt.Errorf("%s key not set but %s set", StakingKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset       = fmt.Errorf("%s key set but %s not set", StakingKeyContentKey, StakingCertContentKey)
+	errMissingStakingSigningKeyFile  = errors.New("missing staking signing key file")
 )
 
 func GetRunnerConfig(v *viper.Viper) (runner.Config, error) {
@@ -119,6 +127,37 @@ func GetRunnerConfig(v *viper.Viper) (runner.Config, error) {
 	return config, nil
 }
 
+// GetDatabaseConfig returns the config of the node's database.
+func GetDatabaseConfig(v *viper.Viper) (node.DatabaseConfig, error) {
+	networkID, err := constants.NetworkID(v.GetString(NetworkNameKey))
+	if err != nil {
+		return node.DatabaseConfig{}, err
+	}
+	return getDatabaseConfig(v, networkID)
+}
+
+// GetPruneConfig returns the config used to prune the node's database while
+// the node is stopped.
+func GetPruneConfig(v *viper.Viper) (prune.Config, error) {
+	networkID, err := constants.NetworkID(v.GetString(NetworkNameKey))
+	if err != nil {
+		return prune.Config{}, err
+	}
+	dbConfig, err := getDatabaseConfig(v, networkID)
+	if err != nil {
+		return prune.Config{}, err
+	}
+	genesisBytes, _, err := getGenesisData(v, networkID)
+	if err != nil {
+		return prune.Config{}, fmt.Errorf("unable to load genesis file: %w", err)
+	}
+	return prune.Config{
+		DatabasePath:    dbConfig.Path,
+		GenesisBytes:    genesisBytes,
+		IndexingEnabled: v.GetBool(IndexEnabledKey),
+	}, nil
+}
+
 func getConsensusConfig(v *viper.Viper) avalanche.Parameters {
 	return avalanche.Parameters{
 		Parameters: snowball.Parameters{
@@ -160,8 +199,17 @@ func getLoggingConfig(v *viper.Viper) (logging.Config, error) {
 	loggingConfig.MaxFiles = int(v.GetUint(LogRotaterMaxFilesKey))
 	loggingConfig.MaxAge = int(v.GetUint(LogRotaterMaxAgeKey))
 	loggingConfig.Compress = v.GetBool(LogRotaterCompressEnabledKey)
+	loggingConfig.MaxTotalSize = int(v.GetUint(LogRotaterMaxTotalSizeKey))
+	if err != nil {
+		return loggingConfig, err
+	}
 
-	return loggingConfig, err
+	if overrides := v.GetString(LogRotaterOverridesKey); overrides != "" {
+		if err := json.Unmarshal([]byte(overrides), &loggingConfig.RotatingWriterOverrides); err != nil {
+			return loggingConfig, fmt.Errorf("couldn't parse %q: %w", LogRotaterOverridesKey, err)
+		}
+	}
+	return loggingConfig, nil
 }
 
 func getAPIAuthConfig(v *viper.Viper) (node.APIAuthConfig, error) {
@@ -189,7 +237,27 @@ func getAPIAuthConfig(v *viper.Viper) (node.APIAuthConfig, error) {
 	return config, nil
 }
 
-func getIPCConfig(v *viper.Viper) node.IPCConfig {
+func getAPIRateLimitConfig(v *viper.Viper) ratelimit.Config {
+	return ratelimit.Config{
+		Enabled: v.GetBool(APIRateLimitEnabledKey),
+		Default: ratelimit.Bucket{
+			RequestsPerSecond: v.GetFloat64(APIRateLimitRequestsPerSecondKey),
+			Burst:             v.GetInt(APIRateLimitBurstKey),
+		},
+		Expensive: ratelimit.Bucket{
+			RequestsPerSecond: v.GetFloat64(APIRateLimitExpensiveRequestsPerSecondKey),
+			Burst:             v.GetInt(APIRateLimitExpensiveBurstKey),
+		},
+		ExpensiveMethods:  v.GetStringSlice(APIRateLimitExpensiveMethodsKey),
+		ClientIdleTimeout: v.GetDuration(APIRateLimitClientIdleTimeoutKey),
+		Quota: ratelimit.Quota{
+			Requests: v.GetUint64(APIRateLimitQuotaRequestsKey),
+			Window:   v.GetDuration(APIRateLimitQuotaWindowKey),
+		},
+	}
+}
+
+func getIPCConfig(v *viper.Viper) (node.IPCConfig, error) {
 	config := node.IPCConfig{
 		IPCAPIEnabled: v.GetBool(IpcAPIEnabledKey),
 		IPCPath:       ipcs.DefaultBaseURL,
@@ -200,7 +268,38 @@ func getIPCConfig(v *viper.Viper) node.IPCConfig {
 	if v.IsSet(IpcsPathKey) {
 		config.IPCPath = GetExpandedArg(v, IpcsPathKey)
 	}
-	return config
+	publishers, err := getIPCPublishers(v)
+	if err != nil {
+		return node.IPCConfig{}, err
+	}
+	config.IPCPublishers = publishers
+	return config, nil
+}
+
+func getIPCPublishers(v *viper.Viper) (map[ids.ID]ipcs.PublisherConfig, error) {
+	var fileBytes []byte
+	switch {
+	case v.IsSet(IpcsPublishersContentKey):
+		var err error
+		fileBytes, err = base64.StdEncoding.DecodeString(v.GetString(IpcsPublishersContentKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(IpcsPublishersFileKey):
+		var err error
+		fileBytes, err = os.ReadFile(GetExpandedArg(v, IpcsPublishersFileKey))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	publishers := make(map[ids.ID]ipcs.PublisherConfig)
+	if err := json.Unmarshal(fileBytes, &publishers); err != nil {
+		return nil, fmt.Errorf("problem unmarshaling IPC publishers: %w", err)
+	}
+	return publishers, nil
 }
 
 func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
@@ -242,12 +341,14 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 			APIIndexerConfig: node.APIIndexerConfig{
 				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
 				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
+				IndexAddresses:       v.GetBool(IndexAddressesEnabledKey),
 			},
 			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
 			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
 			KeystoreAPIEnabled: v.GetBool(KeystoreAPIEnabledKey),
 			MetricsAPIEnabled:  v.GetBool(MetricsAPIEnabledKey),
 			HealthAPIEnabled:   v.GetBool(HealthAPIEnabledKey),
+			GRPCAPIEnabled:     v.GetBool(GRPCAPIEnabledKey),
 		},
 		HTTPHost:          v.GetString(HTTPHostKey),
 		HTTPPort:          uint16(v.GetUint(HTTPPortKey)),
@@ -264,8 +365,49 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 	if err != nil {
 		return node.HTTPConfig{}, err
 	}
-	config.IPCConfig = getIPCConfig(v)
-	return config, nil
+	config.IPCConfig, err = getIPCConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	config.APIRateLimitConfig = getAPIRateLimitConfig(v)
+	if err := config.APIRateLimitConfig.Verify(); err != nil {
+		return node.HTTPConfig{}, err
+	}
+	config.APIMetricsConfig, err = getAPIMetricsConfig(v)
+	return config, err
+}
+
+func getAPIMetricsConfig(v *viper.Viper) (server.MetricsConfig, error) {
+	buckets, err := getLatencyBuckets(v, MetricsAPILatencyBucketsKey)
+	if err != nil {
+		return server.MetricsConfig{}, err
+	}
+	maxChainLabels := v.GetInt(MetricsMaxChainLabelsKey)
+	if maxChainLabels < 0 {
+		return server.MetricsConfig{}, fmt.Errorf("%q must be >= 0", MetricsMaxChainLabelsKey)
+	}
+	return server.MetricsConfig{
+		LatencyBuckets: buckets,
+		MaxChainLabels: maxChainLabels,
+	}, nil
+}
+
+// getLatencyBuckets parses the durations at [key] into histogram bucket
+// boundaries, in nanoseconds.
+func getLatencyBuckets(v *viper.Viper, key string) ([]float64, error) {
+	durations := v.GetStringSlice(key)
+	buckets := make([]float64, len(durations))
+	for i, durationStr := range durations {
+		duration, err := time.ParseDuration(durationStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %q: %w", key, err)
+		}
+		buckets[i] = float64(duration)
+		if i > 0 && buckets[i] <= buckets[i-1] {
+			return nil, fmt.Errorf("%q must be strictly increasing", key)
+		}
+	}
+	return buckets, nil
 }
 
 func getRouterHealthConfig(v *viper.Viper, halflife time.Duration) (router.HealthConfig, error) {
@@ -294,6 +436,7 @@ func getAdaptiveTimeoutConfig(v *viper.Viper) (timer.AdaptiveTimeoutConfig, erro
 		MaximumTimeout:     v.GetDuration(NetworkMaximumTimeoutKey),
 		TimeoutHalflife:    v.GetDuration(NetworkTimeoutHalflifeKey),
 		TimeoutCoefficient: v.GetFloat64(NetworkTimeoutCoefficientKey),
+		TimeoutPercentile:  v.GetFloat64(NetworkTimeoutPercentileKey),
 	}
 	switch {
 	case config.MinimumTimeout < 1:
@@ -306,6 +449,8 @@ func getAdaptiveTimeoutConfig(v *viper.Viper) (timer.AdaptiveTimeoutConfig, erro
 		return timer.AdaptiveTimeoutConfig{}, fmt.Errorf("%q must > 0", NetworkTimeoutHalflifeKey)
 	case config.TimeoutCoefficient < 1:
 		return timer.AdaptiveTimeoutConfig{}, fmt.Errorf("%q must be >= 1", NetworkTimeoutCoefficientKey)
+	case config.TimeoutPercentile < 0 || config.TimeoutPercentile > 1:
+		return timer.AdaptiveTimeoutConfig{}, fmt.Errorf("%q must be in [0, 1]", NetworkTimeoutPercentileKey)
 	}
 
 	return config, nil
@@ -354,9 +499,11 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 				MaxProcessingMsgsPerNode: v.GetUint64(InboundThrottlerMaxProcessingMsgsPerNodeKey),
 				CPUThrottlerConfig: throttling.SystemThrottlerConfig{
 					MaxRecheckDelay: v.GetDuration(InboundThrottlerCPUMaxRecheckDelayKey),
+					MaxUsage:        v.GetFloat64(InboundThrottlerCPUMaxUsageKey),
 				},
 				DiskThrottlerConfig: throttling.SystemThrottlerConfig{
 					MaxRecheckDelay: v.GetDuration(InboundThrottlerDiskMaxRecheckDelayKey),
+					MaxUsage:        v.GetFloat64(InboundThrottlerDiskMaxUsageKey),
 				},
 			},
 
@@ -440,6 +587,10 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.ThrottlerConfig.InboundMsgThrottlerConfig.CPUThrottlerConfig.MaxUsage < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", InboundThrottlerCPUMaxUsageKey)
+	case config.ThrottlerConfig.InboundMsgThrottlerConfig.DiskThrottlerConfig.MaxUsage < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", InboundThrottlerDiskMaxUsageKey)
 	}
 	return config, nil
 }
@@ -555,7 +706,10 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 	return config, nil
 }
 
-func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
+// getIPConfig returns the IP config of the node. If [resolve] is false, the
+// flags are validated but the public IP isn't resolved, so no network requests
+// are made.
+func getIPConfig(v *viper.Viper, resolve bool) (node.IPConfig, error) {
 	// If both deprecated and current flag are given,
 	// override deprecated flag value with new flag value.
 	ipResolutionService := v.GetString(DynamicPublicIPResolverKey)
@@ -597,6 +751,9 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 		if err != nil {
 			return node.IPConfig{}, fmt.Errorf("couldn't create IP resolver: %w", err)
 		}
+		if !resolve {
+			return node.IPConfig{IPResolutionFreq: ipResolutionFreq}, nil
+		}
 
 		// Use that to resolve our public IP.
 		ip, err := resolver.Resolve()
@@ -617,6 +774,10 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 		}, nil
 	}
 
+	if !resolve {
+		return node.IPConfig{IPResolutionFreq: ipResolutionFreq}, nil
+	}
+
 	// User didn't specify a public IP to use, and they didn't specify a public IP resolution
 	// service to use. Try to resolve public IP with NAT traversal.
 	nat := nat.GetRouter()
@@ -639,9 +800,15 @@ func getProfilerConfig(v *viper.Viper) (profiler.Config, error) {
 		Enabled:     v.GetBool(ProfileContinuousEnabledKey),
 		Freq:        v.GetDuration(ProfileContinuousFreqKey),
 		MaxNumFiles: v.GetInt(ProfileContinuousMaxFilesKey),
+
+		CPUThreshold:    v.GetFloat64(ProfileContinuousCPUThresholdKey),
+		MemoryThreshold: v.GetUint64(ProfileContinuousMemoryThresholdKey),
 	}
-	if config.Freq < 0 {
+	switch {
+	case config.Freq < 0:
 		return profiler.Config{}, fmt.Errorf("%s must be >= 0", ProfileContinuousFreqKey)
+	case config.CPUThreshold < 0:
+		return profiler.Config{}, fmt.Errorf("%s must be >= 0", ProfileContinuousCPUThresholdKey)
 	}
 	return config, nil
 }
@@ -667,7 +834,7 @@ func getStakingTLSCertFromFlag(v *viper.Viper) (tls.Certificate, error) {
 	return *cert, nil
 }
 
-func getStakingTLSCertFromFile(v *viper.Viper) (tls.Certificate, error) {
+func getStakingTLSCertFromFile(v *viper.Viper, keyType staking.KeyType) (tls.Certificate, error) {
 	// Parse the staking key/cert paths and expand environment variables
 	stakingKeyPath := GetExpandedArg(v, StakingKeyPathKey)
 	stakingCertPath := GetExpandedArg(v, StakingCertPathKey)
@@ -681,7 +848,7 @@ func getStakingTLSCertFromFile(v *viper.Viper) (tls.Certificate, error) {
 		}
 	} else {
 		// Create the staking key/cert if [stakingKeyPath] and [stakingCertPath] don't exist
-		if err := staking.InitNodeStakingKeyPair(stakingKeyPath, stakingCertPath); err != nil {
+		if err := staking.InitNodeStakingKeyPair(stakingKeyPath, stakingCertPath, keyType); err != nil {
 			return tls.Certificate{}, fmt.Errorf("couldn't generate staking key/cert: %w", err)
 		}
 	}
@@ -695,9 +862,14 @@ func getStakingTLSCertFromFile(v *viper.Viper) (tls.Certificate, error) {
 }
 
 func getStakingTLSCert(v *viper.Viper) (tls.Certificate, error) {
+	keyType, err := staking.ParseKeyType(v.GetString(StakingKeyTypeKey))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("couldn't parse %s: %w", StakingKeyTypeKey, err)
+	}
+
 	if v.GetBool(StakingEphemeralCertEnabledKey) {
 		// Use an ephemeral staking key/cert
-		cert, err := staking.NewTLSCert()
+		cert, err := staking.NewTLSCertWithKeyType(keyType)
 		if err != nil {
 			return tls.Certificate{}, fmt.Errorf("couldn't generate ephemeral staking key/cert: %w", err)
 		}
@@ -712,8 +884,69 @@ func getStakingTLSCert(v *viper.Viper) (tls.Certificate, error) {
 	case v.IsSet(StakingKeyContentKey) && v.IsSet(StakingCertContentKey):
 		return getStakingTLSCertFromFlag(v)
 	default:
-		return getStakingTLSCertFromFile(v)
+		return getStakingTLSCertFromFile(v, keyType)
+	}
+}
+
+func getStakingSigner(v *viper.Viper) (*bls.SecretKey, error) {
+	if v.GetBool(StakingEphemeralSignerEnabledKey) {
+		key, err := bls.NewSecretKey()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate ephemeral signing key: %w", err)
+		}
+		return key, nil
+	}
+
+	if v.IsSet(StakingSignerKeyContentKey) {
+		signerKeyRawContent := v.GetString(StakingSignerKeyContentKey)
+		signerKeyContent, err := base64.StdEncoding.DecodeString(signerKeyRawContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+		key, err := bls.SecretKeyFromBytes(signerKeyContent)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse signing key: %w", err)
+		}
+		return key, nil
+	}
+
+	signingKeyPath := GetExpandedArg(v, StakingSignerKeyPathKey)
+	_, err := os.Stat(signingKeyPath)
+	if !errors.Is(err, fs.ErrNotExist) {
+		signingKeyBytes, err := os.ReadFile(signingKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		key, err := bls.SecretKeyFromBytes(signingKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse signing key: %w", err)
+		}
+		return key, nil
+	}
+
+	// If the signing key location is specified but not found, error
+	if v.IsSet(StakingSignerKeyPathKey) {
+		return nil, errMissingStakingSigningKeyFile
 	}
+
+	// Create the signing key at the default location
+	key, err := bls.NewSecretKey()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate new signing key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(signingKeyPath), perms.ReadWriteExecute); err != nil {
+		return nil, fmt.Errorf("couldn't create path for signing key at %s: %w", signingKeyPath, err)
+	}
+
+	keyBytes := bls.SecretKeyToBytes(key)
+	if err := os.WriteFile(signingKeyPath, keyBytes, perms.ReadWrite); err != nil {
+		return nil, fmt.Errorf("couldn't write new signing key to %s: %w", signingKeyPath, err)
+	}
+	if err := os.Chmod(signingKeyPath, perms.ReadOnly); err != nil {
+		return nil, fmt.Errorf("couldn't restrict permissions on new signing key at %s: %w", signingKeyPath, err)
+	}
+	return key, nil
 }
 
 func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, error) {
@@ -722,6 +955,7 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 		DisabledStakingWeight: v.GetUint64(StakingDisabledWeightKey),
 		StakingKeyPath:        GetExpandedArg(v, StakingKeyPathKey),
 		StakingCertPath:       GetExpandedArg(v, StakingCertPathKey),
+		StakingSignerPath:     GetExpandedArg(v, StakingSignerKeyPathKey),
 	}
 	if !config.EnableStaking && config.DisabledStakingWeight == 0 {
 		return node.StakingConfig{}, errInvalidStakerWeights
@@ -736,6 +970,10 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 	if err != nil {
 		return node.StakingConfig{}, err
 	}
+	config.StakingSigningKey, err = getStakingSigner(v)
+	if err != nil {
+		return node.StakingConfig{}, err
+	}
 	if networkID != constants.MainnetID && networkID != constants.FujiID && networkID != constants.CopycoID {
 		config.UptimeRequirement = v.GetFloat64(UptimeRequirementKey)
 		config.MinValidatorStake = v.GetUint64(MinValidatorStakeKey)
@@ -747,6 +985,7 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 		config.RewardConfig.MinConsumptionRate = v.GetUint64(StakeMinConsumptionRateKey)
 		config.RewardConfig.MintingPeriod = v.GetDuration(StakeMintingPeriodKey)
 		config.RewardConfig.SupplyCap = v.GetUint64(StakeSupplyCapKey)
+		config.RewardCalculator = v.GetString(StakeRewardCalculatorKey)
 		config.MinDelegationFee = v.GetUint32(MinDelegatorFeeKey)
 		switch {
 		case config.UptimeRequirement < 0 || config.UptimeRequirement > 1:
@@ -764,6 +1003,9 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 		case config.RewardConfig.MintingPeriod < config.MaxStakeDuration:
 			return node.StakingConfig{}, errStakeMintingPeriodBelowMin
 		}
+		if _, err := reward.NewCalculatorByName(config.RewardCalculator, config.RewardConfig); err != nil {
+			return node.StakingConfig{}, err
+		}
 	} else {
 		config.StakingConfig = genesis.GetStakingConfig(networkID)
 	}
@@ -843,7 +1085,10 @@ func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, e
 			GetExpandedArg(v, DBPathKey),
 			constants.NetworkName(networkID),
 		),
-		Config: configBytes,
+		Config:          configBytes,
+		MigrationDryRun: v.GetBool(DBMigrationDryRunKey),
+		BackupDir:       GetExpandedArg(v, DBBackupDirKey),
+		RestoreFile:     GetExpandedArg(v, DBRestoreFileKey),
 	}, nil
 }
 
@@ -1123,6 +1368,28 @@ func getDiskSpaceConfig(v *viper.Viper) (requiredAvailableDiskSpace uint64, warn
 	}
 }
 
+func getLogDiskSpaceConfig(v *viper.Viper) (requiredAvailableLogDiskSpace uint64, warningThresholdAvailableLogDiskSpace uint64, err error) {
+	requiredAvailableLogDiskSpace = v.GetUint64(SystemTrackerRequiredAvailableLogSpaceKey)
+	warningThresholdAvailableLogDiskSpace = v.GetUint64(SystemTrackerWarningThresholdAvailableLogSpaceKey)
+	switch {
+	case warningThresholdAvailableLogDiskSpace < requiredAvailableLogDiskSpace:
+		return 0, 0, fmt.Errorf("%q (%d) < %q (%d)", SystemTrackerWarningThresholdAvailableLogSpaceKey, warningThresholdAvailableLogDiskSpace, SystemTrackerRequiredAvailableLogSpaceKey, requiredAvailableLogDiskSpace)
+	default:
+		return requiredAvailableLogDiskSpace, warningThresholdAvailableLogDiskSpace, nil
+	}
+}
+
+func getFDConfig(v *viper.Viper) (requiredAvailableFDs uint64, warningThresholdAvailableFDs uint64, err error) {
+	requiredAvailableFDs = v.GetUint64(SystemTrackerRequiredAvailableFDsKey)
+	warningThresholdAvailableFDs = v.GetUint64(SystemTrackerWarningThresholdAvailableFDsKey)
+	switch {
+	case warningThresholdAvailableFDs < requiredAvailableFDs:
+		return 0, 0, fmt.Errorf("%q (%d) < %q (%d)", SystemTrackerWarningThresholdAvailableFDsKey, warningThresholdAvailableFDs, SystemTrackerRequiredAvailableFDsKey, requiredAvailableFDs)
+	default:
+		return requiredAvailableFDs, warningThresholdAvailableFDs, nil
+	}
+}
+
 func getDiskTargeterConfig(v *viper.Viper) (tracker.TargeterConfig, error) {
 	vdrAlloc := v.GetFloat64(DiskVdrAllocKey)
 	maxNonVdrUsage := v.GetFloat64(DiskMaxNonVdrUsageKey)
@@ -1144,6 +1411,25 @@ func getDiskTargeterConfig(v *viper.Viper) (tracker.TargeterConfig, error) {
 }
 
 func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
+	return getNodeConfig(v, buildDir, nodeConfigOptions{
+		resolveIP:  true,
+		loadChains: true,
+	})
+}
+
+// nodeConfigOptions selects the parts of the node config that getNodeConfig
+// loads.
+type nodeConfigOptions struct {
+	// If false, the public IP of the node isn't resolved
+	resolveIP bool
+	// If false, the genesis, the subnet configs, the chain configs and the VM
+	// aliases aren't loaded. CheckConfig checks them separately.
+	loadChains bool
+}
+
+// getNodeConfig returns the config of the node, with the parts selected by
+// [options].
+func getNodeConfig(v *viper.Viper, buildDir string, options nodeConfigOptions) (node.Config, error) {
 	nodeConfig := node.Config{}
 
 	// Plugin directory defaults to [buildDir]/[pluginsDirName]
@@ -1185,7 +1471,7 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	}
 
 	// IP configuration
-	nodeConfig.IPConfig, err = getIPConfig(v)
+	nodeConfig.IPConfig, err = getIPConfig(v, options.resolveIP)
 	if err != nil {
 		return node.Config{}, err
 	}
@@ -1228,6 +1514,10 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 
 	// Metrics
 	nodeConfig.MeterVMEnabled = v.GetBool(MeterVMsEnabledKey)
+	nodeConfig.ConsensusLatencyBuckets, err = getLatencyBuckets(v, MetricsConsensusLatencyBucketsKey)
+	if err != nil {
+		return node.Config{}, err
+	}
 
 	// Adaptive Timeout Config
 	nodeConfig.AdaptiveTimeoutConfig, err = getAdaptiveTimeoutConfig(v)
@@ -1252,13 +1542,18 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	// File Descriptor Limit
 	nodeConfig.FdLimit = v.GetUint64(FdLimitKey)
 
+	// Pruning
+	nodeConfig.PChainPruningHorizon = v.GetUint64(PChainPruningHorizonKey)
+
 	// Tx Fee
 	nodeConfig.TxFeeConfig = getTxFeeConfig(v, nodeConfig.NetworkID)
 
 	// Genesis Data
-	nodeConfig.GenesisBytes, nodeConfig.AvaxAssetID, err = getGenesisData(v, nodeConfig.NetworkID)
-	if err != nil {
-		return node.Config{}, fmt.Errorf("unable to load genesis file: %w", err)
+	if options.loadChains {
+		nodeConfig.GenesisBytes, nodeConfig.AvaxAssetID, err = getGenesisData(v, nodeConfig.NetworkID)
+		if err != nil {
+			return node.Config{}, fmt.Errorf("unable to load genesis file: %w", err)
+		}
 	}
 
 	// Assertions
@@ -1279,17 +1574,18 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 		return node.Config{}, err
 	}
 
-	// Subnet Configs
-	subnetConfigs, err := getSubnetConfigs(v, nodeConfig.WhitelistedSubnets.List())
-	if err != nil {
-		return node.Config{}, err
-	}
-	nodeConfig.SubnetConfigs = subnetConfigs
+	if options.loadChains {
+		// Subnet Configs
+		nodeConfig.SubnetConfigs, err = getSubnetConfigs(v, nodeConfig.WhitelistedSubnets.List())
+		if err != nil {
+			return node.Config{}, err
+		}
 
-	// Chain Configs
-	nodeConfig.ChainConfigs, err = getChainConfigs(v)
-	if err != nil {
-		return node.Config{}, err
+		// Chain Configs
+		nodeConfig.ChainConfigs, err = getChainConfigs(v)
+		if err != nil {
+			return node.Config{}, err
+		}
 	}
 
 	// Profiler
@@ -1299,9 +1595,11 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	}
 
 	// VM Aliases
-	nodeConfig.VMManager, err = getVMManager(v)
-	if err != nil {
-		return node.Config{}, err
+	if options.loadChains {
+		nodeConfig.VMManager, err = getVMManager(v)
+		if err != nil {
+			return node.Config{}, err
+		}
 	}
 
 	nodeConfig.SystemTrackerFrequency = v.GetDuration(SystemTrackerFrequencyKey)
@@ -1314,6 +1612,16 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	nodeConfig.RequiredAvailableLogDiskSpace, nodeConfig.WarningThresholdAvailableLogDiskSpace, err = getLogDiskSpaceConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
+	nodeConfig.RequiredAvailableFDs, nodeConfig.WarningThresholdAvailableFDs, err = getFDConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	nodeConfig.CPUTargeterConfig, err = getCPUTargeterConfig(v)
 	if err != nil {
 		return node.Config{}, err
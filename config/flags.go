@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ulimit"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 )
 
 const (
@@ -39,9 +40,11 @@ var (
 	defaultDBDir           = filepath.Join(defaultUnexpandedDataDir, "db")
 	defaultLogDir          = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir      = filepath.Join(defaultUnexpandedDataDir, "profiles")
+	defaultBackupDir       = filepath.Join(defaultUnexpandedDataDir, "backups")
 	defaultStakingPath     = filepath.Join(defaultUnexpandedDataDir, "staking")
 	defaultStakingKeyPath  = filepath.Join(defaultStakingPath, "staker.key")
 	defaultStakingCertPath = filepath.Join(defaultStakingPath, "staker.crt")
+	defaultSignerKeyPath   = filepath.Join(defaultStakingPath, "signer.key")
 	defaultConfigDir       = filepath.Join(defaultUnexpandedDataDir, "configs")
 	defaultChainConfigDir  = filepath.Join(defaultConfigDir, "chains")
 	defaultVMConfigDir     = filepath.Join(defaultConfigDir, "vms")
@@ -110,6 +113,9 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(DBPathKey, defaultDBDir, "Path to database directory")
 	fs.String(DBConfigFileKey, "", fmt.Sprintf("Path to database config file. Ignored if %s is specified", DBConfigContentKey))
 	fs.String(DBConfigContentKey, "", "Specifies base64 encoded database config content")
+	fs.Bool(DBMigrationDryRunKey, false, "If true, logs the database migrations that would be run and exits without running them")
+	fs.String(DBBackupDirKey, defaultBackupDir, "Directory that backups of the database are written to")
+	fs.String(DBRestoreFileKey, "", "Path to a backup that is restored on startup. The database must be empty, unless the same backup was already restored into it")
 
 	// Logging
 	fs.String(LogsDirKey, defaultLogDir, "Logging directory for Avalanche")
@@ -120,6 +126,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint(LogRotaterMaxFilesKey, 7, "The maximum number of old log files to retain. 0 means retain all old log files.")
 	fs.Uint(LogRotaterMaxAgeKey, 0, "The maximum number of days to retain old log files based on the timestamp encoded in their filename. 0 means retain all old log files.")
 	fs.Bool(LogRotaterCompressEnabledKey, false, "Enables the compression of rotated log files through gzip.")
+	fs.Uint(LogRotaterMaxTotalSizeKey, 0, "The maximum size in megabytes of a log file and all of its rotated log files. Once exceeded, the oldest rotated log files are removed. 0 means there is no limit.")
+	fs.String(LogRotaterOverridesKey, "", "JSON object mapping logger names to the rotation config to use for that logger, e.g. {\"C\":{\"maxSize\":16,\"maxFiles\":4,\"maxAge\":0,\"maxTotalSize\":64,\"compress\":true}}. Loggers that aren't specified use the default rotation config.")
 	fs.Bool(LogDisableDisplayPluginLogsKey, false, "Disables displaying plugin logs in stdout.")
 
 	// Assertions
@@ -162,7 +170,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(NetworkMaximumTimeoutKey, 10*time.Second, "Maximum timeout value of the adaptive timeout manager")
 	fs.Duration(NetworkMaximumInboundTimeoutKey, 10*time.Second, "Maximum timeout value of an inbound message. Defines duration within which an incoming message must be fulfilled. Incoming messages containing deadline higher than this value will be overridden with this value.")
 	fs.Duration(NetworkTimeoutHalflifeKey, 5*time.Minute, "Halflife of average network response time. Higher value --> network timeout is less volatile. Can't be 0")
-	fs.Float64(NetworkTimeoutCoefficientKey, 2, "Multiplied by average network response time to get the network timeout. Must be >= 1")
+	fs.Float64(NetworkTimeoutCoefficientKey, 2, "Multiplied by the network response time to get the network timeout. Must be >= 1")
+	fs.Float64(NetworkTimeoutPercentileKey, 0.5, "Percentile of the network response time used to get the network timeout. Must be in [0, 1]. If 0, the average network response time is used")
 	fs.Duration(NetworkReadHandshakeTimeoutKey, 15*time.Second, "Timeout value for reading handshake messages")
 	fs.Duration(NetworkPingTimeoutKey, constants.DefaultPingPongTimeout, "Timeout value for Ping-Pong with a peer")
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
@@ -201,6 +210,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(InboundThrottlerBandwidthMaxBurstSizeKey, constants.DefaultMaxMessageSize, "Max inbound bandwidth a node can use at once. Must be at least the max message size. See BandwidthThrottler")
 	fs.Duration(InboundThrottlerCPUMaxRecheckDelayKey, 5*time.Second, "In the CPU-based network throttler, check at least this often whether the node's CPU usage has fallen to an acceptable level")
 	fs.Duration(InboundThrottlerDiskMaxRecheckDelayKey, 5*time.Second, "In the disk-based network throttler, check at least this often whether the node's disk usage has fallen to an acceptable level")
+	fs.Float64(InboundThrottlerCPUMaxUsageKey, .9*float64(runtime.NumCPU()), "Number of CPUs that, if exceeded, will rate limit the nodes using the most CPU first. If 0, this limit is disabled. Value should be in range [0, total core count]")
+	fs.Float64(InboundThrottlerDiskMaxUsageKey, 0, "Number of disk reads per second that, if exceeded, will rate limit the nodes using the most disk reads first. If 0, this limit is disabled. Must be >= 0")
 
 	// Outbound Throttling
 	fs.Uint64(OutboundThrottlerAtLargeAllocSizeKey, 32*units.MiB, "Size, in bytes, of at-large byte allocation in outbound message throttler")
@@ -223,6 +234,15 @@ func addNodeFlags(fs *flag.FlagSet) {
 		fmt.Sprintf("Password file used to initially create/validate API authorization tokens. Ignored if %s is specified. Leading and trailing whitespace is removed from the password. Can be changed via API call",
 			APIAuthPasswordKey))
 	fs.String(APIAuthPasswordKey, "", "Specifies password for API authorization tokens")
+	fs.Bool(APIRateLimitEnabledKey, false, "If true, API requests are rate limited per client. Clients are identified by their auth token, if a valid one is provided, and otherwise by their IP address")
+	fs.Float64(APIRateLimitRequestsPerSecondKey, 50, "Number of API requests per second a single client can sustain")
+	fs.Int(APIRateLimitBurstKey, 100, "Maximum number of API requests a single client can make at once")
+	fs.Float64(APIRateLimitExpensiveRequestsPerSecondKey, 1, fmt.Sprintf("Number of requests per second a single client can sustain to the methods in %s", APIRateLimitExpensiveMethodsKey))
+	fs.Int(APIRateLimitExpensiveBurstKey, 5, fmt.Sprintf("Maximum number of requests a single client can make at once to the methods in %s", APIRateLimitExpensiveMethodsKey))
+	fs.String(APIRateLimitExpensiveMethodsKey, "platform.getStake platform.getCurrentValidators index.getContainerRange avm.getAddressTxs", "Space separated JSON-RPC methods that are additionally charged against the expensive rate limit")
+	fs.Duration(APIRateLimitClientIdleTimeoutKey, 10*time.Minute, "Duration after a client's last API request that its rate limit state is discarded")
+	fs.Uint64(APIRateLimitQuotaRequestsKey, 0, fmt.Sprintf("Total number of API requests a single client can make per %s. If 0, clients don't have a quota", APIRateLimitQuotaWindowKey))
+	fs.Duration(APIRateLimitQuotaWindowKey, 24*time.Hour, "Duration of a client's API request quota window, starting at its first request")
 
 	// Enable/Disable APIs
 	fs.Bool(AdminAPIEnabledKey, false, "If true, this node exposes the Admin API")
@@ -231,6 +251,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Bool(MetricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
 	fs.Bool(HealthAPIEnabledKey, true, "If true, this node exposes the Health API")
 	fs.Bool(IpcAPIEnabledKey, false, "If true, IPCs can be opened")
+	fs.Bool(GRPCAPIEnabledKey, true, "If true, this node exposes gRPC mirrors of the Info, Health, Platform and AVM APIs on the HTTP port")
 
 	// Health Checks
 	fs.Duration(HealthCheckFreqKey, 30*time.Second, "Time between health checks")
@@ -254,6 +275,10 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(StakingKeyContentKey, "", "Specifies base64 encoded TLS private key for staking")
 	fs.String(StakingCertPathKey, defaultStakingCertPath, fmt.Sprintf("Path to the TLS certificate for staking. Ignored if %s is specified", StakingCertContentKey))
 	fs.String(StakingCertContentKey, "", "Specifies base64 encoded TLS certificate for staking")
+	fs.String(StakingKeyTypeKey, "rsa", "Type of key (rsa, ecdsa, or ed25519) to use when generating a staking TLS key and certificate. Peers must support ed25519 certificates to connect to a node using one")
+	fs.Bool(StakingEphemeralSignerEnabledKey, false, "If true, the node uses an ephemeral BLS signer key")
+	fs.String(StakingSignerKeyPathKey, defaultSignerKeyPath, fmt.Sprintf("Path to the BLS signer key. Ignored if %s is specified", StakingSignerKeyContentKey))
+	fs.String(StakingSignerKeyContentKey, "", "Specifies base64 encoded BLS signer key")
 	fs.Uint64(StakingDisabledWeightKey, 100, "Weight to provide to each peer when staking is disabled")
 	// Uptime Requirement
 	fs.Float64(UptimeRequirementKey, genesis.LocalParams.UptimeRequirement, "Fraction of time a validator must be online to receive rewards")
@@ -273,6 +298,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(StakeMinConsumptionRateKey, genesis.LocalParams.RewardConfig.MinConsumptionRate, "Minimum consumption rate of the remaining tokens to mint in the staking function")
 	fs.Duration(StakeMintingPeriodKey, genesis.LocalParams.RewardConfig.MintingPeriod, "Consumption period of the staking function")
 	fs.Uint64(StakeSupplyCapKey, genesis.LocalParams.RewardConfig.SupplyCap, "Supply cap of the staking function")
+	fs.String(StakeRewardCalculatorKey, reward.DefaultCalculatorName, "Name of the registered reward calculator that calculates staking rewards, configured by the staking function configs")
 	// Subnets
 	fs.String(WhitelistedSubnetsKey, "", "Whitelist of subnets to validate")
 
@@ -307,14 +333,23 @@ func addNodeFlags(fs *flag.FlagSet) {
 	// Metrics
 	fs.Bool(MeterVMsEnabledKey, true, "Enable Meter VMs to track VM performance with more granularity")
 	fs.Duration(UptimeMetricFreqKey, 30*time.Second, "Frequency of renewing this node's average uptime metric")
+	fs.String(MetricsAPILatencyBucketsKey, "", "Space separated, increasing list of durations to use as the bucket boundaries of the API request duration histogram. If empty, a default set of boundaries is used")
+	fs.String(MetricsConsensusLatencyBucketsKey, "", "Space separated, increasing list of durations to use as the bucket boundaries of the consensus latency histograms. If empty, only average consensus latencies are reported")
+	fs.Int(MetricsMaxChainLabelsKey, 32, "Max number of chains whose API requests are reported under their own metric label. Requests to any other chain are reported under the \"other\" label. If 0, every chain gets its own label")
 
 	// IPC
 	fs.String(IpcsChainIDsKey, "", "Comma separated list of chain ids to add to the IPC engine. Example: 11111111111111111111111111111111LpoYY,4R5p2RXDGLqaifZE4hHWH9owe34pfoBULn1DrQTWivjg8o4aH")
 	fs.String(IpcsPathKey, "", "The directory (Unix) or named pipe name prefix (Windows) for IPC sockets")
+	fs.String(IpcsPublishersFileKey, "", fmt.Sprintf("Specifies a JSON file that maps chain IDs to the publisher of their IPC events. Chains that aren't specified publish to IPC sockets. Ignored if %s is specified", IpcsPublishersContentKey))
+	fs.String(IpcsPublishersContentKey, "", "Specifies base64 encoded map of chain IDs to the publisher of their IPC events")
 
 	// Indexer
 	fs.Bool(IndexEnabledKey, false, "If true, index all accepted containers and transactions and expose them via an API")
 	fs.Bool(IndexAllowIncompleteKey, false, "If true, allow running the node in such a way that could cause an index to miss transactions. Ignored if index is disabled")
+	fs.Bool(IndexAddressesEnabledKey, false, "If true, also index accepted transactions by the addresses they reference, for chains whose VM supports it. Only transactions accepted while this is enabled are indexed by address. Ignored if index is disabled")
+
+	// Pruning
+	fs.Uint64(PChainPruningHorizonKey, 0, "Number of blocks, behind the last accepted P-chain block, of P-chain validator set history and reward UTXOs to retain. Older history is pruned, so this node can't serve validator sets at older heights or bootstrap other chains from their old blocks. If 0, nothing is pruned")
 
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
@@ -327,6 +362,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Bool(ProfileContinuousEnabledKey, false, "Whether the app should continuously produce performance profiles")
 	fs.Duration(ProfileContinuousFreqKey, 15*time.Minute, "How frequently to rotate performance profiles")
 	fs.Int(ProfileContinuousMaxFilesKey, 5, "Maximum number of historical profiles to keep")
+	fs.Float64(ProfileContinuousCPUThresholdKey, 0, "Number of CPU cores in use that causes the continuous profiler to write its profiles immediately. If 0, CPU usage doesn't trigger profiles")
+	fs.Uint64(ProfileContinuousMemoryThresholdKey, 0, "Number of bytes of heap in use that causes the continuous profiler to write its profiles immediately. If 0, memory usage doesn't trigger profiles")
 	fs.String(VMAliasesFileKey, defaultVMAliasFilePath, fmt.Sprintf("Specifies a JSON file that maps vmIDs with custom aliases. Ignored if %s is specified", VMAliasesContentKey))
 	fs.String(VMAliasesContentKey, "", "Specifies base64 encoded maps vmIDs with custom aliases")
 
@@ -341,6 +378,10 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(SystemTrackerDiskHalflifeKey, time.Minute, "Halflife to use for the disk tracker. Larger halflife --> disk usage metrics change more slowly")
 	fs.Uint64(SystemTrackerRequiredAvailableDiskSpaceKey, units.GiB/2, "Minimum number of available bytes on disk, under which the node will shutdown.")
 	fs.Uint64(SystemTrackerWarningThresholdAvailableDiskSpaceKey, units.GiB, fmt.Sprintf("Warning threshold for the number of available bytes on disk, under which the node will be considered unhealthy.  Must be >= [%s]", SystemTrackerRequiredAvailableDiskSpaceKey))
+	fs.Uint64(SystemTrackerRequiredAvailableLogSpaceKey, units.GiB/4, "Minimum number of available bytes on the disk holding the logs, under which the node will be considered unhealthy")
+	fs.Uint64(SystemTrackerWarningThresholdAvailableLogSpaceKey, units.GiB/2, fmt.Sprintf("Warning threshold for the number of available bytes on the disk holding the logs, under which a warning will be logged. Must be >= [%s]", SystemTrackerRequiredAvailableLogSpaceKey))
+	fs.Uint64(SystemTrackerRequiredAvailableFDsKey, 128, "Minimum number of file descriptors the node must still be able to open, under which the node will be considered unhealthy")
+	fs.Uint64(SystemTrackerWarningThresholdAvailableFDsKey, 1024, fmt.Sprintf("Warning threshold for the number of file descriptors the node can still open, under which a warning will be logged. Must be >= [%s]", SystemTrackerRequiredAvailableFDsKey))
 
 	// CPU management
 	fs.Float64(CPUVdrAllocKey, float64(runtime.NumCPU()), "Maximum number of CPUs to allocate for use by validators. Value should be in range [0, total core count]")
@@ -28,12 +28,16 @@ const (
 	StakeMinConsumptionRateKey                         = "stake-min-consumption-rate"
 	StakeMintingPeriodKey                              = "stake-minting-period"
 	StakeSupplyCapKey                                  = "stake-supply-cap"
+	StakeRewardCalculatorKey                           = "stake-reward-calculator"
 	AssertionsEnabledKey                               = "assertions-enabled"
 	SignatureVerificationEnabledKey                    = "signature-verification-enabled"
 	DBTypeKey                                          = "db-type"
 	DBPathKey                                          = "db-dir"
 	DBConfigFileKey                                    = "db-config-file"
 	DBConfigContentKey                                 = "db-config-file-content"
+	DBMigrationDryRunKey                               = "db-migration-dry-run"
+	DBBackupDirKey                                     = "db-backup-dir"
+	DBRestoreFileKey                                   = "db-restore-file"
 	PublicIPKey                                        = "public-ip"
 	DynamicUpdateDurationKey                           = "dynamic-update-duration"
 	DynamicPublicIPResolverKey                         = "dynamic-public-ip"
@@ -56,6 +60,15 @@ const (
 	APIAuthRequiredKey                                 = "api-auth-required"
 	APIAuthPasswordKey                                 = "api-auth-password"
 	APIAuthPasswordFileKey                             = "api-auth-password-file"
+	APIRateLimitEnabledKey                             = "api-rate-limit-enabled"
+	APIRateLimitRequestsPerSecondKey                   = "api-rate-limit-requests-per-second"
+	APIRateLimitBurstKey                               = "api-rate-limit-burst"
+	APIRateLimitExpensiveRequestsPerSecondKey          = "api-rate-limit-expensive-requests-per-second"
+	APIRateLimitExpensiveBurstKey                      = "api-rate-limit-expensive-burst"
+	APIRateLimitExpensiveMethodsKey                    = "api-rate-limit-expensive-methods"
+	APIRateLimitClientIdleTimeoutKey                   = "api-rate-limit-client-idle-timeout"
+	APIRateLimitQuotaRequestsKey                       = "api-rate-limit-quota-requests"
+	APIRateLimitQuotaWindowKey                         = "api-rate-limit-quota-window"
 	StateSyncIPsKey                                    = "state-sync-ips"
 	StateSyncIDsKey                                    = "state-sync-ids"
 	BootstrapIPsKey                                    = "bootstrap-ips"
@@ -67,6 +80,10 @@ const (
 	StakingKeyContentKey                               = "staking-tls-key-file-content"
 	StakingCertPathKey                                 = "staking-tls-cert-file"
 	StakingCertContentKey                              = "staking-tls-cert-file-content"
+	StakingKeyTypeKey                                  = "staking-tls-key-type"
+	StakingEphemeralSignerEnabledKey                   = "staking-ephemeral-signer-enabled"
+	StakingSignerKeyPathKey                            = "staking-signer-key-file"
+	StakingSignerKeyContentKey                         = "staking-signer-key-file-content"
 	StakingDisabledWeightKey                           = "staking-disabled-weight"
 	NetworkInitialTimeoutKey                           = "network-initial-timeout"
 	NetworkMinimumTimeoutKey                           = "network-minimum-timeout"
@@ -74,6 +91,7 @@ const (
 	NetworkMaximumInboundTimeoutKey                    = "network-maximum-inbound-timeout"
 	NetworkTimeoutHalflifeKey                          = "network-timeout-halflife"
 	NetworkTimeoutCoefficientKey                       = "network-timeout-coefficient"
+	NetworkTimeoutPercentileKey                        = "network-timeout-percentile"
 	NetworkHealthMinPeersKey                           = "network-health-min-conn-peers"
 	NetworkHealthMaxTimeSinceMsgReceivedKey            = "network-health-max-time-since-msg-received"
 	NetworkHealthMaxTimeSinceMsgSentKey                = "network-health-max-time-since-msg-sent"
@@ -108,6 +126,8 @@ const (
 	LogRotaterMaxFilesKey                              = "log-rotater-max-files"
 	LogRotaterMaxAgeKey                                = "log-rotater-max-age"
 	LogRotaterCompressEnabledKey                       = "log-rotater-compress-enabled"
+	LogRotaterMaxTotalSizeKey                          = "log-rotater-max-total-size"
+	LogRotaterOverridesKey                             = "log-rotater-overrides"
 	LogDisableDisplayPluginLogsKey                     = "log-disable-display-plugin-logs"
 	SnowSampleSizeKey                                  = "snow-sample-size"
 	SnowQuorumSizeKey                                  = "snow-quorum-size"
@@ -126,10 +146,16 @@ const (
 	InfoAPIEnabledKey                                  = "api-info-enabled"
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
+	MetricsAPILatencyBucketsKey                        = "metrics-api-latency-buckets"
+	MetricsConsensusLatencyBucketsKey                  = "metrics-consensus-latency-buckets"
+	MetricsMaxChainLabelsKey                           = "metrics-max-chain-labels"
 	HealthAPIEnabledKey                                = "api-health-enabled"
 	IpcAPIEnabledKey                                   = "api-ipcs-enabled"
+	GRPCAPIEnabledKey                                  = "api-grpc-enabled"
 	IpcsChainIDsKey                                    = "ipcs-chain-ids"
 	IpcsPathKey                                        = "ipcs-path"
+	IpcsPublishersFileKey                              = "ipcs-publishers-file"
+	IpcsPublishersContentKey                           = "ipcs-publishers-file-content"
 	MeterVMsEnabledKey                                 = "meter-vms-enabled"
 	ConsensusGossipFrequencyKey                        = "consensus-gossip-frequency"
 	ConsensusGossipAcceptedFrontierValidatorSizeKey    = "consensus-accepted-frontier-gossip-validator-size"
@@ -145,6 +171,8 @@ const (
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
+	IndexAddressesEnabledKey                           = "index-addresses-enabled"
+	PChainPruningHorizonKey                            = "p-chain-pruning-horizon"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
@@ -164,6 +192,8 @@ const (
 	ProfileContinuousEnabledKey                        = "profile-continuous-enabled"
 	ProfileContinuousFreqKey                           = "profile-continuous-freq"
 	ProfileContinuousMaxFilesKey                       = "profile-continuous-max-files"
+	ProfileContinuousCPUThresholdKey                   = "profile-continuous-cpu-threshold"
+	ProfileContinuousMemoryThresholdKey                = "profile-continuous-memory-threshold"
 	InboundThrottlerAtLargeAllocSizeKey                = "throttler-inbound-at-large-alloc-size"
 	InboundThrottlerVdrAllocSizeKey                    = "throttler-inbound-validator-alloc-size"
 	InboundThrottlerNodeMaxAtLargeBytesKey             = "throttler-inbound-node-max-at-large-bytes"
@@ -172,6 +202,8 @@ const (
 	InboundThrottlerBandwidthMaxBurstSizeKey           = "throttler-inbound-bandwidth-max-burst-size"
 	InboundThrottlerCPUMaxRecheckDelayKey              = "throttler-inbound-cpu-max-recheck-delay"
 	InboundThrottlerDiskMaxRecheckDelayKey             = "throttler-inbound-disk-max-recheck-delay"
+	InboundThrottlerCPUMaxUsageKey                     = "throttler-inbound-cpu-max-usage"
+	InboundThrottlerDiskMaxUsageKey                    = "throttler-inbound-disk-max-usage"
 	CPUVdrAllocKey                                     = "throttler-inbound-cpu-validator-alloc"
 	CPUMaxNonVdrUsageKey                               = "throttler-inbound-cpu-max-non-validator-usage"
 	CPUMaxNonVdrNodeUsageKey                           = "throttler-inbound-cpu-max-non-validator-node-usage"
@@ -181,6 +213,10 @@ const (
 	SystemTrackerDiskHalflifeKey                       = "system-tracker-disk-halflife"
 	SystemTrackerRequiredAvailableDiskSpaceKey         = "system-tracker-disk-required-available-space"
 	SystemTrackerWarningThresholdAvailableDiskSpaceKey = "system-tracker-disk-warning-threshold-available-space"
+	SystemTrackerRequiredAvailableLogSpaceKey          = "system-tracker-log-disk-required-available-space"
+	SystemTrackerWarningThresholdAvailableLogSpaceKey  = "system-tracker-log-disk-warning-threshold-available-space"
+	SystemTrackerRequiredAvailableFDsKey               = "system-tracker-fd-required-available"
+	SystemTrackerWarningThresholdAvailableFDsKey       = "system-tracker-fd-warning-threshold-available"
 	DiskVdrAllocKey                                    = "throttler-inbound-disk-validator-alloc"
 	DiskMaxNonVdrUsageKey                              = "throttler-inbound-disk-max-non-validator-usage"
 	DiskMaxNonVdrNodeUsageKey                          = "throttler-inbound-disk-max-non-validator-node-usage"
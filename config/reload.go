@@ -0,0 +1,260 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/viper"
+
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/node"
+)
+
+var (
+	_ node.ConfigReloader = &configReloader{}
+
+	logLevelKeys = []string{
+		LogLevelKey,
+		LogDisplayLevelKey,
+	}
+	apiRateLimitKeys = []string{
+		APIRateLimitRequestsPerSecondKey,
+		APIRateLimitBurstKey,
+		APIRateLimitExpensiveRequestsPerSecondKey,
+		APIRateLimitExpensiveBurstKey,
+		APIRateLimitExpensiveMethodsKey,
+		APIRateLimitClientIdleTimeoutKey,
+		APIRateLimitQuotaRequestsKey,
+		APIRateLimitQuotaWindowKey,
+	}
+	networkHealthKeys = []string{
+		NetworkHealthMinPeersKey,
+		NetworkHealthMaxTimeSinceMsgReceivedKey,
+		NetworkHealthMaxTimeSinceMsgSentKey,
+		NetworkHealthMaxPortionSendQueueFillKey,
+		NetworkHealthMaxSendFailRateKey,
+	}
+	routerHealthKeys = []string{
+		RouterHealthMaxDropRateKey,
+		RouterHealthMaxOutstandingRequestsKey,
+		NetworkHealthMaxOutstandingDurationKey,
+	}
+	inboundBandwidthThrottlerKeys = []string{
+		InboundThrottlerBandwidthRefillRateKey,
+		InboundThrottlerBandwidthMaxBurstSizeKey,
+	}
+	// The chain configs that these keys point to are compared instead of the
+	// keys themselves.
+	chainConfigKeys = []string{
+		ChainConfigDirKey,
+		ChainConfigContentKey,
+	}
+)
+
+// configReloader re-parses the node's flags, environment variables and config
+// file to find the changes that can be applied to a running node.
+type configReloader struct {
+	args []string
+
+	// Config the node was started with
+	startup *viper.Viper
+	// Config that was most recently applied by the node
+	applied *viper.Viper
+	// Config that was most recently reloaded, but hasn't been committed yet
+	pending *viper.Viper
+
+	// Chain configs the node was started with
+	startupChainConfigs map[string]chains.ChainConfig
+	// Chain configs that were most recently delivered to their chains
+	appliedChainConfigs map[string]chains.ChainConfig
+	// Chain configs that were most recently reloaded, but haven't been
+	// committed yet
+	pendingChainConfigs map[string]chains.ChainConfig
+}
+
+// NewConfigReloader returns a ConfigReloader for a node that was started with
+// the command line arguments [args], the config [v] and the chain configs
+// [chainConfigs].
+func NewConfigReloader(args []string, v *viper.Viper, chainConfigs map[string]chains.ChainConfig) node.ConfigReloader {
+	appliedChainConfigs := make(map[string]chains.ChainConfig, len(chainConfigs))
+	for alias, chainConfig := range chainConfigs {
+		appliedChainConfigs[alias] = chainConfig
+	}
+	return &configReloader{
+		args:                args,
+		startup:             v,
+		applied:             v,
+		startupChainConfigs: chainConfigs,
+		appliedChainConfigs: appliedChainConfigs,
+	}
+}
+
+func (r *configReloader) Reload() (node.ReloadableConfig, node.ReloadReport, error) {
+	v, err := BuildViper(BuildFlagSet(), r.args)
+	if err != nil {
+		return node.ReloadableConfig{}, node.ReloadReport{}, fmt.Errorf("couldn't configure flags: %w", err)
+	}
+
+	// The halflives of the health averagers can't be changed, so the values
+	// the node was started with are used.
+	halflife := r.startup.GetDuration(HealthCheckAveragerHalflifeKey)
+
+	loggingConfig, err := getLoggingConfig(v)
+	if err != nil {
+		return node.ReloadableConfig{}, node.ReloadReport{}, err
+	}
+	networkConfig, err := getNetworkConfig(v, halflife)
+	if err != nil {
+		return node.ReloadableConfig{}, node.ReloadReport{}, err
+	}
+	routerHealthConfig, err := getRouterHealthConfig(v, halflife)
+	if err != nil {
+		return node.ReloadableConfig{}, node.ReloadReport{}, err
+	}
+
+	// API rate limiting can only be reconfigured if the rate limiter was
+	// created when the node started.
+	apiRateLimitEnabled := r.startup.GetBool(APIRateLimitEnabledKey)
+	apiRateLimitConfig := getAPIRateLimitConfig(v)
+	apiRateLimitConfig.Enabled = apiRateLimitEnabled
+	if err := apiRateLimitConfig.Verify(); err != nil {
+		return node.ReloadableConfig{}, node.ReloadReport{}, err
+	}
+	chainConfigs, err := getChainConfigs(v)
+	if err != nil {
+		return node.ReloadableConfig{}, node.ReloadReport{}, err
+	}
+
+	var (
+		config     node.ReloadableConfig
+		report     node.ReloadReport
+		reloadable = make(map[string]bool)
+	)
+	apply := func(keys []string) bool {
+		changed := false
+		for _, key := range keys {
+			reloadable[key] = true
+			if !equalValues(r.applied, v, key) {
+				report.Applied = append(report.Applied, key)
+				changed = true
+			}
+		}
+		return changed
+	}
+
+	if apply(logLevelKeys) {
+		config.LogLevel = &loggingConfig.LogLevel
+		config.LogDisplayLevel = &loggingConfig.DisplayLevel
+	}
+	if apiRateLimitEnabled && apply(apiRateLimitKeys) {
+		config.APIRateLimitConfig = &apiRateLimitConfig
+	}
+	if apply(networkHealthKeys) {
+		config.NetworkHealthConfig = &networkConfig.HealthConfig
+	}
+	if apply(routerHealthKeys) {
+		config.RouterHealthConfig = &routerHealthConfig
+	}
+	if apply(inboundBandwidthThrottlerKeys) {
+		config.InboundBandwidthThrottlerConfig = &networkConfig.ThrottlerConfig.InboundMsgThrottlerConfig.BandwidthThrottlerConfig
+	}
+	for _, key := range chainConfigKeys {
+		reloadable[key] = true
+	}
+	config.ChainConfigs = r.reloadChainConfigs(v, chainConfigs, &report)
+
+	// Any other key that differs from the value the node was started with
+	// won't take effect until the node is restarted.
+	keys := make(map[string]struct{})
+	for _, key := range r.startup.AllKeys() {
+		keys[key] = struct{}{}
+	}
+	for _, key := range v.AllKeys() {
+		keys[key] = struct{}{}
+	}
+	for key := range keys {
+		if !reloadable[key] && !equalValues(r.startup, v, key) {
+			report.RequiresRestart = append(report.RequiresRestart, key)
+		}
+	}
+
+	sort.Strings(report.Applied)
+	sort.Strings(report.RequiresRestart)
+	r.pending = v
+	r.pendingChainConfigs = chainConfigs
+	return config, report, nil
+}
+
+func (r *configReloader) Commit(chainAliases []string) {
+	if r.pending != nil {
+		r.applied = r.pending
+		r.pending = nil
+	}
+	for _, alias := range chainAliases {
+		chainConfig, ok := r.pendingChainConfigs[alias]
+		if !ok {
+			delete(r.appliedChainConfigs, alias)
+			continue
+		}
+		r.appliedChainConfigs[alias] = chainConfig
+	}
+	r.pendingChainConfigs = nil
+}
+
+// reloadChainConfigs returns the chain configs in [chainConfigs] that changed
+// since they were last reloaded and adds them to [report]. Changes to the
+// upgrade bytes of chains are reported as requiring a restart.
+func (r *configReloader) reloadChainConfigs(
+	v *viper.Viper,
+	chainConfigs map[string]chains.ChainConfig,
+	report *node.ReloadReport,
+) map[string]node.ReloadedChainConfig {
+	source := ChainConfigDirKey
+	if v.IsSet(ChainConfigContentKey) {
+		source = ChainConfigContentKey
+	}
+
+	aliases := make(map[string]struct{})
+	for alias := range chainConfigs {
+		aliases[alias] = struct{}{}
+	}
+	for alias := range r.appliedChainConfigs {
+		aliases[alias] = struct{}{}
+	}
+	for alias := range r.startupChainConfigs {
+		aliases[alias] = struct{}{}
+	}
+
+	var reloaded map[string]node.ReloadedChainConfig
+	for alias := range aliases {
+		chainConfig := chainConfigs[alias]
+		if !bytes.Equal(chainConfig.Config, r.appliedChainConfigs[alias].Config) {
+			key := fmt.Sprintf("%s.%s.%s", source, alias, chainConfigFileName)
+			if reloaded == nil {
+				reloaded = make(map[string]node.ReloadedChainConfig)
+			}
+			reloaded[alias] = node.ReloadedChainConfig{
+				Key:    key,
+				Config: chainConfig.Config,
+			}
+			report.Applied = append(report.Applied, key)
+		}
+		if !bytes.Equal(chainConfig.Upgrade, r.startupChainConfigs[alias].Upgrade) {
+			key := fmt.Sprintf("%s.%s.%s", source, alias, chainUpgradeFileName)
+			report.RequiresRestart = append(report.RequiresRestart, key)
+		}
+	}
+	return reloaded
+}
+
+// equalValues returns true if [key] has the same value in [a] and [b]. Values
+// are compared by their string representation because the same value may be
+// parsed into different types depending on whether it was provided by a flag,
+// an environment variable or the config file.
+func equalValues(a, b *viper.Viper, key string) bool {
+	return fmt.Sprint(a.Get(key)) == fmt.Sprint(b.Get(key))
+}
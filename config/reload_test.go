@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestConfigReloader(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	configFile := setupConfigJSON(t, root, fmt.Sprintf(`{%q: "info", %q: 9650}`, LogLevelKey, HTTPPortKey))
+	args := []string{"--" + ConfigFileKey + "=" + configFile}
+
+	v, err := BuildViper(BuildFlagSet(), args)
+	assert.NoError(err)
+	r := NewConfigReloader(args, v, nil)
+
+	// Nothing has changed since the node started
+	config, report, err := r.Reload()
+	assert.NoError(err)
+	assert.Equal(node.ReloadableConfig{}, config)
+	assert.Empty(report.Applied)
+	assert.Empty(report.RequiresRestart)
+	r.Commit(nil)
+
+	setupConfigJSON(t, root, fmt.Sprintf(`{%q: "debug", %q: 9651, %q: 3}`, LogLevelKey, HTTPPortKey, NetworkHealthMinPeersKey))
+	config, report, err = r.Reload()
+	assert.NoError(err)
+	assert.Equal(logging.Debug, *config.LogLevel)
+	assert.Equal(logging.Debug, *config.LogDisplayLevel)
+	assert.EqualValues(3, config.NetworkHealthConfig.MinConnectedPeers)
+	assert.Nil(config.APIRateLimitConfig)
+	assert.Nil(config.RouterHealthConfig)
+	assert.Nil(config.InboundBandwidthThrottlerConfig)
+	assert.Equal([]string{LogLevelKey, NetworkHealthMinPeersKey}, report.Applied)
+	assert.Equal([]string{HTTPPortKey}, report.RequiresRestart)
+
+	// The changes are reported again until they are committed
+	config, report, err = r.Reload()
+	assert.NoError(err)
+	assert.Equal(logging.Debug, *config.LogLevel)
+	assert.Equal([]string{LogLevelKey, NetworkHealthMinPeersKey}, report.Applied)
+	r.Commit(nil)
+
+	// Reloading again only reports the changes that still require a restart
+	config, report, err = r.Reload()
+	assert.NoError(err)
+	assert.Equal(node.ReloadableConfig{}, config)
+	assert.Empty(report.Applied)
+	assert.Equal([]string{HTTPPortKey}, report.RequiresRestart)
+
+	setupConfigJSON(t, root, fmt.Sprintf(`{%q: 2}`, NetworkHealthMaxSendFailRateKey))
+	_, _, err = r.Reload()
+	assert.Error(err)
+}
+
+func TestConfigReloaderChainConfigs(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	chainsDir := filepath.Join(root, "chains")
+	setupFile(t, filepath.Join(chainsDir, "C"), chainConfigFileName+".json", `{"a":1}`)
+	setupFile(t, filepath.Join(chainsDir, "X"), chainConfigFileName+".json", `{"b":1}`)
+	args := []string{"--" + ChainConfigDirKey + "=" + chainsDir}
+
+	v, err := BuildViper(BuildFlagSet(), args)
+	assert.NoError(err)
+	chainConfigs, err := getChainConfigs(v)
+	assert.NoError(err)
+	r := NewConfigReloader(args, v, chainConfigs)
+
+	config, report, err := r.Reload()
+	assert.NoError(err)
+	assert.Nil(config.ChainConfigs)
+	assert.Empty(report.Applied)
+	assert.Empty(report.RequiresRestart)
+
+	setupFile(t, filepath.Join(chainsDir, "C"), chainConfigFileName+".json", `{"a":2}`)
+	setupFile(t, filepath.Join(chainsDir, "C"), chainUpgradeFileName+".json", `{}`)
+	config, report, err = r.Reload()
+	assert.NoError(err)
+	assert.Equal(map[string]node.ReloadedChainConfig{
+		"C": {
+			Key:    "chain-config-dir.C.config",
+			Config: []byte(`{"a":2}`),
+		},
+	}, config.ChainConfigs)
+	assert.Equal([]string{"chain-config-dir.C.config"}, report.Applied)
+	assert.Equal([]string{"chain-config-dir.C.upgrade"}, report.RequiresRestart)
+	r.Commit([]string{"C"})
+
+	// Removing the config of a chain resets it to the empty config
+	assert.NoError(os.RemoveAll(filepath.Join(chainsDir, "X")))
+	setupFile(t, filepath.Join(chainsDir, "C"), chainConfigFileName+".json", `{"a":3}`)
+	config, report, err = r.Reload()
+	assert.NoError(err)
+	assert.Equal(map[string]node.ReloadedChainConfig{
+		"C": {
+			Key:    "chain-config-dir.C.config",
+			Config: []byte(`{"a":3}`),
+		},
+		"X": {
+			Key: "chain-config-dir.X.config",
+		},
+	}, config.ChainConfigs)
+	assert.Equal([]string{"chain-config-dir.C.config", "chain-config-dir.X.config"}, report.Applied)
+	assert.Equal([]string{"chain-config-dir.C.upgrade"}, report.RequiresRestart)
+
+	// Only the configs that were delivered to their chains are committed
+	r.Commit([]string{"X"})
+	config, report, err = r.Reload()
+	assert.NoError(err)
+	assert.Equal(map[string]node.ReloadedChainConfig{
+		"C": {
+			Key:    "chain-config-dir.C.config",
+			Config: []byte(`{"a":3}`),
+		},
+	}, config.ChainConfigs)
+	assert.Equal([]string{"chain-config-dir.C.config"}, report.Applied)
+	r.Commit([]string{"C"})
+
+	config, report, err = r.Reload()
+	assert.NoError(err)
+	assert.Nil(config.ChainConfigs)
+	assert.Empty(report.Applied)
+}
@@ -0,0 +1,276 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+// An archive is a gzip stream followed by the SHA-256 checksum of the
+// compressed bytes. The stream contains:
+//   - magic, format version, database version and creation time
+//   - an entry for every key of the database
+//   - an end marker followed by the number of entries
+const (
+	formatVersion uint16 = 0
+
+	entryTag byte = 1
+	endTag   byte = 0
+
+	checksumLen = sha256.Size
+
+	// Number of entries between progress logs
+	logFrequency = 1_000_000
+)
+
+var (
+	magic = [8]byte{'A', 'V', 'A', 'X', 'B', 'K', 'U', 'P'}
+
+	// The prefixes below must match the layout of the database written by the
+	// indexer.
+	indexerDBPrefix         = []byte{0x00}
+	indexPrefixes           = []byte{0x01, 0x02, 0x03}
+	isIncompleteIndexPrefix = byte(0x04)
+	previouslyIndexedPrefix = byte(0x05)
+
+	errBackupExists = errors.New("backup already exists")
+)
+
+// Config describes what is included in a backup.
+type Config struct {
+	// If true, the indices of accepted containers maintained by the indexer
+	// aren't included. Indices that are excluded are marked incomplete in the
+	// backup.
+	ExcludeIndices bool
+}
+
+// Report describes a backup that was written.
+type Report struct {
+	// Number of keys that were included
+	Keys uint64
+	// Number of keys that were excluded
+	ExcludedKeys uint64
+	// Size of the archive, in bytes
+	Size uint64
+	// Checksum of the archive
+	Checksum ids.ID
+}
+
+// WriteFile writes an archive of [db] to a new file at [path]. The file is only
+// created once the archive has been written completely.
+func WriteFile(log logging.Logger, path string, db *manager.VersionedDatabase, config Config) (Report, error) {
+	if _, err := os.Stat(path); err == nil {
+		return Report{}, fmt.Errorf("%w: %s", errBackupExists, path)
+	} else if !os.IsNotExist(err) {
+		return Report{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), perms.ReadWriteExecute); err != nil {
+		return Report{}, fmt.Errorf("couldn't create backup directory: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perms.ReadWrite)
+	if err != nil {
+		return Report{}, err
+	}
+	report, err := Write(log, file, db, config)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return Report{}, err
+	}
+	return report, os.Rename(tmpPath, path)
+}
+
+// Write streams an archive of [db] to [w]. Keys are read from a single
+// iterator, so the archive is a consistent snapshot of [db] even if [db] is
+// written to concurrently.
+func Write(log logging.Logger, w io.Writer, db *manager.VersionedDatabase, config Config) (Report, error) {
+	var (
+		excludedPrefixes map[string]struct{}
+		extraEntries     [][]byte
+		err              error
+	)
+	if config.ExcludeIndices {
+		excludedPrefixes, extraEntries, err = excludeIndices(db)
+		if err != nil {
+			return Report{}, fmt.Errorf("couldn't find indices: %w", err)
+		}
+	}
+
+	hasher := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(w, hasher)}
+	zw := gzip.NewWriter(counter)
+	aw := &archiveWriter{w: bufio.NewWriter(zw)}
+
+	aw.writeBytes(magic[:])
+	aw.writeUint16(formatVersion)
+	aw.writeBytes16([]byte(db.Version.String()))
+	aw.writeUint64(uint64(time.Now().Unix()))
+
+	report := Report{}
+	it := db.Database.NewIterator()
+	for it.Next() && aw.err == nil {
+		key := it.Key()
+		if len(key) >= hashing.HashLen && len(excludedPrefixes) > 0 {
+			if _, excluded := excludedPrefixes[string(key[:hashing.HashLen])]; excluded {
+				report.ExcludedKeys++
+				continue
+			}
+		}
+
+		aw.writeEntry(key, it.Value())
+		report.Keys++
+		if report.Keys%logFrequency == 0 {
+			log.Info("backed up %d keys", report.Keys)
+		}
+	}
+	err = it.Error()
+	it.Release()
+	if err != nil {
+		return Report{}, fmt.Errorf("couldn't iterate over database: %w", err)
+	}
+
+	for _, key := range extraEntries {
+		aw.writeEntry(key, nil)
+		report.Keys++
+	}
+	aw.writeBytes([]byte{endTag})
+	aw.writeUint64(report.Keys)
+	if aw.err == nil {
+		aw.err = aw.w.Flush()
+	}
+	if aw.err == nil {
+		aw.err = zw.Close()
+	}
+	if aw.err != nil {
+		return Report{}, fmt.Errorf("couldn't write archive: %w", aw.err)
+	}
+
+	checksum := hasher.Sum(nil)
+	if _, err := w.Write(checksum); err != nil {
+		return Report{}, fmt.Errorf("couldn't write archive: %w", err)
+	}
+	copy(report.Checksum[:], checksum)
+	report.Size = counter.n + checksumLen
+	return report, nil
+}
+
+// excludeIndices returns the prefixes of the keys of every index of [db] and
+// the keys that mark those indices as incomplete.
+func excludeIndices(db *manager.VersionedDatabase) (map[string]struct{}, [][]byte, error) {
+	indexerDB := prefixdb.New(indexerDBPrefix, db.Database)
+	it := indexerDB.NewIterator()
+	defer it.Release()
+
+	var (
+		excludedPrefixes = make(map[string]struct{})
+		incompleteKeys   [][]byte
+		indexerPrefix    = hashing.ComputeHash256(indexerDBPrefix)
+	)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != hashing.HashLen+1 || key[hashing.HashLen] != previouslyIndexedPrefix {
+			continue
+		}
+
+		// The prefix of a nested prefixed database is hashed with the prefix
+		// of its parent, so the keys of an index are prefixed by the hash of
+		// the indexer's hashed prefix, the chain ID and the type of the index.
+		chainID := key[:hashing.HashLen]
+		for _, indexPrefix := range indexPrefixes {
+			prefix := make([]byte, 0, len(indexerPrefix)+hashing.HashLen+1)
+			prefix = append(prefix, indexerPrefix...)
+			prefix = append(prefix, chainID...)
+			prefix = append(prefix, indexPrefix)
+			excludedPrefixes[string(hashing.ComputeHash256(prefix))] = struct{}{}
+		}
+
+		incompleteKey := make([]byte, 0, len(indexerPrefix)+hashing.HashLen+1)
+		incompleteKey = append(incompleteKey, indexerPrefix...)
+		incompleteKey = append(incompleteKey, chainID...)
+		incompleteKey = append(incompleteKey, isIncompleteIndexPrefix)
+		incompleteKeys = append(incompleteKeys, incompleteKey)
+	}
+	return excludedPrefixes, incompleteKeys, it.Error()
+}
+
+// archiveWriter writes the fields of an archive. After a write fails, further
+// writes are ignored and the error is kept in [err].
+type archiveWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (w *archiveWriter) writeBytes(b []byte) {
+	if w.err == nil {
+		_, w.err = w.w.Write(b)
+	}
+}
+
+func (w *archiveWriter) writeUint16(v uint16) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	w.writeBytes(b[:])
+}
+
+func (w *archiveWriter) writeUint32(v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	w.writeBytes(b[:])
+}
+
+func (w *archiveWriter) writeUint64(v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	w.writeBytes(b[:])
+}
+
+func (w *archiveWriter) writeBytes16(b []byte) {
+	w.writeUint16(uint16(len(b)))
+	w.writeBytes(b)
+}
+
+func (w *archiveWriter) writeBytes32(b []byte) {
+	w.writeUint32(uint32(len(b)))
+	w.writeBytes(b)
+}
+
+func (w *archiveWriter) writeEntry(key, value []byte) {
+	w.writeBytes([]byte{entryTag})
+	w.writeBytes32(key)
+	w.writeBytes32(value)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n uint64
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	n, err := w.w.Write(b)
+	w.n += uint64(n)
+	return n, err
+}
@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestBackupRestore(t *testing.T) {
+	assert := assert.New(t)
+
+	db := manager.NewMemDB(version.CurrentDatabase).Current()
+	entries := map[string][]byte{
+		"":      {1},
+		"key":   {2},
+		"empty": {},
+	}
+	for key, value := range entries {
+		assert.NoError(db.Database.Put([]byte(key), value))
+	}
+
+	path := filepath.Join(t.TempDir(), "backups", "archive")
+	report, err := WriteFile(logging.NoLog{}, path, db, Config{})
+	assert.NoError(err)
+	assert.EqualValues(len(entries), report.Keys)
+	assert.Zero(report.ExcludedKeys)
+
+	info, err := os.Stat(path)
+	assert.NoError(err)
+	assert.EqualValues(info.Size(), report.Size)
+
+	_, err = WriteFile(logging.NoLog{}, path, db, Config{})
+	assert.ErrorIs(err, errBackupExists)
+
+	header, err := Verify(path)
+	assert.NoError(err)
+	assert.Equal(version.CurrentDatabase.String(), header.Version.String())
+	assert.EqualValues(len(entries), header.Keys)
+	assert.Equal(report.Checksum, header.Checksum)
+
+	restoredDB := manager.NewMemDB(version.CurrentDatabase).Current()
+	assert.NoError(RestoreFile(logging.NoLog{}, path, restoredDB))
+	for key, value := range entries {
+		restoredValue, err := restoredDB.Database.Get([]byte(key))
+		assert.NoError(err)
+		assert.Equal(value, restoredValue)
+	}
+
+	// Restoring the same backup again is a no-op
+	assert.NoError(RestoreFile(logging.NoLog{}, path, restoredDB))
+
+	// Restoring into a database that is in use isn't allowed
+	err = RestoreFile(logging.NoLog{}, path, db)
+	assert.ErrorIs(err, errDatabaseNotEmpty)
+
+	// Restoring into a database of a different version isn't allowed
+	err = RestoreFile(logging.NoLog{}, path, manager.NewMemDB(version.DatabaseVersion1_0_0).Current())
+	assert.ErrorIs(err, errWrongVersion)
+}
+
+func TestVerifyCorrupted(t *testing.T) {
+	assert := assert.New(t)
+
+	db := manager.NewMemDB(version.CurrentDatabase).Current()
+	assert.NoError(db.Database.Put([]byte("key"), []byte("value")))
+
+	path := filepath.Join(t.TempDir(), "archive")
+	_, err := WriteFile(logging.NoLog{}, path, db, Config{})
+	assert.NoError(err)
+
+	archive, err := os.ReadFile(path)
+	assert.NoError(err)
+	archive[len(archive)/2]++
+	assert.NoError(os.WriteFile(path, archive, 0o600))
+
+	_, err = Verify(path)
+	assert.ErrorIs(err, errInvalidChecksum)
+
+	assert.NoError(os.WriteFile(path, []byte("short"), 0o600))
+	_, err = Verify(path)
+	assert.ErrorIs(err, errInvalidMagic)
+}
+
+func TestBackupExcludeIndices(t *testing.T) {
+	assert := assert.New(t)
+
+	db := manager.NewMemDB(version.CurrentDatabase).Current()
+	chainID := ids.GenerateTestID()
+	indexerDB := prefixdb.New(indexerDBPrefix, db.Database)
+
+	previouslyIndexedKey := make([]byte, hashing.HashLen+1)
+	copy(previouslyIndexedKey, chainID[:])
+	previouslyIndexedKey[hashing.HashLen] = previouslyIndexedPrefix
+	assert.NoError(indexerDB.Put(previouslyIndexedKey, nil))
+
+	blockIndexPrefix := make([]byte, hashing.HashLen+1)
+	copy(blockIndexPrefix, chainID[:])
+	blockIndexPrefix[hashing.HashLen] = 0x03
+	blockIndexDB := prefixdb.New(blockIndexPrefix, indexerDB)
+	assert.NoError(blockIndexDB.Put([]byte{1}, []byte{2}))
+	assert.NoError(db.Database.Put([]byte("key"), []byte("value")))
+
+	path := filepath.Join(t.TempDir(), "archive")
+	report, err := WriteFile(logging.NoLog{}, path, db, Config{ExcludeIndices: true})
+	assert.NoError(err)
+	assert.EqualValues(3, report.Keys)
+	assert.EqualValues(1, report.ExcludedKeys)
+
+	restoredDB := manager.NewMemDB(version.CurrentDatabase).Current()
+	assert.NoError(RestoreFile(logging.NoLog{}, path, restoredDB))
+
+	has, err := restoredDB.Database.Has([]byte("key"))
+	assert.NoError(err)
+	assert.True(has)
+
+	isEmpty, err := database.IsEmpty(prefixdb.New(blockIndexPrefix, prefixdb.New(indexerDBPrefix, restoredDB.Database)))
+	assert.NoError(err)
+	assert.True(isEmpty)
+
+	// The excluded index is marked incomplete
+	isIncompleteKey := make([]byte, hashing.HashLen+1)
+	copy(isIncompleteKey, chainID[:])
+	isIncompleteKey[hashing.HashLen] = isIncompleteIndexPrefix
+	has, err = prefixdb.New(indexerDBPrefix, restoredDB.Database).Has(isIncompleteKey)
+	assert.NoError(err)
+	assert.True(has)
+}
+
+func TestRestoreDir(t *testing.T) {
+	assert := assert.New(t)
+
+	db := manager.NewMemDB(version.CurrentDatabase).Current()
+	assert.NoError(db.Database.Put([]byte("key"), []byte("value")))
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "archive")
+	_, err := WriteFile(logging.NoLog{}, path, db, Config{})
+	assert.NoError(err)
+
+	newDB := func(path string) (database.Database, error) {
+		return leveldb.New(path, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	}
+	dbPath := filepath.Join(dir, "db", version.CurrentDatabase.String())
+
+	// Leftovers of an interrupted restore are removed
+	assert.NoError(os.MkdirAll(dbPath+".restore", perms.ReadWriteExecute))
+	assert.NoError(os.WriteFile(filepath.Join(dbPath+".restore", "partial"), nil, perms.ReadWrite))
+
+	assert.NoError(RestoreDir(logging.NoLog{}, path, dbPath, version.CurrentDatabase, newDB))
+	_, err = os.Stat(dbPath + ".restore")
+	assert.True(os.IsNotExist(err))
+
+	restoredDB, err := newDB(dbPath)
+	assert.NoError(err)
+	value, err := restoredDB.Get([]byte("key"))
+	assert.NoError(err)
+	assert.Equal([]byte("value"), value)
+	assert.NoError(restoredDB.Close())
+
+	// Restoring the same backup again is a no-op
+	assert.NoError(RestoreDir(logging.NoLog{}, path, dbPath, version.CurrentDatabase, newDB))
+
+	// A failed restore doesn't create the database
+	archive, err := os.ReadFile(path)
+	assert.NoError(err)
+	archive[len(archive)/2]++
+	assert.NoError(os.WriteFile(path, archive, perms.ReadWrite))
+	otherDBPath := filepath.Join(dir, "other", version.CurrentDatabase.String())
+	err = RestoreDir(logging.NoLog{}, path, otherDBPath, version.CurrentDatabase, newDB)
+	assert.ErrorIs(err, errInvalidChecksum)
+	_, err = os.Stat(otherDBPath)
+	assert.True(os.IsNotExist(err))
+}
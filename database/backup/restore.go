@@ -0,0 +1,360 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// maxBatchSize is the number of bytes of entries that are buffered before
+// they are written to the database.
+const maxBatchSize = 1024 * 1024
+
+var (
+	// The checksum of the most recently restored archive is stored under this
+	// prefix so that the same archive isn't restored twice.
+	restorePrefix = []byte("backup")
+	restoredKey   = []byte("restored")
+
+	errInvalidMagic         = errors.New("file isn't a backup")
+	errUnknownFormatVersion = errors.New("unknown backup format version")
+	errInvalidChecksum      = errors.New("backup checksum doesn't match its contents")
+	errInvalidTag           = errors.New("invalid entry tag")
+	errWrongNumEntries      = errors.New("backup contains an unexpected number of entries")
+	errTrailingData         = errors.New("backup contains data after its end")
+	errWrongVersion         = errors.New("backup has a different database version")
+	errDatabaseNotEmpty     = errors.New("database isn't empty")
+)
+
+// Header describes an archive.
+type Header struct {
+	// Version of the database that was backed up
+	Version *version.Semantic
+	// Time the backup was started
+	Timestamp time.Time
+	// Number of keys in the archive
+	Keys uint64
+	// Checksum of the archive
+	Checksum ids.ID
+}
+
+// Verify checks that the file at [path] is a complete and uncorrupted archive
+// and returns its header.
+func Verify(path string) (*Header, error) {
+	header := &Header{}
+	err := readFile(path, header, func([]byte, []byte) error { return nil })
+	return header, err
+}
+
+// RestoreFile writes the contents of the archive at [path] into [db]. The
+// archive is verified before anything is written. [db] must be empty, unless
+// the same archive was already restored into it, in which case nothing is
+// done.
+//
+// If the node stops during a restore, [db] is left partially restored. Databases
+// stored on disk should be restored with RestoreDir instead.
+func RestoreFile(log logging.Logger, path string, db *manager.VersionedDatabase) error {
+	header, err := verifyVersion(path, db.Version)
+	if err != nil {
+		return err
+	}
+	restored, err := isRestored(log, path, header, db.Database)
+	if err != nil || restored {
+		return err
+	}
+	return restore(log, path, header, db.Database)
+}
+
+// RestoreDir writes the contents of the archive at [path] into a new database
+// of version [dbVersion] at [dbPath]. Databases are opened with [newDB]. If a
+// database already exists at [dbPath], it must be empty, unless the same
+// archive was already restored into it, in which case nothing is done.
+//
+// The archive is restored into a temporary directory that is only moved to
+// [dbPath] once the restore completed, so a restore that is interrupted never
+// leaves a partially restored database at [dbPath].
+func RestoreDir(
+	log logging.Logger,
+	path string,
+	dbPath string,
+	dbVersion *version.Semantic,
+	newDB func(path string) (database.Database, error),
+) error {
+	header, err := verifyVersion(path, dbVersion)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(dbPath); err == nil {
+		db, err := newDB(dbPath)
+		if err != nil {
+			return err
+		}
+		restored, err := isRestored(log, path, header, db)
+		if closeErr := db.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil || restored {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	// Anything left in the temporary directory is from an interrupted
+	// restore.
+	tmpPath := dbPath + ".restore"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+	db, err := newDB(tmpPath)
+	if err != nil {
+		return err
+	}
+	err = restore(log, path, header, db)
+	if closeErr := db.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.RemoveAll(tmpPath)
+		return err
+	}
+
+	// The database at [dbPath], if any, is empty
+	if err := os.RemoveAll(dbPath); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, dbPath)
+}
+
+// verifyVersion verifies the archive at [path] and checks that it was written
+// by a database of version [dbVersion].
+func verifyVersion(path string, dbVersion *version.Semantic) (*Header, error) {
+	header, err := Verify(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't verify backup %s: %w", path, err)
+	}
+	if header.Version.Compare(dbVersion) != 0 {
+		return nil, fmt.Errorf("%w: backup has version %s but the database has version %s",
+			errWrongVersion,
+			header.Version,
+			dbVersion,
+		)
+	}
+	return header, nil
+}
+
+// isRestored returns true if the archive described by [header] was already
+// restored into [db]. Returns an error if [db] contains anything else.
+func isRestored(log logging.Logger, path string, header *Header, db database.Database) (bool, error) {
+	restoreDB := prefixdb.New(restorePrefix, db)
+	restoredChecksum, err := database.GetID(restoreDB, restoredKey)
+	switch {
+	case err == nil && restoredChecksum == header.Checksum:
+		log.Info("backup %s was already restored", path)
+		return true, nil
+	case err != nil && err != database.ErrNotFound:
+		return false, err
+	}
+	isEmpty, err := database.IsEmpty(db)
+	if err != nil {
+		return false, err
+	}
+	if !isEmpty {
+		return false, fmt.Errorf("%w: can't restore backup %s", errDatabaseNotEmpty, path)
+	}
+	return false, nil
+}
+
+// restore writes the entries of the archive at [path] into [db] and marks the
+// archive as restored.
+func restore(log logging.Logger, path string, header *Header, db database.Database) error {
+	log.Info("restoring %d keys from backup %s taken at %s",
+		header.Keys,
+		path,
+		header.Timestamp,
+	)
+	batch := db.NewBatch()
+	err := readFile(path, &Header{}, func(key, value []byte) error {
+		if err := batch.Put(key, value); err != nil {
+			return err
+		}
+		if batch.Size() < maxBatchSize {
+			return nil
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't restore backup %s: %w", path, err)
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	restoreDB := prefixdb.New(restorePrefix, db)
+	if err := database.PutID(restoreDB, restoredKey, header.Checksum); err != nil {
+		return err
+	}
+	log.Info("restored backup %s", path)
+	return nil
+}
+
+// readFile verifies the checksum of the archive at [path], populates [header]
+// and calls [onEntry] with every entry of the archive.
+func readFile(path string, header *Header, onEntry func(key, value []byte) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size() - checksumLen
+	if size < 0 {
+		return errInvalidMagic
+	}
+	if _, err := file.ReadAt(header.Checksum[:], size); err != nil {
+		return err
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, io.NewSectionReader(file, 0, size)); err != nil {
+		return err
+	}
+	if !bytes.Equal(hasher.Sum(nil), header.Checksum[:]) {
+		return errInvalidChecksum
+	}
+
+	zr, err := gzip.NewReader(io.NewSectionReader(file, 0, size))
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	return readArchive(bufio.NewReader(zr), header, onEntry)
+}
+
+func readArchive(r *bufio.Reader, header *Header, onEntry func(key, value []byte) error) error {
+	ar := &archiveReader{r: r}
+	if fileMagic := ar.readBytes(len(magic)); ar.err == nil && !bytes.Equal(fileMagic, magic[:]) {
+		return errInvalidMagic
+	}
+	if fileFormatVersion := ar.readUint16(); ar.err == nil && fileFormatVersion != formatVersion {
+		return fmt.Errorf("%w: %d", errUnknownFormatVersion, fileFormatVersion)
+	}
+	versionStr := ar.readBytes(int(ar.readUint16()))
+	timestamp := ar.readUint64()
+	if ar.err != nil {
+		return ar.err
+	}
+	dbVersion, err := version.Parse(string(versionStr))
+	if err != nil {
+		return err
+	}
+	header.Version = dbVersion
+	header.Timestamp = time.Unix(int64(timestamp), 0)
+
+	var numEntries uint64
+	for {
+		tag := ar.readBytes(1)
+		if ar.err != nil {
+			return ar.err
+		}
+		if tag[0] == endTag {
+			break
+		}
+		if tag[0] != entryTag {
+			return fmt.Errorf("%w: %d", errInvalidTag, tag[0])
+		}
+
+		key := ar.readBytes(int(ar.readUint32()))
+		value := ar.readBytes(int(ar.readUint32()))
+		if ar.err != nil {
+			return ar.err
+		}
+		if err := onEntry(key, value); err != nil {
+			return err
+		}
+		numEntries++
+	}
+
+	header.Keys = ar.readUint64()
+	if ar.err != nil {
+		return ar.err
+	}
+	if header.Keys != numEntries {
+		return fmt.Errorf("%w: expected %d but found %d", errWrongNumEntries, header.Keys, numEntries)
+	}
+	if _, err := r.ReadByte(); err != io.EOF {
+		return errTrailingData
+	}
+	return nil
+}
+
+// archiveReader reads the fields of an archive. After a read fails, further
+// reads return zero values and the error is kept in [err].
+type archiveReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (r *archiveReader) readBytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		r.err = err
+		return nil
+	}
+	return buf
+}
+
+func (r *archiveReader) readUint16() uint16 {
+	b := r.readBytes(2)
+	if r.err != nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(b)
+}
+
+func (r *archiveReader) readUint32() uint32 {
+	b := r.readBytes(4)
+	if r.err != nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (r *archiveReader) readUint64() uint64 {
+	b := r.readBytes(8)
+	if r.err != nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
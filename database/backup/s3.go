@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const defaultS3Region = "us-east-1"
+
+var (
+	errNoS3Credentials = errors.New("missing S3 access key ID or secret access key")
+	errInvalidS3URL    = errors.New("S3 URL must be of the form http(s)://<endpoint>/<bucket>/<key>")
+)
+
+// S3Config describes an object of an S3-compatible endpoint that an archive is
+// uploaded to.
+type S3Config struct {
+	// URL of the object, in path style: http(s)://<endpoint>/<bucket>/<key>
+	URL string
+	// Region of the bucket. Defaults to us-east-1, which is also accepted by
+	// most S3-compatible endpoints.
+	Region string
+
+	AccessKeyID     string
+	SecretAccessKey string
+	// Optional token of temporary credentials
+	SessionToken string
+}
+
+// S3CredentialsFromEnv populates the credentials of [config] from the standard
+// AWS environment variables.
+func S3CredentialsFromEnv(config *S3Config) {
+	config.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+	config.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	config.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+}
+
+// Upload uploads the file at [path] to the object described by [config].
+// Files larger than a single part are uploaded with a multipart upload, so
+// archives aren't limited by the maximum size of a single PUT. The part size
+// grows with the file so that the upload never needs more parts than S3
+// allows.
+func Upload(ctx context.Context, client *http.Client, config S3Config, path string) error {
+	if len(config.AccessKeyID) == 0 || len(config.SecretAccessKey) == 0 {
+		return errNoS3Credentials
+	}
+	if len(config.Region) == 0 {
+		config.Region = defaultS3Region
+	}
+	endpoint, bucket, key, err := parseS3URL(config.URL)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	s3Client := s3.New(s3.Options{
+		Region: config.Region,
+		Credentials: credentials.NewStaticCredentialsProvider(
+			config.AccessKeyID,
+			config.SecretAccessKey,
+			config.SessionToken,
+		),
+		EndpointResolver: s3.EndpointResolverFromURL(endpoint),
+		UsePathStyle:     true,
+		HTTPClient:       client,
+	})
+	uploader := manager.NewUploader(s3Client)
+	_, err = uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   file,
+	})
+	if err != nil {
+		return fmt.Errorf("upload to %s failed: %w", config.URL, err)
+	}
+	return nil
+}
+
+// parseS3URL splits a path style object URL into the endpoint, the bucket and
+// the key of the object.
+func parseS3URL(rawURL string) (string, string, string, error) {
+	objectURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", "", err
+	}
+	if objectURL.Scheme != "http" && objectURL.Scheme != "https" || len(objectURL.Host) == 0 {
+		return "", "", "", fmt.Errorf("%w: %s", errInvalidS3URL, rawURL)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(objectURL.Path, "/"), "/", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", "", fmt.Errorf("%w: %s", errInvalidS3URL, rawURL)
+	}
+	endpoint := url.URL{
+		Scheme: objectURL.Scheme,
+		Host:   objectURL.Host,
+	}
+	return endpoint.String(), parts[0], parts[1], nil
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testUploadID = "upload"
+
+// s3Server is a minimal S3 endpoint that supports single and multipart
+// uploads of objects.
+type s3Server struct {
+	lock    sync.Mutex
+	auth    []string
+	objects map[string][]byte
+	parts   map[int][]byte
+}
+
+func (s *s3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.auth = append(s.auth, r.Header.Get("Authorization"))
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", testUploadID)
+	case r.Method == http.MethodPut && query.Has("partNumber"):
+		partNumber, err := strconv.Atoi(query.Get("partNumber"))
+		if err != nil || query.Get("uploadId") != testUploadID {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.parts[partNumber] = body
+		w.Header().Set("ETag", strconv.Quote(strconv.Itoa(partNumber)))
+	case r.Method == http.MethodPost && query.Get("uploadId") == testUploadID:
+		partNumbers := make([]int, 0, len(s.parts))
+		for partNumber := range s.parts {
+			partNumbers = append(partNumbers, partNumber)
+		}
+		sort.Ints(partNumbers)
+		object := []byte{}
+		for _, partNumber := range partNumbers {
+			object = append(object, s.parts[partNumber]...)
+		}
+		s.objects[r.URL.Path] = object
+		fmt.Fprint(w, "<CompleteMultipartUploadResult></CompleteMultipartUploadResult>")
+	case r.Method == http.MethodPut:
+		s.objects[r.URL.Path] = body
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	smallPath := filepath.Join(dir, "small")
+	smallContents := []byte("archive")
+	assert.NoError(os.WriteFile(smallPath, smallContents, 0o600))
+
+	// Larger than a single part, so it's uploaded with a multipart upload
+	largePath := filepath.Join(dir, "large")
+	largeContents := bytes.Repeat([]byte{1, 2, 3}, int(manager.DefaultUploadPartSize))
+	assert.NoError(os.WriteFile(largePath, largeContents, 0o600))
+
+	s3 := &s3Server{
+		objects: make(map[string][]byte),
+		parts:   make(map[int][]byte),
+	}
+	server := httptest.NewServer(s3)
+	defer server.Close()
+
+	config := S3Config{
+		URL:             server.URL + "/bucket/node/small",
+		AccessKeyID:     "id",
+		SecretAccessKey: "secret",
+	}
+	assert.NoError(Upload(context.Background(), server.Client(), config, smallPath))
+	assert.Equal(smallContents, s3.objects["/bucket/node/small"])
+	assert.Len(s3.auth, 1)
+	assert.True(strings.HasPrefix(s3.auth[0], "AWS4-HMAC-SHA256 Credential=id/"))
+
+	config.URL = server.URL + "/bucket/node/large"
+	assert.NoError(Upload(context.Background(), server.Client(), config, largePath))
+	assert.Equal(largeContents, s3.objects["/bucket/node/large"])
+	assert.Len(s3.parts, 3)
+
+	config.URL = server.URL + "/bucket"
+	assert.ErrorIs(Upload(context.Background(), server.Client(), config, smallPath), errInvalidS3URL)
+
+	config.SecretAccessKey = ""
+	assert.ErrorIs(Upload(context.Background(), server.Client(), config, smallPath), errNoS3Credentials)
+}
+
+func TestParseS3URL(t *testing.T) {
+	tests := []struct {
+		url      string
+		endpoint string
+		bucket   string
+		key      string
+		valid    bool
+	}{
+		{
+			url:      "https://s3.amazonaws.com/bucket/node/archive",
+			endpoint: "https://s3.amazonaws.com",
+			bucket:   "bucket",
+			key:      "node/archive",
+			valid:    true,
+		},
+		{
+			url:      "http://localhost:9000/bucket/archive",
+			endpoint: "http://localhost:9000",
+			bucket:   "bucket",
+			key:      "archive",
+			valid:    true,
+		},
+		{url: "https://s3.amazonaws.com/bucket"},
+		{url: "https://s3.amazonaws.com/bucket/"},
+		{url: "ftp://s3.amazonaws.com/bucket/archive"},
+		{url: "/bucket/archive"},
+	}
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			assert := assert.New(t)
+
+			endpoint, bucket, key, err := parseS3URL(test.url)
+			if !test.valid {
+				assert.ErrorIs(err, errInvalidS3URL)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(test.endpoint, endpoint)
+			assert.Equal(test.bucket, bucket)
+			assert.Equal(test.key, key)
+		})
+	}
+}
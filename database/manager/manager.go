@@ -65,6 +65,12 @@ type Manager interface {
 	// Note: calling this more than once with the same [namespace] will cause a
 	// conflict error for the [registerer].
 	NewCompleteMeterDBManager(namespace string, registerer prometheus.Registerer) (Manager, error)
+
+	// Migrate runs the steps of [migrations] that haven't completed yet, in
+	// order, against the managed databases with the matching versions. If
+	// [dryRun], the pending steps are logged but not run. Returns the names of
+	// the migrations that had pending steps.
+	Migrate(log logging.Logger, migrations []Migration, dryRun bool) ([]string, error)
 }
 
 type manager struct {
@@ -235,7 +241,7 @@ func NewManagerFromDBs(dbs []*VersionedDatabase) (Manager, error) {
 		return nil, errNoDBs
 	}
 	SortDescending(dbs)
-	sortedAndUnique := utils.IsSortedAndUnique(innerSortDescendingVersionedDBs(dbs))
+	sortedAndUnique := utils.IsSortedAndUniqueFunc(dbs, newerVersion)
 	if !sortedAndUnique {
 		return nil, errNonSortedAndUniqueDBs
 	}
@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	// Progress of each migration is stored under this prefix in the database
+	// that was migrated.
+	migrationPrefix = []byte("migrations")
+
+	errNoMigrationName          = errors.New("migration has no name")
+	errNoMigrationVersion       = errors.New("migration has no database version")
+	errNoMigrationSteps         = errors.New("migration has no steps")
+	errNoMigrationStepFunc      = errors.New("migration step has no function to run")
+	errDuplicateMigration       = errors.New("duplicate migration")
+	errInvalidMigrationProgress = errors.New("invalid migration progress")
+)
+
+// Migration changes the on-disk format of the database with version [Version]
+// in place.
+//
+// Each completed step is recorded in the migrated database, so a migration
+// that was interrupted resumes from the step it was running the next time the
+// node starts, and a migration that finished is never run again.
+type Migration struct {
+	// Name identifies the migration within its database version. It must not
+	// change once the migration has been released.
+	Name string
+	// Version of the database this migration applies to. If the manager
+	// doesn't contain a database with this version, the migration is skipped.
+	Version *version.Semantic
+	// Steps are run in order. Steps may be appended to a released migration,
+	// but existing steps must not be removed or reordered.
+	Steps []MigrationStep
+}
+
+// MigrationStep is a single resumable part of a Migration.
+type MigrationStep struct {
+	// Description of the step that is logged when the step is run.
+	Description string
+	// Run performs the step. If the node stops before Run returns, Run is
+	// called again on the next startup with the last checkpoint that the step
+	// saved. Any work done after that checkpoint must be safe to repeat.
+	Run func(ctx *MigrationContext) error
+}
+
+// MigrationContext is provided to a MigrationStep when it is run.
+type MigrationContext struct {
+	Log logging.Logger
+	// Manager that contains the database being migrated. It can be used to
+	// read from the databases of previous versions.
+	Manager Manager
+	// Database being migrated
+	DB *VersionedDatabase
+	// Checkpoint most recently saved by this step, or nil if the step is being
+	// run from the start.
+	Checkpoint []byte
+
+	progressDB database.KeyValueWriter
+	key        []byte
+	step       uint32
+}
+
+// SaveCheckpoint records [checkpoint] so that the step resumes from it if the
+// node stops before the step finishes.
+func (ctx *MigrationContext) SaveCheckpoint(checkpoint []byte) error {
+	if err := ctx.progressDB.Put(ctx.key, packMigrationProgress(ctx.step, checkpoint)); err != nil {
+		return fmt.Errorf("couldn't save migration checkpoint: %w", err)
+	}
+	ctx.Checkpoint = checkpoint
+	ctx.Log.Debug("saved checkpoint of step %d of migration %s", ctx.step+1, ctx.key)
+	return nil
+}
+
+func (m *manager) Migrate(log logging.Logger, migrations []Migration, dryRun bool) ([]string, error) {
+	return migrate(m, log, migrations, dryRun)
+}
+
+func migrate(m Manager, log logging.Logger, migrations []Migration, dryRun bool) ([]string, error) {
+	if err := verifyMigrations(migrations); err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, migration := range migrations {
+		db, exists := getDatabase(m, migration.Version)
+		if !exists {
+			log.Debug("skipping migration %s because there is no database with version %s",
+				migration.Name,
+				migration.Version,
+			)
+			continue
+		}
+
+		progressDB := prefixdb.New(migrationPrefix, db.Database)
+		key := []byte(migration.Name)
+		completedSteps, checkpoint, err := getMigrationProgress(progressDB, key)
+		if err != nil {
+			return pending, fmt.Errorf("couldn't get progress of migration %s: %w", migration.Name, err)
+		}
+
+		numSteps := uint32(len(migration.Steps))
+		if completedSteps >= numSteps {
+			continue
+		}
+		pending = append(pending, migration.Name)
+
+		if dryRun {
+			log.Info("migration %s of database %s would run %d of its %d steps",
+				migration.Name,
+				db.Version,
+				numSteps-completedSteps,
+				numSteps,
+			)
+			for i := completedSteps; i < numSteps; i++ {
+				log.Info("    step %d: %s", i+1, migration.Steps[i].Description)
+			}
+			continue
+		}
+
+		log.Info("running migration %s of database %s", migration.Name, db.Version)
+		for i := completedSteps; i < numSteps; i++ {
+			step := migration.Steps[i]
+			if checkpoint == nil {
+				log.Info("running step %d/%d of migration %s: %s", i+1, numSteps, migration.Name, step.Description)
+			} else {
+				log.Info("resuming step %d/%d of migration %s: %s", i+1, numSteps, migration.Name, step.Description)
+			}
+
+			startTime := time.Now()
+			err := step.Run(&MigrationContext{
+				Log:        log,
+				Manager:    m,
+				DB:         db,
+				Checkpoint: checkpoint,
+				progressDB: progressDB,
+				key:        key,
+				step:       i,
+			})
+			if err != nil {
+				return pending, fmt.Errorf("step %d of migration %s failed: %w", i+1, migration.Name, err)
+			}
+			if err := progressDB.Put(key, packMigrationProgress(i+1, nil)); err != nil {
+				return pending, fmt.Errorf("couldn't save progress of migration %s: %w", migration.Name, err)
+			}
+			checkpoint = nil
+
+			log.Info("finished step %d/%d of migration %s in %s", i+1, numSteps, migration.Name, time.Since(startTime))
+		}
+		log.Info("finished migration %s of database %s", migration.Name, db.Version)
+	}
+	return pending, nil
+}
+
+func verifyMigrations(migrations []Migration) error {
+	names := make(map[string]struct{}, len(migrations))
+	for _, migration := range migrations {
+		switch {
+		case len(migration.Name) == 0:
+			return errNoMigrationName
+		case migration.Version == nil:
+			return fmt.Errorf("%w: %s", errNoMigrationVersion, migration.Name)
+		case len(migration.Steps) == 0:
+			return fmt.Errorf("%w: %s", errNoMigrationSteps, migration.Name)
+		}
+		for i, step := range migration.Steps {
+			if step.Run == nil {
+				return fmt.Errorf("%w: step %d of %s", errNoMigrationStepFunc, i+1, migration.Name)
+			}
+		}
+
+		name := fmt.Sprintf("%s/%s", migration.Version, migration.Name)
+		if _, exists := names[name]; exists {
+			return fmt.Errorf("%w: %s", errDuplicateMigration, name)
+		}
+		names[name] = struct{}{}
+	}
+	return nil
+}
+
+// getDatabase returns the database managed by [m] with version [v].
+func getDatabase(m Manager, v *version.Semantic) (*VersionedDatabase, bool) {
+	for _, db := range m.GetDatabases() {
+		if db.Version.Compare(v) == 0 {
+			return db, true
+		}
+	}
+	return nil, false
+}
+
+// getMigrationProgress returns the number of steps of the migration that have
+// completed and the checkpoint of the step that is in progress.
+func getMigrationProgress(db database.KeyValueReader, key []byte) (uint32, []byte, error) {
+	progressBytes, err := db.Get(key)
+	if err == database.ErrNotFound {
+		return 0, nil, nil
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(progressBytes) < wrappers.IntLen {
+		return 0, nil, errInvalidMigrationProgress
+	}
+
+	completedSteps, err := database.ParseUInt32(progressBytes[:wrappers.IntLen])
+	if err != nil {
+		return 0, nil, err
+	}
+	checkpoint := progressBytes[wrappers.IntLen:]
+	if len(checkpoint) == 0 {
+		checkpoint = nil
+	}
+	return completedSteps, checkpoint, nil
+}
+
+func packMigrationProgress(completedSteps uint32, checkpoint []byte) []byte {
+	progressBytes := make([]byte, wrappers.IntLen+len(checkpoint))
+	copy(progressBytes, database.PackUInt32(completedSteps))
+	copy(progressBytes[wrappers.IntLen:], checkpoint)
+	return progressBytes
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package manager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestMigrateInvalid(t *testing.T) {
+	step := MigrationStep{
+		Description: "noop",
+		Run:         func(*MigrationContext) error { return nil },
+	}
+	tests := []struct {
+		name        string
+		migrations  []Migration
+		expectedErr error
+	}{
+		{
+			name:        "no name",
+			migrations:  []Migration{{Version: version.Semantic1_0_0, Steps: []MigrationStep{step}}},
+			expectedErr: errNoMigrationName,
+		},
+		{
+			name:        "no version",
+			migrations:  []Migration{{Name: "a", Steps: []MigrationStep{step}}},
+			expectedErr: errNoMigrationVersion,
+		},
+		{
+			name:        "no steps",
+			migrations:  []Migration{{Name: "a", Version: version.Semantic1_0_0}},
+			expectedErr: errNoMigrationSteps,
+		},
+		{
+			name:        "no step function",
+			migrations:  []Migration{{Name: "a", Version: version.Semantic1_0_0, Steps: []MigrationStep{{}}}},
+			expectedErr: errNoMigrationStepFunc,
+		},
+		{
+			name: "duplicate",
+			migrations: []Migration{
+				{Name: "a", Version: version.Semantic1_0_0, Steps: []MigrationStep{step}},
+				{Name: "a", Version: version.Semantic1_0_0, Steps: []MigrationStep{step}},
+			},
+			expectedErr: errDuplicateMigration,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewMemDB(version.Semantic1_0_0)
+			_, err := m.Migrate(logging.NoLog{}, test.migrations, false)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestMigrateResume(t *testing.T) {
+	assert := assert.New(t)
+
+	errInterrupted := errors.New("interrupted")
+
+	var (
+		m           = NewMemDB(version.Semantic1_0_0)
+		key         = []byte("key")
+		ran         []int
+		checkpoints [][]byte
+		interrupt   = true
+	)
+	migration := Migration{
+		Name:    "test",
+		Version: version.Semantic1_0_0,
+		Steps: []MigrationStep{
+			{
+				Description: "write key",
+				Run: func(ctx *MigrationContext) error {
+					ran = append(ran, 0)
+					return ctx.DB.Database.Put(key, []byte{0})
+				},
+			},
+			{
+				Description: "overwrite key",
+				Run: func(ctx *MigrationContext) error {
+					ran = append(ran, 1)
+					checkpoints = append(checkpoints, ctx.Checkpoint)
+					if err := ctx.SaveCheckpoint([]byte{1}); err != nil {
+						return err
+					}
+					if interrupt {
+						return errInterrupted
+					}
+					return ctx.DB.Database.Put(key, []byte{1})
+				},
+			},
+		},
+	}
+
+	// A dry run doesn't run any steps
+	pending, err := m.Migrate(logging.NoLog{}, []Migration{migration}, true)
+	assert.NoError(err)
+	assert.Equal([]string{"test"}, pending)
+	assert.Empty(ran)
+
+	// The second step fails after saving a checkpoint
+	_, err = m.Migrate(logging.NoLog{}, []Migration{migration}, false)
+	assert.ErrorIs(err, errInterrupted)
+	assert.Equal([]int{0, 1}, ran)
+
+	// The migration resumes from the checkpoint of the second step
+	interrupt = false
+	pending, err = m.Migrate(logging.NoLog{}, []Migration{migration}, false)
+	assert.NoError(err)
+	assert.Equal([]string{"test"}, pending)
+	assert.Equal([]int{0, 1, 1}, ran)
+	assert.Equal([][]byte{nil, {1}}, checkpoints)
+
+	value, err := m.Current().Database.Get(key)
+	assert.NoError(err)
+	assert.Equal([]byte{1}, value)
+
+	// A completed migration isn't run again
+	pending, err = m.Migrate(logging.NoLog{}, []Migration{migration}, false)
+	assert.NoError(err)
+	assert.Empty(pending)
+	assert.Equal([]int{0, 1, 1}, ran)
+}
+
+func TestMigrateSkipsMissingVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMemDB(version.Semantic1_0_0)
+	migration := Migration{
+		Name:    "test",
+		Version: version.DatabaseVersion1_4_5,
+		Steps: []MigrationStep{{
+			Description: "fail",
+			Run: func(*MigrationContext) error {
+				return errors.New("shouldn't have run")
+			},
+		}},
+	}
+	pending, err := m.Migrate(logging.NoLog{}, []Migration{migration}, false)
+	assert.NoError(err)
+	assert.Empty(pending)
+}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	manager "github.com/ava-labs/avalanchego/database/manager"
+	logging "github.com/ava-labs/avalanchego/utils/logging"
 	mock "github.com/stretchr/testify/mock"
 
 	prometheus "github.com/prometheus/client_golang/prometheus"
@@ -95,6 +96,29 @@ func (_m *Manager) MarkCurrentDBBootstrapped() error {
 	return r0
 }
 
+// Migrate provides a mock function with given fields: log, migrations, dryRun
+func (_m *Manager) Migrate(log logging.Logger, migrations []manager.Migration, dryRun bool) ([]string, error) {
+	ret := _m.Called(log, migrations, dryRun)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(logging.Logger, []manager.Migration, bool) []string); ok {
+		r0 = rf(log, migrations, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(logging.Logger, []manager.Migration, bool) error); ok {
+		r1 = rf(log, migrations, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCompleteMeterDBManager provides a mock function with given fields: namespace, registerer
 func (_m *Manager) NewCompleteMeterDBManager(namespace string, registerer prometheus.Registerer) (manager.Manager, error) {
 	ret := _m.Called(namespace, registerer)
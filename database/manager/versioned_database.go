@@ -20,15 +20,14 @@ func (db *VersionedDatabase) Close() error {
 	return db.Database.Close()
 }
 
-type innerSortDescendingVersionedDBs []*VersionedDatabase
-
-// Less returns true if the version at index i is greater than the version at index j
-// such that it will sort in descending order (newest version --> oldest version)
-func (dbs innerSortDescendingVersionedDBs) Less(i, j int) bool {
-	return dbs[i].Version.Compare(dbs[j].Version) > 0
+// newerVersion returns true if [a] has a higher version than [b]
+func newerVersion(a, b *VersionedDatabase) bool {
+	return a.Version.Compare(b.Version) > 0
 }
 
-func (dbs innerSortDescendingVersionedDBs) Len() int      { return len(dbs) }
-func (dbs innerSortDescendingVersionedDBs) Swap(i, j int) { dbs[j], dbs[i] = dbs[i], dbs[j] }
-
-func SortDescending(dbs []*VersionedDatabase) { sort.Sort(innerSortDescendingVersionedDBs(dbs)) }
+// SortDescending sorts [dbs] from the newest version to the oldest version
+func SortDescending(dbs []*VersionedDatabase) {
+	sort.Slice(dbs, func(i, j int) bool {
+		return newerVersion(dbs[i], dbs[j])
+	})
+}
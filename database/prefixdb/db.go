@@ -8,17 +8,18 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/nodb"
+	"github.com/ava-labs/avalanchego/utils/bytespool"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 )
 
-const (
-	defaultBufCap = 256
-)
-
 var (
 	_ database.Database = &Database{}
 	_ database.Batch    = &batch{}
 	_ database.Iterator = &iterator{}
+
+	// keyPool holds the prefixed keys that are only used for the duration of
+	// a single operation. It isn't metered, as it's used on every operation.
+	keyPool = bytespool.NewUnmetered()
 )
 
 // Database partitions a database into a sub-database by prefixing all keys with
@@ -26,8 +27,6 @@ var (
 type Database struct {
 	// All keys in this db begin with this byte slice
 	dbPrefix []byte
-	// Holds unused []byte
-	bufferPool sync.Pool
 
 	// lock needs to be held during Close to guarantee db will not be set to nil
 	// concurrently with another operation. All other operations can hold RLock.
@@ -53,11 +52,6 @@ func NewNested(prefix []byte, db database.Database) *Database {
 	return &Database{
 		dbPrefix: hashing.ComputeHash256(prefix),
 		db:       db,
-		bufferPool: sync.Pool{
-			New: func() interface{} {
-				return make([]byte, 0, defaultBufCap)
-			},
-		},
 	}
 }
 
@@ -73,7 +67,7 @@ func (db *Database) Has(key []byte) (bool, error) {
 	}
 	prefixedKey := db.prefix(key)
 	has, err := db.db.Has(prefixedKey)
-	db.bufferPool.Put(prefixedKey)
+	keyPool.Put(prefixedKey)
 	return has, err
 }
 
@@ -89,7 +83,7 @@ func (db *Database) Get(key []byte) ([]byte, error) {
 	}
 	prefixedKey := db.prefix(key)
 	val, err := db.db.Get(prefixedKey)
-	db.bufferPool.Put(prefixedKey)
+	keyPool.Put(prefixedKey)
 	return val, err
 }
 
@@ -106,7 +100,7 @@ func (db *Database) Put(key, value []byte) error {
 	}
 	prefixedKey := db.prefix(key)
 	err := db.db.Put(prefixedKey, value)
-	db.bufferPool.Put(prefixedKey)
+	keyPool.Put(prefixedKey)
 	return err
 }
 
@@ -122,7 +116,7 @@ func (db *Database) Delete(key []byte) error {
 	}
 	prefixedKey := db.prefix(key)
 	err := db.db.Delete(prefixedKey)
-	db.bufferPool.Put(prefixedKey)
+	keyPool.Put(prefixedKey)
 	return err
 }
 
@@ -160,8 +154,8 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		Iterator: db.db.NewIteratorWithStartAndPrefix(prefixedStart, prefixedPrefix),
 		db:       db,
 	}
-	db.bufferPool.Put(prefixedStart)
-	db.bufferPool.Put(prefixedPrefix)
+	keyPool.Put(prefixedStart)
+	keyPool.Put(prefixedPrefix)
 	return it
 }
 
@@ -172,7 +166,12 @@ func (db *Database) Compact(start, limit []byte) error {
 	if db.db == nil {
 		return database.ErrClosed
 	}
-	return db.db.Compact(db.prefix(start), db.prefix(limit))
+	prefixedStart := db.prefix(start)
+	prefixedLimit := db.prefix(limit)
+	err := db.db.Compact(prefixedStart, prefixedLimit)
+	keyPool.Put(prefixedStart)
+	keyPool.Put(prefixedLimit)
+	return err
 }
 
 func (db *Database) Close() error {
@@ -204,21 +203,19 @@ func (db *Database) HealthCheck() (interface{}, error) {
 }
 
 // Return a copy of [key], prepended with this db's prefix.
-// The returned slice should be put back in the pool
+// The returned slice should be put back in [keyPool]
 // when it's done being used.
 func (db *Database) prefix(key []byte) []byte {
-	// Get a []byte from the pool
-	prefixedKey := db.bufferPool.Get().([]byte)
-	keyLen := len(db.dbPrefix) + len(key)
-	if cap(prefixedKey) >= keyLen {
-		// The [] byte we got from the pool is big enough to hold the prefixed key
-		prefixedKey = prefixedKey[:keyLen]
-	} else {
-		// The []byte from the pool wasn't big enough.
-		// Put it back and allocate a new, bigger one
-		db.bufferPool.Put(prefixedKey)
-		prefixedKey = make([]byte, keyLen)
-	}
+	prefixedKey := keyPool.Get(len(db.dbPrefix) + len(key))
+	copy(prefixedKey, db.dbPrefix)
+	copy(prefixedKey[len(db.dbPrefix):], key)
+	return prefixedKey
+}
+
+// Return a copy of [key], prepended with this db's prefix.
+// Unlike prefix, the returned slice isn't taken from the pool.
+func (db *Database) prefixUnpooled(key []byte) []byte {
+	prefixedKey := make([]byte, len(db.dbPrefix)+len(key))
 	copy(prefixedKey, db.dbPrefix)
 	copy(prefixedKey[len(db.dbPrefix):], key)
 	return prefixedKey
@@ -236,8 +233,8 @@ type batch struct {
 	db *Database
 
 	// Each key is prepended with the database's prefix.
-	// Each byte slice underlying a key should be returned to the pool
-	// when this batch is reset.
+	// The keys aren't taken from the pool, as they must be kept until the
+	// batch is reset, and a batch may be dropped without being reset.
 	writes []keyValue
 }
 
@@ -246,7 +243,7 @@ type batch struct {
 // [key] may be modified after this method returns.
 // [value] may not be modified after this method returns.
 func (b *batch) Put(key, value []byte) error {
-	prefixedKey := b.db.prefix(key)
+	prefixedKey := b.db.prefixUnpooled(key)
 	b.writes = append(b.writes, keyValue{prefixedKey, value, false})
 	return b.Batch.Put(prefixedKey, value)
 }
@@ -255,7 +252,7 @@ func (b *batch) Put(key, value []byte) error {
 // to be modified after b.Batch.Delete returns
 // [key] may be modified after this method returns.
 func (b *batch) Delete(key []byte) error {
-	prefixedKey := b.db.prefix(key)
+	prefixedKey := b.db.prefixUnpooled(key)
 	b.writes = append(b.writes, keyValue{prefixedKey, nil, true})
 	return b.Batch.Delete(prefixedKey)
 }
@@ -273,14 +270,6 @@ func (b *batch) Write() error {
 
 // Reset resets the batch for reuse.
 func (b *batch) Reset() {
-	// Return the byte buffers underneath each key back to the pool.
-	// Don't return the byte buffers underneath each value back to the pool
-	// because we assume in batch.Replay that it's not safe to modify the
-	// value argument to w.Put.
-	for _, kv := range b.writes {
-		b.db.bufferPool.Put(kv.key)
-	}
-
 	// Clear b.writes
 	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
 		b.writes = make([]keyValue, 0, cap(b.writes)/database.CapacityReductionFactor)
@@ -31,6 +31,10 @@ type StakingConfig struct {
 	MaxStakeDuration time.Duration `json:"maxStakeDuration"`
 	// RewardConfig is the config for the reward function.
 	RewardConfig reward.Config `json:"rewardConfig"`
+	// RewardCalculator is the name of the registered reward calculator that
+	// is configured by [RewardConfig]. If empty, the default reward function
+	// is used.
+	RewardCalculator string `json:"rewardCalculator"`
 }
 
 type TxFeeConfig struct {
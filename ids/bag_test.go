@@ -143,7 +143,9 @@ func TestBagFilter(t *testing.T) {
 	bag.AddCount(id1, 3)
 	bag.AddCount(id2, 5)
 
-	even := bag.Filter(0, 1, id0)
+	even := bag.Filter(func(id ID) bool {
+		return EqualSubset(0, 1, id0, id)
+	})
 
 	if count := even.Count(id0); count != 1 {
 		t.Fatalf("Bag.Count returned %d expected %d", count, 1)
@@ -165,7 +167,9 @@ func TestBagSplit(t *testing.T) {
 	bag.AddCount(id1, 3)
 	bag.AddCount(id2, 5)
 
-	bags := bag.Split(0)
+	bags := bag.Split(func(id ID) bool {
+		return id.Bit(0) == 1
+	})
 
 	evens := bags[0]
 	odds := bags[1]
@@ -8,7 +8,6 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"sort"
 
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/cb58"
@@ -129,18 +128,13 @@ func (id ID) MarshalText() ([]byte, error) {
 	return []byte(id.String()), nil
 }
 
-type sortIDData []ID
-
-func (ids sortIDData) Less(i, j int) bool {
-	return bytes.Compare(
-		ids[i][:],
-		ids[j][:]) == -1
+// Less returns true if [id] is lexicographically less than [other]
+func (id ID) Less(other ID) bool {
+	return bytes.Compare(id[:], other[:]) == -1
 }
-func (ids sortIDData) Len() int      { return len(ids) }
-func (ids sortIDData) Swap(i, j int) { ids[j], ids[i] = ids[i], ids[j] }
 
 // SortIDs sorts the ids lexicographically
-func SortIDs(ids []ID) { sort.Sort(sortIDData(ids)) }
+func SortIDs(ids []ID) { utils.Sort(ids) }
 
 // IsSortedAndUniqueIDs returns true if the ids are sorted and unique
-func IsSortedAndUniqueIDs(ids []ID) bool { return utils.IsSortedAndUnique(sortIDData(ids)) }
+func IsSortedAndUniqueIDs(ids []ID) bool { return utils.IsSortedAndUniqueSortable(ids) }
@@ -7,8 +7,8 @@ import (
 	"bytes"
 	"crypto/x509"
 	"fmt"
-	"sort"
 
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 )
 
@@ -34,6 +34,11 @@ func (id NodeID) MarshalText() ([]byte, error) {
 	return []byte(id.String()), nil
 }
 
+// Less returns true if [id] is lexicographically less than [other]
+func (id NodeID) Less(other NodeID) bool {
+	return bytes.Compare(id[:], other[:]) == -1
+}
+
 func (id *NodeID) UnmarshalJSON(b []byte) error {
 	str := string(b)
 	if str == nullStr { // If "null", do nothing
@@ -68,20 +73,8 @@ func NodeIDFromCert(cert *x509.Certificate) NodeID {
 	)
 }
 
-type sortNodeIDData []NodeID
-
-func (ids sortNodeIDData) Less(i, j int) bool {
-	return bytes.Compare(
-		ids[i].Bytes(),
-		ids[j].Bytes()) == -1
-}
-func (ids sortNodeIDData) Len() int      { return len(ids) }
-func (ids sortNodeIDData) Swap(i, j int) { ids[j], ids[i] = ids[i], ids[j] }
-
 // SortNodeIDs sorts the node IDs lexicographically
-func SortNodeIDs(nodeIDs []NodeID) {
-	sort.Sort(sortNodeIDData(nodeIDs))
-}
+func SortNodeIDs(nodeIDs []NodeID) { utils.Sort(nodeIDs) }
 
 // NodeIDFromString is the inverse of NodeID.String()
 func NodeIDFromString(nodeIDStr string) (NodeID, error) {
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 func TestSet(t *testing.T) {
@@ -111,37 +113,6 @@ func TestSetCappedList(t *testing.T) {
 	}
 }
 
-// Test that Clear() works with both the iterative and set-to-nil path
-func TestSetClearLarge(t *testing.T) {
-	// Using iterative clear path
-	set := Set{}
-	for i := 0; i < clearSizeThreshold; i++ {
-		set.Add(GenerateTestID())
-	}
-	set.Clear()
-	if set.Len() != 0 {
-		t.Fatal("length should be 0")
-	}
-	set.Add(GenerateTestID())
-	if set.Len() != 1 {
-		t.Fatal("length should be 1")
-	}
-
-	// Using bulk (set map to nil) path
-	set = Set{}
-	for i := 0; i < clearSizeThreshold+1; i++ {
-		set.Add(GenerateTestID())
-	}
-	set.Clear()
-	if set.Len() != 0 {
-		t.Fatal("length should be 0")
-	}
-	set.Add(GenerateTestID())
-	if set.Len() != 1 {
-		t.Fatal("length should be 1")
-	}
-}
-
 func TestSetPop(t *testing.T) {
 	var s Set
 	_, ok := s.Pop()
@@ -194,22 +165,22 @@ func TestSetMarshalJSON(t *testing.T) {
 func TestSortedList(t *testing.T) {
 	assert := assert.New(t)
 
-	set := Set{}
-	assert.Len(set.SortedList(), 0)
+	s := Set{}
+	assert.Len(set.SortedList(s), 0)
 
-	set.Add(ID{0})
-	sorted := set.SortedList()
+	s.Add(ID{0})
+	sorted := set.SortedList(s)
 	assert.Len(sorted, 1)
 	assert.Equal(ID{0}, sorted[0])
 
-	set.Add(ID{1})
-	sorted = set.SortedList()
+	s.Add(ID{1})
+	sorted = set.SortedList(s)
 	assert.Len(sorted, 2)
 	assert.Equal(ID{0}, sorted[0])
 	assert.Equal(ID{1}, sorted[1])
 
-	set.Add(ID{2})
-	sorted = set.SortedList()
+	s.Add(ID{2})
+	sorted = set.SortedList(s)
 	assert.Len(sorted, 3)
 	assert.Equal(ID{0}, sorted[0])
 	assert.Equal(ID{1}, sorted[1])
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 func TestShortSetContains(t *testing.T) {
@@ -296,22 +298,22 @@ func TestShortSetPop(t *testing.T) {
 func TestShortSortedList(t *testing.T) {
 	assert := assert.New(t)
 
-	set := ShortSet{}
-	assert.Len(set.SortedList(), 0)
+	s := ShortSet{}
+	assert.Len(set.SortedList(s), 0)
 
-	set.Add(ShortID{0})
-	sorted := set.SortedList()
+	s.Add(ShortID{0})
+	sorted := set.SortedList(s)
 	assert.Len(sorted, 1)
 	assert.Equal(ShortID{0}, sorted[0])
 
-	set.Add(ShortID{1})
-	sorted = set.SortedList()
+	s.Add(ShortID{1})
+	sorted = set.SortedList(s)
 	assert.Len(sorted, 2)
 	assert.Equal(ShortID{0}, sorted[0])
 	assert.Equal(ShortID{1}, sorted[1])
 
-	set.Add(ShortID{2})
-	sorted = set.SortedList()
+	s.Add(ShortID{2})
+	sorted = set.SortedList(s)
 	assert.Len(sorted, 3)
 	assert.Equal(ShortID{0}, sorted[0])
 	assert.Equal(ShortID{1}, sorted[1])
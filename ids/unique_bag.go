@@ -6,6 +6,8 @@ package ids
 import (
 	"fmt"
 	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/bag"
 )
 
 const (
@@ -71,14 +73,12 @@ func (b *UniqueBag) List() []ID {
 }
 
 func (b *UniqueBag) Bag(alpha int) Bag {
-	bag := Bag{
-		counts: make(map[ID]int, len(*b)),
-	}
-	bag.SetThreshold(alpha)
+	votes := bag.NewBag[ID](len(*b))
+	votes.SetThreshold(alpha)
 	for id, bs := range *b {
-		bag.AddCount(id, bs.Len())
+		votes.AddCount(id, bs.Len())
 	}
-	return bag
+	return votes
 }
 
 func (b *UniqueBag) PrefixedString(prefix string) string {
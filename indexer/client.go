@@ -6,6 +6,7 @@ package indexer
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -32,6 +33,18 @@ type Client interface {
 	IsAccepted(ctx context.Context, containerID ids.ID, options ...rpc.Option) (bool, error)
 	// Get a container by its index
 	GetContainerByID(ctx context.Context, containerID ids.ID, options ...rpc.Option) (Container, error)
+	// GetContainersByAddress returns up to [numToFetch] containers that
+	// reference [addr], starting at [startIndex], and the start index of the
+	// next page.
+	GetContainersByAddress(ctx context.Context, addr string, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, uint64, error)
+	// GetContainerRangeByTime returns up to [limit] containers accepted in
+	// [start, end], starting at [cursor], and the cursor of the next page.
+	// The returned cursor is empty if there are no more pages.
+	GetContainerRangeByTime(ctx context.Context, start, end time.Time, cursor string, limit int, options ...rpc.Option) ([]Container, string, error)
+	// GetContainerRangeByHeight returns up to [limit] containers with height
+	// in [startHeight, endHeight], starting at [cursor], and the cursor of the
+	// next page. The returned cursor is empty if there are no more pages.
+	GetContainerRangeByHeight(ctx context.Context, startHeight, endHeight uint64, cursor string, limit int, options ...rpc.Option) ([]Container, string, error)
 }
 
 // Client implementation for Avalanche Indexer API Endpoint
@@ -154,3 +167,66 @@ func (c *client) GetContainerByID(ctx context.Context, id ids.ID, options ...rpc
 		Bytes:     containerBytes,
 	}, nil
 }
+
+func (c *client) GetContainersByAddress(ctx context.Context, addr string, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, uint64, error) {
+	var fcs GetContainersByAddressResponse
+	err := c.requester.SendRequest(ctx, "getContainersByAddress", &GetContainersByAddressArgs{
+		Address:    addr,
+		StartIndex: json.Uint64(startIndex),
+		NumToFetch: json.Uint64(numToFetch),
+		Encoding:   formatting.Hex,
+	}, &fcs, options...)
+	if err != nil {
+		return nil, 0, err
+	}
+	containers, err := decodeContainers(fcs.Containers)
+	return containers, uint64(fcs.NextStartIndex), err
+}
+
+func (c *client) GetContainerRangeByTime(ctx context.Context, start, end time.Time, cursor string, limit int, options ...rpc.Option) ([]Container, string, error) {
+	var fcs GetContainerRangeWithCursorResponse
+	err := c.requester.SendRequest(ctx, "getContainerRangeByTime", &GetContainerRangeByTimeArgs{
+		StartTime: start,
+		EndTime:   end,
+		Cursor:    cursor,
+		Limit:     json.Uint64(limit),
+		Encoding:  formatting.Hex,
+	}, &fcs, options...)
+	if err != nil {
+		return nil, "", err
+	}
+	containers, err := decodeContainers(fcs.Containers)
+	return containers, fcs.NextCursor, err
+}
+
+func (c *client) GetContainerRangeByHeight(ctx context.Context, startHeight, endHeight uint64, cursor string, limit int, options ...rpc.Option) ([]Container, string, error) {
+	var fcs GetContainerRangeWithCursorResponse
+	err := c.requester.SendRequest(ctx, "getContainerRangeByHeight", &GetContainerRangeByHeightArgs{
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+		Cursor:      cursor,
+		Limit:       json.Uint64(limit),
+		Encoding:    formatting.Hex,
+	}, &fcs, options...)
+	if err != nil {
+		return nil, "", err
+	}
+	containers, err := decodeContainers(fcs.Containers)
+	return containers, fcs.NextCursor, err
+}
+
+func decodeContainers(fcs []FormattedContainer) ([]Container, error) {
+	containers := make([]Container, len(fcs))
+	for i, fc := range fcs {
+		containerBytes, err := formatting.Decode(fc.Encoding, fc.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode container %s: %w", fc.ID, err)
+		}
+		containers[i] = Container{
+			ID:        fc.ID,
+			Timestamp: fc.Timestamp.Unix(),
+			Bytes:     containerBytes,
+		}
+	}
+	return containers, nil
+}
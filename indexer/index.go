@@ -4,10 +4,12 @@
 package indexer
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
@@ -15,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -32,8 +35,20 @@ var (
 	nextAcceptedIndexKey   = []byte{0x00}
 	indexToContainerPrefix = []byte{0x01}
 	containerToIDPrefix    = []byte{0x02}
-	errNoneAccepted        = errors.New("no containers have been accepted")
-	errNumToFetchZero      = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
+	addressToIndexPrefix   = []byte{0x03}
+	timestampToIndexPrefix = []byte{0x04}
+	heightToIndexPrefix    = []byte{0x05}
+	// Maps to the byte representation of the next index to add to the
+	// timestamp/height index. Used to backfill containers accepted before
+	// those indices existed.
+	nextTimestampIndexedKey = []byte{0x06}
+	nextHeightIndexedKey    = []byte{0x07}
+
+	errNoneAccepted   = errors.New("no containers have been accepted")
+	errNumToFetchZero = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
+	errNoAddressIndex = errors.New("containers aren't indexed by address")
+	errNoHeightIndex  = errors.New("containers aren't indexed by height")
+	errInvalidCursor  = errors.New("invalid cursor")
 
 	_ Index = &index{}
 )
@@ -49,9 +64,29 @@ type Index interface {
 	GetLastAccepted() (Container, error)
 	GetIndex(id ids.ID) (uint64, error)
 	GetContainerByID(id ids.ID) (Container, error)
+	// GetContainersByAddress returns up to [numToFetch] containers that
+	// reference [addr], in order of acceptance, starting with the first such
+	// container at or after [startIndex].
+	GetContainersByAddress(addr ids.ShortID, startIndex uint64, numToFetch uint64) ([]Container, error)
+	// GetContainerRangeByTime returns up to [limit] containers accepted in
+	// [start, end], ordered by acceptance time, and a cursor to fetch the
+	// next page with. The returned cursor is nil if there are no more pages.
+	GetContainerRangeByTime(start, end time.Time, cursor []byte, limit uint64) ([]Container, []byte, error)
+	// GetContainerRangeByHeight returns up to [limit] containers with height
+	// in [startHeight, endHeight], ordered by height, and a cursor to fetch
+	// the next page with. The returned cursor is nil if there are no more
+	// pages.
+	GetContainerRangeByHeight(startHeight, endHeight uint64, cursor []byte, limit uint64) ([]Container, []byte, error)
 	io.Closer
 }
 
+// AddressExtractor returns the addresses referenced by a container.
+// It's called before the container is committed as accepted by its VM.
+type AddressExtractor func(containerBytes []byte) ([]ids.ShortID, error)
+
+// HeightExtractor returns the height of a container.
+type HeightExtractor func(containerBytes []byte) (uint64, error)
+
 // indexer indexes all accepted transactions by the order in which they were accepted
 type index struct {
 	codec codec.Manager
@@ -67,20 +102,43 @@ type index struct {
 	indexToContainer database.Database
 	// Container ID --> Index
 	containerToIndex database.Database
-	log              logging.Logger
+	// Address + Index --> nil
+	// Only written to if [extractAddresses] is non-nil.
+	addressToIndex database.Database
+	// Returns the addresses referenced by a container.
+	// If nil, containers aren't indexed by address.
+	extractAddresses AddressExtractor
+	// Timestamp + Index --> Index
+	timestampToIndex database.Database
+	// Height --> Index
+	// Only written to if [extractHeight] is non-nil.
+	heightToIndex database.Database
+	// Returns the height of a container.
+	// If nil, containers aren't indexed by height.
+	extractHeight HeightExtractor
+	log           logging.Logger
 }
 
 // Returns a new, thread-safe Index.
+// If [extractAddresses] is non-nil, accepted containers are also indexed by
+// the addresses it returns.
+// If [extractHeight] is non-nil, accepted containers are also indexed by the
+// height it returns.
 // Closes [baseDB] on close.
 func newIndex(
 	baseDB database.Database,
 	log logging.Logger,
 	codec codec.Manager,
 	clock mockable.Clock,
+	extractAddresses AddressExtractor,
+	extractHeight HeightExtractor,
 ) (Index, error) {
 	vDB := versiondb.New(baseDB)
 	indexToContainer := prefixdb.New(indexToContainerPrefix, vDB)
 	containerToIndex := prefixdb.New(containerToIDPrefix, vDB)
+	addressToIndex := prefixdb.New(addressToIndexPrefix, vDB)
+	timestampToIndex := prefixdb.New(timestampToIndexPrefix, vDB)
+	heightToIndex := prefixdb.New(heightToIndexPrefix, vDB)
 
 	i := &index{
 		clock:            clock,
@@ -89,6 +147,11 @@ func newIndex(
 		vDB:              vDB,
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
+		addressToIndex:   addressToIndex,
+		extractAddresses: extractAddresses,
+		timestampToIndex: timestampToIndex,
+		heightToIndex:    heightToIndex,
+		extractHeight:    extractHeight,
 		log:              log,
 	}
 
@@ -104,15 +167,86 @@ func newIndex(
 	}
 	i.nextAcceptedIndex = nextAcceptedIndex
 	i.log.Info("next accepted index %d", i.nextAcceptedIndex)
+
+	// Containers accepted by a version of this index that didn't maintain the
+	// timestamp or height index need to be added to them.
+	if err := i.backfill(nextTimestampIndexedKey, i.putTimestamp); err != nil {
+		return nil, fmt.Errorf("couldn't backfill timestamp index: %w", err)
+	}
+	if i.extractHeight != nil {
+		if err := i.backfill(nextHeightIndexedKey, i.putHeight); err != nil {
+			return nil, fmt.Errorf("couldn't backfill height index: %w", err)
+		}
+	}
 	return i, nil
 }
 
+// backfill calls [put] on each container from the index stored at
+// [nextIndexedKey] up to the last accepted container, periodically committing
+// progress.
+func (i *index) backfill(nextIndexedKey []byte, put func(Container, []byte) error) error {
+	nextIndexed, err := database.GetUInt64(i.vDB, nextIndexedKey)
+	if err == database.ErrNotFound {
+		nextIndexed = 0
+	} else if err != nil {
+		return err
+	}
+	if nextIndexed >= i.nextAcceptedIndex {
+		return nil
+	}
+
+	i.log.Info("backfilling containers %d to %d", nextIndexed, i.nextAcceptedIndex-1)
+	for ; nextIndexed < i.nextAcceptedIndex; nextIndexed++ {
+		indexBytes := database.PackUInt64(nextIndexed)
+		container, err := i.getContainerByIndexBytes(indexBytes)
+		if err != nil {
+			return err
+		}
+		if err := put(container, indexBytes); err != nil {
+			return err
+		}
+		if (nextIndexed+1)%MaxFetchedByRange != 0 && nextIndexed+1 != i.nextAcceptedIndex {
+			continue
+		}
+		if err := database.PutUInt64(i.vDB, nextIndexedKey, nextIndexed+1); err != nil {
+			return err
+		}
+		if err := i.vDB.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Persist timestamp + index --> index
+func (i *index) putTimestamp(container Container, indexBytes []byte) error {
+	key := make([]byte, wrappers.LongLen, wrappers.LongLen+len(indexBytes))
+	// Timestamps before 1970 are treated as 1970.
+	if container.Timestamp > 0 {
+		copy(key, database.PackUInt64(uint64(container.Timestamp)))
+	}
+	key = append(key, indexBytes...)
+	return i.timestampToIndex.Put(key, indexBytes)
+}
+
+// Persist height --> index
+func (i *index) putHeight(container Container, indexBytes []byte) error {
+	height, err := i.extractHeight(container.Bytes)
+	if err != nil {
+		return fmt.Errorf("couldn't get height of container %s: %w", container.ID, err)
+	}
+	return i.heightToIndex.Put(database.PackUInt64(height), indexBytes)
+}
+
 // Close this index
 func (i *index) Close() error {
 	errs := wrappers.Errs{}
 	errs.Add(
 		i.indexToContainer.Close(),
 		i.containerToIndex.Close(),
+		i.addressToIndex.Close(),
+		i.timestampToIndex.Close(),
+		i.heightToIndex.Close(),
 		i.vDB.Close(),
 		i.baseDB.Close(),
 	)
@@ -142,11 +276,12 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	ctx.Log.Debug("indexing %d --> container %s", i.nextAcceptedIndex, containerID)
 	// Persist index --> Container
 	nextAcceptedIndexBytes := database.PackUInt64(i.nextAcceptedIndex)
-	bytes, err := i.codec.Marshal(codecVersion, Container{
+	container := Container{
 		ID:        containerID,
 		Bytes:     containerBytes,
 		Timestamp: i.clock.Time().UnixNano(),
-	})
+	}
+	bytes, err := i.codec.Marshal(codecVersion, container)
 	if err != nil {
 		return fmt.Errorf("couldn't serialize container %s: %w", containerID, err)
 	}
@@ -159,11 +294,44 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 		return fmt.Errorf("couldn't map container %s to index: %w", containerID, err)
 	}
 
+	// Persist address + index --> nil for each address [containerID] references
+	if i.extractAddresses != nil {
+		addrs, err := i.extractAddresses(containerBytes)
+		if err != nil {
+			return fmt.Errorf("couldn't get addresses referenced by container %s: %w", containerID, err)
+		}
+		for _, addr := range addrs {
+			if err := i.addressToIndex.Put(addressIndexKey(addr, nextAcceptedIndexBytes), nil); err != nil {
+				return fmt.Errorf("couldn't map address %s to container %s: %w", addr, containerID, err)
+			}
+		}
+	}
+
+	// Persist timestamp + index --> index
+	if err := i.putTimestamp(container, nextAcceptedIndexBytes); err != nil {
+		return fmt.Errorf("couldn't map timestamp of container %s to index: %w", containerID, err)
+	}
+
+	// Persist height --> index
+	if i.extractHeight != nil {
+		if err := i.putHeight(container, nextAcceptedIndexBytes); err != nil {
+			return err
+		}
+	}
+
 	// Persist next accepted index
 	i.nextAcceptedIndex++
 	if err := database.PutUInt64(i.vDB, nextAcceptedIndexKey, i.nextAcceptedIndex); err != nil {
 		return fmt.Errorf("couldn't put accepted container %s into index: %w", containerID, err)
 	}
+	if err := database.PutUInt64(i.vDB, nextTimestampIndexedKey, i.nextAcceptedIndex); err != nil {
+		return fmt.Errorf("couldn't put accepted container %s into index: %w", containerID, err)
+	}
+	if i.extractHeight != nil {
+		if err := database.PutUInt64(i.vDB, nextHeightIndexedKey, i.nextAcceptedIndex); err != nil {
+			return fmt.Errorf("couldn't put accepted container %s into index: %w", containerID, err)
+		}
+	}
 
 	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
 	return i.vDB.Commit()
@@ -265,6 +433,135 @@ func (i *index) GetContainerByID(id ids.ID) (Container, error) {
 	return i.getContainerByIndexBytes(indexBytes)
 }
 
+// GetContainersByAddress returns up to [numToFetch] containers that reference
+// [addr], in the order they were accepted, skipping those accepted before
+// [startIndex]. To fetch the next page, call again with [startIndex] set to one
+// more than the index of the last container returned.
+// [numToFetch] should be in [1, MaxFetchedByRange]
+func (i *index) GetContainersByAddress(addr ids.ShortID, startIndex, numToFetch uint64) ([]Container, error) {
+	// Check arguments for validity
+	if numToFetch == 0 {
+		return nil, errNumToFetchZero
+	} else if numToFetch > MaxFetchedByRange {
+		return nil, fmt.Errorf("requested %d but maximum page size is %d", numToFetch, MaxFetchedByRange)
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if i.extractAddresses == nil {
+		return nil, errNoAddressIndex
+	}
+
+	iter := i.addressToIndex.NewIteratorWithStartAndPrefix(
+		addressIndexKey(addr, database.PackUInt64(startIndex)),
+		addr[:],
+	)
+	defer iter.Release()
+
+	containers := []Container(nil)
+	for uint64(len(containers)) < numToFetch && iter.Next() {
+		indexBytes := iter.Key()[len(addr):]
+		container, err := i.getContainerByIndexBytes(indexBytes)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, container)
+	}
+	return containers, iter.Error()
+}
+
+// GetContainerRangeByTime returns up to [limit] containers accepted in
+// [start, end], ordered by acceptance time. If there are more containers in
+// the range, also returns the cursor to pass to get the next page.
+// [limit] should be in [1, MaxFetchedByRange]
+func (i *index) GetContainerRangeByTime(start, end time.Time, cursor []byte, limit uint64) ([]Container, []byte, error) {
+	startKey := make([]byte, 2*wrappers.LongLen)
+	if startNano := start.UnixNano(); startNano > 0 {
+		copy(startKey, database.PackUInt64(uint64(startNano)))
+	}
+	endKey := bytes.Repeat([]byte{0xff}, 2*wrappers.LongLen)
+	if endNano := end.UnixNano(); endNano >= 0 {
+		copy(endKey, database.PackUInt64(uint64(endNano)))
+	} else {
+		// No containers can have been accepted before 1970.
+		return nil, nil, nil
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.getContainerKeyRange(i.timestampToIndex, startKey, endKey, cursor, limit)
+}
+
+// GetContainerRangeByHeight returns up to [limit] containers with height in
+// [startHeight, endHeight], ordered by height. If there are more containers in
+// the range, also returns the cursor to pass to get the next page.
+// [limit] should be in [1, MaxFetchedByRange]
+func (i *index) GetContainerRangeByHeight(startHeight, endHeight uint64, cursor []byte, limit uint64) ([]Container, []byte, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if i.extractHeight == nil {
+		return nil, nil, errNoHeightIndex
+	}
+	return i.getContainerKeyRange(
+		i.heightToIndex,
+		database.PackUInt64(startHeight),
+		database.PackUInt64(endHeight),
+		cursor,
+		limit,
+	)
+}
+
+// Returns up to [limit] containers whose index is a value of [db] with a key in
+// [startKey, endKey], starting at [cursor] if it's non-empty. If more keys
+// remain in the range, returns the next one as the cursor of the next page.
+// Assumes [i.lock] is held
+func (i *index) getContainerKeyRange(
+	db database.Iteratee,
+	startKey []byte,
+	endKey []byte,
+	cursor []byte,
+	limit uint64,
+) ([]Container, []byte, error) {
+	// Check arguments for validity
+	if limit == 0 {
+		return nil, nil, errNumToFetchZero
+	} else if limit > MaxFetchedByRange {
+		return nil, nil, fmt.Errorf("requested %d but maximum page size is %d", limit, MaxFetchedByRange)
+	} else if bytes.Compare(startKey, endKey) > 0 {
+		return nil, nil, errors.New("start of range is after end of range")
+	}
+	if len(cursor) != 0 {
+		if len(cursor) != len(startKey) || bytes.Compare(cursor, startKey) < 0 || bytes.Compare(cursor, endKey) > 0 {
+			return nil, nil, errInvalidCursor
+		}
+		startKey = cursor
+	}
+
+	iter := db.NewIteratorWithStart(startKey)
+	defer iter.Release()
+
+	containers := []Container(nil)
+	for iter.Next() {
+		key := iter.Key()
+		if bytes.Compare(key, endKey) > 0 {
+			break
+		}
+		if uint64(len(containers)) == limit {
+			// There are more containers in the range
+			return containers, utils.CopyBytes(key), iter.Error()
+		}
+		container, err := i.getContainerByIndexBytes(iter.Value())
+		if err != nil {
+			return nil, nil, err
+		}
+		containers = append(containers, container)
+	}
+	return containers, nil, iter.Error()
+}
+
 // GetLastAccepted returns the last accepted container.
 // Returns an error if no containers have been accepted.
 func (i *index) GetLastAccepted() (Container, error) {
@@ -286,3 +583,12 @@ func (i *index) GetLastAccepted() (Container, error) {
 func (i *index) lastAcceptedIndex() (uint64, bool) {
 	return i.nextAcceptedIndex - 1, i.nextAcceptedIndex != 0
 }
+
+// Returns [addr] followed by [indexBytes]. Keys with the same address are
+// ordered by index, since [indexBytes] is big endian.
+func addressIndexKey(addr ids.ShortID, indexBytes []byte) []byte {
+	key := make([]byte, len(addr)+len(indexBytes))
+	copy(key, addr[:])
+	copy(key[len(addr):], indexBytes)
+	return key
+}
@@ -5,9 +5,11 @@ package indexer
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
@@ -29,7 +31,7 @@ func TestIndex(t *testing.T) {
 	db := versiondb.New(baseDB)
 	ctx := snow.DefaultConsensusContextTest()
 
-	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil, nil)
 	assert.NoError(err)
 	idx := indexIntf.(*index)
 
@@ -83,7 +85,7 @@ func TestIndex(t *testing.T) {
 	assert.NoError(db.Commit())
 	assert.NoError(idx.Close())
 	db = versiondb.New(baseDB)
-	indexIntf, err = newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	indexIntf, err = newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil, nil)
 	assert.NoError(err)
 	idx = indexIntf.(*index)
 
@@ -118,7 +120,7 @@ func TestIndexGetContainerByRangeMaxPageSize(t *testing.T) {
 	assert.NoError(err)
 	db := memdb.New()
 	ctx := snow.DefaultConsensusContextTest()
-	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil, nil)
 	assert.NoError(err)
 	idx := indexIntf.(*index)
 
@@ -160,7 +162,7 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	assert.NoError(err)
 	db := memdb.New()
 	ctx := snow.DefaultConsensusContextTest()
-	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil, nil)
 	assert.NoError(err)
 
 	// Accept the same container twice
@@ -173,3 +175,187 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	assert.NoError(err)
 	assert.EqualValues(gotContainer.Bytes, []byte{1, 2, 3}, "should not have accepted same container twice")
 }
+
+func TestIndexGetContainersByAddress(t *testing.T) {
+	// Setup
+	assert := assert.New(t)
+	codec := codec.NewDefaultManager()
+	err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault())
+	assert.NoError(err)
+	db := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+
+	addr0 := ids.GenerateTestShortID()
+	addr1 := ids.GenerateTestShortID()
+	// The first byte of each container is the number of addresses it
+	// references. Container i references [addr0] and, if odd, [addr1].
+	extractAddresses := func(containerBytes []byte) ([]ids.ShortID, error) {
+		return []ids.ShortID{addr0, addr1}[:containerBytes[0]], nil
+	}
+	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, extractAddresses, nil)
+	assert.NoError(err)
+
+	containerIDs := make([]ids.ID, 10)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		assert.NoError(idx.Accept(ctx, containerIDs[i], []byte{byte(1 + i%2)}))
+	}
+
+	// All containers reference [addr0]
+	containers, err := idx.GetContainersByAddress(addr0, 0, MaxFetchedByRange)
+	assert.NoError(err)
+	assert.Len(containers, 10)
+	for i, container := range containers {
+		assert.Equal(containerIDs[i], container.ID)
+	}
+
+	// Page through the containers that reference [addr1]
+	containers, err = idx.GetContainersByAddress(addr1, 0, 3)
+	assert.NoError(err)
+	assert.Len(containers, 3)
+	assert.Equal(containerIDs[1], containers[0].ID)
+	assert.Equal(containerIDs[3], containers[1].ID)
+	assert.Equal(containerIDs[5], containers[2].ID)
+
+	containers, err = idx.GetContainersByAddress(addr1, 6, 3)
+	assert.NoError(err)
+	assert.Len(containers, 2)
+	assert.Equal(containerIDs[7], containers[0].ID)
+	assert.Equal(containerIDs[9], containers[1].ID)
+
+	containers, err = idx.GetContainersByAddress(addr1, 10, 3)
+	assert.NoError(err)
+	assert.Len(containers, 0)
+
+	// Unknown addresses have no containers
+	containers, err = idx.GetContainersByAddress(ids.GenerateTestShortID(), 0, 3)
+	assert.NoError(err)
+	assert.Len(containers, 0)
+
+	_, err = idx.GetContainersByAddress(addr0, 0, 0)
+	assert.Error(err)
+	_, err = idx.GetContainersByAddress(addr0, 0, MaxFetchedByRange+1)
+	assert.Error(err)
+
+	// Address queries fail on indices that don't index by address
+	idx, err = newIndex(memdb.New(), logging.NoLog{}, codec, mockable.Clock{}, nil, nil)
+	assert.NoError(err)
+	_, err = idx.GetContainersByAddress(addr0, 0, 1)
+	assert.ErrorIs(err, errNoAddressIndex)
+}
+
+func TestIndexGetContainerRangeByTime(t *testing.T) {
+	// Setup
+	assert := assert.New(t)
+	codec := codec.NewDefaultManager()
+	err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault())
+	assert.NoError(err)
+	db := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil, nil)
+	assert.NoError(err)
+	idx := indexIntf.(*index)
+
+	// Accept container i at time [start] + i seconds
+	start := time.Unix(1000, 0)
+	containerIDs := make([]ids.ID, 10)
+	for i := range containerIDs {
+		idx.clock.Set(start.Add(time.Duration(i) * time.Second))
+		containerIDs[i] = ids.GenerateTestID()
+		assert.NoError(idx.Accept(ctx, containerIDs[i], utils.RandomBytes(32)))
+	}
+
+	// Page through containers 2 to 8
+	rangeStart := start.Add(2 * time.Second)
+	rangeEnd := start.Add(8 * time.Second)
+	containers, cursor, err := idx.GetContainerRangeByTime(rangeStart, rangeEnd, nil, 4)
+	assert.NoError(err)
+	assert.NotNil(cursor)
+	assert.Len(containers, 4)
+	for i, container := range containers {
+		assert.Equal(containerIDs[2+i], container.ID)
+	}
+
+	containers, cursor, err = idx.GetContainerRangeByTime(rangeStart, rangeEnd, cursor, 4)
+	assert.NoError(err)
+	assert.Nil(cursor)
+	assert.Len(containers, 3)
+	for i, container := range containers {
+		assert.Equal(containerIDs[6+i], container.ID)
+	}
+
+	// Empty range
+	containers, cursor, err = idx.GetContainerRangeByTime(start.Add(time.Hour), start.Add(2*time.Hour), nil, 4)
+	assert.NoError(err)
+	assert.Nil(cursor)
+	assert.Len(containers, 0)
+
+	// Invalid arguments
+	_, _, err = idx.GetContainerRangeByTime(rangeEnd, rangeStart, nil, 4)
+	assert.Error(err)
+	_, _, err = idx.GetContainerRangeByTime(rangeStart, rangeEnd, nil, 0)
+	assert.Error(err)
+	_, _, err = idx.GetContainerRangeByTime(rangeStart, rangeEnd, []byte{1}, 4)
+	assert.ErrorIs(err, errInvalidCursor)
+
+	// Containers don't have heights
+	_, _, err = idx.GetContainerRangeByHeight(0, 10, nil, 4)
+	assert.ErrorIs(err, errNoHeightIndex)
+}
+
+func TestIndexGetContainerRangeByHeight(t *testing.T) {
+	// Setup
+	assert := assert.New(t)
+	codec := codec.NewDefaultManager()
+	err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault())
+	assert.NoError(err)
+	baseDB := memdb.New()
+	db := versiondb.New(baseDB)
+	ctx := snow.DefaultConsensusContextTest()
+
+	// The height of a container is its first byte
+	extractHeight := func(containerBytes []byte) (uint64, error) {
+		return uint64(containerBytes[0]), nil
+	}
+
+	// Accept some containers before the height index exists
+	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil, nil)
+	assert.NoError(err)
+	containerIDs := make([]ids.ID, 10)
+	for i := range containerIDs[:5] {
+		containerIDs[i] = ids.GenerateTestID()
+		assert.NoError(idx.Accept(ctx, containerIDs[i], []byte{byte(i)}))
+	}
+	assert.NoError(db.Commit())
+	assert.NoError(idx.Close())
+
+	// Re-open the index with height indexing, which should backfill the
+	// containers accepted above
+	db = versiondb.New(baseDB)
+	idx, err = newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil, extractHeight)
+	assert.NoError(err)
+	for i := 5; i < len(containerIDs); i++ {
+		containerIDs[i] = ids.GenerateTestID()
+		assert.NoError(idx.Accept(ctx, containerIDs[i], []byte{byte(i)}))
+	}
+
+	containers, cursor, err := idx.GetContainerRangeByHeight(3, 7, nil, 3)
+	assert.NoError(err)
+	assert.NotNil(cursor)
+	assert.Len(containers, 3)
+	for i, container := range containers {
+		assert.Equal(containerIDs[3+i], container.ID)
+	}
+
+	containers, cursor, err = idx.GetContainerRangeByHeight(3, 7, cursor, 3)
+	assert.NoError(err)
+	assert.Nil(cursor)
+	assert.Len(containers, 2)
+	for i, container := range containers {
+		assert.Equal(containerIDs[6+i], container.ID)
+	}
+
+	// A cursor outside of the range is invalid
+	_, _, err = idx.GetContainerRangeByHeight(3, 7, database.PackUInt64(8), 3)
+	assert.ErrorIs(err, errInvalidCursor)
+}
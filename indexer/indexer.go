@@ -9,8 +9,6 @@ import (
 	"math"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/codec"
@@ -20,8 +18,10 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche"
+	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -59,6 +59,7 @@ type Config struct {
 	Log                    logging.Logger
 	IndexingEnabled        bool
 	AllowIncompleteIndex   bool
+	AddressIndexingEnabled bool
 	DecisionAcceptorGroup  snow.AcceptorGroup
 	ConsensusAcceptorGroup snow.AcceptorGroup
 	APIServer              server.PathAdder
@@ -83,6 +84,7 @@ func NewIndexer(config Config) (Indexer, error) {
 		db:                     config.DB,
 		allowIncompleteIndex:   config.AllowIncompleteIndex,
 		indexingEnabled:        config.IndexingEnabled,
+		addressIndexingEnabled: config.AddressIndexingEnabled,
 		decisionAcceptorGroup:  config.DecisionAcceptorGroup,
 		consensusAcceptorGroup: config.ConsensusAcceptorGroup,
 		txIndices:              map[ids.ID]Index{},
@@ -130,6 +132,10 @@ type indexer struct {
 	// If false, don't create index for a chain when RegisterChain is called
 	indexingEnabled bool
 
+	// If true, index the txs of chains whose VM supports it by the addresses
+	// they reference
+	addressIndexingEnabled bool
+
 	// Chain ID --> index of blocks of that chain (if applicable)
 	blockIndices map[ids.ID]Index
 	// Chain ID --> index of vertices of that chain (if applicable)
@@ -225,7 +231,17 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 
 	switch engine.(type) {
 	case snowman.Engine:
-		index, err := i.registerChainHelper(chainID, blockPrefix, name, "block", i.consensusAcceptorGroup)
+		var extractHeight HeightExtractor
+		if parser, ok := engine.GetVM().(block.Parser); ok {
+			extractHeight = func(blkBytes []byte) (uint64, error) {
+				blk, err := parser.ParseBlock(blkBytes)
+				if err != nil {
+					return 0, err
+				}
+				return blk.Height(), nil
+			}
+		}
+		index, err := i.registerChainHelper(chainID, blockPrefix, name, "block", i.consensusAcceptorGroup, nil, extractHeight)
 		if err != nil {
 			i.log.Fatal("couldn't create block index for %s: %s", name, err)
 			if err := i.close(); err != nil {
@@ -235,7 +251,7 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		}
 		i.blockIndices[chainID] = index
 	case avalanche.Engine:
-		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, name, "vtx", i.consensusAcceptorGroup)
+		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, name, "vtx", i.consensusAcceptorGroup, nil, nil)
 		if err != nil {
 			i.log.Fatal("couldn't create vertex index for %s: %s", name, err)
 			if err := i.close(); err != nil {
@@ -245,7 +261,19 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		}
 		i.vtxIndices[chainID] = vtxIndex
 
-		txIndex, err := i.registerChainHelper(chainID, txPrefix, name, "tx", i.decisionAcceptorGroup)
+		var extractAddresses AddressExtractor
+		if aVM, ok := i.addressIndexedVM(engine); ok {
+			extractAddresses = func(txBytes []byte) ([]ids.ShortID, error) {
+				addrs, err := aVM.TxAddresses(txBytes)
+				if err == vertex.ErrAddressIndexedVMNotImplemented {
+					// The VM may be wrapped by one that implements the
+					// interface on its behalf.
+					return nil, nil
+				}
+				return addrs, err
+			}
+		}
+		txIndex, err := i.registerChainHelper(chainID, txPrefix, name, "tx", i.decisionAcceptorGroup, extractAddresses, nil)
 		if err != nil {
 			i.log.Fatal("couldn't create tx index for %s: %s", name, err)
 			if err := i.close(); err != nil {
@@ -263,17 +291,29 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 	}
 }
 
+// Returns the VM of [engine] if address indexing is enabled and the VM supports
+// it.
+func (i *indexer) addressIndexedVM(engine common.Engine) (vertex.AddressIndexedDAGVM, bool) {
+	if !i.addressIndexingEnabled {
+		return nil, false
+	}
+	aVM, ok := engine.GetVM().(vertex.AddressIndexedDAGVM)
+	return aVM, ok
+}
+
 func (i *indexer) registerChainHelper(
 	chainID ids.ID,
 	prefixEnd byte,
 	name, endpoint string,
 	acceptorGroup snow.AcceptorGroup,
+	extractAddresses AddressExtractor,
+	extractHeight HeightExtractor,
 ) (Index, error) {
 	prefix := make([]byte, hashing.HashLen+wrappers.ByteLen)
 	copy(prefix, chainID[:])
 	prefix[hashing.HashLen] = prefixEnd
 	indexDB := prefixdb.New(prefix, i.db)
-	index, err := newIndex(indexDB, i.log, i.codec, i.clock)
+	index, err := newIndex(indexDB, i.log, i.codec, i.clock, extractAddresses, extractHeight)
 	if err != nil {
 		_ = indexDB.Close()
 		return nil, err
@@ -286,7 +326,7 @@ func (i *indexer) registerChainHelper(
 	}
 
 	// Create an API endpoint for this index
-	apiServer := rpc.NewServer()
+	apiServer := json.NewServer()
 	codec := json.NewCodec()
 	apiServer.RegisterCodec(codec, "application/json")
 	apiServer.RegisterCodec(codec, "application/json;charset=UTF-8")
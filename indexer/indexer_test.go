@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils"
@@ -188,6 +189,11 @@ func TestIndexer(t *testing.T) {
 		Bytes:     blkBytes,
 		Timestamp: now.UnixNano(),
 	}
+	chainVM.EXPECT().ParseBlock(blkBytes).Return(&snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{IDV: blkID},
+		HeightV:       5,
+		BytesV:        blkBytes,
+	}, nil).AnyTimes()
 
 	assert.NoError(config.ConsensusAcceptorGroup.Accept(chain1Ctx, blkID, blkBytes))
 
@@ -220,6 +226,20 @@ func TestIndexer(t *testing.T) {
 	assert.Len(containers, 1)
 	assert.Equal(expectedContainer, containers[0])
 
+	// Verify GetContainerRangeByTime is right
+	containers, cursor, err := blkIdx.GetContainerRangeByTime(now, now, nil, 1)
+	assert.NoError(err)
+	assert.Nil(cursor)
+	assert.Len(containers, 1)
+	assert.Equal(expectedContainer, containers[0])
+
+	// Verify GetContainerRangeByHeight is right
+	containers, cursor, err = blkIdx.GetContainerRangeByHeight(5, 5, nil, 1)
+	assert.NoError(err)
+	assert.Nil(cursor)
+	assert.Len(containers, 1)
+	assert.Equal(expectedContainer, containers[0])
+
 	// Close the indexer
 	assert.NoError(db.Commit())
 	assert.NoError(idxr.Close())
@@ -452,6 +472,7 @@ func TestIncompleteIndex(t *testing.T) {
 	assert.False(previouslyIndexed)
 	chainEngine := &smengmocks.Engine{}
 	chainEngine.On("Context").Return(chain1Ctx)
+	chainEngine.On("GetVM").Return(nil)
 	idxr.RegisterChain("chain1", chainEngine)
 	isIncomplete, err = idxr.isIncomplete(chain1Ctx.ChainID)
 	assert.NoError(err)
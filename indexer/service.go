@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
 )
 
@@ -163,3 +164,139 @@ func (s *service) GetContainerByID(r *http.Request, args *GetContainerByIDArgs,
 	*reply, err = newFormattedContainer(container, index, args.Encoding)
 	return err
 }
+
+type GetContainersByAddressArgs struct {
+	Address    string              `json:"address"`
+	StartIndex json.Uint64         `json:"startIndex"`
+	NumToFetch json.Uint64         `json:"numToFetch"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+type GetContainersByAddressResponse struct {
+	Containers []FormattedContainer `json:"containers"`
+	// The start index to use to fetch the next page of containers
+	NextStartIndex json.Uint64 `json:"nextStartIndex"`
+}
+
+// GetContainersByAddress returns up to [numToFetch] containers that reference
+// [address], in the order they were accepted, starting at [startIndex].
+// If [numToFetch] > [MaxFetchedByRange], returns an error.
+func (s *service) GetContainersByAddress(r *http.Request, args *GetContainersByAddressArgs, reply *GetContainersByAddressResponse) error {
+	addr, err := address.ParseToID(args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+	}
+	containers, err := s.Index.GetContainersByAddress(addr, uint64(args.StartIndex), uint64(args.NumToFetch))
+	if err != nil {
+		return err
+	}
+
+	reply.NextStartIndex = args.StartIndex
+	reply.Containers = make([]FormattedContainer, len(containers))
+	for i, container := range containers {
+		index, err := s.Index.GetIndex(container.ID)
+		if err != nil {
+			return fmt.Errorf("couldn't get index: %w", err)
+		}
+		reply.Containers[i], err = newFormattedContainer(container, index, args.Encoding)
+		if err != nil {
+			return err
+		}
+		reply.NextStartIndex = json.Uint64(index + 1)
+	}
+	return nil
+}
+
+type GetContainerRangeByTimeArgs struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	// Cursor returned by a previous call with the same range. Empty to start
+	// at the beginning of the range.
+	Cursor   string              `json:"cursor"`
+	Limit    json.Uint64         `json:"limit"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+type GetContainerRangeByHeightArgs struct {
+	StartHeight json.Uint64 `json:"startHeight"`
+	EndHeight   json.Uint64 `json:"endHeight"`
+	// Cursor returned by a previous call with the same range. Empty to start
+	// at the beginning of the range.
+	Cursor   string              `json:"cursor"`
+	Limit    json.Uint64         `json:"limit"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+type GetContainerRangeWithCursorResponse struct {
+	Containers []FormattedContainer `json:"containers"`
+	// Pass to the next call to get the next page.
+	// Empty if there are no more containers in the range.
+	NextCursor string `json:"nextCursor"`
+}
+
+// GetContainerRangeByTime returns up to [limit] containers accepted between
+// [startTime] and [endTime], inclusive, ordered by acceptance time.
+// If [limit] > [MaxFetchedByRange], returns an error.
+func (s *service) GetContainerRangeByTime(r *http.Request, args *GetContainerRangeByTimeArgs, reply *GetContainerRangeWithCursorResponse) error {
+	cursor, err := decodeCursor(args.Cursor)
+	if err != nil {
+		return err
+	}
+	containers, nextCursor, err := s.Index.GetContainerRangeByTime(args.StartTime, args.EndTime, cursor, uint64(args.Limit))
+	if err != nil {
+		return err
+	}
+	return s.formatContainerRange(containers, nextCursor, args.Encoding, reply)
+}
+
+// GetContainerRangeByHeight returns up to [limit] containers with heights
+// between [startHeight] and [endHeight], inclusive, ordered by height.
+// Only supported by block indices.
+// If [limit] > [MaxFetchedByRange], returns an error.
+func (s *service) GetContainerRangeByHeight(r *http.Request, args *GetContainerRangeByHeightArgs, reply *GetContainerRangeWithCursorResponse) error {
+	cursor, err := decodeCursor(args.Cursor)
+	if err != nil {
+		return err
+	}
+	containers, nextCursor, err := s.Index.GetContainerRangeByHeight(uint64(args.StartHeight), uint64(args.EndHeight), cursor, uint64(args.Limit))
+	if err != nil {
+		return err
+	}
+	return s.formatContainerRange(containers, nextCursor, args.Encoding, reply)
+}
+
+func (s *service) formatContainerRange(
+	containers []Container,
+	nextCursor []byte,
+	enc formatting.Encoding,
+	reply *GetContainerRangeWithCursorResponse,
+) error {
+	reply.Containers = make([]FormattedContainer, len(containers))
+	for i, container := range containers {
+		index, err := s.Index.GetIndex(container.ID)
+		if err != nil {
+			return fmt.Errorf("couldn't get index: %w", err)
+		}
+		reply.Containers[i], err = newFormattedContainer(container, index, enc)
+		if err != nil {
+			return err
+		}
+	}
+	if len(nextCursor) == 0 {
+		return nil
+	}
+	var err error
+	reply.NextCursor, err = formatting.Encode(formatting.Hex, nextCursor)
+	return err
+}
+
+func decodeCursor(cursor string) ([]byte, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	cursorBytes, err := formatting.Decode(formatting.Hex, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidCursor, err)
+	}
+	return cursorBytes, nil
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+// serveOnce accepts a single connection on a new listener and handles it
+// with [handler]
+func serveOnce(t *testing.T, handler func(net.Conn)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handler(conn)
+	}()
+	return listener.Addr().String()
+}
+
+// serveNATS runs a minimal NATS server that acknowledges every JetStream
+// publish. Published messages are sent on the returned channel.
+func serveNATS(t *testing.T) (string, <-chan string) {
+	published := make(chan string, 1)
+	address := serveOnce(t, func(conn net.Conn) {
+		reader := bufio.NewReader(conn)
+		_, _ = conn.Write([]byte(`INFO {"server_id":"test","version":"2.8.0","proto":1,"headers":true,"max_payload":1048576}` + "\r\n"))
+
+		// The subscription that publish acks are delivered to
+		var ackSID string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+			switch fields[0] {
+			case "SUB":
+				ackSID = fields[len(fields)-1]
+			case "PING":
+				_, _ = conn.Write([]byte("PONG\r\n"))
+			case "PUB":
+				// PUB <subject> <#bytes>
+				size, _ := strconv.Atoi(fields[len(fields)-1])
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(reader, payload); err != nil {
+					return
+				}
+				published <- fields[1] + " " + string(payload[:size])
+			case "HPUB":
+				// HPUB <subject> <reply-to> <#header bytes> <#total bytes>
+				headerSize, _ := strconv.Atoi(fields[3])
+				size, _ := strconv.Atoi(fields[4])
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(reader, payload); err != nil {
+					return
+				}
+				header := textproto.NewReader(bufio.NewReader(bytes.NewReader(payload[:headerSize])))
+				_, _ = header.ReadLine() // NATS/1.0
+				mimeHeader, _ := header.ReadMIMEHeader()
+				published <- fields[1] + " " + mimeHeader.Get("Nats-Msg-Id") + " " + string(payload[headerSize:size])
+
+				ack := `{"stream":"events","seq":1}`
+				_, _ = fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", fields[2], ackSID, len(ack), ack)
+			}
+		}
+	})
+	return address, published
+}
+
+func TestNATSClient(t *testing.T) {
+	assert := assert.New(t)
+
+	address, published := serveNATS(t)
+	client, err := newNATSClient(PublisherConfig{Address: address}, "subject", 5*time.Second)
+	assert.NoError(err)
+	assert.Equal("nats://"+address+"/subject", client.URL())
+	assert.NoError(client.Send(7, []byte("event")))
+	assert.Equal("subject event", <-published)
+	assert.NoError(client.Close())
+}
+
+func TestNATSClientJetStream(t *testing.T) {
+	assert := assert.New(t)
+
+	address, published := serveNATS(t)
+	client, err := newNATSClient(PublisherConfig{
+		Address:   address,
+		JetStream: true,
+	}, "subject", 5*time.Second)
+	assert.NoError(err)
+	assert.NoError(client.Send(7, []byte("event")))
+	assert.Equal("subject subject-7 event", <-published)
+	assert.NoError(client.Close())
+}
+
+func TestNATSClientUnreachable(t *testing.T) {
+	assert := assert.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	address := listener.Addr().String()
+	assert.NoError(listener.Close())
+
+	client, err := newNATSClient(PublisherConfig{Address: address}, "subject", time.Second)
+	assert.NoError(err)
+	assert.Error(client.Send(0, []byte("event")))
+	assert.NoError(client.Close())
+}
+
+func TestKafkaClient(t *testing.T) {
+	assert := assert.New(t)
+
+	client, err := newKafkaClient(PublisherConfig{
+		Address:   "127.0.0.1:1,127.0.0.1:2",
+		Partition: 3,
+		Username:  "user",
+		Password:  "password",
+	}, "topic", time.Second)
+	assert.NoError(err)
+	assert.Equal("kafka://127.0.0.1:1,127.0.0.1:2/topic/3", client.URL())
+	assert.Equal(3, client.writer.Balancer.Balance(kafka.Message{}, 0, 1, 2, 3))
+
+	transport, ok := client.writer.Transport.(*kafka.Transport)
+	assert.True(ok)
+	assert.Equal("PLAIN", transport.SASL.Name())
+	assert.Nil(transport.TLS)
+
+	// Nothing is listening on the brokers, so the event can't be sent
+	assert.Error(client.Send(0, []byte("event")))
+	assert.NoError(client.Close())
+}
+
+func TestKafkaSASLMechanism(t *testing.T) {
+	tests := []struct {
+		mechanism string
+		name      string
+	}{
+		{mechanism: "", name: "PLAIN"},
+		{mechanism: "plain", name: "PLAIN"},
+		{mechanism: "scram-sha-256", name: "SCRAM-SHA-256"},
+		{mechanism: "SCRAM-SHA-512", name: "SCRAM-SHA-512"},
+	}
+	for _, test := range tests {
+		t.Run(test.mechanism, func(t *testing.T) {
+			mechanism, err := kafkaSASLMechanism(PublisherConfig{
+				Username:      "user",
+				Password:      "password",
+				SASLMechanism: test.mechanism,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, test.name, mechanism.Name())
+		})
+	}
+
+	mechanism, err := kafkaSASLMechanism(PublisherConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, mechanism)
+
+	_, err = kafkaSASLMechanism(PublisherConfig{
+		Username:      "user",
+		SASLMechanism: "gssapi",
+	})
+	assert.Error(t, err)
+}
+
+func TestPublisherTLSConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	config, err := (&PublisherTLSConfig{}).tlsConfig()
+	assert.NoError(err)
+	assert.Nil(config.RootCAs)
+	assert.Empty(config.Certificates)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(os.WriteFile(caFile, []byte("not a certificate"), perms.ReadWrite))
+	_, err = (&PublisherTLSConfig{CAFile: caFile}).tlsConfig()
+	assert.ErrorIs(err, errInvalidCAFile)
+}
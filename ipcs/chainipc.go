@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -26,26 +27,42 @@ type context struct {
 	log       logging.Logger
 	networkID uint32
 	path      string
+	// Stores the events queued by publishers that deliver to a broker
+	db database.Database
 }
 
 // ChainIPCs maintains IPCs for a set of chains
 type ChainIPCs struct {
 	context
 	chains                 map[ids.ID]*EventSockets
+	publisherConfigs       map[ids.ID]PublisherConfig
 	consensusAcceptorGroup snow.AcceptorGroup
 	decisionAcceptorGroup  snow.AcceptorGroup
 }
 
-// NewChainIPCs creates a new *ChainIPCs that writes consensus and decision
-// events to IPC sockets
-func NewChainIPCs(log logging.Logger, path string, networkID uint32, consensusAcceptorGroup, decisionAcceptorGroup snow.AcceptorGroup, defaultChainIDs []ids.ID) (*ChainIPCs, error) {
+// NewChainIPCs creates a new *ChainIPCs that publishes consensus and decision
+// events. The events of a chain are written to IPC sockets unless a different
+// publisher is configured for it in [publisherConfigs]. [db] stores the events
+// that are waiting to be delivered to a broker.
+func NewChainIPCs(
+	log logging.Logger,
+	path string,
+	networkID uint32,
+	db database.Database,
+	publisherConfigs map[ids.ID]PublisherConfig,
+	consensusAcceptorGroup,
+	decisionAcceptorGroup snow.AcceptorGroup,
+	defaultChainIDs []ids.ID,
+) (*ChainIPCs, error) {
 	cipcs := &ChainIPCs{
 		context: context{
 			log:       log,
 			networkID: networkID,
 			path:      path,
+			db:        db,
 		},
 		chains:                 make(map[ids.ID]*EventSockets),
+		publisherConfigs:       publisherConfigs,
 		consensusAcceptorGroup: consensusAcceptorGroup,
 		decisionAcceptorGroup:  decisionAcceptorGroup,
 	}
@@ -64,14 +81,14 @@ func (cipcs *ChainIPCs) Publish(chainID ids.ID) (*EventSockets, error) {
 		return es, nil
 	}
 
-	es, err := newEventSockets(cipcs.context, chainID, cipcs.consensusAcceptorGroup, cipcs.decisionAcceptorGroup)
+	es, err := newEventSockets(cipcs.context, chainID, cipcs.publisherConfigs[chainID], cipcs.consensusAcceptorGroup, cipcs.decisionAcceptorGroup)
 	if err != nil {
 		cipcs.log.Error("can't create ipcs: %s", err)
 		return nil, err
 	}
 
 	cipcs.chains[chainID] = es
-	cipcs.log.Info("created IPC sockets for blockchain %s at %s and %s", chainID.String(), es.ConsensusURL(), es.DecisionsURL())
+	cipcs.log.Info("publishing events of blockchain %s to %s and %s", chainID.String(), es.ConsensusURL(), es.DecisionsURL())
 	return es, nil
 }
 
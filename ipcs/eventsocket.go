@@ -4,12 +4,7 @@
 package ipcs
 
 import (
-	"errors"
-	"os"
-	"syscall"
-
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/ava-labs/avalanchego/ipcs/socket"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -17,21 +12,24 @@ import (
 
 var _ snow.Acceptor = &EventSockets{}
 
-// EventSockets is a set of named eventSockets
+// EventSockets is the set of consensus and decisions eventSockets of a chain
 type EventSockets struct {
 	consensusSocket *eventSocket
 	decisionsSocket *eventSocket
 }
 
 // newEventSockets creates a *ChainIPCs with both consensus and decisions IPCs
-func newEventSockets(ctx context, chainID ids.ID, consensusAcceptorGroup, decisionAcceptorGroup snow.AcceptorGroup) (*EventSockets, error) {
-	consensusIPC, err := newEventIPCSocket(ctx, chainID, ipcConsensusIdentifier, consensusAcceptorGroup)
+func newEventSockets(ctx context, chainID ids.ID, config PublisherConfig, consensusAcceptorGroup, decisionAcceptorGroup snow.AcceptorGroup) (*EventSockets, error) {
+	consensusIPC, err := newEventIPCSocket(ctx, chainID, ipcConsensusIdentifier, config, consensusAcceptorGroup)
 	if err != nil {
 		return nil, err
 	}
 
-	decisionsIPC, err := newEventIPCSocket(ctx, chainID, ipcDecisionsIdentifier, decisionAcceptorGroup)
+	decisionsIPC, err := newEventIPCSocket(ctx, chainID, ipcDecisionsIdentifier, config, decisionAcceptorGroup)
 	if err != nil {
+		if err := consensusIPC.stop(); err != nil {
+			return nil, err
+		}
 		return nil, err
 	}
 
@@ -73,55 +71,43 @@ func (ipcs *EventSockets) stop() error {
 	return errs.Err
 }
 
-// ConsensusURL returns the URL of socket receiving consensus events
+// ConsensusURL returns the URL that consensus events are published to
 func (ipcs *EventSockets) ConsensusURL() string {
 	return ipcs.consensusSocket.URL()
 }
 
-// DecisionsURL returns the URL of socket receiving decisions events
+// DecisionsURL returns the URL that decisions events are published to
 func (ipcs *EventSockets) DecisionsURL() string {
 	return ipcs.decisionsSocket.URL()
 }
 
-// eventSocket is a single IPC socket for a single chain
+// eventSocket publishes a single event stream of a single chain
 type eventSocket struct {
-	url          string
 	log          logging.Logger
-	socket       *socket.Socket
+	publisher    Publisher
 	unregisterFn func() error
 }
 
 // newEventIPCSocket creates a *eventSocket for the given chain and
-// EventDispatcher that writes to a local IPC socket
-func newEventIPCSocket(ctx context, chainID ids.ID, name string, acceptorGroup snow.AcceptorGroup) (*eventSocket, error) {
-	var (
-		url     = ipcURL(ctx, chainID, name)
-		ipcName = ipcIdentifierPrefix + "-" + name
-	)
-
-	err := os.Remove(url)
-	if err != nil && !errors.Is(err, syscall.ENOENT) {
+// EventDispatcher that publishes events using the configured publisher
+func newEventIPCSocket(ctx context, chainID ids.ID, name string, config PublisherConfig, acceptorGroup snow.AcceptorGroup) (*eventSocket, error) {
+	ipcName := ipcIdentifierPrefix + "-" + name
+
+	publisher, err := newPublisher(ctx, chainID, name, config)
+	if err != nil {
 		return nil, err
 	}
 
 	eis := &eventSocket{
-		log:    ctx.log,
-		url:    url,
-		socket: socket.NewSocket(url, ctx.log),
+		log:       ctx.log,
+		publisher: publisher,
 		unregisterFn: func() error {
 			return acceptorGroup.DeregisterAcceptor(chainID, ipcName)
 		},
 	}
 
-	if err := eis.socket.Listen(); err != nil {
-		if err := eis.socket.Close(); err != nil {
-			return nil, err
-		}
-		return nil, err
-	}
-
 	if err := acceptorGroup.RegisterAcceptor(chainID, ipcName, eis, false); err != nil {
-		if err := eis.stop(); err != nil {
+		if err := publisher.Close(); err != nil {
 			return nil, err
 		}
 		return nil, err
@@ -132,19 +118,18 @@ func newEventIPCSocket(ctx context, chainID ids.ID, name string, acceptorGroup s
 
 // Accept delivers a message to the eventSocket
 func (eis *eventSocket) Accept(_ *snow.ConsensusContext, _ ids.ID, container []byte) error {
-	eis.socket.Send(container)
-	return nil
+	return eis.publisher.Publish(container)
 }
 
 // stop unregisters the event handler and closes the eventSocket
 func (eis *eventSocket) stop() error {
 	eis.log.Info("closing Chain IPC")
 	errs := wrappers.Errs{}
-	errs.Add(eis.unregisterFn(), eis.socket.Close())
+	errs.Add(eis.unregisterFn(), eis.publisher.Close())
 	return errs.Err
 }
 
-// URL returns the URL of the socket
+// URL returns the URL that events are published to
 func (eis *eventSocket) URL() string {
-	return eis.url
+	return eis.publisher.URL()
 }
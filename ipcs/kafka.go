@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	stdcontext "context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	kafkaClientID = "avalanchego"
+
+	kafkaSASLPlain       = "plain"
+	kafkaSASLSCRAMSHA256 = "scram-sha-256"
+	kafkaSASLSCRAMSHA512 = "scram-sha-512"
+)
+
+var (
+	_ brokerClient    = &kafkaClient{}
+	_ kafka.Balancer = partitionBalancer(0)
+)
+
+// kafkaClient produces events to a single partition of a Kafka topic. The
+// brokers are discovered from the configured bootstrap brokers, and events are
+// produced to the leader of the partition. Every event is produced with
+// acks=all, so an event is only considered sent once every in-sync replica has
+// stored it. The key of the produced record is the big-endian index of the
+// event, which consumers can use to drop redelivered events.
+type kafkaClient struct {
+	url     string
+	timeout time.Duration
+	writer  *kafka.Writer
+}
+
+func newKafkaClient(config PublisherConfig, topic string, timeout time.Duration) (*kafkaClient, error) {
+	transport := &kafka.Transport{
+		DialTimeout: timeout,
+		ClientID:    kafkaClientID,
+	}
+	if config.TLS != nil {
+		tlsConfig, err := config.TLS.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLS = tlsConfig
+	}
+	mechanism, err := kafkaSASLMechanism(config)
+	if err != nil {
+		return nil, err
+	}
+	transport.SASL = mechanism
+
+	brokers := strings.Split(config.Address, ",")
+	return &kafkaClient{
+		url:     fmt.Sprintf("kafka://%s/%s/%d", config.Address, topic, config.Partition),
+		timeout: timeout,
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     partitionBalancer(config.Partition),
+			BatchSize:    1,
+			RequiredAcks: kafka.RequireAll,
+			Transport:    transport,
+		},
+	}, nil
+}
+
+// kafkaSASLMechanism returns the SASL mechanism used to authenticate to the
+// brokers, or nil if no credentials were provided
+func kafkaSASLMechanism(config PublisherConfig) (sasl.Mechanism, error) {
+	if config.Username == "" {
+		return nil, nil
+	}
+	switch strings.ToLower(config.SASLMechanism) {
+	case "", kafkaSASLPlain:
+		return plain.Mechanism{
+			Username: config.Username,
+			Password: config.Password,
+		}, nil
+	case kafkaSASLSCRAMSHA256:
+		return scram.Mechanism(scram.SHA256, config.Username, config.Password)
+	case kafkaSASLSCRAMSHA512:
+		return scram.Mechanism(scram.SHA512, config.Username, config.Password)
+	default:
+		return nil, fmt.Errorf("unknown SASL mechanism %q", config.SASLMechanism)
+	}
+}
+
+func (c *kafkaClient) URL() string { return c.url }
+
+func (c *kafkaClient) Send(index uint64, msg []byte) error {
+	key := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(key, index)
+
+	ctx, cancel := stdcontext.WithTimeout(stdcontext.Background(), c.timeout)
+	defer cancel()
+	return c.writer.WriteMessages(ctx, kafka.Message{
+		Key:   key,
+		Value: msg,
+	})
+}
+
+func (c *kafkaClient) Close() error { return c.writer.Close() }
+
+// partitionBalancer produces every message to the same partition so that
+// events are consumed in the order they were accepted
+type partitionBalancer int32
+
+func (b partitionBalancer) Balance(kafka.Message, ...int) int { return int(b) }
@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const natsClientName = "avalanchego"
+
+var _ brokerClient = &natsClient{}
+
+// natsClient publishes events to a NATS server. If [jetStream] is set, an
+// event is only considered sent once JetStream has stored it. Otherwise, an
+// event is considered sent once the server has processed it.
+//
+// The connection is established on the first send. Once connected, the
+// client reconnects to the configured servers whenever the connection is
+// lost; events sent while disconnected fail and are retried by the queue.
+type natsClient struct {
+	servers   string
+	subject   string
+	jetStream bool
+	timeout   time.Duration
+	options   []nats.Option
+
+	conn *nats.Conn
+	js   nats.JetStreamContext
+}
+
+func newNATSClient(config PublisherConfig, subject string, timeout time.Duration) (*natsClient, error) {
+	options := []nats.Option{
+		nats.Name(natsClientName),
+		nats.Timeout(timeout),
+		nats.MaxReconnects(-1),
+	}
+	switch {
+	case config.CredentialsFile != "":
+		options = append(options, nats.UserCredentials(config.CredentialsFile))
+	case config.Token != "":
+		options = append(options, nats.Token(config.Token))
+	case config.Username != "":
+		options = append(options, nats.UserInfo(config.Username, config.Password))
+	}
+	if config.TLS != nil {
+		tlsConfig, err := config.TLS.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, nats.Secure(tlsConfig))
+	}
+
+	return &natsClient{
+		servers:   config.Address,
+		subject:   subject,
+		jetStream: config.JetStream,
+		timeout:   timeout,
+		options:   options,
+	}, nil
+}
+
+func (c *natsClient) URL() string {
+	return fmt.Sprintf("nats://%s/%s", c.servers, c.subject)
+}
+
+func (c *natsClient) Send(index uint64, msg []byte) error {
+	if c.conn == nil || c.conn.IsClosed() {
+		if err := c.connect(); err != nil {
+			return err
+		}
+	}
+
+	if !c.jetStream {
+		if err := c.conn.Publish(c.subject, msg); err != nil {
+			return err
+		}
+		// The flush only returns once the server has processed the publish
+		return c.conn.FlushTimeout(c.timeout)
+	}
+
+	// JetStream drops messages with a previously seen ID, so redelivered
+	// events are stored only once.
+	_, err := c.js.Publish(
+		c.subject,
+		msg,
+		nats.MsgId(fmt.Sprintf("%s-%d", c.subject, index)),
+		nats.AckWait(c.timeout),
+	)
+	return err
+}
+
+func (c *natsClient) Close() error {
+	if c.conn != nil {
+		c.conn.Close()
+	}
+	return nil
+}
+
+func (c *natsClient) connect() error {
+	conn, err := nats.Connect(c.servers, c.options...)
+	if err != nil {
+		return err
+	}
+	if c.jetStream {
+		js, err := conn.JetStream(nats.MaxWait(c.timeout))
+		if err != nil {
+			conn.Close()
+			return err
+		}
+		c.js = js
+	}
+	c.conn = conn
+	return nil
+}
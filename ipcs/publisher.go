@@ -0,0 +1,217 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/ipcs/socket"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// SocketPublisher publishes events to a local IPC socket
+	SocketPublisher = "socket"
+	// NATSPublisher publishes events to a NATS server
+	NATSPublisher = "nats"
+	// KafkaPublisher publishes events to a Kafka broker
+	KafkaPublisher = "kafka"
+
+	defaultPublisherTimeout = 10 * time.Second
+)
+
+var (
+	errMissingPublisherAddress = errors.New("publisher address must be provided")
+	errInvalidCAFile           = errors.New("no certificates could be parsed from the CA file")
+
+	_ Publisher = &socketPublisher{}
+)
+
+// Publisher delivers the events accepted on a chain to their consumers
+type Publisher interface {
+	// Publish delivers [msg] to the consumers
+	Publish(msg []byte) error
+	// URL returns the location the events are published to
+	URL() string
+	// Close stops publishing events
+	Close() error
+}
+
+// PublisherConfig configures where, and how, the events of a chain are
+// published
+type PublisherConfig struct {
+	// Type is one of "socket", "nats" or "kafka". Defaults to "socket".
+	Type string `json:"type"`
+
+	// Comma separated addresses of the NATS servers or Kafka bootstrap
+	// brokers. Unused by sockets.
+	Address string `json:"address"`
+
+	// Prefix of the NATS subject or Kafka topic the events are published to.
+	// The event type is appended to the prefix. Defaults to
+	// "<networkID>-<chainID>".
+	Topic string `json:"topic"`
+
+	// If true, NATS messages are only considered delivered once they have been
+	// acknowledged by JetStream.
+	JetStream bool `json:"jetStream"`
+
+	// Kafka partition the events are published to
+	Partition int32 `json:"partition"`
+
+	// Credentials used to authenticate to the NATS servers or, using SASL, to
+	// the Kafka brokers
+	Username string `json:"username"`
+	Password string `json:"password"`
+
+	// SASL mechanism used to authenticate to the Kafka brokers. One of
+	// "plain", "scram-sha-256" or "scram-sha-512". Defaults to "plain" if a
+	// username is provided.
+	SASLMechanism string `json:"saslMechanism"`
+
+	// Token used to authenticate to the NATS servers
+	Token string `json:"token"`
+
+	// Path to a NATS credentials file holding a user JWT and NKey seed
+	CredentialsFile string `json:"credentialsFile"`
+
+	// If non-nil, connections to the broker are made over TLS
+	TLS *PublisherTLSConfig `json:"tls"`
+
+	// Max time to wait for the broker to acknowledge an event
+	Timeout time.Duration `json:"timeout"`
+
+	// Number of delivered events kept so that they can be replayed
+	Retention uint64 `json:"retention"`
+
+	// If set, delivery restarts from this event index when the node starts
+	// rather than from the first undelivered event. Events that are no longer
+	// retained can't be replayed.
+	ReplayFromIndex *uint64 `json:"replayFromIndex"`
+}
+
+// PublisherTLSConfig configures the TLS connections made to a broker
+type PublisherTLSConfig struct {
+	// PEM encoded certificate authorities that the broker's certificate is
+	// verified with. If empty, the system's certificate authorities are used.
+	CAFile string `json:"caFile"`
+
+	// PEM encoded certificate and key presented to the broker, if the broker
+	// requires clients to authenticate with a certificate
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+}
+
+// tlsConfig returns the TLS configuration described by [c]
+func (c *PublisherTLSConfig) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		caBytes, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(caBytes) {
+			return nil, fmt.Errorf("%w: %s", errInvalidCAFile, c.CAFile)
+		}
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// newPublisher returns the publisher of the [name] events of [chainID]
+func newPublisher(ctx context, chainID ids.ID, name string, config PublisherConfig) (Publisher, error) {
+	switch config.Type {
+	case "", SocketPublisher:
+		return newSocketPublisher(ctx.log, ipcURL(ctx, chainID, name))
+	case NATSPublisher, KafkaPublisher:
+	default:
+		return nil, fmt.Errorf("unknown publisher type %q", config.Type)
+	}
+
+	if config.Address == "" {
+		return nil, errMissingPublisherAddress
+	}
+	topic := fmt.Sprintf("%d-%s", ctx.networkID, chainID)
+	if config.Topic != "" {
+		topic = config.Topic
+	}
+	topic += "-" + name
+	timeout := defaultPublisherTimeout
+	if config.Timeout != 0 {
+		timeout = config.Timeout
+	}
+
+	var (
+		client brokerClient
+		err    error
+	)
+	if config.Type == NATSPublisher {
+		client, err = newNATSClient(config, topic, timeout)
+	} else {
+		client, err = newKafkaClient(config, topic, timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newQueuedPublisher(
+		ctx.log,
+		prefixdb.New([]byte(fmt.Sprintf("%s-%s", chainID, name)), ctx.db),
+		client,
+		config.Retention,
+		config.ReplayFromIndex,
+	)
+}
+
+// socketPublisher broadcasts events to the clients connected to a local IPC
+// socket. Events are dropped if no client is connected.
+type socketPublisher struct {
+	url    string
+	socket *socket.Socket
+}
+
+func newSocketPublisher(log logging.Logger, url string) (*socketPublisher, error) {
+	err := os.Remove(url)
+	if err != nil && !errors.Is(err, syscall.ENOENT) {
+		return nil, err
+	}
+
+	sp := &socketPublisher{
+		url:    url,
+		socket: socket.NewSocket(url, log),
+	}
+	if err := sp.socket.Listen(); err != nil {
+		if err := sp.socket.Close(); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+	return sp, nil
+}
+
+func (sp *socketPublisher) Publish(msg []byte) error {
+	sp.socket.Send(msg)
+	return nil
+}
+
+func (sp *socketPublisher) URL() string { return sp.url }
+
+func (sp *socketPublisher) Close() error { return sp.socket.Close() }
@@ -0,0 +1,244 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	minRetryDelay = 100 * time.Millisecond
+	maxRetryDelay = 30 * time.Second
+)
+
+var (
+	nextIndexKey     = []byte("next")
+	deliverIndexKey  = []byte("deliver")
+	eventQueuePrefix = []byte("events")
+
+	_ Publisher = &queuedPublisher{}
+)
+
+// brokerClient sends events to a message broker
+type brokerClient interface {
+	// Send delivers the event with the given index. It only returns nil once
+	// the broker has taken responsibility for the event. The index can be
+	// used by the broker, or consumers, to drop redelivered events.
+	Send(index uint64, msg []byte) error
+	// URL returns the location the events are sent to
+	URL() string
+	// Close releases the connection to the broker
+	Close() error
+}
+
+// queuedPublisher persists every published event before asynchronously
+// sending it to a broker. Events are sent in order and are retried until they
+// are acknowledged, so every event is delivered at least once, even across
+// restarts.
+type queuedPublisher struct {
+	log       logging.Logger
+	client    brokerClient
+	retention uint64
+
+	// [lock] guards the fields below
+	lock sync.Mutex
+	// signalled when an event is queued or the publisher is closed
+	cond *sync.Cond
+	// index of the next event to be queued
+	nextIndex uint64
+	// index of the next event to be sent
+	deliverIndex uint64
+	closed       bool
+
+	db database.Database
+	// Event index --> Event
+	events database.Database
+
+	quitCh chan struct{}
+	doneCh chan struct{}
+}
+
+// newQueuedPublisher returns a publisher that queues events in [db] and sends
+// them to [client]. The last [retention] delivered events are kept so that
+// they can be replayed. If [replayFromIndex] is provided, delivery restarts
+// from that index.
+func newQueuedPublisher(
+	log logging.Logger,
+	db database.Database,
+	client brokerClient,
+	retention uint64,
+	replayFromIndex *uint64,
+) (*queuedPublisher, error) {
+	qp := &queuedPublisher{
+		log:       log,
+		client:    client,
+		retention: retention,
+		db:        db,
+		events:    prefixdb.New(eventQueuePrefix, db),
+		quitCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+	qp.cond = sync.NewCond(&qp.lock)
+
+	var err error
+	qp.nextIndex, err = getIndex(db, nextIndexKey)
+	if err != nil {
+		return nil, err
+	}
+	qp.deliverIndex, err = getIndex(db, deliverIndexKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if replayFromIndex != nil && *replayFromIndex < qp.deliverIndex {
+		oldest, err := qp.oldestRetained()
+		if err != nil {
+			return nil, err
+		}
+		index := *replayFromIndex
+		if index < oldest {
+			log.Warn("can't replay events from %d of %s as they are no longer retained. Replaying from %d",
+				index,
+				client.URL(),
+				oldest,
+			)
+			index = oldest
+		}
+		qp.deliverIndex = index
+		if err := database.PutUInt64(db, deliverIndexKey, index); err != nil {
+			return nil, err
+		}
+	}
+
+	go qp.deliver()
+	return qp, nil
+}
+
+// Publish queues [msg] to be sent to the broker. Once Publish returns, [msg]
+// will be delivered even if the node restarts.
+func (qp *queuedPublisher) Publish(msg []byte) error {
+	qp.lock.Lock()
+	defer qp.lock.Unlock()
+
+	if err := qp.events.Put(database.PackUInt64(qp.nextIndex), msg); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(qp.db, nextIndexKey, qp.nextIndex+1); err != nil {
+		return err
+	}
+	qp.nextIndex++
+	qp.cond.Signal()
+	return nil
+}
+
+func (qp *queuedPublisher) URL() string { return qp.client.URL() }
+
+// Close stops delivering events. Undelivered events remain queued and are
+// delivered once the publisher is recreated.
+func (qp *queuedPublisher) Close() error {
+	qp.lock.Lock()
+	qp.closed = true
+	qp.cond.Broadcast()
+	qp.lock.Unlock()
+
+	close(qp.quitCh)
+	<-qp.doneCh
+	return qp.client.Close()
+}
+
+// deliver sends the queued events to the broker until the publisher is closed
+func (qp *queuedPublisher) deliver() {
+	defer close(qp.doneCh)
+
+	delay := minRetryDelay
+	for {
+		index, ok := qp.waitForEvent()
+		if !ok {
+			return
+		}
+
+		err := qp.send(index)
+		if err == nil {
+			delay = minRetryDelay
+			continue
+		}
+
+		qp.log.Warn("failed to deliver event %d to %s. Retrying in %s: %s",
+			index,
+			qp.client.URL(),
+			delay,
+			err,
+		)
+		select {
+		case <-time.After(delay):
+		case <-qp.quitCh:
+			return
+		}
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+// waitForEvent blocks until there is an event to deliver and returns its
+// index. Returns false if the publisher was closed.
+func (qp *queuedPublisher) waitForEvent() (uint64, bool) {
+	qp.lock.Lock()
+	defer qp.lock.Unlock()
+
+	for !qp.closed && qp.deliverIndex >= qp.nextIndex {
+		qp.cond.Wait()
+	}
+	return qp.deliverIndex, !qp.closed
+}
+
+// send delivers the event at [index] and marks it as delivered
+func (qp *queuedPublisher) send(index uint64) error {
+	key := database.PackUInt64(index)
+	msg, err := qp.events.Get(key)
+	if err != nil {
+		return err
+	}
+	if err := qp.client.Send(index, msg); err != nil {
+		return err
+	}
+
+	qp.lock.Lock()
+	defer qp.lock.Unlock()
+
+	qp.deliverIndex = index + 1
+	if err := database.PutUInt64(qp.db, deliverIndexKey, qp.deliverIndex); err != nil {
+		return err
+	}
+	if index < qp.retention {
+		return nil
+	}
+	return qp.events.Delete(database.PackUInt64(index - qp.retention))
+}
+
+// oldestRetained returns the index of the oldest event that is still stored.
+// Returns [deliverIndex] if no delivered event is retained.
+func (qp *queuedPublisher) oldestRetained() (uint64, error) {
+	it := qp.events.NewIterator()
+	defer it.Release()
+
+	if !it.Next() {
+		return qp.deliverIndex, it.Error()
+	}
+	return database.ParseUInt64(it.Key())
+}
+
+func getIndex(db database.KeyValueReader, key []byte) (uint64, error) {
+	index, err := database.GetUInt64(db, key)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return index, err
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ipcs
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errTestBrokerDown = errors.New("broker down")
+
+type testBrokerClient struct {
+	lock sync.Mutex
+	// Number of sends that fail before the broker accepts events
+	failures int
+	indices  []uint64
+	msgs     [][]byte
+}
+
+func (c *testBrokerClient) Send(index uint64, msg []byte) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.failures > 0 {
+		c.failures--
+		return errTestBrokerDown
+	}
+	c.indices = append(c.indices, index)
+	c.msgs = append(c.msgs, msg)
+	return nil
+}
+
+func (c *testBrokerClient) URL() string { return "test" }
+
+func (c *testBrokerClient) Close() error { return nil }
+
+func (c *testBrokerClient) waitForSends(t *testing.T, num int) ([]uint64, [][]byte) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		c.lock.Lock()
+		if len(c.indices) >= num {
+			indices, msgs := c.indices, c.msgs
+			c.lock.Unlock()
+			return indices, msgs
+		}
+		c.lock.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d events to be sent", num)
+	return nil, nil
+}
+
+func TestQueuedPublisherRetries(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	client := &testBrokerClient{failures: 2}
+	qp, err := newQueuedPublisher(logging.NoLog{}, db, client, 0, nil)
+	assert.NoError(err)
+
+	assert.NoError(qp.Publish([]byte{0}))
+	assert.NoError(qp.Publish([]byte{1}))
+
+	indices, msgs := client.waitForSends(t, 2)
+	assert.Equal([]uint64{0, 1}, indices)
+	assert.Equal([][]byte{{0}, {1}}, msgs)
+	assert.NoError(qp.Close())
+
+	// Delivered events aren't retained
+	empty, err := database.IsEmpty(qp.events)
+	assert.NoError(err)
+	assert.True(empty)
+}
+
+func TestQueuedPublisherRestart(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	client := &testBrokerClient{}
+	qp, err := newQueuedPublisher(logging.NoLog{}, db, client, 2, nil)
+	assert.NoError(err)
+	for i := byte(0); i < 4; i++ {
+		assert.NoError(qp.Publish([]byte{i}))
+	}
+	client.waitForSends(t, 4)
+	assert.NoError(qp.Close())
+
+	// Events queued while the broker is unreachable are delivered after a
+	// restart
+	client = &testBrokerClient{failures: 1 << 30}
+	qp, err = newQueuedPublisher(logging.NoLog{}, db, client, 2, nil)
+	assert.NoError(err)
+	assert.NoError(qp.Publish([]byte{4}))
+	assert.NoError(qp.Close())
+
+	client = &testBrokerClient{}
+	qp, err = newQueuedPublisher(logging.NoLog{}, db, client, 2, nil)
+	assert.NoError(err)
+	indices, msgs := client.waitForSends(t, 1)
+	assert.Equal([]uint64{4}, indices)
+	assert.Equal([][]byte{{4}}, msgs)
+	assert.NoError(qp.Close())
+
+	// Only the retained events can be replayed
+	replayFromIndex := uint64(0)
+	client = &testBrokerClient{}
+	qp, err = newQueuedPublisher(logging.NoLog{}, db, client, 2, &replayFromIndex)
+	assert.NoError(err)
+	indices, msgs = client.waitForSends(t, 2)
+	assert.Equal([]uint64{3, 4}, indices)
+	assert.Equal([][]byte{{3}, {4}}, msgs)
+	assert.NoError(qp.Close())
+}
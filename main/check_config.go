@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/avm"
+)
+
+// checkConfig validates the config that a node would be started with [args]
+// and prints every error that is found. Returns the number of errors.
+func checkConfig(args []string) (int, error) {
+	v, err := config.BuildViper(config.BuildFlagSet(), args)
+	if errors.Is(err, pflag.ErrHelp) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("couldn't configure flags: %w", err)
+	}
+
+	// The VMs built into the node. The P-chain's genesis is checked by the
+	// config package.
+	vmFactories := map[ids.ID]vms.Factory{
+		constants.AVMID: &avm.Factory{},
+		constants.EVMID: &evmFactory{},
+	}
+	result := config.CheckConfig(v, vmFactories)
+	for _, unchecked := range result.Unchecked {
+		fmt.Printf("not checked: %s\n", unchecked)
+	}
+	for _, err := range result.Errors {
+		fmt.Printf("error: %s\n", err)
+	}
+	if len(result.Errors) == 0 {
+		fmt.Println("config is valid")
+	}
+	return len(result.Errors), nil
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/params"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms"
+
+	coreth "github.com/ava-labs/coreth/plugin/evm"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	errNoEVMChainConfig = errors.New("genesis has no chain config")
+
+	_ vms.Factory          = &evmFactory{}
+	_ common.ConfigChecker = &evmVM{}
+)
+
+// evmFactory creates the EVMs that the node runs, which are also able to
+// check their genesis and chain config.
+type evmFactory struct{}
+
+func (*evmFactory) New(*snow.Context) (interface{}, error) {
+	return &evmVM{VM: &coreth.VM{}}, nil
+}
+
+type evmVM struct {
+	*coreth.VM
+}
+
+// CheckConfig parses [genesisBytes] and [configBytes] and validates them the
+// way the EVM does when it's initialized.
+func (*evmVM) CheckConfig(genesisBytes []byte, configBytes []byte) error {
+	config := coreth.Config{}
+	config.SetDefaults()
+	defaults := config
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &config); err != nil {
+			return err
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if _, err := gethlog.LvlFromString(config.LogLevel); err != nil {
+		return err
+	}
+
+	genesis := core.Genesis{}
+	if err := json.Unmarshal(genesisBytes, &genesis); err != nil {
+		return err
+	}
+	if genesis.Config == nil || genesis.Config.ChainID == nil {
+		return errNoEVMChainConfig
+	}
+
+	// The chain configs of the Avalanche networks replace the one in the
+	// genesis
+	switch {
+	case genesis.Config.ChainID.Cmp(params.AvalancheMainnetChainID) == 0:
+		genesis.Config = params.AvalancheMainnetChainConfig
+	case genesis.Config.ChainID.Cmp(params.AvalancheFujiChainID) == 0:
+		genesis.Config = params.AvalancheFujiChainConfig
+	case genesis.Config.ChainID.Cmp(params.AvalancheLocalChainID) == 0:
+		genesis.Config = params.AvalancheLocalChainConfig
+	}
+	if err := genesis.Config.CheckConfigForkOrder(); err != nil {
+		return err
+	}
+
+	// Non-default commit intervals are only allowed on the local network
+	if genesis.Config.ChainID.Cmp(params.AvalancheLocalChainID) != 0 {
+		if config.CommitInterval != defaults.CommitInterval {
+			return fmt.Errorf("cannot start non-local network with commit interval %d", config.CommitInterval)
+		}
+		if config.StateSyncCommitInterval != defaults.StateSyncCommitInterval {
+			return fmt.Errorf("cannot start non-local network with syncable interval %d", config.StateSyncCommitInterval)
+		}
+	}
+	return nil
+}
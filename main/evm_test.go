@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEVMCheckConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	vm := &evmVM{}
+	localGenesis := []byte(`{"config":{"chainId":43112},"gasLimit":"0x1","difficulty":"0x0","alloc":{}}`)
+	otherGenesis := []byte(`{"config":{"chainId":1337},"gasLimit":"0x1","difficulty":"0x0","alloc":{}}`)
+
+	assert.Error(vm.CheckConfig([]byte(`{"config":`), nil))
+	assert.ErrorIs(vm.CheckConfig([]byte(`{"gasLimit":"0x1","difficulty":"0x0","alloc":{}}`), nil), errNoEVMChainConfig)
+	assert.NoError(vm.CheckConfig(localGenesis, nil))
+
+	assert.NoError(vm.CheckConfig(localGenesis, []byte(`{"pruning-enabled": false}`)))
+	assert.Error(vm.CheckConfig(localGenesis, []byte(`{"pruning-enabled": "yes"}`)))
+	assert.Error(vm.CheckConfig(localGenesis, []byte(`{"log-level": "loud"}`)))
+
+	// Commit intervals can only be changed on the local network
+	assert.NoError(vm.CheckConfig(localGenesis, []byte(`{"commit-interval": 1}`)))
+	assert.Error(vm.CheckConfig(otherGenesis, []byte(`{"commit-interval": 1}`)))
+}
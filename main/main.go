@@ -16,6 +16,29 @@ import (
 )
 
 func main() {
+	// "avalanchego db prune [flags]" prunes the database of a stopped node
+	if len(os.Args) > 2 && os.Args[1] == "db" && os.Args[2] == "prune" {
+		if err := pruneDB(os.Args[3:]); err != nil {
+			fmt.Printf("couldn't prune database: %s\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// "avalanchego check-config [flags]" validates the config of a node
+	// without starting it
+	if len(os.Args) > 1 && os.Args[1] == "check-config" {
+		numErrs, err := checkConfig(os.Args[2:])
+		if err != nil {
+			fmt.Printf("couldn't check config: %s\n", err)
+			os.Exit(1)
+		}
+		if numErrs != 0 {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	fs := config.BuildFlagSet()
 	v, err := config.BuildViper(fs, os.Args[1:])
 
@@ -44,6 +67,7 @@ func main() {
 		fmt.Printf("couldn't load node config: %s\n", err)
 		os.Exit(1)
 	}
+	nodeConfig.ConfigReloader = config.NewConfigReloader(os.Args[1:], v, nodeConfig.ChainConfigs)
 
 	runner.Run(runnerConfig, nodeConfig)
 }
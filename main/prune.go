@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/app/prune"
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errPruneInMemoryDB = errors.New("an in-memory database can't be pruned")
+
+// pruneDB prunes the database of a stopped node. [args] are parsed the same
+// way as the flags of the node, so the database is found using the same
+// flags, environment variables and config file the node is run with.
+func pruneDB(args []string) error {
+	v, err := config.BuildViper(config.BuildFlagSet(), args)
+	if errors.Is(err, pflag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't configure flags: %w", err)
+	}
+
+	dbConfig, err := config.GetDatabaseConfig(v)
+	if err != nil {
+		return fmt.Errorf("couldn't load database config: %w", err)
+	}
+	if dbConfig.Name == memdb.Name {
+		return errPruneInMemoryDB
+	}
+	pruneConfig, err := config.GetPruneConfig(v)
+	if err != nil {
+		return fmt.Errorf("couldn't load prune config: %w", err)
+	}
+
+	log := logging.NewLogger(
+		false,
+		"",
+		logging.NewWrappedCore(logging.Info, os.Stdout, logging.Plain.ConsoleEncoder()),
+	)
+	dbManager, err := node.NewDatabaseManager(dbConfig, log, prometheus.NewRegistry())
+	if err != nil {
+		return fmt.Errorf("couldn't open database, make sure the node isn't running: %w", err)
+	}
+
+	report, err := prune.Prune(log, dbManager, pruneConfig)
+	if closeErr := dbManager.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(report.Prefixes) == 0 {
+		fmt.Println("no unreferenced state was found")
+	}
+	for _, prefixReport := range report.Prefixes {
+		fmt.Printf("%s: removed %d keys (%d bytes)\n", prefixReport.Name, prefixReport.Keys, prefixReport.Bytes)
+	}
+	fmt.Printf("database size: %d bytes before pruning, %d bytes after pruning\n", report.SizeBefore, report.SizeAfter)
+	return nil
+}
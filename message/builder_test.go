@@ -39,6 +39,7 @@ func init() {
 
 func TestBuildVersion(t *testing.T) {
 	networkID := uint32(12345)
+	capabilities := uint32(1)
 	myTime := uint64(time.Now().Unix())
 	ip := ips.IPPort{
 		IP: net.IPv4(1, 2, 3, 4),
@@ -56,6 +57,7 @@ func TestBuildVersion(t *testing.T) {
 	subnetIDs := [][]byte{subnetID[:]}
 	msg, err := UncompressingBuilder.Version(
 		networkID,
+		capabilities,
 		myTime,
 		ip,
 		myVersionStr,
@@ -73,6 +75,7 @@ func TestBuildVersion(t *testing.T) {
 	assert.NotNil(t, parsedMsg)
 	assert.Equal(t, Version, parsedMsg.Op())
 	assert.EqualValues(t, networkID, parsedMsg.Get(NetworkID))
+	assert.EqualValues(t, capabilities, parsedMsg.Get(Capabilities))
 	assert.EqualValues(t, myTime, parsedMsg.Get(MyTime))
 	assert.EqualValues(t, ip, parsedMsg.Get(IP))
 	assert.EqualValues(t, myVersionStr, parsedMsg.Get(VersionStr))
@@ -7,12 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bytespool"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/metric"
@@ -52,10 +52,6 @@ type Codec interface {
 // codec defines the serialization and deserialization of network messages.
 // It's safe for multiple goroutines to call Pack and Parse concurrently.
 type codec struct {
-	// Contains []byte. Used as an optimization.
-	// Can be accessed by multiple goroutines concurrently.
-	byteSlicePool sync.Pool
-
 	clock mockable.Clock
 
 	compressTimeMetrics   map[Op]metric.Averager
@@ -66,11 +62,6 @@ type codec struct {
 
 func NewCodecWithMemoryPool(namespace string, metrics prometheus.Registerer, maxMessageSize int64, maxMessageTimeout time.Duration) (Codec, error) {
 	c := &codec{
-		byteSlicePool: sync.Pool{
-			New: func() interface{} {
-				return make([]byte, 0, constants.DefaultByteSliceCap)
-			},
-		},
 		compressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
 		decompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
 		compressor:            compression.NewGzipCompressor(maxMessageSize),
@@ -123,10 +114,13 @@ func (c *codec) Pack(
 		return nil, errBadOp
 	}
 
-	buffer := c.byteSlicePool.Get().([]byte)
+	// The buffer is returned to the pool once the message's reference count
+	// drops to 0. The packer may outgrow the buffer, in which case the buffer
+	// is still the slice that is returned to the pool.
+	buf := bytespool.Get(constants.DefaultByteSliceCap)
 	p := wrappers.Packer{
 		MaxSize: math.MaxInt32,
-		Bytes:   buffer[:0],
+		Bytes:   buf[:0],
 	}
 	// Pack the op code (message type)
 	p.PackByte(byte(op))
@@ -140,18 +134,20 @@ func (c *codec) Pack(
 	for _, field := range msgFields {
 		data, ok := fieldValues[field]
 		if !ok {
+			bytespool.Put(buf)
 			return nil, errMissingField
 		}
 		field.Packer()(&p, data)
 	}
 	if p.Err != nil {
+		bytespool.Put(buf)
 		return nil, p.Err
 	}
 	msg := &outboundMessage{
 		op:               op,
 		bytes:            p.Bytes,
+		buf:              buf,
 		refs:             1,
-		c:                c,
 		bypassThrottling: bypassThrottling,
 	}
 	if !compress {
@@ -165,6 +161,7 @@ func (c *codec) Pack(
 	startTime := time.Now()
 	compressedPayloadBytes, err := c.compressor.Compress(payloadBytes)
 	if err != nil {
+		bytespool.Put(buf)
 		return nil, fmt.Errorf("couldn't compress payload of %s message: %w", op, err)
 	}
 	c.compressTimeMetrics[op].Observe(float64(time.Since(startTime)))
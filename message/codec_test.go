@@ -103,7 +103,7 @@ func TestCodecPackParseGzip(t *testing.T) {
 			op: Version,
 			fields: map[Field]interface{}{
 				NetworkID:      uint32(0),
-				NodeID:         uint32(1337),
+				Capabilities:   uint32(1337),
 				MyTime:         uint64(time.Now().Unix()),
 				IP:             ips.IPPort{IP: net.IPv4(1, 2, 3, 4)},
 				VersionStr:     "v1.2.3",
@@ -14,7 +14,7 @@ type Field uint32
 const (
 	VersionStr          Field = iota // Used in handshake
 	NetworkID                        // Used in handshake
-	NodeID                           // TODO: remove NodeID. Used in handshake
+	Capabilities                     // Used in handshake
 	MyTime                           // Used in handshake
 	IP                               // Used in handshake
 	ChainID                          // Used for dispatching
@@ -44,7 +44,7 @@ func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 		return wrappers.TryPackStr
 	case NetworkID:
 		return wrappers.TryPackInt
-	case NodeID:
+	case Capabilities:
 		return wrappers.TryPackInt
 	case MyTime:
 		return wrappers.TryPackLong
@@ -94,7 +94,7 @@ func (f Field) Unpacker() func(*wrappers.Packer) interface{} {
 		return wrappers.TryUnpackStr
 	case NetworkID:
 		return wrappers.TryUnpackInt
-	case NodeID:
+	case Capabilities:
 		return wrappers.TryUnpackInt
 	case MyTime:
 		return wrappers.TryUnpackLong
@@ -143,8 +143,8 @@ func (f Field) String() string {
 		return "VersionStr"
 	case NetworkID:
 		return "NetworkID"
-	case NodeID:
-		return "NodeID"
+	case Capabilities:
+		return "Capabilities"
 	case MyTime:
 		return "MyTime"
 	case IP:
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bytespool"
 )
 
 var (
@@ -105,14 +106,16 @@ type OutboundMessage interface {
 }
 
 type outboundMessage struct {
-	bytes                 []byte
+	bytes []byte
+	// buf is the pooled buffer [bytes] was packed into. [bytes] may have
+	// outgrown it.
+	buf                   []byte
 	bytesSavedCompression int
 	op                    Op
 	bypassThrottling      bool
 
 	refLock sync.Mutex
 	refs    int
-	c       *codec
 }
 
 // Op returns the value of the specified operation in this message
@@ -142,7 +145,7 @@ func (outMsg *outboundMessage) DecRef() {
 
 	outMsg.refs--
 	if outMsg.refs == 0 {
-		outMsg.c.byteSlicePool.Put(outMsg.bytes)
+		bytespool.Put(outMsg.buf)
 	}
 }
 
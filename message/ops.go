@@ -213,8 +213,7 @@ var (
 	// Defines the messages that can be sent/received with this network
 	messages = map[Op][]Field{
 		// Handshake:
-		// TODO: remove NodeID from the Version message
-		Version:  {NetworkID, NodeID, MyTime, IP, VersionStr, VersionTime, SigBytes, TrackedSubnets},
+		Version:  {NetworkID, Capabilities, MyTime, IP, VersionStr, VersionTime, SigBytes, TrackedSubnets},
 		PeerList: {Peers},
 		Ping:     {},
 		Pong:     {Uptime},
@@ -18,6 +18,7 @@ var _ OutboundMsgBuilder = &outMsgBuilder{}
 type OutboundMsgBuilder interface {
 	Version(
 		networkID uint32,
+		capabilities uint32,
 		myTime uint64,
 		ip ips.IPPort,
 		myVersion string,
@@ -173,6 +174,7 @@ func NewOutboundBuilder(c Codec, enableCompression bool) OutboundMsgBuilder {
 
 func (b *outMsgBuilder) Version(
 	networkID uint32,
+	capabilities uint32,
 	myTime uint64,
 	ip ips.IPPort,
 	myVersion string,
@@ -189,7 +191,7 @@ func (b *outMsgBuilder) Version(
 		Version,
 		map[Field]interface{}{
 			NetworkID:      networkID,
-			NodeID:         uint32(0),
+			Capabilities:   capabilities,
 			MyTime:         myTime,
 			IP:             ip,
 			VersionStr:     myVersion,
@@ -84,6 +84,22 @@ type Network interface {
 	PeerInfo(nodeIDs []ids.NodeID) []peer.Info
 
 	NodeUptime() (UptimeResult, bool)
+
+	// SetHealthConfig replaces the thresholds used by HealthCheck.
+	// [SendFailRateHalflife] can't be changed and is ignored.
+	SetHealthConfig(config HealthConfig)
+
+	// SetBandwidthThrottlerConfig replaces the limits of the inbound bandwidth
+	// throttler.
+	SetBandwidthThrottlerConfig(config throttling.BandwidthThrottlerConfig)
+
+	// TrackSubnet starts tracking [subnetID]. Connected validators of the
+	// subnet are reconnected so that the subnet is included in the handshake.
+	TrackSubnet(subnetID ids.ID)
+
+	// UntrackSubnet stops tracking [subnetID]. Connected peers that track the
+	// subnet are reconnected so that the subnet is removed from the handshake.
+	UntrackSubnet(subnetID ids.ID)
 }
 
 type UptimeResult struct {
@@ -121,6 +137,11 @@ type network struct {
 
 	sendFailRateCalculator math.Averager
 
+	healthConfigLock sync.RWMutex
+	// healthConfig is initialized from [config.HealthConfig] and may be
+	// replaced at runtime.
+	healthConfig HealthConfig
+
 	peersLock sync.RWMutex
 	// trackedIPs contains the set of IPs that we are currently attempting to
 	// connect to. An entry is added to this set when we first start attempting
@@ -214,7 +235,6 @@ func NewNetwork(
 		Network:              nil, // This is set below.
 		Router:               router,
 		VersionCompatibility: version.GetCompatibility(config.NetworkID),
-		MySubnets:            config.WhitelistedSubnets,
 		Beacons:              config.Beacons,
 		NetworkID:            config.NetworkID,
 		PingFrequency:        config.PingFrequency,
@@ -222,7 +242,12 @@ func NewNetwork(
 		MaxClockDifference:   config.MaxClockDifference,
 		ResourceTracker:      config.ResourceTracker,
 		PingMessage:          pingMessge,
+		MyCapabilities:       peer.SupportedCapabilities,
+		RequiredCapabilities: peer.RequiredCapabilities(config.TLSKey.Public()),
 	}
+	// The tracked subnets may be modified at runtime, so they are copied to
+	// avoid modifying [config.WhitelistedSubnets].
+	peerConfig.MySubnets.Union(config.WhitelistedSubnets)
 	onCloseCtx, cancel := context.WithCancel(context.Background())
 	n := &network{
 		config:               config,
@@ -245,6 +270,7 @@ func NewNetwork(
 			config.SendFailRateHalflife,
 			time.Now(),
 		)),
+		healthConfig: config.HealthConfig,
 
 		trackedIPs:      make(map[ids.NodeID]*trackedIP),
 		connectingPeers: peer.NewSet(),
@@ -280,6 +306,10 @@ func (n *network) Gossip(
 // 1) Information about health check results
 // 2) An error if the health check reports unhealthy
 func (n *network) HealthCheck() (interface{}, error) {
+	n.healthConfigLock.RLock()
+	healthConfig := n.healthConfig
+	n.healthConfigLock.RUnlock()
+
 	n.peersLock.RLock()
 	connectedTo := n.connectedPeers.Len()
 	n.peersLock.RUnlock()
@@ -287,7 +317,7 @@ func (n *network) HealthCheck() (interface{}, error) {
 	sendFailRate := n.sendFailRateCalculator.Read()
 
 	// Make sure we're connected to at least the minimum number of peers
-	isConnected := connectedTo >= int(n.config.HealthConfig.MinConnectedPeers)
+	isConnected := connectedTo >= int(healthConfig.MinConnectedPeers)
 	healthy := isConnected
 	details := map[string]interface{}{
 		ConnectedPeersKey: connectedTo,
@@ -298,7 +328,7 @@ func (n *network) HealthCheck() (interface{}, error) {
 
 	lastMsgReceivedAt := time.Unix(atomic.LoadInt64(&n.peerConfig.LastReceived), 0)
 	timeSinceLastMsgReceived := now.Sub(lastMsgReceivedAt)
-	wasMsgReceivedRecently := timeSinceLastMsgReceived <= n.config.HealthConfig.MaxTimeSinceMsgReceived
+	wasMsgReceivedRecently := timeSinceLastMsgReceived <= healthConfig.MaxTimeSinceMsgReceived
 	healthy = healthy && wasMsgReceivedRecently
 	details[TimeSinceLastMsgReceivedKey] = timeSinceLastMsgReceived.String()
 	n.metrics.timeSinceLastMsgReceived.Set(float64(timeSinceLastMsgReceived))
@@ -306,13 +336,13 @@ func (n *network) HealthCheck() (interface{}, error) {
 	// Make sure we've sent an outgoing message within the threshold
 	lastMsgSentAt := time.Unix(atomic.LoadInt64(&n.peerConfig.LastSent), 0)
 	timeSinceLastMsgSent := now.Sub(lastMsgSentAt)
-	wasMsgSentRecently := timeSinceLastMsgSent <= n.config.HealthConfig.MaxTimeSinceMsgSent
+	wasMsgSentRecently := timeSinceLastMsgSent <= healthConfig.MaxTimeSinceMsgSent
 	healthy = healthy && wasMsgSentRecently
 	details[TimeSinceLastMsgSentKey] = timeSinceLastMsgSent.String()
 	n.metrics.timeSinceLastMsgSent.Set(float64(timeSinceLastMsgSent))
 
 	// Make sure the message send failed rate isn't too high
-	isMsgFailRate := sendFailRate <= n.config.HealthConfig.MaxSendFailRate
+	isMsgFailRate := sendFailRate <= healthConfig.MaxSendFailRate
 	healthy = healthy && isMsgFailRate
 	details[SendFailRateKey] = sendFailRate
 	n.metrics.sendFailRate.Set(sendFailRate)
@@ -321,16 +351,16 @@ func (n *network) HealthCheck() (interface{}, error) {
 	if !healthy {
 		var errorReasons []string
 		if !isConnected {
-			errorReasons = append(errorReasons, fmt.Sprintf("not connected to a minimum of %d peer(s) only %d", n.config.HealthConfig.MinConnectedPeers, connectedTo))
+			errorReasons = append(errorReasons, fmt.Sprintf("not connected to a minimum of %d peer(s) only %d", healthConfig.MinConnectedPeers, connectedTo))
 		}
 		if !wasMsgReceivedRecently {
-			errorReasons = append(errorReasons, fmt.Sprintf("no messages from network received in %s > %s", timeSinceLastMsgReceived, n.config.HealthConfig.MaxTimeSinceMsgReceived))
+			errorReasons = append(errorReasons, fmt.Sprintf("no messages from network received in %s > %s", timeSinceLastMsgReceived, healthConfig.MaxTimeSinceMsgReceived))
 		}
 		if !wasMsgSentRecently {
-			errorReasons = append(errorReasons, fmt.Sprintf("no messages from network sent in %s > %s", timeSinceLastMsgSent, n.config.HealthConfig.MaxTimeSinceMsgSent))
+			errorReasons = append(errorReasons, fmt.Sprintf("no messages from network sent in %s > %s", timeSinceLastMsgSent, healthConfig.MaxTimeSinceMsgSent))
 		}
 		if !isMsgFailRate {
-			errorReasons = append(errorReasons, fmt.Sprintf("messages failure send rate %g > %g", sendFailRate, n.config.HealthConfig.MaxSendFailRate))
+			errorReasons = append(errorReasons, fmt.Sprintf("messages failure send rate %g > %g", sendFailRate, healthConfig.MaxSendFailRate))
 		}
 
 		return details, fmt.Errorf("network layer is unhealthy reason: %s", strings.Join(errorReasons, ", "))
@@ -338,6 +368,71 @@ func (n *network) HealthCheck() (interface{}, error) {
 	return details, nil
 }
 
+func (n *network) SetHealthConfig(config HealthConfig) {
+	n.healthConfigLock.Lock()
+	defer n.healthConfigLock.Unlock()
+
+	config.SendFailRateHalflife = n.healthConfig.SendFailRateHalflife
+	n.healthConfig = config
+}
+
+func (n *network) SetBandwidthThrottlerConfig(config throttling.BandwidthThrottlerConfig) {
+	n.peerConfig.InboundMsgThrottler.SetBandwidthThrottlerConfig(config)
+}
+
+func (n *network) TrackSubnet(subnetID ids.ID) {
+	n.peerConfig.MySubnetsLock.Lock()
+	if n.peerConfig.MySubnets.Contains(subnetID) {
+		n.peerConfig.MySubnetsLock.Unlock()
+		return
+	}
+	n.metrics.numSubnetPeers.WithLabelValues(subnetID.String()).Set(0)
+	n.peerConfig.MySubnets.Add(subnetID)
+	n.peerConfig.MySubnetsLock.Unlock()
+
+	n.peerConfig.Log.Info("tracking subnet %s", subnetID)
+
+	// Peers only learn which subnets this node tracks during the handshake.
+	n.closePeers(func(p peer.Peer) bool {
+		return n.config.Validators.Contains(subnetID, p.ID())
+	})
+}
+
+func (n *network) UntrackSubnet(subnetID ids.ID) {
+	n.peerConfig.MySubnetsLock.Lock()
+	if !n.peerConfig.MySubnets.Contains(subnetID) {
+		n.peerConfig.MySubnetsLock.Unlock()
+		return
+	}
+	n.peerConfig.MySubnets.Remove(subnetID)
+	n.peerConfig.MySubnetsLock.Unlock()
+
+	n.peerConfig.Log.Info("stopped tracking subnet %s", subnetID)
+
+	n.closePeers(func(p peer.Peer) bool {
+		trackedSubnets := p.TrackedSubnets()
+		return trackedSubnets.Contains(subnetID)
+	})
+}
+
+// closePeers closes the connections to the connected peers that satisfy
+// [shouldClose]. Validators are reconnected to automatically.
+func (n *network) closePeers(shouldClose func(peer.Peer) bool) {
+	n.peersLock.RLock()
+	var peers []peer.Peer
+	for i := 0; i < n.connectedPeers.Len(); i++ {
+		p, _ := n.connectedPeers.GetByIndex(i)
+		if shouldClose(p) {
+			peers = append(peers, p)
+		}
+	}
+	n.peersLock.RUnlock()
+
+	for _, p := range peers {
+		p.StartClose()
+	}
+}
+
 // Connected is called after the peer finishes the handshake.
 // Will not be called after [Disconnected] is called with this peer.
 func (n *network) Connected(nodeID ids.NodeID) {
@@ -467,14 +562,20 @@ func (n *network) Version() (message.OutboundMessage, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	n.peerConfig.MySubnetsLock.RLock()
+	mySubnets := n.peerConfig.MySubnets.List()
+	n.peerConfig.MySubnetsLock.RUnlock()
+
 	return n.peerConfig.MessageCreator.Version(
 		n.peerConfig.NetworkID,
+		uint32(n.peerConfig.MyCapabilities),
 		n.peerConfig.Clock.Unix(),
 		mySignedIP.IP.IP,
 		n.peerConfig.VersionCompatibility.Version().String(),
 		mySignedIP.IP.Timestamp,
 		mySignedIP.Signature,
-		n.peerConfig.MySubnets.List(),
+		mySubnets,
 	)
 }
 
@@ -597,7 +698,10 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 
 func (n *network) TracksSubnet(nodeID ids.NodeID, subnetID ids.ID) bool {
 	if n.config.MyNodeID == nodeID {
-		return subnetID == constants.PrimaryNetworkID || n.config.WhitelistedSubnets.Contains(subnetID)
+		n.peerConfig.MySubnetsLock.RLock()
+		defer n.peerConfig.MySubnetsLock.RUnlock()
+
+		return subnetID == constants.PrimaryNetworkID || n.peerConfig.MySubnets.Contains(subnetID)
 	}
 
 	n.peersLock.RLock()
@@ -357,3 +357,35 @@ func TestTrackVerifiesSignatures(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestTrackSubnet(t *testing.T) {
+	assert := assert.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+
+	network := networks[0].(*network)
+	subnetID := ids.GenerateTestID()
+	assert.False(network.TracksSubnet(nodeIDs[0], subnetID))
+
+	network.TrackSubnet(subnetID)
+	assert.True(network.TracksSubnet(nodeIDs[0], subnetID))
+
+	network.peerConfig.MySubnetsLock.RLock()
+	assert.True(network.peerConfig.MySubnets.Contains(subnetID))
+	network.peerConfig.MySubnetsLock.RUnlock()
+
+	// The subnet should be advertised in future handshakes
+	msg, err := network.Version()
+	assert.NoError(err)
+	inboundMsg, err := newMessageCreator(t).Parse(msg.Bytes(), nodeIDs[0], func() {})
+	assert.NoError(err)
+	assert.Equal([][]byte{subnetID[:]}, inboundMsg.Get(message.TrackedSubnets))
+
+	network.UntrackSubnet(subnetID)
+	assert.False(network.TracksSubnet(nodeIDs[0], subnetID))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
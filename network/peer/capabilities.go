@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"crypto"
+	"crypto/ed25519"
+)
+
+// Capabilities is a bitset of optional protocol features. Each node advertises
+// the capabilities it supports in its Version message.
+//
+// Nodes that predate capabilities always advertise 0.
+type Capabilities uint32
+
+const (
+	// Ed25519Certs is set if the node accepts peers that authenticate with
+	// ed25519 staking certificates.
+	Ed25519Certs Capabilities = 1 << iota
+)
+
+// SupportedCapabilities are the capabilities supported by this node.
+const SupportedCapabilities = Ed25519Certs
+
+// Contains returns true if every capability in [required] is in [c].
+func (c Capabilities) Contains(required Capabilities) bool {
+	return c&required == required
+}
+
+// RequiredCapabilities returns the capabilities that a peer must support to
+// authenticate a node that signs with [key].
+func RequiredCapabilities(key crypto.PublicKey) Capabilities {
+	if _, ok := key.(ed25519.PublicKey); ok {
+		return Ed25519Certs
+	}
+	return 0
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/staking"
+)
+
+func TestCapabilitiesContains(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(Capabilities(0).Contains(0))
+	assert.True(Ed25519Certs.Contains(0))
+	assert.True(Ed25519Certs.Contains(Ed25519Certs))
+	assert.False(Capabilities(0).Contains(Ed25519Certs))
+	assert.False(Ed25519Certs.Contains(Ed25519Certs | 1<<31))
+}
+
+func TestRequiredCapabilities(t *testing.T) {
+	tests := []struct {
+		keyType  staking.KeyType
+		required Capabilities
+	}{
+		{keyType: staking.RSA, required: 0},
+		{keyType: staking.ECDSA, required: 0},
+		{keyType: staking.Ed25519, required: Ed25519Certs},
+	}
+	for _, test := range tests {
+		t.Run(test.keyType.String(), func(t *testing.T) {
+			assert := assert.New(t)
+
+			cert, err := staking.NewTLSCertWithKeyType(test.keyType)
+			assert.NoError(err)
+			assert.Equal(test.required, RequiredCapabilities(cert.Leaf.PublicKey))
+			assert.True(SupportedCapabilities.Contains(test.required))
+		})
+	}
+}
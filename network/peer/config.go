@@ -4,6 +4,7 @@
 package peer
 
 import (
+	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -30,7 +31,6 @@ type Config struct {
 	Network              Network
 	Router               router.InboundHandler
 	VersionCompatibility version.Compatibility
-	MySubnets            ids.Set
 	Beacons              validators.Set
 	NetworkID            uint32
 	PingFrequency        time.Duration
@@ -45,4 +45,16 @@ type Config struct {
 	ResourceTracker tracker.ResourceTracker
 
 	PingMessage message.OutboundMessage
+
+	// Capabilities that this node advertises in its Version message.
+	MyCapabilities Capabilities
+	// Capabilities that a peer must advertise for this node to remain
+	// connected to it.
+	RequiredCapabilities Capabilities
+
+	// Subnets that this node tracks. The tracked subnets may change while the
+	// node is running, so [MySubnetsLock] must be held when accessing
+	// [MySubnets].
+	MySubnetsLock sync.RWMutex
+	MySubnets     ids.Set
 }
@@ -5,10 +5,9 @@ package peer
 
 import (
 	"crypto"
-	"crypto/rand"
 	"crypto/x509"
 
-	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
@@ -23,11 +22,7 @@ type UnsignedIP struct {
 
 // Sign this IP with the provided signer and return the signed IP.
 func (ip *UnsignedIP) Sign(signer crypto.Signer) (*SignedIP, error) {
-	sig, err := signer.Sign(
-		rand.Reader,
-		hashing.ComputeHash256(ip.bytes()),
-		crypto.SHA256,
-	)
+	sig, err := staking.Sign(signer, ip.bytes())
 	return &SignedIP{
 		IP:        *ip,
 		Signature: sig,
@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/bytespool"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -74,6 +75,10 @@ type Peer interface {
 	// be called after [Ready] returns true.
 	TrackedSubnets() ids.Set
 
+	// Capabilities returns the protocol features this peer supports. It should
+	// only be called after [Ready] returns true.
+	Capabilities() Capabilities
+
 	// ObservedUptime returns the local node's uptime according to the peer. The
 	// value ranges from [0, 100]. It should only be called after [Ready]
 	// returns true.
@@ -121,6 +126,9 @@ type peer struct {
 	// trackedSubnets is the subset of subnetIDs the peer sent us in the Version
 	// message that we are also tracking.
 	trackedSubnets ids.Set
+	// capabilities are the protocol features the peer advertised in the
+	// Version message.
+	capabilities Capabilities
 
 	observedUptimeLock sync.RWMutex
 	// [observedUptimeLock] must be held while accessing [observedUptime]
@@ -243,6 +251,8 @@ func (p *peer) Version() *version.Application { return p.version }
 
 func (p *peer) TrackedSubnets() ids.Set { return p.trackedSubnets }
 
+func (p *peer) Capabilities() Capabilities { return p.capabilities }
+
 func (p *peer) ObservedUptime() uint8 {
 	p.observedUptimeLock.RLock()
 	uptime := p.observedUptime
@@ -427,13 +437,15 @@ func (p *peer) readMessages() {
 }
 
 func (p *peer) writeMessages() {
+	// The write buffer is only held while there are messages waiting to be
+	// flushed, so idle peers don't each pin a buffer.
+	writer := bytespool.NewWriter(p.conn, p.Config.WriteBufferSize)
 	defer func() {
+		writer.Discard()
 		p.StartClose()
 		p.close()
 	}()
 
-	writer := bufio.NewWriterSize(p.conn, p.Config.WriteBufferSize)
-
 	// Make sure that the version is the first message sent
 	msg, err := p.Network.Version()
 	p.Log.AssertNoError(err)
@@ -488,8 +500,8 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	}
 
 	// Write the message
-	var buf net.Buffers = [][]byte{msgLenBytes[:], msgBytes}
-	if _, err := io.CopyN(writer, &buf, int64(wrappers.IntLen+msgLen)); err != nil {
+	buf := net.Buffers{msgLenBytes[:], msgBytes}
+	if _, err := buf.WriteTo(writer); err != nil {
 		p.Log.Verbo("error writing to %s: %s", p.id, err)
 		msg.DecRef()
 		return
@@ -669,13 +681,29 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 		return
 	}
 
+	// Both this node and the peer must be able to authenticate the other's
+	// certificate.
+	capabilities := Capabilities(msg.Get(message.Capabilities).(uint32))
+	required := p.RequiredCapabilities | RequiredCapabilities(p.cert.PublicKey)
+	if !capabilities.Contains(required) {
+		p.Log.Debug(
+			"peer %s advertised capabilities (%d) that don't include the required capabilities (%d)",
+			p.id, capabilities, required,
+		)
+		p.StartClose()
+		return
+	}
+	p.capabilities = capabilities
+
 	peerIP := msg.Get(message.IP).(ips.IPPort)
 
 	// handle subnet IDs
 	subnetIDsBytes := msg.Get(message.TrackedSubnets).([][]byte)
+	p.MySubnetsLock.RLock()
 	for _, subnetIDBytes := range subnetIDsBytes {
 		subnetID, err := ids.ToID(subnetIDBytes)
 		if err != nil {
+			p.MySubnetsLock.RUnlock()
 			p.Log.Debug(
 				"tracked subnet of %s could not be parsed: %s",
 				p.id, err,
@@ -688,6 +716,7 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 			p.trackedSubnets.Add(subnetID)
 		}
 	}
+	p.MySubnetsLock.RUnlock()
 
 	p.ip = &SignedIP{
 		IP: UnsignedIP{
@@ -56,15 +56,20 @@ func newMessageCreator(t *testing.T) message.Creator {
 }
 
 func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
+	t.Helper()
+	return makeRawTestPeersWithKeyType(t, staking.RSA)
+}
+
+func makeRawTestPeersWithKeyType(t *testing.T, keyType staking.KeyType) (*rawTestPeer, *rawTestPeer) {
 	t.Helper()
 	assert := assert.New(t)
 
 	conn0, conn1 := net.Pipe()
 
-	tlsCert0, err := staking.NewTLSCert()
+	tlsCert0, err := staking.NewTLSCertWithKeyType(keyType)
 	assert.NoError(err)
 
-	tlsCert1, err := staking.NewTLSCert()
+	tlsCert1, err := staking.NewTLSCertWithKeyType(keyType)
 	assert.NoError(err)
 
 	nodeID0 := ids.NodeIDFromCert(tlsCert0.Leaf)
@@ -84,23 +89,26 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, 10*time.Second)
 	assert.NoError(err)
-	sharedConfig := Config{
-		Metrics:              metrics,
-		MessageCreator:       mc,
-		Log:                  logging.NoLog{},
-		InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
-		VersionCompatibility: version.GetCompatibility(constants.LocalID),
-		MySubnets:            ids.Set{},
-		Beacons:              validators.NewSet(),
-		NetworkID:            constants.LocalID,
-		PingFrequency:        constants.DefaultPingFrequency,
-		PongTimeout:          constants.DefaultPingPongTimeout,
-		MaxClockDifference:   time.Minute,
-		ResourceTracker:      resourceTracker,
-		PingMessage:          pingMessage,
+	newConfig := func(requiredCapabilities Capabilities) *Config {
+		return &Config{
+			Metrics:              metrics,
+			MessageCreator:       mc,
+			Log:                  logging.NoLog{},
+			InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
+			VersionCompatibility: version.GetCompatibility(constants.LocalID),
+			MySubnets:            ids.Set{},
+			Beacons:              validators.NewSet(),
+			NetworkID:            constants.LocalID,
+			PingFrequency:        constants.DefaultPingFrequency,
+			PongTimeout:          constants.DefaultPingPongTimeout,
+			MaxClockDifference:   time.Minute,
+			ResourceTracker:      resourceTracker,
+			PingMessage:          pingMessage,
+			RequiredCapabilities: requiredCapabilities,
+		}
 	}
-	peerConfig0 := sharedConfig
-	peerConfig1 := sharedConfig
+	peerConfig0 := newConfig(RequiredCapabilities(tlsCert0.Leaf.PublicKey))
+	peerConfig1 := newConfig(RequiredCapabilities(tlsCert1.Leaf.PublicKey))
 
 	peerConfig0.Network = &testNetwork{
 		mc: mc,
@@ -141,14 +149,14 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 	})
 
 	peer0 := &rawTestPeer{
-		config:         &peerConfig0,
+		config:         peerConfig0,
 		conn:           conn0,
 		cert:           tlsCert0.Leaf,
 		nodeID:         nodeID0,
 		inboundMsgChan: inboundMsgChan0,
 	}
 	peer1 := &rawTestPeer{
-		config:         &peerConfig1,
+		config:         peerConfig1,
 		conn:           conn1,
 		cert:           tlsCert1.Leaf,
 		nodeID:         nodeID1,
@@ -159,7 +167,10 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 
 func makeTestPeers(t *testing.T) (*testPeer, *testPeer) {
 	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	return startTestPeers(rawPeer0, rawPeer1)
+}
 
+func startTestPeers(rawPeer0, rawPeer1 *rawTestPeer) (*testPeer, *testPeer) {
 	peer0 := &testPeer{
 		Peer: Start(
 			rawPeer0.config,
@@ -263,6 +274,45 @@ func TestReady(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestReadyEd25519(t *testing.T) {
+	assert := assert.New(t)
+
+	peer0, peer1 := startTestPeers(makeRawTestPeersWithKeyType(t, staking.Ed25519))
+
+	err := peer0.AwaitReady(context.Background())
+	assert.NoError(err)
+	assert.True(peer0.Capabilities().Contains(Ed25519Certs))
+
+	err = peer1.AwaitReady(context.Background())
+	assert.NoError(err)
+	assert.True(peer1.Capabilities().Contains(Ed25519Certs))
+
+	peer0.StartClose()
+	err = peer0.AwaitClosed(context.Background())
+	assert.NoError(err)
+	err = peer1.AwaitClosed(context.Background())
+	assert.NoError(err)
+}
+
+func TestMissingRequiredCapabilities(t *testing.T) {
+	assert := assert.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	rawPeer1.config.RequiredCapabilities = 1 << 31
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+
+	// [peer0] doesn't advertise the capability that [peer1] requires, so
+	// [peer1] should drop the connection during the handshake.
+	err := peer1.AwaitClosed(context.Background())
+	assert.NoError(err)
+	assert.False(peer1.Ready())
+
+	err = peer0.AwaitClosed(context.Background())
+	assert.NoError(err)
+	assert.False(peer0.Ready())
+}
+
 func TestSend(t *testing.T) {
 	assert := assert.New(t)
 
@@ -69,6 +69,7 @@ func (n *testNetwork) Version() (message.OutboundMessage, error) {
 	}
 	return n.mc.Version(
 		n.networkID,
+		uint32(SupportedCapabilities),
 		now,
 		n.ip,
 		n.version.String(),
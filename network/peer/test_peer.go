@@ -125,6 +125,8 @@ func StartTestPeer(
 			MaxClockDifference:   time.Minute,
 			ResourceTracker:      resourceTracker,
 			PingMessage:          pingMessage,
+			MyCapabilities:       SupportedCapabilities,
+			RequiredCapabilities: RequiredCapabilities(tlsCert.Leaf.PublicKey),
 		},
 		conn,
 		cert,
@@ -45,6 +45,11 @@ type bandwidthThrottler interface {
 	// Must be called when we stop reading messages from [nodeID].
 	// It's safe for multiple goroutines to concurrently call RemoveNode.
 	RemoveNode(nodeID ids.NodeID)
+
+	// SetConfig replaces the refill rate and max burst size of every node's
+	// bandwidth allocation.
+	// It's safe for multiple goroutines to concurrently call SetConfig.
+	SetConfig(config BandwidthThrottlerConfig)
 }
 
 type BandwidthThrottlerConfig struct {
@@ -149,3 +154,16 @@ func (t *bandwidthThrottlerImpl) RemoveNode(nodeID ids.NodeID) {
 	}
 	delete(t.limiters, nodeID)
 }
+
+// See BandwidthThrottler.
+func (t *bandwidthThrottlerImpl) SetConfig(config BandwidthThrottlerConfig) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.BandwidthThrottlerConfig = config
+	now := time.Now()
+	for _, limiter := range t.limiters {
+		limiter.SetLimitAt(now, rate.Limit(config.RefillRate))
+		limiter.SetBurstAt(now, int(config.MaxBurstSize))
+	}
+}
@@ -58,3 +58,36 @@ func TestBandwidthThrottler(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestBandwidthThrottlerSetConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	throttlerIntf, err := newBandwidthThrottler(logging.NoLog{}, "", prometheus.NewRegistry(), BandwidthThrottlerConfig{
+		RefillRate:   8,
+		MaxBurstSize: 10,
+	})
+	assert.NoError(err)
+	throttler := throttlerIntf.(*bandwidthThrottlerImpl)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID1)
+
+	newConfig := BandwidthThrottlerConfig{
+		RefillRate:   16,
+		MaxBurstSize: 20,
+	}
+	throttler.SetConfig(newConfig)
+	assert.Equal(newConfig, throttler.BandwidthThrottlerConfig)
+
+	// Existing nodes are moved to the new limits.
+	limiter := throttler.limiters[nodeID1]
+	assert.EqualValues(16, limiter.Limit())
+	assert.Equal(20, limiter.Burst())
+
+	// New nodes are given the new limits.
+	nodeID2 := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID2)
+	limiter = throttler.limiters[nodeID2]
+	assert.EqualValues(16, limiter.Limit())
+	assert.Equal(20, limiter.Burst())
+}
@@ -40,6 +40,11 @@ type InboundMsgThrottler interface {
 	// Must be called when we stop reading messages from [nodeID].
 	// It's safe for multiple goroutines to concurrently call RemoveNode.
 	RemoveNode(nodeID ids.NodeID)
+
+	// SetBandwidthThrottlerConfig replaces the bandwidth limits of every node.
+	// It's safe for multiple goroutines to concurrently call
+	// SetBandwidthThrottlerConfig.
+	SetBandwidthThrottlerConfig(config BandwidthThrottlerConfig)
 }
 
 type InboundMsgThrottlerConfig struct {
@@ -177,3 +182,8 @@ func (t *inboundMsgThrottler) AddNode(nodeID ids.NodeID) {
 func (t *inboundMsgThrottler) RemoveNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.RemoveNode(nodeID)
 }
+
+// See BandwidthThrottler.
+func (t *inboundMsgThrottler) SetBandwidthThrottlerConfig(config BandwidthThrottlerConfig) {
+	t.bandwidthThrottler.SetConfig(config)
+}
@@ -5,6 +5,9 @@ package throttling
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -18,7 +21,13 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
-const epsilon = time.Millisecond
+const (
+	epsilon = time.Millisecond
+
+	// usageCapRefreshInterval is how long the usage cap that is applied while
+	// shedding load is used before it is recalculated.
+	usageCapRefreshInterval = 100 * time.Millisecond
+)
 
 var (
 	_ SystemThrottler = &systemThrottler{}
@@ -44,6 +53,11 @@ type SystemThrottlerConfig struct {
 	// The maximum amount of time we'll wait before re-checking whether a call
 	// to [Acquire] can return.
 	MaxRecheckDelay time.Duration `json:"maxRecheckDelay"`
+	// If the total usage exceeds [MaxUsage], the nodes using the most of the
+	// resource are throttled first, until the total usage attributed to nodes
+	// is proportionally reduced to [MaxUsage]. If 0, nodes are only throttled
+	// based on their target usage.
+	MaxUsage float64 `json:"maxUsage"`
 }
 
 type systemThrottler struct {
@@ -53,11 +67,18 @@ type systemThrottler struct {
 	targeter tracker.Targeter
 	// Tells us the utilization of each node.
 	tracker tracker.Tracker
+
+	// [usageCap] is the maximum usage allowed for any node while load is being
+	// shed. It was last calculated at [usageCapTime].
+	usageCapLock sync.Mutex
+	usageCap     float64
+	usageCapTime time.Time
 }
 
 type systemThrottlerMetrics struct {
 	totalWaits      prometheus.Counter
 	totalNoWaits    prometheus.Counter
+	totalSheds      prometheus.Counter
 	awaitingAcquire prometheus.Gauge
 }
 
@@ -73,6 +94,11 @@ func newSystemThrottlerMetrics(namespace string, reg prometheus.Registerer) (*sy
 			Name:      "throttler_total_no_waits",
 			Help:      "Number of times we didn't wait to read a message because their usage is too high",
 		}),
+		totalSheds: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "throttler_total_sheds",
+			Help:      "Number of times we've waited to read a message from a node because it was one of the heaviest users while the total usage was too high",
+		}),
 		awaitingAcquire: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "throttler_awaiting_acquire",
@@ -83,6 +109,7 @@ func newSystemThrottlerMetrics(namespace string, reg prometheus.Registerer) (*sy
 	errs.Add(
 		reg.Register(m.totalWaits),
 		reg.Register(m.totalNoWaits),
+		reg.Register(m.totalSheds),
 		reg.Register(m.awaitingAcquire),
 	)
 	return m, errs.Err
@@ -120,7 +147,12 @@ func (t *systemThrottler) Acquire(ctx context.Context, nodeID ids.NodeID) {
 	// [waited] is true if we waited for this node's usage to fall to an
 	// acceptable level before returning
 	waited := false
+	// [shed] is true if we waited only because load is being shed.
+	shed := false
 	defer func() {
+		if waited && shed {
+			t.metrics.totalSheds.Inc()
+		}
 		if waited {
 			t.metrics.totalWaits.Inc()
 			// Note that [t.metrics.awaitingAcquire.Inc()] was called once if
@@ -137,6 +169,16 @@ func (t *systemThrottler) Acquire(ctx context.Context, nodeID ids.NodeID) {
 		target := t.targeter.TargetUsage(nodeID)
 		// Get actual usage for this node.
 		usage := t.tracker.Usage(nodeID, now)
+		if t.MaxUsage > 0 {
+			// If we're shedding load, this node may not be allowed to use as
+			// much as its target.
+			if usageCap := t.getUsageCap(now); usageCap < target {
+				if usage <= target && usage > usageCap && !waited {
+					shed = true
+				}
+				target = usageCap
+			}
+		}
 		if usage <= target {
 			return
 		}
@@ -174,3 +216,66 @@ func (t *systemThrottler) Acquire(ctx context.Context, nodeID ids.NodeID) {
 		}
 	}
 }
+
+// getUsageCap returns the maximum usage allowed for any node. If the total
+// usage exceeds [t.MaxUsage], the returned cap only affects the nodes using the
+// most of the resource. Otherwise, returns +Inf.
+func (t *systemThrottler) getUsageCap(now time.Time) float64 {
+	t.usageCapLock.Lock()
+	defer t.usageCapLock.Unlock()
+
+	if !t.usageCapTime.IsZero() &&
+		!now.Before(t.usageCapTime) &&
+		now.Sub(t.usageCapTime) < usageCapRefreshInterval {
+		return t.usageCap
+	}
+
+	t.usageCapTime = now
+	t.usageCap = math.Inf(1)
+
+	totalUsage := t.tracker.TotalUsage()
+	if totalUsage <= t.MaxUsage {
+		return t.usageCap
+	}
+
+	// Not all of the total usage is attributed to nodes, so the usage
+	// attributed to nodes is reduced by the same proportion that the total
+	// usage exceeds [t.MaxUsage].
+	usages := t.tracker.Usages(now)
+	attributedUsage := 0.0
+	for _, usage := range usages {
+		attributedUsage += usage
+	}
+	excess := attributedUsage * (totalUsage - t.MaxUsage) / totalUsage
+	t.usageCap = shedLevel(usages, excess)
+	return t.usageCap
+}
+
+// shedLevel returns the level such that reducing every usage in [usages] that
+// is above the level to the level reduces the sum of the usages by [excess].
+// This ensures that the heaviest users are the first to be throttled.
+func shedLevel(usages map[ids.NodeID]float64, excess float64) float64 {
+	sortedUsages := make([]float64, 0, len(usages))
+	for _, usage := range usages {
+		sortedUsages = append(sortedUsages, usage)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(sortedUsages)))
+
+	// [sum] is the sum of the [i+1] largest usages.
+	sum := 0.0
+	for i, usage := range sortedUsages {
+		sum += usage
+
+		// Reducing the [i+1] largest usages to [level] reduces the sum by
+		// [excess].
+		level := (sum - excess) / float64(i+1)
+		nextUsage := 0.0
+		if i+1 < len(sortedUsages) {
+			nextUsage = sortedUsages[i+1]
+		}
+		if level >= nextUsage {
+			return math.Max(level, 0)
+		}
+	}
+	return 0
+}
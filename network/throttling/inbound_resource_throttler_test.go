@@ -178,3 +178,110 @@ func TestSystemThrottlerContextCancel(t *testing.T) {
 		assert.Fail("should have returned immediately")
 	}
 }
+
+func TestSystemThrottlerShedsHeaviest(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Setup
+	mockTracker := tracker.NewMockTracker(ctrl)
+	config := SystemThrottlerConfig{
+		MaxRecheckDelay: time.Second,
+		MaxUsage:        1,
+	}
+	config.Clock.Set(time.Now())
+	vdrs := validators.NewSet()
+	heavyID, lightID := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	targeter := tracker.NewMockTargeter(ctrl)
+	throttler, err := NewSystemThrottler("", prometheus.NewRegistry(), config, vdrs, mockTracker, targeter)
+	assert.NoError(err)
+
+	// The total usage is twice [MaxUsage], so half of the attributed usage
+	// should be shed. Only the heaviest node should be throttled.
+	mockTracker.EXPECT().TotalUsage().Return(2.0).Times(1)
+	mockTracker.EXPECT().Usages(gomock.Any()).Return(map[ids.NodeID]float64{
+		heavyID: 1.5,
+		lightID: 0.1,
+	}).Times(1)
+
+	// Case: The light node is below the usage cap so it isn't throttled.
+	targeter.EXPECT().TargetUsage(lightID).Return(1.0).Times(1)
+	mockTracker.EXPECT().Usage(lightID, gomock.Any()).Return(0.1).Times(1)
+
+	throttler.Acquire(context.Background(), lightID)
+
+	// Case: The heavy node is below its target usage, but above the usage cap,
+	// so we should wait until its usage drops to the usage cap.
+	targeter.EXPECT().TargetUsage(heavyID).Return(10.0).Times(1)
+	mockTracker.EXPECT().Usage(heavyID, gomock.Any()).Return(1.5).Times(1)
+	mockTracker.EXPECT().TimeUntilUsage(heavyID, gomock.Any(), 0.7).Return(time.Second).Times(1)
+
+	// Pass a canceled context into Acquire so that it returns after the first
+	// wait.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	throttler.Acquire(ctx, heavyID)
+}
+
+func TestShedLevel(t *testing.T) {
+	nodeID0, nodeID1, nodeID2 := ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	tests := []struct {
+		name     string
+		usages   map[ids.NodeID]float64
+		excess   float64
+		expected float64
+	}{
+		{
+			name:     "no usages",
+			usages:   map[ids.NodeID]float64{},
+			excess:   1,
+			expected: 0,
+		},
+		{
+			name: "only heaviest throttled",
+			usages: map[ids.NodeID]float64{
+				nodeID0: 4,
+				nodeID1: 2,
+				nodeID2: 1,
+			},
+			excess:   1,
+			expected: 3,
+		},
+		{
+			name: "two heaviest throttled",
+			usages: map[ids.NodeID]float64{
+				nodeID0: 4,
+				nodeID1: 2,
+				nodeID2: 1,
+			},
+			excess:   3,
+			expected: 1.5,
+		},
+		{
+			name: "all throttled",
+			usages: map[ids.NodeID]float64{
+				nodeID0: 4,
+				nodeID1: 2,
+				nodeID2: 1,
+			},
+			excess:   4,
+			expected: 1,
+		},
+		{
+			name: "excess exceeds usage",
+			usages: map[ids.NodeID]float64{
+				nodeID0: 4,
+				nodeID1: 2,
+				nodeID2: 1,
+			},
+			excess:   10,
+			expected: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.InDelta(t, test.expected, shedLevel(test.usages, test.excess), 1e-9)
+		})
+	}
+}
@@ -7,16 +7,21 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api/ratelimit"
+	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/ipcs"
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/dynamicip"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -29,6 +34,9 @@ type IPCConfig struct {
 	IPCAPIEnabled      bool     `json:"ipcAPIEnabled"`
 	IPCPath            string   `json:"ipcPath"`
 	IPCDefaultChainIDs []string `json:"ipcDefaultChainIDs"`
+	// Chain ID --> Publisher of the chain's events. Chains that aren't
+	// specified publish to IPC sockets.
+	IPCPublishers map[ids.ID]ipcs.PublisherConfig `json:"ipcPublishers"`
 }
 
 type APIAuthConfig struct {
@@ -39,6 +47,7 @@ type APIAuthConfig struct {
 type APIIndexerConfig struct {
 	IndexAPIEnabled      bool `json:"indexAPIEnabled"`
 	IndexAllowIncomplete bool `json:"indexAllowIncomplete"`
+	IndexAddresses       bool `json:"indexAddresses"`
 }
 
 type HTTPConfig struct {
@@ -52,6 +61,10 @@ type HTTPConfig struct {
 
 	APIAllowedOrigins []string `json:"apiAllowedOrigins"`
 
+	APIRateLimitConfig ratelimit.Config `json:"apiRateLimitConfig"`
+
+	APIMetricsConfig server.MetricsConfig `json:"apiMetricsConfig"`
+
 	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 	ShutdownWait    time.Duration `json:"shutdownWait"`
 }
@@ -67,6 +80,7 @@ type APIConfig struct {
 	KeystoreAPIEnabled bool `json:"keystoreAPIEnabled"`
 	MetricsAPIEnabled  bool `json:"metricsAPIEnabled"`
 	HealthAPIEnabled   bool `json:"healthAPIEnabled"`
+	GRPCAPIEnabled     bool `json:"grpcAPIEnabled"`
 }
 
 type IPConfig struct {
@@ -86,6 +100,8 @@ type StakingConfig struct {
 	DisabledStakingWeight uint64          `json:"disabledStakingWeight"`
 	StakingKeyPath        string          `json:"stakingKeyPath"`
 	StakingCertPath       string          `json:"stakingCertPath"`
+	StakingSigningKey     *bls.SecretKey  `json:"-"`
+	StakingSignerPath     string          `json:"stakingSignerPath"`
 }
 
 type StateSyncConfig struct {
@@ -127,6 +143,17 @@ type DatabaseConfig struct {
 
 	// Path to config file
 	Config []byte `json:"-"`
+
+	// If true, the pending database migrations are logged rather than run and
+	// the node doesn't start
+	MigrationDryRun bool `json:"migrationDryRun"`
+
+	// Directory that backups are written to
+	BackupDir string `json:"backupDir"`
+
+	// If non-empty, path to a backup that is restored into the database on
+	// startup
+	RestoreFile string `json:"restoreFile"`
 }
 
 // Config contains all of the configurations of an Avalanche node.
@@ -177,11 +204,16 @@ type Config struct {
 	// File Descriptor Limit
 	FdLimit uint64 `json:"fdLimit"`
 
+	// Number of blocks of P-chain history to retain. If 0, nothing is pruned.
+	PChainPruningHorizon uint64 `json:"pChainPruningHorizon"`
+
 	// Consensus configuration
 	ConsensusParams avalanche.Parameters `json:"consensusParams"`
 
 	// Metrics
 	MeterVMEnabled bool `json:"meterVMEnabled"`
+	// Bucket boundaries, in nanoseconds, of the consensus latency histograms
+	ConsensusLatencyBuckets []float64 `json:"consensusLatencyBuckets"`
 
 	// Router that is used to handle incoming consensus messages
 	ConsensusRouter          router.Router       `json:"-"`
@@ -225,4 +257,65 @@ type Config struct {
 
 	RequiredAvailableDiskSpace         uint64 `json:"requiredAvailableDiskSpace"`
 	WarningThresholdAvailableDiskSpace uint64 `json:"warningThresholdAvailableDiskSpace"`
+
+	// Thresholds of the number of available bytes on the disk holding the
+	// logs. Below [RequiredAvailableLogDiskSpace] the node is unhealthy.
+	RequiredAvailableLogDiskSpace         uint64 `json:"requiredAvailableLogDiskSpace"`
+	WarningThresholdAvailableLogDiskSpace uint64 `json:"warningThresholdAvailableLogDiskSpace"`
+
+	// Thresholds of the number of file descriptors the node can still open.
+	// Below [RequiredAvailableFDs] the node is unhealthy.
+	RequiredAvailableFDs         uint64 `json:"requiredAvailableFDs"`
+	WarningThresholdAvailableFDs uint64 `json:"warningThresholdAvailableFDs"`
+
+	// Re-reads the reloadable subset of the config. Nil if the config can't
+	// be reloaded.
+	ConfigReloader ConfigReloader `json:"-"`
+}
+
+// ReloadableConfig is the subset of the config that can be changed without
+// restarting the node. Nil fields are unchanged.
+type ReloadableConfig struct {
+	LogLevel        *logging.Level
+	LogDisplayLevel *logging.Level
+
+	APIRateLimitConfig *ratelimit.Config
+
+	NetworkHealthConfig *network.HealthConfig
+	RouterHealthConfig  *router.HealthConfig
+
+	InboundBandwidthThrottlerConfig *throttling.BandwidthThrottlerConfig
+
+	// Changed configs of chains, keyed by the alias or ID of the chain that
+	// the config was provided for
+	ChainConfigs map[string]ReloadedChainConfig
+}
+
+// ReloadedChainConfig is a changed config of a chain.
+type ReloadedChainConfig struct {
+	// Key that the change is reported under
+	Key string
+	// New user-provided config of the chain
+	Config []byte
+}
+
+// ReloadReport describes the outcome of reloading the config.
+type ReloadReport struct {
+	// Keys whose new values were applied
+	Applied []string `json:"applied"`
+	// Keys whose values changed but won't take effect until the node is
+	// restarted
+	RequiresRestart []string `json:"requiresRestart"`
+}
+
+type ConfigReloader interface {
+	// Reload returns the changes to the reloadable config since the config
+	// was last committed, along with a report of which keys changed.
+	Reload() (ReloadableConfig, ReloadReport, error)
+
+	// Commit records that the node applied the config returned by the last
+	// call to Reload, and delivered the changed configs of the chains in
+	// [chainAliases]. Until a change is committed, later reloads report it
+	// again.
+	Commit(chainAliases []string)
 }
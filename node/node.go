@@ -4,13 +4,18 @@
 package node
 
 import (
+	"context"
 	"crypto"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,14 +29,17 @@ import (
 
 	"github.com/ava-labs/avalanchego/api/admin"
 	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/api/grpcapi"
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/api/ratelimit"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/backup"
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/database/memdb"
@@ -55,6 +63,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/bytespool"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/filesystem"
 	"github.com/ava-labs/avalanchego/utils/hashing"
@@ -64,26 +73,49 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math/meter"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/storage"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/ulimit"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/registry"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	ipcsapi "github.com/ava-labs/avalanchego/api/ipcs"
+	avmpb "github.com/ava-labs/avalanchego/proto/pb/avm"
+	healthpb "github.com/ava-labs/avalanchego/proto/pb/health"
+	infopb "github.com/ava-labs/avalanchego/proto/pb/info"
+	platformvmpb "github.com/ava-labs/avalanchego/proto/pb/platformvm"
 )
 
+const networkLoggerName = "network"
+
 var (
 	genesisHashKey  = []byte("genesisID")
+	runningKey      = []byte("running")
 	indexerDBPrefix = []byte{0x00}
-
-	errInvalidTLSKey = errors.New("invalid TLS key")
-	errShuttingDown  = errors.New("server shutting down")
+	aliasDBPrefix   = []byte("aliases")
+	authDBPrefix    = []byte("auth")
+	ipcsDBPrefix    = []byte("ipcs")
+
+	errInvalidTLSKey          = errors.New("invalid TLS key")
+	errShuttingDown           = errors.New("server shutting down")
+	errConfigNotReloadable    = errors.New("config can't be reloaded")
+	errChainConfigsNotUpdated = errors.New("couldn't update chain configs")
+	errMigrationDryRun        = errors.New("database migration dry run finished")
+	errBackupInProgress       = errors.New("a backup is already in progress")
+	errInvalidBackupName      = errors.New("backup name must be a file name")
+
+	// Migrations of the node's database that are run, in order, on startup.
+	// Released migrations must not be removed or reordered.
+	dbMigrations []manager.Migration
 )
 
 // Node is an instance of an Avalanche node.
@@ -98,6 +130,8 @@ type Node struct {
 	// Storage for this node
 	DBManager manager.Manager
 	DB        database.Database
+	// True iff the node didn't shut down cleanly the last time it ran
+	uncleanShutdown bool
 
 	// Profiles the process. Nil if continuous profiling is disabled.
 	profiler profiler.ContinuousProfiler
@@ -108,6 +142,9 @@ type Node struct {
 	// Handles calls to Keystore API
 	keystore keystore.Keystore
 
+	// Persists the aliases added through the admin API
+	aliasStore admin.AliasStore
+
 	// Manages shared memory
 	sharedMemory atomic.Memory
 
@@ -144,12 +181,21 @@ type Node struct {
 	// Handles HTTP API calls
 	APIServer server.Server
 
+	// Rate limits HTTP API calls. Nil if API rate limiting is disabled.
+	apiRateLimiter ratelimit.Limiter
+
 	// This node's configuration
 	Config *Config
 
 	// ensures that we only close the node once.
 	shutdownOnce sync.Once
 
+	// ensures that config reloads are applied one at a time.
+	reloadLock sync.Mutex
+
+	// ensures that only one backup is written at a time.
+	backupLock sync.Mutex
+
 	// True if node is shutting down or is done shutting down
 	shuttingDown utils.AtomicBool
 
@@ -280,13 +326,20 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
 
+	// The networking gets its own logger so that its log level can be changed
+	// independently of the rest of the node
+	networkLog, err := n.LogFactory.Make(networkLoggerName)
+	if err != nil {
+		return fmt.Errorf("couldn't create network logger: %w", err)
+	}
+
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
 		n.msgCreator,
 		n.MetricsRegisterer,
-		n.Log,
+		networkLog,
 		listener,
-		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, networkLog),
 		consensusRouter,
 		n.benchlistManager,
 	)
@@ -330,7 +383,7 @@ func (b *beaconManager) Connected(vdrID ids.NodeID, nodeVersion *version.Applica
 			b.Router.Connected(vdrID, nodeVersion, subnetID)
 			return
 		}
-		weight, err := math.Add64(weight, b.totalWeight)
+		weight, err := math.Add(weight, b.totalWeight)
 		if err != nil {
 			b.timer.Cancel()
 			b.Router.Connected(vdrID, nodeVersion, subnetID)
@@ -352,7 +405,7 @@ func (b *beaconManager) Disconnected(vdrID ids.NodeID) {
 		// weight can become disconnected. Because it is possible that there are
 		// changes to the validators set, we utilize that Sub64 returns 0 on
 		// error.
-		b.totalWeight, _ = math.Sub64(b.totalWeight, weight)
+		b.totalWeight, _ = math.Sub(b.totalWeight, weight)
 	}
 	b.Router.Disconnected(vdrID)
 }
@@ -409,29 +462,83 @@ func (n *Node) Dispatch() error {
  ******************************************************************************
  */
 
-func (n *Node) initDatabase() error {
-	// start the db manager
+// NewDatabaseManager opens the databases described by [config] and runs any
+// pending migrations of the current database.
+func NewDatabaseManager(
+	config DatabaseConfig,
+	log logging.Logger,
+	registerer prometheus.Registerer,
+) (manager.Manager, error) {
 	var (
 		dbManager manager.Manager
 		err       error
 	)
-	switch n.Config.DatabaseConfig.Name {
+	// The backup is restored before migrating, as the backup may have been
+	// written before some migrations were run.
+	switch config.Name {
 	case rocksdb.Name:
-		path := filepath.Join(n.Config.DatabaseConfig.Path, rocksdb.Name)
-		dbManager, err = manager.NewRocksDB(path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
+		path := filepath.Join(config.Path, rocksdb.Name)
+		if err := restoreBackup(config, log, path, rocksdb.New); err != nil {
+			return nil, err
+		}
+		dbManager, err = manager.NewRocksDB(path, config.Config, log, version.CurrentDatabase, "db_internal", registerer)
 	case leveldb.Name:
-		dbManager, err = manager.NewLevelDB(n.Config.DatabaseConfig.Path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
+		if err := restoreBackup(config, log, config.Path, leveldb.New); err != nil {
+			return nil, err
+		}
+		dbManager, err = manager.NewLevelDB(config.Path, config.Config, log, version.CurrentDatabase, "db_internal", registerer)
 	case memdb.Name:
 		dbManager = manager.NewMemDB(version.CurrentDatabase)
+		if len(config.RestoreFile) != 0 {
+			err = backup.RestoreFile(log, config.RestoreFile, dbManager.Current())
+		}
 	default:
 		err = fmt.Errorf(
 			"db-type was %q but should have been one of {%s, %s, %s}",
-			n.Config.DatabaseConfig.Name,
+			config.Name,
 			leveldb.Name,
 			rocksdb.Name,
 			memdb.Name,
 		)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	pendingMigrations, err := dbManager.Migrate(log, dbMigrations, config.MigrationDryRun)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("couldn't migrate database: %w", err)
+	case config.MigrationDryRun:
+		err = fmt.Errorf("%w with %d pending migrations: %v", errMigrationDryRun, len(pendingMigrations), pendingMigrations)
+	default:
+		return dbManager, nil
+	}
+	_ = dbManager.Close()
+	return nil, err
+}
+
+// restoreBackup restores the backup in [config], if any, into the current
+// version of the database stored under [dbDirPath]. The database is opened
+// with [newDB] only for the restore, so its metrics aren't reported.
+func restoreBackup(
+	config DatabaseConfig,
+	log logging.Logger,
+	dbDirPath string,
+	newDB func(string, []byte, logging.Logger, string, prometheus.Registerer) (database.Database, error),
+) error {
+	if len(config.RestoreFile) == 0 {
+		return nil
+	}
+	dbPath := filepath.Join(dbDirPath, version.CurrentDatabase.String())
+	return backup.RestoreDir(log, config.RestoreFile, dbPath, version.CurrentDatabase, func(path string) (database.Database, error) {
+		return newDB(path, config.Config, log, "", prometheus.NewRegistry())
+	})
+}
+
+func (n *Node) initDatabase() error {
+	// start the db manager
+	dbManager, err := NewDatabaseManager(n.Config.DatabaseConfig, n.Log, n.MetricsRegisterer)
 	if err != nil {
 		return err
 	}
@@ -470,6 +577,21 @@ func (n *Node) initDatabase() error {
 	if genesisHash != expectedGenesisHash {
 		return fmt.Errorf("db contains invalid genesis hash. DB Genesis: %s Generated Genesis: %s", genesisHash, expectedGenesisHash)
 	}
+
+	// [runningKey] is removed when the node shuts down cleanly, so if it's
+	// still present the node must have crashed or been killed.
+	n.uncleanShutdown, err = n.DB.Has(runningKey)
+	if err != nil {
+		return err
+	}
+	if n.uncleanShutdown {
+		n.Log.Warn("node didn't shut down cleanly. Chain state will be checked for inconsistencies")
+	}
+	if err := n.DB.Put(runningKey, nil); err != nil {
+		return err
+	}
+
+	n.aliasStore = admin.NewAliasStore(prefixdb.New(aliasDBPrefix, n.DB))
 	return nil
 }
 
@@ -502,7 +624,16 @@ func (n *Node) initIPCs() error {
 	}
 
 	var err error
-	n.IPCs, err = ipcs.NewChainIPCs(n.Log, n.Config.IPCPath, n.Config.NetworkID, n.ConsensusAcceptorGroup, n.DecisionAcceptorGroup, chainIDs)
+	n.IPCs, err = ipcs.NewChainIPCs(
+		n.Log,
+		n.Config.IPCPath,
+		n.Config.NetworkID,
+		prefixdb.New(ipcsDBPrefix, n.DB),
+		n.Config.IPCPublishers,
+		n.ConsensusAcceptorGroup,
+		n.DecisionAcceptorGroup,
+		chainIDs,
+	)
 	return err
 }
 
@@ -516,6 +647,7 @@ func (n *Node) initIndexer() error {
 	n.indexer, err = indexer.NewIndexer(indexer.Config{
 		IndexingEnabled:        n.Config.IndexAPIEnabled,
 		AllowIncompleteIndex:   n.Config.IndexAllowIncomplete,
+		AddressIndexingEnabled: n.Config.IndexAddresses,
 		DB:                     txIndexerDB,
 		Log:                    n.Log,
 		DecisionAcceptorGroup:  n.DecisionAcceptorGroup,
@@ -549,29 +681,48 @@ func (n *Node) initChains(genesisBytes []byte) {
 }
 
 // initAPIServer initializes the server that handles HTTP calls
+// Assumes n.MetricsRegisterer and n.DB are already set
 func (n *Node) initAPIServer() error {
 	n.Log.Info("initializing API server")
 	n.APIServer = server.New()
 
-	if !n.Config.APIRequireAuthToken {
-		n.APIServer.Initialize(
-			n.Log,
-			n.LogFactory,
-			n.Config.HTTPHost,
-			n.Config.HTTPPort,
-			n.Config.APIAllowedOrigins,
-			n.Config.ShutdownTimeout,
-			n.ID,
-		)
-		return nil
+	var (
+		wrappers []server.Wrapper
+		a        auth.Auth
+	)
+	if n.Config.APIRequireAuthToken {
+		var err error
+		authDB := prefixdb.New(authDBPrefix, n.DB)
+		a, err = auth.New(n.Log, "auth", n.Config.APIAuthPassword, authDB)
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, a)
 	}
 
-	a, err := auth.New(n.Log, "auth", n.Config.APIAuthPassword)
-	if err != nil {
-		return err
+	if n.Config.APIRateLimitConfig.Enabled {
+		// The rate limiter is added last so that it wraps every other handler
+		// and rejects throttled requests before any work is done.
+		var identifyToken ratelimit.TokenIdentifier
+		if a != nil {
+			identifyToken = a.TokenID
+		}
+		limiter, err := ratelimit.New(
+			n.Log,
+			n.Config.APIRateLimitConfig,
+			identifyToken,
+			"api_rate_limiter",
+			n.MetricsRegisterer,
+		)
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, limiter)
+		n.apiRateLimiter = limiter
+		n.Log.Info("API rate limiting is enabled")
 	}
 
-	n.APIServer.Initialize(
+	err := n.APIServer.Initialize(
 		n.Log,
 		n.LogFactory,
 		n.Config.HTTPHost,
@@ -579,8 +730,17 @@ func (n *Node) initAPIServer() error {
 		n.Config.APIAllowedOrigins,
 		n.Config.ShutdownTimeout,
 		n.ID,
-		a,
+		n.MetricsRegisterer,
+		n.Config.APIMetricsConfig,
+		wrappers...,
 	)
+	if err != nil {
+		return err
+	}
+
+	if a == nil {
+		return nil
+	}
 
 	// only create auth service if token authorization is required
 	n.Log.Info("API authorization is enabled. Auth tokens must be passed in the header of API requests, except requests to the auth service.")
@@ -595,6 +755,26 @@ func (n *Node) initAPIServer() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "auth", "")
 }
 
+// initGRPCAPI registers the gRPC mirrors of the JSON-RPC APIs.
+// Assumes n.APIServer is already set
+func (n *Node) initGRPCAPI() {
+	if !n.Config.GRPCAPIEnabled {
+		n.Log.Info("skipping gRPC API initialization because it has been disabled")
+		return
+	}
+
+	n.Log.Info("initializing gRPC API")
+	handler := n.APIServer.Handler()
+	if n.Config.InfoAPIEnabled {
+		infopb.RegisterInfoServer(n.APIServer, grpcapi.NewInfoServer(handler))
+	}
+	if n.Config.HealthAPIEnabled {
+		healthpb.RegisterHealthServer(n.APIServer, grpcapi.NewHealthServer(handler))
+	}
+	platformvmpb.RegisterPlatformServer(n.APIServer, grpcapi.NewPlatformServer(handler))
+	avmpb.RegisterAVMServer(n.APIServer, grpcapi.NewAVMServer(handler))
+}
+
 // Add the default VM aliases
 func (n *Node) addDefaultVMAliases() error {
 	n.Log.Info("adding the default VM aliases")
@@ -694,6 +874,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		ShutdownNodeFunc:                        n.Shutdown,
 		MeterVMEnabled:                          n.Config.MeterVMEnabled,
 		Metrics:                                 n.MetricsGatherer,
+		ConsensusLatencyBuckets:                 n.Config.ConsensusLatencyBuckets,
 		SubnetConfigs:                           n.Config.SubnetConfigs,
 		ChainConfigs:                            n.Config.ChainConfigs,
 		ConsensusGossipFrequency:                n.Config.ConsensusGossipFrequency,
@@ -705,6 +886,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
 		ResourceTracker:                         n.resourceTracker,
 		StateSyncBeacons:                        n.Config.StateSyncIDs,
+		UncleanShutdown:                         n.uncleanShutdown,
 	})
 
 	// Notify the API server when new chains are created
@@ -731,6 +913,16 @@ func (n *Node) initVMs() error {
 		VMManager: n.Config.VMManager,
 	})
 
+	// The P-chain modifies its whitelisted subnets when subnets are tracked
+	// or untracked at runtime, so it is given its own copy.
+	platformWhitelistedSubnets := ids.Set{}
+	platformWhitelistedSubnets.Union(n.Config.WhitelistedSubnets)
+
+	rewardCalculator, err := reward.NewCalculatorByName(n.Config.RewardCalculator, n.Config.RewardConfig)
+	if err != nil {
+		return err
+	}
+
 	// Register the VMs that Avalanche supports
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -741,7 +933,7 @@ func (n *Node) initVMs() error {
 				SubnetTracker:          n.Net,
 				UptimeLockedCalculator: n.uptimeCalculator,
 				StakingEnabled:         n.Config.EnableStaking,
-				WhitelistedSubnets:     n.Config.WhitelistedSubnets,
+				WhitelistedSubnets:     platformWhitelistedSubnets,
 				TxFee:                  n.Config.TxFee,
 				CreateAssetTxFee:       n.Config.CreateAssetTxFee,
 				CreateSubnetTxFee:      n.Config.CreateSubnetTxFee,
@@ -754,9 +946,14 @@ func (n *Node) initVMs() error {
 				MinStakeDuration:       n.Config.MinStakeDuration,
 				MaxStakeDuration:       n.Config.MaxStakeDuration,
 				RewardConfig:           n.Config.RewardConfig,
+				RewardCalculator:       rewardCalculator,
+				PruningHorizon:         n.Config.PChainPruningHorizon,
 				ApricotPhase3Time:      version.GetApricotPhase3Time(n.Config.NetworkID),
 				ApricotPhase4Time:      version.GetApricotPhase4Time(n.Config.NetworkID),
 				ApricotPhase5Time:      version.GetApricotPhase5Time(n.Config.NetworkID),
+
+				SubnetValidatorRemovalTime: version.GetSubnetValidatorRemovalTime(n.Config.NetworkID),
+				BLSKeyRegistrationTime:     version.GetBLSKeyRegistrationTime(n.Config.NetworkID),
 			},
 		}),
 		vmRegisterer.Register(constants.AVMID, &avm.Factory{
@@ -820,12 +1017,15 @@ func (n *Node) initKeystoreAPI() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "keystore", "")
 }
 
-// initMetricsAPI initializes the Metrics API
-// Assumes n.APIServer is already set
-func (n *Node) initMetricsAPI() error {
+// initMetrics initializes the metrics registerer and gatherer
+func (n *Node) initMetrics() {
 	n.MetricsRegisterer = prometheus.NewRegistry()
 	n.MetricsGatherer = metrics.NewMultiGatherer()
+}
 
+// initMetricsAPI initializes the Metrics API
+// Assumes n.APIServer and n.MetricsRegisterer are already set
+func (n *Node) initMetricsAPI() error {
 	if !n.Config.MetricsAPIEnabled {
 		n.Log.Info("skipping metrics API initialization because it has been disabled")
 		return nil
@@ -847,6 +1047,19 @@ func (n *Node) initMetricsAPI() error {
 		return err
 	}
 
+	// IO of the device the database is stored on. File descriptors, goroutines
+	// and GC pauses are already reported by the collectors above.
+	diskCollector := resource.NewDiskCollector("db_volume", n.Config.DatabaseConfig.Path)
+	if err := n.MetricsRegisterer.Register(diskCollector); err != nil {
+		return err
+	}
+
+	// Usage of the byte slices shared by the database, message and network
+	// layers.
+	if err := bytespool.Register(n.MetricsRegisterer); err != nil {
+		return err
+	}
+
 	n.Log.Info("initializing metrics API")
 
 	return n.APIServer.AddRoute(
@@ -887,6 +1100,14 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.Config.VMManager,
 			VMRegistry:   n.VMRegistry,
+			AliasStore:   n.aliasStore,
+			Reloader:     n,
+			Backuper:     n,
+
+			// Continuous profiles may be listed even if the continuous
+			// profiler is disabled, as they may have been written by a
+			// previous run.
+			ContinuousProfileDir: n.continuousProfileDir(),
 		},
 	)
 	if err != nil {
@@ -903,10 +1124,15 @@ func (n *Node) initProfiler() {
 	}
 
 	n.Log.Info("initializing continuous profiler")
-	n.profiler = profiler.NewContinuous(
-		filepath.Join(n.Config.ProfilerConfig.Dir, "continuous"),
+	n.profiler = profiler.NewContinuousWithThresholds(
+		n.continuousProfileDir(),
 		n.Config.ProfilerConfig.Freq,
 		n.Config.ProfilerConfig.MaxNumFiles,
+		profiler.Thresholds{
+			CPUUser: n.resourceManager,
+			CPU:     n.Config.ProfilerConfig.CPUThreshold,
+			Memory:  n.Config.ProfilerConfig.MemoryThreshold,
+		},
 	)
 	go n.Log.RecoverAndPanic(func() {
 		err := n.profiler.Dispatch()
@@ -917,6 +1143,12 @@ func (n *Node) initProfiler() {
 	})
 }
 
+// continuousProfileDir returns the directory that continuous profiles are
+// written to
+func (n *Node) continuousProfileDir() string {
+	return filepath.Join(n.Config.ProfilerConfig.Dir, "continuous")
+}
+
 func (n *Node) initInfoAPI() error {
 	if !n.Config.InfoAPIEnabled {
 		n.Log.Info("skipping info API initialization because it has been disabled")
@@ -925,11 +1157,19 @@ func (n *Node) initInfoAPI() error {
 
 	n.Log.Info("initializing info API")
 
+	stakingSigner, ok := n.Config.StakingTLSCert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return errInvalidTLSKey
+	}
+
 	primaryValidators, _ := n.vdrs.GetValidators(constants.PrimaryNetworkID)
 	service, err := info.NewService(
 		info.Parameters{
 			Version:               version.CurrentApp,
 			NodeID:                n.ID,
+			NodePOP:               signer.NewProofOfPossession(n.Config.StakingSigningKey),
+			StakingCert:           n.Config.StakingTLSCert.Leaf,
+			StakingSigner:         stakingSigner,
 			NetworkID:             n.Config.NetworkID,
 			TxFee:                 n.Config.TxFee,
 			CreateAssetTxFee:      n.Config.CreateAssetTxFee,
@@ -1007,6 +1247,60 @@ func (n *Node) initHealthAPI() error {
 		return fmt.Errorf("couldn't register resource health check: %w", err)
 	}
 
+	logDiskSpaceCheck := health.CheckerFunc(func() (interface{}, error) {
+		// confirm that the logs can still be written
+		availableDiskBytes, err := storage.AvailableBytes(n.Config.LoggingConfig.Directory)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get available disk space of log directory: %w", err)
+		}
+
+		if availableDiskBytes < n.Config.RequiredAvailableLogDiskSpace {
+			err = fmt.Errorf("remaining available log disk space (%d) is below minimum required available space (%d)", availableDiskBytes, n.Config.RequiredAvailableLogDiskSpace)
+		} else if availableDiskBytes < n.Config.WarningThresholdAvailableLogDiskSpace {
+			n.Log.Warn("remaining available log disk space (%d) is below the warning threshold of disk space (%d)", availableDiskBytes, n.Config.WarningThresholdAvailableLogDiskSpace)
+		}
+
+		return map[string]interface{}{
+			"availableDiskBytes": availableDiskBytes,
+		}, err
+	})
+
+	err = n.health.RegisterHealthCheck("logdiskspace", logDiskSpaceCheck)
+	if err != nil {
+		return fmt.Errorf("couldn't register log disk space health check: %w", err)
+	}
+
+	fdCheck := health.CheckerFunc(func() (interface{}, error) {
+		// confirm that the node can still open files and connections
+		openFDs, fdLimit, err := ulimit.FDUsage()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get file descriptor usage: %w", err)
+		}
+
+		var availableFDs uint64
+		if openFDs < fdLimit {
+			availableFDs = fdLimit - openFDs
+		}
+		if availableFDs < n.Config.RequiredAvailableFDs {
+			err = fmt.Errorf("remaining available file descriptors (%d) is below minimum required available file descriptors (%d)", availableFDs, n.Config.RequiredAvailableFDs)
+		} else if availableFDs < n.Config.WarningThresholdAvailableFDs {
+			n.Log.Warn("remaining available file descriptors (%d) is below the warning threshold of file descriptors (%d)", availableFDs, n.Config.WarningThresholdAvailableFDs)
+		}
+
+		return map[string]interface{}{
+			"openFDs":      openFDs,
+			"fdLimit":      fdLimit,
+			"availableFDs": availableFDs,
+		}, err
+	})
+
+	// File descriptor usage isn't available on every platform
+	if _, _, err := ulimit.FDUsage(); err != nil {
+		n.Log.Warn("skipping file descriptor health check: %s", err)
+	} else if err := n.health.RegisterHealthCheck("filedescriptors", fdCheck); err != nil {
+		return fmt.Errorf("couldn't register file descriptor health check: %w", err)
+	}
+
 	handler, err := health.NewGetAndPostHandler(n.Log, healthChecker)
 	if err != nil {
 		return err
@@ -1077,7 +1371,8 @@ func (n *Node) initIPCAPI() error {
 	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "ipcs", "")
 }
 
-// Give chains aliases as specified by the genesis information
+// Give chains aliases as specified by the genesis information and the aliases
+// that were persisted by the admin API
 func (n *Node) initChainAliases(genesisBytes []byte) error {
 	n.Log.Info("initializing chain aliases")
 	_, chainAliases, err := genesis.Aliases(genesisBytes)
@@ -1092,6 +1387,25 @@ func (n *Node) initChainAliases(genesisBytes []byte) error {
 			}
 		}
 	}
+
+	persistedAliases, err := n.aliasStore.ChainAliases()
+	if err != nil {
+		return err
+	}
+	for chainID, aliases := range persistedAliases {
+		endpoint := path.Join(constants.ChainAliasPrefix, chainID.String())
+		for _, alias := range aliases {
+			// A persisted alias may conflict with one that was added since it
+			// was persisted. This shouldn't prevent the node from starting.
+			if err := n.chainManager.Alias(chainID, alias); err != nil {
+				n.Log.Warn("couldn't apply persisted alias %q of chain %s: %s", alias, chainID, err)
+				continue
+			}
+			if err := n.APIServer.AddAliases(endpoint, path.Join(constants.ChainAliasPrefix, alias)); err != nil {
+				n.Log.Warn("couldn't apply persisted alias %q of chain %s: %s", alias, chainID, err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -1108,6 +1422,18 @@ func (n *Node) initAPIAliases(genesisBytes []byte) error {
 			return err
 		}
 	}
+
+	persistedAliases, err := n.aliasStore.RouteAliases()
+	if err != nil {
+		return err
+	}
+	for url, aliases := range persistedAliases {
+		for _, alias := range aliases {
+			if err := n.APIServer.AddAliases(url, alias); err != nil {
+				n.Log.Warn("couldn't apply persisted alias %q of %q: %s", alias, url, err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -1182,18 +1508,22 @@ func (n *Node) Initialize(
 		return fmt.Errorf("problem initializing node beacons: %w", err)
 	}
 
+	n.initMetrics()
+
+	if err := n.initDatabase(); err != nil { // Set up the node's database
+		return fmt.Errorf("problem initializing database: %w", err)
+	}
+
 	if err := n.initAPIServer(); err != nil { // Start the API Server
 		return fmt.Errorf("couldn't initialize API server: %w", err)
 	}
 
+	n.initGRPCAPI()
+
 	if err := n.initMetricsAPI(); err != nil { // Start the Metrics API
 		return fmt.Errorf("couldn't initialize metrics API: %w", err)
 	}
 
-	if err := n.initDatabase(); err != nil { // Set up the node's database
-		return fmt.Errorf("problem initializing database: %w", err)
-	}
-
 	if err := n.initKeystoreAPI(); err != nil { // Start the Keystore API
 		return fmt.Errorf("couldn't initialize keystore API: %w", err)
 	}
@@ -1276,6 +1606,144 @@ func (n *Node) Initialize(
 	return nil
 }
 
+// ReloadConfig re-reads the node's config and applies the changes to the
+// reloadable subset of it. Returns the keys that were applied and the keys
+// that changed but require a restart.
+func (n *Node) ReloadConfig() ([]string, []string, error) {
+	n.reloadLock.Lock()
+	defer n.reloadLock.Unlock()
+
+	if n.Config.ConfigReloader == nil {
+		return nil, nil, errConfigNotReloadable
+	}
+
+	config, report, err := n.Config.ConfigReloader.Reload()
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't reload config: %w", err)
+	}
+
+	if config.LogLevel != nil || config.LogDisplayLevel != nil {
+		for _, name := range n.LogFactory.GetLoggerNames() {
+			if config.LogLevel != nil {
+				if err := n.LogFactory.SetLogLevel(name, *config.LogLevel); err != nil {
+					return nil, nil, err
+				}
+			}
+			if config.LogDisplayLevel != nil {
+				if err := n.LogFactory.SetDisplayLevel(name, *config.LogDisplayLevel); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+	if config.APIRateLimitConfig != nil && n.apiRateLimiter != nil {
+		if err := n.apiRateLimiter.SetConfig(*config.APIRateLimitConfig); err != nil {
+			return nil, nil, fmt.Errorf("couldn't update API rate limits: %w", err)
+		}
+	}
+	if config.NetworkHealthConfig != nil {
+		n.Net.SetHealthConfig(*config.NetworkHealthConfig)
+	}
+	if config.RouterHealthConfig != nil {
+		n.Config.ConsensusRouter.SetHealthConfig(*config.RouterHealthConfig)
+	}
+	if config.InboundBandwidthThrottlerConfig != nil {
+		n.Net.SetBandwidthThrottlerConfig(*config.InboundBandwidthThrottlerConfig)
+	}
+	aliases := make([]string, 0, len(config.ChainConfigs))
+	for alias := range config.ChainConfigs {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	var (
+		delivered = make([]string, 0, len(aliases))
+		failed    []string
+	)
+	for _, alias := range aliases {
+		chainConfig := config.ChainConfigs[alias]
+		err := n.chainManager.UpdateChainConfig(alias, chainConfig.Config)
+		switch {
+		case errors.Is(err, common.ErrConfigUpdaterNotImplemented):
+			// The VM can only be given the new config by restarting its chain
+			report.Applied = removeKey(report.Applied, chainConfig.Key)
+			report.RequiresRestart = append(report.RequiresRestart, chainConfig.Key)
+			sort.Strings(report.RequiresRestart)
+		case err != nil:
+			report.Applied = removeKey(report.Applied, chainConfig.Key)
+			failed = append(failed, fmt.Sprintf("%s: %s", alias, err))
+		default:
+			delivered = append(delivered, alias)
+		}
+	}
+
+	// The changes are only committed once they were applied, so that the
+	// chain configs that weren't delivered are retried by the next reload.
+	n.Config.ConfigReloader.Commit(delivered)
+
+	n.Log.Info("reloaded config. applied: %v, requires restart: %v",
+		report.Applied,
+		report.RequiresRestart,
+	)
+	if len(failed) != 0 {
+		return report.Applied, report.RequiresRestart, fmt.Errorf("%w: %s", errChainConfigsNotUpdated, strings.Join(failed, ", "))
+	}
+	return report.Applied, report.RequiresRestart, nil
+}
+
+// removeKey returns [keys] without [key].
+func removeKey(keys []string, key string) []string {
+	filtered := keys[:0]
+	for _, k := range keys {
+		if k != key {
+			filtered = append(filtered, k)
+		}
+	}
+	return filtered
+}
+
+// Backup writes a consistent archive of the node's current database to the
+// file [name] in the backup directory while the node keeps running. If
+// [upload] is non-nil, the archive is then uploaded with the S3 credentials in
+// the node's environment and the local file is removed. Returns the location
+// of the archive.
+func (n *Node) Backup(name string, config backup.Config, upload *backup.S3Config) (string, backup.Report, error) {
+	if !n.backupLock.TryLock() {
+		return "", backup.Report{}, errBackupInProgress
+	}
+	defer n.backupLock.Unlock()
+
+	if len(name) == 0 {
+		name = fmt.Sprintf("%d.backup", time.Now().Unix())
+	}
+	if name != filepath.Base(name) || name == "." || name == ".." {
+		return "", backup.Report{}, fmt.Errorf("%w: %q", errInvalidBackupName, name)
+	}
+	path := filepath.Join(n.Config.DatabaseConfig.BackupDir, name)
+
+	n.Log.Info("writing backup to %s", path)
+	startTime := time.Now()
+	report, err := backup.WriteFile(n.Log, path, n.DBManager.Current(), config)
+	if err != nil {
+		return "", backup.Report{}, fmt.Errorf("couldn't write backup: %w", err)
+	}
+	n.Log.Info("wrote backup of %d keys (%d bytes) to %s in %s",
+		report.Keys,
+		report.Size,
+		path,
+		time.Since(startTime),
+	)
+	if upload == nil {
+		return path, report, nil
+	}
+
+	backup.S3CredentialsFromEnv(upload)
+	if err := backup.Upload(context.Background(), http.DefaultClient, *upload, path); err != nil {
+		return "", backup.Report{}, fmt.Errorf("couldn't upload backup %s: %w", path, err)
+	}
+	n.Log.Info("uploaded backup %s to %s", path, upload.URL)
+	return upload.URL, report, os.Remove(path)
+}
+
 // Shutdown this node
 // May be called multiple times
 func (n *Node) Shutdown(exitCode int) {
@@ -1333,6 +1801,13 @@ func (n *Node) shutdown() {
 	n.Log.Info("cleaning up plugin subprocesses")
 	plugin.CleanupClients()
 
+	// If the node is shutting down because of an error, the chain state is
+	// checked again the next time it starts.
+	if n.DB != nil && n.ExitCode() == 0 {
+		if err := n.DB.Delete(runningKey); err != nil {
+			n.Log.Warn("couldn't mark the node as shut down cleanly: %s", err)
+		}
+	}
 	if n.DBManager != nil {
 		if err := n.DBManager.Close(); err != nil {
 			n.Log.Warn("error during DB shutdown: %s", err)
@@ -0,0 +1,456 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: platformvm/platformvm.proto
+
+package platformvm
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PlatformClient is the client API for Platform service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PlatformClient interface {
+	GetHeight(ctx context.Context, in *GetHeightRequest, opts ...grpc.CallOption) (*GetHeightResponse, error)
+	GetTimestamp(ctx context.Context, in *GetTimestampRequest, opts ...grpc.CallOption) (*GetTimestampResponse, error)
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	GetCurrentValidators(ctx context.Context, in *GetCurrentValidatorsRequest, opts ...grpc.CallOption) (*GetCurrentValidatorsResponse, error)
+	GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error)
+	GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error)
+	IssueTx(ctx context.Context, in *IssueTxRequest, opts ...grpc.CallOption) (*IssueTxResponse, error)
+	// SubscribeAcceptedBlocks streams the blocks accepted after the call is
+	// made, as published on /ext/bc/P/events.
+	SubscribeAcceptedBlocks(ctx context.Context, in *SubscribeAcceptedBlocksRequest, opts ...grpc.CallOption) (Platform_SubscribeAcceptedBlocksClient, error)
+	// SubscribeAcceptedTxs streams the txs accepted after the call is made, as
+	// published on /ext/bc/P/events.
+	SubscribeAcceptedTxs(ctx context.Context, in *SubscribeAcceptedTxsRequest, opts ...grpc.CallOption) (Platform_SubscribeAcceptedTxsClient, error)
+}
+
+type platformClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlatformClient(cc grpc.ClientConnInterface) PlatformClient {
+	return &platformClient{cc}
+}
+
+func (c *platformClient) GetHeight(ctx context.Context, in *GetHeightRequest, opts ...grpc.CallOption) (*GetHeightResponse, error) {
+	out := new(GetHeightResponse)
+	err := c.cc.Invoke(ctx, "/platformvm.Platform/GetHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTimestamp(ctx context.Context, in *GetTimestampRequest, opts ...grpc.CallOption) (*GetTimestampResponse, error) {
+	out := new(GetTimestampResponse)
+	err := c.cc.Invoke(ctx, "/platformvm.Platform/GetTimestamp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
+	out := new(GetBalanceResponse)
+	err := c.cc.Invoke(ctx, "/platformvm.Platform/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetCurrentValidators(ctx context.Context, in *GetCurrentValidatorsRequest, opts ...grpc.CallOption) (*GetCurrentValidatorsResponse, error) {
+	out := new(GetCurrentValidatorsResponse)
+	err := c.cc.Invoke(ctx, "/platformvm.Platform/GetCurrentValidators", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error) {
+	out := new(GetTxResponse)
+	err := c.cc.Invoke(ctx, "/platformvm.Platform/GetTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error) {
+	out := new(GetTxStatusResponse)
+	err := c.cc.Invoke(ctx, "/platformvm.Platform/GetTxStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) IssueTx(ctx context.Context, in *IssueTxRequest, opts ...grpc.CallOption) (*IssueTxResponse, error) {
+	out := new(IssueTxResponse)
+	err := c.cc.Invoke(ctx, "/platformvm.Platform/IssueTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) SubscribeAcceptedBlocks(ctx context.Context, in *SubscribeAcceptedBlocksRequest, opts ...grpc.CallOption) (Platform_SubscribeAcceptedBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Platform_ServiceDesc.Streams[0], "/platformvm.Platform/SubscribeAcceptedBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &platformSubscribeAcceptedBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Platform_SubscribeAcceptedBlocksClient interface {
+	Recv() (*AcceptedBlock, error)
+	grpc.ClientStream
+}
+
+type platformSubscribeAcceptedBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *platformSubscribeAcceptedBlocksClient) Recv() (*AcceptedBlock, error) {
+	m := new(AcceptedBlock)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *platformClient) SubscribeAcceptedTxs(ctx context.Context, in *SubscribeAcceptedTxsRequest, opts ...grpc.CallOption) (Platform_SubscribeAcceptedTxsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Platform_ServiceDesc.Streams[1], "/platformvm.Platform/SubscribeAcceptedTxs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &platformSubscribeAcceptedTxsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Platform_SubscribeAcceptedTxsClient interface {
+	Recv() (*AcceptedTx, error)
+	grpc.ClientStream
+}
+
+type platformSubscribeAcceptedTxsClient struct {
+	grpc.ClientStream
+}
+
+func (x *platformSubscribeAcceptedTxsClient) Recv() (*AcceptedTx, error) {
+	m := new(AcceptedTx)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PlatformServer is the server API for Platform service.
+// All implementations must embed UnimplementedPlatformServer
+// for forward compatibility
+type PlatformServer interface {
+	GetHeight(context.Context, *GetHeightRequest) (*GetHeightResponse, error)
+	GetTimestamp(context.Context, *GetTimestampRequest) (*GetTimestampResponse, error)
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	GetCurrentValidators(context.Context, *GetCurrentValidatorsRequest) (*GetCurrentValidatorsResponse, error)
+	GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error)
+	GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error)
+	IssueTx(context.Context, *IssueTxRequest) (*IssueTxResponse, error)
+	// SubscribeAcceptedBlocks streams the blocks accepted after the call is
+	// made, as published on /ext/bc/P/events.
+	SubscribeAcceptedBlocks(*SubscribeAcceptedBlocksRequest, Platform_SubscribeAcceptedBlocksServer) error
+	// SubscribeAcceptedTxs streams the txs accepted after the call is made, as
+	// published on /ext/bc/P/events.
+	SubscribeAcceptedTxs(*SubscribeAcceptedTxsRequest, Platform_SubscribeAcceptedTxsServer) error
+	mustEmbedUnimplementedPlatformServer()
+}
+
+// UnimplementedPlatformServer must be embedded to have forward compatible implementations.
+type UnimplementedPlatformServer struct {
+}
+
+func (UnimplementedPlatformServer) GetHeight(context.Context, *GetHeightRequest) (*GetHeightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeight not implemented")
+}
+func (UnimplementedPlatformServer) GetTimestamp(context.Context, *GetTimestampRequest) (*GetTimestampResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimestamp not implemented")
+}
+func (UnimplementedPlatformServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedPlatformServer) GetCurrentValidators(context.Context, *GetCurrentValidatorsRequest) (*GetCurrentValidatorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentValidators not implemented")
+}
+func (UnimplementedPlatformServer) GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTx not implemented")
+}
+func (UnimplementedPlatformServer) GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxStatus not implemented")
+}
+func (UnimplementedPlatformServer) IssueTx(context.Context, *IssueTxRequest) (*IssueTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueTx not implemented")
+}
+func (UnimplementedPlatformServer) SubscribeAcceptedBlocks(*SubscribeAcceptedBlocksRequest, Platform_SubscribeAcceptedBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeAcceptedBlocks not implemented")
+}
+func (UnimplementedPlatformServer) SubscribeAcceptedTxs(*SubscribeAcceptedTxsRequest, Platform_SubscribeAcceptedTxsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeAcceptedTxs not implemented")
+}
+func (UnimplementedPlatformServer) mustEmbedUnimplementedPlatformServer() {}
+
+// UnsafePlatformServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlatformServer will
+// result in compilation errors.
+type UnsafePlatformServer interface {
+	mustEmbedUnimplementedPlatformServer()
+}
+
+func RegisterPlatformServer(s grpc.ServiceRegistrar, srv PlatformServer) {
+	s.RegisterService(&Platform_ServiceDesc, srv)
+}
+
+func _Platform_GetHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformvm.Platform/GetHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetHeight(ctx, req.(*GetHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTimestamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTimestampRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTimestamp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformvm.Platform/GetTimestamp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTimestamp(ctx, req.(*GetTimestampRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformvm.Platform/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetCurrentValidators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentValidatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetCurrentValidators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformvm.Platform/GetCurrentValidators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetCurrentValidators(ctx, req.(*GetCurrentValidatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformvm.Platform/GetTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTx(ctx, req.(*GetTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetTxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetTxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformvm.Platform/GetTxStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetTxStatus(ctx, req.(*GetTxStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IssueTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IssueTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/platformvm.Platform/IssueTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IssueTx(ctx, req.(*IssueTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_SubscribeAcceptedBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeAcceptedBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlatformServer).SubscribeAcceptedBlocks(m, &platformSubscribeAcceptedBlocksServer{stream})
+}
+
+type Platform_SubscribeAcceptedBlocksServer interface {
+	Send(*AcceptedBlock) error
+	grpc.ServerStream
+}
+
+type platformSubscribeAcceptedBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *platformSubscribeAcceptedBlocksServer) Send(m *AcceptedBlock) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Platform_SubscribeAcceptedTxs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeAcceptedTxsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlatformServer).SubscribeAcceptedTxs(m, &platformSubscribeAcceptedTxsServer{stream})
+}
+
+type Platform_SubscribeAcceptedTxsServer interface {
+	Send(*AcceptedTx) error
+	grpc.ServerStream
+}
+
+type platformSubscribeAcceptedTxsServer struct {
+	grpc.ServerStream
+}
+
+func (x *platformSubscribeAcceptedTxsServer) Send(m *AcceptedTx) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Platform_ServiceDesc is the grpc.ServiceDesc for Platform service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Platform_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "platformvm.Platform",
+	HandlerType: (*PlatformServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHeight",
+			Handler:    _Platform_GetHeight_Handler,
+		},
+		{
+			MethodName: "GetTimestamp",
+			Handler:    _Platform_GetTimestamp_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _Platform_GetBalance_Handler,
+		},
+		{
+			MethodName: "GetCurrentValidators",
+			Handler:    _Platform_GetCurrentValidators_Handler,
+		},
+		{
+			MethodName: "GetTx",
+			Handler:    _Platform_GetTx_Handler,
+		},
+		{
+			MethodName: "GetTxStatus",
+			Handler:    _Platform_GetTxStatus_Handler,
+		},
+		{
+			MethodName: "IssueTx",
+			Handler:    _Platform_IssueTx_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeAcceptedBlocks",
+			Handler:       _Platform_SubscribeAcceptedBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeAcceptedTxs",
+			Handler:       _Platform_SubscribeAcceptedTxs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "platformvm/platformvm.proto",
+}
@@ -60,6 +60,18 @@ type VMClient interface {
 	BlockReject(ctx context.Context, in *BlockRejectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// StateSummary
 	StateSummaryAccept(ctx context.Context, in *StateSummaryAcceptRequest, opts ...grpc.CallOption) (*StateSummaryAcceptResponse, error)
+	// ConfigUpdater
+	UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*UpdateConfigResponse, error)
+	// CongestionReportingVM
+	Congestion(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CongestionResponse, error)
+	// ConfigChecker
+	CheckConfig(ctx context.Context, in *CheckConfigRequest, opts ...grpc.CallOption) (*CheckConfigResponse, error)
+	// Streaming
+	//
+	// Long-lived alternatives to ParseBlock and AppGossip that avoid paying the
+	// setup cost of a unary RPC for every message on these hot paths.
+	ParseBlockStream(ctx context.Context, opts ...grpc.CallOption) (VM_ParseBlockStreamClient, error)
+	AppGossipStream(ctx context.Context, opts ...grpc.CallOption) (VM_AppGossipStreamClient, error)
 }
 
 type vMClient struct {
@@ -349,6 +361,98 @@ func (c *vMClient) StateSummaryAccept(ctx context.Context, in *StateSummaryAccep
 	return out, nil
 }
 
+func (c *vMClient) UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*UpdateConfigResponse, error) {
+	out := new(UpdateConfigResponse)
+	err := c.cc.Invoke(ctx, "/vm.VM/UpdateConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) Congestion(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CongestionResponse, error) {
+	out := new(CongestionResponse)
+	err := c.cc.Invoke(ctx, "/vm.VM/Congestion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) CheckConfig(ctx context.Context, in *CheckConfigRequest, opts ...grpc.CallOption) (*CheckConfigResponse, error) {
+	out := new(CheckConfigResponse)
+	err := c.cc.Invoke(ctx, "/vm.VM/CheckConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) ParseBlockStream(ctx context.Context, opts ...grpc.CallOption) (VM_ParseBlockStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VM_ServiceDesc.Streams[0], "/vm.VM/ParseBlockStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vMParseBlockStreamClient{stream}
+	return x, nil
+}
+
+type VM_ParseBlockStreamClient interface {
+	Send(*ParseBlockRequest) error
+	Recv() (*ParseBlockStreamResponse, error)
+	grpc.ClientStream
+}
+
+type vMParseBlockStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *vMParseBlockStreamClient) Send(m *ParseBlockRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *vMParseBlockStreamClient) Recv() (*ParseBlockStreamResponse, error) {
+	m := new(ParseBlockStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vMClient) AppGossipStream(ctx context.Context, opts ...grpc.CallOption) (VM_AppGossipStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VM_ServiceDesc.Streams[1], "/vm.VM/AppGossipStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vMAppGossipStreamClient{stream}
+	return x, nil
+}
+
+type VM_AppGossipStreamClient interface {
+	Send(*AppGossipMsg) error
+	CloseAndRecv() (*emptypb.Empty, error)
+	grpc.ClientStream
+}
+
+type vMAppGossipStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *vMAppGossipStreamClient) Send(m *AppGossipMsg) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *vMAppGossipStreamClient) CloseAndRecv() (*emptypb.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(emptypb.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // VMServer is the server API for VM service.
 // All implementations must embed UnimplementedVMServer
 // for forward compatibility
@@ -390,6 +494,18 @@ type VMServer interface {
 	BlockReject(context.Context, *BlockRejectRequest) (*emptypb.Empty, error)
 	// StateSummary
 	StateSummaryAccept(context.Context, *StateSummaryAcceptRequest) (*StateSummaryAcceptResponse, error)
+	// ConfigUpdater
+	UpdateConfig(context.Context, *UpdateConfigRequest) (*UpdateConfigResponse, error)
+	// CongestionReportingVM
+	Congestion(context.Context, *emptypb.Empty) (*CongestionResponse, error)
+	// ConfigChecker
+	CheckConfig(context.Context, *CheckConfigRequest) (*CheckConfigResponse, error)
+	// Streaming
+	//
+	// Long-lived alternatives to ParseBlock and AppGossip that avoid paying the
+	// setup cost of a unary RPC for every message on these hot paths.
+	ParseBlockStream(VM_ParseBlockStreamServer) error
+	AppGossipStream(VM_AppGossipStreamServer) error
 	mustEmbedUnimplementedVMServer()
 }
 
@@ -490,6 +606,21 @@ func (UnimplementedVMServer) BlockReject(context.Context, *BlockRejectRequest) (
 func (UnimplementedVMServer) StateSummaryAccept(context.Context, *StateSummaryAcceptRequest) (*StateSummaryAcceptResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StateSummaryAccept not implemented")
 }
+func (UnimplementedVMServer) UpdateConfig(context.Context, *UpdateConfigRequest) (*UpdateConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfig not implemented")
+}
+func (UnimplementedVMServer) Congestion(context.Context, *emptypb.Empty) (*CongestionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Congestion not implemented")
+}
+func (UnimplementedVMServer) CheckConfig(context.Context, *CheckConfigRequest) (*CheckConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckConfig not implemented")
+}
+func (UnimplementedVMServer) ParseBlockStream(VM_ParseBlockStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ParseBlockStream not implemented")
+}
+func (UnimplementedVMServer) AppGossipStream(VM_AppGossipStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method AppGossipStream not implemented")
+}
 func (UnimplementedVMServer) mustEmbedUnimplementedVMServer() {}
 
 // UnsafeVMServer may be embedded to opt out of forward compatibility for this service.
@@ -1061,6 +1192,112 @@ func _VM_StateSummaryAccept_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VM_UpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).UpdateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vm.VM/UpdateConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).UpdateConfig(ctx, req.(*UpdateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_Congestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).Congestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vm.VM/Congestion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).Congestion(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_CheckConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).CheckConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vm.VM/CheckConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).CheckConfig(ctx, req.(*CheckConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_ParseBlockStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VMServer).ParseBlockStream(&vMParseBlockStreamServer{stream})
+}
+
+type VM_ParseBlockStreamServer interface {
+	Send(*ParseBlockStreamResponse) error
+	Recv() (*ParseBlockRequest, error)
+	grpc.ServerStream
+}
+
+type vMParseBlockStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *vMParseBlockStreamServer) Send(m *ParseBlockStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *vMParseBlockStreamServer) Recv() (*ParseBlockRequest, error) {
+	m := new(ParseBlockRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _VM_AppGossipStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VMServer).AppGossipStream(&vMAppGossipStreamServer{stream})
+}
+
+type VM_AppGossipStreamServer interface {
+	SendAndClose(*emptypb.Empty) error
+	Recv() (*AppGossipMsg, error)
+	grpc.ServerStream
+}
+
+type vMAppGossipStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *vMAppGossipStreamServer) SendAndClose(m *emptypb.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *vMAppGossipStreamServer) Recv() (*AppGossipMsg, error) {
+	m := new(AppGossipMsg)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // VM_ServiceDesc is the grpc.ServiceDesc for VM service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1192,7 +1429,31 @@ var VM_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StateSummaryAccept",
 			Handler:    _VM_StateSummaryAccept_Handler,
 		},
+		{
+			MethodName: "UpdateConfig",
+			Handler:    _VM_UpdateConfig_Handler,
+		},
+		{
+			MethodName: "Congestion",
+			Handler:    _VM_Congestion_Handler,
+		},
+		{
+			MethodName: "CheckConfig",
+			Handler:    _VM_CheckConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ParseBlockStream",
+			Handler:       _VM_ParseBlockStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "AppGossipStream",
+			Handler:       _VM_AppGossipStream_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "vm/vm.proto",
 }
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -20,6 +21,9 @@ var (
 	ErrAddressLimit                = errors.New("address limit exceeded")
 	ErrInvalidFilterParam          = errors.New("invalid bloom filter params")
 	ErrInvalidCommand              = errors.New("invalid command")
+	ErrNoTopics                    = errors.New("no topics provided")
+	ErrUnknownTopic                = errors.New("unknown topic")
+	ErrUnsupportedTopic            = errors.New("topic isn't supported by this chain")
 	_                       Filter = &connection{}
 )
 
@@ -39,7 +43,14 @@ type connection struct {
 
 	fp *FilterParam
 
+	topicsLock sync.RWMutex
+	topics     map[Topic]struct{}
+
 	active uint32
+
+	// droppedMessages is the number of consecutive messages that could not be
+	// queued because the peer isn't reading fast enough.
+	droppedMessages uint32
 }
 
 func (c *connection) Check(addr []byte) bool {
@@ -55,15 +66,34 @@ func (c *connection) deactivate() {
 	atomic.StoreUint32(&c.active, 0)
 }
 
+func (c *connection) isSubscribed(topic Topic) bool {
+	c.topicsLock.RLock()
+	defer c.topicsLock.RUnlock()
+
+	_, ok := c.topics[topic]
+	return ok
+}
+
+// Send queues [msg] to be written to the peer. If the peer has fallen too far
+// behind, the connection is closed rather than allowing the backlog to grow.
 func (c *connection) Send(msg interface{}) bool {
 	if !c.isActive() {
 		return false
 	}
 	select {
 	case c.send <- msg:
+		atomic.StoreUint32(&c.droppedMessages, 0)
 		return true
 	default:
 	}
+
+	if atomic.AddUint32(&c.droppedMessages, 1) > maxDroppedMessages {
+		c.s.log.Debug("closing slow subscriber after %d consecutive dropped messages", maxDroppedMessages)
+		c.deactivate()
+		// Closing the underlying connection will cause the readPump to exit,
+		// which will remove this connection from the server.
+		_ = c.conn.Close()
+	}
 	return false
 }
 
@@ -147,6 +177,9 @@ func (c *connection) writePump() {
 	}
 }
 
+// readMessage reads and handles the next command. An error is only returned if
+// the connection should be closed. If the command is invalid, the error is sent
+// to the client, which can keep using the connection.
 func (c *connection) readMessage() error {
 	_, r, err := c.conn.NextReader()
 	if err != nil {
@@ -165,6 +198,10 @@ func (c *connection) readMessage() error {
 		c.handleNewSet(cmd.NewSet)
 	case cmd.AddAddresses != nil:
 		err = c.handleAddAddresses(cmd.AddAddresses)
+	case cmd.Subscribe != nil:
+		err = c.handleSubscribe(cmd.Subscribe)
+	case cmd.Unsubscribe != nil:
+		err = c.handleUnsubscribe(cmd.Unsubscribe)
 	default:
 		err = ErrInvalidCommand
 	}
@@ -173,7 +210,7 @@ func (c *connection) readMessage() error {
 			Error: err.Error(),
 		})
 	}
-	return err
+	return nil
 }
 
 func (c *connection) handleNewBloom(cmd *NewBloom) error {
@@ -203,3 +240,33 @@ func (c *connection) handleAddAddresses(cmd *AddAddresses) error {
 	c.s.subscribedConnections.Add(c)
 	return nil
 }
+
+func (c *connection) handleSubscribe(cmd *Subscribe) error {
+	if err := c.s.verifyTopics(cmd.Topics); err != nil {
+		return err
+	}
+
+	c.topicsLock.Lock()
+	for _, topic := range cmd.Topics {
+		c.topics[topic] = struct{}{}
+	}
+	c.topicsLock.Unlock()
+
+	c.s.subscribe(c, cmd.Topics)
+	return nil
+}
+
+func (c *connection) handleUnsubscribe(cmd *Unsubscribe) error {
+	if err := c.s.verifyTopics(cmd.Topics); err != nil {
+		return err
+	}
+
+	c.topicsLock.Lock()
+	for _, topic := range cmd.Topics {
+		delete(c.topics, topic)
+	}
+	c.topicsLock.Unlock()
+
+	c.s.unsubscribe(c, cmd.Topics)
+	return nil
+}
@@ -6,3 +6,16 @@ package pubsub
 type Filterer interface {
 	Filter(connections []Filter) ([]bool, interface{})
 }
+
+// broadcaster notifies every connection of the same message.
+type broadcaster struct {
+	msg interface{}
+}
+
+func (b broadcaster) Filter(connections []Filter) ([]bool, interface{}) {
+	toNotify := make([]bool, len(connections))
+	for i := range toNotify {
+		toNotify[i] = true
+	}
+	return toNotify, b.msg
+}
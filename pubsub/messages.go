@@ -28,11 +28,29 @@ type AddAddresses struct {
 	addressIds [][]byte
 }
 
+// Subscribe command to start receiving notifications on the provided topics
+type Subscribe struct {
+	Topics []Topic `json:"topics"`
+}
+
+// Unsubscribe command to stop receiving notifications on the provided topics
+type Unsubscribe struct {
+	Topics []Topic `json:"topics"`
+}
+
 // Command execution command
 type Command struct {
 	NewBloom     *NewBloom     `json:"newBloom,omitempty"`
 	NewSet       *NewSet       `json:"newSet,omitempty"`
 	AddAddresses *AddAddresses `json:"addAddresses,omitempty"`
+	Subscribe    *Subscribe    `json:"subscribe,omitempty"`
+	Unsubscribe  *Unsubscribe  `json:"unsubscribe,omitempty"`
+}
+
+// Notification is sent to connections subscribed to a topic
+type Notification struct {
+	Topic   Topic       `json:"topic"`
+	Message interface{} `json:"message"`
 }
 
 func (c *Command) String() string {
@@ -43,6 +61,10 @@ func (c *Command) String() string {
 		return "newSet"
 	case c.AddAddresses != nil:
 		return "addAddresses"
+	case c.Subscribe != nil:
+		return "subscribe"
+	case c.Unsubscribe != nil:
+		return "unsubscribe"
 	default:
 		return "unknown"
 	}
@@ -4,6 +4,7 @@
 package pubsub
 
 import (
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
@@ -36,6 +37,10 @@ const (
 	// Maximum number of pending messages to send to a peer.
 	maxPendingMessages = 1024 // messages
 
+	// Maximum number of consecutive messages that can be dropped for a peer
+	// before the peer is disconnected.
+	maxDroppedMessages = maxPendingMessages // messages
+
 	// MaxBytes the max number of bytes for a filter
 	MaxBytes = 1 * units.MiB
 
@@ -61,13 +66,21 @@ type Server struct {
 	conns map[*connection]struct{}
 	// subscribedConnections the connections that have activated subscriptions
 	subscribedConnections *connections
+	// topicConnections the connections that are subscribed to each topic
+	topicConnections map[Topic]*connections
 }
 
-func New(networkID uint32, log logging.Logger) *Server {
+// New returns a server whose connections can subscribe to [topics]
+func New(networkID uint32, log logging.Logger, topics ...Topic) *Server {
+	topicConnections := make(map[Topic]*connections, len(topics))
+	for _, topic := range topics {
+		topicConnections[topic] = newConnections()
+	}
 	return &Server{
 		log:                   log,
 		conns:                 make(map[*connection]struct{}),
 		subscribedConnections: newConnections(),
+		topicConnections:      topicConnections,
 	}
 }
 
@@ -82,6 +95,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		conn:   wsConn,
 		send:   make(chan interface{}, maxPendingMessages),
 		fp:     NewFilterParam(),
+		topics: make(map[Topic]struct{}),
 		active: 1,
 	}
 	s.addConnection(conn)
@@ -101,6 +115,70 @@ func (s *Server) Publish(parser Filterer) {
 	}
 }
 
+// PublishTopic sends a notification to the connections subscribed to [topic].
+// If the topic is filtered, [parser] is used to select which of the subscribed
+// connections should be notified.
+func (s *Server) PublishTopic(topic Topic, parser Filterer) {
+	topicConns, ok := s.topicConnections[topic]
+	if !ok {
+		return
+	}
+	conns := topicConns.Conns()
+	if len(conns) == 0 {
+		return
+	}
+
+	toNotify, msg := parser.Filter(conns)
+	notification := &Notification{
+		Topic:   topic,
+		Message: msg,
+	}
+	for i, shouldNotify := range toNotify {
+		if !shouldNotify && topic.Filtered() {
+			continue
+		}
+		conn := conns[i].(*connection)
+		if !conn.Send(notification) {
+			s.log.Verbo("dropping %s notification to subscribed connection due to too many pending messages", topic)
+		}
+	}
+}
+
+// Broadcast sends [msg] to every connection subscribed to the unfiltered
+// [topic].
+func (s *Server) Broadcast(topic Topic, msg interface{}) {
+	s.PublishTopic(topic, broadcaster{msg: msg})
+}
+
+// verifyTopics returns an error if any of the provided topics are unknown or
+// aren't served by this server.
+func (s *Server) verifyTopics(topics []Topic) error {
+	if len(topics) == 0 {
+		return ErrNoTopics
+	}
+	for _, topic := range topics {
+		if !topic.Valid() {
+			return fmt.Errorf("%w: %q", ErrUnknownTopic, topic)
+		}
+		if _, ok := s.topicConnections[topic]; !ok {
+			return fmt.Errorf("%w: %q", ErrUnsupportedTopic, topic)
+		}
+	}
+	return nil
+}
+
+func (s *Server) subscribe(conn *connection, topics []Topic) {
+	for _, topic := range topics {
+		s.topicConnections[topic].Add(conn)
+	}
+}
+
+func (s *Server) unsubscribe(conn *connection, topics []Topic) {
+	for _, topic := range topics {
+		s.topicConnections[topic].Remove(conn)
+	}
+}
+
 func (s *Server) addConnection(conn *connection) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -113,6 +191,9 @@ func (s *Server) addConnection(conn *connection) {
 
 func (s *Server) removeConnection(conn *connection) {
 	s.subscribedConnections.Remove(conn)
+	for _, conns := range s.topicConnections {
+		conns.Remove(conn)
+	}
 
 	s.lock.Lock()
 	defer s.lock.Unlock()
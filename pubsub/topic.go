@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+// Topic is a stream of notifications that a connection can subscribe to.
+type Topic string
+
+const (
+	// AcceptedBlocks notifies subscribers of every block accepted by the
+	// chain.
+	AcceptedBlocks Topic = "acceptedBlocks"
+
+	// AcceptedTxs notifies subscribers of every transaction accepted by the
+	// chain.
+	AcceptedTxs Topic = "acceptedTxs"
+
+	// UTXOs notifies subscribers of the UTXOs consumed and produced by
+	// accepted transactions that spend from or send to an address in the
+	// connection's filter. Only the X-chain serves this topic.
+	UTXOs Topic = "utxos"
+)
+
+// Valid returns true if this topic is supported by the server.
+func (t Topic) Valid() bool {
+	switch t {
+	case AcceptedBlocks, AcceptedTxs, UTXOs:
+		return true
+	default:
+		return false
+	}
+}
+
+// Filtered returns true if notifications on this topic are only sent to
+// connections whose address filter matches the notification.
+func (t Topic) Filtered() bool {
+	return t == UTXOs
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestVerifyTopics(t *testing.T) {
+	assert := assert.New(t)
+
+	s := New(0, logging.NoLog{}, AcceptedTxs, UTXOs)
+	assert.NoError(s.verifyTopics([]Topic{AcceptedTxs, UTXOs}))
+	assert.ErrorIs(s.verifyTopics(nil), ErrNoTopics)
+
+	assert.ErrorIs(s.verifyTopics([]Topic{AcceptedTxs, "unknown"}), ErrUnknownTopic)
+	assert.ErrorIs(s.verifyTopics([]Topic{AcceptedBlocks}), ErrUnsupportedTopic)
+}
+
+func TestTopicFiltered(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(AcceptedBlocks.Filtered())
+	assert.False(AcceptedTxs.Filtered())
+	assert.True(UTXOs.Filtered())
+}
+
+func TestBroadcasterNotifiesAll(t *testing.T) {
+	assert := assert.New(t)
+
+	msg := "hello"
+	toNotify, resp := broadcaster{msg: msg}.Filter(make([]Filter, 3))
+	assert.Equal([]bool{true, true, true}, toNotify)
+	assert.Equal(msg, resp)
+}
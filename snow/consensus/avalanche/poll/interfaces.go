@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 )
 
@@ -14,7 +15,7 @@ import (
 type Set interface {
 	fmt.Stringer
 
-	Add(requestID uint32, vdrs ids.NodeIDBag) bool
+	Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool
 	Vote(requestID uint32, vdr ids.NodeID, votes []ids.ID) []ids.UniqueBag
 	Len() int
 }
@@ -30,5 +31,5 @@ type Poll interface {
 
 // Factory creates a new Poll
 type Factory interface {
-	New(vdrs ids.NodeIDBag) Poll
+	New(vdrs bag.Bag[ids.NodeID]) Poll
 }
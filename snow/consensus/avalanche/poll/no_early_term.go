@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
 )
 
 var (
@@ -20,7 +21,7 @@ type noEarlyTermFactory struct{}
 // termination
 func NewNoEarlyTermFactory() Factory { return noEarlyTermFactory{} }
 
-func (noEarlyTermFactory) New(vdrs ids.NodeIDBag) Poll {
+func (noEarlyTermFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
 	return &noEarlyTermPoll{polled: vdrs}
 }
 
@@ -28,7 +29,7 @@ func (noEarlyTermFactory) New(vdrs ids.NodeIDBag) Poll {
 // query or a timeout occurs
 type noEarlyTermPoll struct {
 	votes  ids.UniqueBag
-	polled ids.NodeIDBag
+	polled bag.Bag[ids.NodeID]
 }
 
 // Vote registers a response for this poll
@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/metric"
 )
@@ -86,8 +87,9 @@ func NewSet(
 
 // Add to the current set of polls
 // Returns true if the poll was registered correctly and the network sample
-//         should be made.
-func (s *set) Add(requestID uint32, vdrs ids.NodeIDBag) bool {
+//
+//	should be made.
+func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 	if _, exists := s.polls.Get(requestID); exists {
 		s.log.Debug("dropping poll due to duplicated requestID: %d", requestID)
 		return false
@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
@@ -52,7 +53,7 @@ func TestCreateAndFinishPoll(t *testing.T) {
 	vdr1 := ids.NodeID{1}
 	vdr2 := ids.NodeID{2} // k = 2
 
-	vdrs := ids.NodeIDBag{}
+	vdrs := bag.Bag[ids.NodeID]{}
 	vdrs.Add(
 		vdr1,
 		vdr2,
@@ -102,12 +103,12 @@ func TestCreateAndFinishPollOutOfOrder_OlderFinishesFirst(t *testing.T) {
 	vdrs := []ids.NodeID{vdr1, vdr2, vdr3}
 
 	// create two polls for the two vtxs
-	vdrBag := ids.NodeIDBag{}
+	vdrBag := bag.Bag[ids.NodeID]{}
 	vdrBag.Add(vdrs...)
 	added := s.Add(1, vdrBag)
 	assert.True(t, added)
 
-	vdrBag = ids.NodeIDBag{}
+	vdrBag = bag.Bag[ids.NodeID]{}
 	vdrBag.Add(vdrs...)
 	added = s.Add(2, vdrBag)
 	assert.True(t, added)
@@ -155,17 +156,17 @@ func TestCreateAndFinishPollOutOfOrder_UnfinishedPollsGaps(t *testing.T) {
 	vdrs := []ids.NodeID{vdr1, vdr2, vdr3}
 
 	// create three polls for the two vtxs
-	vdrBag := ids.NodeIDBag{}
+	vdrBag := bag.Bag[ids.NodeID]{}
 	vdrBag.Add(vdrs...)
 	added := s.Add(1, vdrBag)
 	assert.True(t, added)
 
-	vdrBag = ids.NodeIDBag{}
+	vdrBag = bag.Bag[ids.NodeID]{}
 	vdrBag.Add(vdrs...)
 	added = s.Add(2, vdrBag)
 	assert.True(t, added)
 
-	vdrBag = ids.NodeIDBag{}
+	vdrBag = bag.Bag[ids.NodeID]{}
 	vdrBag.Add(vdrs...)
 	added = s.Add(3, vdrBag)
 	assert.True(t, added)
@@ -218,7 +219,7 @@ func TestSetString(t *testing.T) {
 
 	vdr1 := ids.NodeID{1} // k = 1
 
-	vdrs := ids.NodeIDBag{}
+	vdrs := bag.Bag[ids.NodeID]{}
 	vdrs.Add(vdr1)
 
 	expected := `current polls: (Size = 1)
@@ -13,6 +13,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/metrics"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 const minMapSize = 16
@@ -57,18 +59,18 @@ type Topological struct {
 	cg snowstorm.Consensus
 
 	// preferred is the frontier of vtxIDs that are strongly preferred
-	preferred ids.Set
+	preferred set.Set[ids.ID]
 
 	// virtuous is the frontier of vtxIDs that are strongly virtuous
-	virtuous ids.Set
+	virtuous set.Set[ids.ID]
 
 	// orphans are the txIDs that are virtuous, but not preferred
-	orphans ids.Set
+	orphans set.Set[ids.ID]
 
 	// virtuousVoting are the txIDs that are virtuous and still awaiting
 	// additional votes before acceptance. transactionVertices whose vertices
 	// are not considered virtuous are removed from this set.
-	virtuousVoting ids.Set
+	virtuousVoting set.Set[ids.ID]
 
 	// frontier is the set of vts that have no descendents
 	frontier map[ids.ID]Vertex
@@ -78,9 +80,9 @@ type Topological struct {
 
 	// Used in [calculateInDegree] and [markAncestorInDegrees].
 	// Should only be accessed in those methods.
-	// We use this one instance of ids.Set instead of creating a
-	// new ids.Set during each call to [calculateInDegree].
-	leaves ids.Set
+	// We use this one instance of set.Set[ids.ID] instead of creating a
+	// new set.Set[ids.ID] during each call to [calculateInDegree].
+	leaves set.Set[ids.ID]
 
 	// Kahn nodes used in [calculateInDegree] and [markAncestorInDegrees].
 	// Should only be accessed in those methods.
@@ -110,11 +112,11 @@ func (ta *Topological) Initialize(
 
 	ta.ctx = ctx
 	ta.params = params
-	ta.leaves = ids.Set{}
+	ta.leaves = set.Set[ids.ID]{}
 	ta.votes = ids.UniqueBag{}
 	ta.kahnNodes = make(map[ids.ID]kahnNode)
 
-	latencyMetrics, err := metrics.NewLatency("vtx", "vertex/vertices", ctx.Log, "", ctx.Registerer)
+	latencyMetrics, err := metrics.NewLatency("vtx", "vertex/vertices", ctx.Log, "", ctx.LatencyBuckets, ctx.Registerer)
 	if err != nil {
 		return err
 	}
@@ -210,11 +212,11 @@ func (ta *Topological) VertexIssued(vtx Vertex) bool {
 
 func (ta *Topological) TxIssued(tx snowstorm.Tx) bool { return ta.cg.Issued(tx) }
 
-func (ta *Topological) Orphans() ids.Set { return ta.orphans }
+func (ta *Topological) Orphans() set.Set[ids.ID] { return ta.orphans }
 
-func (ta *Topological) Virtuous() ids.Set { return ta.virtuous }
+func (ta *Topological) Virtuous() set.Set[ids.ID] { return ta.virtuous }
 
-func (ta *Topological) Preferences() ids.Set { return ta.preferred }
+func (ta *Topological) Preferences() set.Set[ids.ID] { return ta.preferred }
 
 func (ta *Topological) RecordPoll(responses ids.UniqueBag) error {
 	// Register a new poll call
@@ -234,7 +236,7 @@ func (ta *Topological) RecordPoll(responses ids.UniqueBag) error {
 	if partialVotes.Len() < ta.params.Alpha {
 		// Because there were less than alpha total returned votes, we can skip
 		// the traversals and fail the poll.
-		_, err := ta.cg.RecordPoll(ids.Bag{})
+		_, err := ta.cg.RecordPoll(bag.Bag[ids.ID]{})
 		return err
 	}
 
@@ -380,9 +382,9 @@ func (ta *Topological) markAncestorInDegrees(
 
 // Count the number of votes for each operation by pushing votes upwards through
 // vertex ancestors.
-func (ta *Topological) pushVotes() (ids.Bag, error) {
+func (ta *Topological) pushVotes() (bag.Bag[ids.ID], error) {
 	ta.votes.Clear()
-	txConflicts := make(map[ids.ID]ids.Set, minMapSize)
+	txConflicts := make(map[ids.ID]set.Set[ids.ID], minMapSize)
 
 	// A leaf is a node with no inbound edges. This removes each leaf and pushes
 	// the votes upwards, potentially creating new leaves, until there are no
@@ -393,7 +395,7 @@ func (ta *Topological) pushVotes() (ids.Bag, error) {
 		if !ok {
 			// Should never happen because we just checked that [ta.leaves] is
 			// not empty.
-			return ids.Bag{}, errNoLeaves
+			return bag.Bag[ids.ID]{}, errNoLeaves
 		}
 
 		kahn := ta.kahnNodes[leaf]
@@ -402,7 +404,7 @@ func (ta *Topological) pushVotes() (ids.Bag, error) {
 			vtx := tv.vtx
 			txs, err := vtx.Txs()
 			if err != nil {
-				return ids.Bag{}, err
+				return bag.Bag[ids.ID]{}, err
 			}
 			for _, tx := range txs {
 				// Give the votes to the consumer
@@ -428,7 +430,7 @@ func (ta *Topological) pushVotes() (ids.Bag, error) {
 
 			parents, err := vtx.Parents()
 			if err != nil {
-				return ids.Bag{}, err
+				return bag.Bag[ids.ID]{}, err
 			}
 			for _, dep := range parents {
 				depID := dep.ID()
@@ -466,7 +468,9 @@ func (ta *Topological) pushVotes() (ids.Bag, error) {
 // I now update all my ancestors
 // If any of my parents are rejected, reject myself
 // If I'm preferred, remove all my ancestors from the preferred frontier, add
-//     myself to the preferred frontier
+//
+//	myself to the preferred frontier
+//
 // If all my parents are accepted and I'm acceptable, accept myself
 func (ta *Topological) update(vtx Vertex) error {
 	vtxID := vtx.ID()
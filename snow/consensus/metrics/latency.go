@@ -72,7 +72,9 @@ type latency struct {
 }
 
 // Initialize the metrics with the provided names.
-func NewLatency(metricName, descriptionName string, log logging.Logger, namespace string, reg prometheus.Registerer) (Latency, error) {
+// If [buckets] is non-empty, the accepted and rejected latencies are reported
+// as histograms with the provided bucket boundaries, in nanoseconds.
+func NewLatency(metricName, descriptionName string, log logging.Logger, namespace string, buckets []float64, reg prometheus.Registerer) (Latency, error) {
 	errs := wrappers.Errs{}
 	l := &latency{
 		processingEntries: linkedhashmap.New(),
@@ -96,17 +98,19 @@ func NewLatency(metricName, descriptionName string, log logging.Logger, namespac
 			reg,
 			&errs,
 		),
-		latAccepted: metric.NewAveragerWithErrs(
+		latAccepted: metric.NewBucketedAveragerWithErrs(
 			namespace,
 			fmt.Sprintf("%s_accepted", metricName),
 			fmt.Sprintf("time (in ns) from issuance of a %s to its acceptance", descriptionName),
+			buckets,
 			reg,
 			&errs,
 		),
-		latRejected: metric.NewAveragerWithErrs(
+		latRejected: metric.NewBucketedAveragerWithErrs(
 			namespace,
 			fmt.Sprintf("%s_rejected", metricName),
 			fmt.Sprintf("time (in ns) from issuance of a %s to its rejection", descriptionName),
+			buckets,
 			reg,
 			&errs,
 		),
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
 )
 
 // Consensus represents a general snow instance that can be used directly to
@@ -28,7 +29,7 @@ type Consensus interface {
 
 	// RecordPoll records the results of a network poll. Assumes all choices
 	// have been previously added.
-	RecordPoll(votes ids.Bag)
+	RecordPoll(votes bag.Bag[ids.ID])
 
 	// RecordUnsuccessfulPoll resets the snowflake counters of this consensus
 	// instance
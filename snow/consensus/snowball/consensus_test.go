@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
 )
 
 var _ Consensus = &Byzantine{}
@@ -25,13 +26,13 @@ func (b *Byzantine) Initialize(params Parameters, choice ids.ID) {
 	b.preference = choice
 }
 
-func (b *Byzantine) Parameters() Parameters   { return b.params }
-func (b *Byzantine) Add(choice ids.ID)        {}
-func (b *Byzantine) Preference() ids.ID       { return b.preference }
-func (b *Byzantine) RecordPoll(votes ids.Bag) {}
-func (b *Byzantine) RecordUnsuccessfulPoll()  {}
-func (b *Byzantine) Finalized() bool          { return true }
-func (b *Byzantine) String() string           { return b.preference.String() }
+func (b *Byzantine) Parameters() Parameters           { return b.params }
+func (b *Byzantine) Add(choice ids.ID)                {}
+func (b *Byzantine) Preference() ids.ID               { return b.preference }
+func (b *Byzantine) RecordPoll(votes bag.Bag[ids.ID]) {}
+func (b *Byzantine) RecordUnsuccessfulPoll()          {}
+func (b *Byzantine) Finalized() bool                  { return true }
+func (b *Byzantine) String() string                   { return b.preference.String() }
 
 var (
 	Red   = ids.Empty.Prefix(0)
@@ -5,6 +5,7 @@ package snowball
 
 import (
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
 )
 
 var (
@@ -33,7 +34,7 @@ func (f *Flat) Initialize(params Parameters, choice ids.ID) {
 
 func (f *Flat) Parameters() Parameters { return f.params }
 
-func (f *Flat) RecordPoll(votes ids.Bag) {
+func (f *Flat) RecordPoll(votes bag.Bag[ids.ID]) {
 	if pollMode, numVotes := votes.Mode(); numVotes >= f.params.Alpha {
 		f.RecordSuccessfulPoll(pollMode)
 	} else {
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
 )
 
 func TestFlatParams(t *testing.T) { ParamsTest(t, FlatFactory{}) }
@@ -26,7 +27,7 @@ func TestFlat(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	twoBlue := ids.Bag{}
+	twoBlue := bag.Bag[ids.ID]{}
 	twoBlue.Add(Blue, Blue)
 	f.RecordPoll(twoBlue)
 
@@ -36,7 +37,7 @@ func TestFlat(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	oneRedOneBlue := ids.Bag{}
+	oneRedOneBlue := bag.Bag[ids.ID]{}
 	twoBlue.Add(Red, Blue)
 	f.RecordPoll(oneRedOneBlue)
 
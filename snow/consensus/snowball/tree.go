@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
 )
 
 var (
@@ -67,13 +68,16 @@ func (t *Tree) Add(choice ids.ID) {
 	}
 }
 
-func (t *Tree) RecordPoll(votes ids.Bag) {
+func (t *Tree) RecordPoll(votes bag.Bag[ids.ID]) {
 	// Get the assumed decided prefix of the root node.
 	decidedPrefix := t.node.DecidedPrefix()
 
 	// If any of the bits differ from the preference in this prefix, the vote is
 	// for a rejected operation. So, we filter out these invalid votes.
-	filteredVotes := votes.Filter(0, decidedPrefix, t.Preference())
+	preference := t.Preference()
+	filteredVotes := votes.Filter(func(id ids.ID) bool {
+		return ids.EqualSubset(0, decidedPrefix, preference, id)
+	})
 
 	// Now that the votes have been restricted to valid votes, pass them into
 	// the first snowball instance
@@ -125,7 +129,7 @@ type node interface {
 	// Adds a new choice to vote on
 	Add(newChoice ids.ID) node
 	// Apply the votes, reset the model if needed
-	RecordPoll(votes ids.Bag, shouldReset bool) (newChild node)
+	RecordPoll(votes bag.Bag[ids.ID], shouldReset bool) (newChild node)
 	// Returns true if consensus has been reached on this node
 	Finalized() bool
 
@@ -169,144 +173,145 @@ func (u *unaryNode) DecidedPrefix() int { return u.decidedPrefix }
 // snowball instances, and this function's purpose is convert one of these unary
 // snowball instances into a binary snowball instance.
 // There are 5 possible cases.
-// 1. None of these instances should be split, we should attempt to split a
-//    child
 //
-//        For example, attempting to insert the value "00001" in this node:
+//  1. None of these instances should be split, we should attempt to split a
+//     child
 //
-//                       +-------------------+ <-- This node will not be split
-//                       |                   |
-//                       |       0 0 0       |
-//                       |                   |
-//                       +-------------------+ <-- Pass the add to the child
-//                                 ^
-//                                 |
+//     For example, attempting to insert the value "00001" in this node:
 //
-//        Results in:
+//     +-------------------+ <-- This node will not be split
+//     |                   |
+//     |       0 0 0       |
+//     |                   |
+//     +-------------------+ <-- Pass the add to the child
+//     ^
+//     |
 //
-//                       +-------------------+
-//                       |                   |
-//                       |       0 0 0       |
-//                       |                   |
-//                       +-------------------+ <-- With the modified child
-//                                 ^
-//                                 |
+//     Results in:
 //
-// 2. This instance represents a series of only one unary instance and it must
-//    be split
-//       This will return a binary choice, with one child the same as my child,
-//       and another (possibly nil child) representing a new chain to the end of
-//       the hash
+//     +-------------------+
+//     |                   |
+//     |       0 0 0       |
+//     |                   |
+//     +-------------------+ <-- With the modified child
+//     ^
+//     |
 //
-//        For example, attempting to insert the value "1" in this tree:
+//  2. This instance represents a series of only one unary instance and it must
+//     be split
+//     This will return a binary choice, with one child the same as my child,
+//     and another (possibly nil child) representing a new chain to the end of
+//     the hash
 //
-//                       +-------------------+
-//                       |                   |
-//                       |         0         |
-//                       |                   |
-//                       +-------------------+
+//     For example, attempting to insert the value "1" in this tree:
 //
-//        Results in:
+//     +-------------------+
+//     |                   |
+//     |         0         |
+//     |                   |
+//     +-------------------+
 //
-//                       +-------------------+
-//                       |         |         |
-//                       |    0    |    1    |
-//                       |         |         |
-//                       +-------------------+
+//     Results in:
 //
-// 3. This instance must be split on the first bit
-//       This will return a binary choice, with one child equal to this instance
-//       with decidedPrefix increased by one, and another representing a new
-//       chain to the end of the hash
+//     +-------------------+
+//     |         |         |
+//     |    0    |    1    |
+//     |         |         |
+//     +-------------------+
 //
-//        For example, attempting to insert the value "10" in this tree:
+//  3. This instance must be split on the first bit
+//     This will return a binary choice, with one child equal to this instance
+//     with decidedPrefix increased by one, and another representing a new
+//     chain to the end of the hash
 //
-//                       +-------------------+
-//                       |                   |
-//                       |        0 0        |
-//                       |                   |
-//                       +-------------------+
+//     For example, attempting to insert the value "10" in this tree:
 //
-//        Results in:
+//     +-------------------+
+//     |                   |
+//     |        0 0        |
+//     |                   |
+//     +-------------------+
 //
-//                       +-------------------+
-//                       |         |         |
-//                       |    0    |    1    |
-//                       |         |         |
-//                       +-------------------+
-//                            ^         ^
-//                           /           \
-//            +-------------------+ +-------------------+
-//            |                   | |                   |
-//            |         0         | |         0         |
-//            |                   | |                   |
-//            +-------------------+ +-------------------+
+//     Results in:
 //
-// 4. This instance must be split on the last bit
-//       This will modify this unary choice. The commonPrefix is decreased by
-//       one. The child is set to a binary instance that has a child equal to
-//       the current child and another child equal to a new unary instance to
-//       the end of the hash
+//     +-------------------+
+//     |         |         |
+//     |    0    |    1    |
+//     |         |         |
+//     +-------------------+
+//     ^         ^
+//     /           \
+//     +-------------------+ +-------------------+
+//     |                   | |                   |
+//     |         0         | |         0         |
+//     |                   | |                   |
+//     +-------------------+ +-------------------+
 //
-//        For example, attempting to insert the value "01" in this tree:
+//  4. This instance must be split on the last bit
+//     This will modify this unary choice. The commonPrefix is decreased by
+//     one. The child is set to a binary instance that has a child equal to
+//     the current child and another child equal to a new unary instance to
+//     the end of the hash
 //
-//                       +-------------------+
-//                       |                   |
-//                       |        0 0        |
-//                       |                   |
-//                       +-------------------+
+//     For example, attempting to insert the value "01" in this tree:
 //
-//        Results in:
+//     +-------------------+
+//     |                   |
+//     |        0 0        |
+//     |                   |
+//     +-------------------+
 //
-//                       +-------------------+
-//                       |                   |
-//                       |         0         |
-//                       |                   |
-//                       +-------------------+
-//                                 ^
-//                                 |
-//                       +-------------------+
-//                       |         |         |
-//                       |    0    |    1    |
-//                       |         |         |
-//                       +-------------------+
+//     Results in:
 //
-// 5. This instance must be split on an interior bit
-//       This will modify this unary choice. The commonPrefix is set to the
-//       interior bit. The child is set to a binary instance that has a child
-//       equal to this unary choice with the decidedPrefix equal to the interior
-//       bit and another child equal to a new unary instance to the end of the
-//       hash
+//     +-------------------+
+//     |                   |
+//     |         0         |
+//     |                   |
+//     +-------------------+
+//     ^
+//     |
+//     +-------------------+
+//     |         |         |
+//     |    0    |    1    |
+//     |         |         |
+//     +-------------------+
 //
-//        For example, attempting to insert the value "010" in this tree:
+//  5. This instance must be split on an interior bit
+//     This will modify this unary choice. The commonPrefix is set to the
+//     interior bit. The child is set to a binary instance that has a child
+//     equal to this unary choice with the decidedPrefix equal to the interior
+//     bit and another child equal to a new unary instance to the end of the
+//     hash
 //
-//                       +-------------------+
-//                       |                   |
-//                       |       0 0 0       |
-//                       |                   |
-//                       +-------------------+
+//     For example, attempting to insert the value "010" in this tree:
 //
-//        Results in:
+//     +-------------------+
+//     |                   |
+//     |       0 0 0       |
+//     |                   |
+//     +-------------------+
 //
-//                       +-------------------+
-//                       |                   |
-//                       |         0         |
-//                       |                   |
-//                       +-------------------+
-//                                 ^
-//                                 |
-//                       +-------------------+
-//                       |         |         |
-//                       |    0    |    1    |
-//                       |         |         |
-//                       +-------------------+
-//                            ^         ^
-//                           /           \
-//            +-------------------+ +-------------------+
-//            |                   | |                   |
-//            |         0         | |         0         |
-//            |                   | |                   |
-//            +-------------------+ +-------------------+
+//     Results in:
+//
+//     +-------------------+
+//     |                   |
+//     |         0         |
+//     |                   |
+//     +-------------------+
+//     ^
+//     |
+//     +-------------------+
+//     |         |         |
+//     |    0    |    1    |
+//     |         |         |
+//     +-------------------+
+//     ^         ^
+//     /           \
+//     +-------------------+ +-------------------+
+//     |                   | |                   |
+//     |         0         | |         0         |
+//     |                   | |                   |
+//     +-------------------+ +-------------------+
 func (u *unaryNode) Add(newChoice ids.ID) node {
 	if u.Finalized() {
 		return u // Only happens if the tree is finalized, or it's a leaf node
@@ -389,7 +394,7 @@ func (u *unaryNode) Add(newChoice ids.ID) node {
 	return u // Do nothing, the choice was already rejected
 }
 
-func (u *unaryNode) RecordPoll(votes ids.Bag, reset bool) node {
+func (u *unaryNode) RecordPoll(votes bag.Bag[ids.ID], reset bool) node {
 	// We are guaranteed that the votes are of IDs that have previously been
 	// added. This ensures that the provided votes all have the same bits in the
 	// range [u.decidedPrefix, u.commonPrefix) as in u.preference.
@@ -492,10 +497,12 @@ func (b *binaryNode) Add(id ids.ID) node {
 	return b
 }
 
-func (b *binaryNode) RecordPoll(votes ids.Bag, reset bool) node {
+func (b *binaryNode) RecordPoll(votes bag.Bag[ids.ID], reset bool) node {
 	// The list of votes we are passed is split into votes for bit 0 and votes
 	// for bit 1
-	splitVotes := votes.Split(uint(b.bit))
+	splitVotes := votes.Split(func(id ids.ID) bool {
+		return id.Bit(uint(b.bit)) == 1
+	})
 
 	bit := 0
 	// We only care about which bit is set if a successful poll can happen
@@ -518,8 +525,12 @@ func (b *binaryNode) RecordPoll(votes ids.Bag, reset bool) node {
 		if child := b.children[bit]; child != nil {
 			// The votes are filtered to ensure that they are votes that should
 			// count for the child
-			filteredVotes := prunedVotes.Filter(
-				b.bit+1, child.DecidedPrefix(), b.preferences[bit])
+			start := b.bit + 1
+			end := child.DecidedPrefix()
+			preference := b.preferences[bit]
+			filteredVotes := prunedVotes.Filter(func(id ids.ID) bool {
+				return ids.EqualSubset(start, end, preference, id)
+			})
 
 			newChild := child.RecordPoll(filteredVotes, b.shouldReset[bit])
 			if b.snowball.Finalized() {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
 	"github.com/ava-labs/avalanchego/utils/sampler"
 )
 
@@ -28,7 +29,7 @@ func TestSnowballSingleton(t *testing.T) {
 		t.Fatalf("Snowball is finalized too soon")
 	}
 
-	oneRed := ids.Bag{}
+	oneRed := bag.Bag[ids.ID]{}
 	oneRed.Add(Red)
 	tree.RecordPoll(oneRed)
 
@@ -36,7 +37,7 @@ func TestSnowballSingleton(t *testing.T) {
 		t.Fatalf("Snowball is finalized too soon")
 	}
 
-	empty := ids.Bag{}
+	empty := bag.Bag[ids.ID]{}
 	tree.RecordPoll(empty)
 
 	if tree.Finalized() {
@@ -59,7 +60,7 @@ func TestSnowballSingleton(t *testing.T) {
 
 	tree.Add(Blue)
 
-	oneBlue := ids.Bag{}
+	oneBlue := bag.Bag[ids.ID]{}
 	oneBlue.Add(Blue)
 	tree.RecordPoll(oneBlue)
 
@@ -81,7 +82,7 @@ func TestSnowballRecordUnsuccessfulPoll(t *testing.T) {
 		t.Fatalf("Snowball is finalized too soon")
 	}
 
-	oneRed := ids.Bag{}
+	oneRed := bag.Bag[ids.ID]{}
 	oneRed.Add(Red)
 	tree.RecordPoll(oneRed)
 
@@ -122,7 +123,7 @@ func TestSnowballBinary(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	oneBlue := ids.Bag{}
+	oneBlue := bag.Bag[ids.ID]{}
 	oneBlue.Add(Blue)
 	tree.RecordPoll(oneBlue)
 
@@ -132,7 +133,7 @@ func TestSnowballBinary(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	oneRed := ids.Bag{}
+	oneRed := bag.Bag[ids.ID]{}
 	oneRed.Add(Red)
 	tree.RecordPoll(oneRed)
 
@@ -188,7 +189,7 @@ func TestSnowballLastBinary(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	oneBag := ids.Bag{}
+	oneBag := bag.Bag[ids.ID]{}
 	oneBag.Add(one)
 	tree.RecordPoll(oneBag)
 
@@ -237,7 +238,7 @@ func TestSnowballAddPreviouslyRejected(t *testing.T) {
 		}
 	}
 
-	zeroBag := ids.Bag{}
+	zeroBag := bag.Bag[ids.ID]{}
 	zeroBag.Add(zero)
 	tree.RecordPoll(zeroBag)
 
@@ -298,7 +299,7 @@ func TestSnowballNewUnary(t *testing.T) {
 		}
 	}
 
-	oneBag := ids.Bag{}
+	oneBag := bag.Bag[ids.ID]{}
 	oneBag.Add(one)
 	tree.RecordPoll(oneBag)
 
@@ -361,7 +362,7 @@ func TestSnowballTransitiveReset(t *testing.T) {
 		}
 	}
 
-	zeroBag := ids.Bag{}
+	zeroBag := bag.Bag[ids.ID]{}
 	zeroBag.Add(zero)
 	tree.RecordPoll(zeroBag)
 
@@ -382,7 +383,7 @@ func TestSnowballTransitiveReset(t *testing.T) {
 		}
 	}
 
-	emptyBag := ids.Bag{}
+	emptyBag := bag.Bag[ids.ID]{}
 	tree.RecordPoll(emptyBag)
 
 	{
@@ -456,7 +457,7 @@ func TestSnowballTrinary(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	redBag := ids.Bag{}
+	redBag := bag.Bag[ids.ID]{}
 	redBag.Add(Red)
 	tree.RecordPoll(redBag)
 
@@ -466,7 +467,7 @@ func TestSnowballTrinary(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	blueBag := ids.Bag{}
+	blueBag := bag.Bag[ids.ID]{}
 	blueBag.Add(Blue)
 	tree.RecordPoll(blueBag)
 
@@ -476,7 +477,7 @@ func TestSnowballTrinary(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	greenBag := ids.Bag{}
+	greenBag := bag.Bag[ids.ID]{}
 	greenBag.Add(Green)
 	tree.RecordPoll(greenBag)
 
@@ -524,7 +525,7 @@ func TestSnowballCloseTrinary(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	yellowBag := ids.Bag{}
+	yellowBag := bag.Bag[ids.ID]{}
 	yellowBag.Add(yellow)
 	tree.RecordPoll(yellowBag)
 
@@ -534,7 +535,7 @@ func TestSnowballCloseTrinary(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	magentaBag := ids.Bag{}
+	magentaBag := bag.Bag[ids.ID]{}
 	magentaBag.Add(magenta)
 	tree.RecordPoll(magentaBag)
 
@@ -544,7 +545,7 @@ func TestSnowballCloseTrinary(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	cyanBag := ids.Bag{}
+	cyanBag := bag.Bag[ids.ID]{}
 	cyanBag.Add(cyan)
 	tree.RecordPoll(cyanBag)
 
@@ -583,7 +584,7 @@ func TestSnowballAddRejected(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	c0010Bag := ids.Bag{}
+	c0010Bag := bag.Bag[ids.ID]{}
 	c0010Bag.Add(c0010)
 
 	tree.RecordPoll(c0010Bag)
@@ -638,7 +639,7 @@ func TestSnowballResetChild(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	c0000Bag := ids.Bag{}
+	c0000Bag := bag.Bag[ids.ID]{}
 	c0000Bag.Add(c0000)
 
 	tree.RecordPoll(c0000Bag)
@@ -657,7 +658,7 @@ func TestSnowballResetChild(t *testing.T) {
 		}
 	}
 
-	emptyBag := ids.Bag{}
+	emptyBag := bag.Bag[ids.ID]{}
 
 	tree.RecordPoll(emptyBag)
 	{
@@ -711,7 +712,7 @@ func TestSnowballResetSibling(t *testing.T) {
 		t.Fatalf("Finalized too early")
 	}
 
-	c0100Bag := ids.Bag{}
+	c0100Bag := bag.Bag[ids.ID]{}
 	c0100Bag.Add(c0100)
 
 	tree.RecordPoll(c0100Bag)
@@ -730,7 +731,7 @@ func TestSnowballResetSibling(t *testing.T) {
 		}
 	}
 
-	c1000Bag := ids.Bag{}
+	c1000Bag := bag.Bag[ids.ID]{}
 	c1000Bag.Add(c1000)
 
 	tree.RecordPoll(c1000Bag)
@@ -872,7 +873,7 @@ func TestSnowballFineGrained(t *testing.T) {
 		}
 	}
 
-	c0000Bag := ids.Bag{}
+	c0000Bag := bag.Bag[ids.ID]{}
 	c0000Bag.Add(c0000)
 	tree.RecordPoll(c0000Bag)
 	{
@@ -892,7 +893,7 @@ func TestSnowballFineGrained(t *testing.T) {
 		}
 	}
 
-	c0010Bag := ids.Bag{}
+	c0010Bag := bag.Bag[ids.ID]{}
 	c0010Bag.Add(c0010)
 	tree.RecordPoll(c0010Bag)
 	{
@@ -1020,7 +1021,7 @@ func TestSnowballFilterBinaryChildren(t *testing.T) {
 		}
 	}
 
-	c0000Bag := ids.Bag{}
+	c0000Bag := bag.Bag[ids.ID]{}
 	c0000Bag.Add(c0000)
 	tree.RecordPoll(c0000Bag)
 	{
@@ -1054,7 +1055,7 @@ func TestSnowballFilterBinaryChildren(t *testing.T) {
 		}
 	}
 
-	c0100Bag := ids.Bag{}
+	c0100Bag := bag.Bag[ids.ID]{}
 	c0100Bag.Add(c0100)
 	tree.RecordPoll(c0100Bag)
 	{
@@ -7,6 +7,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/utils/bag"
 )
 
 // Consensus represents a general snowman instance that can be used directly to
@@ -46,7 +47,7 @@ type Consensus interface {
 
 	// RecordPoll collects the results of a network poll. Assumes all decisions
 	// have been previously added. Returns if a critical error has occurred.
-	RecordPoll(ids.Bag) error
+	RecordPoll(bag.Bag[ids.ID]) error
 
 	// Finalized returns true if all decisions that have been added have been
 	// finalized. Note, it is possible that after returning finalized, a new
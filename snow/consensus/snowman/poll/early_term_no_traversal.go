@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
 )
 
 type earlyTermNoTraversalFactory struct {
@@ -19,7 +20,7 @@ func NewEarlyTermNoTraversalFactory(alpha int) Factory {
 	return &earlyTermNoTraversalFactory{alpha: alpha}
 }
 
-func (f *earlyTermNoTraversalFactory) New(vdrs ids.NodeIDBag) Poll {
+func (f *earlyTermNoTraversalFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
 	return &earlyTermNoTraversalPoll{
 		polled: vdrs,
 		alpha:  f.alpha,
@@ -30,8 +31,8 @@ func (f *earlyTermNoTraversalFactory) New(vdrs ids.NodeIDBag) Poll {
 // the result of the poll. However, does not terminate tightly with this bound.
 // It terminates as quickly as it can without performing any DAG traversals.
 type earlyTermNoTraversalPoll struct {
-	votes  ids.Bag
-	polled ids.NodeIDBag
+	votes  bag.Bag[ids.ID]
+	polled bag.Bag[ids.NodeID]
 	alpha  int
 }
 
@@ -61,7 +62,7 @@ func (p *earlyTermNoTraversalPoll) Finished() bool {
 }
 
 // Result returns the result of this poll
-func (p *earlyTermNoTraversalPoll) Result() ids.Bag { return p.votes }
+func (p *earlyTermNoTraversalPoll) Result() bag.Bag[ids.ID] { return p.votes }
 
 func (p *earlyTermNoTraversalPoll) PrefixedString(prefix string) string {
 	return fmt.Sprintf(
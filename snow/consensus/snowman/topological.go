@@ -13,6 +13,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/metrics"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 var (
@@ -55,16 +57,16 @@ type Topological struct {
 	blocks map[ids.ID]*snowmanBlock // blockID -> snowmanBlock
 
 	// preferredIDs stores the set of IDs that are currently preferred.
-	preferredIDs ids.Set
+	preferredIDs set.Set[ids.ID]
 
 	// tail is the preferred block with no children
 	tail ids.ID
 
 	// Used in [calculateInDegree] and.
 	// Should only be accessed in that method.
-	// We use this one instance of ids.Set instead of creating a
-	// new ids.Set during each call to [calculateInDegree].
-	leaves ids.Set
+	// We use this one instance of set.Set[ids.ID] instead of creating a
+	// new set.Set[ids.ID] during each call to [calculateInDegree].
+	leaves set.Set[ids.ID]
 
 	// Kahn nodes used in [calculateInDegree] and [markAncestorInDegrees].
 	// Should only be accessed in those methods.
@@ -79,7 +81,7 @@ type kahnNode struct {
 	// inDegree is 0, then this node is a leaf
 	inDegree int
 	// votes for all the children of this node, so far
-	votes ids.Bag
+	votes bag.Bag[ids.ID]
 }
 
 // Used to track which children should receive votes
@@ -87,7 +89,7 @@ type votes struct {
 	// parentID is the parent of all the votes provided in the votes bag
 	parentID ids.ID
 	// votes for all the children of the parent
-	votes ids.Bag
+	votes bag.Bag[ids.ID]
 }
 
 func (ts *Topological) Initialize(ctx *snow.ConsensusContext, params snowball.Parameters, rootID ids.ID, rootHeight uint64) error {
@@ -95,7 +97,7 @@ func (ts *Topological) Initialize(ctx *snow.ConsensusContext, params snowball.Pa
 		return err
 	}
 
-	latencyMetrics, err := metrics.NewLatency("blks", "block(s)", ctx.Log, "", ctx.Registerer)
+	latencyMetrics, err := metrics.NewLatency("blks", "block(s)", ctx.Log, "", ctx.LatencyBuckets, ctx.Registerer)
 	if err != nil {
 		return err
 	}
@@ -113,7 +115,7 @@ func (ts *Topological) Initialize(ctx *snow.ConsensusContext, params snowball.Pa
 	}
 	ts.Height = heightMetrics
 
-	ts.leaves = ids.Set{}
+	ts.leaves = set.Set[ids.ID]{}
 	ts.kahnNodes = make(map[ids.ID]kahnNode)
 	ts.ctx = ctx
 	ts.params = params
@@ -228,7 +230,7 @@ func (ts *Topological) Preference() ids.ID { return ts.tail }
 // The complexity of this function is:
 // - Runtime = 3 * |live set| + |votes|
 // - Space = 2 * |live set| + |votes|
-func (ts *Topological) RecordPoll(voteBag ids.Bag) error {
+func (ts *Topological) RecordPoll(voteBag bag.Bag[ids.ID]) error {
 	// Register a new poll call
 	ts.pollNumber++
 
@@ -317,7 +319,7 @@ func (ts *Topological) HealthCheck() (interface{}, error) {
 // takes in a list of votes and sets up the topological ordering. Returns the
 // reachable section of the graph annotated with the number of inbound edges and
 // the non-transitively applied votes. Also returns the list of leaf blocks.
-func (ts *Topological) calculateInDegree(votes ids.Bag) {
+func (ts *Topological) calculateInDegree(votes bag.Bag[ids.ID]) {
 	// Clear the Kahn node set
 	for k := range ts.kahnNodes {
 		delete(ts.kahnNodes, k)
@@ -8,6 +8,8 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/set"
 
 	sbcon "github.com/ava-labs/avalanchego/snow/consensus/snowball"
 )
@@ -41,21 +43,21 @@ type Consensus interface {
 
 	// Returns the set of virtuous transactions
 	// that have not yet been accepted or rejected
-	Virtuous() ids.Set
+	Virtuous() set.Set[ids.ID]
 
 	// Returns the currently preferred transactions to be finalized
-	Preferences() ids.Set
+	Preferences() set.Set[ids.ID]
 
 	// Return the current virtuous transactions that are being voted on.
-	VirtuousVoting() ids.Set
+	VirtuousVoting() set.Set[ids.ID]
 
 	// Returns the set of transactions conflicting with <Tx>
-	Conflicts(Tx) ids.Set
+	Conflicts(Tx) set.Set[ids.ID]
 
 	// Collects the results of a network poll. Assumes all transactions
 	// have been previously added. Returns true if any statuses or preferences
 	// changed. Returns if a critical error has occurred.
-	RecordPoll(ids.Bag) (bool, error)
+	RecordPoll(bag.Bag[ids.ID]) (bool, error)
 
 	// Returns true iff all remaining transactions are rogue. Note, it is
 	// possible that after returning quiesce, a new decision may be added such
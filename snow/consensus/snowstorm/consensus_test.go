@@ -19,6 +19,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 
 	sbcon "github.com/ava-labs/avalanchego/snow/consensus/snowball"
@@ -58,7 +60,7 @@ var (
 	Red, Green, Blue, Alpha *TestTx
 )
 
-//  R - G - B - A
+// R - G - B - A
 func Setup() {
 	Red = &TestTx{}
 	Green = &TestTx{}
@@ -277,7 +279,7 @@ func LeftoverInputTest(t *testing.T, factory Factory) {
 		t.Fatalf("Finalized too early")
 	}
 
-	r := ids.Bag{}
+	r := bag.Bag[ids.ID]{}
 	r.SetThreshold(2)
 	r.AddCount(Red.ID(), 2)
 	if updated, err := graph.RecordPoll(r); err != nil {
@@ -337,7 +339,7 @@ func LowerConfidenceTest(t *testing.T, factory Factory) {
 		t.Fatalf("Finalized too early")
 	}
 
-	r := ids.Bag{}
+	r := bag.Bag[ids.ID]{}
 	r.SetThreshold(2)
 	r.AddCount(Red.ID(), 2)
 	if updated, err := graph.RecordPoll(r); err != nil {
@@ -400,7 +402,7 @@ func MiddleConfidenceTest(t *testing.T, factory Factory) {
 		t.Fatalf("Finalized too early")
 	}
 
-	r := ids.Bag{}
+	r := bag.Bag[ids.ID]{}
 	r.SetThreshold(2)
 	r.AddCount(Red.ID(), 2)
 	if updated, err := graph.RecordPoll(r); err != nil {
@@ -457,7 +459,7 @@ func IndependentTest(t *testing.T, factory Factory) {
 		t.Fatalf("Finalized too early")
 	}
 
-	ra := ids.Bag{}
+	ra := bag.Bag[ids.ID]{}
 	ra.SetThreshold(2)
 	ra.AddCount(Red.ID(), 2)
 	ra.AddCount(Alpha.ID(), 2)
@@ -694,7 +696,7 @@ func AddNonEmptyWhitelistTest(t *testing.T, factory Factory) {
 		InputIDsV:     []ids.ID{ids.GenerateTestID()},
 		DependenciesV: []Tx{tx1, tx2, tx3, tx4},
 		HasWhitelistV: true,
-		WhitelistV: ids.Set{
+		WhitelistV: set.Set[ids.ID]{
 			tx1.IDV: struct{}{},
 			tx2.IDV: struct{}{},
 			tx3.IDV: struct{}{},
@@ -719,7 +721,7 @@ func AddNonEmptyWhitelistTest(t *testing.T, factory Factory) {
 		InputIDsV:     []ids.ID{ids.GenerateTestID()},
 		DependenciesV: []Tx{tx1, tx2, tx6},
 		HasWhitelistV: true,
-		WhitelistV: ids.Set{
+		WhitelistV: set.Set[ids.ID]{
 			tx1.IDV: struct{}{},
 			tx2.IDV: struct{}{},
 			tx6.IDV: struct{}{},
@@ -742,14 +744,14 @@ func AddNonEmptyWhitelistTest(t *testing.T, factory Factory) {
 	assert.Equal(t, 2., mss["whitelist_tx_processing"])
 
 	vset1 := graph.Virtuous()
-	if !vset1.Equals(ids.Set{
+	if !vset1.Equals(set.Set[ids.ID]{
 		tx1.IDV: struct{}{},
 		tx2.IDV: struct{}{},
 	}) {
 		t.Fatalf("unexpected virtuous %v", vset1)
 	}
 	pset1 := graph.Preferences()
-	if !pset1.Equals(ids.Set{
+	if !pset1.Equals(set.Set[ids.ID]{
 		tx1.IDV:  struct{}{},
 		tx2.IDV:  struct{}{},
 		tx3.IDV:  struct{}{},
@@ -762,7 +764,7 @@ func AddNonEmptyWhitelistTest(t *testing.T, factory Factory) {
 		t.Fatal("unexpected Finalized")
 	}
 
-	r := ids.Bag{}
+	r := bag.Bag[ids.ID]{}
 	r.SetThreshold(2)
 	r.AddCount(tx1.ID(), 2)
 
@@ -775,13 +777,13 @@ func AddNonEmptyWhitelistTest(t *testing.T, factory Factory) {
 	}
 
 	vset2 := graph.Virtuous()
-	if !vset2.Equals(ids.Set{
+	if !vset2.Equals(set.Set[ids.ID]{
 		tx2.IDV: struct{}{},
 	}) {
 		t.Fatalf("unexpected virtuous %v", vset2)
 	}
 	pset2 := graph.Preferences()
-	if !pset2.Equals(ids.Set{
+	if !pset2.Equals(set.Set[ids.ID]{
 		tx2.IDV:  struct{}{},
 		tx3.IDV:  struct{}{},
 		tx4.IDV:  struct{}{},
@@ -895,7 +897,7 @@ func WhitelistConflictsTest(t *testing.T, factory Factory) {
 	for i := range txIDs {
 		txIDs[i] = ids.GenerateTestID()
 	}
-	allTxIDs := ids.NewSet(n)
+	allTxIDs := set.NewSet[ids.ID](n)
 	allTxIDs.Add(txIDs...)
 
 	// each spending each other
@@ -917,7 +919,7 @@ func WhitelistConflictsTest(t *testing.T, factory Factory) {
 		}
 	}
 
-	whitelist := ids.NewSet(1)
+	whitelist := set.NewSet[ids.ID](1)
 	whitelist.Add(ids.GenerateTestID())
 
 	// make whitelist transaction that conflicts with tx outside of its
@@ -1007,7 +1009,7 @@ func AcceptingDependencyTest(t *testing.T, factory Factory) {
 		t.Fatalf("Wrong status. %s should be %s", purple.ID(), choices.Processing)
 	}
 
-	g := ids.Bag{}
+	g := bag.Bag[ids.ID]{}
 	g.Add(Green.ID())
 	if updated, err := graph.RecordPoll(g); err != nil {
 		t.Fatal(err)
@@ -1031,7 +1033,7 @@ func AcceptingDependencyTest(t *testing.T, factory Factory) {
 		t.Fatalf("Wrong status. %s should be %s", purple.ID(), choices.Processing)
 	}
 
-	rp := ids.Bag{}
+	rp := bag.Bag[ids.ID]{}
 	rp.Add(Red.ID(), purple.ID())
 	if updated, err := graph.RecordPoll(rp); err != nil {
 		t.Fatal(err)
@@ -1055,7 +1057,7 @@ func AcceptingDependencyTest(t *testing.T, factory Factory) {
 		t.Fatalf("Wrong status. %s should be %s", purple.ID(), choices.Processing)
 	}
 
-	r := ids.Bag{}
+	r := bag.Bag[ids.ID]{}
 	r.Add(Red.ID())
 	if updated, err := graph.RecordPoll(r); err != nil {
 		t.Fatal(err)
@@ -1149,7 +1151,7 @@ func AcceptingSlowDependencyTest(t *testing.T, factory Factory) {
 		t.Fatalf("Wrong status. %s should be %s", purple.ID(), choices.Processing)
 	}
 
-	g := ids.Bag{}
+	g := bag.Bag[ids.ID]{}
 	g.Add(Green.ID())
 	if updated, err := graph.RecordPoll(g); err != nil {
 		t.Fatal(err)
@@ -1173,7 +1175,7 @@ func AcceptingSlowDependencyTest(t *testing.T, factory Factory) {
 		t.Fatalf("Wrong status. %s should be %s", purple.ID(), choices.Processing)
 	}
 
-	p := ids.Bag{}
+	p := bag.Bag[ids.ID]{}
 	p.Add(purple.ID())
 	if updated, err := graph.RecordPoll(p); err != nil {
 		t.Fatal(err)
@@ -1197,7 +1199,7 @@ func AcceptingSlowDependencyTest(t *testing.T, factory Factory) {
 		t.Fatalf("Wrong status. %s should be %s", purple.ID(), choices.Processing)
 	}
 
-	rp := ids.Bag{}
+	rp := bag.Bag[ids.ID]{}
 	rp.Add(Red.ID(), purple.ID())
 	if updated, err := graph.RecordPoll(rp); err != nil {
 		t.Fatal(err)
@@ -1221,7 +1223,7 @@ func AcceptingSlowDependencyTest(t *testing.T, factory Factory) {
 		t.Fatalf("Wrong status. %s should be %s", purple.ID(), choices.Processing)
 	}
 
-	r := ids.Bag{}
+	r := bag.Bag[ids.ID]{}
 	r.Add(Red.ID())
 	if updated, err := graph.RecordPoll(r); err != nil {
 		t.Fatal(err)
@@ -1300,7 +1302,7 @@ func RejectingDependencyTest(t *testing.T, factory Factory) {
 		t.Fatalf("Wrong status. %s should be %s", purple.ID(), choices.Processing)
 	}
 
-	gp := ids.Bag{}
+	gp := bag.Bag[ids.ID]{}
 	gp.Add(Green.ID(), purple.ID())
 	if updated, err := graph.RecordPoll(gp); err != nil {
 		t.Fatal(err)
@@ -1480,7 +1482,7 @@ func VirtuousDependsOnRogueTest(t *testing.T, factory Factory) {
 		t.Fatal(err)
 	}
 
-	votes := ids.Bag{}
+	votes := bag.Bag[ids.ID]{}
 	votes.Add(rogue1.ID())
 	votes.Add(virtuous.ID())
 	if updated, err := graph.RecordPoll(votes); err != nil {
@@ -1556,7 +1558,7 @@ func ErrorOnAcceptedTest(t *testing.T, factory Factory) {
 		t.Fatal(err)
 	}
 
-	votes := ids.Bag{}
+	votes := bag.Bag[ids.ID]{}
 	votes.Add(purple.ID())
 	if _, err := graph.RecordPoll(votes); err == nil {
 		t.Fatalf("Should have errored on accepting an invalid tx")
@@ -1602,7 +1604,7 @@ func ErrorOnRejectingLowerConfidenceConflictTest(t *testing.T, factory Factory)
 		t.Fatal(err)
 	}
 
-	votes := ids.Bag{}
+	votes := bag.Bag[ids.ID]{}
 	votes.Add(purple.ID())
 	if _, err := graph.RecordPoll(votes); err == nil {
 		t.Fatalf("Should have errored on rejecting an invalid tx")
@@ -1648,7 +1650,7 @@ func ErrorOnRejectingHigherConfidenceConflictTest(t *testing.T, factory Factory)
 		t.Fatal(err)
 	}
 
-	votes := ids.Bag{}
+	votes := bag.Bag[ids.ID]{}
 	votes.Add(purple.ID())
 	if _, err := graph.RecordPoll(votes); err == nil {
 		t.Fatalf("Should have errored on rejecting an invalid tx")
@@ -1677,7 +1679,7 @@ func UTXOCleanupTest(t *testing.T, factory Factory) {
 	err = graph.Add(Green)
 	assert.NoError(t, err)
 
-	redVotes := ids.Bag{}
+	redVotes := bag.Bag[ids.ID]{}
 	redVotes.Add(Red.ID())
 	changed, err := graph.RecordPoll(redVotes)
 	assert.NoError(t, err)
@@ -1693,7 +1695,7 @@ func UTXOCleanupTest(t *testing.T, factory Factory) {
 	err = graph.Add(Blue)
 	assert.NoError(t, err)
 
-	blueVotes := ids.Bag{}
+	blueVotes := bag.Bag[ids.ID]{}
 	blueVotes.Add(Blue.ID())
 	changed, err = graph.RecordPoll(blueVotes)
 	assert.NoError(t, err)
@@ -1772,7 +1774,7 @@ func StringTest(t *testing.T, factory Factory, prefix string) {
 		t.Fatalf("Finalized too early")
 	}
 
-	rb := ids.Bag{}
+	rb := bag.Bag[ids.ID]{}
 	rb.SetThreshold(2)
 	rb.AddCount(Red.ID(), 2)
 	rb.AddCount(Blue.ID(), 2)
@@ -1808,7 +1810,7 @@ func StringTest(t *testing.T, factory Factory, prefix string) {
 		t.Fatalf("Finalized too early")
 	}
 
-	ga := ids.Bag{}
+	ga := bag.Bag[ids.ID]{}
 	ga.SetThreshold(2)
 	ga.AddCount(Green.ID(), 2)
 	ga.AddCount(Alpha.ID(), 2)
@@ -1842,7 +1844,7 @@ func StringTest(t *testing.T, factory Factory, prefix string) {
 		t.Fatalf("Finalized too early")
 	}
 
-	empty := ids.Bag{}
+	empty := bag.Bag[ids.ID]{}
 	if changed, err := graph.RecordPoll(empty); err != nil {
 		t.Fatal(err)
 	} else if changed {
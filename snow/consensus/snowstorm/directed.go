@@ -13,6 +13,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/metrics"
 	"github.com/ava-labs/avalanchego/snow/events"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 
 	sbcon "github.com/ava-labs/avalanchego/snow/consensus/snowball"
@@ -44,13 +46,13 @@ type Directed struct {
 	params sbcon.Parameters
 
 	// each element of preferences is the ID of a transaction that is preferred
-	preferences ids.Set
+	preferences set.Set[ids.ID]
 
 	// each element of virtuous is the ID of a transaction that is virtuous
-	virtuous ids.Set
+	virtuous set.Set[ids.ID]
 
 	// each element is in the virtuous set and is still being voted on
-	virtuousVoting ids.Set
+	virtuousVoting set.Set[ids.ID]
 
 	// number of times RecordPoll has been called
 	pollNumber uint64
@@ -70,10 +72,10 @@ type Directed struct {
 
 	// Key: UTXO ID
 	// Value: IDs of transactions that consume the UTXO specified in the key
-	utxos map[ids.ID]ids.Set
+	utxos map[ids.ID]set.Set[ids.ID]
 
 	// map transaction ID to the set of whitelisted transaction IDs.
-	whitelists map[ids.ID]ids.Set
+	whitelists map[ids.ID]set.Set[ids.ID]
 }
 
 type directedTx struct {
@@ -85,11 +87,11 @@ type directedTx struct {
 
 	// ins is the set of txIDs that this tx conflicts with that are less
 	// preferred than this tx
-	ins ids.Set
+	ins set.Set[ids.ID]
 
 	// outs is the set of txIDs that this tx conflicts with that are more
 	// preferred than this tx
-	outs ids.Set
+	outs set.Set[ids.ID]
 
 	// tx is the actual transaction this node represents
 	tx Tx
@@ -108,12 +110,12 @@ func (dg *Directed) Initialize(
 		return fmt.Errorf("failed to create poll metrics: %w", err)
 	}
 
-	dg.Latency, err = metrics.NewLatency("txs", "transaction(s)", ctx.Log, "", ctx.Registerer)
+	dg.Latency, err = metrics.NewLatency("txs", "transaction(s)", ctx.Log, "", ctx.LatencyBuckets, ctx.Registerer)
 	if err != nil {
 		return fmt.Errorf("failed to create latency metrics: %w", err)
 	}
 
-	dg.whitelistTxLatency, err = metrics.NewLatency("whitelist_tx", "whitelist transaction(s)", ctx.Log, "", ctx.Registerer)
+	dg.whitelistTxLatency, err = metrics.NewLatency("whitelist_tx", "whitelist transaction(s)", ctx.Log, "", ctx.LatencyBuckets, ctx.Registerer)
 	if err != nil {
 		return fmt.Errorf("failed to create whitelist tx metrics: %w", err)
 	}
@@ -137,19 +139,19 @@ func (dg *Directed) Initialize(
 	}
 
 	dg.txs = make(map[ids.ID]*directedTx)
-	dg.utxos = make(map[ids.ID]ids.Set)
-	dg.whitelists = make(map[ids.ID]ids.Set)
+	dg.utxos = make(map[ids.ID]set.Set[ids.ID])
+	dg.whitelists = make(map[ids.ID]set.Set[ids.ID])
 
 	return params.Verify()
 }
 
 func (dg *Directed) Parameters() sbcon.Parameters { return dg.params }
 
-func (dg *Directed) Virtuous() ids.Set { return dg.virtuous }
+func (dg *Directed) Virtuous() set.Set[ids.ID] { return dg.virtuous }
 
-func (dg *Directed) Preferences() ids.Set { return dg.preferences }
+func (dg *Directed) Preferences() set.Set[ids.ID] { return dg.preferences }
 
-func (dg *Directed) VirtuousVoting() ids.Set { return dg.virtuousVoting }
+func (dg *Directed) VirtuousVoting() set.Set[ids.ID] { return dg.virtuousVoting }
 
 func (dg *Directed) Quiesce() bool {
 	numVirtuous := dg.virtuousVoting.Len()
@@ -248,8 +250,8 @@ func (dg *Directed) IsVirtuous(tx Tx) bool {
 	return true
 }
 
-func (dg *Directed) Conflicts(tx Tx) ids.Set {
-	var conflicts ids.Set
+func (dg *Directed) Conflicts(tx Tx) set.Set[ids.ID] {
+	var conflicts set.Set[ids.ID]
 	if node, exists := dg.txs[tx.ID()]; exists {
 		// If the tx is currently processing, the conflicting txs are just the
 		// union of the inbound conflicts and the outbound conflicts.
@@ -395,7 +397,7 @@ func (dg *Directed) addEdge(src, dst *directedTx) {
 }
 
 func (dg *Directed) Remove(txID ids.ID) error {
-	err := dg.reject(ids.Set{
+	err := dg.reject(set.Set[ids.ID]{
 		txID: struct{}{},
 	})
 
@@ -417,7 +419,7 @@ func (dg *Directed) Issued(tx Tx) bool {
 	return ok
 }
 
-func (dg *Directed) RecordPoll(votes ids.Bag) (bool, error) {
+func (dg *Directed) RecordPoll(votes bag.Bag[ids.ID]) (bool, error) {
 	// Increase the vote ID. This is only updated here and is used to reset the
 	// confidence values of transactions lazily.
 	// This is also used to track the number of polls required to accept/reject
@@ -528,7 +530,7 @@ func (dg *Directed) accept(txID ids.ID) error {
 }
 
 // reject all the named txIDs and remove them from the graph
-func (dg *Directed) reject(conflictIDs ids.Set) error {
+func (dg *Directed) reject(conflictIDs set.Set[ids.ID]) error {
 	for conflictKey := range conflictIDs {
 		conflict := dg.txs[conflictKey]
 		// This tx is no longer an option for consuming the UTXOs from its
@@ -618,7 +620,7 @@ func (dg *Directed) redirectEdge(txNode *directedTx, conflictID ids.ID) bool {
 	return true
 }
 
-func (dg *Directed) removeConflict(txIDKey ids.ID, neighborIDs ids.Set) {
+func (dg *Directed) removeConflict(txIDKey ids.ID, neighborIDs set.Set[ids.ID]) {
 	for neighborID := range neighborIDs {
 		neighbor, exists := dg.txs[neighborID]
 		if !exists {
@@ -68,6 +68,11 @@ type ConsensusContext struct {
 
 	Registerer Registerer
 
+	// LatencyBuckets are the bucket boundaries, in nanoseconds, of the
+	// consensus latency histograms. If empty, only the average latency is
+	// reported.
+	LatencyBuckets []float64
+
 	// DecisionAcceptor is the callback that will be fired whenever a VM is
 	// notified that their object, either a block in snowman or a transaction
 	// in avalanche, was accepted.
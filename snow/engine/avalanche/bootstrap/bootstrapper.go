@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
 )
 
@@ -100,7 +101,7 @@ type bootstrapper struct {
 
 	// IDs of vertices that we will send a GetAncestors request for once we are
 	// not at the max number of outstanding requests
-	needToFetch ids.Set
+	needToFetch set.Set[ids.ID]
 
 	// Contains IDs of vertices that have recently been processed
 	processedCache *cache.LRU
@@ -172,7 +173,7 @@ func (b *bootstrapper) Ancestors(vdr ids.NodeID, requestID uint32, vtxs [][]byte
 	if err != nil {
 		return err
 	}
-	eligibleVertices := ids.NewSet(len(parents))
+	eligibleVertices := set.NewSet[ids.ID](len(parents))
 	for _, parent := range parents {
 		eligibleVertices.Add(parent.ID())
 	}
@@ -337,7 +338,7 @@ func (b *bootstrapper) process(vtxs ...avalanche.Vertex) error {
 		}
 	}
 
-	vtxHeightSet := ids.Set{}
+	vtxHeightSet := set.Set[ids.ID]{}
 	prevHeight := uint64(0)
 
 	for toProcess.Len() > 0 { // While there are unprocessed vertices
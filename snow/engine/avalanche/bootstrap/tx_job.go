@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 var errMissingTxDependenciesOnAccept = errors.New("attempting to accept a transaction with missing dependencies")
@@ -45,8 +46,8 @@ type txJob struct {
 }
 
 func (t *txJob) ID() ids.ID { return t.tx.ID() }
-func (t *txJob) MissingDependencies() (ids.Set, error) {
-	missing := ids.Set{}
+func (t *txJob) MissingDependencies() (set.Set[ids.ID], error) {
+	missing := set.Set[ids.ID]{}
 	deps, err := t.tx.Dependencies()
 	if err != nil {
 		return missing, err
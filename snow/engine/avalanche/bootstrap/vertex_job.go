@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 var errMissingVtxDependenciesOnAccept = errors.New("attempting to execute blocked vertex")
@@ -46,8 +47,8 @@ type vertexJob struct {
 
 func (v *vertexJob) ID() ids.ID { return v.vtx.ID() }
 
-func (v *vertexJob) MissingDependencies() (ids.Set, error) {
-	missing := ids.Set{}
+func (v *vertexJob) MissingDependencies() (set.Set[ids.ID], error) {
+	missing := set.Set[ids.ID]{}
 	parents, err := v.vtx.Parents()
 	if err != nil {
 		return missing, err
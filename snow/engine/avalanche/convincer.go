@@ -7,6 +7,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
@@ -18,11 +19,11 @@ type convincer struct {
 	requestID uint32
 	sent      bool
 	abandoned bool
-	deps      ids.Set
+	deps      set.Set[ids.ID]
 	errs      *wrappers.Errs
 }
 
-func (c *convincer) Dependencies() ids.Set { return c.deps }
+func (c *convincer) Dependencies() set.Set[ids.ID] { return c.deps }
 
 // Mark that a dependency has been met.
 func (c *convincer) Fulfill(id ids.ID) {
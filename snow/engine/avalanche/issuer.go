@@ -8,6 +8,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 // issuer issues [vtx] into consensus after its dependencies are met.
@@ -15,7 +17,7 @@ type issuer struct {
 	t                 *Transitive
 	vtx               avalanche.Vertex
 	issued, abandoned bool
-	vtxDeps, txDeps   ids.Set
+	vtxDeps, txDeps   set.Set[ids.ID]
 }
 
 // Register that a vertex we were waiting on has been issued to consensus.
@@ -114,7 +116,7 @@ func (i *issuer) Update() {
 		i.t.Ctx.Log.Error("Query for %s was dropped due to an insufficient number of validators", vtxID)
 	}
 
-	vdrBag := ids.NodeIDBag{} // Validators to sample repr. as a set
+	vdrBag := bag.Bag[ids.NodeID]{} // Validators to sample repr. as a set
 	for _, vdr := range vdrs {
 		vdrBag.Add(vdr.ID())
 	}
@@ -154,14 +156,14 @@ func (i *issuer) Update() {
 
 type vtxIssuer struct{ i *issuer }
 
-func (vi *vtxIssuer) Dependencies() ids.Set { return vi.i.vtxDeps }
-func (vi *vtxIssuer) Fulfill(id ids.ID)     { vi.i.FulfillVtx(id) }
-func (vi *vtxIssuer) Abandon(ids.ID)        { vi.i.Abandon() }
-func (vi *vtxIssuer) Update()               { vi.i.Update() }
+func (vi *vtxIssuer) Dependencies() set.Set[ids.ID] { return vi.i.vtxDeps }
+func (vi *vtxIssuer) Fulfill(id ids.ID)             { vi.i.FulfillVtx(id) }
+func (vi *vtxIssuer) Abandon(ids.ID)                { vi.i.Abandon() }
+func (vi *vtxIssuer) Update()                       { vi.i.Update() }
 
 type txIssuer struct{ i *issuer }
 
-func (ti *txIssuer) Dependencies() ids.Set { return ti.i.txDeps }
-func (ti *txIssuer) Fulfill(id ids.ID)     { ti.i.FulfillTx(id) }
-func (ti *txIssuer) Abandon(ids.ID)        { ti.i.Abandon() }
-func (ti *txIssuer) Update()               { ti.i.Update() }
+func (ti *txIssuer) Dependencies() set.Set[ids.ID] { return ti.i.txDeps }
+func (ti *txIssuer) Fulfill(id ids.ID)             { ti.i.FulfillTx(id) }
+func (ti *txIssuer) Abandon(ids.ID)                { ti.i.Abandon() }
+func (ti *txIssuer) Update()                       { ti.i.Update() }
@@ -15,8 +15,10 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/events"
+	"github.com/ava-labs/avalanchego/utils/bag"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/sampler"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
 )
@@ -48,11 +50,11 @@ type Transitive struct {
 	outstandingVtxReqs common.Requests
 
 	// missingTxs tracks transaction that are missing
-	missingTxs ids.Set
+	missingTxs set.Set[ids.ID]
 
 	// IDs of vertices that are queued to be added to consensus but haven't yet been
 	// because of missing dependencies
-	pending ids.Set
+	pending set.Set[ids.ID]
 
 	// vtxBlocked tracks operations that are blocked on vertices
 	// txBlocked tracks operations that are blocked on transactions
@@ -455,7 +457,7 @@ func (t *Transitive) issue(vtx avalanche.Vertex) error {
 	if err != nil {
 		return err
 	}
-	txIDs := ids.NewSet(len(txs))
+	txIDs := set.NewSet[ids.ID](len(txs))
 	for _, tx := range txs {
 		txIDs.Add(tx.ID())
 	}
@@ -516,14 +518,14 @@ func (t *Transitive) batch(txs []snowstorm.Tx, opt batchOption) ([]snowstorm.Tx,
 	if opt.limit && t.Params.OptimalProcessing <= t.Consensus.NumProcessing() {
 		return txs, nil
 	}
-	issuedTxs := ids.Set{}
-	consumed := ids.Set{}
+	issuedTxs := set.Set[ids.ID]{}
+	consumed := set.Set[ids.ID]{}
 	orphans := t.Consensus.Orphans()
 	start := 0
 	end := 0
 	for end < len(txs) {
 		tx := txs[end]
-		inputs := ids.Set{}
+		inputs := set.Set[ids.ID]{}
 		inputs.Add(tx.InputIDs()...)
 		overlaps := consumed.Overlaps(inputs)
 		if end-start >= t.Params.BatchSize || (opt.force && overlaps) {
@@ -574,13 +576,13 @@ func (t *Transitive) issueRepoll() {
 		return
 	}
 
-	vdrBag := ids.NodeIDBag{} // IDs of validators to be sampled
+	vdrBag := bag.Bag[ids.NodeID]{} // IDs of validators to be sampled
 	for _, vdr := range vdrs {
 		vdrBag.Add(vdr.ID())
 	}
 
 	vdrList := vdrBag.List()
-	vdrSet := ids.NewNodeIDSet(len(vdrList))
+	vdrSet := set.NewSet[ids.NodeID](len(vdrList))
 	vdrSet.Add(vdrList...)
 
 	// Poll the network
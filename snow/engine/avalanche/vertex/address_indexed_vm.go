@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vertex
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var ErrAddressIndexedVMNotImplemented = errors.New("vm does not implement AddressIndexedDAGVM interface")
+
+// AddressIndexedDAGVM extends DAGVM to allow the node to index accepted
+// transactions by the addresses they reference.
+type AddressIndexedDAGVM interface {
+	// TxAddresses returns the addresses referenced by the transaction with
+	// bytes [txBytes]. It is called before the transaction is committed as
+	// accepted, so any state the transaction consumes is still available.
+	//
+	// Should return ErrAddressIndexedVMNotImplemented if the VM doesn't
+	// support address indexing.
+	TxAddresses(txBytes []byte) ([]ids.ShortID, error)
+}
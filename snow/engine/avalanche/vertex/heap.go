@@ -4,26 +4,18 @@
 package vertex
 
 import (
-	"container/heap"
-
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+	"github.com/ava-labs/avalanchego/utils/heap"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
-var (
-	_ Heap           = &maxHeightVertexHeap{}
-	_ heap.Interface = &priorityQueue{}
-)
-
-type priorityQueue []avalanche.Vertex
+var _ Heap = &maxHeightVertexHeap{}
 
-func (pq priorityQueue) Len() int { return len(pq) }
-
-// Returns true if the vertex at index i has greater height than the vertex at
-// index j.
-func (pq priorityQueue) Less(i, j int) bool {
-	statusI := pq[i].Status()
-	statusJ := pq[j].Status()
+// Returns true if the vertex [i] has greater height than the vertex [j].
+func lessVertex(i, j avalanche.Vertex) bool {
+	statusI := i.Status()
+	statusJ := j.Status()
 
 	// Put unknown vertices at the front of the heap to ensure once we have made
 	// it below a certain height in DAG traversal we do not need to reset
@@ -35,37 +27,17 @@ func (pq priorityQueue) Less(i, j int) bool {
 	}
 
 	// Treat errors on retrieving the height as if the vertex is not fetched
-	heightI, errI := pq[i].Height()
+	heightI, errI := i.Height()
 	if errI != nil {
 		return true
 	}
-	heightJ, errJ := pq[j].Height()
+	heightJ, errJ := j.Height()
 	if errJ != nil {
 		return false
 	}
 	return heightI > heightJ
 }
 
-func (pq priorityQueue) Swap(i, j int) {
-	pq[i], pq[j] = pq[j], pq[i]
-}
-
-// Push adds an item to this priority queue. x must have type *vertexItem
-func (pq *priorityQueue) Push(x interface{}) {
-	item := x.(avalanche.Vertex)
-	*pq = append(*pq, item)
-}
-
-// Pop returns the last item in this priorityQueue
-func (pq *priorityQueue) Pop() interface{} {
-	old := *pq
-	n := len(old)
-	item := old[n-1]
-	old[n-1] = nil
-	*pq = old[0 : n-1]
-	return item
-}
-
 // Heap defines the functionality of a heap of vertices with unique VertexIDs
 // ordered by height
 type Heap interface {
@@ -87,15 +59,19 @@ type Heap interface {
 }
 
 // NewHeap returns an empty Heap
-func NewHeap() Heap { return &maxHeightVertexHeap{} }
+func NewHeap() Heap {
+	return &maxHeightVertexHeap{
+		heap: heap.New(lessVertex),
+	}
+}
 
 type maxHeightVertexHeap struct {
-	heap       priorityQueue
-	elementIDs ids.Set
+	heap       heap.Heap[avalanche.Vertex]
+	elementIDs set.Set[ids.ID]
 }
 
 func (vh *maxHeightVertexHeap) Clear() {
-	vh.heap = priorityQueue{}
+	vh.heap.Clear()
 	vh.elementIDs.Clear()
 }
 
@@ -108,7 +84,7 @@ func (vh *maxHeightVertexHeap) Push(vtx avalanche.Vertex) bool {
 	}
 
 	vh.elementIDs.Add(vtxID)
-	heap.Push(&vh.heap, vtx)
+	vh.heap.Push(vtx)
 	return true
 }
 
@@ -116,7 +92,7 @@ func (vh *maxHeightVertexHeap) Push(vtx avalanche.Vertex) bool {
 // vertex and returns it. Otherwise, removes and returns the vertex in this heap
 // with the greatest height.
 func (vh *maxHeightVertexHeap) Pop() avalanche.Vertex {
-	vtx := heap.Pop(&vh.heap).(avalanche.Vertex)
+	vtx := vh.heap.Pop()
 	vh.elementIDs.Remove(vtx.ID())
 	return vtx
 }
@@ -11,18 +11,19 @@ import (
 	"github.com/ava-labs/avalanchego/utils/hashing"
 )
 
-type sortHashOfData [][]byte
-
-func (d sortHashOfData) Less(i, j int) bool {
+func lessHashOf(a, b []byte) bool {
 	return bytes.Compare(
-		hashing.ComputeHash256(d[i]),
-		hashing.ComputeHash256(d[j]),
+		hashing.ComputeHash256(a),
+		hashing.ComputeHash256(b),
 	) == -1
 }
-func (d sortHashOfData) Len() int      { return len(d) }
-func (d sortHashOfData) Swap(i, j int) { d[j], d[i] = d[i], d[j] }
 
-func SortHashOf(bytesSlice [][]byte) { sort.Sort(sortHashOfData(bytesSlice)) }
+func SortHashOf(bytesSlice [][]byte) {
+	sort.Slice(bytesSlice, func(i, j int) bool {
+		return lessHashOf(bytesSlice[i], bytesSlice[j])
+	})
+}
+
 func IsSortedAndUniqueHashOf(bytesSlice [][]byte) bool {
-	return utils.IsSortedAndUnique(sortHashOfData(bytesSlice))
+	return utils.IsSortedAndUniqueFunc(bytesSlice, lessHashOf)
 }
@@ -11,6 +11,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 func TestVotingFinishesWithAbandonedDep(t *testing.T) {
@@ -26,7 +28,7 @@ func TestVotingFinishesWithAbandonedDep(t *testing.T) {
 	vdr2 := ids.NodeID{2}
 	vdr3 := ids.NodeID{3}
 
-	vdrs := ids.NodeIDBag{}
+	vdrs := bag.Bag[ids.NodeID]{}
 	vdrs.Add(
 		vdr1,
 		vdr2,
@@ -35,7 +37,7 @@ func TestVotingFinishesWithAbandonedDep(t *testing.T) {
 	// add poll for request 1
 	transitive.polls.Add(1, vdrs)
 
-	vdrs = ids.NodeIDBag{}
+	vdrs = bag.Bag[ids.NodeID]{}
 	vdrs.Add(
 		vdr1,
 		vdr3,
@@ -55,7 +57,7 @@ func TestVotingFinishesWithAbandonedDep(t *testing.T) {
 		t:         transitive,
 		requestID: 2,
 		response:  []ids.ID{vote2},
-		deps:      ids.NewSet(0),
+		deps:      set.NewSet[ids.ID](0),
 		vdr:       vdr1,
 	}
 
@@ -63,7 +65,7 @@ func TestVotingFinishesWithAbandonedDep(t *testing.T) {
 		t:         transitive,
 		requestID: 2,
 		response:  []ids.ID{vote2},
-		deps:      ids.NewSet(0),
+		deps:      set.NewSet[ids.ID](0),
 		vdr:       vdr3,
 	}
 
@@ -76,7 +78,7 @@ func TestVotingFinishesWithAbandonedDep(t *testing.T) {
 	// vote on request 1
 	// add dependency to voter1's vote which has to be fulfilled prior to finishing
 	voter1Dep := ids.GenerateTestID()
-	voter1DepSet := ids.NewSet(1)
+	voter1DepSet := set.NewSet[ids.ID](1)
 	voter1DepSet.Add(voter1Dep)
 
 	voter1 = &voter{
@@ -91,7 +93,7 @@ func TestVotingFinishesWithAbandonedDep(t *testing.T) {
 		t:         transitive,
 		requestID: 1,
 		response:  []ids.ID{vote1},
-		deps:      ids.NewSet(0),
+		deps:      set.NewSet[ids.ID](0),
 		vdr:       vdr2,
 	}
 
@@ -117,7 +119,7 @@ func TestVotingFinishesWithAbandonDepMiddleRequest(t *testing.T) {
 	vdr2 := ids.NodeID{2}
 	vdr3 := ids.NodeID{3}
 
-	vdrs := ids.NodeIDBag{}
+	vdrs := bag.Bag[ids.NodeID]{}
 	vdrs.Add(
 		vdr1,
 		vdr2,
@@ -126,7 +128,7 @@ func TestVotingFinishesWithAbandonDepMiddleRequest(t *testing.T) {
 	// add poll for request 1
 	transitive.polls.Add(1, vdrs)
 
-	vdrs = ids.NodeIDBag{}
+	vdrs = bag.Bag[ids.NodeID]{}
 	vdrs.Add(
 		vdr1,
 		vdr3,
@@ -135,7 +137,7 @@ func TestVotingFinishesWithAbandonDepMiddleRequest(t *testing.T) {
 	// add poll for request 2
 	transitive.polls.Add(2, vdrs)
 
-	vdrs = ids.NodeIDBag{}
+	vdrs = bag.Bag[ids.NodeID]{}
 	vdrs.Add(
 		vdr2,
 		vdr3,
@@ -156,7 +158,7 @@ func TestVotingFinishesWithAbandonDepMiddleRequest(t *testing.T) {
 		t:         transitive,
 		requestID: 3,
 		response:  []ids.ID{vote3},
-		deps:      ids.NewSet(0),
+		deps:      set.NewSet[ids.ID](0),
 		vdr:       vdr3,
 	}
 
@@ -164,7 +166,7 @@ func TestVotingFinishesWithAbandonDepMiddleRequest(t *testing.T) {
 		t:         transitive,
 		requestID: 3,
 		response:  []ids.ID{vote3},
-		deps:      ids.NewSet(0),
+		deps:      set.NewSet[ids.ID](0),
 		vdr:       vdr2,
 	}
 
@@ -177,14 +179,14 @@ func TestVotingFinishesWithAbandonDepMiddleRequest(t *testing.T) {
 	// vote on request 2
 	// add dependency to req2/voter3's vote which has to be fulfilled prior to finishing
 	req2Voter2Dep := ids.GenerateTestID()
-	req2Voter2DepSet := ids.NewSet(1)
+	req2Voter2DepSet := set.NewSet[ids.ID](1)
 	req2Voter2DepSet.Add(req2Voter2Dep)
 
 	req2Voter1 := &voter{
 		t:         transitive,
 		requestID: 2,
 		response:  []ids.ID{vote2},
-		deps:      ids.NewSet(0),
+		deps:      set.NewSet[ids.ID](0),
 		vdr:       vdr1,
 	}
 
@@ -205,7 +207,7 @@ func TestVotingFinishesWithAbandonDepMiddleRequest(t *testing.T) {
 	// vote on request 1
 	// add dependency to voter1's vote which has to be fulfilled prior to finishing
 	req1Voter1Dep := ids.GenerateTestID()
-	req1Voter1DepSet := ids.NewSet(1)
+	req1Voter1DepSet := set.NewSet[ids.ID](1)
 	req1Voter1DepSet.Add(req1Voter1Dep)
 	req1Voter1 := &voter{
 		t:         transitive,
@@ -219,7 +221,7 @@ func TestVotingFinishesWithAbandonDepMiddleRequest(t *testing.T) {
 		t:         transitive,
 		requestID: 1,
 		response:  []ids.ID{vote1},
-		deps:      ids.NewSet(0),
+		deps:      set.NewSet[ids.ID](0),
 		vdr:       vdr2,
 	}
 
@@ -251,7 +253,7 @@ func TestSharedDependency(t *testing.T) {
 	vdr2 := ids.NodeID{2}
 	vdr3 := ids.NodeID{3}
 
-	vdrs := ids.NodeIDBag{}
+	vdrs := bag.Bag[ids.NodeID]{}
 	vdrs.Add(
 		vdr1,
 		vdr2,
@@ -260,7 +262,7 @@ func TestSharedDependency(t *testing.T) {
 	// add poll for request 1
 	transitive.polls.Add(1, vdrs)
 
-	vdrs = ids.NodeIDBag{}
+	vdrs = bag.Bag[ids.NodeID]{}
 	vdrs.Add(
 		vdr1,
 		vdr3,
@@ -269,7 +271,7 @@ func TestSharedDependency(t *testing.T) {
 	// add poll for request 2
 	transitive.polls.Add(2, vdrs)
 
-	vdrs = ids.NodeIDBag{}
+	vdrs = bag.Bag[ids.NodeID]{}
 	vdrs.Add(
 		vdr2,
 		vdr3,
@@ -291,7 +293,7 @@ func TestSharedDependency(t *testing.T) {
 		t:         transitive,
 		requestID: 3,
 		response:  []ids.ID{vote3},
-		deps:      ids.NewSet(0),
+		deps:      set.NewSet[ids.ID](0),
 		vdr:       vdr3,
 	}
 
@@ -301,7 +303,7 @@ func TestSharedDependency(t *testing.T) {
 		t:         transitive,
 		requestID: 3,
 		response:  []ids.ID{vote3},
-		deps:      ids.NewSet(0),
+		deps:      set.NewSet[ids.ID](0),
 		vdr:       vdr2,
 	}
 
@@ -312,7 +314,7 @@ func TestSharedDependency(t *testing.T) {
 
 	// setup common dependency
 	dep := ids.GenerateTestID()
-	depSet := ids.NewSet(1)
+	depSet := set.NewSet[ids.ID](1)
 	depSet.Add(dep)
 
 	req2Voter1 := &voter{
@@ -330,7 +332,7 @@ func TestSharedDependency(t *testing.T) {
 		t:         transitive,
 		requestID: 2,
 		response:  []ids.ID{vote2},
-		deps:      ids.NewSet(0),
+		deps:      set.NewSet[ids.ID](0),
 		vdr:       vdr3,
 	}
 
@@ -354,7 +356,7 @@ func TestSharedDependency(t *testing.T) {
 		t:         transitive,
 		requestID: 1,
 		response:  []ids.ID{vote1},
-		deps:      ids.NewSet(0),
+		deps:      set.NewSet[ids.ID](0),
 		vdr:       vdr2,
 	}
 
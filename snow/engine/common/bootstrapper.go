@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 const (
@@ -43,23 +44,23 @@ type bootstrapper struct {
 	// Holds the beacons that were sampled for the accepted frontier
 	sampledBeacons validators.Set
 	// IDs of validators we should request an accepted frontier from
-	pendingSendAcceptedFrontier ids.NodeIDSet
+	pendingSendAcceptedFrontier set.Set[ids.NodeID]
 	// IDs of validators we requested an accepted frontier from but haven't
 	// received a reply yet
-	pendingReceiveAcceptedFrontier ids.NodeIDSet
+	pendingReceiveAcceptedFrontier set.Set[ids.NodeID]
 	// IDs of validators that failed to respond with their accepted frontier
-	failedAcceptedFrontier ids.NodeIDSet
+	failedAcceptedFrontier set.Set[ids.NodeID]
 	// IDs of all the returned accepted frontiers
-	acceptedFrontierSet ids.Set
+	acceptedFrontierSet set.Set[ids.ID]
 
 	// IDs of validators we should request filtering the accepted frontier from
-	pendingSendAccepted ids.NodeIDSet
+	pendingSendAccepted set.Set[ids.NodeID]
 	// IDs of validators we requested filtering the accepted frontier from but
 	// haven't received a reply yet
-	pendingReceiveAccepted ids.NodeIDSet
+	pendingReceiveAccepted set.Set[ids.NodeID]
 	// IDs of validators that failed to respond with their filtered accepted
 	// frontier
-	failedAccepted ids.NodeIDSet
+	failedAccepted set.Set[ids.NodeID]
 	// IDs of the returned accepted containers and the stake weight that has
 	// marked them as accepted
 	acceptedVotes    map[ids.ID]uint64
@@ -179,7 +180,7 @@ func (b *bootstrapper) Accepted(validatorID ids.NodeID, requestID uint32, contai
 
 	for _, containerID := range containerIDs {
 		previousWeight := b.acceptedVotes[containerID]
-		newWeight, err := math.Add64(weight, previousWeight)
+		newWeight, err := math.Add(weight, previousWeight)
 		if err != nil {
 			b.Ctx.Log.Error("Error calculating the Accepted votes - weight: %v, previousWeight: %v", weight, previousWeight)
 			newWeight = stdmath.MaxUint64
@@ -309,7 +310,7 @@ func (b *bootstrapper) Restart(reset bool) error {
 // Ask up to [MaxOutstandingBroadcastRequests] bootstrap validators to send
 // their accepted frontier with the current accepted frontier
 func (b *bootstrapper) sendGetAcceptedFrontiers() {
-	vdrs := ids.NewNodeIDSet(1)
+	vdrs := set.NewSet[ids.NodeID](1)
 	for b.pendingSendAcceptedFrontier.Len() > 0 && b.pendingReceiveAcceptedFrontier.Len() < MaxOutstandingBroadcastRequests {
 		vdr, _ := b.pendingSendAcceptedFrontier.Pop()
 		// Add the validator to the set to send the messages to
@@ -326,7 +327,7 @@ func (b *bootstrapper) sendGetAcceptedFrontiers() {
 // Ask up to [MaxOutstandingBroadcastRequests] bootstrap validators to send
 // their filtered accepted frontier
 func (b *bootstrapper) sendGetAccepted() {
-	vdrs := ids.NewNodeIDSet(1)
+	vdrs := set.NewSet[ids.NodeID](1)
 	for b.pendingSendAccepted.Len() > 0 && b.pendingReceiveAccepted.Len() < MaxOutstandingBroadcastRequests {
 		vdr, _ := b.pendingSendAccepted.Pop()
 		// Add the validator to the set to send the messages to
@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"errors"
+)
+
+var ErrConfigCheckerNotImplemented = errors.New("vm does not implement ConfigChecker interface")
+
+// ConfigChecker is an optional interface that a VM can implement to check the
+// genesis and the config of a chain before the chain is created.
+type ConfigChecker interface {
+	// CheckConfig returns an error if a chain of this VM couldn't be
+	// initialized with [genesisBytes] and [configBytes], which have the same
+	// format as the arguments passed to Initialize.
+	//
+	// CheckConfig is called on a VM that hasn't been initialized, and the VM
+	// isn't initialized afterwards.
+	CheckConfig(genesisBytes []byte, configBytes []byte) error
+}
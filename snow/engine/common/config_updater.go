@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"errors"
+)
+
+var ErrConfigUpdaterNotImplemented = errors.New("vm does not implement ConfigUpdater interface")
+
+// ConfigUpdater is an optional interface that a VM can implement to apply a
+// new chain config without restarting its chain.
+type ConfigUpdater interface {
+	// UpdateConfig is called with the new user-provided config of the chain.
+	// The config has the same format as the [configBytes] passed to
+	// Initialize. If an error is returned, the VM must keep using its previous
+	// config.
+	//
+	// The context lock is held while UpdateConfig is called.
+	UpdateConfig(configBytes []byte) error
+}
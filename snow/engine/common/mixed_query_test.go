@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/golang/mock/gomock"
 )
 
@@ -29,7 +30,7 @@ func TestSendMixedQuery(t *testing.T) {
 			senderF: func() *MockSender {
 				s := NewMockSender(ctrl)
 				s.EXPECT().SendPushQuery(
-					ids.NodeIDSet{vdr1: struct{}{}, vdr2: struct{}{}, vdr3: struct{}{}},
+					set.Set[ids.NodeID]{vdr1: struct{}{}, vdr2: struct{}{}, vdr3: struct{}{}},
 					reqID,
 					containerID,
 					containerBytes,
@@ -48,13 +49,13 @@ func TestSendMixedQuery(t *testing.T) {
 			senderF: func() *MockSender {
 				s := NewMockSender(ctrl)
 				s.EXPECT().SendPushQuery(
-					ids.NodeIDSet{vdr1: struct{}{}},
+					set.Set[ids.NodeID]{vdr1: struct{}{}},
 					reqID,
 					containerID,
 					containerBytes,
 				).Times(1)
 				s.EXPECT().SendPullQuery(
-					ids.NodeIDSet{vdr2: struct{}{}, vdr3: struct{}{}},
+					set.Set[ids.NodeID]{vdr2: struct{}{}, vdr3: struct{}{}},
 					reqID,
 					containerID,
 				).Times(1)
@@ -67,7 +68,7 @@ func TestSendMixedQuery(t *testing.T) {
 			senderF: func() *MockSender {
 				s := NewMockSender(ctrl)
 				s.EXPECT().SendPushQuery(
-					ids.NodeIDSet{vdr1: struct{}{}, vdr2: struct{}{}},
+					set.Set[ids.NodeID]{vdr1: struct{}{}, vdr2: struct{}{}},
 					reqID,
 					containerID,
 					containerBytes,
@@ -92,7 +93,7 @@ func TestSendMixedQuery(t *testing.T) {
 					containerBytes,
 				).Times(0)
 				s.EXPECT().SendPullQuery(
-					ids.NodeIDSet{vdr1: struct{}{}},
+					set.Set[ids.NodeID]{vdr1: struct{}{}},
 					reqID,
 					containerID,
 				).Times(1)
@@ -105,7 +106,7 @@ func TestSendMixedQuery(t *testing.T) {
 			senderF: func() *MockSender {
 				s := NewMockSender(ctrl)
 				s.EXPECT().SendPushQuery(
-					ids.NodeIDSet{vdr1: struct{}{}, vdr2: struct{}{}},
+					set.Set[ids.NodeID]{vdr1: struct{}{}, vdr2: struct{}{}},
 					reqID,
 					containerID,
 					containerBytes,
@@ -12,6 +12,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/prometheus/client_golang/prometheus"
@@ -195,13 +197,22 @@ func (j *Jobs) Commit() error {
 	return j.db.Commit()
 }
 
+// Repair fixes inconsistencies between the runnable jobs, the jobs and the
+// number of pending jobs that would otherwise cause execution to fail.
+func (j *Jobs) Repair(log logging.Logger) error {
+	if err := j.state.Repair(log); err != nil {
+		return err
+	}
+	return j.Commit()
+}
+
 type JobsWithMissing struct {
 	*Jobs
 
 	// keep the missing ID set in memory to avoid unnecessary database reads and
 	// writes.
-	missingIDs                            ids.Set
-	removeFromMissingIDs, addToMissingIDs ids.Set
+	missingIDs                            set.Set[ids.ID]
+	removeFromMissingIDs, addToMissingIDs set.Set[ids.ID]
 }
 
 func NewWithMissing(
@@ -12,6 +12,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,11 +26,11 @@ func testJob(t *testing.T, jobID ids.ID, executed *bool, parentID ids.ID, parent
 	return &TestJob{
 		T:   t,
 		IDF: func() ids.ID { return jobID },
-		MissingDependenciesF: func() (ids.Set, error) {
+		MissingDependenciesF: func() (set.Set[ids.ID], error) {
 			if parentID != ids.Empty && !*parentExecuted {
-				return ids.Set{parentID: struct{}{}}, nil
+				return set.Set[ids.ID]{parentID: struct{}{}}, nil
 			}
-			return ids.Set{}, nil
+			return set.Set[ids.ID]{}, nil
 		},
 		HasMissingDependenciesF: func() (bool, error) {
 			if parentID != ids.Empty && !*parentExecuted {
@@ -292,7 +294,7 @@ func TestMissingJobs(t *testing.T) {
 	numMissingIDs := jobs.NumMissingIDs()
 	assert.Equal(2, numMissingIDs)
 
-	missingIDSet := ids.Set{}
+	missingIDSet := set.Set[ids.ID]{}
 	missingIDSet.Add(jobs.MissingIDs()...)
 
 	containsJob0ID := missingIDSet.Contains(job0ID)
@@ -312,7 +314,7 @@ func TestMissingJobs(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	missingIDSet = ids.Set{}
+	missingIDSet = set.Set[ids.ID]{}
 	missingIDSet.Add(jobs.MissingIDs()...)
 
 	containsJob0ID = missingIDSet.Contains(job0ID)
@@ -431,7 +433,7 @@ func TestInitializeNumJobs(t *testing.T) {
 		T: t,
 
 		IDF:                     func() ids.ID { return job0ID },
-		MissingDependenciesF:    func() (ids.Set, error) { return nil, nil },
+		MissingDependenciesF:    func() (set.Set[ids.ID], error) { return nil, nil },
 		HasMissingDependenciesF: func() (bool, error) { return false, nil },
 		BytesF:                  func() []byte { return []byte{0} },
 	}
@@ -439,7 +441,7 @@ func TestInitializeNumJobs(t *testing.T) {
 		T: t,
 
 		IDF:                     func() ids.ID { return job1ID },
-		MissingDependenciesF:    func() (ids.Set, error) { return nil, nil },
+		MissingDependenciesF:    func() (set.Set[ids.ID], error) { return nil, nil },
 		HasMissingDependenciesF: func() (bool, error) { return false, nil },
 		BytesF:                  func() []byte { return []byte{1} },
 	}
@@ -517,3 +519,47 @@ func TestClearAll(t *testing.T) {
 	assert.NoError(err)
 	assert.False(hasJob1)
 }
+
+func TestRepair(t *testing.T) {
+	assert := assert.New(t)
+
+	parser := &TestParser{T: t}
+	db := memdb.New()
+
+	jobs, err := New(db, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	assert.NoError(jobs.SetParser(parser))
+
+	jobID := ids.GenerateTestID()
+	job := testJob(t, jobID, nil, ids.Empty, nil)
+	parser.ParseF = func(b []byte) (Job, error) { return job, nil }
+	pushed, err := jobs.Push(job)
+	assert.NoError(err)
+	assert.True(pushed)
+
+	// Leave a runnable job ID without its job and a stale number of jobs
+	danglingJobID := ids.GenerateTestID()
+	assert.NoError(jobs.state.AddRunnableJob(danglingJobID))
+	assert.NoError(database.PutUInt64(jobs.state.metadataDB, numJobsKey, 5))
+	assert.NoError(jobs.Commit())
+
+	jobs, err = New(db, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	assert.NoError(jobs.SetParser(parser))
+	assert.EqualValues(5, jobs.PendingJobs())
+
+	assert.NoError(jobs.Repair(logging.NoLog{}))
+	assert.EqualValues(1, jobs.PendingJobs())
+
+	jobs, err = New(db, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	assert.NoError(jobs.SetParser(parser))
+	assert.EqualValues(1, jobs.PendingJobs())
+
+	removedJob, err := jobs.state.RemoveRunnableJob()
+	assert.NoError(err)
+	assert.Equal(jobID, removedJob.ID())
+	hasNext, err := jobs.state.HasRunnableJob()
+	assert.NoError(err)
+	assert.False(hasNext)
+}
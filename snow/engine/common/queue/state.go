@@ -12,6 +12,8 @@ import (
 	"github.com/ava-labs/avalanchego/database/linkeddb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -153,6 +155,50 @@ func (s *state) Clear() error {
 	return errs.Err
 }
 
+// Repair removes runnable job IDs whose job isn't in the queue and resets the
+// number of pending jobs to the number of jobs in the queue
+func (s *state) Repair(log logging.Logger) error {
+	runnableIter := s.runnableJobIDs.NewIterator()
+	defer runnableIter.Release()
+
+	var danglingJobIDs []ids.ID
+	for runnableIter.Next() {
+		jobID, err := ids.ToID(runnableIter.Key())
+		if err != nil {
+			return fmt.Errorf("couldn't convert job ID bytes to job ID: %w", err)
+		}
+		has, err := s.jobsDB.Has(jobID[:])
+		if err != nil {
+			return err
+		}
+		if !has {
+			danglingJobIDs = append(danglingJobIDs, jobID)
+		}
+	}
+	if err := runnableIter.Error(); err != nil {
+		return err
+	}
+	for _, jobID := range danglingJobIDs {
+		jobID := jobID
+		log.Warn("removing runnable job %s that isn't in the queue", jobID)
+		if err := s.runnableJobIDs.Delete(jobID[:]); err != nil {
+			return err
+		}
+	}
+
+	count, err := database.Count(s.jobsDB)
+	if err != nil {
+		return err
+	}
+	numJobs := uint64(count)
+	if numJobs == s.numJobs {
+		return nil
+	}
+	log.Warn("resetting the number of pending jobs from %d to %d", s.numJobs, numJobs)
+	s.numJobs = numJobs
+	return database.PutUInt64(s.metadataDB, numJobsKey, s.numJobs)
+}
+
 // AddRunnableJob adds [jobID] to the runnable queue
 func (s *state) AddRunnableJob(jobID ids.ID) error {
 	return s.runnableJobIDs.Put(jobID[:], nil)
@@ -273,7 +319,7 @@ func (s *state) DisableCaching() {
 	s.cachingEnabled = false
 }
 
-func (s *state) AddMissingJobIDs(missingIDs ids.Set) error {
+func (s *state) AddMissingJobIDs(missingIDs set.Set[ids.ID]) error {
 	for missingID := range missingIDs {
 		missingID := missingID
 		if err := s.missingJobIDs.Put(missingID[:], nil); err != nil {
@@ -283,7 +329,7 @@ func (s *state) AddMissingJobIDs(missingIDs ids.Set) error {
 	return nil
 }
 
-func (s *state) RemoveMissingJobIDs(missingIDs ids.Set) error {
+func (s *state) RemoveMissingJobIDs(missingIDs set.Set[ids.ID]) error {
 	for missingID := range missingIDs {
 		missingID := missingID
 		if err := s.missingJobIDs.Delete(missingID[:]); err != nil {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
 )
 
@@ -22,7 +23,7 @@ type Peers interface {
 	// PreferredPeers returns the currently connected validators. If there are
 	// no currently connected validators then it will return the currently
 	// connected peers.
-	PreferredPeers() ids.NodeIDSet
+	PreferredPeers() set.Set[ids.NodeID]
 }
 
 type peers struct {
@@ -33,9 +34,9 @@ type peers struct {
 	connectedWeight uint64
 	// connectedValidators is the set of currently connected peers with a
 	// non-zero stake weight
-	connectedValidators ids.NodeIDSet
+	connectedValidators set.Set[ids.NodeID]
 	// connectedPeers is the set of all connected peers
-	connectedPeers ids.NodeIDSet
+	connectedPeers set.Set[ids.NodeID]
 }
 
 func NewPeers() Peers {
@@ -108,17 +109,17 @@ func (p *peers) ConnectedWeight() uint64 {
 	return p.connectedWeight
 }
 
-func (p *peers) PreferredPeers() ids.NodeIDSet {
+func (p *peers) PreferredPeers() set.Set[ids.NodeID] {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 
 	if p.connectedValidators.Len() == 0 {
-		connectedPeers := ids.NewNodeIDSet(p.connectedPeers.Len())
+		connectedPeers := set.NewSet[ids.NodeID](p.connectedPeers.Len())
 		connectedPeers.Union(p.connectedPeers)
 		return connectedPeers
 	}
 
-	connectedValidators := ids.NewNodeIDSet(p.connectedValidators.Len())
+	connectedValidators := set.NewSet[ids.NodeID](p.connectedValidators.Len())
 	connectedValidators.Union(p.connectedValidators)
 	return connectedValidators
 }
@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"errors"
+)
+
+var ErrCongestionReportingVMNotImplemented = errors.New("vm does not implement CongestionReportingVM interface")
+
+// CongestionReportingVM is an optional extension of ChainVM that reports how
+// much work is waiting to be included in blocks. Wrapping VMs, such as the
+// proposervm, use the report to decide how long to wait before building the
+// next block.
+type CongestionReportingVM interface {
+	// Congestion returns the fraction of a block that the VM's pending txs
+	// would fill, in the range [0, 1]. 0 means that only a few txs are
+	// pending, and 1 means that at least a full block of txs is pending.
+	Congestion() (float64, error)
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"errors"
+	"testing"
+)
+
+var (
+	errCongestion = errors.New("unexpectedly called Congestion")
+
+	_ CongestionReportingVM = &TestCongestionReportingVM{}
+)
+
+// TestCongestionReportingVM is a CongestionReportingVM that is useful for
+// testing.
+type TestCongestionReportingVM struct {
+	T *testing.T
+
+	CantCongestion bool
+
+	CongestionF func() (float64, error)
+}
+
+func (vm *TestCongestionReportingVM) Congestion() (float64, error) {
+	if vm.CongestionF != nil {
+		return vm.CongestionF()
+	}
+	if vm.CantCongestion && vm.T != nil {
+		vm.T.Fatal(errCongestion)
+	}
+	return 0, errCongestion
+}
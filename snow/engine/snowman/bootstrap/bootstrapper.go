@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/version"
 )
@@ -68,7 +69,7 @@ type bootstrapper struct {
 	// nodeID will be added back to [fetchFrom] unless the Ancestors message is
 	// empty. This is to attempt to prevent requesting containers from that peer
 	// again.
-	fetchFrom ids.NodeIDSet
+	fetchFrom set.Set[ids.NodeID]
 }
 
 func New(config Config, onFinished func(lastReqID uint32) error) (common.BootstrapableEngine, error) {
@@ -361,11 +362,11 @@ func (b *bootstrapper) Clear() error {
 
 // process a series of consecutive blocks starting at [blk].
 //
-// - blk is a block that is assumed to have been marked as acceptable by the
-//   bootstrapping engine.
-// - processingBlocks is a set of blocks that can be used to lookup blocks. This
-//   enables the engine to process multiple blocks without relying on the VM to
-//   have stored blocks during `ParseBlock`.
+//   - blk is a block that is assumed to have been marked as acceptable by the
+//     bootstrapping engine.
+//   - processingBlocks is a set of blocks that can be used to lookup blocks. This
+//     enables the engine to process multiple blocks without relying on the VM to
+//     have stored blocks during `ParseBlock`.
 //
 // If [blk]'s height is <= the last accepted height, then it will be removed
 // from the missingIDs set.
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
 )
 
@@ -58,20 +59,20 @@ type stateSyncer struct {
 	frontierSeeders validators.Set
 	// IDs of validators we should request state summary frontier from.
 	// Will be consumed seeders are reached out for frontier.
-	targetSeeders ids.NodeIDSet
+	targetSeeders set.Set[ids.NodeID]
 	// IDs of validators we requested a state summary frontier from
 	// but haven't received a reply yet. ID is cleared if/when reply arrives.
-	pendingSeeders ids.NodeIDSet
+	pendingSeeders set.Set[ids.NodeID]
 	// IDs of validators that failed to respond with their state summary frontier
-	failedSeeders ids.NodeIDSet
+	failedSeeders set.Set[ids.NodeID]
 
 	// IDs of validators we should request filtering the accepted state summaries from
-	targetVoters ids.NodeIDSet
+	targetVoters set.Set[ids.NodeID]
 	// IDs of validators we requested filtering the accepted state summaries from
 	// but haven't received a reply yet. ID is cleared if/when reply arrives.
-	pendingVoters ids.NodeIDSet
+	pendingVoters set.Set[ids.NodeID]
 	// IDs of validators that failed to respond with their filtered accepted state summaries
-	failedVoters ids.NodeIDSet
+	failedVoters set.Set[ids.NodeID]
 
 	// summaryID --> (summary, weight)
 	weightedSummaries map[ids.ID]*weightedSummary
@@ -217,7 +218,7 @@ func (ss *stateSyncer) AcceptedStateSummary(validatorID ids.NodeID, requestID ui
 			continue
 		}
 
-		newWeight, err := math.Add64(weight, ws.weight)
+		newWeight, err := math.Add(weight, ws.weight)
 		if err != nil {
 			ss.Ctx.Log.Error("Error calculating the Accepted votes - weight: %v, previousWeight: %v", weight, ws.weight)
 			newWeight = stdmath.MaxUint64
@@ -433,7 +434,7 @@ func (ss *stateSyncer) restart() error {
 // to send their accepted state summary. It is called again until there are
 // no more seeders to be reached in the pending set
 func (ss *stateSyncer) sendGetStateSummaryFrontiers() {
-	vdrs := ids.NewNodeIDSet(1)
+	vdrs := set.NewSet[ids.NodeID](1)
 	for ss.targetSeeders.Len() > 0 && ss.pendingSeeders.Len() < common.MaxOutstandingBroadcastRequests {
 		vdr, _ := ss.targetSeeders.Pop()
 		vdrs.Add(vdr)
@@ -449,7 +450,7 @@ func (ss *stateSyncer) sendGetStateSummaryFrontiers() {
 // their filtered accepted frontier. It is called again until there are
 // no more voters to be reached in the pending set.
 func (ss *stateSyncer) sendGetAcceptedStateSummaries() {
-	vdrs := ids.NewNodeIDSet(1)
+	vdrs := set.NewSet[ids.NodeID](1)
 	for ss.targetVoters.Len() > 0 && ss.pendingVoters.Len() < common.MaxOutstandingBroadcastRequests {
 		vdr, _ := ss.targetVoters.Pop()
 		vdrs.Add(vdr)
@@ -5,6 +5,8 @@ package snowman
 
 import (
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 // Voter records chits received from [vdr] once its dependencies are met.
@@ -13,10 +15,10 @@ type voter struct {
 	vdr       ids.NodeID
 	requestID uint32
 	response  ids.ID
-	deps      ids.Set
+	deps      set.Set[ids.ID]
 }
 
-func (v *voter) Dependencies() ids.Set { return v.deps }
+func (v *voter) Dependencies() set.Set[ids.ID] { return v.deps }
 
 // Mark that a dependency has been met.
 func (v *voter) Fulfill(id ids.ID) {
@@ -32,7 +34,7 @@ func (v *voter) Update() {
 		return
 	}
 
-	var results []ids.Bag
+	var results []bag.Bag[ids.ID]
 	if v.response == ids.Empty {
 		results = v.t.polls.Drop(v.requestID, v.vdr)
 	} else {
@@ -83,8 +85,8 @@ func (v *voter) Update() {
 // Note: bubbleVotes does not bubbleVotes to all of the ancestors in consensus,
 // just the most recent one. bubbling to the rest of the ancestors, which may
 // also be in consensus is handled in RecordPoll.
-func (v *voter) bubbleVotes(votes ids.Bag) ids.Bag {
-	bubbledVotes := ids.Bag{}
+func (v *voter) bubbleVotes(votes bag.Bag[ids.ID]) bag.Bag[ids.ID] {
+	bubbledVotes := bag.Bag[ids.ID]{}
 
 votesLoop:
 	for _, vote := range votes.List() {
@@ -46,6 +46,11 @@ type Handler interface {
 	SetOnStopped(onStopped func())
 	Start(recoverPanic bool)
 	Push(msg message.InboundMessage)
+	// Handle processes [msg] on the calling goroutine rather than queueing it
+	// for the dispatchers launched by Start. This allows the order in which
+	// messages are processed to be controlled, such as in simulations. It
+	// must not be called on a handler that has been started.
+	Handle(msg message.InboundMessage) error
 	Stop()
 	StopWithError(err error)
 	Stopped() chan struct{}
@@ -231,6 +236,17 @@ func (h *handler) Push(msg message.InboundMessage) {
 	}
 }
 
+func (h *handler) Handle(msg message.InboundMessage) error {
+	switch msg.Op() {
+	case message.AppRequest, message.AppGossip, message.AppRequestFailed, message.AppResponse:
+		return h.executeAsyncMsg(msg)
+	case message.Notify, message.GossipRequest, message.Timeout:
+		return h.handleChanMsg(msg)
+	default:
+		return h.handleSyncMsg(msg)
+	}
+}
+
 func (h *handler) RegisterTimeout(d time.Duration) {
 	go func() {
 		timer := time.NewTimer(d)
@@ -365,7 +381,7 @@ func (h *handler) handleSyncMsg(msg message.InboundMessage) error {
 		op        = msg.Op()
 		startTime = h.clock.Time()
 	)
-	h.resourceTracker.StartProcessing(nodeID, startTime)
+	h.resourceTracker.StartChainProcessing(h.ctx.ChainID, nodeID, startTime)
 	h.ctx.Lock.Lock()
 	defer func() {
 		h.ctx.Lock.Unlock()
@@ -374,7 +390,7 @@ func (h *handler) handleSyncMsg(msg message.InboundMessage) error {
 			endTime   = h.clock.Time()
 			histogram = h.metrics.messages[op]
 		)
-		h.resourceTracker.StopProcessing(nodeID, endTime)
+		h.resourceTracker.StopChainProcessing(h.ctx.ChainID, nodeID, endTime)
 		histogram.Observe(float64(endTime.Sub(startTime)))
 		msg.OnFinishedHandling()
 		h.ctx.Log.Debug("Finished handling sync message: %s", op)
@@ -587,13 +603,13 @@ func (h *handler) executeAsyncMsg(msg message.InboundMessage) error {
 		op        = msg.Op()
 		startTime = h.clock.Time()
 	)
-	h.resourceTracker.StartProcessing(nodeID, startTime)
+	h.resourceTracker.StartChainProcessing(h.ctx.ChainID, nodeID, startTime)
 	defer func() {
 		var (
 			endTime   = h.clock.Time()
 			histogram = h.metrics.messages[op]
 		)
-		h.resourceTracker.StopProcessing(nodeID, endTime)
+		h.resourceTracker.StopChainProcessing(h.ctx.ChainID, nodeID, endTime)
 		histogram.Observe(float64(endTime.Sub(startTime)))
 		msg.OnFinishedHandling()
 		h.ctx.Log.Debug("Finished handling async message: %s", op)
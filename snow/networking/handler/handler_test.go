@@ -293,3 +293,72 @@ func TestHandlerDispatchInternal(t *testing.T) {
 	case <-calledNotify:
 	}
 }
+
+func TestHandlerHandle(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+	vdr0 := ids.GenerateTestNodeID()
+	err := vdrs.AddWeight(vdr0, 1)
+	assert.NoError(err)
+	metrics := prometheus.NewRegistry()
+	mc, err := message.NewCreator(metrics, true, "dummyNamespace", 10*time.Second)
+	assert.NoError(err)
+
+	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	assert.NoError(err)
+	handler, err := New(
+		mc,
+		ctx,
+		vdrs,
+		nil,
+		nil,
+		time.Second,
+		resourceTracker,
+	)
+	assert.NoError(err)
+
+	var (
+		calledNotify     bool
+		calledPullQuery  bool
+		calledAppGossip  bool
+		calledOnFinished int
+	)
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext { return ctx }
+	engine.NotifyF = func(common.Message) error {
+		calledNotify = true
+		return nil
+	}
+	engine.PullQueryF = func(nodeID ids.NodeID, requestID uint32, blkID ids.ID) error {
+		calledPullQuery = true
+		return nil
+	}
+	engine.AppGossipF = func(nodeID ids.NodeID, msg []byte) error {
+		calledAppGossip = true
+		return nil
+	}
+	handler.SetConsensus(engine)
+	ctx.SetState(snow.NormalOp) // assumed bootstrapping is done
+
+	// Messages are processed synchronously without starting the handler
+	err = handler.Handle(mc.InternalVMMessage(ctx.NodeID, uint32(common.PendingTxs)))
+	assert.NoError(err)
+	assert.True(calledNotify)
+
+	pullQuery := mc.InboundPullQuery(ctx.ChainID, 1, time.Second, ids.GenerateTestID(), vdr0)
+	err = handler.Handle(pullQuery)
+	assert.NoError(err)
+	assert.True(calledPullQuery)
+
+	appGossip, err := mc.AppGossip(ctx.ChainID, []byte{1})
+	assert.NoError(err)
+	inAppGossip, err := mc.Parse(appGossip.Bytes(), vdr0, func() { calledOnFinished++ })
+	assert.NoError(err)
+	err = handler.Handle(inAppGossip)
+	assert.NoError(err)
+	assert.True(calledAppGossip)
+	assert.Equal(1, calledOnFinished)
+}
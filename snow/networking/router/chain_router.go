@@ -52,6 +52,7 @@ type peer struct {
 // that they are working on.
 type ChainRouter struct {
 	clock      mockable.Clock
+	myNodeID   ids.NodeID
 	log        logging.Logger
 	msgCreator message.Creator
 	lock       sync.Mutex
@@ -97,6 +98,7 @@ func (cr *ChainRouter) Initialize(
 	metricsNamespace string,
 	metricsRegisterer prometheus.Registerer,
 ) error {
+	cr.myNodeID = nodeID
 	cr.log = log
 	cr.msgCreator = msgCreator
 	cr.chains = make(map[ids.ID]handler.Handler)
@@ -412,6 +414,27 @@ func (cr *ChainRouter) Unbenched(chainID ids.ID, nodeID ids.NodeID) {
 	}
 }
 
+func (cr *ChainRouter) TrackSubnet(subnetID ids.ID) {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	cr.peers[cr.myNodeID].trackedSubnets.Add(subnetID)
+}
+
+func (cr *ChainRouter) UntrackSubnet(subnetID ids.ID) {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	cr.peers[cr.myNodeID].trackedSubnets.Remove(subnetID)
+}
+
+func (cr *ChainRouter) SetHealthConfig(healthConfig HealthConfig) {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	cr.healthConfig = healthConfig
+}
+
 // HealthCheck returns results of router health checks. Returns:
 // 1) Information about health check results
 // 2) An error if the health check reports unhealthy
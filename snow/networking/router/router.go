@@ -38,6 +38,13 @@ type Router interface {
 	) error
 	Shutdown()
 	AddChain(chain handler.Handler)
+	// TrackSubnet marks this node as tracking [subnetID], so that chains of
+	// the subnet are notified that this node is connected.
+	TrackSubnet(subnetID ids.ID)
+	// UntrackSubnet marks this node as no longer tracking [subnetID].
+	UntrackSubnet(subnetID ids.ID)
+	// SetHealthConfig replaces the thresholds used by HealthCheck.
+	SetHealthConfig(healthConfig HealthConfig)
 	health.Checker
 }
 
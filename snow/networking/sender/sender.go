@@ -46,6 +46,10 @@ type sender struct {
 
 	gossipConfig GossipConfig
 
+	// If true, messages to this node are put into [router] on the calling
+	// goroutine rather than on a new goroutine
+	synchronous bool
+
 	// Request message type --> Counts how many of that request
 	// have failed because the node was benched
 	failedDueToBench map[message.Op]prometheus.Counter
@@ -58,6 +62,33 @@ func New(
 	router router.Router,
 	timeouts timeout.Manager,
 	gossipConfig GossipConfig,
+) (common.Sender, error) {
+	return newSender(ctx, msgCreator, externalSender, router, timeouts, gossipConfig, false)
+}
+
+// NewSynchronous returns a sender that puts messages to this node into
+// [router] on the calling goroutine. This makes the order in which those
+// messages are routed deterministic, but [router] must never block on the
+// engine that uses the returned sender.
+func NewSynchronous(
+	ctx *snow.ConsensusContext,
+	msgCreator message.Creator,
+	externalSender ExternalSender,
+	router router.Router,
+	timeouts timeout.Manager,
+	gossipConfig GossipConfig,
+) (common.Sender, error) {
+	return newSender(ctx, msgCreator, externalSender, router, timeouts, gossipConfig, true)
+}
+
+func newSender(
+	ctx *snow.ConsensusContext,
+	msgCreator message.Creator,
+	externalSender ExternalSender,
+	router router.Router,
+	timeouts timeout.Manager,
+	gossipConfig GossipConfig,
+	synchronous bool,
 ) (common.Sender, error) {
 	s := &sender{
 		ctx:              ctx,
@@ -66,6 +97,7 @@ func New(
 		router:           router,
 		timeouts:         timeouts,
 		gossipConfig:     gossipConfig,
+		synchronous:      synchronous,
 		failedDueToBench: make(map[message.Op]prometheus.Counter, len(message.ConsensusRequestOps)),
 	}
 
@@ -103,7 +135,7 @@ func (s *sender) SendGetStateSummaryFrontier(nodeIDs ids.NodeIDSet, requestID ui
 	if nodeIDs.Contains(s.ctx.NodeID) {
 		nodeIDs.Remove(s.ctx.NodeID)
 		inMsg := s.msgCreator.InboundGetStateSummaryFrontier(s.ctx.ChainID, requestID, deadline, s.ctx.NodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 	}
 
 	// Create the outbound message.
@@ -128,7 +160,7 @@ func (s *sender) SendStateSummaryFrontier(nodeID ids.NodeID, requestID uint32, s
 	// Sending this message to myself.
 	if nodeID == s.ctx.NodeID {
 		inMsg := s.msgCreator.InboundStateSummaryFrontier(s.ctx.ChainID, requestID, summary, nodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return
 	}
 
@@ -178,7 +210,7 @@ func (s *sender) SendGetAcceptedStateSummary(nodeIDs ids.NodeIDSet, requestID ui
 	if nodeIDs.Contains(s.ctx.NodeID) {
 		nodeIDs.Remove(s.ctx.NodeID)
 		inMsg := s.msgCreator.InboundGetAcceptedStateSummary(s.ctx.ChainID, requestID, heights, deadline, s.ctx.NodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 	}
 
 	// Create the outbound message.
@@ -214,7 +246,7 @@ func (s *sender) SendGetAcceptedStateSummary(nodeIDs ids.NodeIDSet, requestID ui
 func (s *sender) SendAcceptedStateSummary(nodeID ids.NodeID, requestID uint32, summaryIDs []ids.ID) {
 	if nodeID == s.ctx.NodeID {
 		inMsg := s.msgCreator.InboundAcceptedStateSummary(s.ctx.ChainID, requestID, summaryIDs, nodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return
 	}
 
@@ -263,7 +295,7 @@ func (s *sender) SendGetAcceptedFrontier(nodeIDs ids.NodeIDSet, requestID uint32
 	if nodeIDs.Contains(s.ctx.NodeID) {
 		nodeIDs.Remove(s.ctx.NodeID)
 		inMsg := s.msgCreator.InboundGetAcceptedFrontier(s.ctx.ChainID, requestID, deadline, s.ctx.NodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 	}
 
 	// Create the outbound message.
@@ -288,7 +320,7 @@ func (s *sender) SendAcceptedFrontier(nodeID ids.NodeID, requestID uint32, conta
 	// Sending this message to myself.
 	if nodeID == s.ctx.NodeID {
 		inMsg := s.msgCreator.InboundAcceptedFrontier(s.ctx.ChainID, requestID, containerIDs, nodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return
 	}
 
@@ -338,7 +370,7 @@ func (s *sender) SendGetAccepted(nodeIDs ids.NodeIDSet, requestID uint32, contai
 	if nodeIDs.Contains(s.ctx.NodeID) {
 		nodeIDs.Remove(s.ctx.NodeID)
 		inMsg := s.msgCreator.InboundGetAccepted(s.ctx.ChainID, requestID, deadline, containerIDs, s.ctx.NodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 	}
 
 	// Create the outbound message.
@@ -374,7 +406,7 @@ func (s *sender) SendGetAccepted(nodeIDs ids.NodeIDSet, requestID uint32, contai
 func (s *sender) SendAccepted(nodeID ids.NodeID, requestID uint32, containerIDs []ids.ID) {
 	if nodeID == s.ctx.NodeID {
 		inMsg := s.msgCreator.InboundAccepted(s.ctx.ChainID, requestID, containerIDs, nodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return
 	}
 
@@ -419,7 +451,7 @@ func (s *sender) SendGetAncestors(nodeID ids.NodeID, requestID uint32, container
 	// Sending a GetAncestors to myself always fails.
 	if nodeID == s.ctx.NodeID {
 		inMsg := s.msgCreator.InternalFailedRequest(message.GetAncestorsFailed, nodeID, s.ctx.ChainID, requestID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return
 	}
 
@@ -429,7 +461,7 @@ func (s *sender) SendGetAncestors(nodeID ids.NodeID, requestID uint32, container
 		s.failedDueToBench[message.GetAncestors].Inc() // update metric
 		s.timeouts.RegisterRequestToUnreachableValidator()
 		inMsg := s.msgCreator.InternalFailedRequest(message.GetAncestorsFailed, nodeID, s.ctx.ChainID, requestID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return
 	}
 
@@ -441,7 +473,7 @@ func (s *sender) SendGetAncestors(nodeID ids.NodeID, requestID uint32, container
 	if err != nil {
 		s.ctx.Log.Error("failed to build GetAncestors message: %s", err)
 		inMsg := s.msgCreator.InternalFailedRequest(message.GetAncestorsFailed, nodeID, s.ctx.ChainID, requestID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return
 	}
 
@@ -458,7 +490,7 @@ func (s *sender) SendGetAncestors(nodeID ids.NodeID, requestID uint32, container
 		)
 		s.timeouts.RegisterRequestToUnreachableValidator()
 		inMsg := s.msgCreator.InternalFailedRequest(message.GetAncestorsFailed, nodeID, s.ctx.ChainID, requestID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 	}
 }
 
@@ -511,7 +543,7 @@ func (s *sender) SendGet(nodeID ids.NodeID, requestID uint32, containerID ids.ID
 	// Sending a Get to myself always fails.
 	if nodeID == s.ctx.NodeID {
 		inMsg := s.msgCreator.InternalFailedRequest(message.GetFailed, nodeID, s.ctx.ChainID, requestID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return
 	}
 
@@ -521,7 +553,7 @@ func (s *sender) SendGet(nodeID ids.NodeID, requestID uint32, containerID ids.ID
 		s.failedDueToBench[message.Get].Inc() // update metric
 		s.timeouts.RegisterRequestToUnreachableValidator()
 		inMsg := s.msgCreator.InternalFailedRequest(message.GetFailed, nodeID, s.ctx.ChainID, requestID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return
 	}
 
@@ -546,7 +578,7 @@ func (s *sender) SendGet(nodeID ids.NodeID, requestID uint32, containerID ids.ID
 
 		s.timeouts.RegisterRequestToUnreachableValidator()
 		inMsg := s.msgCreator.InternalFailedRequest(message.GetFailed, nodeID, s.ctx.ChainID, requestID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 	}
 }
 
@@ -621,7 +653,7 @@ func (s *sender) SendPushQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 	if nodeIDs.Contains(s.ctx.NodeID) {
 		nodeIDs.Remove(s.ctx.NodeID)
 		inMsg := s.msgCreator.InboundPushQuery(s.ctx.ChainID, requestID, deadline, containerID, container, s.ctx.NodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 	}
 
 	// Some of [nodeIDs] may be benched. That is, they've been unresponsive
@@ -634,7 +666,7 @@ func (s *sender) SendPushQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 
 			// Immediately register a failure. Do so asynchronously to avoid deadlock.
 			inMsg := s.msgCreator.InternalFailedRequest(message.QueryFailed, nodeID, s.ctx.ChainID, requestID)
-			go s.router.HandleInbound(inMsg)
+			s.sendToSelf(inMsg)
 		}
 	}
 
@@ -671,7 +703,7 @@ func (s *sender) SendPushQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 			// Register failures for nodes we didn't send a request to.
 			s.timeouts.RegisterRequestToUnreachableValidator()
 			inMsg := s.msgCreator.InternalFailedRequest(message.QueryFailed, nodeID, s.ctx.ChainID, requestID)
-			go s.router.HandleInbound(inMsg)
+			s.sendToSelf(inMsg)
 		}
 	}
 }
@@ -705,7 +737,7 @@ func (s *sender) SendPullQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 	if nodeIDs.Contains(s.ctx.NodeID) {
 		nodeIDs.Remove(s.ctx.NodeID)
 		inMsg := s.msgCreator.InboundPullQuery(s.ctx.ChainID, requestID, deadline, containerID, s.ctx.NodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 	}
 
 	// Some of the nodes in [nodeIDs] may be benched. That is, they've been unresponsive
@@ -717,7 +749,7 @@ func (s *sender) SendPullQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 			s.timeouts.RegisterRequestToUnreachableValidator()
 			// Immediately register a failure. Do so asynchronously to avoid deadlock.
 			inMsg := s.msgCreator.InternalFailedRequest(message.QueryFailed, nodeID, s.ctx.ChainID, requestID)
-			go s.router.HandleInbound(inMsg)
+			s.sendToSelf(inMsg)
 		}
 	}
 
@@ -741,7 +773,7 @@ func (s *sender) SendPullQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 			// Register failures for nodes we didn't send a request to.
 			s.timeouts.RegisterRequestToUnreachableValidator()
 			inMsg := s.msgCreator.InternalFailedRequest(message.QueryFailed, nodeID, s.ctx.ChainID, requestID)
-			go s.router.HandleInbound(inMsg)
+			s.sendToSelf(inMsg)
 		}
 	}
 }
@@ -759,7 +791,7 @@ func (s *sender) SendChits(nodeID ids.NodeID, requestID uint32, votes []ids.ID)
 	// to my own router rather than sending it over the network
 	if nodeID == s.ctx.NodeID {
 		inMsg := s.msgCreator.InboundChits(s.ctx.ChainID, requestID, votes, nodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return
 	}
 
@@ -804,7 +836,7 @@ func (s *sender) SendChitsV2(nodeID ids.NodeID, requestID uint32, votes []ids.ID
 	// to my own router rather than sending it over the network
 	if nodeID == s.ctx.NodeID {
 		inMsg := s.msgCreator.InboundChitsV2(s.ctx.ChainID, requestID, votes, vote, nodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return
 	}
 
@@ -864,7 +896,7 @@ func (s *sender) SendAppRequest(nodeIDs ids.NodeIDSet, requestID uint32, appRequ
 	if nodeIDs.Contains(s.ctx.NodeID) {
 		nodeIDs.Remove(s.ctx.NodeID)
 		inMsg := s.msgCreator.InboundAppRequest(s.ctx.ChainID, requestID, deadline, appRequestBytes, s.ctx.NodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 	}
 
 	// Some of the nodes in [nodeIDs] may be benched. That is, they've been unresponsive
@@ -877,7 +909,7 @@ func (s *sender) SendAppRequest(nodeIDs ids.NodeIDSet, requestID uint32, appRequ
 
 			// Immediately register a failure. Do so asynchronously to avoid deadlock.
 			inMsg := s.msgCreator.InternalFailedRequest(message.AppRequestFailed, nodeID, s.ctx.ChainID, requestID)
-			go s.router.HandleInbound(inMsg)
+			s.sendToSelf(inMsg)
 		}
 	}
 
@@ -911,7 +943,7 @@ func (s *sender) SendAppRequest(nodeIDs ids.NodeIDSet, requestID uint32, appRequ
 			// Register failures for nodes we didn't send a request to.
 			s.timeouts.RegisterRequestToUnreachableValidator()
 			inMsg := s.msgCreator.InternalFailedRequest(message.AppRequestFailed, nodeID, s.ctx.ChainID, requestID)
-			go s.router.HandleInbound(inMsg)
+			s.sendToSelf(inMsg)
 		}
 	}
 	return nil
@@ -922,7 +954,7 @@ func (s *sender) SendAppRequest(nodeIDs ids.NodeIDSet, requestID uint32, appRequ
 func (s *sender) SendAppResponse(nodeID ids.NodeID, requestID uint32, appResponseBytes []byte) error {
 	if nodeID == s.ctx.NodeID {
 		inMsg := s.msgCreator.InboundAppResponse(s.ctx.ChainID, requestID, appResponseBytes, nodeID)
-		go s.router.HandleInbound(inMsg)
+		s.sendToSelf(inMsg)
 		return nil
 	}
 
@@ -1057,3 +1089,13 @@ func (s *sender) Accept(ctx *snow.ConsensusContext, containerID ids.ID, containe
 	}
 	return nil
 }
+
+// sendToSelf puts [msg], which was sent by this node to itself, into the
+// router
+func (s *sender) sendToSelf(msg message.InboundMessage) {
+	if s.synchronous {
+		s.router.HandleInbound(msg)
+		return
+	}
+	go s.router.HandleInbound(msg)
+}
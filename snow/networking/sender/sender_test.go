@@ -319,3 +319,53 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		<-await
 	}
 }
+
+// inboundRouter records the messages that are routed to it
+type inboundRouter struct {
+	router.Router
+	msgs []message.InboundMessage
+}
+
+func (r *inboundRouter) RegisterRequest(ids.NodeID, ids.ID, uint32, message.Op) {}
+
+func (r *inboundRouter) HandleInbound(msg message.InboundMessage) {
+	r.msgs = append(r.msgs, msg)
+}
+
+func TestSynchronousSendToSelf(t *testing.T) {
+	assert := assert.New(t)
+
+	mc, err := message.NewCreator(prometheus.NewRegistry(), true, "dummyNamespace", 10*time.Second)
+	assert.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	externalSender := &ExternalSenderTest{TB: t}
+	externalSender.Default(false)
+	router := &inboundRouter{}
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Millisecond,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     10 * time.Second,
+			TimeoutHalflife:    5 * time.Minute,
+			TimeoutCoefficient: 1.25,
+		},
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	assert.NoError(err)
+
+	sender, err := NewSynchronous(ctx, mc, externalSender, router, tm, defaultGossipConfig)
+	assert.NoError(err)
+
+	// Messages to this node are routed before the send returns
+	nodeIDs := ids.NodeIDSet{}
+	nodeIDs.Add(ctx.NodeID)
+	sender.SendPullQuery(nodeIDs, 1, ids.Empty)
+	sender.SendChits(ctx.NodeID, 1, []ids.ID{ids.Empty})
+
+	assert.Len(router.msgs, 2)
+	assert.Equal(message.PullQuery, router.msgs[0].Op())
+	assert.Equal(message.Chits, router.msgs[1].Op())
+}
@@ -35,6 +35,20 @@ func (m *MockTracker) EXPECT() *MockTrackerMockRecorder {
 	return m.recorder
 }
 
+// ChainUsage mocks base method.
+func (m *MockTracker) ChainUsage(chainID ids.ID, now time.Time) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainUsage", chainID, now)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// ChainUsage indicates an expected call of ChainUsage.
+func (mr *MockTrackerMockRecorder) ChainUsage(chainID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainUsage", reflect.TypeOf((*MockTracker)(nil).ChainUsage), chainID, now)
+}
+
 // TimeUntilUsage mocks base method.
 func (m *MockTracker) TimeUntilUsage(nodeID ids.NodeID, now time.Time, value float64) time.Duration {
 	m.ctrl.T.Helper()
@@ -77,6 +91,20 @@ func (mr *MockTrackerMockRecorder) Usage(nodeID, now interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockTracker)(nil).Usage), nodeID, now)
 }
 
+// Usages mocks base method.
+func (m *MockTracker) Usages(now time.Time) map[ids.NodeID]float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usages", now)
+	ret0, _ := ret[0].(map[ids.NodeID]float64)
+	return ret0
+}
+
+// Usages indicates an expected call of Usages.
+func (mr *MockTrackerMockRecorder) Usages(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usages", reflect.TypeOf((*MockTracker)(nil).Usages), now)
+}
+
 // MockResourceTracker is a mock of ResourceTracker interface.
 type MockResourceTracker struct {
 	ctrl     *gomock.Controller
@@ -128,6 +156,18 @@ func (mr *MockResourceTrackerMockRecorder) DiskTracker() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiskTracker", reflect.TypeOf((*MockResourceTracker)(nil).DiskTracker))
 }
 
+// StartChainProcessing mocks base method.
+func (m *MockResourceTracker) StartChainProcessing(arg0 ids.ID, arg1 ids.NodeID, arg2 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StartChainProcessing", arg0, arg1, arg2)
+}
+
+// StartChainProcessing indicates an expected call of StartChainProcessing.
+func (mr *MockResourceTrackerMockRecorder) StartChainProcessing(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartChainProcessing", reflect.TypeOf((*MockResourceTracker)(nil).StartChainProcessing), arg0, arg1, arg2)
+}
+
 // StartProcessing mocks base method.
 func (m *MockResourceTracker) StartProcessing(arg0 ids.NodeID, arg1 time.Time) {
 	m.ctrl.T.Helper()
@@ -140,6 +180,18 @@ func (mr *MockResourceTrackerMockRecorder) StartProcessing(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartProcessing", reflect.TypeOf((*MockResourceTracker)(nil).StartProcessing), arg0, arg1)
 }
 
+// StopChainProcessing mocks base method.
+func (m *MockResourceTracker) StopChainProcessing(arg0 ids.ID, arg1 ids.NodeID, arg2 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StopChainProcessing", arg0, arg1, arg2)
+}
+
+// StopChainProcessing indicates an expected call of StopChainProcessing.
+func (mr *MockResourceTrackerMockRecorder) StopChainProcessing(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopChainProcessing", reflect.TypeOf((*MockResourceTracker)(nil).StopChainProcessing), arg0, arg1, arg2)
+}
+
 // StopProcessing mocks base method.
 func (m *MockResourceTracker) StopProcessing(arg0 ids.NodeID, arg1 time.Time) {
 	m.ctrl.T.Helper()
@@ -5,6 +5,7 @@ package tracker
 
 import (
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -31,6 +32,12 @@ type Tracker interface {
 	// If the node's usage isn't known, or is already <= [value], returns the
 	// zero duration.
 	TimeUntilUsage(nodeID ids.NodeID, now time.Time, value float64) time.Duration
+	// Returns the current usage of every node that has recently used the
+	// resource.
+	Usages(now time.Time) map[ids.NodeID]float64
+	// Returns the current usage attributed to messages handled by the given
+	// chain.
+	ChainUsage(chainID ids.ID, now time.Time) float64
 }
 
 type DiskTracker interface {
@@ -46,6 +53,12 @@ type ResourceTracker interface {
 	StartProcessing(ids.NodeID, time.Time)
 	// Registers that the given node stopped processing at the given time.
 	StopProcessing(ids.NodeID, time.Time)
+	// Registers that the given node started processing a message handled by
+	// the given chain at the given time.
+	StartChainProcessing(ids.ID, ids.NodeID, time.Time)
+	// Registers that the given node stopped processing a message handled by
+	// the given chain at the given time.
+	StopChainProcessing(ids.ID, ids.NodeID, time.Time)
 }
 
 type cpuResourceTracker struct {
@@ -110,6 +123,28 @@ func (t *cpuResourceTracker) TimeUntilUsage(nodeID ids.NodeID, now time.Time, va
 	return m.(meter.Meter).TimeUntil(now, value/scale)
 }
 
+func (t *cpuResourceTracker) Usages(now time.Time) map[ids.NodeID]float64 {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	realCPUUsage := rt.resources.CPUUsage()
+	rt.metrics.cpuMetric.Set(realCPUUsage)
+
+	return rt.usages(now, realCPUUsage)
+}
+
+func (t *cpuResourceTracker) ChainUsage(chainID ids.ID, now time.Time) float64 {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	realCPUUsage := rt.resources.CPUUsage()
+	rt.metrics.cpuMetric.Set(realCPUUsage)
+
+	return rt.chainUsage(chainID, now, realCPUUsage)
+}
+
 type diskResourceTracker struct {
 	t *resourceTracker
 }
@@ -187,6 +222,32 @@ func (t *diskResourceTracker) TimeUntilUsage(nodeID ids.NodeID, now time.Time, v
 	return m.(meter.Meter).TimeUntil(now, value/scale)
 }
 
+func (t *diskResourceTracker) Usages(now time.Time) map[ids.NodeID]float64 {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	// [realWriteUsage] is only used for metrics.
+	realReadUsage, realWriteUsage := rt.resources.DiskUsage()
+	rt.metrics.diskReadsMetric.Set(realReadUsage)
+	rt.metrics.diskWritesMetric.Set(realWriteUsage)
+
+	return rt.usages(now, realReadUsage)
+}
+
+func (t *diskResourceTracker) ChainUsage(chainID ids.ID, now time.Time) float64 {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	// [realWriteUsage] is only used for metrics.
+	realReadUsage, realWriteUsage := rt.resources.DiskUsage()
+	rt.metrics.diskReadsMetric.Set(realReadUsage)
+	rt.metrics.diskWritesMetric.Set(realWriteUsage)
+
+	return rt.chainUsage(chainID, now, realReadUsage)
+}
+
 type resourceTracker struct {
 	lock sync.RWMutex
 
@@ -200,8 +261,12 @@ type resourceTracker struct {
 	// utilized. This doesn't necessarily result in the meters being sorted
 	// based on their usage. However, in practice the nodes that are not being
 	// utilized will move towards the oldest elements where they can be deleted.
-	meters  linkedhashmap.LinkedHashmap
-	metrics *trackerMetrics
+	meters linkedhashmap.LinkedHashmap
+	// Chain ID --> Meter that tracks the number of current processing requests
+	// handled by the chain. The number of chains is small, so these meters are
+	// never pruned.
+	chainMeters map[ids.ID]meter.Meter
+	metrics     *trackerMetrics
 }
 
 func NewResourceTracker(
@@ -216,6 +281,7 @@ func NewResourceTracker(
 		processingMeter: factory.New(halflife),
 		halflife:        halflife,
 		meters:          linkedhashmap.New(),
+		chainMeters:     make(map[ids.ID]meter.Meter),
 	}
 	var err error
 	t.metrics, err = newCPUTrackerMetrics("resource_tracker", reg)
@@ -251,6 +317,103 @@ func (rt *resourceTracker) StopProcessing(nodeID ids.NodeID, now time.Time) {
 	rt.processingMeter.Dec(now, 1)
 }
 
+func (rt *resourceTracker) StartChainProcessing(chainID ids.ID, nodeID ids.NodeID, now time.Time) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	meter := rt.getMeter(nodeID)
+	meter.Inc(now, 1)
+	chainMeter := rt.getChainMeter(chainID)
+	chainMeter.Inc(now, 1)
+	rt.processingMeter.Inc(now, 1)
+}
+
+func (rt *resourceTracker) StopChainProcessing(chainID ids.ID, nodeID ids.NodeID, now time.Time) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	meter := rt.getMeter(nodeID)
+	meter.Dec(now, 1)
+	chainMeter := rt.getChainMeter(chainID)
+	chainMeter.Dec(now, 1)
+	rt.processingMeter.Dec(now, 1)
+
+	// Report the chain's usage once it finishes processing a message so that
+	// the per-chain metrics don't require polling.
+	chainLabel := chainID.String()
+	realReadUsage, _ := rt.resources.DiskUsage()
+	rt.metrics.chainCPUMetric.WithLabelValues(chainLabel).Set(rt.chainUsage(chainID, now, rt.resources.CPUUsage()))
+	rt.metrics.chainDiskReadsMetric.WithLabelValues(chainLabel).Set(rt.chainUsage(chainID, now, realReadUsage))
+
+	rt.metrics.memoryMetric.Set(float64(rt.resources.MemoryUsage()))
+
+	// Memory isn't attributed to the messages that allocated it, so the
+	// memory of the node's process is estimated to be used by each chain in
+	// proportion to the time spent handling its messages. The estimates of
+	// every chain are updated, so that the estimates of idle chains decay. The
+	// memory of a plugin's process is reported by the chain that runs it.
+	nodeMemoryUsage := float64(rt.resources.ProcessMemoryUsage(os.Getpid()))
+	for chainID := range rt.chainMeters {
+		rt.metrics.chainMemoryEstimateMetric.WithLabelValues(chainID.String()).Set(rt.chainUsage(chainID, now, nodeMemoryUsage))
+	}
+}
+
+// usages returns the portion of [realUsage] attributed to each node that
+// has recently processed messages.
+// assumes [rt.lock] is held.
+func (rt *resourceTracker) usages(now time.Time, realUsage float64) map[ids.NodeID]float64 {
+	rt.prune(now)
+
+	usages := make(map[ids.NodeID]float64, rt.meters.Len())
+	measuredProcessingTime := rt.processingMeter.Read(now)
+	rt.metrics.processingTimeMetric.Set(measuredProcessingTime)
+
+	if measuredProcessingTime == 0 {
+		return usages
+	}
+
+	it := rt.meters.NewIterator()
+	for it.Next() {
+		portionUsageByNode := it.Value().(meter.Meter).Read(now) / measuredProcessingTime
+		usages[it.Key().(ids.NodeID)] = realUsage * portionUsageByNode
+	}
+	return usages
+}
+
+// chainUsage returns the portion of [realUsage] attributed to messages handled
+// by [chainID].
+// assumes [rt.lock] is held.
+func (rt *resourceTracker) chainUsage(chainID ids.ID, now time.Time, realUsage float64) float64 {
+	measuredProcessingTime := rt.processingMeter.Read(now)
+	rt.metrics.processingTimeMetric.Set(measuredProcessingTime)
+
+	if measuredProcessingTime == 0 {
+		return 0
+	}
+
+	m, exists := rt.chainMeters[chainID]
+	if !exists {
+		return 0
+	}
+
+	portionUsageByChain := m.Read(now) / measuredProcessingTime
+	return realUsage * portionUsageByChain
+}
+
+// getChainMeter returns the meter used to measure CPU time spent processing
+// messages handled by [chainID].
+// assumes [rt.lock] is held.
+func (rt *resourceTracker) getChainMeter(chainID ids.ID) meter.Meter {
+	m, exists := rt.chainMeters[chainID]
+	if exists {
+		return m
+	}
+
+	newMeter := rt.factory.New(rt.halflife)
+	rt.chainMeters[chainID] = newMeter
+	return newMeter
+}
+
 // getMeter returns the meter used to measure CPU time spent processing
 // messages from [nodeID].
 // assumes [rt.lock] is held.
@@ -292,6 +455,11 @@ type trackerMetrics struct {
 	diskReadsMetric      prometheus.Gauge
 	diskWritesMetric     prometheus.Gauge
 	diskSpaceAvailable   prometheus.Gauge
+	memoryMetric         prometheus.Gauge
+	chainCPUMetric       *prometheus.GaugeVec
+	chainDiskReadsMetric *prometheus.GaugeVec
+	// Memory of the node's process estimated to be used by each chain
+	chainMemoryEstimateMetric *prometheus.GaugeVec
 }
 
 func newCPUTrackerMetrics(namespace string, reg prometheus.Registerer) (*trackerMetrics, error) {
@@ -321,6 +489,35 @@ func newCPUTrackerMetrics(namespace string, reg prometheus.Registerer) (*tracker
 			Name:      "disk_available_space",
 			Help:      "Available space remaining (bytes) on the database volume",
 		}),
+		memoryMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "memory_usage",
+			Help:      "Resident memory (bytes) tracked by the resource manager",
+		}),
+		chainCPUMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "chain_cpu_usage",
+				Help:      "CPU usage attributed to handling messages for each chain. Value should be in [0, number of CPU cores]",
+			},
+			[]string{"chain"},
+		),
+		chainDiskReadsMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "chain_disk_reads",
+				Help:      "Disk reads (bytes/sec) attributed to handling messages for each chain",
+			},
+			[]string{"chain"},
+		),
+		chainMemoryEstimateMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "chain_memory_usage_estimate",
+				Help:      "Resident memory (bytes) of the node's process, excluding plugins, estimated to be used by each chain from the portion of time spent handling its messages",
+			},
+			[]string{"chain"},
+		),
 	}
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -329,6 +526,10 @@ func newCPUTrackerMetrics(namespace string, reg prometheus.Registerer) (*tracker
 		reg.Register(m.diskReadsMetric),
 		reg.Register(m.diskWritesMetric),
 		reg.Register(m.diskSpaceAvailable),
+		reg.Register(m.memoryMetric),
+		reg.Register(m.chainCPUMetric),
+		reg.Register(m.chainDiskReadsMetric),
+		reg.Register(m.chainMemoryEstimateMetric),
 	)
 	return m, errs.Err
 }
@@ -4,12 +4,14 @@
 package tracker
 
 import (
+	"os"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/stretchr/testify/assert"
 
@@ -133,3 +135,60 @@ func TestCPUTrackerTimeUntilCPUUtilization(t *testing.T) {
 	// Make sure it returns the zero duration if the node isn't known
 	assert.Zero(t, cpuTracker.TimeUntilUsage(ids.GenerateTestNodeID(), now, 0.0001))
 }
+
+func TestChainTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	halflife := 5 * time.Second
+
+	ctrl := gomock.NewController(t)
+	mockUser := resource.NewMockUser(ctrl)
+	mockUser.EXPECT().CPUUsage().Return(1.0).AnyTimes()
+	mockUser.EXPECT().DiskUsage().Return(100.0, 0.0).AnyTimes()
+	mockUser.EXPECT().MemoryUsage().Return(uint64(1500)).AnyTimes()
+	mockUser.EXPECT().ProcessMemoryUsage(os.Getpid()).Return(uint64(1000)).AnyTimes()
+
+	tracker, err := NewResourceTracker(prometheus.NewRegistry(), mockUser, meter.ContinuousFactory{}, halflife)
+	assert.NoError(err)
+
+	chain1 := ids.ID{1}
+	chain2 := ids.ID{2}
+	node1 := ids.NodeID{1}
+	node2 := ids.NodeID{2}
+
+	// [node1] sends messages to [chain1] for twice as long as [node2] sends
+	// messages to [chain2].
+	startTime := time.Now()
+	tracker.StartChainProcessing(chain1, node1, startTime)
+	tracker.StopChainProcessing(chain1, node1, startTime.Add(2*halflife))
+	tracker.StartChainProcessing(chain2, node2, startTime.Add(2*halflife))
+	endTime := startTime.Add(3 * halflife)
+	tracker.StopChainProcessing(chain2, node2, endTime)
+
+	cpuTracker := tracker.CPUTracker()
+	chain1Usage := cpuTracker.ChainUsage(chain1, endTime)
+	chain2Usage := cpuTracker.ChainUsage(chain2, endTime)
+	assert.Positive(chain1Usage)
+	assert.Positive(chain2Usage)
+	assert.InDelta(cpuTracker.TotalUsage(), chain1Usage+chain2Usage, 1e-9)
+	assert.Zero(cpuTracker.ChainUsage(ids.ID{3}, endTime))
+
+	// All of the processing was done on behalf of a node and a chain, so the
+	// usage attributed to each node matches the usage attributed to its chain.
+	usages := cpuTracker.Usages(endTime)
+	assert.Len(usages, 2)
+	assert.InDelta(chain1Usage, usages[node1], 1e-9)
+	assert.InDelta(chain2Usage, usages[node2], 1e-9)
+
+	diskTracker := tracker.DiskTracker()
+	assert.InDelta(100*chain1Usage, diskTracker.ChainUsage(chain1, endTime), 1e-6)
+	assert.InDelta(100*chain2Usage, diskTracker.Usages(endTime)[node2], 1e-6)
+
+	// The memory of the node's process is estimated to be used by each chain
+	// in the same proportion as CPU. The estimate of [chain1] is updated even
+	// though it was idle when [chain2] finished processing.
+	metrics := tracker.(*resourceTracker).metrics
+	assert.Equal(1500.0, testutil.ToFloat64(metrics.memoryMetric))
+	assert.InDelta(1000*chain1Usage, testutil.ToFloat64(metrics.chainMemoryEstimateMetric.WithLabelValues(chain1.String())), 1e-6)
+	assert.InDelta(1000*chain2Usage, testutil.ToFloat64(metrics.chainMemoryEstimateMetric.WithLabelValues(chain2.String())), 1e-6)
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const rsaKeySize = 4096
+
+var errUnknownKeyType = errors.New("unknown key type")
+
+// KeyType is the type of key that signs a staking certificate.
+type KeyType byte
+
+const (
+	// RSA is a 4096 bit RSA key. This is the default staking key type.
+	RSA KeyType = iota
+	// ECDSA is an ECDSA key on the P-256 curve.
+	ECDSA
+	// Ed25519 is an ed25519 key. Both the certificate and the signatures it
+	// produces are significantly smaller, and cheaper to verify, than RSA.
+	Ed25519
+)
+
+// ParseKeyType returns the KeyType named [s].
+func ParseKeyType(s string) (KeyType, error) {
+	switch strings.ToLower(s) {
+	case "rsa":
+		return RSA, nil
+	case "ecdsa":
+		return ECDSA, nil
+	case "ed25519":
+		return Ed25519, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", errUnknownKeyType, s)
+	}
+}
+
+func (k KeyType) String() string {
+	switch k {
+	case RSA:
+		return "rsa"
+	case ECDSA:
+		return "ecdsa"
+	case Ed25519:
+		return "ed25519"
+	default:
+		return "unknown"
+	}
+}
+
+// CertKeyType returns the type of key that [cert] was issued for.
+func CertKeyType(cert *x509.Certificate) (KeyType, error) {
+	switch cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return RSA, nil
+	case *ecdsa.PublicKey:
+		return ECDSA, nil
+	case ed25519.PublicKey:
+		return Ed25519, nil
+	default:
+		return 0, fmt.Errorf("%w: %T", errUnknownKeyType, cert.PublicKey)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package staking
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// Sign returns the signature of [msg] by [signer]. The signature can be
+// verified with the signer's certificate using CheckSignature with the
+// certificate's SignatureAlgorithm.
+//
+// RSA and ECDSA keys sign the SHA256 hash of [msg], whereas ed25519 keys sign
+// [msg] directly.
+func Sign(signer crypto.Signer, msg []byte) ([]byte, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		return signer.Sign(rand.Reader, msg, crypto.Hash(0))
+	}
+	return signer.Sign(rand.Reader, hashing.ComputeHash256(msg), crypto.SHA256)
+}
@@ -5,6 +5,10 @@ package staking
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
@@ -22,13 +26,13 @@ import (
 // InitNodeStakingKeyPair generates a self-signed TLS key/cert pair to use in
 // staking. The key and files will be placed at [keyPath] and [certPath],
 // respectively. If there is already a file at [keyPath], returns nil.
-func InitNodeStakingKeyPair(keyPath, certPath string) error {
+func InitNodeStakingKeyPair(keyPath, certPath string, keyType KeyType) error {
 	// If there is already a file at [keyPath], do nothing
 	if _, err := os.Stat(keyPath); !os.IsNotExist(err) {
 		return nil
 	}
 
-	certBytes, keyBytes, err := NewCertAndKeyBytes()
+	certBytes, keyBytes, err := NewCertAndKeyBytesWithKeyType(keyType)
 	if err != nil {
 		return err
 	}
@@ -92,8 +96,15 @@ func LoadTLSCertFromFiles(keyPath, certPath string) (*tls.Certificate, error) {
 	return &cert, err
 }
 
+// NewTLSCert returns a new RSA staking certificate.
 func NewTLSCert() (*tls.Certificate, error) {
-	certBytes, keyBytes, err := NewCertAndKeyBytes()
+	return NewTLSCertWithKeyType(RSA)
+}
+
+// NewTLSCertWithKeyType returns a new staking certificate signed by a newly
+// generated key of type [keyType].
+func NewTLSCertWithKeyType(keyType KeyType) (*tls.Certificate, error) {
+	certBytes, keyBytes, err := NewCertAndKeyBytesWithKeyType(keyType)
 	if err != nil {
 		return nil, err
 	}
@@ -105,13 +116,26 @@ func NewTLSCert() (*tls.Certificate, error) {
 	return &cert, err
 }
 
-// Creates a new staking private key / staking certificate pair.
+// Creates a new RSA staking private key / staking certificate pair.
 // Returns the PEM byte representations of both.
 func NewCertAndKeyBytes() ([]byte, []byte, error) {
+	return NewCertAndKeyBytesWithKeyType(RSA)
+}
+
+// Creates a new staking private key / staking certificate pair using a key of
+// type [keyType]. Returns the PEM byte representations of both.
+func NewCertAndKeyBytesWithKeyType(keyType KeyType) ([]byte, []byte, error) {
 	// Create key to sign cert with
-	key, err := rsa.GenerateKey(rand.Reader, 4096)
+	key, err := newKey(keyType)
 	if err != nil {
-		return nil, nil, fmt.Errorf("couldn't generate rsa key: %w", err)
+		return nil, nil, fmt.Errorf("couldn't generate %s key: %w", keyType, err)
+	}
+
+	// Only RSA keys can be used for key encipherment. The other key types are
+	// only ever used to sign.
+	keyUsage := x509.KeyUsageDigitalSignature
+	if keyType == RSA {
+		keyUsage |= x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment
 	}
 
 	// Create self-signed staking cert
@@ -119,10 +143,10 @@ func NewCertAndKeyBytes() ([]byte, []byte, error) {
 		SerialNumber:          big.NewInt(0),
 		NotBefore:             time.Date(2000, time.January, 0, 0, 0, 0, 0, time.UTC),
 		NotAfter:              time.Now().AddDate(100, 0, 0),
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageDataEncipherment,
+		KeyUsage:              keyUsage,
 		BasicConstraintsValid: true,
 	}
-	certBytes, err := x509.CreateCertificate(rand.Reader, certTemplate, certTemplate, &key.PublicKey, key)
+	certBytes, err := x509.CreateCertificate(rand.Reader, certTemplate, certTemplate, key.Public(), key)
 	if err != nil {
 		return nil, nil, fmt.Errorf("couldn't create certificate: %w", err)
 	}
@@ -142,3 +166,17 @@ func NewCertAndKeyBytes() ([]byte, []byte, error) {
 	}
 	return certBuff.Bytes(), keyBuff.Bytes(), nil
 }
+
+func newKey(keyType KeyType) (crypto.Signer, error) {
+	switch keyType {
+	case RSA:
+		return rsa.GenerateKey(rand.Reader, rsaKeySize)
+	case ECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case Ed25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnknownKeyType, keyType)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"crypto"
 	"crypto/rand"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -29,3 +30,55 @@ func TestMakeKeys(t *testing.T) {
 	err = cert.Leaf.CheckSignature(cert.Leaf.SignatureAlgorithm, msg, sig)
 	assert.NoError(err)
 }
+
+func TestKeyTypes(t *testing.T) {
+	for _, keyType := range []KeyType{RSA, ECDSA, Ed25519} {
+		t.Run(keyType.String(), func(t *testing.T) {
+			assert := assert.New(t)
+
+			parsedKeyType, err := ParseKeyType(keyType.String())
+			assert.NoError(err)
+			assert.Equal(keyType, parsedKeyType)
+
+			cert, err := NewTLSCertWithKeyType(keyType)
+			assert.NoError(err)
+
+			certKeyType, err := CertKeyType(cert.Leaf)
+			assert.NoError(err)
+			assert.Equal(keyType, certKeyType)
+
+			msg := []byte(fmt.Sprintf("msg %d", time.Now().Unix()))
+			sig, err := Sign(cert.PrivateKey.(crypto.Signer), msg)
+			assert.NoError(err)
+
+			err = cert.Leaf.CheckSignature(cert.Leaf.SignatureAlgorithm, msg, sig)
+			assert.NoError(err)
+
+			err = cert.Leaf.CheckSignature(cert.Leaf.SignatureAlgorithm, append(msg, 0), sig)
+			assert.Error(err)
+		})
+	}
+}
+
+func TestParseKeyTypeUnknown(t *testing.T) {
+	_, err := ParseKeyType("dsa")
+	assert.ErrorIs(t, err, errUnknownKeyType)
+}
+
+func TestInitNodeStakingKeyPair(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "staking", "staker.key")
+	certPath := filepath.Join(dir, "staking", "staker.crt")
+
+	err := InitNodeStakingKeyPair(keyPath, certPath, Ed25519)
+	assert.NoError(err)
+
+	cert, err := LoadTLSCertFromFiles(keyPath, certPath)
+	assert.NoError(err)
+
+	keyType, err := CertKeyType(cert.Leaf)
+	assert.NoError(err)
+	assert.Equal(Ed25519, keyType)
+}
@@ -0,0 +1,464 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package localnetwork runs an Avalanche network inside the current process.
+//
+// Every node of the network is a full avalanchego node that stores its state in
+// memory and connects to its peers over the loopback interface, so the network
+// exercises the same networking, consensus, and VM code as a deployed network
+// without requiring any external binaries. This allows VM developers to write
+// fast integration tests against a real network.
+//
+// The local time of every P-chain in the network is controlled by the network,
+// which allows tests to add stakers and then move time forward to when they
+// start and stop validating.
+package localnetwork
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+const (
+	// DefaultNetworkID is the ID of the network if none is provided
+	DefaultNetworkID uint32 = 1337
+
+	// DefaultNumNodes is the number of genesis validators if none is provided
+	DefaultNumNodes = 5
+
+	// stakerStartDelay is how far after the current time stakers added by the
+	// network start staking. Stakers must start after the synchrony bound of
+	// the P-chain.
+	stakerStartDelay = 30 * time.Second
+
+	pollFrequency = 50 * time.Millisecond
+)
+
+var (
+	errInvalidNumNodes   = errors.New("network must have at least one node")
+	errStandardNetworkID = errors.New("network ID is reserved for a standard network")
+	errTimeMovedBack     = errors.New("time can't be moved backwards")
+	errStopped           = errors.New("network has been stopped")
+)
+
+// Config describes the network to run
+type Config struct {
+	// NetworkID of the network. Defaults to [DefaultNetworkID].
+	NetworkID uint32
+	// NumNodes is the number of validators in the genesis of the network.
+	// Defaults to [DefaultNumNodes].
+	NumNodes int
+	// PluginDir is the directory that the nodes load VM plugins from. If
+	// empty, the nodes only run the built-in VMs.
+	PluginDir string
+	// LogLevel of the nodes' log files. Defaults to "info".
+	LogLevel string
+	// Flags are additional node flags passed to every node of the network.
+	// They take precedence over the flags set by the network.
+	Flags map[string]interface{}
+}
+
+// Network is a set of nodes that run in this process.
+//
+// The network is funded by [genesis.EWOQKey], which is used to issue the
+// transactions of the helpers.
+type Network struct {
+	config  Config
+	dir     string
+	genesis string
+
+	lock sync.Mutex
+	// [now] is the local time of every P-chain in the network. If [now] is
+	// zero, the P-chains follow the wall clock.
+	now          time.Time
+	genesisNodes []*Node
+	nodes        []*Node
+	wallet       primary.Wallet
+	stopped      bool
+}
+
+// New starts a network of [config.NumNodes] validators and blocks until all of
+// them have bootstrapped.
+//
+// The returned network must be stopped with Stop.
+func New(ctx context.Context, config Config) (*Network, error) {
+	if config.NetworkID == 0 {
+		config.NetworkID = DefaultNetworkID
+	}
+	if config.NumNodes == 0 {
+		config.NumNodes = DefaultNumNodes
+	}
+	if config.LogLevel == "" {
+		config.LogLevel = "info"
+	}
+	switch {
+	case config.NumNodes < 0:
+		return nil, errInvalidNumNodes
+	case config.NetworkID == constants.MainnetID,
+		config.NetworkID == constants.FujiID,
+		config.NetworkID == constants.CopycoID,
+		config.NetworkID == constants.LocalID:
+		return nil, fmt.Errorf("%w: %d", errStandardNetworkID, config.NetworkID)
+	}
+
+	keys := make([]*nodeKeys, config.NumNodes)
+	for i := range keys {
+		k, err := newNodeKeys()
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = k
+	}
+
+	genesisContent, err := newGenesis(config.NetworkID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create genesis: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "localnetwork")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create network directory: %w", err)
+	}
+
+	n := &Network{
+		config:  config,
+		dir:     dir,
+		genesis: genesisContent,
+	}
+
+	// The first node starts without any beacons and the rest of the genesis
+	// validators bootstrap from it.
+	for i, k := range keys {
+		var bootstrappers []*Node
+		if i > 0 {
+			bootstrappers = n.genesisNodes[:1]
+		}
+		nd, err := n.startNode(k, bootstrappers, time.Time{})
+		if err != nil {
+			n.Stop()
+			return nil, err
+		}
+		n.genesisNodes = append(n.genesisNodes, nd)
+	}
+	for _, nd := range n.genesisNodes {
+		if err := nd.awaitBootstrapped(ctx); err != nil {
+			n.Stop()
+			return nil, err
+		}
+	}
+	n.nodes = append(n.nodes, n.genesisNodes...)
+	return n, nil
+}
+
+// newGenesis returns the base64 encoded genesis of a network whose initial
+// validators are [keys]. The genesis allocations are the same as the local
+// network's.
+func newGenesis(networkID uint32, keys []*nodeKeys) (string, error) {
+	config := genesis.LocalConfig
+	config.NetworkID = networkID
+	config.StartTime = uint64(time.Now().Unix())
+
+	rewardAddr := genesis.EWOQKey.PublicKey().Address()
+	config.InitialStakers = make([]genesis.Staker, len(keys))
+	for i, k := range keys {
+		config.InitialStakers[i] = genesis.Staker{
+			NodeID:        k.nodeID,
+			RewardAddress: rewardAddr,
+			DelegationFee: reward.PercentDenominator,
+		}
+	}
+
+	unparsedConfig, err := config.Unparse()
+	if err != nil {
+		return "", err
+	}
+	genesisBytes, err := json.Marshal(unparsedConfig)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(genesisBytes), nil
+}
+
+// Nodes returns the running nodes of the network. The genesis validators are
+// first, followed by the nodes in the order they were added.
+func (n *Network) Nodes() []*Node {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	nodes := make([]*Node, len(n.nodes))
+	copy(nodes, n.nodes)
+	return nodes
+}
+
+// AddNode starts a new node that bootstraps from the genesis validators and
+// blocks until it has bootstrapped. The node doesn't validate until it's added
+// as a validator.
+func (n *Network) AddNode(ctx context.Context) (*Node, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.stopped {
+		return nil, errStopped
+	}
+
+	keys, err := newNodeKeys()
+	if err != nil {
+		return nil, err
+	}
+	nd, err := n.startNode(keys, n.genesisNodes, n.now)
+	if err != nil {
+		return nil, err
+	}
+	if err := nd.awaitBootstrapped(ctx); err != nil {
+		nd.stop()
+		return nil, err
+	}
+	n.nodes = append(n.nodes, nd)
+	return nd, nil
+}
+
+// Now returns the local time of the P-chains of the network
+func (n *Network) Now() time.Time {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.getNow()
+}
+
+func (n *Network) getNow() time.Time {
+	if n.now.IsZero() {
+		return time.Now()
+	}
+	return n.now
+}
+
+// AdvanceTime moves the local time of every P-chain in the network forward by
+// [duration]. After the first call, the P-chains no longer follow the wall
+// clock.
+//
+// Stakers whose start or end time is reached are added to or removed from the
+// validator set by the P-chain asynchronously.
+func (n *Network) AdvanceTime(duration time.Duration) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if duration < 0 {
+		return errTimeMovedBack
+	}
+	n.setTime(n.getNow().Add(duration))
+	return nil
+}
+
+func (n *Network) setTime(t time.Time) {
+	n.now = t
+	for _, nd := range n.nodes {
+		nd.setTime(t)
+	}
+}
+
+// Wallet returns a wallet that issues transactions with the funds of
+// [genesis.EWOQKey] to the first node of the network.
+//
+// The wallet is shared with the helpers of the network. Transactions issued
+// with the same key through any other wallet will cause the wallet to go out
+// of sync.
+func (n *Network) Wallet(ctx context.Context) (primary.Wallet, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.getWallet(ctx)
+}
+
+func (n *Network) getWallet(ctx context.Context) (primary.Wallet, error) {
+	if n.stopped {
+		return nil, errStopped
+	}
+	if n.wallet != nil {
+		return n.wallet, nil
+	}
+
+	kc := secp256k1fx.NewKeychain(genesis.EWOQKey)
+	wallet, err := primary.NewWalletFromURI(ctx, n.genesisNodes[0].URI, kc)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create wallet: %w", err)
+	}
+	n.wallet = primary.NewWalletWithOptions(wallet, common.WithPollFrequency(pollFrequency))
+	return n.wallet, nil
+}
+
+// AddValidator adds [nodeID] as a validator of the primary network that stakes
+// [weight] for [duration]. The time of the network is advanced to the start of
+// the validation period, and AddValidator blocks until every node of the
+// network considers [nodeID] to be a validator.
+func (n *Network) AddValidator(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	weight uint64,
+	duration time.Duration,
+) (ids.ID, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	wallet, err := n.getWallet(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	vdr := n.newValidator(nodeID, weight, duration)
+	txID, err := wallet.P().IssueAddValidatorTx(
+		vdr,
+		n.owner(),
+		reward.PercentDenominator,
+		common.WithContext(ctx),
+	)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("couldn't add validator %s: %w", nodeID, err)
+	}
+	return txID, n.startStaking(ctx, constants.PrimaryNetworkID, vdr)
+}
+
+// CreateSubnet creates a new subnet that is owned by [genesis.EWOQKey]
+func (n *Network) CreateSubnet(ctx context.Context) (ids.ID, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	wallet, err := n.getWallet(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	subnetID, err := wallet.P().IssueCreateSubnetTx(
+		n.owner(),
+		common.WithContext(ctx),
+	)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("couldn't create subnet: %w", err)
+	}
+	return subnetID, nil
+}
+
+// AddSubnetValidator adds [nodeID] as a validator of [subnetID] with [weight]
+// for [duration]. The node must be validating the primary network for the whole
+// period. The time of the network is advanced to the start of the validation
+// period, and AddSubnetValidator blocks until every node of the network
+// considers [nodeID] to be a validator of [subnetID].
+//
+// The node only runs the chains of [subnetID] once it tracks the subnet.
+func (n *Network) AddSubnetValidator(
+	ctx context.Context,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	weight uint64,
+	duration time.Duration,
+) (ids.ID, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	wallet, err := n.getWallet(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	vdr := n.newValidator(nodeID, weight, duration)
+	txID, err := wallet.P().IssueAddSubnetValidatorTx(
+		&validator.SubnetValidator{
+			Validator: *vdr,
+			Subnet:    subnetID,
+		},
+		common.WithContext(ctx),
+	)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("couldn't add validator %s to subnet %s: %w", nodeID, subnetID, err)
+	}
+	return txID, n.startStaking(ctx, subnetID, vdr)
+}
+
+// newValidator returns a validation period of [duration] that starts soon
+// enough to be accepted by the P-chain
+func (n *Network) newValidator(nodeID ids.NodeID, weight uint64, duration time.Duration) *validator.Validator {
+	startTime := n.getNow().Add(stakerStartDelay)
+	return &validator.Validator{
+		NodeID: nodeID,
+		Start:  uint64(startTime.Unix()),
+		End:    uint64(startTime.Add(duration).Unix()),
+		Wght:   weight,
+	}
+}
+
+// startStaking advances the time of the network to the start of [vdr] and
+// blocks until every node reports [vdr] as a current validator of [subnetID].
+func (n *Network) startStaking(ctx context.Context, subnetID ids.ID, vdr *validator.Validator) error {
+	n.setTime(vdr.StartTime())
+
+	ticker := time.NewTicker(pollFrequency)
+	defer ticker.Stop()
+
+	nodeIDs := []ids.NodeID{vdr.NodeID}
+	for _, nd := range n.nodes {
+		client := platformvm.NewClient(nd.URI)
+		for {
+			vdrs, err := client.GetCurrentValidators(ctx, subnetID, nodeIDs)
+			if err == nil && len(vdrs) > 0 {
+				break
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return fmt.Errorf("validator %s didn't start on node %s: %w", vdr.NodeID, nd.ID, ctx.Err())
+			}
+		}
+	}
+	return nil
+}
+
+func (n *Network) owner() *secp256k1fx.OutputOwners {
+	return &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs: []ids.ShortID{
+			genesis.EWOQKey.PublicKey().Address(),
+		},
+	}
+}
+
+// Stop shuts down every node of the network and removes their files. Stop
+// must be called once the network is no longer needed.
+//
+// Stopping a node cleans up the VM plugin processes of every node in this
+// process, so the nodes of a network can't be stopped individually.
+func (n *Network) Stop() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.stopped {
+		return nil
+	}
+	n.stopped = true
+
+	nodes := n.nodes
+	if len(nodes) == 0 {
+		// The genesis validators are only tracked in [n.nodes] once they
+		// have bootstrapped
+		nodes = n.genesisNodes
+	}
+	for _, nd := range nodes {
+		nd.stop()
+	}
+	return os.RemoveAll(n.dir)
+}
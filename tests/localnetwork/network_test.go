@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package localnetwork
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+func TestNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in-process network in short mode")
+	}
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	network, err := New(ctx, Config{
+		NumNodes: 3,
+	})
+	assert.NoError(err)
+	defer func() {
+		assert.NoError(network.Stop())
+	}()
+	assert.Len(network.Nodes(), 3)
+
+	nd, err := network.AddNode(ctx)
+	assert.NoError(err)
+	assert.Len(network.Nodes(), 4)
+
+	_, err = network.AddValidator(ctx, nd.ID, 2*units.KiloAvax, 48*time.Hour)
+	assert.NoError(err)
+
+	subnetID, err := network.CreateSubnet(ctx)
+	assert.NoError(err)
+	assert.NoError(nd.TrackSubnet(ctx, subnetID))
+
+	_, err = network.AddSubnetValidator(ctx, subnetID, nd.ID, 1, 24*time.Hour)
+	assert.NoError(err)
+
+	// Once the validation period is over, the subnet validator should be
+	// removed from the validator set
+	assert.NoError(network.AdvanceTime(25 * time.Hour))
+	client := platformvm.NewClient(nd.URI)
+	for {
+		vdrs, err := client.GetCurrentValidators(ctx, subnetID, []ids.NodeID{nd.ID})
+		assert.NoError(err)
+		if len(vdrs) == 0 {
+			break
+		}
+
+		select {
+		case <-time.After(pollFrequency):
+		case <-ctx.Done():
+			t.Fatal("subnet validator wasn't removed")
+		}
+	}
+}
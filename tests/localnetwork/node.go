@@ -0,0 +1,295 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package localnetwork
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/admin"
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+// bootstrappedChains are the chains that must be bootstrapped before a node is
+// considered to be running
+var bootstrappedChains = []string{"P", "X", "C"}
+
+// Node is an avalanchego node that runs in this process
+type Node struct {
+	// ID of the node
+	ID ids.NodeID
+	// URI of the node's HTTP API server
+	URI string
+	// StakingAddress is the IP and port the node accepts peer connections on
+	StakingAddress string
+
+	node       *node.Node
+	log        logging.Logger
+	logFactory logging.Factory
+	done       chan struct{}
+
+	// [platformVM] and [platformCtx] are set when the node creates its
+	// P-chain
+	lock        sync.Mutex
+	startTime   time.Time
+	platformVM  *platformvm.VM
+	platformCtx *snow.Context
+}
+
+// nodeKeys are the staking credentials of a node
+type nodeKeys struct {
+	nodeID  ids.NodeID
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newNodeKeys() (*nodeKeys, error) {
+	certPEM, keyPEM, err := staking.NewCertAndKeyBytes()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate staking certificate: %w", err)
+	}
+	cert, err := staking.LoadTLSCertFromBytes(keyPEM, certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse staking certificate: %w", err)
+	}
+	return &nodeKeys{
+		nodeID:  ids.NodeIDFromCert(cert.Leaf),
+		certPEM: certPEM,
+		keyPEM:  keyPEM,
+	}, nil
+}
+
+// startNode starts a node with [keys] that bootstraps from [bootstrappers].
+// [startTime] is the initial local time of the node's P-chain. If
+// [startTime] is zero, the P-chain follows the wall clock.
+func (n *Network) startNode(keys *nodeKeys, bootstrappers []*Node, startTime time.Time) (*Node, error) {
+	httpPort, err := getFreePort()
+	if err != nil {
+		return nil, err
+	}
+	stakingPort, err := getFreePort()
+	if err != nil {
+		return nil, err
+	}
+
+	dataDir := filepath.Join(n.dir, keys.nodeID.String())
+	buildDir := filepath.Join(dataDir, "build")
+	if err := os.MkdirAll(filepath.Join(buildDir, "plugins"), perms.ReadWriteExecute); err != nil {
+		return nil, fmt.Errorf("couldn't create build directory: %w", err)
+	}
+
+	flags := map[string]interface{}{
+		config.NetworkNameKey:                   n.config.NetworkID,
+		config.GenesisConfigContentKey:          n.genesis,
+		config.DataDirKey:                       dataDir,
+		config.BuildDirKey:                      buildDir,
+		config.DBTypeKey:                        "memdb",
+		config.LogLevelKey:                      n.config.LogLevel,
+		config.LogDisplayLevelKey:               "off",
+		config.HTTPHostKey:                      "127.0.0.1",
+		config.HTTPPortKey:                      httpPort,
+		config.HTTPShutdownWaitKey:              0,
+		config.PublicIPKey:                      "127.0.0.1",
+		config.StakingPortKey:                   stakingPort,
+		config.StakingKeyContentKey:             base64.StdEncoding.EncodeToString(keys.keyPEM),
+		config.StakingCertContentKey:            base64.StdEncoding.EncodeToString(keys.certPEM),
+		config.StakingEphemeralSignerEnabledKey: true,
+		config.AdminAPIEnabledKey:               true,
+		config.MeterVMsEnabledKey:               false,
+
+		// Every node connects from the same IP, so inbound connections
+		// mustn't be rate-limited by IP
+		config.InboundConnUpgradeThrottlerCooldownKey: 0,
+	}
+	var (
+		bootstrapIPs string
+		bootstrapIDs string
+	)
+	for i, bootstrapper := range bootstrappers {
+		if i > 0 {
+			bootstrapIPs += ","
+			bootstrapIDs += ","
+		}
+		bootstrapIPs += bootstrapper.StakingAddress
+		bootstrapIDs += bootstrapper.ID.String()
+	}
+	flags[config.BootstrapIPsKey] = bootstrapIPs
+	flags[config.BootstrapIDsKey] = bootstrapIDs
+	for key, value := range n.config.Flags {
+		flags[key] = value
+	}
+
+	args := make([]string, 0, len(flags))
+	for key, value := range flags {
+		args = append(args, fmt.Sprintf("--%s=%v", key, value))
+	}
+	v, err := config.BuildViper(config.BuildFlagSet(), args)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse node flags: %w", err)
+	}
+	nodeConfig, err := config.GetNodeConfig(v, buildDir)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load node config: %w", err)
+	}
+	if n.config.PluginDir != "" {
+		nodeConfig.PluginDir = n.config.PluginDir
+	}
+
+	nd := &Node{
+		ID:             keys.nodeID,
+		URI:            fmt.Sprintf("http://127.0.0.1:%d", httpPort),
+		StakingAddress: fmt.Sprintf("127.0.0.1:%d", stakingPort),
+		node:           &node.Node{},
+		done:           make(chan struct{}),
+		startTime:      startTime,
+	}
+	nodeConfig.VMManager = &vmManager{
+		Manager: nodeConfig.VMManager,
+		node:    nd,
+	}
+
+	nd.logFactory = logging.NewFactory(nodeConfig.LoggingConfig)
+	nd.log, err = nd.logFactory.Make("main")
+	if err != nil {
+		nd.logFactory.Close()
+		return nil, err
+	}
+	if err := nd.node.Initialize(&nodeConfig, nd.log, nd.logFactory); err != nil {
+		nd.log.Stop()
+		nd.logFactory.Close()
+		return nil, fmt.Errorf("couldn't initialize node: %w", err)
+	}
+
+	go func() {
+		defer close(nd.done)
+		err := nd.node.Dispatch()
+		nd.log.Debug("dispatch returned with: %s", err)
+	}()
+	return nd, nil
+}
+
+// awaitBootstrapped blocks until the node has finished bootstrapping the
+// chains of the primary network or [ctx] is done.
+func (nd *Node) awaitBootstrapped(ctx context.Context) error {
+	client := info.NewClient(nd.URI)
+	ticker := time.NewTicker(pollFrequency)
+	defer ticker.Stop()
+
+	for _, chain := range bootstrappedChains {
+		for {
+			bootstrapped, err := client.IsBootstrapped(ctx, chain)
+			if err == nil && bootstrapped {
+				break
+			}
+
+			select {
+			case <-ticker.C:
+			case <-nd.done:
+				return fmt.Errorf("node %s stopped before bootstrapping %s", nd.ID, chain)
+			case <-ctx.Done():
+				return fmt.Errorf("node %s didn't bootstrap %s: %w", nd.ID, chain, ctx.Err())
+			}
+		}
+	}
+	return nil
+}
+
+// TrackSubnet starts running the chains of [subnetID] on this node
+func (nd *Node) TrackSubnet(ctx context.Context, subnetID ids.ID) error {
+	return admin.NewClient(nd.URI).TrackSubnet(ctx, subnetID)
+}
+
+// setTime sets the local time of the node's P-chain to [t]
+func (nd *Node) setTime(t time.Time) {
+	nd.lock.Lock()
+	defer nd.lock.Unlock()
+
+	nd.startTime = t
+	if nd.platformVM == nil {
+		return
+	}
+
+	nd.platformCtx.Lock.Lock()
+	defer nd.platformCtx.Lock.Unlock()
+
+	nd.platformVM.SetTime(t)
+}
+
+// stop shuts down the node and blocks until it has exited
+func (nd *Node) stop() {
+	nd.node.Shutdown(0)
+	<-nd.done
+	nd.log.Stop()
+	nd.logFactory.Close()
+}
+
+// vmManager records the P-chain VM created by a node so that the network can
+// control its local time
+type vmManager struct {
+	vms.Manager
+	node *Node
+}
+
+func (m *vmManager) GetFactory(vmID ids.ID) (vms.Factory, error) {
+	factory, err := m.Manager.GetFactory(vmID)
+	if err != nil || vmID != constants.PlatformVMID {
+		return factory, err
+	}
+	return &platformVMFactory{
+		Factory: factory,
+		node:    m.node,
+	}, nil
+}
+
+type platformVMFactory struct {
+	vms.Factory
+	node *Node
+}
+
+func (f *platformVMFactory) New(ctx *snow.Context) (interface{}, error) {
+	vmIntf, err := f.Factory.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vm, ok := vmIntf.(*platformvm.VM)
+	if !ok {
+		return vmIntf, nil
+	}
+
+	f.node.lock.Lock()
+	defer f.node.lock.Unlock()
+
+	if !f.node.startTime.IsZero() {
+		vm.Clock().Set(f.node.startTime)
+	}
+	f.node.platformVM = vm
+	f.node.platformCtx = ctx
+	return vm, nil
+}
+
+// getFreePort returns a port on the loopback interface that isn't in use
+func getFreePort() (uint16, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("couldn't find a free port: %w", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	return uint16(port), listener.Close()
+}
@@ -0,0 +1,374 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulation runs the snowman consensus engine of several nodes over a
+// simulated network. Time is virtual and every source of randomness is seeded,
+// so a simulation with a given seed always delivers the same messages in the
+// same order. This allows partition and latency scenarios to be reproduced
+// exactly.
+//
+// Each node runs the real chain router, sender, handler and snowman engine.
+// Only the peer-to-peer network, the request timeouts and the VM are
+// simulated.
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/heap"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	DefaultNumNodes        = 5
+	DefaultMinLatency      = 10 * time.Millisecond
+	DefaultMaxLatency      = 100 * time.Millisecond
+	DefaultRequestTimeout  = 2 * time.Second
+	DefaultGossipFrequency = 10 * time.Second
+)
+
+var (
+	// chainID is the ID of the chain every node runs
+	chainID = ids.ID{'s', 'i', 'm'}
+
+	// startTime is the virtual time every simulation starts at
+	startTime = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	errInvalidLatency  = errors.New("min latency must be non-negative and no larger than max latency")
+	errInvalidDropRate = errors.New("drop rate must be in [0, 1]")
+)
+
+// Config describes a simulation
+type Config struct {
+	// Seed of every source of randomness in the simulation
+	Seed int64
+	// NumNodes is the number of validators. Each validator has the same weight.
+	// Defaults to DefaultNumNodes.
+	NumNodes int
+	// Every message is delayed by a latency chosen uniformly from
+	// [MinLatency, MaxLatency]. Messages may therefore be reordered. Default
+	// to DefaultMinLatency and DefaultMaxLatency.
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// DropRate is the probability that a message is lost
+	DropRate float64
+	// RequestTimeout is how long a node waits for a response to a request.
+	// Defaults to DefaultRequestTimeout.
+	RequestTimeout time.Duration
+	// GossipFrequency is how often each node gossips its last accepted block.
+	// Defaults to DefaultGossipFrequency.
+	GossipFrequency time.Duration
+	// Params of the consensus engines. If K is zero, every node queries every
+	// validator.
+	//
+	// The engine doesn't order the validators it sends push and pull queries
+	// to, so the simulation is only deterministic if MixedQueryNumPushVdr is
+	// either 0 or K.
+	Params snowball.Parameters
+}
+
+// Network is a set of nodes connected by a simulated network. It isn't safe
+// for concurrent use.
+type Network struct {
+	config Config
+	clock  mockable.Clock
+	rng    *rand.Rand
+
+	// events that haven't happened yet, ordered by the time they happen at
+	// and then the order in which they were scheduled
+	events    heap.Heap[*event]
+	numEvents uint64
+
+	nodes     []*Node
+	nodesByID map[ids.NodeID]*Node
+	// partitions maps each node to the partition it's in. Nodes that aren't in
+	// the map are in partition 0.
+	partitions map[ids.NodeID]int
+
+	trace []string
+	err   error
+}
+
+// event is a function that is called at a point in virtual time
+type event struct {
+	time time.Time
+	seq  uint64
+	fn   func() error
+}
+
+// New returns a network of validators that have all accepted the same genesis
+// block and are connected to each other
+func New(config Config) (*Network, error) {
+	if config.NumNodes == 0 {
+		config.NumNodes = DefaultNumNodes
+	}
+	if config.MinLatency == 0 && config.MaxLatency == 0 {
+		config.MinLatency = DefaultMinLatency
+		config.MaxLatency = DefaultMaxLatency
+	}
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = DefaultRequestTimeout
+	}
+	if config.GossipFrequency == 0 {
+		config.GossipFrequency = DefaultGossipFrequency
+	}
+	if config.Params.K == 0 {
+		config.Params = defaultParams(config.NumNodes)
+	}
+	if err := verifyLatency(config.MinLatency, config.MaxLatency); err != nil {
+		return nil, err
+	}
+	if err := verifyDropRate(config.DropRate); err != nil {
+		return nil, err
+	}
+	if err := config.Params.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid consensus parameters: %w", err)
+	}
+
+	// Validators are sampled using the global sampler, so simulations must not
+	// run concurrently to be deterministic.
+	sampler.Seed(config.Seed)
+
+	n := &Network{
+		config: config,
+		// #nosec G404
+		rng: rand.New(rand.NewSource(config.Seed)),
+		events: heap.New(func(a, b *event) bool {
+			if !a.time.Equal(b.time) {
+				return a.time.Before(b.time)
+			}
+			return a.seq < b.seq
+		}),
+		nodesByID:  make(map[ids.NodeID]*Node, config.NumNodes),
+		partitions: make(map[ids.NodeID]int),
+	}
+	n.clock.Set(startTime)
+
+	nodeIDs := make([]ids.NodeID, config.NumNodes)
+	for i := range nodeIDs {
+		nodeIDs[i] = ids.NodeID{byte(i >> 8), byte(i), 1}
+	}
+	for _, nodeID := range nodeIDs {
+		nd, err := n.newNode(nodeID, nodeIDs)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create node %s: %w", nodeID, err)
+		}
+		n.nodes = append(n.nodes, nd)
+		n.nodesByID[nodeID] = nd
+	}
+	for _, nd := range n.nodes {
+		for _, peer := range n.nodes {
+			if peer != nd {
+				nd.router.Connected(peer.ID, version.CurrentApp, constants.PrimaryNetworkID)
+			}
+		}
+		n.scheduleGossip(nd)
+	}
+	return n, nil
+}
+
+func defaultParams(numNodes int) snowball.Parameters {
+	return snowball.Parameters{
+		K:                       numNodes,
+		Alpha:                   numNodes/2 + 1,
+		BetaVirtuous:            5,
+		BetaRogue:               10,
+		ConcurrentRepolls:       4,
+		OptimalProcessing:       10,
+		MaxOutstandingItems:     256,
+		MaxItemProcessingTime:   time.Minute,
+		MixedQueryNumPushVdr:    numNodes,
+		MixedQueryNumPushNonVdr: numNodes,
+	}
+}
+
+func verifyLatency(min, max time.Duration) error {
+	if min < 0 || min > max {
+		return errInvalidLatency
+	}
+	return nil
+}
+
+func verifyDropRate(rate float64) error {
+	if rate < 0 || rate > 1 {
+		return errInvalidDropRate
+	}
+	return nil
+}
+
+// Nodes returns the nodes in the network
+func (n *Network) Nodes() []*Node { return n.nodes }
+
+// Now returns the current virtual time
+func (n *Network) Now() time.Time { return n.clock.Time() }
+
+// Trace returns a description of every message the nodes have processed, in
+// the order they were processed. Simulations with the same config and the same
+// actions produce the same trace.
+func (n *Network) Trace() []string { return n.trace }
+
+// SetLatency changes the range of latencies of messages sent from now on
+func (n *Network) SetLatency(min, max time.Duration) error {
+	if err := verifyLatency(min, max); err != nil {
+		return err
+	}
+	n.config.MinLatency = min
+	n.config.MaxLatency = max
+	return nil
+}
+
+// SetDropRate changes the probability that messages sent from now on are lost
+func (n *Network) SetDropRate(rate float64) error {
+	if err := verifyDropRate(rate); err != nil {
+		return err
+	}
+	n.config.DropRate = rate
+	return nil
+}
+
+// Partition splits the network so that nodes can only send messages to nodes
+// in the same group. Nodes that aren't in any of [groups] form a group of
+// their own. Messages that are already in flight are still delivered.
+func (n *Network) Partition(groups ...[]ids.NodeID) {
+	n.partitions = make(map[ids.NodeID]int)
+	for i, group := range groups {
+		for _, nodeID := range group {
+			n.partitions[nodeID] = i + 1
+		}
+	}
+}
+
+// Heal removes any partition
+func (n *Network) Heal() { n.Partition() }
+
+// Step processes the next event. Returns false if there are no events left.
+func (n *Network) Step() (bool, error) {
+	if n.err != nil {
+		return false, n.err
+	}
+
+	for n.events.Len() > 0 {
+		ev := n.events.Pop()
+		n.clock.Set(ev.time)
+		for _, nd := range n.nodes {
+			nd.mc.SetTime(ev.time)
+		}
+		if err := ev.fn(); err != nil {
+			n.err = err
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// RunFor processes every event that happens in the next [d] and advances the
+// virtual time by [d]
+func (n *Network) RunFor(d time.Duration) error {
+	end := n.clock.Time().Add(d)
+	for n.events.Len() > 0 && !n.events.Peek().time.After(end) {
+		if _, err := n.Step(); err != nil {
+			return err
+		}
+	}
+	n.clock.Set(end)
+	return nil
+}
+
+// RunUntil processes events until [done] returns true or [timeout] has passed.
+// [done] is checked after every event. Returns whether [done] returned true.
+func (n *Network) RunUntil(done func() bool, timeout time.Duration) (bool, error) {
+	end := n.clock.Time().Add(timeout)
+	for !done() {
+		if n.events.Len() == 0 || n.events.Peek().time.After(end) {
+			n.clock.Set(end)
+			return false, nil
+		}
+		if _, err := n.Step(); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// schedule calls [fn] after [delay]
+func (n *Network) schedule(delay time.Duration, fn func() error) {
+	ev := &event{
+		time: n.clock.Time().Add(delay),
+		seq:  n.numEvents,
+		fn:   fn,
+	}
+	n.numEvents++
+	n.events.Push(ev)
+}
+
+// scheduleGossip makes [nd] gossip periodically
+func (n *Network) scheduleGossip(nd *Node) {
+	n.schedule(n.config.GossipFrequency, func() error {
+		n.scheduleGossip(nd)
+		return nd.handle(nd.mc.InternalGossipRequest(nd.ID))
+	})
+}
+
+// send [msg] from [from] to each of [nodeIDs]. Returns the nodes the message
+// was sent to, including those it will be lost on the way to.
+func (n *Network) send(from *Node, msg message.OutboundMessage, nodeIDs []ids.NodeID) ids.NodeIDSet {
+	// [msg]'s bytes may be reused once the sender is done with it
+	msgBytes := make([]byte, len(msg.Bytes()))
+	copy(msgBytes, msg.Bytes())
+
+	ids.SortNodeIDs(nodeIDs)
+	sentTo := ids.NewNodeIDSet(len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		to, ok := n.nodesByID[nodeID]
+		if !ok {
+			continue
+		}
+		sentTo.Add(nodeID)
+
+		// The randomness is drawn for every message, regardless of whether
+		// it's delivered, so that partitions don't change the fate of other
+		// messages.
+		dropped := n.rng.Float64() < n.config.DropRate
+		latency := n.config.MinLatency + time.Duration(n.rng.Int63n(int64(n.config.MaxLatency-n.config.MinLatency)+1))
+		if dropped || n.partitions[from.ID] != n.partitions[nodeID] {
+			continue
+		}
+
+		n.schedule(latency, func() error {
+			inMsg, err := to.mc.Parse(msgBytes, from.ID, nil)
+			if err != nil {
+				return fmt.Errorf("%s couldn't parse message from %s: %w", to.ID, from.ID, err)
+			}
+			to.router.HandleInbound(inMsg)
+			return nil
+		})
+	}
+	return sentTo
+}
+
+// gossip [msg] from [from] to [numPeers] nodes sampled uniformly
+func (n *Network) gossip(from *Node, msg message.OutboundMessage, numPeers int) ids.NodeIDSet {
+	peers := make([]ids.NodeID, 0, len(n.nodes)-1)
+	for _, nd := range n.nodes {
+		if nd != from {
+			peers = append(peers, nd.ID)
+		}
+	}
+	n.rng.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+	if numPeers < len(peers) {
+		peers = peers[:numPeers]
+	}
+	return n.send(from, msg, peers)
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// allAccepted returns a function that reports whether each of [nodes] has
+// accepted [numBlocks] blocks after genesis
+func allAccepted(nodes []*Node, numBlocks int) func() bool {
+	return func() bool {
+		for _, nd := range nodes {
+			if len(nd.Accepted()) != numBlocks+1 {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// runPartition runs a scenario where a minority of the network builds a block
+// that conflicts with a block accepted by the majority while the network is
+// partitioned
+func runPartition(t *testing.T, seed int64) *Network {
+	assert := assert.New(t)
+
+	network, err := New(Config{
+		Seed:     seed,
+		DropRate: 0.05,
+	})
+	assert.NoError(err)
+	nodes := network.Nodes()
+	assert.Len(nodes, DefaultNumNodes)
+
+	nodes[0].BuildBlock()
+	accepted, err := network.RunUntil(allAccepted(nodes, 1), time.Minute)
+	assert.NoError(err)
+	assert.True(accepted)
+
+	minority, majority := nodes[:2], nodes[2:]
+	network.Partition([]ids.NodeID{minority[0].ID, minority[1].ID})
+	minority[0].BuildBlock()
+	majority[0].BuildBlock()
+	accepted, err = network.RunUntil(allAccepted(majority, 2), time.Minute)
+	assert.NoError(err)
+	assert.True(accepted)
+
+	// The minority can't finalize its block without the majority
+	assert.NoError(network.RunFor(time.Minute))
+	for _, nd := range minority {
+		assert.Len(nd.Accepted(), 2)
+	}
+
+	network.Heal()
+	accepted, err = network.RunUntil(allAccepted(nodes, 2), 5*time.Minute)
+	assert.NoError(err)
+	assert.True(accepted)
+	for _, nd := range nodes {
+		assert.Equal(majority[0].Accepted(), nd.Accepted())
+	}
+	return network
+}
+
+func TestPartition(t *testing.T) {
+	runPartition(t, 0)
+}
+
+func TestDeterministic(t *testing.T) {
+	assert := assert.New(t)
+
+	trace := runPartition(t, 1).Trace()
+	assert.NotEmpty(trace)
+	assert.Equal(trace, runPartition(t, 1).Trace())
+	assert.NotEqual(trace, runPartition(t, 2).Trace())
+}
+
+func TestLatency(t *testing.T) {
+	assert := assert.New(t)
+
+	latency := 500 * time.Millisecond
+	network, err := New(Config{
+		MinLatency: latency,
+		MaxLatency: latency,
+	})
+	assert.NoError(err)
+	nodes := network.Nodes()
+
+	// The block can't be accepted before its first query has been responded
+	// to
+	start := network.Now()
+	nodes[0].BuildBlock()
+	accepted, err := network.RunUntil(allAccepted(nodes, 1), time.Minute)
+	assert.NoError(err)
+	assert.True(accepted)
+	assert.GreaterOrEqual(network.Now().Sub(start), 2*latency)
+
+	// Responses that take longer than the request timeout are dropped, so no
+	// block can be accepted
+	assert.NoError(network.SetLatency(DefaultRequestTimeout, DefaultRequestTimeout))
+	nodes[0].BuildBlock()
+	accepted, err = network.RunUntil(allAccepted(nodes[:1], 2), time.Minute)
+	assert.NoError(err)
+	assert.False(accepted)
+
+	assert.ErrorIs(network.SetLatency(time.Second, 0), errInvalidLatency)
+	assert.ErrorIs(network.SetDropRate(2), errInvalidDropRate)
+}
@@ -0,0 +1,316 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/common/tracker"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/math/meter"
+	"github.com/ava-labs/avalanchego/utils/resource"
+
+	smcon "github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+	snowgetter "github.com/ava-labs/avalanchego/snow/engine/snowman/getter"
+	nettracker "github.com/ava-labs/avalanchego/snow/networking/tracker"
+)
+
+var (
+	_ handler.Handler       = &simHandler{}
+	_ sender.ExternalSender = &externalSender{}
+	_ timeout.Manager       = &timeoutManager{}
+)
+
+// Node is a validator in a simulated network
+type Node struct {
+	ID ids.NodeID
+
+	network *Network
+	mc      message.Creator
+	router  *router.ChainRouter
+	handler *simHandler
+	vm      *vm
+}
+
+func (n *Network) newNode(nodeID ids.NodeID, nodeIDs []ids.NodeID) (*Node, error) {
+	nd := &Node{
+		ID:      nodeID,
+		network: n,
+		router:  &router.ChainRouter{},
+		vm:      newVM(nodeID, n.clock.Time),
+	}
+
+	var err error
+	nd.mc, err = message.NewCreator(prometheus.NewRegistry(), false, "", n.config.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.NodeID = nodeID
+	ctx.ChainID = chainID
+	ctx.SubnetID = constants.PrimaryNetworkID
+	ctx.SetState(snow.NormalOp)
+
+	vdrs := validators.NewSet()
+	for _, vdrID := range nodeIDs {
+		if err := vdrs.AddWeight(vdrID, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	timeouts := &timeoutManager{
+		network:   n,
+		requests:  make(map[ids.ID]*request),
+		deadlines: make(map[int64]struct{}),
+	}
+	err = nd.router.Initialize(
+		nodeID,
+		logging.NoLog{},
+		nd.mc,
+		timeouts,
+		time.Second,
+		ids.Set{},
+		ids.Set{},
+		func(int) {},
+		router.HealthConfig{},
+		"",
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize router: %w", err)
+	}
+
+	// Messages to this node must be routed synchronously so that they're
+	// scheduled in a deterministic order
+	sender, err := sender.NewSynchronous(
+		ctx,
+		nd.mc,
+		&externalSender{node: nd},
+		nd.router,
+		timeouts,
+		sender.GossipConfig{
+			AcceptedFrontierValidatorSize: 2,
+			OnAcceptValidatorSize:         2,
+			AppGossipValidatorSize:        2,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create sender: %w", err)
+	}
+
+	resourceTracker, err := nettracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create resource tracker: %w", err)
+	}
+	h, err := handler.New(
+		nd.mc,
+		ctx,
+		vdrs,
+		nil,
+		nil,
+		n.config.GossipFrequency,
+		resourceTracker,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create handler: %w", err)
+	}
+	nd.handler = &simHandler{
+		Handler: h,
+		node:    nd,
+	}
+
+	commonCfg := common.Config{
+		Ctx:                            ctx,
+		Validators:                     vdrs,
+		Beacons:                        vdrs,
+		SampleK:                        n.config.Params.K,
+		Alpha:                          vdrs.Weight()/2 + 1,
+		StartupTracker:                 tracker.NewStartup(tracker.NewPeers(), 0),
+		Sender:                         sender,
+		Timer:                          nd.handler,
+		MaxTimeGetAncestors:            time.Second,
+		AncestorsMaxContainersSent:     2000,
+		AncestorsMaxContainersReceived: 2000,
+		SharedCfg:                      &common.SharedConfig{},
+	}
+	getter, err := snowgetter.New(nd.vm, commonCfg)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create getter: %w", err)
+	}
+	engine, err := smeng.New(smeng.Config{
+		AllGetsServer: getter,
+		Ctx:           ctx,
+		VM:            nd.vm,
+		Sender:        sender,
+		Validators:    vdrs,
+		Params:        n.config.Params,
+		Consensus:     &smcon.Topological{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create engine: %w", err)
+	}
+	h.SetConsensus(engine)
+
+	// Every node starts out having accepted genesis, so bootstrapping is
+	// skipped
+	ctx.Lock.Lock()
+	err = engine.Start(0)
+	ctx.Lock.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't start engine: %w", err)
+	}
+
+	nd.router.AddChain(nd.handler)
+	return nd, nil
+}
+
+// BuildBlock makes the node build a block on top of its preferred block
+func (nd *Node) BuildBlock() {
+	nd.handler.Push(nd.mc.InternalVMMessage(nd.ID, uint32(common.PendingTxs)))
+}
+
+// LastAccepted returns the ID of the last block the node accepted
+func (nd *Node) LastAccepted() ids.ID { return nd.vm.lastAccepted }
+
+// Accepted returns the IDs of the blocks the node has accepted, starting with
+// genesis
+func (nd *Node) Accepted() []ids.ID {
+	accepted := make([]ids.ID, len(nd.vm.accepted))
+	copy(accepted, nd.vm.accepted)
+	return accepted
+}
+
+// handle passes [msg] to the node's engine
+func (nd *Node) handle(msg message.InboundMessage) error {
+	n := nd.network
+	n.trace = append(n.trace, fmt.Sprintf("%s %s %s", n.clock.Time().Sub(startTime), nd.ID, msg))
+	if err := nd.handler.Handle(msg); err != nil {
+		return fmt.Errorf("%s failed to handle %s: %w", nd.ID, msg, err)
+	}
+	return nil
+}
+
+// simHandler processes the messages pushed to it as simulation events rather
+// than on the handler's dispatchers
+type simHandler struct {
+	handler.Handler
+	node *Node
+}
+
+func (h *simHandler) Push(msg message.InboundMessage) {
+	h.node.network.schedule(0, func() error {
+		return h.node.handle(msg)
+	})
+}
+
+func (h *simHandler) RegisterTimeout(d time.Duration) {
+	h.node.network.schedule(d, func() error {
+		return h.node.handle(h.node.mc.InternalTimeout(h.node.ID))
+	})
+}
+
+// externalSender sends messages over the simulated network
+type externalSender struct {
+	node *Node
+}
+
+func (s *externalSender) Send(msg message.OutboundMessage, nodeIDs ids.NodeIDSet, _ ids.ID, _ bool) ids.NodeIDSet {
+	return s.node.network.send(s.node, msg, nodeIDs.List())
+}
+
+func (s *externalSender) Gossip(
+	msg message.OutboundMessage,
+	_ ids.ID,
+	_ bool,
+	numValidatorsToSend int,
+	numNonValidatorsToSend int,
+	numPeersToSend int,
+) ids.NodeIDSet {
+	// Every peer is a validator
+	return s.node.network.gossip(s.node, msg, numValidatorsToSend+numPeersToSend)
+}
+
+// timeoutManager fails requests that haven't been responded to after the
+// network's request timeout
+type timeoutManager struct {
+	network *Network
+	// requests that haven't been responded to
+	requests map[ids.ID]*request
+	// deadlines that a timeout event has been scheduled for
+	deadlines map[int64]struct{}
+}
+
+type request struct {
+	id             ids.ID
+	deadline       time.Time
+	timeoutHandler func()
+}
+
+func (*timeoutManager) Dispatch() {}
+
+func (m *timeoutManager) TimeoutDuration() time.Duration { return m.network.config.RequestTimeout }
+
+func (*timeoutManager) IsBenched(ids.NodeID, ids.ID) bool { return false }
+
+func (*timeoutManager) RegisterChain(*snow.ConsensusContext) error { return nil }
+
+func (m *timeoutManager) RegisterRequest(_ ids.NodeID, _ ids.ID, _ message.Op, requestID ids.ID, timeoutHandler func()) {
+	timeout := m.network.config.RequestTimeout
+	deadline := m.network.Now().Add(timeout)
+	m.requests[requestID] = &request{
+		id:             requestID,
+		deadline:       deadline,
+		timeoutHandler: timeoutHandler,
+	}
+	if _, ok := m.deadlines[deadline.UnixNano()]; ok {
+		return
+	}
+
+	// Requests are registered in an arbitrary order, so the requests that
+	// expire at the same time are failed together in order of their IDs
+	m.deadlines[deadline.UnixNano()] = struct{}{}
+	m.network.schedule(timeout, func() error {
+		delete(m.deadlines, deadline.UnixNano())
+
+		var expired []*request
+		for _, req := range m.requests {
+			if req.deadline.Equal(deadline) {
+				expired = append(expired, req)
+			}
+		}
+		sort.Slice(expired, func(i, j int) bool {
+			return bytes.Compare(expired[i].id[:], expired[j].id[:]) < 0
+		})
+		for _, req := range expired {
+			delete(m.requests, req.id)
+			req.timeoutHandler()
+		}
+		return nil
+	})
+}
+
+func (*timeoutManager) RegisterRequestToUnreachableValidator() {}
+
+func (m *timeoutManager) RegisterResponse(_ ids.NodeID, _ ids.ID, requestID ids.ID, _ message.Op, _ time.Duration) {
+	m.RemoveRequest(requestID)
+}
+
+func (m *timeoutManager) RemoveRequest(requestID ids.ID) { delete(m.requests, requestID) }
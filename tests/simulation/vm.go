@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	_ block.ChainVM  = &vm{}
+	_ snowman.Block  = &testBlock{}
+	_ health.Checker = &vm{}
+
+	errUnknownBlock = errors.New("unknown block")
+
+	genesisBytes = []byte("simulation genesis")
+)
+
+// vm is a chain VM whose blocks carry no state. Blocks are built on top of the
+// preferred block and are identified by the hash of their bytes, so blocks
+// built in the same order are identical across runs.
+type vm struct {
+	nodeID ids.NodeID
+	clock  func() time.Time
+
+	blocks       map[ids.ID]*testBlock
+	preferred    ids.ID
+	lastAccepted ids.ID
+	// accepted is the IDs of the blocks this VM has accepted, in order
+	accepted []ids.ID
+	// numBuilt is the number of blocks this VM has built
+	numBuilt uint64
+}
+
+func newVM(nodeID ids.NodeID, clock func() time.Time) *vm {
+	genesis := &testBlock{
+		TestBlock: snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     hashing.ComputeHash256Array(genesisBytes),
+				StatusV: choices.Accepted,
+			},
+			BytesV: genesisBytes,
+		},
+	}
+	genesisID := genesis.ID()
+	v := &vm{
+		nodeID:       nodeID,
+		clock:        clock,
+		blocks:       map[ids.ID]*testBlock{genesisID: genesis},
+		preferred:    genesisID,
+		lastAccepted: genesisID,
+		accepted:     []ids.ID{genesisID},
+	}
+	genesis.vm = v
+	return v
+}
+
+func (*vm) Initialize(
+	*snow.Context,
+	manager.Manager,
+	[]byte,
+	[]byte,
+	[]byte,
+	chan<- common.Message,
+	[]*common.Fx,
+	common.AppSender,
+) error {
+	return nil
+}
+
+func (*vm) SetState(snow.State) error { return nil }
+func (*vm) Shutdown() error           { return nil }
+func (*vm) Version() (string, error)  { return "", nil }
+
+func (*vm) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) { return nil, nil }
+func (*vm) CreateHandlers() (map[string]*common.HTTPHandler, error)       { return nil, nil }
+
+func (*vm) HealthCheck() (interface{}, error) { return nil, nil }
+
+func (*vm) Connected(ids.NodeID, *version.Application) error { return nil }
+func (*vm) Disconnected(ids.NodeID) error                    { return nil }
+
+func (*vm) AppRequest(ids.NodeID, uint32, time.Time, []byte) error { return nil }
+func (*vm) AppRequestFailed(ids.NodeID, uint32) error              { return nil }
+func (*vm) AppResponse(ids.NodeID, uint32, []byte) error           { return nil }
+func (*vm) AppGossip(ids.NodeID, []byte) error                     { return nil }
+
+func (v *vm) BuildBlock() (snowman.Block, error) {
+	parent := v.blocks[v.preferred]
+	parentID := parent.ID()
+	v.numBuilt++
+
+	p := wrappers.Packer{MaxSize: 1024}
+	p.PackFixedBytes(parentID[:])
+	p.PackLong(parent.Height() + 1)
+	p.PackLong(uint64(v.clock().UnixNano()))
+	p.PackFixedBytes(v.nodeID[:])
+	p.PackLong(v.numBuilt)
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	return v.ParseBlock(p.Bytes)
+}
+
+func (v *vm) ParseBlock(b []byte) (snowman.Block, error) {
+	blkID := hashing.ComputeHash256Array(b)
+	if blk, ok := v.blocks[blkID]; ok {
+		return blk, nil
+	}
+
+	p := wrappers.Packer{Bytes: b}
+	parentID, err := ids.ToID(p.UnpackFixedBytes(hashing.HashLen))
+	if err != nil {
+		return nil, err
+	}
+	height := p.UnpackLong()
+	timestamp := int64(p.UnpackLong())
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	blk := &testBlock{
+		TestBlock: snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     blkID,
+				StatusV: choices.Processing,
+			},
+			ParentV:    parentID,
+			HeightV:    height,
+			TimestampV: time.Unix(0, timestamp),
+			BytesV:     b,
+		},
+		vm: v,
+	}
+	v.blocks[blkID] = blk
+	return blk, nil
+}
+
+func (v *vm) GetBlock(blkID ids.ID) (snowman.Block, error) {
+	blk, ok := v.blocks[blkID]
+	if !ok {
+		return nil, errUnknownBlock
+	}
+	return blk, nil
+}
+
+func (v *vm) SetPreference(blkID ids.ID) error {
+	v.preferred = blkID
+	return nil
+}
+
+func (v *vm) LastAccepted() (ids.ID, error) { return v.lastAccepted, nil }
+
+// testBlock reports its acceptance to the VM that parsed it
+type testBlock struct {
+	snowman.TestBlock
+	vm *vm
+}
+
+func (b *testBlock) Accept() error {
+	if err := b.TestBlock.Accept(); err != nil {
+		return err
+	}
+	b.vm.lastAccepted = b.ID()
+	b.vm.accepted = append(b.vm.accepted, b.ID())
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const minBagSize = 16
+
+// Bag is a multiset of elements.
+//
+// A bag can be filtered and split by arbitrary predicates, which eases its use
+// for voting.
+type Bag[T comparable] struct {
+	counts map[T]int
+	size   int
+
+	mode     T
+	modeFreq int
+
+	threshold    int
+	metThreshold set.Set[T]
+}
+
+// NewBag returns a new bag with initial capacity for [size] distinct elements
+func NewBag[T comparable](size int) Bag[T] {
+	if size < 0 {
+		size = 0
+	}
+	return Bag[T]{
+		counts: make(map[T]int, size),
+	}
+}
+
+// Of returns a bag containing [elts]
+func Of[T comparable](elts ...T) Bag[T] {
+	b := Bag[T]{}
+	b.Add(elts...)
+	return b
+}
+
+func (b *Bag[T]) init() {
+	if b.counts == nil {
+		b.counts = make(map[T]int, minBagSize)
+	}
+}
+
+// SetThreshold sets the number of times an element must be added to be
+// contained in the threshold set.
+func (b *Bag[_]) SetThreshold(threshold int) {
+	if b.threshold == threshold {
+		return
+	}
+
+	b.threshold = threshold
+	b.metThreshold.Clear()
+	for elt, count := range b.counts {
+		if count >= threshold {
+			b.metThreshold.Add(elt)
+		}
+	}
+}
+
+// Add increases the number of times each element has been seen by one.
+func (b *Bag[T]) Add(elts ...T) {
+	for _, elt := range elts {
+		b.AddCount(elt, 1)
+	}
+}
+
+// AddCount increases the number of times the element has been seen by count.
+//
+// count must be >= 0
+func (b *Bag[T]) AddCount(elt T, count int) {
+	if count <= 0 {
+		return
+	}
+
+	b.init()
+
+	totalCount := b.counts[elt] + count
+	b.counts[elt] = totalCount
+	b.size += count
+
+	if totalCount > b.modeFreq {
+		b.mode = elt
+		b.modeFreq = totalCount
+	}
+	if totalCount >= b.threshold {
+		b.metThreshold.Add(elt)
+	}
+}
+
+// Count returns the number of times the element has been added.
+func (b *Bag[T]) Count(elt T) int {
+	return b.counts[elt]
+}
+
+// Remove sets the count of the provided element to zero.
+func (b *Bag[T]) Remove(elt T) {
+	count, ok := b.counts[elt]
+	if !ok {
+		return
+	}
+
+	delete(b.counts, elt)
+	b.size -= count
+	b.metThreshold.Remove(elt)
+
+	if elt != b.mode {
+		return
+	}
+	// The mode was removed, so it's recalculated from the remaining elements.
+	b.mode = *new(T)
+	b.modeFreq = 0
+	for elt, count := range b.counts {
+		if count > b.modeFreq {
+			b.mode = elt
+			b.modeFreq = count
+		}
+	}
+}
+
+// Len returns the number of times an element has been added.
+func (b *Bag[_]) Len() int { return b.size }
+
+// List returns a list of all elements that have been added, without
+// duplicates.
+func (b *Bag[T]) List() []T {
+	elts := make([]T, len(b.counts))
+	i := 0
+	for elt := range b.counts {
+		elts[i] = elt
+		i++
+	}
+	return elts
+}
+
+// Equals returns true if the bags contain the same elements
+func (b *Bag[T]) Equals(other Bag[T]) bool {
+	if b.Len() != other.Len() {
+		return false
+	}
+	for elt, count := range b.counts {
+		if count != other.counts[elt] {
+			return false
+		}
+	}
+	return true
+}
+
+// Mode returns the element that has been seen the most and the number of times
+// it has been seen. Ties are broken by the first element to be seen the
+// reported number of times.
+func (b *Bag[T]) Mode() (T, int) { return b.mode, b.modeFreq }
+
+// Threshold returns the elements that have been seen at least threshold times.
+func (b *Bag[T]) Threshold() set.Set[T] { return b.metThreshold }
+
+// Filter returns the bag of elements with the same counts as this bag, except
+// only the elements that [filterFunc] returns true for are included.
+func (b *Bag[T]) Filter(filterFunc func(T) bool) Bag[T] {
+	newBag := Bag[T]{}
+	for elt, count := range b.counts {
+		if filterFunc(elt) {
+			newBag.AddCount(elt, count)
+		}
+	}
+	return newBag
+}
+
+// Split returns the bags of elements with the same counts as this bag, except
+// the elements that [splitFunc] returns false for are in the 0th index and the
+// elements that [splitFunc] returns true for are in the 1st index.
+func (b *Bag[T]) Split(splitFunc func(T) bool) [2]Bag[T] {
+	splitVotes := [2]Bag[T]{}
+	for elt, count := range b.counts {
+		if splitFunc(elt) {
+			splitVotes[1].AddCount(elt, count)
+		} else {
+			splitVotes[0].AddCount(elt, count)
+		}
+	}
+	return splitVotes
+}
+
+func (b *Bag[_]) PrefixedString(prefix string) string {
+	sb := strings.Builder{}
+
+	sb.WriteString(fmt.Sprintf("Bag: (Size = %d)", b.Len()))
+	for elt, count := range b.counts {
+		sb.WriteString(fmt.Sprintf("\n%s    ID[%v]: Count = %d", prefix, elt, count))
+	}
+
+	return sb.String()
+}
+
+func (b *Bag[_]) String() string { return b.PrefixedString("") }
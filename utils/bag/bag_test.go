@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBag(t *testing.T) {
+	assert := assert.New(t)
+
+	b := Bag[string]{}
+	b.SetThreshold(2)
+	b.Add("a", "b", "b")
+	b.AddCount("c", 3)
+
+	assert.Equal(6, b.Len())
+	assert.Equal(1, b.Count("a"))
+	assert.Equal(2, b.Count("b"))
+	assert.Equal(0, b.Count("d"))
+	assert.Len(b.List(), 3)
+
+	mode, freq := b.Mode()
+	assert.Equal("c", mode)
+	assert.Equal(3, freq)
+
+	threshold := b.Threshold()
+	assert.Equal(2, threshold.Len())
+	assert.True(threshold.Contains("b"))
+	assert.True(threshold.Contains("c"))
+
+	other := Of("c", "b", "c", "a", "b", "c")
+	assert.True(b.Equals(other))
+	other.Add("a")
+	assert.False(b.Equals(other))
+}
+
+func TestBagRemove(t *testing.T) {
+	assert := assert.New(t)
+
+	b := Bag[int]{}
+	b.SetThreshold(2)
+	b.AddCount(1, 1)
+	b.AddCount(2, 2)
+	b.AddCount(3, 3)
+
+	b.Remove(3)
+	assert.Equal(3, b.Len())
+	assert.Equal(0, b.Count(3))
+	threshold := b.Threshold()
+	assert.False(threshold.Contains(3))
+
+	// The mode is recalculated after it is removed
+	mode, freq := b.Mode()
+	assert.Equal(2, mode)
+	assert.Equal(2, freq)
+
+	// Removing a missing element does nothing
+	b.Remove(4)
+	assert.Equal(3, b.Len())
+}
+
+func TestBagFilterAndSplit(t *testing.T) {
+	assert := assert.New(t)
+
+	b := Bag[int]{}
+	b.AddCount(0, 1)
+	b.AddCount(1, 3)
+	b.AddCount(2, 5)
+
+	isOdd := func(i int) bool { return i%2 == 1 }
+
+	odds := b.Filter(isOdd)
+	assert.Equal(3, odds.Len())
+	assert.Equal(3, odds.Count(1))
+
+	split := b.Split(isOdd)
+	assert.Equal(6, split[0].Len())
+	assert.Equal(1, split[0].Count(0))
+	assert.Equal(5, split[0].Count(2))
+	assert.True(split[1].Equals(odds))
+}
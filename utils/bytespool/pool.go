@@ -0,0 +1,206 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bytespool
+
+import (
+	"math/bits"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// minClassShift is the log2 of the smallest size class. Slices with a
+	// capacity below 1<<minClassShift aren't worth pooling.
+	minClassShift = 6 // 64 B
+	// maxClassShift is the log2 of the largest size class. Slices with a
+	// capacity above 1<<maxClassShift are left to the garbage collector so
+	// that rare, large, allocations aren't retained.
+	maxClassShift = 21 // 2 MiB
+
+	numClasses = maxClassShift - minClassShift + 1
+
+	// MaxSize is the size of the largest slice that is pooled.
+	MaxSize = 1 << maxClassShift
+)
+
+// shared is the pool used by the package level functions.
+var shared = New("bytespool")
+
+// Get returns a slice with length [size] from the shared pool.
+func Get(size int) []byte { return shared.Get(size) }
+
+// Put returns [b] to the shared pool.
+func Put(b []byte) { shared.Put(b) }
+
+// Register registers the metrics of the shared pool with [registerer].
+func Register(registerer prometheus.Registerer) error {
+	return shared.Register(registerer)
+}
+
+// Pool is a set of byte slice pools, one for each power of two size class. A
+// slice returned by Get has a capacity equal to the smallest size class that
+// can hold it, so slices are only ever reused for requests of a similar size.
+//
+// It's safe for multiple goroutines to use a Pool concurrently.
+type Pool struct {
+	// classes[i] contains slices with capacity of at least
+	// 1<<(i+minClassShift).
+	classes [numClasses]sync.Pool
+
+	// metrics is nil if this pool isn't metered.
+	metrics *metrics
+}
+
+// metrics are updated atomically on every Get and Put, and are only read by
+// prometheus when they are collected.
+type metrics struct {
+	inUse      int64
+	inUseBytes int64
+	hits       int64
+	misses     int64
+
+	collectors []prometheus.Collector
+}
+
+// New returns a new pool whose metrics are reported under [namespace]. The
+// metrics aren't exported until Register is called.
+func New(namespace string) *Pool {
+	m := &metrics{}
+	m.collectors = []prometheus.Collector{
+		prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "in_use",
+				Help:      "Number of slices taken from the pool that haven't been returned",
+			},
+			loadFunc(&m.inUse),
+		),
+		prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "in_use_bytes",
+				Help:      "Capacity, in bytes, of the slices taken from the pool that haven't been returned",
+			},
+			loadFunc(&m.inUseBytes),
+		),
+		prometheus.NewCounterFunc(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "hits",
+				Help:      "Number of requests served by a previously returned slice",
+			},
+			loadFunc(&m.hits),
+		),
+		prometheus.NewCounterFunc(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "misses",
+				Help:      "Number of requests that required a new slice to be allocated",
+			},
+			loadFunc(&m.misses),
+		),
+	}
+	return &Pool{metrics: m}
+}
+
+// NewUnmetered returns a new pool that doesn't keep any metrics. It's meant for
+// hot paths where the slices are returned right after being used, so the
+// metrics wouldn't be worth their cost.
+func NewUnmetered() *Pool {
+	return &Pool{}
+}
+
+// Register registers the metrics of this pool with [registerer]. It's a no-op
+// if the pool isn't metered.
+func (p *Pool) Register(registerer prometheus.Registerer) error {
+	if p.metrics == nil {
+		return nil
+	}
+	errs := wrappers.Errs{}
+	for _, collector := range p.metrics.collectors {
+		errs.Add(registerer.Register(collector))
+	}
+	return errs.Err
+}
+
+// Get returns a slice with length [size]. The contents of the slice are
+// arbitrary. The slice should be returned with Put once it is no longer used.
+func (p *Pool) Get(size int) []byte {
+	var (
+		b   []byte
+		hit bool
+	)
+	if class, ok := getClass(size); ok {
+		if pooled, ok := p.classes[class].Get().([]byte); ok {
+			hit = true
+			b = pooled[:size]
+		} else {
+			b = make([]byte, size, classSize(class))
+		}
+	} else {
+		b = make([]byte, size)
+	}
+
+	if m := p.metrics; m != nil {
+		if hit {
+			atomic.AddInt64(&m.hits, 1)
+		} else {
+			atomic.AddInt64(&m.misses, 1)
+		}
+		atomic.AddInt64(&m.inUse, 1)
+		atomic.AddInt64(&m.inUseBytes, int64(cap(b)))
+	}
+	return b
+}
+
+// Put returns [b] to the pool. [b] must have been returned by Get and may have
+// been resliced since, but must not have been grown past its capacity, so that
+// the pool accounts for the same capacity it handed out. A buffer that had to
+// be grown should be returned as the slice originally returned by Get. [b] must
+// not be used after calling Put.
+func (p *Pool) Put(b []byte) {
+	if m := p.metrics; m != nil {
+		atomic.AddInt64(&m.inUse, -1)
+		atomic.AddInt64(&m.inUseBytes, -int64(cap(b)))
+	}
+
+	if class, ok := putClass(cap(b)); ok {
+		p.classes[class].Put(b[:0])
+	}
+}
+
+func loadFunc(v *int64) func() float64 {
+	return func() float64 {
+		return float64(atomic.LoadInt64(v))
+	}
+}
+
+// getClass returns the smallest size class whose slices can hold [size]
+// bytes. Returns false if [size] is too large to be pooled.
+func getClass(size int) (int, bool) {
+	if size > MaxSize {
+		return 0, false
+	}
+	if size <= 1<<minClassShift {
+		return 0, true
+	}
+	return bits.Len(uint(size-1)) - minClassShift, true
+}
+
+// putClass returns the largest size class that a slice with capacity [capacity]
+// can serve. Returns false if the slice shouldn't be pooled.
+func putClass(capacity int) (int, bool) {
+	if capacity < 1<<minClassShift || capacity > MaxSize {
+		return 0, false
+	}
+	return bits.Len(uint(capacity)) - 1 - minClassShift, true
+}
+
+func classSize(class int) int {
+	return 1 << (class + minClassShift)
+}
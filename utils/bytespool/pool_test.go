@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bytespool
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolGetSizeClasses(t *testing.T) {
+	tests := []struct {
+		size        int
+		expectedCap int
+	}{
+		{size: 0, expectedCap: 64},
+		{size: 1, expectedCap: 64},
+		{size: 64, expectedCap: 64},
+		{size: 65, expectedCap: 128},
+		{size: 1000, expectedCap: 1024},
+		{size: 1024, expectedCap: 1024},
+		{size: MaxSize, expectedCap: MaxSize},
+		{size: MaxSize + 1, expectedCap: MaxSize + 1},
+	}
+	for _, test := range tests {
+		p := New("")
+		b := p.Get(test.size)
+		assert.Len(t, b, test.size)
+		assert.Equal(t, test.expectedCap, cap(b))
+	}
+}
+
+func TestPoolPutClasses(t *testing.T) {
+	assert := assert.New(t)
+
+	// Slices are returned to the largest class they can fully serve.
+	class, ok := putClass(64)
+	assert.True(ok)
+	assert.Equal(0, class)
+
+	class, ok = putClass(127)
+	assert.True(ok)
+	assert.Equal(0, class)
+
+	class, ok = putClass(128)
+	assert.True(ok)
+	assert.Equal(1, class)
+
+	class, ok = putClass(MaxSize)
+	assert.True(ok)
+	assert.Equal(numClasses-1, class)
+
+	_, ok = putClass(63)
+	assert.False(ok)
+
+	_, ok = putClass(MaxSize + 1)
+	assert.False(ok)
+}
+
+func TestPoolReusesResizedSlice(t *testing.T) {
+	assert := assert.New(t)
+
+	p := New("")
+	b := p.Get(10)
+	b = append(b, make([]byte, 50)...)
+	p.Put(b[:1])
+	assert.Zero(p.metrics.inUse)
+	assert.Zero(p.metrics.inUseBytes)
+
+	// Whether or not the resliced slice is reused, the returned slice must
+	// always be large enough.
+	for i := 0; i < 10; i++ {
+		b := p.Get(64)
+		assert.Len(b, 64)
+		assert.Equal(64, cap(b))
+		p.Put(b)
+	}
+}
+
+func TestPoolMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	p := New("")
+	assert.NoError(p.Register(prometheus.NewRegistry()))
+
+	inUse, inUseBytes, hits, misses := p.metrics.collectors[0], p.metrics.collectors[1], p.metrics.collectors[2], p.metrics.collectors[3]
+
+	b0 := p.Get(100)
+	b1 := p.Get(MaxSize + 1)
+	assert.Equal(2., testutil.ToFloat64(inUse))
+	assert.Equal(float64(128+MaxSize+1), testutil.ToFloat64(inUseBytes))
+
+	p.Put(b0)
+	p.Put(b1)
+	assert.Zero(testutil.ToFloat64(inUse))
+	assert.Zero(testutil.ToFloat64(inUseBytes))
+
+	_ = p.Get(100)
+	assert.Equal(3., testutil.ToFloat64(hits)+testutil.ToFloat64(misses))
+}
+
+func TestPoolUnmetered(t *testing.T) {
+	assert := assert.New(t)
+
+	p := NewUnmetered()
+	registerer := prometheus.NewRegistry()
+	assert.NoError(p.Register(registerer))
+
+	b := p.Get(100)
+	assert.Len(b, 100)
+	assert.Equal(128, cap(b))
+	p.Put(b)
+
+	metrics, err := registerer.Gather()
+	assert.NoError(err)
+	assert.Empty(metrics)
+}
+
+func TestPoolRegisterTwice(t *testing.T) {
+	p := New("")
+	registerer := prometheus.NewRegistry()
+	assert.NoError(t, p.Register(registerer))
+	assert.Error(t, p.Register(registerer))
+}
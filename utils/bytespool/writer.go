@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bytespool
+
+import (
+	"io"
+)
+
+var _ io.Writer = &Writer{}
+
+// Writer buffers writes to an underlying io.Writer. Unlike bufio.Writer, the
+// buffer is taken from a Pool on the first write and returned to the pool
+// whenever the buffered data is flushed, so idle writers don't hold onto
+// memory.
+//
+// If an error occurs writing to the underlying writer, all subsequent writes
+// and flushes will return the error.
+type Writer struct {
+	pool *Pool
+	w    io.Writer
+	size int
+	buf  []byte
+	err  error
+}
+
+// NewWriter returns a new Writer that buffers up to [size] bytes using buffers
+// from the shared pool.
+func NewWriter(w io.Writer, size int) *Writer {
+	return NewWriterWithPool(shared, w, size)
+}
+
+// NewWriterWithPool returns a new Writer that buffers up to [size] bytes using
+// buffers from [pool].
+func NewWriterWithPool(pool *Pool, w io.Writer, size int) *Writer {
+	return &Writer{
+		pool: pool,
+		w:    w,
+		size: size,
+	}
+}
+
+// Write buffers [b]. If [b] doesn't fit into the buffer, the buffered data is
+// flushed first. If [b] is larger than the buffer, it's written directly.
+func (w *Writer) Write(b []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	if len(w.buf)+len(b) > w.size {
+		if err := w.Flush(); err != nil {
+			return 0, err
+		}
+		if len(b) >= w.size {
+			n, err := w.w.Write(b)
+			w.err = err
+			return n, err
+		}
+	}
+
+	if w.buf == nil {
+		w.buf = w.pool.Get(w.size)[:0]
+	}
+	w.buf = append(w.buf, b...)
+	return len(b), nil
+}
+
+// Flush writes any buffered data to the underlying writer and returns the
+// buffer to the pool.
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.buf == nil {
+		return nil
+	}
+
+	n, err := w.w.Write(w.buf)
+	if err == nil && n < len(w.buf) {
+		err = io.ErrShortWrite
+	}
+	w.Discard()
+	w.err = err
+	return err
+}
+
+// Buffered returns the number of bytes that have been written into the buffer
+// but not yet flushed.
+func (w *Writer) Buffered() int {
+	return len(w.buf)
+}
+
+// Discard drops any buffered data and returns the buffer to the pool.
+func (w *Writer) Discard() {
+	if w.buf != nil {
+		w.pool.Put(w.buf)
+		w.buf = nil
+	}
+}
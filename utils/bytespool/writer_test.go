@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bytespool
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTest = errors.New("non-nil error")
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errTest }
+
+type shortWriter struct{}
+
+func (shortWriter) Write(b []byte) (int, error) { return len(b) / 2, nil }
+
+func TestWriter(t *testing.T) {
+	assert := assert.New(t)
+
+	p := New("")
+	dst := &bytes.Buffer{}
+	w := NewWriterWithPool(p, dst, 8)
+
+	// Nothing is taken from the pool until data is written.
+	assert.Zero(p.metrics.inUse)
+
+	n, err := w.Write([]byte{1, 2, 3})
+	assert.NoError(err)
+	assert.Equal(3, n)
+	assert.Equal(3, w.Buffered())
+	assert.Zero(dst.Len())
+	assert.Equal(int64(1), p.metrics.inUse)
+
+	// Overflowing the buffer flushes the previously buffered data.
+	n, err = w.Write([]byte{4, 5, 6, 7, 8, 9})
+	assert.NoError(err)
+	assert.Equal(6, n)
+	assert.Equal([]byte{1, 2, 3}, dst.Bytes())
+	assert.Equal(6, w.Buffered())
+
+	// Writes larger than the buffer bypass it.
+	n, err = w.Write(make([]byte, 10))
+	assert.NoError(err)
+	assert.Equal(10, n)
+	assert.Equal(19, dst.Len())
+	assert.Zero(w.Buffered())
+
+	// Flushing returns the buffer to the pool.
+	_, err = w.Write([]byte{10})
+	assert.NoError(err)
+	assert.NoError(w.Flush())
+	assert.Equal(20, dst.Len())
+	assert.Zero(w.Buffered())
+	assert.Zero(p.metrics.inUse)
+}
+
+func TestWriterStickyError(t *testing.T) {
+	assert := assert.New(t)
+
+	p := New("")
+	w := NewWriterWithPool(p, errWriter{}, 8)
+
+	_, err := w.Write([]byte{1})
+	assert.NoError(err)
+
+	assert.ErrorIs(w.Flush(), errTest)
+	assert.Zero(p.metrics.inUse)
+
+	_, err = w.Write([]byte{1})
+	assert.ErrorIs(err, errTest)
+	assert.ErrorIs(w.Flush(), errTest)
+}
+
+func TestWriterShortWrite(t *testing.T) {
+	w := NewWriterWithPool(New(""), shortWriter{}, 8)
+
+	_, err := w.Write([]byte{1, 2})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, w.Flush(), io.ErrShortWrite)
+}
+
+func TestWriterDiscard(t *testing.T) {
+	assert := assert.New(t)
+
+	p := New("")
+	dst := &bytes.Buffer{}
+	w := NewWriterWithPool(p, dst, 8)
+
+	_, err := w.Write([]byte{1, 2})
+	assert.NoError(err)
+	w.Discard()
+	assert.Zero(p.metrics.inUse)
+
+	assert.NoError(w.Flush())
+	assert.Zero(dst.Len())
+}
@@ -0,0 +1,29 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package bls implements BLS signatures over BLS12-381 with public keys in G1
+// and signatures in G2. Rogue key attacks are prevented with proofs of
+// possession, so signatures on the same message can be aggregated safely.
+package bls
+
+import "errors"
+
+var (
+	ciphersuiteSignature = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	ciphersuitePoP       = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+	errNoKeys       = errors.New("no public keys")
+	errNoSignatures = errors.New("no signatures")
+	errFailedAggr   = errors.New("couldn't aggregate")
+)
+
+// Verify returns true if [sig] is a valid signature of [msg] by [pk]
+func Verify(pk *PublicKey, sig *Signature, msg []byte) bool {
+	return sig.Verify(false, pk, false, msg, ciphersuiteSignature)
+}
+
+// VerifyProofOfPossession returns true if [sig] is a valid proof of possession
+// of the secret key of [pk] over [msg]
+func VerifyProofOfPossession(pk *PublicKey, sig *Signature, msg []byte) bool {
+	return sig.Verify(false, pk, false, msg, ciphersuitePoP)
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	sk, err := NewSecretKey()
+	assert.NoError(err)
+	pk := PublicFromSecretKey(sk)
+
+	msg := []byte("hello")
+	sig := Sign(sk, msg)
+	assert.True(Verify(pk, sig, msg))
+	assert.False(Verify(pk, sig, []byte("goodbye")))
+
+	// Signatures and proofs of possession use different domains
+	assert.False(VerifyProofOfPossession(pk, sig, msg))
+
+	otherSK, err := NewSecretKey()
+	assert.NoError(err)
+	assert.False(Verify(PublicFromSecretKey(otherSK), sig, msg))
+}
+
+func TestProofOfPossession(t *testing.T) {
+	assert := assert.New(t)
+
+	sk, err := NewSecretKey()
+	assert.NoError(err)
+	pk := PublicFromSecretKey(sk)
+
+	msg := PublicKeyToBytes(pk)
+	pop := SignProofOfPossession(sk, msg)
+	assert.True(VerifyProofOfPossession(pk, pop, msg))
+	assert.False(Verify(pk, pop, msg))
+}
+
+func TestAggregation(t *testing.T) {
+	assert := assert.New(t)
+
+	msg := []byte("hello")
+	pks := []*PublicKey{}
+	sigs := []*Signature{}
+	for i := 0; i < 5; i++ {
+		sk, err := NewSecretKey()
+		assert.NoError(err)
+		pks = append(pks, PublicFromSecretKey(sk))
+		sigs = append(sigs, Sign(sk, msg))
+	}
+
+	aggPK, err := AggregatePublicKeys(pks)
+	assert.NoError(err)
+	aggSig, err := AggregateSignatures(sigs)
+	assert.NoError(err)
+	assert.True(Verify(aggPK, aggSig, msg))
+
+	// Missing a signer
+	partialSig, err := AggregateSignatures(sigs[1:])
+	assert.NoError(err)
+	assert.False(Verify(aggPK, partialSig, msg))
+
+	_, err = AggregatePublicKeys(nil)
+	assert.ErrorIs(err, errNoKeys)
+	_, err = AggregateSignatures(nil)
+	assert.ErrorIs(err, errNoSignatures)
+}
+
+func TestSerialization(t *testing.T) {
+	assert := assert.New(t)
+
+	sk, err := NewSecretKey()
+	assert.NoError(err)
+
+	skBytes := SecretKeyToBytes(sk)
+	assert.Len(skBytes, SecretKeyLen)
+	parsedSK, err := SecretKeyFromBytes(skBytes)
+	assert.NoError(err)
+	assert.Equal(skBytes, SecretKeyToBytes(parsedSK))
+
+	pk := PublicFromSecretKey(sk)
+	pkBytes := PublicKeyToBytes(pk)
+	assert.Len(pkBytes, PublicKeyLen)
+	parsedPK, err := PublicKeyFromBytes(pkBytes)
+	assert.NoError(err)
+	assert.Equal(pkBytes, PublicKeyToBytes(parsedPK))
+
+	sig := Sign(sk, []byte("hello"))
+	sigBytes := SignatureToBytes(sig)
+	assert.Len(sigBytes, SignatureLen)
+	parsedSig, err := SignatureFromBytes(sigBytes)
+	assert.NoError(err)
+	assert.True(Verify(parsedPK, parsedSig, []byte("hello")))
+
+	_, err = SecretKeyFromBytes(make([]byte, SecretKeyLen+1))
+	assert.Error(err)
+	_, err = PublicKeyFromBytes(make([]byte, PublicKeyLen))
+	assert.Error(err)
+	_, err = SignatureFromBytes(make([]byte, SignatureLen))
+	assert.Error(err)
+}
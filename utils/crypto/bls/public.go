@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bls
+
+import (
+	"errors"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// PublicKeyLen is the number of bytes in a compressed public key
+const PublicKeyLen = blst.BLST_P1_COMPRESS_BYTES
+
+var (
+	errFailedPublicKeyDecompress = errors.New("couldn't decompress public key")
+	errInvalidPublicKey          = errors.New("invalid public key")
+)
+
+type (
+	PublicKey          = blst.P1Affine
+	AggregatePublicKey = blst.P1Aggregate
+)
+
+// PublicKeyToBytes returns the compressed big-endian format of the public key
+func PublicKeyToBytes(pk *PublicKey) []byte {
+	return pk.Compress()
+}
+
+// PublicKeyFromBytes parses the compressed big-endian format of the public key
+// into a public key. The public key must be in the correct subgroup and must
+// not be the identity.
+func PublicKeyFromBytes(pkBytes []byte) (*PublicKey, error) {
+	pk := new(PublicKey).Uncompress(pkBytes)
+	if pk == nil {
+		return nil, errFailedPublicKeyDecompress
+	}
+	if !pk.KeyValidate() {
+		return nil, errInvalidPublicKey
+	}
+	return pk, nil
+}
+
+// AggregatePublicKeys aggregates a non-zero number of public keys into a single
+// public key. Signatures of the same message by the keys can be verified
+// against the aggregated key once the signatures are aggregated.
+func AggregatePublicKeys(pks []*PublicKey) (*PublicKey, error) {
+	if len(pks) == 0 {
+		return nil, errNoKeys
+	}
+
+	var agg AggregatePublicKey
+	if !agg.Aggregate(pks, false) {
+		return nil, errFailedAggr
+	}
+	return agg.ToAffine(), nil
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bls
+
+import (
+	"crypto/rand"
+	"errors"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// SecretKeyLen is the number of bytes in a serialized secret key
+const SecretKeyLen = blst.BLST_SCALAR_BYTES
+
+var (
+	errFailedSecretKeyDeserialize = errors.New("couldn't deserialize secret key")
+
+	// The ikm length must be at least 32 bytes
+	ikmLen = 32
+)
+
+type SecretKey = blst.SecretKey
+
+// NewSecretKey generates a new secret key from the local source of
+// cryptographically secure randomness
+func NewSecretKey() (*SecretKey, error) {
+	var ikm [32]byte
+	if _, err := rand.Read(ikm[:]); err != nil {
+		return nil, err
+	}
+	sk := blst.KeyGen(ikm[:ikmLen])
+	ikm = [32]byte{} // zero out the ikm
+	return sk, nil
+}
+
+// SecretKeyToBytes returns the big-endian format of the secret key
+func SecretKeyToBytes(sk *SecretKey) []byte {
+	return sk.Serialize()
+}
+
+// SecretKeyFromBytes parses the big-endian format of the secret key into a
+// secret key
+func SecretKeyFromBytes(skBytes []byte) (*SecretKey, error) {
+	sk := new(SecretKey).Deserialize(skBytes)
+	if sk == nil {
+		return nil, errFailedSecretKeyDeserialize
+	}
+	return sk, nil
+}
+
+// PublicFromSecretKey returns the public key that corresponds to [sk]
+func PublicFromSecretKey(sk *SecretKey) *PublicKey {
+	return new(PublicKey).From(sk)
+}
+
+// Sign [msg] to authorize it
+func Sign(sk *SecretKey, msg []byte) *Signature {
+	return new(Signature).Sign(sk, msg, ciphersuiteSignature)
+}
+
+// SignProofOfPossession signs [msg] to prove the ownership of [sk]
+func SignProofOfPossession(sk *SecretKey, msg []byte) *Signature {
+	return new(Signature).Sign(sk, msg, ciphersuitePoP)
+}
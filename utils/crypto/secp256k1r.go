@@ -285,16 +285,18 @@ func verifySECP256K1RSignatureFormat(sig []byte) error {
 	return nil
 }
 
-type innerSortSECP2561RSigs [][SECP256K1RSigLen]byte
-
-func (lst innerSortSECP2561RSigs) Less(i, j int) bool { return bytes.Compare(lst[i][:], lst[j][:]) < 0 }
-func (lst innerSortSECP2561RSigs) Len() int           { return len(lst) }
-func (lst innerSortSECP2561RSigs) Swap(i, j int)      { lst[j], lst[i] = lst[i], lst[j] }
+func lessSECP2561RSig(a, b [SECP256K1RSigLen]byte) bool {
+	return bytes.Compare(a[:], b[:]) < 0
+}
 
 // SortSECP2561RSigs sorts a slice of SECP2561R signatures
-func SortSECP2561RSigs(lst [][SECP256K1RSigLen]byte) { sort.Sort(innerSortSECP2561RSigs(lst)) }
+func SortSECP2561RSigs(lst [][SECP256K1RSigLen]byte) {
+	sort.Slice(lst, func(i, j int) bool {
+		return lessSECP2561RSig(lst[i], lst[j])
+	})
+}
 
 // IsSortedAndUniqueSECP2561RSigs returns true if [sigs] is sorted
 func IsSortedAndUniqueSECP2561RSigs(sigs [][SECP256K1RSigLen]byte) bool {
-	return utils.IsSortedAndUnique(innerSortSECP2561RSigs(sigs))
+	return utils.IsSortedAndUniqueFunc(sigs, lessSECP2561RSig)
 }
@@ -14,11 +14,33 @@ import (
 const addressSep = "-"
 
 var (
-	errNoSeparator = errors.New("no separator found in address")
-	errBits5To8    = errors.New("unable to convert address from 5-bit to 8-bit formatting")
-	errBits8To5    = errors.New("unable to convert address from 8-bit to 5-bit formatting")
+	errNoSeparator   = errors.New("no separator found in address")
+	errBits5To8      = errors.New("unable to convert address from 5-bit to 8-bit formatting")
+	errBits8To5      = errors.New("unable to convert address from 8-bit to 5-bit formatting")
+	errWrongEncoding = errors.New("wrong address checksum encoding")
 )
 
+// Encoding is the checksum scheme of a bech32 style address
+type Encoding byte
+
+const (
+	// Bech32 is the checksum scheme defined in BIP-173
+	Bech32 Encoding = iota
+	// Bech32m is the checksum scheme defined in BIP-350
+	Bech32m
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case Bech32:
+		return "bech32"
+	case Bech32m:
+		return "bech32m"
+	default:
+		return "unknown"
+	}
+}
+
 // Parse takes in an address string and splits returns the corresponding parts.
 // This returns the chain ID alias, bech32 HRP, address bytes, and an error if
 // it occurs.
@@ -41,21 +63,13 @@ func Format(chainIDAlias string, hrp string, addr []byte) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("%s%s%s", chainIDAlias, addressSep, addrStr), nil
+	return chainIDAlias + addressSep + addrStr, nil
 }
 
 // ParseBech32 takes a bech32 address as input and returns the HRP and data
 // section of a bech32 address
 func ParseBech32(addrStr string) (string, []byte, error) {
-	rawHRP, decoded, err := bech32.Decode(addrStr)
-	if err != nil {
-		return "", nil, err
-	}
-	addrBytes, err := bech32.ConvertBits(decoded, 5, 8, true)
-	if err != nil {
-		return "", nil, errBits5To8
-	}
-	return rawHRP, addrBytes, nil
+	return parseBech32(addrStr, Bech32)
 }
 
 // FormatBech32 takes an address's bytes as input and returns a bech32 address
@@ -66,3 +80,58 @@ func FormatBech32(hrp string, payload []byte) (string, error) {
 	}
 	return bech32.Encode(hrp, fiveBits)
 }
+
+// ParseBech32m takes a bech32m address as input and returns the HRP and data
+// section of a bech32m address
+func ParseBech32m(addrStr string) (string, []byte, error) {
+	return parseBech32(addrStr, Bech32m)
+}
+
+// FormatBech32m takes an address's bytes as input and returns a bech32m
+// address
+func FormatBech32m(hrp string, payload []byte) (string, error) {
+	fiveBits, err := bech32.ConvertBits(payload, 8, 5, true)
+	if err != nil {
+		return "", errBits8To5
+	}
+	return bech32.EncodeM(hrp, fiveBits)
+}
+
+// ParseBech32Generic takes either a bech32 or a bech32m address as input and
+// returns the HRP, data section, and the encoding of the address
+func ParseBech32Generic(addrStr string) (string, []byte, Encoding, error) {
+	rawHRP, decoded, version, err := bech32.DecodeGeneric(addrStr)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	var encoding Encoding
+	switch version {
+	case bech32.Version0:
+		encoding = Bech32
+	case bech32.VersionM:
+		encoding = Bech32m
+	default:
+		return "", nil, 0, errWrongEncoding
+	}
+	addrBytes, err := bech32.ConvertBits(decoded, 5, 8, false)
+	if err != nil {
+		return "", nil, 0, errBits5To8
+	}
+	return rawHRP, addrBytes, encoding, nil
+}
+
+// parseBech32 parses [addrStr] and verifies that its checksum is [encoding]
+func parseBech32(addrStr string, encoding Encoding) (string, []byte, error) {
+	rawHRP, addrBytes, actualEncoding, err := ParseBech32Generic(addrStr)
+	if err != nil {
+		return "", nil, err
+	}
+	if actualEncoding != encoding {
+		return "", nil, fmt.Errorf("%w: expected %s but got %s",
+			errWrongEncoding,
+			encoding,
+			actualEncoding,
+		)
+	}
+	return rawHRP, addrBytes, nil
+}
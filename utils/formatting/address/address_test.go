@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package address
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/stretchr/testify/assert"
+)
+
+var testAddr = []byte{
+	0x3c, 0xb7, 0xd3, 0x84, 0x2e, 0x8c, 0xee, 0x6a,
+	0x0e, 0xbd, 0x09, 0xf1, 0xfe, 0x88, 0x4f, 0x68,
+	0x61, 0xe1, 0xb2, 0x9c,
+}
+
+func TestFormatParse(t *testing.T) {
+	assert := assert.New(t)
+
+	addrStr, err := Format("X", "avax", testAddr)
+	assert.NoError(err)
+	assert.Equal("X-avax18jma8ppw3nhx5r4ap8clazz0dps7rv5ukulre5", addrStr)
+
+	chainIDAlias, hrp, addr, err := Parse(addrStr)
+	assert.NoError(err)
+	assert.Equal("X", chainIDAlias)
+	assert.Equal("avax", hrp)
+	assert.Equal(testAddr, addr)
+
+	_, _, _, err = Parse("avax18jma8ppw3nhx5r4ap8clazz0dps7rv5ukulre5")
+	assert.ErrorIs(err, errNoSeparator)
+}
+
+func TestBech32m(t *testing.T) {
+	assert := assert.New(t)
+
+	bech32Str, err := FormatBech32("avax", testAddr)
+	assert.NoError(err)
+	bech32mStr, err := FormatBech32m("avax", testAddr)
+	assert.NoError(err)
+	assert.NotEqual(bech32Str, bech32mStr)
+
+	hrp, addr, err := ParseBech32m(bech32mStr)
+	assert.NoError(err)
+	assert.Equal("avax", hrp)
+	assert.Equal(testAddr, addr)
+
+	// Each parser only accepts its own checksum
+	_, _, err = ParseBech32m(bech32Str)
+	assert.ErrorIs(err, errWrongEncoding)
+	_, _, err = ParseBech32(bech32mStr)
+	assert.ErrorIs(err, errWrongEncoding)
+
+	hrp, addr, encoding, err := ParseBech32Generic(bech32Str)
+	assert.NoError(err)
+	assert.Equal("avax", hrp)
+	assert.Equal(testAddr, addr)
+	assert.Equal(Bech32, encoding)
+
+	_, _, encoding, err = ParseBech32Generic(bech32mStr)
+	assert.NoError(err)
+	assert.Equal(Bech32m, encoding)
+}
+
+func TestValidateHRP(t *testing.T) {
+	tests := []struct {
+		hrp         string
+		expectedErr error
+	}{
+		{hrp: "avax"},
+		{hrp: "local"},
+		{hrp: "a!~"},
+		{hrp: "", expectedErr: errEmptyHRP},
+		{hrp: "Avax", expectedErr: errInvalidHRPChar},
+		{hrp: "av ax", expectedErr: errInvalidHRPChar},
+		{hrp: "av\x7fax", expectedErr: errInvalidHRPChar},
+		{hrp: string(make([]byte, maxHRPLength+1)), expectedErr: errHRPTooLong},
+	}
+	for _, test := range tests {
+		t.Run(test.hrp, func(t *testing.T) {
+			assert.ErrorIs(t, ValidateHRP(test.hrp), test.expectedErr)
+		})
+	}
+}
+
+func TestParseWithHRP(t *testing.T) {
+	assert := assert.New(t)
+
+	addrStr, err := Format("P", "fuji", testAddr)
+	assert.NoError(err)
+
+	chainIDAlias, addr, err := ParseWithHRP(addrStr, "fuji")
+	assert.NoError(err)
+	assert.Equal("P", chainIDAlias)
+	assert.Equal(testAddr, addr)
+
+	_, _, err = ParseWithHRP(addrStr, "avax")
+	assert.ErrorIs(err, errWrongHRP)
+
+	bech32Str, err := FormatBech32("fuji", testAddr)
+	assert.NoError(err)
+
+	addr, err = ParseBech32WithHRP(bech32Str, "fuji")
+	assert.NoError(err)
+	assert.Equal(testAddr, addr)
+
+	_, err = ParseBech32WithHRP(bech32Str, "avax")
+	assert.ErrorIs(err, errWrongHRP)
+}
+
+func TestFormatBatch(t *testing.T) {
+	assert := assert.New(t)
+
+	addrs := [][]byte{
+		testAddr,
+		make([]byte, 20),
+		{1, 2, 3},
+	}
+
+	formatted, err := FormatBatch("X", "avax", addrs)
+	assert.NoError(err)
+	assert.Len(formatted, len(addrs))
+	for i, addr := range addrs {
+		expected, err := Format("X", "avax", addr)
+		assert.NoError(err)
+		assert.Equal(expected, formatted[i])
+	}
+
+	formattedM, err := FormatBech32mBatch("X", "avax", addrs)
+	assert.NoError(err)
+	for i, addr := range addrs {
+		expected, err := FormatBech32m("avax", addr)
+		assert.NoError(err)
+		assert.Equal("X-"+expected, formattedM[i])
+	}
+
+	chainIDAliases, parsed, err := ParseBatchWithHRP(formatted, "avax")
+	assert.NoError(err)
+	assert.Equal([]string{"X", "X", "X"}, chainIDAliases)
+	assert.Equal(addrs, parsed)
+
+	_, _, err = ParseBatchWithHRP(formatted, "fuji")
+	assert.ErrorIs(err, errWrongHRP)
+
+	_, err = FormatBatch("X", "AVAX", addrs)
+	assert.ErrorIs(err, errInvalidHRPChar)
+}
+
+func TestConvert8To5(t *testing.T) {
+	assert := assert.New(t)
+
+	for length := 1; length < 64; length++ {
+		data := make([]byte, length)
+		for i := range data {
+			data[i] = byte(i*37 + length)
+		}
+		expected, err := bech32.ConvertBits(data, 8, 5, true)
+		assert.NoError(err)
+		assert.Equal(expected, convert8To5(nil, data))
+	}
+}
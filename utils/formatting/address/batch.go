@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package address
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/bech32"
+)
+
+// FormatBatch formats each of [addrs] with the same chain prefix and HRP. The
+// HRP and prefix are only validated once, and the 5-bit conversion buffer is
+// shared across the addresses.
+func FormatBatch(chainIDAlias string, hrp string, addrs [][]byte) ([]string, error) {
+	return formatBatch(chainIDAlias, hrp, addrs, bech32.Encode)
+}
+
+// FormatBech32mBatch is the bech32m equivalent of FormatBatch
+func FormatBech32mBatch(chainIDAlias string, hrp string, addrs [][]byte) ([]string, error) {
+	return formatBatch(chainIDAlias, hrp, addrs, bech32.EncodeM)
+}
+
+func formatBatch(
+	chainIDAlias string,
+	hrp string,
+	addrs [][]byte,
+	encode func(hrp string, data []byte) (string, error),
+) ([]string, error) {
+	if err := ValidateHRP(hrp); err != nil {
+		return nil, err
+	}
+
+	var (
+		prefix    = chainIDAlias + addressSep
+		fiveBits  []byte
+		formatted = make([]string, len(addrs))
+	)
+	for i, addr := range addrs {
+		fiveBits = convert8To5(fiveBits[:0], addr)
+		addrStr, err := encode(hrp, fiveBits)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't format address %d: %w", i, err)
+		}
+		formatted[i] = prefix + addrStr
+	}
+	return formatted, nil
+}
+
+// ParseBatchWithHRP parses each of [addrStrs] and verifies that they all have
+// the HRP [expectedHRP]. This returns the chain ID alias and address bytes of
+// each address.
+func ParseBatchWithHRP(addrStrs []string, expectedHRP string) ([]string, [][]byte, error) {
+	var (
+		chainIDAliases = make([]string, len(addrStrs))
+		addrs          = make([][]byte, len(addrStrs))
+	)
+	for i, addrStr := range addrStrs {
+		chainIDAlias, addr, err := ParseWithHRP(addrStr, expectedHRP)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+		}
+		chainIDAliases[i] = chainIDAlias
+		addrs[i] = addr
+	}
+	return chainIDAliases, addrs, nil
+}
+
+// convert8To5 appends the 5-bit groups of [data] to [dst], padding the final
+// group with zeros.
+func convert8To5(dst, data []byte) []byte {
+	var (
+		acc  uint32
+		bits uint8
+	)
+	for _, b := range data {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			dst = append(dst, byte(acc>>bits)&0x1f)
+		}
+	}
+	if bits > 0 {
+		dst = append(dst, byte(acc<<(5-bits))&0x1f)
+	}
+	return dst
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package address
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxHRPLength is the maximum length of an HRP allowed by BIP-173
+const maxHRPLength = 83
+
+var (
+	errEmptyHRP       = errors.New("hrp is empty")
+	errHRPTooLong     = errors.New("hrp is too long")
+	errInvalidHRPChar = errors.New("hrp contains an invalid character")
+	errWrongHRP       = errors.New("wrong hrp")
+)
+
+// ValidateHRP returns nil if [hrp] is a valid human-readable part. In addition
+// to the requirements of BIP-173, the HRP must be lowercase so that it has a
+// single canonical representation.
+func ValidateHRP(hrp string) error {
+	switch {
+	case len(hrp) == 0:
+		return errEmptyHRP
+	case len(hrp) > maxHRPLength:
+		return fmt.Errorf("%w: length %d > %d", errHRPTooLong, len(hrp), maxHRPLength)
+	}
+	for i := 0; i < len(hrp); i++ {
+		c := hrp[i]
+		if c < 33 || c > 126 || ('A' <= c && c <= 'Z') {
+			return fmt.Errorf("%w: %q at index %d", errInvalidHRPChar, c, i)
+		}
+	}
+	return nil
+}
+
+// VerifyHRP returns an error if [hrp] isn't [expectedHRP]
+func VerifyHRP(hrp, expectedHRP string) error {
+	if hrp != expectedHRP {
+		return fmt.Errorf("%w: expected %q but got %q", errWrongHRP, expectedHRP, hrp)
+	}
+	return nil
+}
+
+// ParseWithHRP parses [addrStr] and verifies that its HRP is [expectedHRP].
+// This returns the chain ID alias and the address bytes.
+func ParseWithHRP(addrStr, expectedHRP string) (string, []byte, error) {
+	chainIDAlias, hrp, addr, err := Parse(addrStr)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := VerifyHRP(hrp, expectedHRP); err != nil {
+		return "", nil, err
+	}
+	return chainIDAlias, addr, nil
+}
+
+// ParseBech32WithHRP parses the bech32 address [addrStr] and verifies that its
+// HRP is [expectedHRP]. This returns the address bytes.
+func ParseBech32WithHRP(addrStr, expectedHRP string) ([]byte, error) {
+	hrp, addr, err := ParseBech32(addrStr)
+	if err != nil {
+		return nil, err
+	}
+	return addr, VerifyHRP(hrp, expectedHRP)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package heap
+
+import "container/heap"
+
+var _ heap.Interface = &queue[int]{}
+
+// Heap is a binary heap of elements ordered by the provided less function. The
+// element for which less returns true against every other element is at the
+// top of the heap.
+type Heap[T any] struct {
+	queue *queue[T]
+}
+
+// New returns an empty heap ordered by [less]
+func New[T any](less func(a, b T) bool) Heap[T] {
+	return Heap[T]{
+		queue: &queue[T]{
+			less: less,
+		},
+	}
+}
+
+// Push adds [elt] to the heap
+func (h Heap[T]) Push(elt T) { heap.Push(h.queue, elt) }
+
+// Pop removes and returns the top element. Assumes that there is at least one
+// element.
+func (h Heap[T]) Pop() T { return heap.Pop(h.queue).(T) }
+
+// Peek returns the top element without removing it. Assumes that there is at
+// least one element.
+func (h Heap[T]) Peek() T { return h.queue.elts[0] }
+
+// Len returns the number of elements in the heap
+func (h Heap[T]) Len() int { return len(h.queue.elts) }
+
+// Clear removes all the elements from the heap
+func (h Heap[T]) Clear() {
+	var zero T
+	for i := range h.queue.elts {
+		h.queue.elts[i] = zero
+	}
+	h.queue.elts = h.queue.elts[:0]
+}
+
+type queue[T any] struct {
+	elts []T
+	less func(a, b T) bool
+}
+
+func (q *queue[T]) Len() int           { return len(q.elts) }
+func (q *queue[T]) Less(i, j int) bool { return q.less(q.elts[i], q.elts[j]) }
+func (q *queue[T]) Swap(i, j int)      { q.elts[i], q.elts[j] = q.elts[j], q.elts[i] }
+func (q *queue[T]) Push(x interface{}) { q.elts = append(q.elts, x.(T)) }
+func (q *queue[T]) Pop() interface{} {
+	var zero T
+	newLen := len(q.elts) - 1
+	elt := q.elts[newLen]
+	q.elts[newLen] = zero
+	q.elts = q.elts[:newLen]
+	return elt
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package heap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeap(t *testing.T) {
+	assert := assert.New(t)
+
+	h := New(func(a, b int) bool { return a < b })
+	assert.Zero(h.Len())
+
+	for _, elt := range []int{5, 1, 4, 2, 3, 1} {
+		h.Push(elt)
+	}
+	assert.Equal(6, h.Len())
+	assert.Equal(1, h.Peek())
+
+	popped := make([]int, 0, h.Len())
+	for h.Len() > 0 {
+		popped = append(popped, h.Pop())
+	}
+	assert.Equal([]int{1, 1, 2, 3, 4, 5}, popped)
+}
+
+func TestHeapClear(t *testing.T) {
+	assert := assert.New(t)
+
+	h := New(func(a, b int) bool { return a > b })
+	h.Push(1)
+	h.Push(2)
+	assert.Equal(2, h.Peek())
+
+	h.Clear()
+	assert.Zero(h.Len())
+
+	h.Push(3)
+	assert.Equal(3, h.Pop())
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package json
+
+import (
+	"sync"
+
+	"github.com/gorilla/rpc/v2"
+)
+
+var _ ServiceDescriber = &Server{}
+
+// ServiceDescriber is implemented by handlers that can report the JSON RPC
+// services they dispatch to
+type ServiceDescriber interface {
+	// Services returns the receivers of the registered services, keyed by the
+	// name of the service
+	Services() map[string]interface{}
+}
+
+// Server is a JSON RPC server that remembers the services registered on it so
+// that they can be described
+type Server struct {
+	*rpc.Server
+
+	lock     sync.RWMutex
+	services map[string]interface{}
+}
+
+// NewServer returns a new JSON RPC server. Codecs must still be registered on
+// the returned server.
+func NewServer() *Server {
+	return &Server{
+		Server:   rpc.NewServer(),
+		services: make(map[string]interface{}),
+	}
+}
+
+// RegisterService registers [receiver] as the service [name]. See
+// rpc.Server.RegisterService.
+func (s *Server) RegisterService(receiver interface{}, name string) error {
+	if err := s.Server.RegisterService(receiver, name); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.services[name] = receiver
+	return nil
+}
+
+func (s *Server) Services() map[string]interface{} {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	services := make(map[string]interface{}, len(s.services))
+	for name, receiver := range s.services {
+		services[name] = receiver
+	}
+	return services
+}
@@ -4,11 +4,14 @@
 package logging
 
 type RotatingWriterConfig struct {
-	MaxSize   int    `json:"maxSize"` // in megabytes
-	MaxFiles  int    `json:"maxFiles"`
-	MaxAge    int    `json:"maxAge"` // in days
-	Directory string `json:"directory"`
-	Compress  bool   `json:"compress"`
+	MaxSize  int `json:"maxSize"` // in megabytes
+	MaxFiles int `json:"maxFiles"`
+	MaxAge   int `json:"maxAge"` // in days
+	// Maximum size of a log file and all of its rotated files. If 0, there is
+	// no limit.
+	MaxTotalSize int    `json:"maxTotalSize"` // in megabytes
+	Directory    string `json:"directory"`
+	Compress     bool   `json:"compress"`
 }
 
 // Config defines the configuration of a logger
@@ -21,4 +24,21 @@ type Config struct {
 	LogFormat               Format `json:"logFormat"`
 	MsgPrefix               string `json:"-"`
 	LoggerName              string `json:"-"`
+
+	// Logger name --> rotating writer config to use instead of the default.
+	// If the directory of an override is empty, the default directory is used.
+	RotatingWriterOverrides map[string]RotatingWriterConfig `json:"rotatingWriterOverrides"`
+}
+
+// rotatingWriterConfig returns the rotating writer config of the logger named
+// [LoggerName].
+func (c *Config) rotatingWriterConfig() RotatingWriterConfig {
+	override, ok := c.RotatingWriterOverrides[c.LoggerName]
+	if !ok {
+		return c.RotatingWriterConfig
+	}
+	if override.Directory == "" {
+		override.Directory = c.Directory
+	}
+	return override
 }
@@ -6,17 +6,24 @@ package logging
 import (
 	"fmt"
 	"os"
-	"path"
+	"sort"
 	"sync"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var _ Factory = &factory{}
 
+// stdout writes to the standard output of the process. Unlike [os.Stdout],
+// closing it is a no-op, so stopping a logger doesn't prevent the other
+// loggers of the process from displaying their logs.
+type stdout struct{}
+
+func (stdout) Write(p []byte) (int, error) { return os.Stdout.Write(p) }
+
+func (stdout) Close() error { return nil }
+
 // Factory creates new instances of different types of Logger
 type Factory interface {
 	// Make creates a new logger with name [name]
@@ -37,7 +44,7 @@ type Factory interface {
 	// GetDisplayLevels returns all log display levels in factory as name, level pairs
 	GetDisplayLevel(name string) (Level, error)
 
-	// GetLoggerNames returns the names of all logs created by this factory
+	// GetLoggerNames returns the sorted names of all logs created by this factory
 	GetLoggerNames() []string
 
 	// Close stops and clears all of a Factory's instantiated loggers
@@ -76,16 +83,10 @@ func (f *factory) makeLogger(config Config) (Logger, error) {
 	consoleEnc := config.LogFormat.ConsoleEncoder()
 	fileEnc := config.LogFormat.FileEncoder()
 
-	consoleCore := NewWrappedCore(config.DisplayLevel, os.Stdout, consoleEnc)
+	consoleCore := NewWrappedCore(config.DisplayLevel, stdout{}, consoleEnc)
 	consoleCore.WriterDisabled = config.DisableWriterDisplaying
 
-	rw := &lumberjack.Logger{
-		Filename:   path.Join(config.Directory, config.LoggerName+".log"),
-		MaxSize:    config.MaxSize,  // megabytes
-		MaxAge:     config.MaxAge,   // days
-		MaxBackups: config.MaxFiles, // files
-		Compress:   config.Compress,
-	}
+	rw := newRotatingWriter(config.LoggerName, config.rotatingWriterConfig())
 	fileCore := NewWrappedCore(config.LogLevel, rw, fileEnc)
 	prefix := config.LogFormat.WrapPrefix(config.MsgPrefix)
 
@@ -171,6 +172,7 @@ func (f *factory) GetLoggerNames() []string {
 	for name := range f.loggers {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
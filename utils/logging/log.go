@@ -87,6 +87,13 @@ func (l *log) log(level Level, format string, args ...interface{}) {
 	}
 }
 
+// Should only be called from [Level]w functions.
+func (l *log) logw(level Level, msg string, fields ...zap.Field) {
+	if ce := l.internalLogger.Check(zapcore.Level(level), msg); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
 func (l *log) Fatal(format string, args ...interface{}) {
 	l.log(Fatal, format, args...)
 }
@@ -115,6 +122,42 @@ func (l *log) Verbo(format string, args ...interface{}) {
 	l.log(Verbo, format, args...)
 }
 
+func (l *log) Fatalw(msg string, fields ...zap.Field) {
+	l.logw(Fatal, msg, fields...)
+}
+
+func (l *log) Errorw(msg string, fields ...zap.Field) {
+	l.logw(Error, msg, fields...)
+}
+
+func (l *log) Warnw(msg string, fields ...zap.Field) {
+	l.logw(Warn, msg, fields...)
+}
+
+func (l *log) Infow(msg string, fields ...zap.Field) {
+	l.logw(Info, msg, fields...)
+}
+
+func (l *log) Tracew(msg string, fields ...zap.Field) {
+	l.logw(Trace, msg, fields...)
+}
+
+func (l *log) Debugw(msg string, fields ...zap.Field) {
+	l.logw(Debug, msg, fields...)
+}
+
+func (l *log) Verbow(msg string, fields ...zap.Field) {
+	l.logw(Verbo, msg, fields...)
+}
+
+func (l *log) With(fields ...zap.Field) Logger {
+	return &log{
+		assertionsEnabled: l.assertionsEnabled,
+		wrappedCores:      l.wrappedCores,
+		internalLogger:    l.internalLogger.With(fields...),
+	}
+}
+
 func (l *log) AssertNoError(err error) {
 	if err != nil {
 		l.Fatal("%s", err)
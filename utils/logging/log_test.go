@@ -3,7 +3,15 @@
 
 package logging
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/zap"
+)
 
 func TestLog(t *testing.T) {
 	log := NewLogger(false, "", NewWrappedCore(Info, Discard, Plain.ConsoleEncoder()))
@@ -21,3 +29,32 @@ func TestLog(t *testing.T) {
 		t.Fatalf("Exit function was never called")
 	}
 }
+
+type bufferWriteCloser struct {
+	bytes.Buffer
+}
+
+func (*bufferWriteCloser) Close() error { return nil }
+
+func TestLogJSONFields(t *testing.T) {
+	assert := assert.New(t)
+
+	w := &bufferWriteCloser{}
+	log := NewLogger(false, "C Chain", NewWrappedCore(Info, w, JSON.FileEncoder()))
+	log = log.With(zap.String("chain", "C"))
+
+	log.Debugw("dropped", zap.Int("height", 1))
+	assert.Zero(w.Len())
+
+	log.Infow("accepted block", zap.Uint64("height", 2))
+
+	entry := map[string]interface{}{}
+	assert.NoError(json.Unmarshal(w.Bytes(), &entry))
+	assert.Equal("info", entry["level"])
+	assert.Equal("C Chain", entry["logger"])
+	assert.Equal("accepted block", entry["msg"])
+	assert.Equal("C", entry["chain"])
+	assert.Equal(2.0, entry["height"])
+	assert.Contains(entry["caller"], "log_test.go")
+	assert.Contains(entry, "timestamp")
+}
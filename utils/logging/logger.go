@@ -5,6 +5,8 @@ package logging
 
 import (
 	"io"
+
+	"go.uber.org/zap"
 )
 
 // Logger defines the interface that is used to keep a record of all events that
@@ -34,6 +36,21 @@ type Logger interface {
 	// aspect of the program
 	Verbo(format string, args ...interface{})
 
+	// Fatalw, Errorw, Warnw, Infow, Tracew, Debugw, and Verbow log [msg] at the
+	// corresponding level along with the provided structured [fields]. When
+	// using the JSON log format, each field is written as a separate key.
+	Fatalw(msg string, fields ...zap.Field)
+	Errorw(msg string, fields ...zap.Field)
+	Warnw(msg string, fields ...zap.Field)
+	Infow(msg string, fields ...zap.Field)
+	Tracew(msg string, fields ...zap.Field)
+	Debugw(msg string, fields ...zap.Field)
+	Verbow(msg string, fields ...zap.Field)
+
+	// With returns a logger that includes [fields] in every message that it
+	// logs. The returned logger shares its outputs with this logger.
+	With(fields ...zap.Field) Logger
+
 	// If assertions are enabled, will result in a panic if err is non-nil
 	AssertNoError(err error)
 	// If assertions are enabled, will result in a panic if b is false
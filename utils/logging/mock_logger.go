@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	zap "go.uber.org/zap"
 )
 
 // MockLogger is a mock of Logger interface.
@@ -108,6 +109,23 @@ func (mr *MockLoggerMockRecorder) Debug(format interface{}, args ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debug", reflect.TypeOf((*MockLogger)(nil).Debug), varargs...)
 }
 
+// Debugw mocks base method.
+func (m *MockLogger) Debugw(msg string, fields ...zap.Field) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Debugw", varargs...)
+}
+
+// Debugw indicates an expected call of Debugw.
+func (mr *MockLoggerMockRecorder) Debugw(msg interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debugw", reflect.TypeOf((*MockLogger)(nil).Debugw), varargs...)
+}
+
 // Error mocks base method.
 func (m *MockLogger) Error(format string, args ...interface{}) {
 	m.ctrl.T.Helper()
@@ -125,6 +143,23 @@ func (mr *MockLoggerMockRecorder) Error(format interface{}, args ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockLogger)(nil).Error), varargs...)
 }
 
+// Errorw mocks base method.
+func (m *MockLogger) Errorw(msg string, fields ...zap.Field) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Errorw", varargs...)
+}
+
+// Errorw indicates an expected call of Errorw.
+func (mr *MockLoggerMockRecorder) Errorw(msg interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errorw", reflect.TypeOf((*MockLogger)(nil).Errorw), varargs...)
+}
+
 // Fatal mocks base method.
 func (m *MockLogger) Fatal(format string, args ...interface{}) {
 	m.ctrl.T.Helper()
@@ -142,6 +177,23 @@ func (mr *MockLoggerMockRecorder) Fatal(format interface{}, args ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatal", reflect.TypeOf((*MockLogger)(nil).Fatal), varargs...)
 }
 
+// Fatalw mocks base method.
+func (m *MockLogger) Fatalw(msg string, fields ...zap.Field) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Fatalw", varargs...)
+}
+
+// Fatalw indicates an expected call of Fatalw.
+func (mr *MockLoggerMockRecorder) Fatalw(msg interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatalw", reflect.TypeOf((*MockLogger)(nil).Fatalw), varargs...)
+}
+
 // GetDisplayLevel mocks base method.
 func (m *MockLogger) GetDisplayLevel() Level {
 	m.ctrl.T.Helper()
@@ -187,6 +239,23 @@ func (mr *MockLoggerMockRecorder) Info(format interface{}, args ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockLogger)(nil).Info), varargs...)
 }
 
+// Infow mocks base method.
+func (m *MockLogger) Infow(msg string, fields ...zap.Field) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Infow", varargs...)
+}
+
+// Infow indicates an expected call of Infow.
+func (mr *MockLoggerMockRecorder) Infow(msg interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Infow", reflect.TypeOf((*MockLogger)(nil).Infow), varargs...)
+}
+
 // RecoverAndExit mocks base method.
 func (m *MockLogger) RecoverAndExit(f, exit func()) {
 	m.ctrl.T.Helper()
@@ -300,6 +369,23 @@ func (mr *MockLoggerMockRecorder) Trace(format interface{}, args ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trace", reflect.TypeOf((*MockLogger)(nil).Trace), varargs...)
 }
 
+// Tracew mocks base method.
+func (m *MockLogger) Tracew(msg string, fields ...zap.Field) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Tracew", varargs...)
+}
+
+// Tracew indicates an expected call of Tracew.
+func (mr *MockLoggerMockRecorder) Tracew(msg interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tracew", reflect.TypeOf((*MockLogger)(nil).Tracew), varargs...)
+}
+
 // Verbo mocks base method.
 func (m *MockLogger) Verbo(format string, args ...interface{}) {
 	m.ctrl.T.Helper()
@@ -317,6 +403,23 @@ func (mr *MockLoggerMockRecorder) Verbo(format interface{}, args ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verbo", reflect.TypeOf((*MockLogger)(nil).Verbo), varargs...)
 }
 
+// Verbow mocks base method.
+func (m *MockLogger) Verbow(msg string, fields ...zap.Field) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Verbow", varargs...)
+}
+
+// Verbow indicates an expected call of Verbow.
+func (mr *MockLoggerMockRecorder) Verbow(msg interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verbow", reflect.TypeOf((*MockLogger)(nil).Verbow), varargs...)
+}
+
 // Warn mocks base method.
 func (m *MockLogger) Warn(format string, args ...interface{}) {
 	m.ctrl.T.Helper()
@@ -334,6 +437,41 @@ func (mr *MockLoggerMockRecorder) Warn(format interface{}, args ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*MockLogger)(nil).Warn), varargs...)
 }
 
+// Warnw mocks base method.
+func (m *MockLogger) Warnw(msg string, fields ...zap.Field) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warnw", varargs...)
+}
+
+// Warnw indicates an expected call of Warnw.
+func (mr *MockLoggerMockRecorder) Warnw(msg interface{}, fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnw", reflect.TypeOf((*MockLogger)(nil).Warnw), varargs...)
+}
+
+// With mocks base method.
+func (m *MockLogger) With(fields ...zap.Field) Logger {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "With", varargs...)
+	ret0, _ := ret[0].(Logger)
+	return ret0
+}
+
+// With indicates an expected call of With.
+func (mr *MockLoggerMockRecorder) With(fields ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "With", reflect.TypeOf((*MockLogger)(nil).With), fields...)
+}
+
 // Write mocks base method.
 func (m *MockLogger) Write(p []byte) (int, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	megabyte = 1024 * 1024
+
+	// defaultMaxSize is the size, in megabytes, that the underlying writer
+	// rotates files at if no size is specified.
+	defaultMaxSize = 100
+
+	logFileExt = ".log"
+)
+
+var _ io.WriteCloser = &rotatingWriter{}
+
+// rotatingWriter writes to a log file that is rotated by size and age. Once
+// the total size of the rotated files exceeds the configured cap, the oldest
+// rotated files are removed.
+type rotatingWriter struct {
+	lock sync.Mutex
+
+	writer *lumberjack.Logger
+
+	dir  string
+	name string
+
+	// Maximum number of bytes allowed across the current log file and all of
+	// its rotated files. If 0, there is no limit.
+	maxTotalSize int64
+	// Maximum size of the current log file before it is rotated.
+	maxFileSize int64
+	// Number of bytes written since the retention policy was last enforced.
+	written int64
+}
+
+func newRotatingWriter(name string, config RotatingWriterConfig) *rotatingWriter {
+	maxSize := config.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+	return &rotatingWriter{
+		writer: &lumberjack.Logger{
+			Filename:   filepath.Join(config.Directory, name+logFileExt),
+			MaxSize:    config.MaxSize,  // megabytes
+			MaxAge:     config.MaxAge,   // days
+			MaxBackups: config.MaxFiles, // files
+			Compress:   config.Compress,
+		},
+		dir:          config.Directory,
+		name:         name,
+		maxTotalSize: int64(config.MaxTotalSize) * megabyte,
+		maxFileSize:  int64(maxSize) * megabyte,
+	}
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	if w.maxTotalSize <= 0 {
+		return n, err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	// The underlying writer only rotates once the current file reaches
+	// [maxFileSize], so there is no need to check the rotated files more often
+	// than that.
+	w.written += int64(n)
+	if w.written >= w.maxFileSize {
+		w.written = 0
+		w.enforceMaxTotalSize()
+	}
+	return n, err
+}
+
+func (w *rotatingWriter) Close() error {
+	if w.maxTotalSize > 0 {
+		w.lock.Lock()
+		w.enforceMaxTotalSize()
+		w.lock.Unlock()
+	}
+	return w.writer.Close()
+}
+
+// enforceMaxTotalSize removes the oldest rotated log files until the total
+// size of the log files is at most [maxTotalSize].
+//
+// Assumes [w.lock] is held.
+func (w *rotatingWriter) enforceMaxTotalSize() {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return
+	}
+
+	var (
+		totalSize int64
+		rotated   []os.FileInfo
+	)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		fileName := entry.Name()
+		switch {
+		case fileName == w.name+logFileExt:
+			totalSize += info.Size()
+		case w.isRotatedFile(fileName):
+			totalSize += info.Size()
+			rotated = append(rotated, info)
+		}
+	}
+
+	// Rotated file names end with the time they were rotated, so sorting by
+	// name orders them from oldest to newest.
+	sort.Slice(rotated, func(i, j int) bool {
+		return rotated[i].Name() < rotated[j].Name()
+	})
+	for _, info := range rotated {
+		if totalSize <= w.maxTotalSize {
+			return
+		}
+		if err := os.Remove(filepath.Join(w.dir, info.Name())); err == nil {
+			totalSize -= info.Size()
+		}
+	}
+}
+
+// isRotatedFile returns true if [fileName] is a rotated, and possibly
+// compressed, version of this writer's log file.
+func (w *rotatingWriter) isRotatedFile(fileName string) bool {
+	if !strings.HasPrefix(fileName, w.name+"-") {
+		return false
+	}
+	fileName = strings.TrimSuffix(fileName, ".gz")
+	if !strings.HasSuffix(fileName, logFileExt) {
+		return false
+	}
+	// Rotated files have the form <name>-<timestamp>.log where the timestamp
+	// doesn't contain any letters other than 'T'. This avoids treating the log
+	// files of other loggers, such as <name>-<suffix>.log, as rotated files.
+	timestamp := strings.TrimSuffix(strings.TrimPrefix(fileName, w.name+"-"), logFileExt)
+	for _, r := range timestamp {
+		if (r < '0' || r > '9') && r != '-' && r != '.' && r != 'T' {
+			return false
+		}
+	}
+	return len(timestamp) > 0
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingWriterMaxTotalSize(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	oldFiles := []string{
+		"main-2022-01-01T00-00-00.000.log.gz",
+		"main-2022-01-02T00-00-00.000.log.gz",
+		"main-2022-01-03T00-00-00.000.log",
+	}
+	otherFiles := []string{
+		"main-chain.log",
+		"C-2022-01-01T00-00-00.000.log",
+	}
+	for _, fileName := range append(oldFiles, otherFiles...) {
+		assert.NoError(os.WriteFile(filepath.Join(dir, fileName), make([]byte, megabyte/2), 0o600))
+	}
+
+	w := newRotatingWriter("main", RotatingWriterConfig{
+		Directory:    dir,
+		MaxSize:      1,
+		MaxTotalSize: 1,
+	})
+	_, err := w.Write([]byte("hello\n"))
+	assert.NoError(err)
+	assert.NoError(w.Close())
+
+	// The current log file and the newest rotated file fit in the cap.
+	for _, fileName := range oldFiles[:2] {
+		assert.NoFileExists(filepath.Join(dir, fileName))
+	}
+	assert.FileExists(filepath.Join(dir, oldFiles[2]))
+	assert.FileExists(filepath.Join(dir, "main.log"))
+	for _, fileName := range otherFiles {
+		assert.FileExists(filepath.Join(dir, fileName))
+	}
+}
+
+func TestRotatingWriterNoMaxTotalSize(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	rotatedFile := filepath.Join(dir, "main-2022-01-01T00-00-00.000.log")
+	assert.NoError(os.WriteFile(rotatedFile, make([]byte, megabyte), 0o600))
+
+	w := newRotatingWriter("main", RotatingWriterConfig{
+		Directory: dir,
+		MaxSize:   1,
+	})
+	_, err := w.Write([]byte("hello\n"))
+	assert.NoError(err)
+	assert.NoError(w.Close())
+
+	assert.FileExists(rotatedFile)
+}
+
+func TestConfigRotatingWriterOverrides(t *testing.T) {
+	assert := assert.New(t)
+
+	config := Config{
+		RotatingWriterConfig: RotatingWriterConfig{
+			Directory: "logs",
+			MaxSize:   8,
+		},
+		RotatingWriterOverrides: map[string]RotatingWriterConfig{
+			"C": {
+				MaxSize:      16,
+				MaxTotalSize: 64,
+				Compress:     true,
+			},
+		},
+		LoggerName: "main",
+	}
+	assert.Equal(config.RotatingWriterConfig, config.rotatingWriterConfig())
+
+	config.LoggerName = "C"
+	assert.Equal(RotatingWriterConfig{
+		Directory:    "logs",
+		MaxSize:      16,
+		MaxTotalSize: 64,
+		Compress:     true,
+	}, config.rotatingWriterConfig())
+}
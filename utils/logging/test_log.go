@@ -6,6 +6,8 @@ package logging
 import (
 	"errors"
 	"io"
+
+	"go.uber.org/zap"
 )
 
 var (
@@ -35,6 +37,22 @@ func (NoLog) Debug(format string, args ...interface{}) {}
 
 func (NoLog) Verbo(format string, args ...interface{}) {}
 
+func (NoLog) Fatalw(string, ...zap.Field) {}
+
+func (NoLog) Errorw(string, ...zap.Field) {}
+
+func (NoLog) Warnw(string, ...zap.Field) {}
+
+func (NoLog) Infow(string, ...zap.Field) {}
+
+func (NoLog) Tracew(string, ...zap.Field) {}
+
+func (NoLog) Debugw(string, ...zap.Field) {}
+
+func (NoLog) Verbow(string, ...zap.Field) {}
+
+func (n NoLog) With(...zap.Field) Logger { return n }
+
 func (NoLog) AssertNoError(error) {}
 
 func (NoLog) AssertTrue(b bool, format string, args ...interface{}) {}
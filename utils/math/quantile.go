@@ -0,0 +1,187 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package math
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+const (
+	// maxQuantileExponent is the largest exponent that may be applied to a
+	// new observation's weight before all the bucket weights are rescaled.
+	maxQuantileExponent = 64
+
+	// minQuantileBucketWeight is the weight below which a bucket is considered
+	// to be empty after rescaling.
+	minQuantileBucketWeight = 1e-12
+)
+
+var (
+	errInvalidRelativeAccuracy = errors.New("relative accuracy must be in (0, 1)")
+	errNonPositiveHalflife     = errors.New("halflife must be positive")
+)
+
+// Quantiles tracks a continuous time exponentially decaying estimate of the
+// distribution of the provided values.
+type Quantiles interface {
+	// Observe the value at the given time
+	Observe(value float64, currentTime time.Time)
+
+	// Quantile returns an estimate of the [q]-th quantile of the provided
+	// values. [q] is expected to be in [0, 1]. If no values have been
+	// observed, 0 is returned.
+	Quantile(q float64) float64
+}
+
+// quantileSketch is a log-bucketed sketch of the observed values. Each
+// estimate returned is within [relativeAccuracy] of a value that was observed
+// near the requested rank.
+//
+// Older observations are decayed with the provided halflife. Rather than
+// decaying every bucket on each observation, new observations are given an
+// exponentially increasing weight, and all the buckets are rescaled once that
+// weight becomes too large.
+type quantileSketch struct {
+	halflife float64
+	logGamma float64
+
+	// Time that weights are currently measured relative to
+	startTime time.Time
+
+	// Weight of observations that were <= 0
+	zeroWeight float64
+	// buckets[i] is the weight of observations in bucket [i + offset]
+	buckets     []float64
+	offset      int
+	totalWeight float64
+}
+
+// NewQuantiles returns a new Quantiles estimator. Estimates are accurate to
+// within [relativeAccuracy] of the true value, and older values are decayed by
+// [halflife].
+func NewQuantiles(
+	relativeAccuracy float64,
+	halflife time.Duration,
+	currentTime time.Time,
+) (Quantiles, error) {
+	switch {
+	case relativeAccuracy <= 0 || relativeAccuracy >= 1:
+		return nil, errInvalidRelativeAccuracy
+	case halflife <= 0:
+		return nil, errNonPositiveHalflife
+	}
+	gamma := (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	return &quantileSketch{
+		halflife:  float64(halflife) / convertEToBase2,
+		logGamma:  math.Log(gamma),
+		startTime: currentTime,
+	}, nil
+}
+
+func (s *quantileSketch) Observe(value float64, currentTime time.Time) {
+	exponent := float64(currentTime.Sub(s.startTime)) / s.halflife
+	if exponent > maxQuantileExponent {
+		s.rescale(currentTime)
+		exponent = 0
+	}
+	weight := math.Exp(exponent)
+	s.totalWeight += weight
+
+	if value <= 0 {
+		s.zeroWeight += weight
+		return
+	}
+
+	index := int(math.Ceil(math.Log(value) / s.logGamma))
+	switch {
+	case len(s.buckets) == 0:
+		s.buckets = []float64{0}
+		s.offset = index
+	case index < s.offset:
+		grow := s.offset - index
+		buckets := make([]float64, grow+len(s.buckets))
+		copy(buckets[grow:], s.buckets)
+		s.buckets = buckets
+		s.offset = index
+	case index >= s.offset+len(s.buckets):
+		grow := index - s.offset - len(s.buckets) + 1
+		s.buckets = append(s.buckets, make([]float64, grow)...)
+	}
+	s.buckets[index-s.offset] += weight
+}
+
+func (s *quantileSketch) Quantile(q float64) float64 {
+	if s.totalWeight == 0 {
+		return 0
+	}
+
+	rank := q * s.totalWeight
+	if s.zeroWeight > 0 && rank <= s.zeroWeight {
+		return 0
+	}
+
+	seen := s.zeroWeight
+	for i, weight := range s.buckets {
+		seen += weight
+		if weight > 0 && seen >= rank {
+			return s.value(i + s.offset)
+		}
+	}
+
+	// Floating point rounding may cause [seen] to fall marginally short of
+	// [rank]. Report the largest non-empty bucket.
+	for i := len(s.buckets) - 1; i >= 0; i-- {
+		if s.buckets[i] > 0 {
+			return s.value(i + s.offset)
+		}
+	}
+	return 0
+}
+
+// value returns the representative value of the bucket at [index].
+func (s *quantileSketch) value(index int) float64 {
+	// Bucket [index] contains values in (gamma^(index-1), gamma^index]. The
+	// returned value minimizes the relative error across that range.
+	upper := math.Exp(float64(index) * s.logGamma)
+	lower := math.Exp(float64(index-1) * s.logGamma)
+	return 2 * lower * upper / (lower + upper)
+}
+
+// rescale sets [startTime] to [currentTime] and adjusts all the weights
+// accordingly.
+func (s *quantileSketch) rescale(currentTime time.Time) {
+	scale := math.Exp(float64(s.startTime.Sub(currentTime)) / s.halflife)
+	s.startTime = currentTime
+
+	s.zeroWeight = rescaleWeight(s.zeroWeight, scale)
+	s.totalWeight = s.zeroWeight
+	for i, weight := range s.buckets {
+		weight = rescaleWeight(weight, scale)
+		s.buckets[i] = weight
+		s.totalWeight += weight
+	}
+
+	// Drop the empty buckets from either end so that the sketch only spans the
+	// recently observed values.
+	start := 0
+	for start < len(s.buckets) && s.buckets[start] == 0 {
+		start++
+	}
+	end := len(s.buckets)
+	for end > start && s.buckets[end-1] == 0 {
+		end--
+	}
+	s.buckets = s.buckets[start:end]
+	s.offset += start
+}
+
+func rescaleWeight(weight, scale float64) float64 {
+	weight *= scale
+	if weight < minQuantileBucketWeight {
+		return 0
+	}
+	return weight
+}
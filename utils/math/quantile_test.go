@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package math
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuantilesInvalidArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewQuantiles(0, time.Second, time.Now())
+	assert.ErrorIs(err, errInvalidRelativeAccuracy)
+
+	_, err = NewQuantiles(1, time.Second, time.Now())
+	assert.ErrorIs(err, errInvalidRelativeAccuracy)
+
+	_, err = NewQuantiles(.01, 0, time.Now())
+	assert.ErrorIs(err, errNonPositiveHalflife)
+}
+
+func TestQuantilesEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	q, err := NewQuantiles(.01, time.Second, time.Now())
+	assert.NoError(err)
+	assert.Zero(q.Quantile(.5))
+}
+
+func TestQuantilesAccuracy(t *testing.T) {
+	assert := assert.New(t)
+
+	relativeAccuracy := .01
+	currentTime := time.Now()
+	q, err := NewQuantiles(relativeAccuracy, time.Hour, currentTime)
+	assert.NoError(err)
+
+	for i := 1; i <= 1000; i++ {
+		q.Observe(float64(i), currentTime)
+	}
+
+	for _, test := range []struct {
+		quantile float64
+		expected float64
+	}{
+		{quantile: 0, expected: 1},
+		{quantile: .5, expected: 500},
+		{quantile: .95, expected: 950},
+		{quantile: .99, expected: 990},
+		{quantile: 1, expected: 1000},
+	} {
+		assert.InEpsilon(test.expected, q.Quantile(test.quantile), relativeAccuracy)
+	}
+}
+
+func TestQuantilesBimodal(t *testing.T) {
+	assert := assert.New(t)
+
+	currentTime := time.Now()
+	q, err := NewQuantiles(.01, time.Hour, currentTime)
+	assert.NoError(err)
+
+	// 90% of the values are fast and 10% of the values are slow. An average
+	// would report a value that was never observed.
+	for i := 0; i < 900; i++ {
+		q.Observe(10, currentTime)
+	}
+	for i := 0; i < 100; i++ {
+		q.Observe(1000, currentTime)
+	}
+
+	assert.InEpsilon(10, q.Quantile(.5), .01)
+	assert.InEpsilon(1000, q.Quantile(.95), .01)
+}
+
+func TestQuantilesZero(t *testing.T) {
+	assert := assert.New(t)
+
+	currentTime := time.Now()
+	q, err := NewQuantiles(.01, time.Hour, currentTime)
+	assert.NoError(err)
+
+	q.Observe(0, currentTime)
+	q.Observe(-1, currentTime)
+	q.Observe(100, currentTime)
+
+	assert.Zero(q.Quantile(.5))
+	assert.InEpsilon(100, q.Quantile(1), .01)
+}
+
+func TestQuantilesDecay(t *testing.T) {
+	assert := assert.New(t)
+
+	halflife := time.Second
+	currentTime := time.Now()
+	q, err := NewQuantiles(.01, halflife, currentTime)
+	assert.NoError(err)
+
+	q.Observe(10, currentTime)
+
+	// After one halflife, a single observation has twice the weight of the
+	// previous observation.
+	currentTime = currentTime.Add(halflife)
+	q.Observe(1000, currentTime)
+	assert.InEpsilon(10, q.Quantile(.3), .01)
+	assert.InEpsilon(1000, q.Quantile(.4), .01)
+
+	// Advancing far enough forces the weights to be rescaled. The old
+	// observations should no longer have any effect.
+	currentTime = currentTime.Add(1000 * halflife)
+	q.Observe(100, currentTime)
+	assert.InEpsilon(100, q.Quantile(0), .01)
+	assert.InEpsilon(100, q.Quantile(1), .01)
+}
@@ -3,12 +3,27 @@
 
 package math
 
-import (
-	"errors"
-	"math"
+import "errors"
+
+var (
+	ErrOverflow  = errors.New("overflow occurred")
+	ErrUnderflow = errors.New("underflow occurred")
 )
 
-var errOverflow = errors.New("overflow occurred")
+// Integer is any integer type
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Signed is any signed integer type
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is any unsigned integer type
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
 
 // Max64 returns the maximum of the values provided
 func Max64(max uint64, nums ...uint64) uint64 {
@@ -40,36 +55,82 @@ func Min64(min uint64, nums ...uint64) uint64 {
 	return min
 }
 
-// Add64 returns:
+// Add returns:
 // 1) a + b
-// 2) If there is overflow, an error
-func Add64(a, b uint64) (uint64, error) {
-	if a > math.MaxUint64-b {
-		return 0, errOverflow
+// 2) If there is overflow or underflow, an error
+func Add[T Integer](a, b T) (T, error) {
+	var zero T
+	c := a + b
+	switch {
+	case b > zero && c < a:
+		return zero, ErrOverflow
+	case b < zero && c > a:
+		return zero, ErrUnderflow
+	default:
+		return c, nil
 	}
-	return a + b, nil
 }
 
-// Sub64 returns:
+// Sub returns:
 // 1) a - b
-// 2) If there is underflow, an error
-func Sub64(a, b uint64) (uint64, error) {
-	if a < b {
-		return 0, errOverflow
+// 2) If there is overflow or underflow, an error
+func Sub[T Integer](a, b T) (T, error) {
+	var zero T
+	c := a - b
+	switch {
+	case b > zero && c > a:
+		return zero, ErrUnderflow
+	case b < zero && c < a:
+		return zero, ErrOverflow
+	default:
+		return c, nil
 	}
-	return a - b, nil
 }
 
-// Mul64 returns:
+// Mul returns:
 // 1) a * b
-// 2) If there is overflow, an error
-func Mul64(a, b uint64) (uint64, error) {
-	if b != 0 && a > math.MaxUint64/b {
-		return 0, errOverflow
+// 2) If there is overflow or underflow, an error
+func Mul[T Integer](a, b T) (T, error) {
+	var zero T
+	if a == zero || b == zero {
+		return zero, nil
 	}
-	return a * b, nil
+
+	c := a * b
+	// The sign check catches the case where [a] is the minimum value of a
+	// signed type and [b] is -1, as dividing [c] by [b] doesn't detect that
+	// overflow.
+	negative := (a < zero) != (b < zero)
+	if c/b != a || (c < zero) != negative {
+		if negative {
+			return zero, ErrUnderflow
+		}
+		return zero, ErrOverflow
+	}
+	return c, nil
 }
 
 func Diff64(a, b uint64) uint64 {
 	return Max64(a, b) - Min64(a, b)
 }
+
+// Add64 returns:
+// 1) a + b
+// 2) If there is overflow, an error
+//
+// Deprecated: Use Add instead.
+func Add64(a, b uint64) (uint64, error) { return Add(a, b) }
+
+// Sub64 returns:
+// 1) a - b
+// 2) If there is underflow, an error
+//
+// Deprecated: Use Sub instead.
+func Sub64(a, b uint64) (uint64, error) { return Sub(a, b) }
+
+// Mul64 returns:
+// 1) a * b
+// 2) If there is overflow, an error
+//
+// Deprecated: Use Mul instead.
+func Mul64(a, b uint64) (uint64, error) { return Mul(a, b) }
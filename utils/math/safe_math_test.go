@@ -6,6 +6,8 @@ package math
 import (
 	"math"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 const maxUint64 uint64 = math.MaxUint64
@@ -116,3 +118,96 @@ func TestDiff64(t *testing.T) {
 		t.Fatalf("Expected %d, got %d", maxUint64, actual)
 	}
 }
+
+func TestAdd(t *testing.T) {
+	assert := assert.New(t)
+
+	sum, err := Add[int8](math.MaxInt8, math.MinInt8)
+	assert.NoError(err)
+	assert.Equal(int8(-1), sum)
+
+	_, err = Add[int8](math.MaxInt8, 1)
+	assert.ErrorIs(err, ErrOverflow)
+
+	_, err = Add[int8](math.MinInt8, -1)
+	assert.ErrorIs(err, ErrUnderflow)
+
+	_, err = Add[uint16](math.MaxUint16, 1)
+	assert.ErrorIs(err, ErrOverflow)
+
+	sum64, err := Add[int64](math.MaxInt64, 0)
+	assert.NoError(err)
+	assert.Equal(int64(math.MaxInt64), sum64)
+}
+
+func TestSub(t *testing.T) {
+	assert := assert.New(t)
+
+	diff, err := Sub[int8](math.MinInt8, math.MinInt8)
+	assert.NoError(err)
+	assert.Zero(diff)
+
+	_, err = Sub[int8](math.MinInt8, 1)
+	assert.ErrorIs(err, ErrUnderflow)
+
+	_, err = Sub[int8](0, math.MinInt8)
+	assert.ErrorIs(err, ErrOverflow)
+
+	_, err = Sub[uint32](0, 1)
+	assert.ErrorIs(err, ErrUnderflow)
+}
+
+func TestMul(t *testing.T) {
+	assert := assert.New(t)
+
+	product, err := Mul[int8](-16, 8)
+	assert.NoError(err)
+	assert.Equal(int8(math.MinInt8), product)
+
+	_, err = Mul[int8](16, 8)
+	assert.ErrorIs(err, ErrOverflow)
+
+	_, err = Mul[int8](-16, 9)
+	assert.ErrorIs(err, ErrUnderflow)
+
+	_, err = Mul[int8](math.MinInt8, -1)
+	assert.ErrorIs(err, ErrOverflow)
+
+	_, err = Mul[int8](-1, math.MinInt8)
+	assert.ErrorIs(err, ErrOverflow)
+
+	product, err = Mul[int8](math.MinInt8, 0)
+	assert.NoError(err)
+	assert.Zero(product)
+
+	_, err = Mul[uint8](16, 16)
+	assert.ErrorIs(err, ErrOverflow)
+}
+
+func TestCheckedExhaustive(t *testing.T) {
+	assert := assert.New(t)
+
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			for _, test := range []struct {
+				op       func(int8, int8) (int8, error)
+				expected int
+			}{
+				{op: Add[int8], expected: a + b},
+				{op: Sub[int8], expected: a - b},
+				{op: Mul[int8], expected: a * b},
+			} {
+				result, err := test.op(int8(a), int8(b))
+				switch {
+				case test.expected > math.MaxInt8:
+					assert.ErrorIs(err, ErrOverflow)
+				case test.expected < math.MinInt8:
+					assert.ErrorIs(err, ErrUnderflow)
+				default:
+					assert.NoError(err)
+					assert.Equal(int8(test.expected), result)
+				}
+			}
+		}
+	}
+}
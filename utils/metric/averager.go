@@ -59,3 +59,23 @@ func NewNoAverager() Averager {
 }
 
 func (noAverager) Observe(float64) {}
+
+// NewBucketedAveragerWithErrs returns an Averager that also reports the
+// distribution of its observations across [buckets]. The reported count and
+// sum are named the same as those of an averager created by
+// NewAveragerWithErrs. If [buckets] is empty, an averager created by
+// NewAveragerWithErrs is returned.
+func NewBucketedAveragerWithErrs(namespace, name, desc string, buckets []float64, reg prometheus.Registerer, errs *wrappers.Errs) Averager {
+	if len(buckets) == 0 {
+		return NewAveragerWithErrs(namespace, name, desc, reg, errs)
+	}
+
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      fmt.Sprintf("Distribution of %s", desc),
+		Buckets:   buckets,
+	})
+	errs.Add(reg.Register(histogram))
+	return histogram
+}
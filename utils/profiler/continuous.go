@@ -4,12 +4,32 @@
 package profiler
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"github.com/ava-labs/avalanchego/utils/filesystem"
+	"github.com/ava-labs/avalanchego/utils/resource"
+)
+
+const (
+	// thresholdCheckFreq is how often the thresholds are compared against the
+	// current resource usage.
+	thresholdCheckFreq = 10 * time.Second
+
+	// minTriggeredWindow is the minimum amount of time that a CPU profile is
+	// recorded for before exceeding a threshold can end it early. This ensures
+	// that triggered profiles contain useful data and limits how quickly
+	// historical profiles are rotated out during sustained load.
+	minTriggeredWindow = 30 * time.Second
 )
 
 // Config that is used to describe the options of the continuous profiler.
@@ -18,6 +38,43 @@ type Config struct {
 	Enabled     bool          `json:"enabled"`
 	Freq        time.Duration `json:"freq"`
 	MaxNumFiles int           `json:"maxNumFiles"`
+	// Number of CPU cores in use that causes the current profiles to be
+	// written immediately. If 0, CPU usage doesn't trigger profiles.
+	CPUThreshold float64 `json:"cpuThreshold"`
+	// Number of bytes of heap in use that causes the current profiles to be
+	// written immediately. If 0, memory usage doesn't trigger profiles.
+	MemoryThreshold uint64 `json:"memoryThreshold"`
+}
+
+// Thresholds that cause the continuous profiler to write its profiles before
+// the end of the current period.
+type Thresholds struct {
+	// Reports the current CPU usage. Must be non-nil if [CPU] is non-zero.
+	CPUUser resource.CPUUser
+	// Number of CPU cores. If 0, CPU usage is not checked.
+	CPU float64
+	// Number of bytes of heap in use. If 0, memory usage is not checked.
+	Memory uint64
+}
+
+func (t *Thresholds) enabled() bool {
+	return t.CPU > 0 || t.Memory > 0
+}
+
+// exceeded returns true if the current resource usage exceeds any of the
+// thresholds.
+func (t *Thresholds) exceeded() bool {
+	if t.CPU > 0 && t.CPUUser.CPUUsage() >= t.CPU {
+		return true
+	}
+	if t.Memory > 0 {
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+		if memStats.HeapInuse >= t.Memory {
+			return true
+		}
+	}
+	return false
 }
 
 // ContinuousProfiler periodically captures CPU, memory, and lock profiles
@@ -30,16 +87,29 @@ type continuousProfiler struct {
 	profiler    *profiler
 	freq        time.Duration
 	maxNumFiles int
+	thresholds  Thresholds
 
 	// Dispatch returns when closer is closed
 	closer chan struct{}
 }
 
 func NewContinuous(dir string, freq time.Duration, maxNumFiles int) ContinuousProfiler {
+	return NewContinuousWithThresholds(dir, freq, maxNumFiles, Thresholds{})
+}
+
+// NewContinuousWithThresholds returns a continuous profiler that additionally
+// writes its profiles as soon as the resource usage exceeds [thresholds].
+func NewContinuousWithThresholds(
+	dir string,
+	freq time.Duration,
+	maxNumFiles int,
+	thresholds Thresholds,
+) ContinuousProfiler {
 	return &continuousProfiler{
 		profiler:    new(dir),
 		freq:        freq,
 		maxNumFiles: maxNumFiles,
+		thresholds:  thresholds,
 		closer:      make(chan struct{}),
 	}
 }
@@ -48,18 +118,22 @@ func (p *continuousProfiler) Dispatch() error {
 	t := time.NewTicker(p.freq)
 	defer t.Stop()
 
+	// If no thresholds are enabled, [check] is nil and never fires.
+	var check <-chan time.Time
+	if p.thresholds.enabled() {
+		checkTicker := time.NewTicker(thresholdCheckFreq)
+		defer checkTicker.Stop()
+		check = checkTicker.C
+	}
+
 	for {
 		if err := p.start(); err != nil {
 			return err
 		}
 
-		select {
-		case <-p.closer:
-			return p.stop()
-		case <-t.C:
-			if err := p.stop(); err != nil {
-				return err
-			}
+		closed := p.wait(t, check)
+		if err := p.stop(); err != nil || closed {
+			return err
 		}
 
 		if err := p.rotate(); err != nil {
@@ -68,6 +142,27 @@ func (p *continuousProfiler) Dispatch() error {
 	}
 }
 
+// wait returns once the current profiles should be written. Returns true if
+// the profiler was shutdown.
+func (p *continuousProfiler) wait(t *time.Ticker, check <-chan time.Time) bool {
+	windowStart := time.Now()
+	for {
+		select {
+		case <-p.closer:
+			return true
+		case <-t.C:
+			return false
+		case <-check:
+			if time.Since(windowStart) < minTriggeredWindow || !p.thresholds.exceeded() {
+				continue
+			}
+			// Start a new full period after the triggered profiles.
+			t.Reset(p.freq)
+			return false
+		}
+	}
+}
+
 func (p *continuousProfiler) start() error {
 	return p.profiler.StartCPUProfiler()
 }
@@ -106,3 +201,60 @@ func rotate(name string, maxNumFiles int) error {
 	_, err := filesystem.RenameIfExists(name, destFilename)
 	return err
 }
+
+// Snapshot describes a profile that was written to disk
+type Snapshot struct {
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// ListSnapshots returns the profiles that have been written to [dir], ordered
+// from most recently written to least recently written.
+func ListSnapshots(dir string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]Snapshot, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !isProfileFile(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, Snapshot{
+			Name:    entry.Name(),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].ModTime.After(snapshots[j].ModTime)
+	})
+	return snapshots, nil
+}
+
+// isProfileFile returns true if [name] is the name of a profile or a rotated
+// profile.
+func isProfileFile(name string) bool {
+	for _, profileFile := range []string{cpuProfileFile, memProfileFile, lockProfileFile} {
+		if name == profileFile {
+			return true
+		}
+		suffix := strings.TrimPrefix(name, profileFile+".")
+		if suffix == name {
+			continue
+		}
+		if _, err := strconv.ParseUint(suffix, 10, 64); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package profiler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCPUUser float64
+
+func (u testCPUUser) CPUUsage() float64 { return float64(u) }
+
+func TestThresholds(t *testing.T) {
+	assert := assert.New(t)
+
+	thresholds := Thresholds{}
+	assert.False(thresholds.enabled())
+	assert.False(thresholds.exceeded())
+
+	thresholds = Thresholds{
+		CPUUser: testCPUUser(1),
+		CPU:     2,
+	}
+	assert.True(thresholds.enabled())
+	assert.False(thresholds.exceeded())
+
+	thresholds.CPUUser = testCPUUser(2.5)
+	assert.True(thresholds.exceeded())
+
+	// Any process has at least one byte of heap in use.
+	thresholds = Thresholds{
+		Memory: 1,
+	}
+	assert.True(thresholds.enabled())
+	assert.True(thresholds.exceeded())
+}
+
+func TestContinuousProfilerShutdown(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	p := NewContinuous(dir, time.Hour, 2)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- p.Dispatch()
+	}()
+	p.Shutdown()
+	assert.NoError(<-errs)
+
+	snapshots, err := ListSnapshots(dir)
+	assert.NoError(err)
+
+	names := make([]string, len(snapshots))
+	for i, snapshot := range snapshots {
+		names[i] = snapshot.Name
+	}
+	assert.ElementsMatch([]string{cpuProfileFile, memProfileFile, lockProfileFile}, names)
+}
+
+func TestListSnapshots(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	now := time.Now()
+	files := []struct {
+		name    string
+		age     time.Duration
+		profile bool
+	}{
+		{name: cpuProfileFile, age: 0, profile: true},
+		{name: cpuProfileFile + ".1", age: time.Minute, profile: true},
+		{name: memProfileFile + ".2", age: 2 * time.Minute, profile: true},
+		{name: lockProfileFile + ".10", age: 3 * time.Minute, profile: true},
+		{name: cpuProfileFile + ".tmp", profile: false},
+		{name: "stacktrace.txt", profile: false},
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		assert.NoError(os.WriteFile(path, []byte(file.name), 0o600))
+		modTime := now.Add(-file.age)
+		assert.NoError(os.Chtimes(path, modTime, modTime))
+	}
+
+	snapshots, err := ListSnapshots(dir)
+	assert.NoError(err)
+
+	expected := []string{}
+	for _, file := range files {
+		if file.profile {
+			expected = append(expected, file.name)
+		}
+	}
+	names := make([]string, len(snapshots))
+	for i, snapshot := range snapshots {
+		names[i] = snapshot.Name
+		assert.EqualValues(len(snapshot.Name), snapshot.Size)
+	}
+	assert.Equal(expected, names)
+}
+
+func TestListSnapshotsMissingDir(t *testing.T) {
+	assert := assert.New(t)
+
+	snapshots, err := ListSnapshots(filepath.Join(t.TempDir(), "missing"))
+	assert.NoError(err)
+	assert.Empty(snapshots)
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package resource
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/shirou/gopsutil/disk"
+)
+
+var _ prometheus.Collector = &diskCollector{}
+
+// diskCollector reports the IO counters of the device that backs a path
+type diskCollector struct {
+	path string
+
+	readBytes    *prometheus.Desc
+	writtenBytes *prometheus.Desc
+	reads        *prometheus.Desc
+	writes       *prometheus.Desc
+	ioTime       *prometheus.Desc
+}
+
+// NewDiskCollector returns a collector that reports the IO counters of the
+// device [path] is stored on. The counters include the IO of every process
+// using the device. If the device can't be determined, for example because the
+// operating system doesn't expose its counters, nothing is reported.
+func NewDiskCollector(namespace, path string) prometheus.Collector {
+	return &diskCollector{
+		path: path,
+		readBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "read_bytes_total"),
+			"Number of bytes read from the device",
+			nil,
+			nil,
+		),
+		writtenBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "written_bytes_total"),
+			"Number of bytes written to the device",
+			nil,
+			nil,
+		),
+		reads: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "reads_total"),
+			"Number of reads completed by the device",
+			nil,
+			nil,
+		),
+		writes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "writes_total"),
+			"Number of writes completed by the device",
+			nil,
+			nil,
+		),
+		ioTime: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "io_time_seconds_total"),
+			"Time (seconds) the device has spent doing IO",
+			nil,
+			nil,
+		),
+	}
+}
+
+func (c *diskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.readBytes
+	ch <- c.writtenBytes
+	ch <- c.reads
+	ch <- c.writes
+	ch <- c.ioTime
+}
+
+func (c *diskCollector) Collect(ch chan<- prometheus.Metric) {
+	device, ok := c.device()
+	if !ok {
+		return
+	}
+	counters, err := disk.IOCounters(device)
+	if err != nil {
+		return
+	}
+	stat, ok := counters[device]
+	if !ok {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.readBytes, prometheus.CounterValue, float64(stat.ReadBytes))
+	ch <- prometheus.MustNewConstMetric(c.writtenBytes, prometheus.CounterValue, float64(stat.WriteBytes))
+	ch <- prometheus.MustNewConstMetric(c.reads, prometheus.CounterValue, float64(stat.ReadCount))
+	ch <- prometheus.MustNewConstMetric(c.writes, prometheus.CounterValue, float64(stat.WriteCount))
+	ch <- prometheus.MustNewConstMetric(c.ioTime, prometheus.CounterValue, float64(stat.IoTime)/1000)
+}
+
+// device returns the name of the device [c.path] is stored on, as it's known
+// to disk.IOCounters. The device is looked up on every collection because the
+// path may not exist yet when the collector is created.
+func (c *diskCollector) device() (string, bool) {
+	path, err := filepath.Abs(c.path)
+	if err != nil {
+		return "", false
+	}
+	path, err = filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", false
+	}
+	partitions, err := disk.Partitions(true)
+	if err != nil {
+		return "", false
+	}
+	partition, ok := findPartition(path, partitions)
+	if !ok {
+		return "", false
+	}
+
+	// Devices are often referred to by symlinks, such as those in
+	// /dev/mapper, but are counted under the name of the underlying device.
+	device, err := filepath.EvalSymlinks(partition.Device)
+	if err != nil {
+		device = partition.Device
+	}
+	return filepath.Base(device), true
+}
+
+// findPartition returns the partition with the longest mountpoint that
+// contains [path]
+func findPartition(path string, partitions []disk.PartitionStat) (disk.PartitionStat, bool) {
+	var (
+		best  disk.PartitionStat
+		found bool
+	)
+	for _, partition := range partitions {
+		if !containsPath(partition.Mountpoint, path) {
+			continue
+		}
+		if !found || len(partition.Mountpoint) > len(best.Mountpoint) {
+			best = partition
+			found = true
+		}
+	}
+	return best, found
+}
+
+// containsPath returns true if [path] is [dir] or is in [dir]
+func containsPath(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/shirou/gopsutil/disk"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPartition(t *testing.T) {
+	partitions := []disk.PartitionStat{
+		{Device: "/dev/sda1", Mountpoint: "/"},
+		{Device: "/dev/sdb1", Mountpoint: "/data"},
+		{Device: "/dev/sdc1", Mountpoint: "/data/db"},
+	}
+
+	tests := []struct {
+		path   string
+		device string
+	}{
+		{path: "/", device: "/dev/sda1"},
+		{path: "/home/user/.avalanchego/db", device: "/dev/sda1"},
+		{path: "/data", device: "/dev/sdb1"},
+		{path: "/data/dbs", device: "/dev/sdb1"},
+		{path: "/data/db", device: "/dev/sdc1"},
+		{path: "/data/db/mainnet", device: "/dev/sdc1"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			partition, ok := findPartition(test.path, partitions)
+			assert.True(t, ok)
+			assert.Equal(t, test.device, partition.Device)
+		})
+	}
+
+	_, ok := findPartition("/data", partitions[2:])
+	assert.False(t, ok)
+}
+
+func TestDiskCollector(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := prometheus.NewRegistry()
+	assert.NoError(registry.Register(NewDiskCollector("db_volume", t.TempDir())))
+
+	// The counters may not be available in this environment, but collecting
+	// them must not fail.
+	metrics, err := registry.Gather()
+	assert.NoError(err)
+	for _, metric := range metrics {
+		assert.Contains(metric.GetName(), "db_volume_")
+	}
+}
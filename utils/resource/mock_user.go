@@ -75,3 +75,31 @@ func (mr *MockUserMockRecorder) AvailableDiskBytes() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailableDiskBytes", reflect.TypeOf((*MockUser)(nil).AvailableDiskBytes))
 }
+
+// MemoryUsage mocks base method
+func (m *MockUser) MemoryUsage() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MemoryUsage")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// MemoryUsage indicates an expected call of MemoryUsage
+func (mr *MockUserMockRecorder) MemoryUsage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MemoryUsage", reflect.TypeOf((*MockUser)(nil).MemoryUsage))
+}
+
+// ProcessMemoryUsage mocks base method
+func (m *MockUser) ProcessMemoryUsage(arg0 int) uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessMemoryUsage", arg0)
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// ProcessMemoryUsage indicates an expected call of ProcessMemoryUsage
+func (mr *MockUserMockRecorder) ProcessMemoryUsage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessMemoryUsage", reflect.TypeOf((*MockUser)(nil).ProcessMemoryUsage), arg0)
+}
@@ -15,3 +15,7 @@ func (noUsage) CPUUsage() float64 { return 0 }
 func (noUsage) DiskUsage() (float64, float64) { return 0, 0 }
 
 func (noUsage) AvailableDiskBytes() uint64 { return math.MaxUint64 }
+
+func (noUsage) MemoryUsage() uint64 { return 0 }
+
+func (noUsage) ProcessMemoryUsage(int) uint64 { return 0 }
@@ -38,9 +38,20 @@ type DiskUser interface {
 	AvailableDiskBytes() uint64
 }
 
+type MemoryUser interface {
+	// MemoryUsage returns the number of bytes of resident memory this user
+	// has attributed to it.
+	MemoryUsage() uint64
+
+	// ProcessMemoryUsage returns the number of bytes of resident memory
+	// recently used by the process [pid]. Returns 0 if [pid] isn't tracked.
+	ProcessMemoryUsage(pid int) uint64
+}
+
 type User interface {
 	CPUUser
 	DiskUser
+	MemoryUser
 }
 
 type ProcessTracker interface {
@@ -71,6 +82,8 @@ type manager struct {
 	readUsage float64
 	// [writeUsage] is the number of bytes/second written to disk recently.
 	writeUsage float64
+	// [memoryUsage] is the number of bytes of resident memory currently used.
+	memoryUsage uint64
 
 	availableDiskBytes uint64
 
@@ -102,6 +115,24 @@ func (m *manager) DiskUsage() (float64, float64) {
 	return m.readUsage, m.writeUsage
 }
 
+func (m *manager) MemoryUsage() uint64 {
+	m.usageLock.RLock()
+	defer m.usageLock.RUnlock()
+
+	return m.memoryUsage
+}
+
+func (m *manager) ProcessMemoryUsage(pid int) uint64 {
+	m.processesLock.Lock()
+	defer m.processesLock.Unlock()
+
+	p, ok := m.processes[pid]
+	if !ok {
+		return 0
+	}
+	return p.memoryUsage
+}
+
 func (m *manager) AvailableDiskBytes() uint64 {
 	m.usageLock.RLock()
 	defer m.usageLock.RUnlock()
@@ -143,7 +174,7 @@ func (m *manager) update(diskPath string, frequency, cpuHalflife, diskHalflife t
 
 	frequencyInSeconds := frequency.Seconds()
 	for {
-		currentCPUUsage, currentReadUsage, currentWriteUsage := m.getActiveUsage(frequencyInSeconds)
+		currentCPUUsage, currentReadUsage, currentWriteUsage, currentMemoryUsage := m.getActiveUsage(frequencyInSeconds)
 		currentScaledCPUUsage := newCPUWeight * currentCPUUsage
 		currentScaledReadUsage := newDiskWeight * currentReadUsage
 		currentScaledWriteUsage := newDiskWeight * currentWriteUsage
@@ -154,6 +185,7 @@ func (m *manager) update(diskPath string, frequency, cpuHalflife, diskHalflife t
 		m.cpuUsage = oldCPUWeight*m.cpuUsage + currentScaledCPUUsage
 		m.readUsage = oldDiskWeight*m.readUsage + currentScaledReadUsage
 		m.writeUsage = oldDiskWeight*m.writeUsage + currentScaledWriteUsage
+		m.memoryUsage = currentMemoryUsage
 
 		if getBytesErr == nil {
 			m.availableDiskBytes = availableBytes
@@ -173,23 +205,27 @@ func (m *manager) update(diskPath string, frequency, cpuHalflife, diskHalflife t
 // 1. Current CPU usage by all processes.
 // 2. Current bytes/sec read from disk by all processes.
 // 3. Current bytes/sec written to disk by all processes.
-func (m *manager) getActiveUsage(secondsSinceLastUpdate float64) (float64, float64, float64) {
+// 4. Current bytes of resident memory used by all processes.
+func (m *manager) getActiveUsage(secondsSinceLastUpdate float64) (float64, float64, float64, uint64) {
 	m.processesLock.Lock()
 	defer m.processesLock.Unlock()
 
 	var (
-		totalCPU   float64
-		totalRead  float64
-		totalWrite float64
+		totalCPU    float64
+		totalRead   float64
+		totalWrite  float64
+		totalMemory uint64
 	)
 	for _, p := range m.processes {
 		cpu, read, write := p.getActiveUsage(secondsSinceLastUpdate)
 		totalCPU += cpu
 		totalRead += read
 		totalWrite += write
+		p.memoryUsage = p.getMemoryUsage()
+		totalMemory += p.memoryUsage
 	}
 
-	return totalCPU, totalRead, totalWrite
+	return totalCPU, totalRead, totalWrite, totalMemory
 }
 
 type proc struct {
@@ -205,6 +241,9 @@ type proc struct {
 	// [lastWriteBytes] is the most recent measurement of total disk bytes
 	// written.
 	lastWriteBytes uint64
+
+	// [memoryUsage] is the most recent measurement of resident memory bytes.
+	memoryUsage uint64
 }
 
 func (p *proc) getActiveUsage(secondsSinceLastUpdate float64) (float64, float64, float64) {
@@ -249,6 +288,16 @@ func (p *proc) getActiveUsage(secondsSinceLastUpdate float64) (float64, float64,
 	return cpu, read, write
 }
 
+func (p *proc) getMemoryUsage() uint64 {
+	// If there is an error tracking the memory usage of a process, assume that
+	// the usage is 0.
+	mem, err := p.p.MemoryInfo()
+	if err != nil {
+		return 0
+	}
+	return mem.RSS
+}
+
 // getSampleWeights converts the frequency of CPU sampling and the halflife of
 // the CPU sample's usefulness into weights to scale the newly sampled point and
 // previously samples.
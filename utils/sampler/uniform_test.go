@@ -114,7 +114,7 @@ func UniformDistributionTest(t *testing.T, s Uniform) {
 	val, err := s.Sample(3)
 	assert.NoError(t, err)
 
-	utils.SortUint64(val)
+	utils.SortOrdered(val)
 	assert.Equal(
 		t,
 		[]uint64{0, 1, 2},
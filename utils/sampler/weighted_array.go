@@ -58,7 +58,7 @@ func (s *weightedArray) Initialize(weights []uint64) error {
 
 	cumulativeWeight := uint64(0)
 	for i := 0; i < len(s.arr); i++ {
-		newWeight, err := safemath.Add64(
+		newWeight, err := safemath.Add(
 			cumulativeWeight,
 			s.arr[i].cumulativeWeight,
 		)
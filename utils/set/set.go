@@ -0,0 +1,236 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package set
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+const (
+	// The minimum capacity of a set
+	minSetSize = 16
+
+	// If a set has more than this many keys, it will be cleared by setting the map to nil
+	// rather than iteratively deleting
+	clearSizeThreshold = 512
+)
+
+// Set is a set of elements
+type Set[T comparable] map[T]struct{}
+
+type sortable[T any] interface {
+	comparable
+	utils.Sortable[T]
+}
+
+// Return a new set with initial capacity [size].
+// More or less than [size] elements can be added to this set.
+// Using NewSet() rather than Set[T]{} is just an optimization that can
+// be used if you know how many elements will be put in this set.
+func NewSet[T comparable](size int) Set[T] {
+	if size < 0 {
+		return Set[T]{}
+	}
+	return make(map[T]struct{}, size)
+}
+
+// Of returns a set containing [elts]
+func Of[T comparable](elts ...T) Set[T] {
+	s := NewSet[T](len(elts))
+	s.Add(elts...)
+	return s
+}
+
+func (s *Set[T]) init(size int) {
+	if *s == nil {
+		if minSetSize > size {
+			size = minSetSize
+		}
+		*s = make(map[T]struct{}, size)
+	}
+}
+
+// Add all the elements to this set, if the element is already in the set,
+// nothing happens
+func (s *Set[T]) Add(elts ...T) {
+	s.init(2 * len(elts))
+	for _, elt := range elts {
+		(*s)[elt] = struct{}{}
+	}
+}
+
+// Union adds all the elements from the provided set to this set.
+func (s *Set[T]) Union(set Set[T]) {
+	s.init(2 * set.Len())
+	for elt := range set {
+		(*s)[elt] = struct{}{}
+	}
+}
+
+// Difference removes all the elements in the provided set from this set.
+func (s *Set[T]) Difference(set Set[T]) {
+	for elt := range set {
+		delete(*s, elt)
+	}
+}
+
+// Contains returns true if the set contains this element, false otherwise
+func (s *Set[T]) Contains(elt T) bool {
+	_, contains := (*s)[elt]
+	return contains
+}
+
+// Overlaps returns true if the intersection of the set is non-empty
+func (s *Set[T]) Overlaps(big Set[T]) bool {
+	small := *s
+	if small.Len() > big.Len() {
+		small = big
+		big = *s
+	}
+
+	for elt := range small {
+		if _, ok := big[elt]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of elements in this set
+func (s Set[_]) Len() int { return len(s) }
+
+// Remove all the elements from this set, if an element isn't in the set,
+// nothing happens
+func (s *Set[T]) Remove(elts ...T) {
+	for _, elt := range elts {
+		delete(*s, elt)
+	}
+}
+
+// Clear empties this set
+func (s *Set[_]) Clear() {
+	if len(*s) > clearSizeThreshold {
+		*s = nil
+		return
+	}
+	for elt := range *s {
+		delete(*s, elt)
+	}
+}
+
+// List converts this set into a list
+func (s Set[T]) List() []T {
+	elts := make([]T, s.Len())
+	i := 0
+	for elt := range s {
+		elts[i] = elt
+		i++
+	}
+	return elts
+}
+
+// CappedList returns a list of length at most [size].
+// Size should be >= 0. If size < 0, returns nil.
+func (s Set[T]) CappedList(size int) []T {
+	if size < 0 {
+		return nil
+	}
+	if l := s.Len(); l < size {
+		size = l
+	}
+	i := 0
+	elts := make([]T, size)
+	for elt := range s {
+		if i >= size {
+			break
+		}
+		elts[i] = elt
+		i++
+	}
+	return elts
+}
+
+// Equals returns true if the sets contain the same elements
+func (s Set[T]) Equals(other Set[T]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	for elt := range other {
+		if _, contains := s[elt]; !contains {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the string representation of a set
+func (s Set[_]) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("{")
+	first := true
+	for elt := range s {
+		if !first {
+			sb.WriteString(", ")
+		}
+		first = false
+		sb.WriteString(fmt.Sprint(elt))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// Returns an element. If the set is empty, returns false
+func (s *Set[T]) Peek() (T, bool) {
+	for elt := range *s {
+		return elt, true
+	}
+	return *new(T), false
+}
+
+// Removes and returns an element. If the set is empty, does nothing and returns
+// false.
+func (s *Set[T]) Pop() (T, bool) {
+	for elt := range *s {
+		delete(*s, elt)
+		return elt, true
+	}
+	return *new(T), false
+}
+
+// MarshalJSON marshals the set as a list. The list is sorted by the JSON
+// representation of the elements so that the output is deterministic.
+func (s *Set[_]) MarshalJSON() ([]byte, error) {
+	eltBytes := make([][]byte, 0, s.Len())
+	for elt := range *s {
+		b, err := json.Marshal(elt)
+		if err != nil {
+			return nil, err
+		}
+		eltBytes = append(eltBytes, b)
+	}
+	utils.Sort2DBytes(eltBytes)
+
+	jsonBuf := bytes.Buffer{}
+	jsonBuf.WriteByte('[')
+	for i, b := range eltBytes {
+		if i != 0 {
+			jsonBuf.WriteByte(',')
+		}
+		jsonBuf.Write(b)
+	}
+	jsonBuf.WriteByte(']')
+	return jsonBuf.Bytes(), nil
+}
+
+// SortedList returns the elements of [s] sorted in increasing order
+func SortedList[T sortable[T]](s Set[T]) []T {
+	elts := s.List()
+	utils.Sort(elts)
+	return elts
+}
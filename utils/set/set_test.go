@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSortable uint64
+
+func (t testSortable) Less(other testSortable) bool { return t < other }
+
+func TestSet(t *testing.T) {
+	assert := assert.New(t)
+
+	s := Set[int]{}
+	assert.False(s.Contains(1))
+
+	s.Add(1, 2, 2)
+	assert.Equal(2, s.Len())
+	assert.True(s.Contains(1))
+	assert.True(s.Contains(2))
+
+	s.Remove(1)
+	assert.False(s.Contains(1))
+	assert.True(s.Equals(Of(2)))
+
+	s.Union(Of(3, 4))
+	assert.True(s.Equals(Of(2, 3, 4)))
+	assert.True(s.Overlaps(Of(4, 5)))
+	assert.False(s.Overlaps(Of(5)))
+
+	s.Difference(Of(2, 5))
+	assert.True(s.Equals(Of(3, 4)))
+	assert.Len(s.List(), 2)
+	assert.Len(s.CappedList(1), 1)
+	assert.Nil(s.CappedList(-1))
+
+	elt, ok := s.Peek()
+	assert.True(ok)
+	assert.True(s.Contains(elt))
+
+	elt, ok = s.Pop()
+	assert.True(ok)
+	assert.False(s.Contains(elt))
+	assert.Equal(1, s.Len())
+
+	s.Clear()
+	assert.Equal(0, s.Len())
+	_, ok = s.Pop()
+	assert.False(ok)
+}
+
+// Test that Clear() works with both the iterative and set-to-nil path
+func TestSetClearLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, size := range []int{clearSizeThreshold, clearSizeThreshold + 1} {
+		s := NewSet[int](size)
+		for i := 0; i < size; i++ {
+			s.Add(i)
+		}
+		s.Clear()
+		assert.Equal(0, s.Len())
+		s.Add(0)
+		assert.Equal(1, s.Len())
+	}
+}
+
+func TestSetMarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	s := Set[string]{}
+	asJSON, err := s.MarshalJSON()
+	assert.NoError(err)
+	assert.Equal("[]", string(asJSON))
+
+	s.Add("b", "a", "c")
+	asJSON, err = s.MarshalJSON()
+	assert.NoError(err)
+	assert.Equal(`["a","b","c"]`, string(asJSON))
+}
+
+func TestSortedList(t *testing.T) {
+	assert := assert.New(t)
+
+	s := Of[testSortable](3, 1, 2)
+	assert.Equal([]testSortable{1, 2, 3}, SortedList(s))
+	assert.Empty(SortedList(Set[testSortable]{}))
+}
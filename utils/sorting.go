@@ -8,59 +8,88 @@ import (
 	"sort"
 )
 
-// IsSortedAndUnique returns true if the elements in the data are unique and sorted.
-func IsSortedAndUnique(data sort.Interface) bool {
-	for i := data.Len() - 2; i >= 0; i-- {
-		if !data.Less(i, i+1) {
-			return false
-		}
-	}
-	return true
+// Sortable is implemented by types that have a total order
+type Sortable[T any] interface {
+	Less(T) bool
 }
 
-type innerSortUint32 []uint32
+// Ordered is satisfied by the types that support the < operator
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
 
-func (su32 innerSortUint32) Less(i, j int) bool { return su32[i] < su32[j] }
-func (su32 innerSortUint32) Len() int           { return len(su32) }
-func (su32 innerSortUint32) Swap(i, j int)      { su32[j], su32[i] = su32[i], su32[j] }
+// SortableSlice implements sort.Interface for a slice of Sortable elements
+type SortableSlice[T Sortable[T]] []T
 
-// SortUint32 sorts an uint32 array
-func SortUint32(u32 []uint32) { sort.Sort(innerSortUint32(u32)) }
+func (s SortableSlice[T]) Len() int           { return len(s) }
+func (s SortableSlice[T]) Less(i, j int) bool { return s[i].Less(s[j]) }
+func (s SortableSlice[T]) Swap(i, j int)      { s[j], s[i] = s[i], s[j] }
 
-// IsSortedAndUniqueUint32 returns true if the array of uint32s are sorted and unique
-func IsSortedAndUniqueUint32(arr []uint32) bool {
+// Sort sorts [s] in increasing order
+func Sort[T Sortable[T]](s []T) { sort.Sort(SortableSlice[T](s)) }
+
+// SortOrdered sorts [s] in increasing order
+func SortOrdered[T Ordered](s []T) {
+	sort.Slice(s, func(i, j int) bool {
+		return s[i] < s[j]
+	})
+}
+
+// Sort2DBytes sorts a 2D byte array
+// Each byte array is not sorted internally; the byte arrays are sorted relative to another.
+func Sort2DBytes(arr [][]byte) {
+	sort.Slice(arr, func(i, j int) bool {
+		return bytes.Compare(arr[i], arr[j]) < 0
+	})
+}
+
+// IsSorted2DBytes returns true iff [arr] is sorted
+func IsSorted2DBytes(arr [][]byte) bool {
 	for i := 0; i < len(arr)-1; i++ {
-		if arr[i] >= arr[i+1] {
+		if bytes.Compare(arr[i], arr[i+1]) > 0 {
 			return false
 		}
 	}
 	return true
 }
 
-type innerSortUint64 []uint64
-
-func (su64 innerSortUint64) Less(i, j int) bool { return su64[i] < su64[j] }
-func (su64 innerSortUint64) Len() int           { return len(su64) }
-func (su64 innerSortUint64) Swap(i, j int)      { su64[j], su64[i] = su64[i], su64[j] }
-
-// SortUint64 sorts an uint64 array
-func SortUint64(u64 []uint64) { sort.Sort(innerSortUint64(u64)) }
-
-// IsSortedAndUniqueUint64 returns true if the array of uint64s are sorted and unique
-func IsSortedAndUniqueUint64(u64 []uint64) bool { return IsSortedAndUnique(innerSortUint64(u64)) }
-
-type innerSortBytes [][]byte
-
-func (arr innerSortBytes) Less(i, j int) bool {
-	return bytes.Compare(arr[i], arr[j]) == -1
+// IsSortedAndUnique returns true if the elements in the data are unique and
+// sorted.
+func IsSortedAndUnique(data sort.Interface) bool {
+	for i := data.Len() - 2; i >= 0; i-- {
+		if !data.Less(i, i+1) {
+			return false
+		}
+	}
+	return true
 }
 
-func (arr innerSortBytes) Len() int      { return len(arr) }
-func (arr innerSortBytes) Swap(i, j int) { arr[j], arr[i] = arr[i], arr[j] }
+// IsSortedAndUniqueSortable returns true if the elements of [s] are unique and
+// sorted
+func IsSortedAndUniqueSortable[T Sortable[T]](s []T) bool {
+	return IsSortedAndUniqueFunc(s, func(a, b T) bool {
+		return a.Less(b)
+	})
+}
 
-// Sort2DBytes sorts a 2D byte array
-// Each byte array is not sorted internally; the byte arrays are sorted relative to another.
-func Sort2DBytes(arr [][]byte) { sort.Sort(innerSortBytes(arr)) }
+// IsSortedAndUniqueOrdered returns true if the elements of [s] are unique and
+// sorted
+func IsSortedAndUniqueOrdered[T Ordered](s []T) bool {
+	return IsSortedAndUniqueFunc(s, func(a, b T) bool {
+		return a < b
+	})
+}
 
-// IsSorted2DBytes returns true iff [arr] is sorted
-func IsSorted2DBytes(arr [][]byte) bool { return sort.IsSorted(innerSortBytes(arr)) }
+// IsSortedAndUniqueFunc returns true if the elements of [s] are unique and
+// sorted by [less]
+func IsSortedAndUniqueFunc[T any](s []T, less func(a, b T) bool) bool {
+	for i := 0; i < len(s)-1; i++ {
+		if !less(s[i], s[i+1]) {
+			return false
+		}
+	}
+	return true
+}
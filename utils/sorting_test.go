@@ -6,6 +6,8 @@ package utils
 import (
 	"math/rand"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSort2dByteArray(t *testing.T) {
@@ -34,7 +36,7 @@ func TestSort2dByteArray(t *testing.T) {
 	}
 }
 
-func TestSortUint32Array(t *testing.T) {
+func TestIsSortedAndUniqueOrdered(t *testing.T) {
 	tests := []struct {
 		name     string
 		arr      []uint32
@@ -109,12 +111,48 @@ func TestSortUint32Array(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			if test.isSorted {
-				if !IsSortedAndUniqueUint32(test.arr) {
+				if !IsSortedAndUniqueOrdered(test.arr) {
 					t.Fatal("should have been marked as sorted and unique")
 				}
-			} else if IsSortedAndUniqueUint32(test.arr) {
+			} else if IsSortedAndUniqueOrdered(test.arr) {
 				t.Fatal("shouldn't have been marked as sorted and unique")
 			}
 		})
 	}
 }
+
+type sortable int
+
+func (s sortable) Less(other sortable) bool { return s < other }
+
+func TestSortSortable(t *testing.T) {
+	assert := assert.New(t)
+
+	s := []sortable{3, 1, 2}
+	assert.False(IsSortedAndUniqueSortable(s))
+
+	Sort(s)
+	assert.Equal([]sortable{1, 2, 3}, s)
+	assert.True(IsSortedAndUniqueSortable(s))
+
+	s = append(s, 3)
+	assert.False(IsSortedAndUniqueSortable(s))
+}
+
+func TestSortOrdered(t *testing.T) {
+	assert := assert.New(t)
+
+	s := []uint64{5, 0, 3}
+	SortOrdered(s)
+	assert.Equal([]uint64{0, 3, 5}, s)
+	assert.True(IsSortedAndUniqueOrdered(s))
+}
+
+func TestIsSortedAndUniqueFunc(t *testing.T) {
+	assert := assert.New(t)
+
+	greater := func(a, b int) bool { return a > b }
+	assert.True(IsSortedAndUniqueFunc([]int{3, 2, 1}, greater))
+	assert.False(IsSortedAndUniqueFunc([]int{1, 2, 3}, greater))
+	assert.True(IsSortedAndUniqueFunc([]int(nil), greater))
+}
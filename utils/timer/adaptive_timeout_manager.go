@@ -7,6 +7,7 @@ import (
 	"container/heap"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -19,8 +20,23 @@ import (
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+const (
+	// quantileRelativeAccuracy is the relative accuracy of the tracked latency
+	// percentiles.
+	quantileRelativeAccuracy = .01
+
+	opLabel       = "op"
+	quantileLabel = "quantile"
+	allOpsLabel   = "all"
+)
+
 var (
 	errNonPositiveHalflife = errors.New("timeout halflife must be positive")
+	errInvalidPercentile   = errors.New("timeout percentile must be in [0, 1]")
+
+	// exportedQuantiles are the latency quantiles that are reported as
+	// metrics.
+	exportedQuantiles = []float64{.5, .95, .99}
 
 	_ heap.Interface         = &timeoutQueue{}
 	_ AdaptiveTimeoutManager = &adaptiveTimeoutManager{}
@@ -67,9 +83,13 @@ type AdaptiveTimeoutConfig struct {
 	InitialTimeout time.Duration `json:"initialTimeout"`
 	MinimumTimeout time.Duration `json:"minimumTimeout"`
 	MaximumTimeout time.Duration `json:"maximumTimeout"`
-	// Timeout is [timeoutCoefficient] * average response time
+	// Timeout is [timeoutCoefficient] * the [timeoutPercentile] response time
 	// [timeoutCoefficient] must be > 1
 	TimeoutCoefficient float64 `json:"timeoutCoefficient"`
+	// Percentile of the response time that the timeout is based on.
+	// [timeoutPercentile] must be in [0, 1]
+	// If 0, the timeout is based on the average response time.
+	TimeoutPercentile float64 `json:"timeoutPercentile"`
 	// Larger halflife --> less volatile timeout
 	// [timeoutHalfLife] must be positive
 	TimeoutHalflife time.Duration `json:"timeoutHalflife"`
@@ -103,11 +123,19 @@ type adaptiveTimeoutManager struct {
 	clock                            mockable.Clock
 	networkTimeoutMetric, avgLatency prometheus.Gauge
 	numTimeouts                      prometheus.Counter
+	latencyQuantiles                 *prometheus.GaugeVec
 	// Averages the response time from all peers
 	averager math.Averager
-	// Timeout is [timeoutCoefficient] * average response time
+	// Tracks the distribution of the response time from all peers
+	quantiles math.Quantiles
+	// Tracks the distribution of the response time of each message type
+	opQuantiles map[message.Op]math.Quantiles
+	halflife    time.Duration
+	// Timeout is [timeoutCoefficient] * the [timeoutPercentile] response time
 	// [timeoutCoefficient] must be > 1
 	timeoutCoefficient float64
+	// If 0, the average response time is used instead of a percentile.
+	timeoutPercentile float64
 	minimumTimeout     time.Duration
 	maximumTimeout     time.Duration
 	currentTimeout     time.Duration // Amount of time before a timeout
@@ -130,6 +158,8 @@ func NewAdaptiveTimeoutManager(
 		return nil, fmt.Errorf("timeout coefficient must be >= 1 but got %f", config.TimeoutCoefficient)
 	case config.TimeoutHalflife <= 0:
 		return nil, errNonPositiveHalflife
+	case config.TimeoutPercentile < 0 || config.TimeoutPercentile > 1:
+		return nil, errInvalidPercentile
 	}
 
 	tm := &adaptiveTimeoutManager{
@@ -148,19 +178,42 @@ func NewAdaptiveTimeoutManager(
 			Name:      "timeouts",
 			Help:      "Number of timed out requests",
 		}),
+		latencyQuantiles: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "latency_quantile",
+				Help:      "Network latency quantiles in nanoseconds",
+			},
+			[]string{opLabel, quantileLabel},
+		),
+		opQuantiles:        make(map[message.Op]math.Quantiles),
+		halflife:           config.TimeoutHalflife,
 		minimumTimeout:     config.MinimumTimeout,
 		maximumTimeout:     config.MaximumTimeout,
 		currentTimeout:     config.InitialTimeout,
 		timeoutCoefficient: config.TimeoutCoefficient,
+		timeoutPercentile:  config.TimeoutPercentile,
 		timeoutMap:         make(map[ids.ID]*adaptiveTimeout),
 	}
 	tm.timer = NewTimer(tm.timeout)
-	tm.averager = math.NewAverager(float64(config.InitialTimeout), config.TimeoutHalflife, tm.clock.Time())
+
+	now := tm.clock.Time()
+	tm.averager = math.NewAverager(float64(config.InitialTimeout), config.TimeoutHalflife, now)
+
+	var err error
+	tm.quantiles, err = math.NewQuantiles(quantileRelativeAccuracy, config.TimeoutHalflife, now)
+	if err != nil {
+		return nil, err
+	}
+	// Seed the distribution with the initial timeout so that the timeout
+	// doesn't jump to the first observed latency.
+	tm.quantiles.Observe(float64(config.InitialTimeout), now)
 
 	errs := &wrappers.Errs{}
 	errs.Add(metricsRegister.Register(tm.networkTimeoutMetric))
 	errs.Add(metricsRegister.Register(tm.avgLatency))
 	errs.Add(metricsRegister.Register(tm.numTimeouts))
+	errs.Add(metricsRegister.Register(tm.latencyQuantiles))
 	return tm, errs.Err
 }
 
@@ -221,6 +274,7 @@ func (tm *adaptiveTimeoutManager) remove(id ids.ID, now time.Time) {
 	if timeout.op != message.Get {
 		timeoutRegisteredAt := timeout.deadline.Add(-1 * timeout.duration)
 		latency := now.Sub(timeoutRegisteredAt)
+		tm.observeOpLatency(timeout.op, latency, now)
 		tm.observeLatencyAndUpdateTimeout(latency, now)
 	}
 
@@ -263,8 +317,15 @@ func (tm *adaptiveTimeoutManager) ObserveLatency(latency time.Duration) {
 // Assumes [tm.lock] is held
 func (tm *adaptiveTimeoutManager) observeLatencyAndUpdateTimeout(latency time.Duration, now time.Time) {
 	tm.averager.Observe(float64(latency), now)
+	tm.quantiles.Observe(float64(latency), now)
+
 	avgLatency := tm.averager.Read()
-	tm.currentTimeout = time.Duration(tm.timeoutCoefficient * avgLatency)
+	baseLatency := avgLatency
+	if tm.timeoutPercentile > 0 {
+		baseLatency = tm.quantiles.Quantile(tm.timeoutPercentile)
+	}
+
+	tm.currentTimeout = time.Duration(tm.timeoutCoefficient * baseLatency)
 	if tm.currentTimeout > tm.maximumTimeout {
 		tm.currentTimeout = tm.maximumTimeout
 	} else if tm.currentTimeout < tm.minimumTimeout {
@@ -273,6 +334,29 @@ func (tm *adaptiveTimeoutManager) observeLatencyAndUpdateTimeout(latency time.Du
 	// Update the metrics
 	tm.networkTimeoutMetric.Set(float64(tm.currentTimeout))
 	tm.avgLatency.Set(avgLatency)
+	tm.exportQuantiles(allOpsLabel, tm.quantiles)
+}
+
+// Assumes [tm.lock] is held
+func (tm *adaptiveTimeoutManager) observeOpLatency(op message.Op, latency time.Duration, now time.Time) {
+	quantiles, exists := tm.opQuantiles[op]
+	if !exists {
+		// The config was verified on construction, so this can't error.
+		quantiles, _ = math.NewQuantiles(quantileRelativeAccuracy, tm.halflife, now)
+		tm.opQuantiles[op] = quantiles
+	}
+	quantiles.Observe(float64(latency), now)
+	tm.exportQuantiles(op.String(), quantiles)
+}
+
+// Assumes [tm.lock] is held
+func (tm *adaptiveTimeoutManager) exportQuantiles(op string, quantiles math.Quantiles) {
+	for _, q := range exportedQuantiles {
+		tm.latencyQuantiles.With(prometheus.Labels{
+			opLabel:       op,
+			quantileLabel: strconv.FormatFloat(q, 'f', -1, 64),
+		}).Set(quantiles.Quantile(q))
+	}
 }
 
 // Returns the handler function associated with the next timeout.
@@ -73,6 +73,17 @@ func TestAdaptiveTimeoutManagerInit(t *testing.T) {
 			},
 			shouldErrWith: "timeout halflife is negative",
 		},
+		{
+			config: AdaptiveTimeoutConfig{
+				InitialTimeout:     2 * time.Second,
+				MinimumTimeout:     2 * time.Second,
+				MaximumTimeout:     3 * time.Second,
+				TimeoutCoefficient: 1,
+				TimeoutHalflife:    5 * time.Minute,
+				TimeoutPercentile:  1.5,
+			},
+			shouldErrWith: "timeout percentile > 1",
+		},
 		{
 			config: AdaptiveTimeoutConfig{
 				InitialTimeout:     2 * time.Second,
@@ -140,3 +151,73 @@ func TestAdaptiveTimeoutManager(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestAdaptiveTimeoutManagerPercentile(t *testing.T) {
+	assert := assert.New(t)
+
+	tmIntf, err := NewAdaptiveTimeoutManager(
+		&AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Second,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     time.Hour,
+			TimeoutHalflife:    time.Hour,
+			TimeoutCoefficient: 2,
+			TimeoutPercentile:  .9,
+		},
+		"",
+		prometheus.NewRegistry(),
+	)
+	assert.NoError(err)
+	tm := tmIntf.(*adaptiveTimeoutManager)
+	tm.clock.Set(time.Now())
+
+	// With a bimodal latency distribution, the timeout should be driven by
+	// the slow responses rather than by the average.
+	for i := 0; i < 80; i++ {
+		tm.ObserveLatency(10 * time.Millisecond)
+	}
+	for i := 0; i < 20; i++ {
+		tm.ObserveLatency(500 * time.Millisecond)
+	}
+	assert.InEpsilon(float64(time.Second), float64(tm.TimeoutDuration()), .02)
+}
+
+func TestAdaptiveTimeoutManagerOpQuantiles(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := prometheus.NewRegistry()
+	tmIntf, err := NewAdaptiveTimeoutManager(
+		&AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Second,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     time.Hour,
+			TimeoutHalflife:    time.Hour,
+			TimeoutCoefficient: 2,
+		},
+		"",
+		registry,
+	)
+	assert.NoError(err)
+	tm := tmIntf.(*adaptiveTimeoutManager)
+	now := time.Now()
+	tm.clock.Set(now)
+
+	tm.Put(ids.ID{1}, message.PullQuery, func() {})
+	tm.clock.Set(now.Add(100 * time.Millisecond))
+	tm.Remove(ids.ID{1})
+
+	assert.Contains(tm.opQuantiles, message.PullQuery)
+	assert.InEpsilon(float64(100*time.Millisecond), tm.opQuantiles[message.PullQuery].Quantile(.5), .01)
+
+	metrics, err := registry.Gather()
+	assert.NoError(err)
+
+	numQuantiles := 0
+	for _, family := range metrics {
+		if family.GetName() == "latency_quantile" {
+			numQuantiles = len(family.GetMetric())
+		}
+	}
+	// The quantiles are reported for all ops and for the PullQuery op
+	assert.Equal(2*len(exportedQuantiles), numQuantiles)
+}
@@ -0,0 +1,29 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !windows
+// +build !windows
+
+package ulimit
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// FDUsage returns the number of file descriptors this process has open and the
+// number of file descriptors it is allowed to open.
+func FDUsage() (open uint64, limit uint64, err error) {
+	var rLimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rLimit); err != nil {
+		return 0, 0, fmt.Errorf("error getting rlimit: %w", err)
+	}
+
+	fds, err := os.ReadDir("/dev/fd")
+	if err != nil {
+		return 0, 0, fmt.Errorf("error listing open fds: %w", err)
+	}
+	// Don't count the fd used to list the open fds
+	return uint64(len(fds) - 1), fdLimit(rLimit), nil
+}
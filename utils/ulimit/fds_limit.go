@@ -0,0 +1,13 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !windows && !freebsd
+// +build !windows,!freebsd
+
+package ulimit
+
+import "syscall"
+
+func fdLimit(rLimit syscall.Rlimit) uint64 {
+	return rLimit.Cur
+}
@@ -0,0 +1,13 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build freebsd
+// +build freebsd
+
+package ulimit
+
+import "syscall"
+
+func fdLimit(rLimit syscall.Rlimit) uint64 {
+	return uint64(rLimit.Cur)
+}
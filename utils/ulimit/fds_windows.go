@@ -0,0 +1,16 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build windows
+// +build windows
+
+package ulimit
+
+import "errors"
+
+var errFDUsageUnsupported = errors.New("fd usage is not supported for windows")
+
+// FDUsage is not supported for windows and always returns an error.
+func FDUsage() (open uint64, limit uint64, err error) {
+	return 0, 0, errFDUsageUnsupported
+}
@@ -4,6 +4,8 @@
 package ulimit
 
 import (
+	"os"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,3 +19,23 @@ func Test_SetDefault(t *testing.T) {
 	err := Set(DefaultFDLimit, logging.NoLog{})
 	assert.NoErrorf(err, "default fd-limit failed %v", err)
 }
+
+func TestFDUsage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fd usage is not supported for windows")
+	}
+	assert := assert.New(t)
+
+	open, limit, err := FDUsage()
+	assert.NoError(err)
+	assert.NotZero(open)
+	assert.LessOrEqual(open, limit)
+
+	f, err := os.CreateTemp(t.TempDir(), "fd")
+	assert.NoError(err)
+	defer f.Close()
+
+	newOpen, _, err := FDUsage()
+	assert.NoError(err)
+	assert.Equal(open+1, newOpen)
+}
@@ -28,6 +28,8 @@ const (
 	BoolLen = 1
 	// IPLen is the number of bytes per IP
 	IPLen = 16 + ShortLen
+	// MaxVarintLen is the maximum number of bytes per varint
+	MaxVarintLen = binary.MaxVarintLen64
 )
 
 var (
@@ -36,6 +38,8 @@ var (
 	errInvalidInput   = errors.New("input does not match expected format")
 	errBadType        = errors.New("wrong type passed")
 	errBadBool        = errors.New("unexpected value when unpacking bool")
+	errBadVarint      = errors.New("varint is not minimally encoded")
+	errVarintOverflow = errors.New("varint overflows a 64-bit integer")
 )
 
 // Packer packs and unpacks a byte array from/to standard values
@@ -175,6 +179,63 @@ func (p *Packer) UnpackLong() uint64 {
 	return val
 }
 
+// PackUvarint appends an unsigned varint to the byte array. Smaller values are
+// packed into fewer bytes.
+func (p *Packer) PackUvarint(val uint64) {
+	var buf [MaxVarintLen]byte
+	n := binary.PutUvarint(buf[:], val)
+	p.PackFixedBytes(buf[:n])
+}
+
+// UnpackUvarint unpacks an unsigned varint from the byte array. Only the
+// minimal encoding of a value is accepted, so every value has exactly one
+// representation.
+func (p *Packer) UnpackUvarint() uint64 {
+	if p.Errored() {
+		return 0
+	}
+	if p.Offset < 0 {
+		p.Add(errNegativeOffset)
+		return 0
+	}
+
+	val, n := binary.Uvarint(p.Bytes[p.Offset:])
+	switch {
+	case n == 0:
+		p.Add(errBadLength)
+		return 0
+	case n < 0:
+		p.Add(errVarintOverflow)
+		return 0
+	case n > 1 && p.Bytes[p.Offset+n-1] == 0:
+		// A trailing zero byte only adds leading zero bits to the value
+		p.Add(errBadVarint)
+		return 0
+	}
+	p.Offset += n
+	return val
+}
+
+// PackVarint appends a signed varint to the byte array. Values with a smaller
+// magnitude are packed into fewer bytes.
+func (p *Packer) PackVarint(val int64) {
+	var buf [MaxVarintLen]byte
+	n := binary.PutVarint(buf[:], val)
+	p.PackFixedBytes(buf[:n])
+}
+
+// UnpackVarint unpacks a signed varint from the byte array. Only the minimal
+// encoding of a value is accepted.
+func (p *Packer) UnpackVarint() int64 {
+	// Signed varints are zig-zag encoded unsigned varints
+	uval := p.UnpackUvarint()
+	val := int64(uval >> 1)
+	if uval&1 != 0 {
+		val = ^val
+	}
+	return val
+}
+
 // PackBool packs a bool into the byte array
 func (p *Packer) PackBool(b bool) {
 	if b {
@@ -5,6 +5,7 @@ package wrappers
 
 import (
 	"bytes"
+	"math"
 	"net"
 	"reflect"
 	"testing"
@@ -649,3 +650,72 @@ func TestPackClaimedIPPortList(t *testing.T) {
 	assert.Equal(t, ip.Signature, resolvedUnpackedIPCertList[0].Signature)
 	assert.Equal(t, ip.Timestamp, resolvedUnpackedIPCertList[0].Timestamp)
 }
+
+func TestPackerUvarint(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		val      uint64
+		expected []byte
+	}{
+		{val: 0, expected: []byte{0x00}},
+		{val: 1, expected: []byte{0x01}},
+		{val: 127, expected: []byte{0x7f}},
+		{val: 128, expected: []byte{0x80, 0x01}},
+		{val: 300, expected: []byte{0xac, 0x02}},
+		{val: math.MaxUint64, expected: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+	}
+	for _, test := range tests {
+		p := Packer{MaxSize: MaxVarintLen}
+		p.PackUvarint(test.val)
+		assert.NoError(p.Err)
+		assert.Equal(test.expected, p.Bytes)
+
+		p = Packer{Bytes: test.expected}
+		assert.Equal(test.val, p.UnpackUvarint())
+		assert.NoError(p.Err)
+		assert.Equal(len(test.expected), p.Offset)
+	}
+}
+
+func TestPackerUnpackUvarintInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string][]byte{
+		"empty":       {},
+		"truncated":   {0x80},
+		"non-minimal": {0x80, 0x00},
+		"overflow":    {0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02},
+	}
+	for name, bytes := range tests {
+		p := Packer{Bytes: bytes}
+		p.UnpackUvarint()
+		assert.Error(p.Err, name)
+	}
+}
+
+func TestPackerVarint(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		val      int64
+		expected []byte
+	}{
+		{val: 0, expected: []byte{0x00}},
+		{val: -1, expected: []byte{0x01}},
+		{val: 1, expected: []byte{0x02}},
+		{val: -64, expected: []byte{0x7f}},
+		{val: 64, expected: []byte{0x80, 0x01}},
+		{val: math.MinInt64, expected: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+	}
+	for _, test := range tests {
+		p := Packer{MaxSize: MaxVarintLen}
+		p.PackVarint(test.val)
+		assert.NoError(p.Err)
+		assert.Equal(test.expected, p.Bytes)
+
+		p = Packer{Bytes: test.expected}
+		assert.Equal(test.val, p.UnpackVarint())
+		assert.NoError(p.Err)
+	}
+}
@@ -103,6 +103,20 @@ var (
 		constants.FujiID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
 	}
 	XChainMigrationDefaultTime = time.Date(2022, time.January, 1, 1, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	SubnetValidatorRemovalTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.FujiID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	SubnetValidatorRemovalDefaultTime = time.Date(2022, time.January, 1, 1, 0, 0, 0, time.UTC)
+
+	// FIXME: update this before release
+	BLSKeyRegistrationTimes = map[uint32]time.Time{
+		constants.MainnetID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.FujiID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	BLSKeyRegistrationDefaultTime = time.Date(2022, time.January, 1, 1, 0, 0, 0, time.UTC)
 )
 
 func GetApricotPhase0Time(networkID uint32) time.Time {
@@ -161,6 +175,20 @@ func GetXChainMigrationTime(networkID uint32) time.Time {
 	return XChainMigrationDefaultTime
 }
 
+func GetSubnetValidatorRemovalTime(networkID uint32) time.Time {
+	if upgradeTime, exists := SubnetValidatorRemovalTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return SubnetValidatorRemovalDefaultTime
+}
+
+func GetBLSKeyRegistrationTime(networkID uint32) time.Time {
+	if upgradeTime, exists := BLSKeyRegistrationTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return BLSKeyRegistrationDefaultTime
+}
+
 func GetCompatibility(networkID uint32) Compatibility {
 	return NewCompatibility(
 		CurrentApp,
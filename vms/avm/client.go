@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 
 	cjson "github.com/ava-labs/avalanchego/utils/json"
 )
@@ -33,6 +34,12 @@ type Client interface {
 	ConfirmTx(ctx context.Context, txID ids.ID, freq time.Duration, options ...rpc.Option) (choices.Status, error)
 	// GetTx returns the byte representation of [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
+	// IssueTxs issues the byte representations of txs, in order. Either all of
+	// them are issued or none of them are.
+	IssueTxs(ctx context.Context, txsBytes [][]byte, options ...rpc.Option) ([]IssueTxResult, error)
+	// EstimateFee returns the fee that must be burned by the, possibly
+	// unsigned, tx [txBytes] and the ID of the asset it must be paid in
+	EstimateFee(ctx context.Context, txBytes []byte, options ...rpc.Option) (uint64, ids.ID, error)
 	// IssueStopVertex issues a stop vertex.
 	IssueStopVertex(ctx context.Context, options ...rpc.Option) error
 	// GetUTXOs returns the byte representation of the UTXOs controlled by [addrs]
@@ -55,8 +62,28 @@ type Client interface {
 		startUTXOID ids.ID,
 		options ...rpc.Option,
 	) ([][]byte, ids.ShortID, ids.ID, error)
+	// GetUTXOsByID returns the byte representation and the status of each of
+	// the UTXOs in [utxoIDs]. The byte representation is nil unless the UTXO
+	// is unspent.
+	GetUTXOsByID(ctx context.Context, utxoIDs []*avax.UTXOID, options ...rpc.Option) ([][]byte, []string, error)
+	// GetSignatureStatus returns which signatures the, possibly partially
+	// signed, tx [txBytes] still needs
+	GetSignatureStatus(ctx context.Context, txBytes []byte, options ...rpc.Option) (*GetSignatureStatusReply, error)
 	// GetAssetDescription returns a description of [assetID]
 	GetAssetDescription(ctx context.Context, assetID string, options ...rpc.Option) (*GetAssetDescriptionReply, error)
+	// GetAssetDescriptions returns the descriptions of [assetIDs]
+	GetAssetDescriptions(ctx context.Context, assetIDs []string, options ...rpc.Option) ([]AssetDescription, error)
+	// GetNFTs returns at most [limit] NFTs owned by [addr], held in UTXOs after
+	// [startUTXOID]. If [assetID] isn't empty, only NFTs of [assetID] are
+	// returned. Also returns the UTXO ID to start the next page from.
+	GetNFTs(
+		ctx context.Context,
+		addr ids.ShortID,
+		assetID string,
+		startUTXOID ids.ID,
+		limit uint32,
+		options ...rpc.Option,
+	) ([]NFT, ids.ID, error)
 	// GetBalance returns the balance of [assetID] held by [addr].
 	// If [includePartial], balance includes partial owned (i.e. in a multisig) funds.
 	GetBalance(ctx context.Context, addr ids.ShortID, assetID string, includePartial bool, options ...rpc.Option) (*GetBalanceReply, error)
@@ -187,6 +214,36 @@ func NewClient(uri, chain string) Client {
 	}
 }
 
+func (c *client) IssueTxs(ctx context.Context, txsBytes [][]byte, options ...rpc.Option) ([]IssueTxResult, error) {
+	txStrs := make([]string, len(txsBytes))
+	for i, txBytes := range txsBytes {
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		if err != nil {
+			return nil, err
+		}
+		txStrs[i] = txStr
+	}
+	res := &IssueTxsReply{}
+	err := c.requester.SendRequest(ctx, "issueTxs", &IssueTxsArgs{
+		Txs:      txStrs,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res.Results, err
+}
+
+func (c *client) EstimateFee(ctx context.Context, txBytes []byte, options ...rpc.Option) (uint64, ids.ID, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return 0, ids.Empty, err
+	}
+	res := &api.EstimateFeeReply{}
+	err = c.requester.SendRequest(ctx, "estimateFee", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return uint64(res.Fee), res.AssetID, err
+}
+
 func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error) {
 	txStr, err := formatting.Encode(formatting.Hex, txBytes)
 	if err != nil {
@@ -300,6 +357,50 @@ func (c *client) GetAtomicUTXOs(
 	return utxos, endAddr, endUTXOID, err
 }
 
+func (c *client) GetUTXOsByID(ctx context.Context, utxoIDs []*avax.UTXOID, options ...rpc.Option) ([][]byte, []string, error) {
+	utxoIDStrs := make([]string, len(utxoIDs))
+	for i, utxoID := range utxoIDs {
+		utxoIDStrs[i] = utxoID.String()
+	}
+
+	res := &api.GetUTXOsByIDReply{}
+	err := c.requester.SendRequest(ctx, "getUTXOsByID", &api.GetUTXOsByIDArgs{
+		UTXOIDs:  utxoIDStrs,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	utxos := make([][]byte, len(res.UTXOs))
+	statuses := make([]string, len(res.UTXOs))
+	for i, utxo := range res.UTXOs {
+		statuses[i] = utxo.Status
+		if utxo.Status != api.UTXOUnspent {
+			continue
+		}
+		utxoBytes, err := formatting.Decode(res.Encoding, utxo.UTXO)
+		if err != nil {
+			return nil, nil, err
+		}
+		utxos[i] = utxoBytes
+	}
+	return utxos, statuses, nil
+}
+
+func (c *client) GetSignatureStatus(ctx context.Context, txBytes []byte, options ...rpc.Option) (*GetSignatureStatusReply, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &GetSignatureStatusReply{}
+	err = c.requester.SendRequest(ctx, "getSignatureStatus", &GetSignatureStatusArgs{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetAssetDescription(ctx context.Context, assetID string, options ...rpc.Option) (*GetAssetDescriptionReply, error) {
 	res := &GetAssetDescriptionReply{}
 	err := c.requester.SendRequest(ctx, "getAssetDescription", &GetAssetDescriptionArgs{
@@ -308,6 +409,32 @@ func (c *client) GetAssetDescription(ctx context.Context, assetID string, option
 	return res, err
 }
 
+func (c *client) GetAssetDescriptions(ctx context.Context, assetIDs []string, options ...rpc.Option) ([]AssetDescription, error) {
+	res := &GetAssetDescriptionsReply{}
+	err := c.requester.SendRequest(ctx, "getAssetDescriptions", &GetAssetDescriptionsArgs{
+		AssetIDs: assetIDs,
+	}, res, options...)
+	return res.Assets, err
+}
+
+func (c *client) GetNFTs(
+	ctx context.Context,
+	addr ids.ShortID,
+	assetID string,
+	startUTXOID ids.ID,
+	limit uint32,
+	options ...rpc.Option,
+) ([]NFT, ids.ID, error) {
+	res := &GetNFTsReply{}
+	err := c.requester.SendRequest(ctx, "getNFTs", &GetNFTsArgs{
+		Address:     addr.String(),
+		AssetID:     assetID,
+		StartUTXOID: startUTXOID,
+		Limit:       cjson.Uint32(limit),
+	}, res, options...)
+	return res.NFTs, res.EndUTXOID, err
+}
+
 func (c *client) GetBalance(
 	ctx context.Context,
 	addr ids.ShortID,
@@ -5,12 +5,16 @@ package avm
 
 import (
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/pubsub"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 )
 
-var _ pubsub.Filterer = &filterer{}
+var (
+	_ pubsub.Filterer = &filterer{}
+	_ pubsub.Filterer = &utxoFilterer{}
+)
 
 type filterer struct {
 	tx *txs.Tx
@@ -22,8 +26,65 @@ func NewPubSubFilterer(tx *txs.Tx) pubsub.Filterer {
 
 // Apply the filter on the addresses.
 func (f *filterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
+	return filterAddresses(f.tx.UTXOs(), filters), api.JSONTxID{
+		TxID: f.tx.ID(),
+	}
+}
+
+// UTXOChanges is the notification sent to subscribers of the UTXOs topic.
+type UTXOChanges struct {
+	TxID ids.ID `json:"txID"`
+	// Consumed are the IDs of the UTXOs spent by the transaction
+	Consumed []ids.ID `json:"consumed"`
+	// Produced are the IDs of the UTXOs created by the transaction
+	Produced []ids.ID `json:"produced"`
+}
+
+type utxoFilterer struct {
+	tx *txs.Tx
+	// inputUTXOs are the UTXOs consumed by [tx]
+	inputUTXOs []*avax.UTXO
+}
+
+// NewUTXOFilterer returns a filterer that notifies connections of the UTXOs
+// consumed and produced by [tx] if any of [inputUTXOs], the UTXOs consumed by
+// [tx], or of [tx]'s outputs are owned by an address in the connection's
+// filter.
+func NewUTXOFilterer(tx *txs.Tx, inputUTXOs []*avax.UTXO) pubsub.Filterer {
+	return &utxoFilterer{
+		tx:         tx,
+		inputUTXOs: inputUTXOs,
+	}
+}
+
+func (f *utxoFilterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
+	inputs := f.tx.Unsigned.InputUTXOs()
+	consumed := make([]ids.ID, len(inputs))
+	for i, utxoID := range inputs {
+		consumed[i] = utxoID.InputID()
+	}
+
+	outputs := f.tx.UTXOs()
+	produced := make([]ids.ID, len(outputs))
+	for i, utxo := range outputs {
+		produced[i] = utxo.InputID()
+	}
+
+	utxos := make([]*avax.UTXO, 0, len(f.inputUTXOs)+len(outputs))
+	utxos = append(utxos, f.inputUTXOs...)
+	utxos = append(utxos, outputs...)
+	return filterAddresses(utxos, filters), &UTXOChanges{
+		TxID:     f.tx.ID(),
+		Consumed: consumed,
+		Produced: produced,
+	}
+}
+
+// filterAddresses returns which of the [filters] match an address that owns
+// one of [utxos].
+func filterAddresses(utxos []*avax.UTXO, filters []pubsub.Filter) []bool {
 	resp := make([]bool, len(filters))
-	for _, utxo := range f.tx.UTXOs() {
+	for _, utxo := range utxos {
 		addressable, ok := utxo.Out.(avax.Addressable)
 		if !ok {
 			continue
@@ -38,7 +99,5 @@ func (f *filterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
 			}
 		}
 	}
-	return resp, api.JSONTxID{
-		TxID: f.tx.ID(),
-	}
+	return resp
 }
@@ -49,3 +49,53 @@ func TestFilter(t *testing.T) {
 	fr, _ := parser.Filter([]pubsub.Filter{&mockFilter{addr: addrBytes}})
 	assert.Equal([]bool{true}, fr)
 }
+
+func TestUTXOFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	addrID := ids.ShortID{1}
+	inputID := avax.UTXOID{TxID: ids.ID{2}, OutputIndex: 1}
+	tx := txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		Ins: []*avax.TransferableInput{
+			{
+				UTXOID: inputID,
+				In:     &secp256k1fx.TransferInput{},
+			},
+		},
+		Outs: []*avax.TransferableOutput{
+			{
+				Out: &secp256k1fx.TransferOutput{
+					OutputOwners: secp256k1fx.OutputOwners{
+						Addrs: []ids.ShortID{addrID},
+					},
+				},
+			},
+		},
+	}}}
+	addrBytes := addrID[:]
+
+	// The spender of the input is notified even though it doesn't receive an
+	// output
+	spenderID := ids.ShortID{4}
+	inputUTXO := &avax.UTXO{
+		UTXOID: inputID,
+		Out: &secp256k1fx.TransferOutput{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Addrs: []ids.ShortID{spenderID},
+			},
+		},
+	}
+
+	parser := NewUTXOFilterer(&tx, []*avax.UTXO{inputUTXO})
+	fr, msg := parser.Filter([]pubsub.Filter{
+		&mockFilter{addr: addrBytes},
+		&mockFilter{addr: []byte{3}},
+		&mockFilter{addr: spenderID[:]},
+	})
+	assert.Equal([]bool{true, false, true}, fr)
+
+	changes, ok := msg.(*UTXOChanges)
+	assert.True(ok)
+	assert.Equal([]ids.ID{inputID.InputID()}, changes.Consumed)
+	assert.Len(changes.Produced, 1)
+}
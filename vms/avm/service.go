@@ -10,17 +10,21 @@ import (
 	"net/http"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/types"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
@@ -31,6 +35,17 @@ const (
 
 	// Max number of items allowed in a page
 	maxPageSize uint64 = 1024
+
+	// Max number of txs that can be passed in as argument to IssueTxs
+	maxIssueTxs = 256
+
+	// Max number of assets that can be passed in as argument to
+	// GetAssetDescriptions
+	maxGetAssetDescriptions = 1024
+
+	// Max number of UTXO IDs that can be passed in as argument to
+	// GetUTXOsByID
+	maxGetUTXOsByID = 1024
 )
 
 var (
@@ -48,6 +63,14 @@ var (
 	errNoAddresses            = errors.New("no addresses provided")
 	errNoKeys                 = errors.New("from addresses have no keys or funds")
 	errMissingPrivateKey      = errors.New("argument 'privateKey' not given")
+	errNoTxs                  = errors.New("no transactions provided")
+	errNoAssetIDs             = errors.New("no asset IDs provided")
+	errNoUTXOIDs              = errors.New("no UTXO IDs provided")
+	errUnknownInputType       = errors.New("unknown input type")
+	errUnknownOpType          = errors.New("unknown operation type")
+	errUnknownCredentialType  = errors.New("unknown credential type")
+	errUnknownOutputType      = errors.New("unknown output type")
+	errInvalidNumUTXOsInOp    = errors.New("invalid number of UTXOs in operation")
 )
 
 // Service defines the base service for the asset vm
@@ -75,10 +98,108 @@ func (service *Service) IssueTx(r *http.Request, args *api.FormattedTx, reply *a
 	return nil
 }
 
+// IssueTxsArgs are the arguments for calling IssueTxs
+type IssueTxsArgs struct {
+	Txs      []string            `json:"txs"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// IssueTxResult is the result of issuing one of the txs passed to IssueTxs
+type IssueTxResult struct {
+	// ID of the tx. Empty if the tx couldn't be parsed.
+	TxID ids.ID `json:"txID"`
+	// True if the tx was issued into consensus
+	Issued bool `json:"issued"`
+	// Reason the tx wasn't issued, if it wasn't
+	Error string `json:"error,omitempty"`
+}
+
+// IssueTxsReply defines the IssueTxs replies returned from the API
+type IssueTxsReply struct {
+	// The result of issuing each tx, in the order they were provided
+	Results []IssueTxResult `json:"results"`
+}
+
+// IssueTxs attempts to issue a batch of transactions into consensus, in order.
+// Either all of the transactions are issued or none of them are.
+func (service *Service) IssueTxs(r *http.Request, args *IssueTxsArgs, reply *IssueTxsReply) error {
+	service.vm.ctx.Log.Debug("AVM: IssueTxs called with %d txs", len(args.Txs))
+
+	switch numTxs := len(args.Txs); {
+	case numTxs == 0:
+		return errNoTxs
+	case numTxs > maxIssueTxs:
+		return fmt.Errorf("number of txs given, %d, exceeds maximum, %d", numTxs, maxIssueTxs)
+	}
+
+	txsBytes := make([][]byte, len(args.Txs))
+	for i, txStr := range args.Txs {
+		txBytes, err := formatting.Decode(args.Encoding, txStr)
+		if err != nil {
+			return fmt.Errorf("problem decoding transaction %d: %w", i, err)
+		}
+		txsBytes[i] = txBytes
+	}
+
+	txIDs, txErrs, err := service.vm.IssueTxs(txsBytes)
+	if err != nil {
+		return err
+	}
+
+	reply.Results = make([]IssueTxResult, len(txIDs))
+	for i, txID := range txIDs {
+		reply.Results[i].TxID = txID
+		if txErrs[i] != nil {
+			reply.Results[i].Error = txErrs[i].Error()
+		} else {
+			reply.Results[i].Issued = true
+		}
+	}
+	return nil
+}
+
 func (service *Service) IssueStopVertex(_ *http.Request, _ *struct{}, _ *struct{}) error {
 	return service.vm.issueStopVertex()
 }
 
+// EstimateFee returns the fee that must be burned by the provided tx under the
+// current fee configuration. The tx may be either unsigned or signed.
+func (service *Service) EstimateFee(_ *http.Request, args *api.FormattedTx, reply *api.EstimateFeeReply) error {
+	service.vm.ctx.Log.Debug("AVM: EstimateFee called")
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	unsignedTx, err := service.parseUnsignedTx(txBytes)
+	if err != nil {
+		return fmt.Errorf("problem parsing transaction: %w", err)
+	}
+
+	feeCalculator := txFeeCalculator{vm: service.vm}
+	if err := unsignedTx.Visit(&feeCalculator); err != nil {
+		return err
+	}
+
+	reply.Fee = json.Uint64(feeCalculator.fee)
+	reply.AssetID = service.vm.feeAssetID
+	return nil
+}
+
+// parseUnsignedTx parses [txBytes] as an unsigned tx, falling back to parsing
+// it as a signed tx
+func (service *Service) parseUnsignedTx(txBytes []byte) (txs.UnsignedTx, error) {
+	var unsignedTx txs.UnsignedTx
+	if _, err := service.vm.parser.Codec().Unmarshal(txBytes, &unsignedTx); err == nil {
+		return unsignedTx, nil
+	}
+	tx, err := service.vm.parser.Parse(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Unsigned, nil
+}
+
 // GetTxStatusReply defines the GetTxStatus replies returned from the API
 type GetTxStatusReply struct {
 	Status choices.Status `json:"status"`
@@ -193,6 +314,11 @@ func (service *Service) GetTx(r *http.Request, args *api.GetTxArgs, reply *api.G
 }
 
 // GetUTXOs gets all utxos for passed in addresses
+//
+// UTXOs of this chain are read from the index of UTXOs by address that is
+// updated as UTXOs are added and removed on accept. Each page starts from
+// [args.StartIndex] in that index, so fetching a page only reads the UTXOs
+// it returns, regardless of the number of UTXOs an address holds.
 func (service *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply *api.GetUTXOsReply) error {
 	service.vm.ctx.Log.Debug("AVM: GetUTXOs called for with %s", args.Addresses)
 
@@ -287,6 +413,322 @@ func (service *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply
 	return nil
 }
 
+// GetUTXOsByID returns the UTXOs with the provided IDs, along with whether
+// each UTXO is unspent, spent, or unknown to this chain
+func (service *Service) GetUTXOsByID(r *http.Request, args *api.GetUTXOsByIDArgs, reply *api.GetUTXOsByIDReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetUTXOsByID called with %d UTXO IDs", len(args.UTXOIDs))
+
+	if len(args.UTXOIDs) == 0 {
+		return errNoUTXOIDs
+	}
+	if len(args.UTXOIDs) > maxGetUTXOsByID {
+		return fmt.Errorf("number of UTXO IDs given, %d, exceeds maximum, %d", len(args.UTXOIDs), maxGetUTXOsByID)
+	}
+
+	utxoIDs := make([]*avax.UTXOID, len(args.UTXOIDs))
+	for i, utxoIDStr := range args.UTXOIDs {
+		utxoID, err := avax.ParseUTXOID(utxoIDStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse UTXO ID %q: %w", utxoIDStr, err)
+		}
+		utxoIDs[i] = utxoID
+	}
+
+	reply.UTXOs = make([]api.UTXOByID, len(utxoIDs))
+	codec := service.vm.parser.Codec()
+	for i, utxoID := range utxoIDs {
+		reply.UTXOs[i].UTXOID = utxoID.String()
+
+		utxo, err := service.vm.state.GetUTXO(utxoID.InputID())
+		switch err {
+		case nil:
+			b, err := codec.Marshal(txs.CodecVersion, utxo)
+			if err != nil {
+				return fmt.Errorf("problem marshalling UTXO: %w", err)
+			}
+			reply.UTXOs[i].UTXO, err = formatting.Encode(args.Encoding, b)
+			if err != nil {
+				return fmt.Errorf("couldn't encode UTXO %s as string: %w", utxoID, err)
+			}
+			reply.UTXOs[i].Status = api.UTXOUnspent
+			continue
+		case database.ErrNotFound:
+		default:
+			return fmt.Errorf("problem retrieving UTXO %s: %w", utxoID, err)
+		}
+
+		spent, err := service.isSpent(utxoID)
+		if err != nil {
+			return fmt.Errorf("problem retrieving the status of UTXO %s: %w", utxoID, err)
+		}
+		if spent {
+			reply.UTXOs[i].Status = api.UTXOSpent
+		} else {
+			reply.UTXOs[i].Status = api.UTXOUnknown
+		}
+	}
+	reply.Encoding = args.Encoding
+	return nil
+}
+
+// isSpent returns true if [utxoID], which is assumed to not be in the UTXO
+// set, was produced by an accepted transaction
+func (service *Service) isSpent(utxoID *avax.UTXOID) (bool, error) {
+	status, err := service.vm.state.GetStatus(utxoID.TxID)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if status != choices.Accepted {
+		return false, nil
+	}
+
+	tx, err := service.vm.state.GetTx(utxoID.TxID)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return int(utxoID.OutputIndex) < len(tx.UTXOs()), nil
+}
+
+// GetSignatureStatusArgs are arguments for passing into GetSignatureStatus
+// requests
+type GetSignatureStatusArgs struct {
+	Tx       string              `json:"tx"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// InputSignatureStatus describes the signatures provided for an input of a
+// tx. [Signed] and [Missing] are the addresses that have and haven't yet
+// provided the signatures required to spend the UTXO.
+type InputSignatureStatus struct {
+	UTXOID    string      `json:"utxoID"`
+	Threshold json.Uint32 `json:"threshold"`
+	Signed    []string    `json:"signed"`
+	Missing   []string    `json:"missing"`
+}
+
+// GetSignatureStatusReply defines the GetSignatureStatus replies returned from
+// the API. [Inputs] is in the same order as the tx's credentials.
+type GetSignatureStatusReply struct {
+	Complete bool                   `json:"complete"`
+	Inputs   []InputSignatureStatus `json:"inputs"`
+}
+
+// GetSignatureStatus returns which signatures a, possibly partially signed,
+// tx still needs before it can be issued. This is intended to be used to
+// collect the signatures of multisig outputs from their owners.
+func (service *Service) GetSignatureStatus(r *http.Request, args *GetSignatureStatusArgs, reply *GetSignatureStatusReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetSignatureStatus called")
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := service.vm.parser.Parse(txBytes)
+	if err != nil {
+		return fmt.Errorf("problem parsing transaction: %w", err)
+	}
+
+	inputs, err := signedInputs(tx.Unsigned)
+	if err != nil {
+		return err
+	}
+
+	factory := &crypto.FactorySECP256K1R{}
+	txHash := hashing.ComputeHash256(tx.Unsigned.Bytes())
+	reply.Complete = true
+	reply.Inputs = make([]InputSignatureStatus, len(inputs))
+	for i, input := range inputs {
+		utxo, err := service.getInputUTXO(input.sourceChain, input.utxoID)
+		if err != nil {
+			return fmt.Errorf("problem retrieving UTXO %s: %w", input.utxoID, err)
+		}
+		owners, err := utxoOwners(utxo.Out)
+		if err != nil {
+			return err
+		}
+
+		var cred *secp256k1fx.Credential
+		if i < len(tx.Creds) {
+			cred, err = secpCredential(tx.Creds[i].Verifiable)
+			if err != nil {
+				return err
+			}
+		}
+
+		status, err := secp256k1fx.GetSignatureStatus(factory, txHash, input.in, cred, owners)
+		if err != nil {
+			return fmt.Errorf("invalid input spending UTXO %s: %w", input.utxoID, err)
+		}
+
+		inputStatus := &reply.Inputs[i]
+		inputStatus.UTXOID = input.utxoID.String()
+		inputStatus.Threshold = json.Uint32(owners.Threshold)
+		inputStatus.Signed, err = service.formatLocalAddresses(status.Signed)
+		if err != nil {
+			return err
+		}
+		inputStatus.Missing, err = service.formatLocalAddresses(status.Missing)
+		if err != nil {
+			return err
+		}
+		reply.Complete = reply.Complete && status.Complete()
+	}
+	return nil
+}
+
+// signedInput is an input of a tx that requires a credential
+type signedInput struct {
+	sourceChain ids.ID
+	utxoID      *avax.UTXOID
+	in          *secp256k1fx.Input
+}
+
+// signedInputs returns the inputs of [utx], in the same order as the
+// credentials of the tx
+func signedInputs(utx txs.UnsignedTx) ([]signedInput, error) {
+	var (
+		ins []signedInput
+		err error
+	)
+	switch utx := utx.(type) {
+	case *txs.BaseTx:
+		ins, err = transferableSignedInputs(utx.BlockchainID, utx.Ins)
+	case *txs.CreateAssetTx:
+		ins, err = transferableSignedInputs(utx.BlockchainID, utx.Ins)
+	case *txs.ExportTx:
+		ins, err = transferableSignedInputs(utx.BlockchainID, utx.Ins)
+	case *txs.ImportTx:
+		ins, err = transferableSignedInputs(utx.BlockchainID, utx.Ins)
+		if err != nil {
+			return nil, err
+		}
+		var importedIns []signedInput
+		importedIns, err = transferableSignedInputs(utx.SourceChain, utx.ImportedIns)
+		ins = append(ins, importedIns...)
+	case *txs.OperationTx:
+		ins, err = transferableSignedInputs(utx.BlockchainID, utx.Ins)
+		if err != nil {
+			return nil, err
+		}
+		var opIns []signedInput
+		opIns, err = operationSignedInputs(utx.BlockchainID, utx.Ops)
+		ins = append(ins, opIns...)
+	default:
+		return nil, fmt.Errorf("unknown tx type %T", utx)
+	}
+	return ins, err
+}
+
+func transferableSignedInputs(chainID ids.ID, ins []*avax.TransferableInput) ([]signedInput, error) {
+	signedIns := make([]signedInput, len(ins))
+	for i, in := range ins {
+		transferInput, ok := in.In.(*secp256k1fx.TransferInput)
+		if !ok {
+			return nil, errUnknownInputType
+		}
+		signedIns[i] = signedInput{
+			sourceChain: chainID,
+			utxoID:      &in.UTXOID,
+			in:          &transferInput.Input,
+		}
+	}
+	return signedIns, nil
+}
+
+func operationSignedInputs(chainID ids.ID, ops []*txs.Operation) ([]signedInput, error) {
+	signedIns := make([]signedInput, len(ops))
+	for i, op := range ops {
+		if len(op.UTXOIDs) != 1 {
+			return nil, errInvalidNumUTXOsInOp
+		}
+
+		var in *secp256k1fx.Input
+		switch op := op.Op.(type) {
+		case *secp256k1fx.MintOperation:
+			in = &op.MintInput
+		case *nftfx.MintOperation:
+			in = &op.MintInput
+		case *nftfx.TransferOperation:
+			in = &op.Input
+		case *propertyfx.MintOperation:
+			in = &op.MintInput
+		case *propertyfx.BurnOperation:
+			in = &op.Input
+		default:
+			return nil, errUnknownOpType
+		}
+		signedIns[i] = signedInput{
+			sourceChain: chainID,
+			utxoID:      op.UTXOIDs[0],
+			in:          in,
+		}
+	}
+	return signedIns, nil
+}
+
+func secpCredential(cred verify.Verifiable) (*secp256k1fx.Credential, error) {
+	switch cred := cred.(type) {
+	case *secp256k1fx.Credential:
+		return cred, nil
+	case *nftfx.Credential:
+		return &cred.Credential, nil
+	case *propertyfx.Credential:
+		return &cred.Credential, nil
+	default:
+		return nil, errUnknownCredentialType
+	}
+}
+
+func utxoOwners(out verify.State) (*secp256k1fx.OutputOwners, error) {
+	switch out := out.(type) {
+	case *secp256k1fx.TransferOutput:
+		return &out.OutputOwners, nil
+	case *secp256k1fx.MintOutput:
+		return &out.OutputOwners, nil
+	case *nftfx.TransferOutput:
+		return &out.OutputOwners, nil
+	case *nftfx.MintOutput:
+		return &out.OutputOwners, nil
+	case *propertyfx.OwnedOutput:
+		return &out.OutputOwners, nil
+	case *propertyfx.MintOutput:
+		return &out.OutputOwners, nil
+	default:
+		return nil, errUnknownOutputType
+	}
+}
+
+// getInputUTXO returns the UTXO [utxoID], which is either a UTXO of this
+// chain or an atomic UTXO exported from [sourceChain]
+func (service *Service) getInputUTXO(sourceChain ids.ID, utxoID *avax.UTXOID) (*avax.UTXO, error) {
+	if sourceChain == service.vm.ctx.ChainID {
+		return service.vm.getUTXO(utxoID)
+	}
+
+	inputID := utxoID.InputID()
+	utxoBytes, err := service.vm.ctx.SharedMemory.Get(sourceChain, [][]byte{inputID[:]})
+	if err != nil {
+		return nil, err
+	}
+	utxo := &avax.UTXO{}
+	_, err = service.vm.parser.Codec().Unmarshal(utxoBytes[0], utxo)
+	return utxo, err
+}
+
+func (service *Service) formatLocalAddresses(addrs []ids.ShortID) ([]string, error) {
+	addrStrs, err := service.vm.FormatLocalAddresses(addrs)
+	if err != nil {
+		return nil, fmt.Errorf("problem formatting address: %w", err)
+	}
+	return addrStrs, nil
+}
+
 // GetAssetDescriptionArgs are arguments for passing into GetAssetDescription requests
 type GetAssetDescriptionArgs struct {
 	AssetID string `json:"assetID"`
@@ -329,6 +771,159 @@ func (service *Service) GetAssetDescription(_ *http.Request, args *GetAssetDescr
 	return nil
 }
 
+// GetAssetDescriptionsArgs are arguments for passing into
+// GetAssetDescriptions requests
+type GetAssetDescriptionsArgs struct {
+	AssetIDs []string `json:"assetIDs"`
+}
+
+// AssetDescription describes an asset
+type AssetDescription struct {
+	FormattedAssetID
+	Name         string     `json:"name"`
+	Symbol       string     `json:"symbol"`
+	Denomination json.Uint8 `json:"denomination"`
+	// Type is one of "fungible", "nft", "property", or "unknown"
+	Type string `json:"type"`
+}
+
+// GetAssetDescriptionsReply defines the GetAssetDescriptions replies returned
+// from the API
+type GetAssetDescriptionsReply struct {
+	Assets []AssetDescription `json:"assets"`
+}
+
+// GetAssetDescriptions returns the descriptions of the provided assets, in the
+// order they were provided
+func (service *Service) GetAssetDescriptions(_ *http.Request, args *GetAssetDescriptionsArgs, reply *GetAssetDescriptionsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetAssetDescriptions called with %s", args.AssetIDs)
+
+	if len(args.AssetIDs) == 0 {
+		return errNoAssetIDs
+	}
+	if len(args.AssetIDs) > maxGetAssetDescriptions {
+		return fmt.Errorf("number of asset IDs given, %d, exceeds maximum, %d", len(args.AssetIDs), maxGetAssetDescriptions)
+	}
+
+	reply.Assets = make([]AssetDescription, len(args.AssetIDs))
+	for i, assetIDStr := range args.AssetIDs {
+		assetID, err := service.vm.lookupAssetID(assetIDStr)
+		if err != nil {
+			return err
+		}
+
+		asset, err := service.vm.state.GetAsset(assetID)
+		if err == database.ErrNotFound {
+			return fmt.Errorf("%w: %s", errUnknownAssetID, assetIDStr)
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't get asset %s: %w", assetID, err)
+		}
+
+		reply.Assets[i] = AssetDescription{
+			FormattedAssetID: FormattedAssetID{AssetID: assetID},
+			Name:             asset.Name,
+			Symbol:           asset.Symbol,
+			Denomination:     json.Uint8(asset.Denomination),
+			Type:             asset.Type,
+		}
+	}
+	return nil
+}
+
+// GetNFTsArgs are arguments for passing into GetNFTs requests
+type GetNFTsArgs struct {
+	Address string `json:"address"`
+	// If provided, only NFTs of this asset are returned
+	AssetID string `json:"assetID"`
+	// If provided, only NFTs held in UTXOs after this UTXO are returned
+	StartUTXOID ids.ID      `json:"startUTXOID"`
+	Limit       json.Uint32 `json:"limit"`
+}
+
+// NFT describes an NFT held by an address
+type NFT struct {
+	UTXOID  ids.ID              `json:"utxoID"`
+	AssetID ids.ID              `json:"assetID"`
+	GroupID json.Uint32         `json:"groupID"`
+	Payload types.JSONByteSlice `json:"payload"`
+}
+
+// GetNFTsReply defines the GetNFTs replies returned from the API
+type GetNFTsReply struct {
+	NFTs []NFT `json:"nfts"`
+	// The UTXO to pass as StartUTXOID to fetch the next page. If no NFTs
+	// remain, this is the empty ID.
+	EndUTXOID ids.ID `json:"endUTXOID"`
+}
+
+// GetNFTs returns the NFTs owned by an address, in the order that the UTXOs
+// that hold them are indexed by the address
+func (service *Service) GetNFTs(_ *http.Request, args *GetNFTsArgs, reply *GetNFTsReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetNFTs called with %s", args.Address)
+
+	addr, err := avax.ParseServiceAddress(service.vm, args.Address)
+	if err != nil {
+		return fmt.Errorf("problem parsing address %q: %w", args.Address, err)
+	}
+
+	filterAsset := args.AssetID != ""
+	var assetID ids.ID
+	if filterAsset {
+		assetID, err = service.vm.lookupAssetID(args.AssetID)
+		if err != nil {
+			return err
+		}
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || int(maxPageSize) < limit {
+		limit = int(maxPageSize)
+	}
+
+	reply.NFTs = []NFT{}
+	start := args.StartUTXOID
+	for len(reply.NFTs) < limit {
+		utxoIDs, err := service.vm.state.UTXOIDs(addr.Bytes(), start, limit)
+		if err != nil {
+			return fmt.Errorf("couldn't get UTXOs of %s: %w", args.Address, err)
+		}
+		if len(utxoIDs) == 0 {
+			// There are no more NFTs
+			reply.EndUTXOID = ids.Empty
+			return nil
+		}
+
+		for _, utxoID := range utxoIDs {
+			start = utxoID
+
+			utxo, err := service.vm.state.GetUTXO(utxoID)
+			if err != nil {
+				return fmt.Errorf("couldn't get UTXO %s: %w", utxoID, err)
+			}
+			if filterAsset && utxo.AssetID() != assetID {
+				continue
+			}
+			out, ok := utxo.Out.(*nftfx.TransferOutput)
+			if !ok {
+				continue
+			}
+
+			reply.NFTs = append(reply.NFTs, NFT{
+				UTXOID:  utxoID,
+				AssetID: utxo.AssetID(),
+				GroupID: json.Uint32(out.GroupID),
+				Payload: out.Payload,
+			})
+			if len(reply.NFTs) == limit {
+				break
+			}
+		}
+	}
+	reply.EndUTXOID = start
+	return nil
+}
+
 // GetBalanceArgs are arguments for passing into GetBalance requests
 type GetBalanceArgs struct {
 	Address        string `json:"address"`
@@ -383,7 +978,7 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 		if !args.IncludePartial && (len(owners.Addrs) != 1 || owners.Locktime > now) {
 			continue
 		}
-		amt, err := safemath.Add64(transferable.Amount(), uint64(reply.Balance))
+		amt, err := safemath.Add(transferable.Amount(), uint64(reply.Balance))
 		if err != nil {
 			return err
 		}
@@ -446,7 +1041,7 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 		assetID := utxo.AssetID()
 		assetIDs.Add(assetID)
 		balance := balances[assetID] // 0 if key doesn't exist
-		balance, err := safemath.Add64(transferable.Amount(), balance)
+		balance, err := safemath.Add(transferable.Amount(), balance)
 		if err != nil {
 			balances[assetID] = math.MaxUint64
 		} else {
@@ -795,15 +1390,12 @@ func (service *Service) ListAddresses(_ *http.Request, args *api.UserPass, respo
 		return user.Close()
 	}
 
-	for _, address := range addresses {
-		addr, err := service.vm.FormatLocalAddress(address)
-		if err != nil {
-			// Drop any potential error closing the database to report the
-			// original error
-			_ = user.Close()
-			return fmt.Errorf("problem formatting address: %w", err)
-		}
-		response.Addresses = append(response.Addresses, addr)
+	response.Addresses, err = service.formatLocalAddresses(addresses)
+	if err != nil {
+		// Drop any potential error closing the database to report the
+		// original error
+		_ = user.Close()
+		return err
 	}
 	return user.Close()
 }
@@ -981,7 +1573,7 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 			assetIDs[output.AssetID] = assetID
 		}
 		currentAmount := amounts[assetID]
-		newAmount, err := safemath.Add64(currentAmount, uint64(output.Amount))
+		newAmount, err := safemath.Add(currentAmount, uint64(output.Amount))
 		if err != nil {
 			return fmt.Errorf("problem calculating required spend amount: %w", err)
 		}
@@ -1012,7 +1604,7 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		amountsWithFee[assetID] = amount
 	}
 
-	amountWithFee, err := safemath.Add64(amounts[service.vm.feeAssetID], service.vm.TxFee)
+	amountWithFee, err := safemath.Add(amounts[service.vm.feeAssetID], service.vm.TxFee)
 	if err != nil {
 		return fmt.Errorf("problem calculating required spend amount: %w", err)
 	}
@@ -1468,7 +2060,7 @@ func (service *Service) Import(_ *http.Request, args *ImportArgs, reply *api.JSO
 			return err
 		}
 		for asset, amount := range localAmountsSpent {
-			newAmount, err := safemath.Add64(amountsSpent[asset], amount)
+			newAmount, err := safemath.Add(amountsSpent[asset], amount)
 			if err != nil {
 				return fmt.Errorf("problem calculating required spend amount: %w", err)
 			}
@@ -1592,7 +2184,7 @@ func (service *Service) Export(_ *http.Request, args *ExportArgs, reply *api.JSO
 
 	amounts := map[ids.ID]uint64{}
 	if assetID == service.vm.feeAssetID {
-		amountWithFee, err := safemath.Add64(uint64(args.Amount), service.vm.TxFee)
+		amountWithFee, err := safemath.Add(uint64(args.Amount), service.vm.TxFee)
 		if err != nil {
 			return fmt.Errorf("problem calculating required spend amount: %w", err)
 		}
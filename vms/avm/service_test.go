@@ -217,6 +217,62 @@ func TestServiceIssueTx(t *testing.T) {
 	}
 }
 
+func TestServiceIssueTxs(t *testing.T) {
+	genesisBytes, vm, s, _, _ := setup(t, true)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	if err := s.IssueTxs(nil, &IssueTxsArgs{}, &IssueTxsReply{}); err != errNoTxs {
+		t.Fatalf("Expected %q, got %q", errNoTxs, err)
+	}
+
+	tx := NewTx(t, genesisBytes, vm)
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Issuing the same tx twice in a batch conflicts, so neither is issued
+	txsArgs := &IssueTxsArgs{
+		Txs:      []string{txStr, txStr},
+		Encoding: formatting.Hex,
+	}
+	txsReply := &IssueTxsReply{}
+	if err := s.IssueTxs(nil, txsArgs, txsReply); err != nil {
+		t.Fatal(err)
+	}
+	expectedResults := []IssueTxResult{
+		{
+			TxID:  tx.ID(),
+			Error: errBatchNotIssued.Error(),
+		},
+		{
+			TxID:  tx.ID(),
+			Error: errConflictsWithBatch.Error(),
+		},
+	}
+	assert.Equal(t, expectedResults, txsReply.Results)
+	assert.Empty(t, vm.txs)
+
+	txsArgs.Txs = []string{txStr}
+	txsReply = &IssueTxsReply{}
+	if err := s.IssueTxs(nil, txsArgs, txsReply); err != nil {
+		t.Fatal(err)
+	}
+	expectedResults = []IssueTxResult{
+		{
+			TxID:   tx.ID(),
+			Issued: true,
+		},
+	}
+	assert.Equal(t, expectedResults, txsReply.Results)
+	assert.Len(t, vm.txs, 1)
+}
+
 func TestServiceGetTxStatus(t *testing.T) {
 	genesisBytes, vm, s, _, _ := setup(t, true)
 	defer func() {
@@ -2051,6 +2107,74 @@ func TestServiceGetUTXOs(t *testing.T) {
 	}
 }
 
+func TestServiceGetUTXOsPagination(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, _ := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// More UTXOs than fit in a single page
+	rawAddr := ids.GenerateTestShortID()
+	numUTXOs := 2*int(maxPageSize) + 1
+	utxoIDs := ids.Set{}
+	for i := 0; i < numUTXOs; i++ {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: vm.ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{rawAddr},
+				},
+			},
+		}
+		utxoID := utxo.InputID()
+		assert.NoError(vm.state.PutUTXO(utxoID, utxo))
+		utxoIDs.Add(utxoID)
+	}
+
+	xAddr, err := vm.FormatLocalAddress(rawAddr)
+	assert.NoError(err)
+
+	// Every UTXO is returned exactly once when paging with the end index of
+	// the previous page
+	fetchedUTXOIDs := ids.Set{}
+	args := &api.GetUTXOsArgs{
+		Addresses: []string{xAddr},
+		Encoding:  formatting.Hex,
+	}
+	for numPages := 0; ; numPages++ {
+		assert.LessOrEqual(numPages, 3)
+
+		reply := &api.GetUTXOsReply{}
+		assert.NoError(s.GetUTXOs(nil, args, reply))
+		if reply.NumFetched == 0 {
+			break
+		}
+		assert.LessOrEqual(int(reply.NumFetched), int(maxPageSize))
+
+		for _, utxoStr := range reply.UTXOs {
+			utxoBytes, err := formatting.Decode(formatting.Hex, utxoStr)
+			assert.NoError(err)
+			utxo := &avax.UTXO{}
+			_, err = vm.parser.Codec().Unmarshal(utxoBytes, utxo)
+			assert.NoError(err)
+
+			utxoID := utxo.InputID()
+			assert.False(fetchedUTXOIDs.Contains(utxoID))
+			fetchedUTXOIDs.Add(utxoID)
+		}
+		args.StartIndex = reply.EndIndex
+	}
+	assert.Equal(utxoIDs, fetchedUTXOIDs)
+}
+
 func TestGetAssetDescription(t *testing.T) {
 	_, vm, s, _, genesisTx := setup(t, true)
 	defer func() {
@@ -2078,6 +2202,275 @@ func TestGetAssetDescription(t *testing.T) {
 	}
 }
 
+func TestGetAssetDescriptions(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	avaxAssetID := genesisTx.ID()
+
+	reply := GetAssetDescriptionsReply{}
+	err := s.GetAssetDescriptions(nil, &GetAssetDescriptionsArgs{
+		AssetIDs: []string{avaxAssetID.String(), "asset1"},
+	}, &reply)
+	assert.NoError(err)
+	assert.Len(reply.Assets, 2)
+	for _, asset := range reply.Assets {
+		assert.Equal(avaxAssetID, asset.AssetID)
+		assert.Equal("AVAX", asset.Name)
+		assert.Equal("SYMB", asset.Symbol)
+		assert.Equal("fungible", asset.Type)
+	}
+
+	err = s.GetAssetDescriptions(nil, &GetAssetDescriptionsArgs{
+		AssetIDs: []string{ids.GenerateTestID().String()},
+	}, &reply)
+	assert.ErrorIs(err, errUnknownAssetID)
+
+	err = s.GetAssetDescriptions(nil, &GetAssetDescriptionsArgs{}, &reply)
+	assert.ErrorIs(err, errNoAssetIDs)
+}
+
+func TestGetNFTs(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	addr := keys[0].PublicKey().Address()
+	addrStr, err := vm.FormatLocalAddress(addr)
+	assert.NoError(err)
+
+	nftAssetID := ids.GenerateTestID()
+	otherNFTAssetID := ids.GenerateTestID()
+	nftUTXOs := make([]*avax.UTXO, 0, 3)
+	for i, assetID := range []ids.ID{nftAssetID, nftAssetID, otherNFTAssetID} {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetID},
+			Out: &nftfx.TransferOutput{
+				GroupID: uint32(i),
+				Payload: []byte{byte(i)},
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+		assert.NoError(vm.state.PutUTXO(utxo.InputID(), utxo))
+		nftUTXOs = append(nftUTXOs, utxo)
+	}
+
+	// Only NFTs are returned, not the genesis AVAX
+	reply := GetNFTsReply{}
+	err = s.GetNFTs(nil, &GetNFTsArgs{
+		Address: addrStr,
+	}, &reply)
+	assert.NoError(err)
+	assert.Len(reply.NFTs, len(nftUTXOs))
+	assert.Equal(ids.Empty, reply.EndUTXOID)
+	for _, nft := range reply.NFTs {
+		assert.NotEqual(genesisTx.ID(), nft.AssetID)
+	}
+
+	// NFTs can be filtered by asset
+	err = s.GetNFTs(nil, &GetNFTsArgs{
+		Address: addrStr,
+		AssetID: otherNFTAssetID.String(),
+	}, &reply)
+	assert.NoError(err)
+	assert.Len(reply.NFTs, 1)
+	assert.Equal(nftUTXOs[2].InputID(), reply.NFTs[0].UTXOID)
+	assert.EqualValues(2, reply.NFTs[0].GroupID)
+	assert.EqualValues([]byte{2}, reply.NFTs[0].Payload)
+
+	// NFTs can be paginated
+	fetched := map[ids.ID]bool{}
+	start := ids.Empty
+	for {
+		err = s.GetNFTs(nil, &GetNFTsArgs{
+			Address:     addrStr,
+			StartUTXOID: start,
+			Limit:       1,
+		}, &reply)
+		assert.NoError(err)
+		for _, nft := range reply.NFTs {
+			fetched[nft.UTXOID] = true
+		}
+		if reply.EndUTXOID == ids.Empty {
+			break
+		}
+		assert.Len(reply.NFTs, 1)
+		start = reply.EndUTXOID
+	}
+	assert.Len(fetched, len(nftUTXOs))
+}
+
+func TestGetUTXOsByID(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	genesisUTXOs := genesisTx.UTXOs()
+	assert.NotEmpty(genesisUTXOs)
+	genesisUTXO := genesisUTXOs[0]
+
+	unknownIndex := &avax.UTXOID{
+		TxID:        genesisTx.ID(),
+		OutputIndex: uint32(len(genesisUTXOs)),
+	}
+	unknownTx := &avax.UTXOID{
+		TxID: ids.GenerateTestID(),
+	}
+
+	args := &api.GetUTXOsByIDArgs{
+		UTXOIDs: []string{
+			genesisUTXO.UTXOID.String(),
+			unknownIndex.String(),
+			unknownTx.String(),
+		},
+		Encoding: formatting.Hex,
+	}
+	reply := api.GetUTXOsByIDReply{}
+	assert.NoError(s.GetUTXOsByID(nil, args, &reply))
+	assert.Len(reply.UTXOs, 3)
+
+	// UTXOs are returned in the requested order
+	assert.Equal(genesisUTXO.UTXOID.String(), reply.UTXOs[0].UTXOID)
+	assert.Equal(api.UTXOUnspent, reply.UTXOs[0].Status)
+	utxoBytes, err := formatting.Decode(reply.Encoding, reply.UTXOs[0].UTXO)
+	assert.NoError(err)
+	expectedBytes, err := vm.parser.Codec().Marshal(txs.CodecVersion, genesisUTXO)
+	assert.NoError(err)
+	assert.Equal(expectedBytes, utxoBytes)
+
+	assert.Equal(api.UTXOUnknown, reply.UTXOs[1].Status)
+	assert.Empty(reply.UTXOs[1].UTXO)
+	assert.Equal(api.UTXOUnknown, reply.UTXOs[2].Status)
+	assert.Empty(reply.UTXOs[2].UTXO)
+
+	// Once consumed, a UTXO produced by an accepted tx is reported as spent
+	assert.NoError(vm.state.DeleteUTXO(genesisUTXO.InputID()))
+	assert.NoError(s.GetUTXOsByID(nil, args, &reply))
+	assert.Equal(api.UTXOSpent, reply.UTXOs[0].Status)
+	assert.Empty(reply.UTXOs[0].UTXO)
+
+	// Malformed UTXO IDs are rejected
+	err = s.GetUTXOsByID(nil, &api.GetUTXOsByIDArgs{
+		UTXOIDs: []string{genesisTx.ID().String()},
+	}, &reply)
+	assert.Error(err)
+
+	err = s.GetUTXOsByID(nil, &api.GetUTXOsByIDArgs{}, &reply)
+	assert.ErrorIs(err, errNoUTXOIDs)
+}
+
+func TestGetSignatureStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Create a 2-of-3 multisig UTXO
+	owners, err := secp256k1fx.NewOutputOwners(0, 2, []ids.ShortID{
+		keys[0].PublicKey().Address(),
+		keys[1].PublicKey().Address(),
+		keys[2].PublicKey().Address(),
+	})
+	assert.NoError(err)
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: genesisTx.ID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          startBalance,
+			OutputOwners: *owners,
+		},
+	}
+	assert.NoError(vm.state.PutUTXO(utxo.InputID(), utxo))
+
+	keysByAddr := map[ids.ShortID]*crypto.PrivateKeySECP256K1R{}
+	for _, key := range keys {
+		keysByAddr[key.PublicKey().Address()] = key
+	}
+	signers := []*crypto.PrivateKeySECP256K1R{
+		keysByAddr[owners.Addrs[0]],
+		keysByAddr[owners.Addrs[1]],
+	}
+	signer0Addr, err := vm.FormatLocalAddress(owners.Addrs[0])
+	assert.NoError(err)
+	signer1Addr, err := vm.FormatLocalAddress(owners.Addrs[1])
+	assert.NoError(err)
+
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+		Ins: []*avax.TransferableInput{{
+			UTXOID: utxo.UTXOID,
+			Asset:  utxo.Asset,
+			In: &secp256k1fx.TransferInput{
+				Amt: startBalance,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0, 1},
+				},
+			},
+		}},
+	}}}
+	codec := vm.parser.Codec()
+	assert.NoError(tx.SignSECP256K1Fx(codec, [][]*crypto.PrivateKeySECP256K1R{signers}))
+
+	getStatus := func(tx *txs.Tx) *GetSignatureStatusReply {
+		txBytes, err := codec.Marshal(txs.CodecVersion, tx)
+		assert.NoError(err)
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		assert.NoError(err)
+
+		reply := &GetSignatureStatusReply{}
+		assert.NoError(s.GetSignatureStatus(nil, &GetSignatureStatusArgs{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply))
+		return reply
+	}
+
+	// Fully signed
+	reply := getStatus(tx)
+	assert.True(reply.Complete)
+	assert.Len(reply.Inputs, 1)
+	assert.Equal(utxo.UTXOID.String(), reply.Inputs[0].UTXOID)
+	assert.EqualValues(2, reply.Inputs[0].Threshold)
+	assert.Equal([]string{signer0Addr, signer1Addr}, reply.Inputs[0].Signed)
+	assert.Empty(reply.Inputs[0].Missing)
+
+	// Partially signed
+	cred := tx.Creds[0].Verifiable.(*secp256k1fx.Credential)
+	cred.Sigs[1] = [crypto.SECP256K1RSigLen]byte{}
+	reply = getStatus(tx)
+	assert.False(reply.Complete)
+	assert.Equal([]string{signer0Addr}, reply.Inputs[0].Signed)
+	assert.Equal([]string{signer1Addr}, reply.Inputs[0].Missing)
+
+	// Unsigned
+	tx.Creds = nil
+	reply = getStatus(tx)
+	assert.False(reply.Complete)
+	assert.Empty(reply.Inputs[0].Signed)
+	assert.Equal([]string{signer0Addr, signer1Addr}, reply.Inputs[0].Missing)
+}
+
 func TestGetBalance(t *testing.T) {
 	_, vm, s, _, genesisTx := setup(t, true)
 	defer func() {
@@ -2733,3 +3126,67 @@ func TestImport(t *testing.T) {
 		})
 	}
 }
+
+func TestEstimateFee(t *testing.T) {
+	assert := assert.New(t)
+
+	_, vm, s, _, _ := setup(t, true)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	vm.TxFee = 1000
+	vm.CreateAssetTxFee = 2000
+	codec := vm.parser.Codec()
+
+	estimateFee := func(txIntf interface{}) (*api.EstimateFeeReply, error) {
+		txBytes, err := codec.Marshal(txs.CodecVersion, txIntf)
+		assert.NoError(err)
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		assert.NoError(err)
+
+		reply := &api.EstimateFeeReply{}
+		err = s.EstimateFee(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply)
+		return reply, err
+	}
+
+	baseTx := avax.BaseTx{
+		NetworkID:    networkID,
+		BlockchainID: chainID,
+	}
+
+	// Unsigned txs
+	var unsignedTx txs.UnsignedTx = &txs.BaseTx{BaseTx: baseTx}
+	reply, err := estimateFee(&unsignedTx)
+	assert.NoError(err)
+	assert.EqualValues(1000, reply.Fee)
+	assert.Equal(vm.feeAssetID, reply.AssetID)
+
+	unsignedTx = &txs.CreateAssetTx{
+		BaseTx:       txs.BaseTx{BaseTx: baseTx},
+		Name:         "Team Rocket",
+		Symbol:       "TR",
+		Denomination: 0,
+	}
+	reply, err = estimateFee(&unsignedTx)
+	assert.NoError(err)
+	assert.EqualValues(2000, reply.Fee)
+
+	// Signed txs
+	tx := &txs.Tx{Unsigned: &txs.ExportTx{
+		BaseTx:           txs.BaseTx{BaseTx: baseTx},
+		DestinationChain: constants.PlatformChainID,
+	}}
+	assert.NoError(tx.SignSECP256K1Fx(codec, nil))
+	reply, err = estimateFee(tx)
+	assert.NoError(err)
+	assert.EqualValues(1000, reply.Fee)
+
+	// Malformed txs
+	_, err = estimateFee(&avax.UTXOID{})
+	assert.Error(err)
+}
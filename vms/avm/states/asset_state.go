@@ -0,0 +1,211 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/cache/metercacher"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	assetCacheSize = 1024
+
+	// FungibleAsset is an asset whose initial state holds secp256k1fx outputs
+	FungibleAsset = "fungible"
+	// NFTAsset is an asset whose initial state holds nftfx outputs
+	NFTAsset = "nft"
+	// PropertyAsset is an asset whose initial state holds propertyfx outputs
+	PropertyAsset = "property"
+	// UnknownAsset is an asset whose initial state doesn't hold any outputs
+	// of a known fx
+	UnknownAsset = "unknown"
+)
+
+var (
+	assetPrefix = []byte("asset")
+
+	assetsIndexedKey = []byte("assets indexed")
+
+	_ AssetState = &assetState{}
+)
+
+// Asset is the metadata of an asset created on this chain
+type Asset struct {
+	Name         string `serialize:"true"`
+	Symbol       string `serialize:"true"`
+	Denomination byte   `serialize:"true"`
+	Type         string `serialize:"true"`
+}
+
+// NewAsset returns the metadata of the asset created by [tx]
+func NewAsset(tx *txs.CreateAssetTx) *Asset {
+	return &Asset{
+		Name:         tx.Name,
+		Symbol:       tx.Symbol,
+		Denomination: tx.Denomination,
+		Type:         assetType(tx),
+	}
+}
+
+// assetType classifies the asset created by [tx] by the outputs in its
+// initial state
+func assetType(tx *txs.CreateAssetTx) string {
+	for _, state := range tx.States {
+		for _, out := range state.Outs {
+			switch out.(type) {
+			case *secp256k1fx.TransferOutput, *secp256k1fx.MintOutput:
+				return FungibleAsset
+			case *nftfx.TransferOutput, *nftfx.MintOutput:
+				return NFTAsset
+			case *propertyfx.OwnedOutput, *propertyfx.MintOutput:
+				return PropertyAsset
+			}
+		}
+	}
+	return UnknownAsset
+}
+
+// AssetState indexes the assets created on this chain
+type AssetState interface {
+	// GetAsset returns the metadata of the asset [assetID]
+	GetAsset(assetID ids.ID) (*Asset, error)
+
+	// PutAsset saves the metadata of the asset [assetID]
+	PutAsset(assetID ids.ID, asset *Asset) error
+
+	// IsAssetIndexed returns true if the asset index has been built
+	IsAssetIndexed() (bool, error)
+
+	// IndexAssets adds the assets created by the stored, accepted
+	// transactions to the asset index. This only needs to be done once, to
+	// populate the index with assets that were created before the asset index
+	// existed.
+	IndexAssets() error
+}
+
+type assetState struct {
+	parser txs.Parser
+
+	// Caches assetID -> *Asset. If the *Asset is nil, the asset isn't in
+	// storage.
+	assetCache cache.Cacher
+	assetDB    database.Database
+
+	// txID -> tx bytes, as stored by the TxState
+	txDB        database.Database
+	statuses    avax.StatusState
+	singletonDB database.Database
+}
+
+func newAssetState(
+	assetDB database.Database,
+	txDB database.Database,
+	statuses avax.StatusState,
+	singletonDB database.Database,
+	parser txs.Parser,
+	metrics prometheus.Registerer,
+) (*assetState, error) {
+	cache, err := metercacher.New(
+		"asset_cache",
+		metrics,
+		&cache.LRU{Size: assetCacheSize},
+	)
+	return &assetState{
+		parser: parser,
+
+		assetCache: cache,
+		assetDB:    assetDB,
+
+		txDB:        txDB,
+		statuses:    statuses,
+		singletonDB: singletonDB,
+	}, err
+}
+
+func (s *assetState) GetAsset(assetID ids.ID) (*Asset, error) {
+	if assetIntf, found := s.assetCache.Get(assetID); found {
+		if assetIntf == nil {
+			return nil, database.ErrNotFound
+		}
+		return assetIntf.(*Asset), nil
+	}
+
+	assetBytes, err := s.assetDB.Get(assetID[:])
+	if err == database.ErrNotFound {
+		s.assetCache.Put(assetID, nil)
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	asset := &Asset{}
+	if _, err := s.parser.Codec().Unmarshal(assetBytes, asset); err != nil {
+		return nil, err
+	}
+
+	s.assetCache.Put(assetID, asset)
+	return asset, nil
+}
+
+func (s *assetState) PutAsset(assetID ids.ID, asset *Asset) error {
+	assetBytes, err := s.parser.Codec().Marshal(txs.CodecVersion, asset)
+	if err != nil {
+		return err
+	}
+
+	s.assetCache.Put(assetID, asset)
+	return s.assetDB.Put(assetID[:], assetBytes)
+}
+
+func (s *assetState) IsAssetIndexed() (bool, error) {
+	return s.singletonDB.Has(assetsIndexedKey)
+}
+
+func (s *assetState) IndexAssets() error {
+	iter := s.txDB.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		tx, err := s.parser.ParseGenesis(iter.Value())
+		if err != nil {
+			return err
+		}
+		createAssetTx, ok := tx.Unsigned.(*txs.CreateAssetTx)
+		if !ok {
+			continue
+		}
+
+		// Only the assets of accepted transactions exist
+		txID := tx.ID()
+		status, err := s.statuses.GetStatus(txID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if status != choices.Accepted {
+			continue
+		}
+
+		if err := s.PutAsset(txID, NewAsset(createAssetTx)); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return s.singletonDB.Put(assetsIndexedKey, nil)
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package states
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newTestCreateAssetTx(t *testing.T, parser txs.Parser, name string, out verify.State) *txs.Tx {
+	tx := &txs.Tx{Unsigned: &txs.CreateAssetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: chainID,
+		}},
+		Name:         name,
+		Symbol:       "SYMB",
+		Denomination: 9,
+		States: []*txs.InitialState{{
+			FxIndex: 0,
+			Outs:    []verify.State{out},
+		}},
+	}}
+	assert.NoError(t, parser.InitializeGenesisTx(tx))
+	return tx
+}
+
+func TestNewAsset(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		out          verify.State
+		expectedType string
+	}{
+		{&secp256k1fx.TransferOutput{}, FungibleAsset},
+		{&secp256k1fx.MintOutput{}, FungibleAsset},
+		{&nftfx.TransferOutput{}, NFTAsset},
+		{&nftfx.MintOutput{}, NFTAsset},
+	}
+	for _, test := range tests {
+		tx := &txs.CreateAssetTx{
+			Name:         "name",
+			Symbol:       "SYMB",
+			Denomination: 9,
+			States: []*txs.InitialState{{
+				Outs: []verify.State{test.out},
+			}},
+		}
+		asset := NewAsset(tx)
+		assert.Equal("name", asset.Name)
+		assert.Equal("SYMB", asset.Symbol)
+		assert.Equal(byte(9), asset.Denomination)
+		assert.Equal(test.expectedType, asset.Type)
+	}
+
+	assert.Equal(UnknownAsset, NewAsset(&txs.CreateAssetTx{}).Type)
+}
+
+func TestAssetState(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := txs.NewParser([]fxs.Fx{&secp256k1fx.Fx{}})
+	assert.NoError(err)
+
+	db := memdb.New()
+	s, err := New(db, parser, prometheus.NewRegistry())
+	assert.NoError(err)
+
+	_, err = s.GetAsset(assetID)
+	assert.Equal(database.ErrNotFound, err)
+
+	asset := &Asset{
+		Name:         "name",
+		Symbol:       "SYMB",
+		Denomination: 9,
+		Type:         FungibleAsset,
+	}
+	assert.NoError(s.PutAsset(assetID, asset))
+
+	fetched, err := s.GetAsset(assetID)
+	assert.NoError(err)
+	assert.Equal(asset, fetched)
+
+	// The asset is persisted
+	s, err = New(db, parser, prometheus.NewRegistry())
+	assert.NoError(err)
+
+	fetched, err = s.GetAsset(assetID)
+	assert.NoError(err)
+	assert.Equal(asset, fetched)
+}
+
+func TestIndexAssets(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := txs.NewParser([]fxs.Fx{&secp256k1fx.Fx{}, &nftfx.Fx{}})
+	assert.NoError(err)
+
+	s, err := New(memdb.New(), parser, prometheus.NewRegistry())
+	assert.NoError(err)
+
+	acceptedTx := newTestCreateAssetTx(t, parser, "accepted", &secp256k1fx.MintOutput{})
+	processingTx := newTestCreateAssetTx(t, parser, "processing", &secp256k1fx.MintOutput{})
+	for _, tx := range []*txs.Tx{acceptedTx, processingTx} {
+		assert.NoError(s.PutTx(tx.ID(), tx))
+	}
+	assert.NoError(s.PutStatus(acceptedTx.ID(), choices.Accepted))
+	assert.NoError(s.PutStatus(processingTx.ID(), choices.Processing))
+
+	indexed, err := s.IsAssetIndexed()
+	assert.NoError(err)
+	assert.False(indexed)
+
+	assert.NoError(s.IndexAssets())
+
+	indexed, err = s.IsAssetIndexed()
+	assert.NoError(err)
+	assert.True(indexed)
+
+	asset, err := s.GetAsset(acceptedTx.ID())
+	assert.NoError(err)
+	assert.Equal("accepted", asset.Name)
+	assert.Equal(FungibleAsset, asset.Type)
+
+	_, err = s.GetAsset(processingTx.ID())
+	assert.Equal(database.ErrNotFound, err)
+
+	_, err = s.GetAsset(ids.GenerateTestID())
+	assert.Equal(database.ErrNotFound, err)
+}
@@ -25,6 +25,7 @@ var (
 // singletons.
 type State interface {
 	avax.UTXOState
+	AssetState
 	avax.StatusState
 	avax.SingletonState
 	TxState
@@ -32,6 +33,7 @@ type State interface {
 
 type state struct {
 	avax.UTXOState
+	*assetState
 	avax.StatusState
 	avax.SingletonState
 	TxState
@@ -53,9 +55,22 @@ func New(db database.Database, parser txs.Parser, metrics prometheus.Registerer)
 		return nil, err
 	}
 
+	assetState, err := newAssetState(
+		prefixdb.New(assetPrefix, db),
+		txDB,
+		statusState,
+		singletonDB,
+		parser,
+		metrics,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	txState, err := NewTxState(txDB, parser, metrics)
 	return &state{
 		UTXOState:      utxoState,
+		assetState:     assetState,
 		StatusState:    statusState,
 		SingletonState: avax.NewSingletonState(singletonDB),
 		TxState:        txState,
@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import "github.com/ava-labs/avalanchego/vms/avm/txs"
+
+var _ txs.Visitor = &txFeeCalculator{}
+
+// txFeeCalculator calculates the fee that must be burned by a tx
+type txFeeCalculator struct {
+	// inputs
+	vm *VM
+
+	// outputs
+	fee uint64
+}
+
+func (f *txFeeCalculator) BaseTx(*txs.BaseTx) error {
+	f.fee = f.vm.TxFee
+	return nil
+}
+
+func (f *txFeeCalculator) CreateAssetTx(*txs.CreateAssetTx) error {
+	f.fee = f.vm.CreateAssetTxFee
+	return nil
+}
+
+func (f *txFeeCalculator) OperationTx(*txs.OperationTx) error {
+	f.fee = f.vm.TxFee
+	return nil
+}
+
+func (f *txFeeCalculator) ImportTx(*txs.ImportTx) error {
+	f.fee = f.vm.TxFee
+	return nil
+}
+
+func (f *txFeeCalculator) ExportTx(*txs.ExportTx) error {
+	f.fee = f.vm.TxFee
+	return nil
+}
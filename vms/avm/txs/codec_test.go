@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/codec/codecfuzz"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func FuzzCodec(f *testing.F) {
+	parser, err := NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	codecfuzz.Fuzz(f, parser.Codec(), reflect.TypeOf(&Tx{}))
+}
+
+func FuzzGenesisCodec(f *testing.F) {
+	parser, err := NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	codecfuzz.Fuzz(f, parser.GenesisCodec(), reflect.TypeOf(&Tx{}))
+}
@@ -7,10 +7,13 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/pubsub"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
+	"github.com/ava-labs/avalanchego/vms/avm/states"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 )
@@ -166,6 +169,13 @@ func (tx *UniqueTx) Accept() error {
 		}
 	}
 
+	// Index the metadata of newly created assets
+	if createAssetTx, ok := tx.Tx.Unsigned.(*txs.CreateAssetTx); ok {
+		if err := tx.vm.state.PutAsset(txID, states.NewAsset(createAssetTx)); err != nil {
+			return fmt.Errorf("couldn't index asset %s: %w", txID, err)
+		}
+	}
+
 	if err := tx.setStatus(choices.Accepted); err != nil {
 		return fmt.Errorf("couldn't set status of tx %s: %w", txID, err)
 	}
@@ -186,6 +196,8 @@ func (tx *UniqueTx) Accept() error {
 	}
 
 	tx.vm.pubsub.Publish(NewPubSubFilterer(tx.Tx))
+	tx.vm.pubsub.Broadcast(pubsub.AcceptedTxs, api.JSONTxID{TxID: txID})
+	tx.vm.pubsub.PublishTopic(pubsub.UTXOs, NewUTXOFilterer(tx.Tx, inputUTXOs))
 	tx.vm.walletService.decided(txID)
 
 	tx.deps = nil // Needed to prevent a memory leak
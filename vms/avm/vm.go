@@ -12,8 +12,6 @@ import (
 
 	stdjson "encoding/json"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
@@ -39,6 +37,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
@@ -57,9 +56,13 @@ var (
 	errUnknownFx                 = errors.New("unknown feature extension")
 	errGenesisAssetMustHaveState = errors.New("genesis asset must have non-empty state")
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
+	errBatchNotIssued            = errors.New("not issued because another tx in the batch is invalid")
+	errConflictsWithBatch        = errors.New("tx conflicts with an earlier tx in the batch")
 	errInsufficientFunds         = errors.New("insufficient funds")
 
-	_ vertex.DAGVM = &VM{}
+	_ vertex.DAGVM               = &VM{}
+	_ vertex.AddressIndexedDAGVM = &VM{}
+	_ common.ConfigChecker       = &VM{}
 )
 
 type VM struct {
@@ -166,7 +169,7 @@ func (vm *VM) Initialize(
 	vm.db = versiondb.New(db)
 	vm.assetToFxCache = &cache.LRU{Size: assetToFxCacheSize}
 
-	vm.pubsub = pubsub.New(ctx.NetworkID, ctx.Log)
+	vm.pubsub = pubsub.New(ctx.NetworkID, ctx.Log, pubsub.AcceptedTxs, pubsub.UTXOs)
 
 	typedFxs := make([]extensions.Fx, len(fxs))
 	vm.fxs = make([]*extensions.ParsedFx, len(fxs))
@@ -205,6 +208,17 @@ func (vm *VM) Initialize(
 
 	vm.state = state
 
+	indexed, err := vm.state.IsAssetIndexed()
+	if err != nil {
+		return err
+	}
+	if !indexed {
+		ctx.Log.Info("indexing assets")
+		if err := vm.state.IndexAssets(); err != nil {
+			return fmt.Errorf("failed to index assets: %w", err)
+		}
+	}
+
 	if err := vm.initGenesis(genesisBytes); err != nil {
 		return err
 	}
@@ -294,7 +308,7 @@ func (vm *VM) Version() (string, error) {
 func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 	codec := json.NewCodec()
 
-	rpcServer := rpc.NewServer()
+	rpcServer := json.NewServer()
 	rpcServer.RegisterCodec(codec, "application/json")
 	rpcServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	rpcServer.RegisterInterceptFunc(vm.metrics.apiRequestMetric.InterceptRequest)
@@ -304,7 +318,7 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 		return nil, err
 	}
 
-	walletServer := rpc.NewServer()
+	walletServer := json.NewServer()
 	walletServer.RegisterCodec(codec, "application/json")
 	walletServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	walletServer.RegisterInterceptFunc(vm.metrics.apiRequestMetric.InterceptRequest)
@@ -320,7 +334,7 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 }
 
 func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := json.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -354,6 +368,47 @@ func (vm *VM) GetTx(txID ids.ID) (snowstorm.Tx, error) {
 	return tx, tx.verifyWithoutCacheWrites()
 }
 
+// TxAddresses returns the addresses that own an output produced or consumed by
+// the tx. Outputs imported from another chain aren't included, as they aren't
+// stored in this chain's state.
+func (vm *VM) TxAddresses(txBytes []byte) ([]ids.ShortID, error) {
+	tx, err := vm.parser.Parse(txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	outs := []verify.State(nil)
+	for _, utxoID := range tx.Unsigned.InputUTXOs() {
+		if utxoID.Symbolic() {
+			continue
+		}
+		utxo, err := vm.getUTXO(utxoID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get UTXO %s: %w", utxoID.InputID(), err)
+		}
+		outs = append(outs, utxo.Out)
+	}
+	for _, utxo := range tx.UTXOs() {
+		outs = append(outs, utxo.Out)
+	}
+
+	addrs := ids.ShortSet{}
+	for _, out := range outs {
+		addressable, ok := out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+		for _, addrBytes := range addressable.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				return nil, err
+			}
+			addrs.Add(addr)
+		}
+	}
+	return addrs.List(), nil
+}
+
 /*
  ******************************************************************************
  ********************************** JSON API **********************************
@@ -379,6 +434,65 @@ func (vm *VM) IssueTx(b []byte) (ids.ID, error) {
 	return tx.ID(), nil
 }
 
+// IssueTxs attempts to issue [txsBytes] into consensus, in order. Either all of
+// the txs are issued or none of them are. A tx may spend the outputs of an
+// earlier tx in the batch, but may not conflict with it. The returned slices
+// contain the ID of, and the reason for not issuing, each tx. If a tx can't be
+// parsed, its ID is empty.
+func (vm *VM) IssueTxs(txsBytes [][]byte) ([]ids.ID, []error, error) {
+	if !vm.bootstrapped {
+		return nil, nil, errBootstrapping
+	}
+
+	var (
+		txs      = make([]*UniqueTx, len(txsBytes))
+		txIDs    = make([]ids.ID, len(txsBytes))
+		txErrs   = make([]error, len(txsBytes))
+		consumed = ids.Set{}
+		valid    = true
+	)
+	for i, txBytes := range txsBytes {
+		tx, err := vm.parseTx(txBytes)
+		if err != nil {
+			txErrs[i] = err
+			valid = false
+			continue
+		}
+		txs[i] = tx
+		txIDs[i] = tx.ID()
+
+		if err := tx.verifyWithoutCacheWrites(); err != nil {
+			txErrs[i] = err
+			valid = false
+			continue
+		}
+
+		inputIDs := tx.InputIDs()
+		for _, inputID := range inputIDs {
+			if consumed.Contains(inputID) {
+				txErrs[i] = errConflictsWithBatch
+				valid = false
+				break
+			}
+		}
+		consumed.Add(inputIDs...)
+	}
+
+	if !valid {
+		for i, err := range txErrs {
+			if err == nil {
+				txErrs[i] = errBatchNotIssued
+			}
+		}
+		return txIDs, txErrs, nil
+	}
+
+	for _, tx := range txs {
+		vm.issueTx(tx)
+	}
+	return txIDs, txErrs, nil
+}
+
 func (vm *VM) issueStopVertex() error {
 	select {
 	case vm.toEngine <- common.StopVertex:
@@ -413,6 +527,44 @@ func (vm *VM) FlushTxs() {
  ******************************************************************************
  */
 
+// CheckConfig parses [genesisBytes] and [configBytes] the way Initialize does.
+// The fxs of the chain aren't known before it's created, so the genesis is
+// parsed with the fxs of the X-chain.
+func (vm *VM) CheckConfig(genesisBytes []byte, configBytes []byte) error {
+	if len(configBytes) > 0 {
+		if err := stdjson.Unmarshal(configBytes, &Config{}); err != nil {
+			return err
+		}
+	}
+
+	parser, err := txs.NewParser([]extensions.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	})
+	if err != nil {
+		return err
+	}
+
+	genesis := Genesis{}
+	if _, err := parser.GenesisCodec().Unmarshal(genesisBytes, &genesis); err != nil {
+		return err
+	}
+	for _, genesisTx := range genesis.Txs {
+		if len(genesisTx.Outs) != 0 {
+			return errGenesisAssetMustHaveState
+		}
+
+		tx := txs.Tx{
+			Unsigned: &genesisTx.CreateAssetTx,
+		}
+		if err := parser.InitializeGenesisTx(&tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (vm *VM) initGenesis(genesisBytes []byte) error {
 	genesisCodec := vm.parser.GenesisCodec()
 	genesis := Genesis{}
@@ -472,6 +624,11 @@ func (vm *VM) initState(tx txs.Tx) error {
 	if err := vm.state.PutStatus(txID, choices.Accepted); err != nil {
 		return err
 	}
+	if createAssetTx, ok := tx.Unsigned.(*txs.CreateAssetTx); ok {
+		if err := vm.state.PutAsset(txID, states.NewAsset(createAssetTx)); err != nil {
+			return err
+		}
+	}
 	for _, utxo := range tx.UTXOs() {
 		if err := vm.state.PutUTXO(utxo.InputID(), utxo); err != nil {
 			return err
@@ -714,7 +871,7 @@ func (vm *VM) Spend(
 			// this input doesn't have an amount, so I don't care about it here
 			continue
 		}
-		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
+		newAmountSpent, err := safemath.Add(amountSpent, input.Amount())
 		if err != nil {
 			// there was an error calculating the consumed amount, just error
 			return nil, nil, nil, errSpendOverflow
@@ -847,7 +1004,7 @@ func (vm *VM) SpendAll(
 			// this input doesn't have an amount, so I don't care about it here
 			continue
 		}
-		newAmountSpent, err := safemath.Add64(amountSpent, input.Amount())
+		newAmountSpent, err := safemath.Add(amountSpent, input.Amount())
 		if err != nil {
 			// there was an error calculating the consumed amount, just error
 			return nil, nil, nil, errSpendOverflow
@@ -474,6 +474,17 @@ func TestInvalidGenesis(t *testing.T) {
 	}
 }
 
+func TestCheckConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	vm := &VM{}
+	genesisBytes := BuildGenesisTest(t)
+	assert.NoError(vm.CheckConfig(genesisBytes, nil))
+	assert.NoError(vm.CheckConfig(genesisBytes, []byte(`{"index-transactions": true}`)))
+	assert.Error(vm.CheckConfig(genesisBytes, []byte(`{"index-transactions": "yes"}`)))
+	assert.Error(vm.CheckConfig(nil, nil))
+}
+
 func TestInvalidFx(t *testing.T) {
 	vm := &VM{}
 	ctx := NewContext(t)
@@ -537,6 +548,32 @@ func TestFxInitializationFailure(t *testing.T) {
 	}
 }
 
+func TestTxAddresses(t *testing.T) {
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	newTx := NewTx(t, genesisBytes, vm)
+
+	addrs, err := vm.TxAddresses(newTx.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedAddr := keys[0].PublicKey().Address()
+	if len(addrs) != 1 || addrs[0] != expectedAddr {
+		t.Fatalf("expected addresses [%s] but got %v", expectedAddr, addrs)
+	}
+
+	if _, err := vm.TxAddresses([]byte{1, 2, 3}); err == nil {
+		t.Fatal("should have failed to get addresses of invalid tx")
+	}
+}
+
 func TestIssueTx(t *testing.T) {
 	genesisBytes, issuer, vm, _ := GenesisVM(t)
 	ctx := vm.ctx
@@ -29,6 +29,10 @@ type AddressManager interface {
 	// FormatAddress takes in a chainID and a raw address and produces the
 	// formatted address for that chain
 	FormatAddress(chainID ids.ID, addr ids.ShortID) (string, error)
+
+	// FormatLocalAddresses takes in raw addresses and produces the formatted
+	// addresses for this chain
+	FormatLocalAddresses(addrs []ids.ShortID) ([]string, error)
 }
 
 type addressManager struct {
@@ -57,7 +61,8 @@ func (a *addressManager) ParseLocalAddress(addrStr string) (ids.ShortID, error)
 }
 
 func (a *addressManager) ParseAddress(addrStr string) (ids.ID, ids.ShortID, error) {
-	chainIDAlias, hrp, addrBytes, err := address.Parse(addrStr)
+	hrp := constants.GetHRP(a.ctx.NetworkID)
+	chainIDAlias, addrBytes, err := address.ParseWithHRP(addrStr, hrp)
 	if err != nil {
 		return ids.ID{}, ids.ShortID{}, err
 	}
@@ -67,15 +72,6 @@ func (a *addressManager) ParseAddress(addrStr string) (ids.ID, ids.ShortID, erro
 		return ids.ID{}, ids.ShortID{}, err
 	}
 
-	expectedHRP := constants.GetHRP(a.ctx.NetworkID)
-	if hrp != expectedHRP {
-		return ids.ID{}, ids.ShortID{}, fmt.Errorf(
-			"expected hrp %q but got %q",
-			expectedHRP,
-			hrp,
-		)
-	}
-
 	addr, err := ids.ToShortID(addrBytes)
 	if err != nil {
 		return ids.ID{}, ids.ShortID{}, err
@@ -96,6 +92,19 @@ func (a *addressManager) FormatAddress(chainID ids.ID, addr ids.ShortID) (string
 	return address.Format(chainIDAlias, hrp, addr.Bytes())
 }
 
+func (a *addressManager) FormatLocalAddresses(addrs []ids.ShortID) ([]string, error) {
+	chainIDAlias, err := a.ctx.BCLookup.PrimaryAlias(a.ctx.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	addrBytes := make([][]byte, len(addrs))
+	for i := range addrs {
+		addrBytes[i] = addrs[i][:]
+	}
+	hrp := constants.GetHRP(a.ctx.NetworkID)
+	return address.FormatBatch(chainIDAlias, hrp, addrBytes)
+}
+
 func ParseLocalAddresses(a AddressManager, addrStrs []string) (ids.ShortSet, error) {
 	addrs := make(ids.ShortSet, len(addrStrs))
 	for _, addrStr := range addrStrs {
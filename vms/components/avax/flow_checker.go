@@ -31,7 +31,7 @@ func (fc *FlowChecker) Produce(assetID ids.ID, amount uint64) { fc.add(fc.produc
 
 func (fc *FlowChecker) add(value map[ids.ID]uint64, assetID ids.ID, amount uint64) {
 	var err error
-	value[assetID], err = math.Add64(value[assetID], amount)
+	value[assetID], err = math.Add(value[assetID], amount)
 	fc.errs.Add(err)
 }
 
@@ -149,28 +149,18 @@ func (in *TransferableInput) Verify() error {
 	}
 }
 
-type innerSortTransferableInputs []*TransferableInput
-
-func (ins innerSortTransferableInputs) Less(i, j int) bool {
-	iID, iIndex := ins[i].InputSource()
-	jID, jIndex := ins[j].InputSource()
-
-	switch bytes.Compare(iID[:], jID[:]) {
-	case -1:
-		return true
-	case 0:
-		return iIndex < jIndex
-	default:
-		return false
-	}
+func lessTransferableInput(a, b *TransferableInput) bool {
+	return a.UTXOID.Less(&b.UTXOID)
 }
-func (ins innerSortTransferableInputs) Len() int      { return len(ins) }
-func (ins innerSortTransferableInputs) Swap(i, j int) { ins[j], ins[i] = ins[i], ins[j] }
 
-func SortTransferableInputs(ins []*TransferableInput) { sort.Sort(innerSortTransferableInputs(ins)) }
+func SortTransferableInputs(ins []*TransferableInput) {
+	sort.Slice(ins, func(i, j int) bool {
+		return lessTransferableInput(ins[i], ins[j])
+	})
+}
 
 func IsSortedAndUniqueTransferableInputs(ins []*TransferableInput) bool {
-	return utils.IsSortedAndUnique(innerSortTransferableInputs(ins))
+	return utils.IsSortedAndUniqueFunc(ins, lessTransferableInput)
 }
 
 type innerSortTransferableInputsWithSigners struct {
@@ -179,17 +169,7 @@ type innerSortTransferableInputsWithSigners struct {
 }
 
 func (ins *innerSortTransferableInputsWithSigners) Less(i, j int) bool {
-	iID, iIndex := ins.ins[i].InputSource()
-	jID, jIndex := ins.ins[j].InputSource()
-
-	switch bytes.Compare(iID[:], jID[:]) {
-	case -1:
-		return true
-	case 0:
-		return iIndex < jIndex
-	default:
-		return false
-	}
+	return lessTransferableInput(ins.ins[i], ins.ins[j])
 }
 func (ins *innerSortTransferableInputsWithSigners) Len() int { return len(ins.ins) }
 func (ins *innerSortTransferableInputsWithSigners) Swap(i, j int) {
@@ -205,8 +185,8 @@ func SortTransferableInputsWithSigners(ins []*TransferableInput, signers [][]*cr
 
 // IsSortedAndUniqueTransferableInputsWithSigners returns true if the inputs are
 // sorted and unique
-func IsSortedAndUniqueTransferableInputsWithSigners(ins []*TransferableInput, signers [][]*crypto.PrivateKeySECP256K1R) bool {
-	return utils.IsSortedAndUnique(&innerSortTransferableInputsWithSigners{ins: ins, signers: signers})
+func IsSortedAndUniqueTransferableInputsWithSigners(ins []*TransferableInput, _ [][]*crypto.PrivateKeySECP256K1R) bool {
+	return IsSortedAndUniqueTransferableInputs(ins)
 }
 
 // VerifyTx verifies that the inputs and outputs flowcheck, including a fee.
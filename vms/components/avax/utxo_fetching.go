@@ -9,6 +9,7 @@ import (
 	"math"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
@@ -22,7 +23,7 @@ func GetBalance(db UTXOReader, addrs ids.ShortSet) (uint64, error) {
 	balance := uint64(0)
 	for _, utxo := range utxos {
 		if out, ok := utxo.Out.(Amounter); ok {
-			if balance, err = safemath.Add64(out.Amount(), balance); err != nil {
+			if balance, err = safemath.Add(out.Amount(), balance); err != nil {
 				return 0, err
 			}
 		}
@@ -64,9 +65,9 @@ func GetPaginatedUTXOs(
 ) ([]*UTXO, ids.ShortID, ids.ID, error) {
 	var (
 		utxos      []*UTXO
-		seen       ids.Set              // IDs of UTXOs already in the list
-		searchSize = limit              // the limit diminishes which can impact the expected return
-		addrsList  = addrs.SortedList() // enforces the same ordering for pagination
+		seen       ids.Set                 // IDs of UTXOs already in the list
+		searchSize = limit                 // the limit diminishes which can impact the expected return
+		addrsList  = set.SortedList(addrs) // enforces the same ordering for pagination
 	)
 	for _, addr := range addrsList {
 		start := ids.Empty
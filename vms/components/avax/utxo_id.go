@@ -7,7 +7,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
@@ -15,7 +16,8 @@ import (
 )
 
 var (
-	errNilUTXOID = errors.New("nil utxo ID is not valid")
+	errNilUTXOID             = errors.New("nil utxo ID is not valid")
+	errMalformedUTXOIDString = errors.New("unexpected number of tokens in string")
 
 	_ verify.Verifiable = &UTXOID{}
 )
@@ -50,6 +52,29 @@ func (utxo *UTXOID) String() string {
 	return fmt.Sprintf("%s:%d", utxo.TxID, utxo.OutputIndex)
 }
 
+// ParseUTXOID parses a UTXO ID in the format returned by String
+func ParseUTXOID(utxoID string) (*UTXOID, error) {
+	strs := strings.Split(utxoID, ":")
+	if len(strs) != 2 {
+		return nil, errMalformedUTXOIDString
+	}
+
+	txID, err := ids.FromString(strs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	outputIndex, err := strconv.ParseUint(strs[1], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UTXOID{
+		TxID:        txID,
+		OutputIndex: uint32(outputIndex),
+	}, nil
+}
+
 func (utxo *UTXOID) Verify() error {
 	switch {
 	case utxo == nil:
@@ -59,26 +84,23 @@ func (utxo *UTXOID) Verify() error {
 	}
 }
 
-type innerSortUTXOIDs []*UTXOID
-
-func (utxos innerSortUTXOIDs) Less(i, j int) bool {
-	iID, iIndex := utxos[i].InputSource()
-	jID, jIndex := utxos[j].InputSource()
+// Less returns true if [utxo] is ordered before [other] by (TxID, OutputIndex)
+func (utxo *UTXOID) Less(other *UTXOID) bool {
+	utxoID, utxoIndex := utxo.InputSource()
+	otherID, otherIndex := other.InputSource()
 
-	switch bytes.Compare(iID[:], jID[:]) {
+	switch bytes.Compare(utxoID[:], otherID[:]) {
 	case -1:
 		return true
 	case 0:
-		return iIndex < jIndex
+		return utxoIndex < otherIndex
 	default:
 		return false
 	}
 }
-func (utxos innerSortUTXOIDs) Len() int      { return len(utxos) }
-func (utxos innerSortUTXOIDs) Swap(i, j int) { utxos[j], utxos[i] = utxos[i], utxos[j] }
 
-func SortUTXOIDs(utxos []*UTXOID) { sort.Sort(innerSortUTXOIDs(utxos)) }
+func SortUTXOIDs(utxos []*UTXOID) { utils.Sort(utxos) }
 
 func IsSortedAndUniqueUTXOIDs(utxos []*UTXOID) bool {
-	return utils.IsSortedAndUnique(innerSortUTXOIDs(utxos))
+	return utils.IsSortedAndUniqueSortable(utxos)
 }
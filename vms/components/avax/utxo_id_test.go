@@ -58,3 +58,32 @@ func TestUTXOID(t *testing.T) {
 		t.Fatalf("Parsing returned the wrong UTXO ID")
 	}
 }
+
+func TestParseUTXOID(t *testing.T) {
+	utxoID := UTXOID{
+		TxID:        ids.GenerateTestID(),
+		OutputIndex: 0x20212223,
+	}
+
+	parsed, err := ParseUTXOID(utxoID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if utxoID.InputID() != parsed.InputID() {
+		t.Fatalf("Parsing returned the wrong UTXO ID")
+	}
+
+	invalid := []string{
+		"",
+		utxoID.TxID.String(),
+		utxoID.String() + ":0",
+		"notAnID:0",
+		utxoID.TxID.String() + ":notAnIndex",
+		utxoID.TxID.String() + ":4294967296",
+	}
+	for _, str := range invalid {
+		if _, err := ParseUTXOID(str); err == nil {
+			t.Fatalf("Should have errored parsing %q", str)
+		}
+	}
+}
@@ -30,6 +30,7 @@ const (
 type UTXOState interface {
 	UTXOReader
 	UTXOWriter
+	UTXOIterator
 }
 
 // UTXOReader is a thin wrapper around a database to provide fetching of UTXOs.
@@ -49,6 +50,16 @@ type UTXOGetter interface {
 	GetUTXO(utxoID ids.ID) (*UTXO, error)
 }
 
+// UTXOIterator is a thin wrapper around a database to provide iteration over
+// every UTXO in storage.
+type UTXOIterator interface {
+	// AllUTXOIDs returns the IDs of the UTXOs in storage, in order, starting
+	// after [previous].
+	// If [previous] is not in storage, starts at the first ID after it.
+	// Returns at most [limit] IDs.
+	AllUTXOIDs(previous ids.ID, limit int) ([]ids.ID, error)
+}
+
 // UTXOWriter is a thin wrapper around a database to provide storage and
 // deletion of UTXOs.
 type UTXOWriter interface {
@@ -210,6 +221,25 @@ func (s *utxoState) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, err
 	return utxoIDs, iter.Error()
 }
 
+func (s *utxoState) AllUTXOIDs(start ids.ID, limit int) ([]ids.ID, error) {
+	iter := s.utxoDB.NewIteratorWithStart(start[:])
+	defer iter.Release()
+
+	utxoIDs := []ids.ID(nil)
+	for len(utxoIDs) < limit && iter.Next() {
+		utxoID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if utxoID == start {
+			continue
+		}
+
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	return utxoIDs, iter.Error()
+}
+
 func (s *utxoState) getIndexDB(addr []byte) linkeddb.LinkedDB {
 	addrStr := string(addr)
 	if indexList, exists := s.indexCache.Get(addrStr); exists {
@@ -95,4 +95,12 @@ func TestUTXOState(t *testing.T) {
 	utxoIDs, err = s.UTXOIDs(addr[:], ids.Empty, 5)
 	assert.NoError(err)
 	assert.Equal([]ids.ID{utxoID}, utxoIDs)
+
+	utxoIDs, err = s.AllUTXOIDs(ids.Empty, 5)
+	assert.NoError(err)
+	assert.Equal([]ids.ID{utxoID}, utxoIDs)
+
+	utxoIDs, err = s.AllUTXOIDs(utxoID, 5)
+	assert.NoError(err)
+	assert.Empty(utxoIDs)
 }
@@ -5,6 +5,7 @@ package chain
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/cache/metercacher"
@@ -374,3 +375,16 @@ func (s *State) LastAcceptedBlock() *BlockWrapper {
 func (s *State) LastAcceptedBlockInternal() snowman.Block {
 	return s.LastAcceptedBlock().Block
 }
+
+// VerifiedBlocksInternal returns the internal snowman.Blocks that have been
+// verified and are currently in consensus, sorted by height
+func (s *State) VerifiedBlocksInternal() []snowman.Block {
+	blks := make([]snowman.Block, 0, len(s.verifiedBlocks))
+	for _, blk := range s.verifiedBlocks {
+		blks = append(blks, blk.Block)
+	}
+	sort.Slice(blks, func(i, j int) bool {
+		return blks[i].Height() < blks[j].Height()
+	})
+	return blks
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+// Gossiper propagates txs to other nodes with AppGossip
+type Gossiper struct {
+	appSender common.AppSender
+	// IDs of the txs that were recently gossiped or received through gossip
+	recentTxs *cache.LRU
+}
+
+// NewGossiper returns a Gossiper that sends gossip with [appSender]. The IDs of
+// the last [recentCacheSize] txs that were gossiped are remembered so that
+// they aren't gossiped again.
+func NewGossiper(appSender common.AppSender, recentCacheSize int) *Gossiper {
+	return &Gossiper{
+		appSender: appSender,
+		recentTxs: &cache.LRU{Size: recentCacheSize},
+	}
+}
+
+// Gossip sends the bytes of [tx] to other nodes, unless [tx] was recently
+// gossiped or received
+func (g *Gossiper) Gossip(tx Tx) error {
+	txID := tx.ID()
+	if _, ok := g.recentTxs.Get(txID); ok {
+		return nil
+	}
+	g.recentTxs.Put(txID, nil)
+	return g.appSender.SendAppGossip(tx.Bytes())
+}
+
+// Received handles [tx], which was gossiped by another node, by adding it to
+// [mempool] and gossiping it to other nodes. [verify] is called before [tx]
+// is added, unless [tx] was recently gossiped or received, and [tx] is
+// dropped if it returns an error.
+//
+// Returns true if [tx] was added to [mempool].
+func (g *Gossiper) Received(mempool *Mempool, tx Tx, verify func(Tx) error) (bool, error) {
+	txID := tx.ID()
+	if _, ok := g.recentTxs.Get(txID); ok || mempool.Has(txID) {
+		return false, nil
+	}
+	if err := verify(tx); err != nil {
+		return false, err
+	}
+	if err := mempool.Add(tx); err != nil {
+		return false, err
+	}
+	return true, g.Gossip(tx)
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+func TestGossiper(t *testing.T) {
+	assert := assert.New(t)
+
+	sender := &common.SenderTest{T: t}
+	gossiped := [][]byte(nil)
+	sender.SendAppGossipF = func(msg []byte) error {
+		gossiped = append(gossiped, msg)
+		return nil
+	}
+
+	g := NewGossiper(sender, 16)
+	m, err := New(Config{MaxWeight: 1024})
+	assert.NoError(err)
+
+	tx0 := testTx{0}
+	tx1 := testTx{1}
+	tx2 := testTx{2}
+
+	// Txs are only gossiped once
+	assert.NoError(g.Gossip(tx0))
+	assert.NoError(g.Gossip(tx0))
+	assert.Equal([][]byte{tx0}, gossiped)
+
+	// Received txs are added to the mempool and regossiped
+	verify := func(Tx) error { return nil }
+	added, err := g.Received(m, tx1, verify)
+	assert.NoError(err)
+	assert.True(added)
+	assert.True(m.Has(tx1.ID()))
+	assert.Equal([][]byte{tx0, tx1}, gossiped)
+
+	added, err = g.Received(m, tx1, verify)
+	assert.NoError(err)
+	assert.False(added)
+	assert.Equal([][]byte{tx0, tx1}, gossiped)
+
+	// Invalid txs are dropped
+	errInvalid := errors.New("invalid")
+	added, err = g.Received(m, tx2, func(Tx) error { return errInvalid })
+	assert.ErrorIs(err, errInvalid)
+	assert.False(added)
+	assert.False(m.Has(tx2.ID()))
+	assert.Equal([][]byte{tx0, tx1}, gossiped)
+}
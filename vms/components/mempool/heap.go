@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"container/heap"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	_ heap.Interface = &popHeap{}
+	_ heap.Interface = &evictHeap{}
+)
+
+type entry struct {
+	tx       Tx
+	weight   uint64
+	priority uint64
+	inputs   ids.Set
+	// order in which the tx was added to the mempool
+	seq uint64
+
+	popIndex   int
+	evictIndex int
+}
+
+// popHeap orders entries by descending priority, then by ascending seq, so
+// that the highest priority tx that was added first is at the top
+type popHeap []*entry
+
+func (h popHeap) Len() int { return len(h) }
+
+func (h popHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h popHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].popIndex = i
+	h[j].popIndex = j
+}
+
+func (h *popHeap) Push(x interface{}) {
+	e := x.(*entry)
+	e.popIndex = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *popHeap) Pop() interface{} {
+	old := *h
+	n := len(old) - 1
+	e := old[n]
+	old[n] = nil
+	*h = old[:n]
+	return e
+}
+
+// evictHeap orders entries by ascending priority, then by descending seq, so
+// that the lowest priority tx that was added last is at the top
+type evictHeap []*entry
+
+func (h evictHeap) Len() int { return len(h) }
+
+func (h evictHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority < h[j].priority
+	}
+	return h[i].seq > h[j].seq
+}
+
+func (h evictHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].evictIndex = i
+	h[j].evictIndex = j
+}
+
+func (h *evictHeap) Push(x interface{}) {
+	e := x.(*entry)
+	e.evictIndex = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *evictHeap) Pop() interface{} {
+	old := *h
+	n := len(old) - 1
+	e := old[n]
+	old[n] = nil
+	*h = old[:n]
+	return e
+}
@@ -0,0 +1,311 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+var (
+	ErrDuplicateTx   = errors.New("duplicate tx")
+	ErrConflictingTx = errors.New("tx conflicts with a tx in the mempool")
+	ErrTxTooHeavy    = errors.New("tx is heavier than the mempool's max weight")
+	ErrMempoolFull   = errors.New("mempool is full")
+
+	errNoTxParser = errors.New("a persisted mempool requires a tx parser")
+)
+
+// Tx is a transaction that can be put into a Mempool
+type Tx interface {
+	ID() ids.ID
+	Bytes() []byte
+}
+
+// Config configures a Mempool. Only MaxWeight is required.
+type Config struct {
+	// MaxWeight is the maximum total weight of the txs in the mempool
+	MaxWeight uint64
+
+	// Weight returns the weight of a tx. If nil, the weight of a tx is the
+	// length of its bytes.
+	Weight func(Tx) uint64
+
+	// Priority returns the priority of a tx, such as the fee it pays per unit
+	// of weight. Txs with a higher priority are popped first. When the
+	// mempool is full, txs with a lower priority are evicted to make room for
+	// a tx with a higher priority. If nil, every tx has the same priority, so
+	// txs are popped in the order they were added and a full mempool rejects
+	// new txs.
+	Priority func(Tx) uint64
+
+	// InputIDs returns the IDs of the state that a tx consumes. A tx that
+	// consumes an input that's consumed by a tx in the mempool is rejected.
+	// If nil, txs never conflict.
+	InputIDs func(Tx) ids.Set
+
+	// DB, if non-nil, is where the mempool is persisted by Persist and loaded
+	// from by New. This allows txs to survive restarts.
+	DB database.Database
+
+	// ParseTx parses the bytes of a persisted tx. Required if DB is non-nil.
+	ParseTx func([]byte) (Tx, error)
+}
+
+// Mempool holds txs that are waiting to be put into a block. The total weight
+// of the txs is bounded, txs are deduplicated by ID, and conflicting txs are
+// rejected.
+//
+// Mempool isn't safe for concurrent use.
+type Mempool struct {
+	config Config
+
+	weight  uint64
+	nextSeq uint64
+
+	// txID -> tx
+	txs       map[ids.ID]*entry
+	popHeap   popHeap
+	evictHeap evictHeap
+	// inputID -> ID of the tx in the mempool that consumes it
+	consumedInputs map[ids.ID]ids.ID
+}
+
+// New returns an empty mempool. If [config.DB] is non-nil, the txs that were
+// persisted are loaded into the mempool. Persisted txs that can no longer be
+// parsed or added are dropped.
+func New(config Config) (*Mempool, error) {
+	if config.DB != nil && config.ParseTx == nil {
+		return nil, errNoTxParser
+	}
+
+	m := &Mempool{
+		config:         config,
+		txs:            make(map[ids.ID]*entry),
+		consumedInputs: make(map[ids.ID]ids.ID),
+	}
+	return m, m.load()
+}
+
+// Add [tx] to the mempool. Lower priority txs may be evicted to make room for
+// [tx].
+func (m *Mempool) Add(tx Tx) error {
+	txID := tx.ID()
+	if _, ok := m.txs[txID]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateTx, txID)
+	}
+
+	weight := m.weightOf(tx)
+	if weight > m.config.MaxWeight {
+		return fmt.Errorf("%w: %d > %d", ErrTxTooHeavy, weight, m.config.MaxWeight)
+	}
+
+	inputs := m.inputsOf(tx)
+	for inputID := range inputs {
+		if conflictID, ok := m.consumedInputs[inputID]; ok {
+			return fmt.Errorf("%w: %s", ErrConflictingTx, conflictID)
+		}
+	}
+
+	priority := m.priorityOf(tx)
+	evicted := []*entry(nil)
+	for m.weight+weight > m.config.MaxWeight {
+		lowest := m.evictHeap[0]
+		if lowest.priority >= priority {
+			// [tx] isn't worth more than the txs it would replace, so the
+			// evicted txs are restored
+			for _, e := range evicted {
+				m.insert(e)
+			}
+			return ErrMempoolFull
+		}
+		m.remove(lowest)
+		evicted = append(evicted, lowest)
+	}
+
+	m.insert(&entry{
+		tx:       tx,
+		weight:   weight,
+		priority: priority,
+		inputs:   inputs,
+		seq:      m.nextSeq,
+	})
+	m.nextSeq++
+	return nil
+}
+
+// Has returns true if [txID] is in the mempool
+func (m *Mempool) Has(txID ids.ID) bool {
+	_, ok := m.txs[txID]
+	return ok
+}
+
+// Get returns the tx [txID], if it's in the mempool
+func (m *Mempool) Get(txID ids.ID) (Tx, bool) {
+	e, ok := m.txs[txID]
+	if !ok {
+		return nil, false
+	}
+	return e.tx, true
+}
+
+// Remove the txs with the provided IDs, if they're in the mempool
+func (m *Mempool) Remove(txIDs ...ids.ID) {
+	for _, txID := range txIDs {
+		if e, ok := m.txs[txID]; ok {
+			m.remove(e)
+		}
+	}
+}
+
+// RemoveConflicts removes the txs that consume any of [inputIDs]. This should
+// be called with the inputs of accepted txs, because any tx that conflicts
+// with an accepted tx can never be accepted.
+func (m *Mempool) RemoveConflicts(inputIDs ids.Set) {
+	for inputID := range inputIDs {
+		if txID, ok := m.consumedInputs[inputID]; ok {
+			m.remove(m.txs[txID])
+		}
+	}
+}
+
+// Peek returns the tx that would be popped next, without removing it. Returns
+// false if the mempool is empty.
+func (m *Mempool) Peek() (Tx, bool) {
+	if len(m.popHeap) == 0 {
+		return nil, false
+	}
+	return m.popHeap[0].tx, true
+}
+
+// Pop removes and returns the highest priority tx. Txs with the same priority
+// are popped in the order they were added. Returns false if the mempool is
+// empty.
+func (m *Mempool) Pop() (Tx, bool) {
+	if len(m.popHeap) == 0 {
+		return nil, false
+	}
+	e := m.popHeap[0]
+	m.remove(e)
+	return e.tx, true
+}
+
+// Len returns the number of txs in the mempool
+func (m *Mempool) Len() int {
+	return len(m.txs)
+}
+
+// Weight returns the total weight of the txs in the mempool
+func (m *Mempool) Weight() uint64 {
+	return m.weight
+}
+
+// Persist writes the txs in the mempool to [config.DB], replacing any txs that
+// were previously persisted. This is a no-op if [config.DB] is nil.
+func (m *Mempool) Persist() error {
+	db := m.config.DB
+	if db == nil {
+		return nil
+	}
+
+	batch := db.NewBatch()
+	if err := deleteAll(db, batch); err != nil {
+		return err
+	}
+	for _, e := range m.txs {
+		if err := batch.Put(database.PackUInt64(e.seq), e.tx.Bytes()); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// load adds the txs in [config.DB] to the mempool, in the order they were
+// originally added, and then deletes them from [config.DB]
+func (m *Mempool) load() error {
+	db := m.config.DB
+	if db == nil {
+		return nil
+	}
+
+	iter := db.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		tx, err := m.config.ParseTx(iter.Value())
+		if err != nil {
+			continue
+		}
+		_ = m.Add(tx)
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	batch := db.NewBatch()
+	if err := deleteAll(db, batch); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (m *Mempool) insert(e *entry) {
+	txID := e.tx.ID()
+	m.txs[txID] = e
+	for inputID := range e.inputs {
+		m.consumedInputs[inputID] = txID
+	}
+	heap.Push(&m.popHeap, e)
+	heap.Push(&m.evictHeap, e)
+	m.weight += e.weight
+}
+
+func (m *Mempool) remove(e *entry) {
+	delete(m.txs, e.tx.ID())
+	for inputID := range e.inputs {
+		delete(m.consumedInputs, inputID)
+	}
+	heap.Remove(&m.popHeap, e.popIndex)
+	heap.Remove(&m.evictHeap, e.evictIndex)
+	m.weight -= e.weight
+}
+
+func (m *Mempool) weightOf(tx Tx) uint64 {
+	if m.config.Weight == nil {
+		return uint64(len(tx.Bytes()))
+	}
+	return m.config.Weight(tx)
+}
+
+func (m *Mempool) priorityOf(tx Tx) uint64 {
+	if m.config.Priority == nil {
+		return 0
+	}
+	return m.config.Priority(tx)
+}
+
+func (m *Mempool) inputsOf(tx Tx) ids.Set {
+	if m.config.InputIDs == nil {
+		return nil
+	}
+	return m.config.InputIDs(tx)
+}
+
+// deleteAll adds the deletion of every key in [db] to [batch]
+func deleteAll(db database.Iteratee, batch database.Batch) error {
+	iter := db.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := batch.Delete(utils.CopyBytes(iter.Key())); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
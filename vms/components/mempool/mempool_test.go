@@ -0,0 +1,229 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+var _ Tx = &testTx{}
+
+// testTx's first byte is its priority and its second byte, if present, is the
+// input it consumes
+type testTx []byte
+
+func (tx testTx) ID() ids.ID    { return hashing.ComputeHash256Array(tx) }
+func (tx testTx) Bytes() []byte { return tx }
+
+func testPriority(tx Tx) uint64 { return uint64(tx.Bytes()[0]) }
+
+func testInputIDs(tx Tx) ids.Set {
+	bytes := tx.Bytes()
+	if len(bytes) < 2 {
+		return nil
+	}
+	inputs := ids.Set{}
+	inputs.Add(ids.Empty.Prefix(uint64(bytes[1])))
+	return inputs
+}
+
+func testParseTx(bytes []byte) (Tx, error) { return testTx(bytes), nil }
+
+func TestMempoolFIFO(t *testing.T) {
+	assert := assert.New(t)
+
+	m, err := New(Config{MaxWeight: 2})
+	assert.NoError(err)
+
+	tx0 := testTx{0}
+	tx1 := testTx{1}
+	tx2 := testTx{2}
+
+	assert.NoError(m.Add(tx0))
+	assert.ErrorIs(m.Add(tx0), ErrDuplicateTx)
+	assert.NoError(m.Add(tx1))
+	assert.Equal(2, m.Len())
+	assert.EqualValues(2, m.Weight())
+	assert.ErrorIs(m.Add(tx2), ErrMempoolFull)
+	assert.ErrorIs(m.Add(testTx{3, 3, 3}), ErrTxTooHeavy)
+
+	assert.True(m.Has(tx0.ID()))
+	m.Remove(tx0.ID())
+	assert.False(m.Has(tx0.ID()))
+	assert.NoError(m.Add(tx2))
+
+	// Without priorities, txs are popped in the order they were added
+	tx, ok := m.Peek()
+	assert.True(ok)
+	assert.Equal(tx1, tx)
+
+	tx, ok = m.Pop()
+	assert.True(ok)
+	assert.Equal(tx1, tx)
+
+	tx, ok = m.Pop()
+	assert.True(ok)
+	assert.Equal(tx2, tx)
+
+	_, ok = m.Pop()
+	assert.False(ok)
+	assert.Zero(m.Len())
+	assert.Zero(m.Weight())
+}
+
+func TestMempoolPriority(t *testing.T) {
+	assert := assert.New(t)
+
+	m, err := New(Config{
+		MaxWeight: 3,
+		Weight:    func(Tx) uint64 { return 1 },
+		Priority:  testPriority,
+	})
+	assert.NoError(err)
+
+	lowTx := testTx{1}
+	midTx0 := testTx{2, 0}
+	midTx1 := testTx{2, 1}
+	highTx := testTx{3}
+
+	assert.NoError(m.Add(lowTx))
+	assert.NoError(m.Add(midTx0))
+	assert.NoError(m.Add(midTx1))
+
+	// A tx that doesn't have a higher priority than any tx in the mempool
+	// can't evict anything
+	assert.ErrorIs(m.Add(testTx{1, 1}), ErrMempoolFull)
+	assert.Equal(3, m.Len())
+
+	// The lowest priority tx is evicted
+	assert.NoError(m.Add(highTx))
+	assert.False(m.Has(lowTx.ID()))
+	assert.Equal(3, m.Len())
+
+	// Between txs with the same priority, the newest is evicted
+	assert.NoError(m.Add(testTx{4}))
+	assert.True(m.Has(midTx0.ID()))
+	assert.False(m.Has(midTx1.ID()))
+
+	// Txs are popped by priority
+	tx, ok := m.Pop()
+	assert.True(ok)
+	assert.Equal(testTx{4}, tx)
+
+	tx, ok = m.Pop()
+	assert.True(ok)
+	assert.Equal(highTx, tx)
+
+	tx, ok = m.Pop()
+	assert.True(ok)
+	assert.Equal(midTx0, tx)
+}
+
+func TestMempoolConflicts(t *testing.T) {
+	assert := assert.New(t)
+
+	m, err := New(Config{
+		MaxWeight: 1024,
+		InputIDs:  testInputIDs,
+	})
+	assert.NoError(err)
+
+	tx := testTx{0, 1}
+	conflictingTx := testTx{1, 1}
+
+	assert.NoError(m.Add(tx))
+	assert.ErrorIs(m.Add(conflictingTx), ErrConflictingTx)
+
+	// Removing a tx frees its inputs
+	m.Remove(tx.ID())
+	assert.NoError(m.Add(conflictingTx))
+
+	// Accepting a tx that consumes the same input removes the conflict
+	m.RemoveConflicts(testInputIDs(tx))
+	assert.Zero(m.Len())
+	assert.NoError(m.Add(tx))
+}
+
+func TestMempoolPersist(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	config := Config{
+		MaxWeight: 1024,
+		DB:        db,
+		ParseTx:   testParseTx,
+	}
+	m, err := New(config)
+	assert.NoError(err)
+
+	tx0 := testTx{0}
+	tx1 := testTx{1}
+	tx2 := testTx{2}
+	assert.NoError(m.Add(tx0))
+	assert.NoError(m.Add(tx1))
+	assert.NoError(m.Persist())
+
+	// Persisting replaces the previously persisted txs
+	m.Remove(tx0.ID())
+	assert.NoError(m.Add(tx2))
+	assert.NoError(m.Persist())
+
+	m, err = New(config)
+	assert.NoError(err)
+	assert.Equal(2, m.Len())
+
+	// The persisted txs are loaded in the order they were added
+	tx, ok := m.Pop()
+	assert.True(ok)
+	assert.Equal(tx1, tx)
+
+	tx, ok = m.Pop()
+	assert.True(ok)
+	assert.Equal(tx2, tx)
+
+	// The persisted txs are only loaded once
+	m, err = New(config)
+	assert.NoError(err)
+	assert.Zero(m.Len())
+
+	_, err = New(Config{DB: db})
+	assert.ErrorIs(err, errNoTxParser)
+}
+
+func TestMempoolPersistDropsUnparsableTxs(t *testing.T) {
+	assert := assert.New(t)
+
+	db := memdb.New()
+	m, err := New(Config{
+		MaxWeight: 1024,
+		DB:        db,
+		ParseTx:   testParseTx,
+	})
+	assert.NoError(err)
+	assert.NoError(m.Add(testTx{0}))
+	assert.NoError(m.Add(testTx{1}))
+	assert.NoError(m.Persist())
+
+	errUnparsable := errors.New("unparsable")
+	m, err = New(Config{
+		MaxWeight: 1024,
+		DB:        db,
+		ParseTx: func(bytes []byte) (Tx, error) {
+			if bytes[0] == 0 {
+				return nil, errUnparsable
+			}
+			return testTx(bytes), nil
+		},
+	})
+	assert.NoError(err)
+	assert.Equal(1, m.Len())
+	assert.True(m.Has(testTx{1}.ID()))
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowmanvm
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+// App is the application specific logic of a VM built with this package. The
+// VM handles block storage, consensus and the mempool, while the App defines
+// what transactions are and how they change the chain's state.
+//
+// Transactions are opaque bytes to the VM. Blocks contain an ordered list of
+// transactions, which are executed in order on top of the state of the
+// block's parent.
+type App interface {
+	// Initialize is called once, before any other method.
+	Initialize(ctx *snow.Context, configBytes []byte) error
+
+	// Genesis writes the genesis state of the chain into [db]. This is only
+	// called the first time the chain is started.
+	Genesis(db database.Database, genesisBytes []byte) error
+
+	// ExecuteTx verifies [tx] against the state in [db] and writes the
+	// changes made by [tx] into [db]. If an error is returned, the changes
+	// written into [db] are discarded.
+	//
+	// ExecuteTx must be deterministic. The same transaction may be executed
+	// multiple times on different states, such as when a block is built and
+	// when it's verified.
+	ExecuteTx(db database.Database, tx []byte) error
+
+	// CreateHandlers returns the HTTP handlers of the app, which are served
+	// under the chain's API endpoint. The handlers are called while holding
+	// the context lock, unless their lock options say otherwise.
+	CreateHandlers(vm *VM) (map[string]*common.HTTPHandler, error)
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowmanvm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
+)
+
+// maxFutureBlockTime is how far in the future a block's timestamp may be
+const maxFutureBlockTime = 10 * time.Second
+
+var (
+	errWrongHeight         = errors.New("block height isn't one greater than its parent's height")
+	errTimestampTooEarly   = errors.New("block timestamp is before its parent's timestamp")
+	errTimestampTooLate    = errors.New("block timestamp is too far in the future")
+	errNoTxs               = errors.New("block doesn't contain any transactions")
+	errTooManyTxs          = errors.New("block contains too many transactions")
+	errParentNotVerified   = errors.New("parent block hasn't been verified")
+	errParentDecided       = errors.New("parent block was decided but isn't the last accepted block")
+	errUnexpectedBlockType = errors.New("unexpected block type")
+
+	_ chain.Block = &Block{}
+)
+
+// Block is a block of transactions
+type Block struct {
+	PrntID ids.ID   `serialize:"true" json:"parentID"`
+	Hght   uint64   `serialize:"true" json:"height"`
+	Tmstmp int64    `serialize:"true" json:"timestamp"`
+	Txs    [][]byte `serialize:"true" json:"txs"`
+
+	id     ids.ID
+	bytes  []byte
+	status choices.Status
+	vm     *VM
+
+	// state contains the changes made by this block, on top of the state of
+	// its parent. It's only set while this block is verified and processing.
+	state *versiondb.Database
+}
+
+// initialize sets the fields of [b] that aren't serialized
+func (b *Block) initialize(vm *VM, bytes []byte, status choices.Status) {
+	b.id = hashing.ComputeHash256Array(bytes)
+	b.bytes = bytes
+	b.status = status
+	b.vm = vm
+}
+
+func (b *Block) ID() ids.ID                      { return b.id }
+func (b *Block) Parent() ids.ID                  { return b.PrntID }
+func (b *Block) Height() uint64                  { return b.Hght }
+func (b *Block) Timestamp() time.Time            { return time.Unix(b.Tmstmp, 0) }
+func (b *Block) Bytes() []byte                   { return b.bytes }
+func (b *Block) Status() choices.Status          { return b.status }
+func (b *Block) SetStatus(status choices.Status) { b.status = status }
+
+// Verify executes the txs of this block on top of the state of its parent
+func (b *Block) Verify() error {
+	switch {
+	case len(b.Txs) == 0:
+		return errNoTxs
+	case len(b.Txs) > b.vm.config.MaxBlockTxs:
+		return fmt.Errorf("%w: %d > %d", errTooManyTxs, len(b.Txs), b.vm.config.MaxBlockTxs)
+	}
+
+	parent, parentState, err := b.vm.getParentState(b.PrntID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case b.Hght != parent.Hght+1:
+		return fmt.Errorf("%w: expected %d but got %d", errWrongHeight, parent.Hght+1, b.Hght)
+	case b.Tmstmp < parent.Tmstmp:
+		return errTimestampTooEarly
+	case b.Timestamp().After(b.vm.clock.Time().Add(maxFutureBlockTime)):
+		return errTimestampTooLate
+	}
+
+	state := versiondb.New(parentState)
+	appDB := prefixdb.New(appPrefix, state)
+	for i, tx := range b.Txs {
+		if err := b.vm.app.ExecuteTx(appDB, tx); err != nil {
+			return fmt.Errorf("failed to execute tx %d: %w", i, err)
+		}
+	}
+	b.state = state
+	return nil
+}
+
+// Accept writes this block and the changes it made to the database
+func (b *Block) Accept() error {
+	b.status = choices.Accepted
+
+	// The changes made by the parent were already written to the database, so
+	// this block's changes can be written directly to the database.
+	if err := b.state.SetDatabase(b.vm.db); err != nil {
+		return err
+	}
+	if err := b.state.Commit(); err != nil {
+		return err
+	}
+	// Any processing children were verified on top of [b.state], which now
+	// passes reads through to the database.
+	b.state = nil
+
+	if err := b.vm.putAcceptedBlock(b); err != nil {
+		return err
+	}
+	if err := b.vm.maybePutStateSummary(b); err != nil {
+		return err
+	}
+	return b.vm.db.Commit()
+}
+
+// Reject drops the changes made by this block. The txs in this block aren't
+// added back to the mempool, so they need to be issued again.
+func (b *Block) Reject() error {
+	b.status = choices.Rejected
+	b.state = nil
+	return nil
+}
+
+// blockOrError returns [blk] as a *Block
+func blockOrError(blk snowman.Block) (*Block, error) {
+	b, ok := blk.(*Block)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errUnexpectedBlockType, blk)
+	}
+	return b, nil
+}
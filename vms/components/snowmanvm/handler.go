@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowmanvm
+
+import (
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// NewJSONHandler returns a handler that serves the exported methods of
+// [service] as JSON RPC 2.0 methods named "[name].[method]". The handler is
+// called while holding the context lock.
+func NewJSONHandler(name string, service interface{}) (*common.HTTPHandler, error) {
+	codec := json.NewCodec()
+
+	server := json.NewServer()
+	server.RegisterCodec(codec, "application/json")
+	server.RegisterCodec(codec, "application/json;charset=UTF-8")
+	return &common.HTTPHandler{Handler: server}, server.RegisterService(service, name)
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowmanvm
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/mempool"
+)
+
+var (
+	errMempoolFull = mempool.ErrMempoolFull
+
+	_ mempool.Tx = rawTx{}
+)
+
+// rawTx is a tx that's identified by the hash of its bytes
+type rawTx []byte
+
+func (tx rawTx) ID() ids.ID    { return hashing.ComputeHash256Array(tx) }
+func (tx rawTx) Bytes() []byte { return tx }
+
+// Mempool is a bounded, first in first out queue of transactions that are
+// waiting to be put into a block. Transactions are identified by the hash of
+// their bytes, and duplicate transactions are dropped.
+type Mempool struct {
+	txs *mempool.Mempool
+}
+
+func NewMempool(maxSize int) *Mempool {
+	// Every tx has the same weight, so the mempool's weight is the number of
+	// txs in it
+	txs, _ := mempool.New(mempool.Config{
+		MaxWeight: uint64(maxSize),
+		Weight:    func(mempool.Tx) uint64 { return 1 },
+	})
+	return &Mempool{txs: txs}
+}
+
+// Add [tx] to the mempool. Adding a tx that is already in the mempool is a
+// no-op.
+func (m *Mempool) Add(tx []byte) error {
+	err := m.txs.Add(rawTx(tx))
+	if errors.Is(err, mempool.ErrDuplicateTx) {
+		return nil
+	}
+	return err
+}
+
+// Has returns true if [txID] is in the mempool
+func (m *Mempool) Has(txID ids.ID) bool {
+	return m.txs.Has(txID)
+}
+
+// Remove the txs with the provided IDs, if they're in the mempool
+func (m *Mempool) Remove(txIDs ...ids.ID) {
+	m.txs.Remove(txIDs...)
+}
+
+// Pop removes and returns the oldest tx in the mempool. Returns false if the
+// mempool is empty.
+func (m *Mempool) Pop() ([]byte, bool) {
+	tx, ok := m.txs.Pop()
+	if !ok {
+		return nil, false
+	}
+	return tx.Bytes(), true
+}
+
+// Len returns the number of txs in the mempool
+func (m *Mempool) Len() int {
+	return m.txs.Len()
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowmanvm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+func TestMempool(t *testing.T) {
+	assert := assert.New(t)
+
+	m := NewMempool(2)
+
+	tx0 := []byte{0}
+	tx1 := []byte{1}
+	tx2 := []byte{2}
+
+	assert.NoError(m.Add(tx0))
+	assert.NoError(m.Add(tx0)) // duplicates are dropped
+	assert.NoError(m.Add(tx1))
+	assert.Equal(2, m.Len())
+	assert.ErrorIs(m.Add(tx2), errMempoolFull)
+
+	assert.True(m.Has(hashing.ComputeHash256Array(tx0)))
+	m.Remove(hashing.ComputeHash256Array(tx0))
+	assert.False(m.Has(hashing.ComputeHash256Array(tx0)))
+	assert.NoError(m.Add(tx2))
+
+	// Txs are popped in the order they were added, skipping removed txs
+	tx, ok := m.Pop()
+	assert.True(ok)
+	assert.Equal(tx1, tx)
+
+	tx, ok = m.Pop()
+	assert.True(ok)
+	assert.Equal(tx2, tx)
+
+	_, ok = m.Pop()
+	assert.False(ok)
+	assert.Zero(m.Len())
+}
@@ -0,0 +1,214 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowmanvm
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+var _ block.StateSummary = &StateSummary{}
+
+// KeyValue is an entry of the app state
+type KeyValue struct {
+	Key   []byte `serialize:"true"`
+	Value []byte `serialize:"true"`
+}
+
+// StateSummary contains an accepted block and the complete app state as of
+// that block. Because the whole state is included in the summary, state sync
+// is only supported by apps with small states.
+type StateSummary struct {
+	Block []byte     `serialize:"true"`
+	State []KeyValue `serialize:"true"`
+
+	id     ids.ID
+	bytes  []byte
+	height uint64
+	vm     *VM
+}
+
+func (s *StateSummary) ID() ids.ID     { return s.id }
+func (s *StateSummary) Height() uint64 { return s.height }
+func (s *StateSummary) Bytes() []byte  { return s.bytes }
+
+// Accept replaces the app state with the state in this summary and marks the
+// summary's block as the last accepted block. Summaries at or below the
+// current last accepted height are skipped.
+func (s *StateSummary) Accept() (bool, error) {
+	lastAccepted := s.vm.State.LastAcceptedBlockInternal()
+	if s.height <= lastAccepted.Height() {
+		return false, nil
+	}
+
+	blk, err := s.vm.parseSummaryBlock(s.Block)
+	if err != nil {
+		return false, err
+	}
+
+	if err := clearDatabase(s.vm.appDB); err != nil {
+		return false, err
+	}
+	for _, kv := range s.State {
+		if err := s.vm.appDB.Put(kv.Key, kv.Value); err != nil {
+			return false, err
+		}
+	}
+
+	if err := s.vm.putAcceptedBlock(blk); err != nil {
+		return false, err
+	}
+	if err := s.vm.putStateSummary(s); err != nil {
+		return false, err
+	}
+	if err := s.vm.db.Commit(); err != nil {
+		return false, err
+	}
+
+	if err := s.vm.State.SetLastAcceptedBlock(blk); err != nil {
+		return false, err
+	}
+	s.vm.preferred = blk.ID()
+
+	// The state was synced while accepting the summary. The engine waits for
+	// this message after Accept returns.
+	go func() {
+		s.vm.toEngine <- common.StateSyncDone
+	}()
+	return true, nil
+}
+
+func (vm *VM) StateSyncEnabled() (bool, error) {
+	return vm.config.StateSyncInterval > 0, nil
+}
+
+// State is synced atomically when a summary is accepted, so there is never an
+// ongoing sync
+func (vm *VM) GetOngoingSyncStateSummary() (block.StateSummary, error) {
+	return nil, database.ErrNotFound
+}
+
+func (vm *VM) GetLastStateSummary() (block.StateSummary, error) {
+	height, err := database.GetUInt64(vm.metadataDB, lastSummaryHeightKey)
+	if err != nil {
+		return nil, err
+	}
+	return vm.GetStateSummary(height)
+}
+
+func (vm *VM) ParseStateSummary(summaryBytes []byte) (block.StateSummary, error) {
+	summary := &StateSummary{}
+	if _, err := c.Unmarshal(summaryBytes, summary); err != nil {
+		return nil, err
+	}
+	blk, err := vm.parseSummaryBlock(summary.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	summary.id = hashing.ComputeHash256Array(summaryBytes)
+	summary.bytes = summaryBytes
+	summary.height = blk.Height()
+	summary.vm = vm
+	return summary, nil
+}
+
+func (vm *VM) GetStateSummary(height uint64) (block.StateSummary, error) {
+	summaryBytes, err := vm.summaryDB.Get(database.PackUInt64(height))
+	if err != nil {
+		return nil, err
+	}
+	return vm.ParseStateSummary(summaryBytes)
+}
+
+// maybePutStateSummary creates a state summary of [blk], which must be the
+// last accepted block, if summaries are taken at its height
+func (vm *VM) maybePutStateSummary(blk *Block) error {
+	interval := vm.config.StateSyncInterval
+	if interval == 0 || blk.Height()%interval != 0 {
+		return nil
+	}
+
+	summary := &StateSummary{
+		Block: blk.Bytes(),
+	}
+	iter := vm.appDB.NewIterator()
+	defer iter.Release()
+	for iter.Next() {
+		summary.State = append(summary.State, KeyValue{
+			Key:   utils.CopyBytes(iter.Key()),
+			Value: utils.CopyBytes(iter.Value()),
+		})
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	summaryBytes, err := c.Marshal(codecVersion, summary)
+	if err != nil {
+		// The app state is too large to be included in a summary. Every node
+		// will fail to create this summary, so it's skipped.
+		vm.ctx.Log.Warn("couldn't create state summary at height %d: %s", blk.Height(), err)
+		return nil
+	}
+	summary.id = hashing.ComputeHash256Array(summaryBytes)
+	summary.bytes = summaryBytes
+	summary.height = blk.Height()
+	return vm.putStateSummary(summary)
+}
+
+// putStateSummary stores [summary] and removes the previous summary, so that
+// only the most recent summary is kept
+func (vm *VM) putStateSummary(summary *StateSummary) error {
+	prevHeight, err := database.GetUInt64(vm.metadataDB, lastSummaryHeightKey)
+	switch err {
+	case nil:
+		if err := vm.summaryDB.Delete(database.PackUInt64(prevHeight)); err != nil {
+			return err
+		}
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+
+	if err := vm.summaryDB.Put(database.PackUInt64(summary.height), summary.bytes); err != nil {
+		return err
+	}
+	return database.PutUInt64(vm.metadataDB, lastSummaryHeightKey, summary.height)
+}
+
+func (vm *VM) parseSummaryBlock(blkBytes []byte) (*Block, error) {
+	blk := &Block{}
+	if _, err := c.Unmarshal(blkBytes, blk); err != nil {
+		return nil, err
+	}
+	blk.initialize(vm, blkBytes, choices.Accepted)
+	return blk, nil
+}
+
+// clearDatabase deletes every key in [db]
+func clearDatabase(db database.Database) error {
+	iter := db.NewIterator()
+	keys := [][]byte(nil)
+	for iter.Next() {
+		keys = append(keys, utils.CopyBytes(iter.Key()))
+	}
+	err := iter.Error()
+	iter.Release()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,455 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowmanvm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/components/chain"
+	"github.com/ava-labs/avalanchego/vms/components/mempool"
+)
+
+const (
+	decidedCacheSize    = 2048
+	missingCacheSize    = 2048
+	unverifiedCacheSize = 2048
+	bytesToIDCacheSize  = 2048
+	recentTxsCacheSize  = 512
+)
+
+var (
+	blockPrefix    = []byte("block")
+	heightPrefix   = []byte("height")
+	metadataPrefix = []byte("metadata")
+	appPrefix      = []byte("app")
+	summaryPrefix  = []byte("summary")
+
+	lastAcceptedKey      = []byte("last accepted")
+	lastSummaryHeightKey = []byte("last summary height")
+
+	errNoPendingTxs = errors.New("no pending transactions")
+
+	_ block.ChainVM               = &VM{}
+	_ block.HeightIndexedChainVM  = &VM{}
+	_ block.StateSyncableVM       = &VM{}
+	_ block.CongestionReportingVM = &VM{}
+)
+
+// Config configures a VM
+type Config struct {
+	// Version reported by the VM
+	Version string
+	// Maximum number of txs in a block
+	MaxBlockTxs int
+	// Maximum number of txs waiting to be put into a block
+	MempoolSize int
+	// Number of blocks between state summaries. If 0, state summaries aren't
+	// created and state sync is disabled.
+	StateSyncInterval uint64
+}
+
+// DefaultConfig is a reasonable default Config
+var DefaultConfig = Config{
+	Version:     "v0.0.0",
+	MaxBlockTxs: 256,
+	MempoolSize: 4096,
+}
+
+// VM implements block.ChainVM on top of an App. It stores accepted blocks,
+// indexes them by height, and keeps the state changes made by processing
+// blocks in memory until they're decided.
+//
+// Txs issued to the VM are gossiped to other validators, and gossiped txs are
+// added to the mempool if they can be executed on top of the preferred block.
+//
+// If enabled, a state summary is created every [StateSyncInterval] blocks,
+// which allows new nodes to state sync instead of executing every block.
+type VM struct {
+	*chain.State
+
+	app    App
+	config Config
+
+	ctx      *snow.Context
+	toEngine chan<- common.Message
+	clock    mockable.Clock
+
+	// db holds all the VM's accepted data
+	db *versiondb.Database
+	// blkID -> block bytes of accepted blocks
+	blockDB database.Database
+	// height -> blkID of accepted blocks
+	heightDB   database.Database
+	metadataDB database.Database
+	// Accepted state of the app
+	appDB database.Database
+	// height -> state summary bytes
+	summaryDB database.Database
+
+	mempool   *Mempool
+	gossiper  *mempool.Gossiper
+	preferred ids.ID
+}
+
+// New returns a VM that runs [app]
+func New(app App, config Config) *VM {
+	return &VM{
+		app:    app,
+		config: config,
+	}
+}
+
+func (vm *VM) Initialize(
+	ctx *snow.Context,
+	dbManager manager.Manager,
+	genesisBytes []byte,
+	_ []byte,
+	configBytes []byte,
+	toEngine chan<- common.Message,
+	_ []*common.Fx,
+	appSender common.AppSender,
+) error {
+	vm.ctx = ctx
+	vm.toEngine = toEngine
+	vm.mempool = NewMempool(vm.config.MempoolSize)
+	vm.gossiper = mempool.NewGossiper(appSender, recentTxsCacheSize)
+
+	vm.db = versiondb.New(dbManager.Current().Database)
+	vm.blockDB = prefixdb.New(blockPrefix, vm.db)
+	vm.heightDB = prefixdb.New(heightPrefix, vm.db)
+	vm.metadataDB = prefixdb.New(metadataPrefix, vm.db)
+	vm.appDB = prefixdb.New(appPrefix, vm.db)
+	vm.summaryDB = prefixdb.New(summaryPrefix, vm.db)
+
+	if err := vm.app.Initialize(ctx, configBytes); err != nil {
+		return err
+	}
+
+	lastAccepted, err := vm.getLastAccepted(genesisBytes)
+	if err != nil {
+		return err
+	}
+	vm.preferred = lastAccepted.ID()
+
+	registerer := prometheus.NewRegistry()
+	vm.State, err = chain.NewMeteredState(
+		registerer,
+		&chain.Config{
+			DecidedCacheSize:    decidedCacheSize,
+			MissingCacheSize:    missingCacheSize,
+			UnverifiedCacheSize: unverifiedCacheSize,
+			BytesToIDCacheSize:  bytesToIDCacheSize,
+			LastAcceptedBlock:   lastAccepted,
+			GetBlock:            vm.getBlock,
+			UnmarshalBlock:      vm.parseBlock,
+			BuildBlock:          vm.buildBlock,
+			GetBlockIDAtHeight:  vm.GetBlockIDAtHeight,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return ctx.Metrics.Register(registerer)
+}
+
+// getLastAccepted returns the last accepted block. If the chain hasn't been
+// started before, the genesis block is created and accepted.
+func (vm *VM) getLastAccepted(genesisBytes []byte) (*Block, error) {
+	lastAcceptedID, err := database.GetID(vm.metadataDB, lastAcceptedKey)
+	if err == nil {
+		return vm.getAcceptedBlock(lastAcceptedID)
+	}
+	if err != database.ErrNotFound {
+		return nil, err
+	}
+
+	if err := vm.app.Genesis(vm.appDB, genesisBytes); err != nil {
+		return nil, fmt.Errorf("failed to initialize genesis state: %w", err)
+	}
+
+	// The genesis block doesn't contain any txs, so its parent ID is derived
+	// from the genesis bytes to give each chain a unique genesis block.
+	genesis := &Block{
+		PrntID: hashing.ComputeHash256Array(genesisBytes),
+	}
+	bytes, err := c.Marshal(codecVersion, genesis)
+	if err != nil {
+		return nil, err
+	}
+	genesis.initialize(vm, bytes, choices.Accepted)
+
+	if err := vm.putAcceptedBlock(genesis); err != nil {
+		return nil, err
+	}
+	return genesis, vm.db.Commit()
+}
+
+func (vm *VM) SetState(state snow.State) error {
+	return nil
+}
+
+func (vm *VM) Shutdown() error {
+	if vm.db == nil {
+		return nil
+	}
+	return vm.db.Close()
+}
+
+func (vm *VM) Version() (string, error) {
+	return vm.config.Version, nil
+}
+
+func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
+	return nil, nil
+}
+
+func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
+	return vm.app.CreateHandlers(vm)
+}
+
+func (vm *VM) HealthCheck() (interface{}, error) {
+	return nil, nil
+}
+
+func (vm *VM) Connected(ids.NodeID, *version.Application) error { return nil }
+
+func (vm *VM) Disconnected(ids.NodeID) error { return nil }
+
+// This VM doesn't (currently) have any app-specific messages
+func (vm *VM) AppRequest(ids.NodeID, uint32, time.Time, []byte) error { return nil }
+
+// This VM doesn't (currently) have any app-specific messages
+func (vm *VM) AppResponse(ids.NodeID, uint32, []byte) error { return nil }
+
+// This VM doesn't (currently) have any app-specific messages
+func (vm *VM) AppRequestFailed(ids.NodeID, uint32) error { return nil }
+
+// AppGossip adds the gossiped tx [msg] to the mempool, if it can be executed
+// on top of the preferred block. Gossip that can't be handled is dropped.
+func (vm *VM) AppGossip(nodeID ids.NodeID, msg []byte) error {
+	txID := hashing.ComputeHash256Array(msg)
+	if vm.mempool.Has(txID) {
+		return nil
+	}
+
+	_, parentState, err := vm.getParentState(vm.preferred)
+	if err != nil {
+		vm.ctx.Log.Debug("dropping tx %s gossiped by %s: %s", txID, nodeID, err)
+		return nil
+	}
+	if err := vm.app.ExecuteTx(prefixdb.New(appPrefix, versiondb.New(parentState)), msg); err != nil {
+		vm.ctx.Log.Debug("dropping tx %s gossiped by %s: %s", txID, nodeID, err)
+		return nil
+	}
+
+	if err := vm.IssueTx(msg); err != nil {
+		vm.ctx.Log.Debug("dropping tx %s gossiped by %s: %s", txID, nodeID, err)
+	}
+	return nil
+}
+
+func (vm *VM) SetPreference(blkID ids.ID) error {
+	vm.preferred = blkID
+	return nil
+}
+
+// IssueTx adds [tx] to the mempool, to be put into a future block. Txs that
+// weren't already in the mempool are gossiped to other validators.
+func (vm *VM) IssueTx(tx []byte) error {
+	txID := hashing.ComputeHash256Array(tx)
+	if vm.mempool.Has(txID) {
+		return nil
+	}
+	if err := vm.mempool.Add(tx); err != nil {
+		return err
+	}
+
+	if err := vm.gossiper.Gossip(rawTx(tx)); err != nil {
+		vm.ctx.Log.Debug("failed to gossip tx %s: %s", txID, err)
+	}
+
+	// Notify the engine that there are txs to put into a block
+	select {
+	case vm.toEngine <- common.PendingTxs:
+	default:
+	}
+	return nil
+}
+
+// Congestion returns the fraction of a block that the txs in the mempool would
+// fill
+func (vm *VM) Congestion() (float64, error) {
+	congestion := float64(vm.mempool.Len()) / float64(vm.config.MaxBlockTxs)
+	return math.Min(congestion, 1), nil
+}
+
+// AcceptedState returns the state of the app as of the last accepted block.
+// The returned database must only be read while holding the context lock.
+func (vm *VM) AcceptedState() database.Database {
+	return vm.appDB
+}
+
+func (vm *VM) VerifyHeightIndex() error {
+	return nil
+}
+
+func (vm *VM) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	return database.GetID(vm.heightDB, database.PackUInt64(height))
+}
+
+// buildBlock puts the txs in the mempool that can be executed on top of the
+// preferred block into a new block
+func (vm *VM) buildBlock() (snowman.Block, error) {
+	parent, parentState, err := vm.getParentState(vm.preferred)
+	if err != nil {
+		return nil, err
+	}
+
+	state := versiondb.New(parentState)
+	txs := [][]byte(nil)
+	for len(txs) < vm.config.MaxBlockTxs {
+		tx, ok := vm.mempool.Pop()
+		if !ok {
+			break
+		}
+
+		// Txs that can't be executed are dropped
+		txState := versiondb.New(state)
+		if err := vm.app.ExecuteTx(prefixdb.New(appPrefix, txState), tx); err != nil {
+			vm.ctx.Log.Debug("dropping tx %s: %s", hashing.ComputeHash256Array(tx), err)
+			continue
+		}
+		if err := txState.Commit(); err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	if len(txs) == 0 {
+		return nil, errNoPendingTxs
+	}
+
+	timestamp := vm.clock.Unix()
+	if parentTimestamp := uint64(parent.Tmstmp); timestamp < parentTimestamp {
+		timestamp = parentTimestamp
+	}
+	blk := &Block{
+		PrntID: parent.ID(),
+		Hght:   parent.Hght + 1,
+		Tmstmp: int64(timestamp),
+		Txs:    txs,
+	}
+	bytes, err := c.Marshal(codecVersion, blk)
+	if err != nil {
+		return nil, err
+	}
+	blk.initialize(vm, bytes, choices.Processing)
+
+	// Notify the engine if there are more txs to put into a block
+	if vm.mempool.Len() > 0 {
+		select {
+		case vm.toEngine <- common.PendingTxs:
+		default:
+		}
+	}
+	return blk, nil
+}
+
+func (vm *VM) parseBlock(bytes []byte) (snowman.Block, error) {
+	blk := &Block{}
+	if _, err := c.Unmarshal(bytes, blk); err != nil {
+		return nil, err
+	}
+	// The status is set by the chain state using the height index
+	blk.initialize(vm, bytes, choices.Processing)
+	return blk, nil
+}
+
+// getBlock returns the accepted block [blkID]. Processing blocks are tracked by
+// the chain state.
+func (vm *VM) getBlock(blkID ids.ID) (snowman.Block, error) {
+	return vm.getAcceptedBlock(blkID)
+}
+
+func (vm *VM) getAcceptedBlock(blkID ids.ID) (*Block, error) {
+	bytes, err := vm.blockDB.Get(blkID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	blk := &Block{}
+	if _, err := c.Unmarshal(bytes, blk); err != nil {
+		return nil, err
+	}
+	blk.initialize(vm, bytes, choices.Accepted)
+	return blk, nil
+}
+
+func (vm *VM) putAcceptedBlock(blk *Block) error {
+	blkID := blk.ID()
+	if err := vm.blockDB.Put(blkID[:], blk.Bytes()); err != nil {
+		return err
+	}
+	if err := database.PutID(vm.heightDB, database.PackUInt64(blk.Height()), blkID); err != nil {
+		return err
+	}
+
+	// The txs were accepted, so they shouldn't be put into another block
+	txIDs := make([]ids.ID, len(blk.Txs))
+	for i, tx := range blk.Txs {
+		txIDs[i] = hashing.ComputeHash256Array(tx)
+	}
+	vm.mempool.Remove(txIDs...)
+
+	return database.PutID(vm.metadataDB, lastAcceptedKey, blkID)
+}
+
+// getParentState returns the block [blkID] and the state that a child of the
+// block should be executed on top of
+func (vm *VM) getParentState(blkID ids.ID) (*Block, database.Database, error) {
+	blkIntf, err := vm.State.GetBlockInternal(blkID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't get parent block %s: %w", blkID, err)
+	}
+	blk, err := blockOrError(blkIntf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch blk.Status() {
+	case choices.Accepted:
+		lastAcceptedID, err := vm.LastAccepted()
+		if err != nil {
+			return nil, nil, err
+		}
+		if blkID != lastAcceptedID {
+			return nil, nil, errParentDecided
+		}
+		return blk, vm.db, nil
+	case choices.Processing:
+		if blk.state == nil {
+			return nil, nil, errParentNotVerified
+		}
+		return blk, blk.state, nil
+	default:
+		return nil, nil, errParentDecided
+	}
+}
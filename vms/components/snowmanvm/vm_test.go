@@ -0,0 +1,397 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowmanvm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	errInvalidTx = errors.New("invalid tx")
+
+	_ App = &testApp{}
+)
+
+// testApp stores each tx as a key with the value of the genesis bytes. Empty
+// txs are invalid.
+type testApp struct {
+	genesisBytes []byte
+}
+
+func (a *testApp) Initialize(*snow.Context, []byte) error { return nil }
+
+func (a *testApp) Genesis(_ database.Database, genesisBytes []byte) error {
+	a.genesisBytes = genesisBytes
+	return nil
+}
+
+func (a *testApp) ExecuteTx(db database.Database, tx []byte) error {
+	if len(tx) == 0 {
+		return errInvalidTx
+	}
+	return db.Put(tx, a.genesisBytes)
+}
+
+func (a *testApp) CreateHandlers(*VM) (map[string]*common.HTTPHandler, error) {
+	return nil, nil
+}
+
+func newTestVM(t *testing.T, dbManager manager.Manager) (*VM, chan common.Message) {
+	sender := &common.SenderTest{
+		SendAppGossipF: func([]byte) error { return nil },
+	}
+	return newConfiguredTestVM(t, dbManager, DefaultConfig, sender)
+}
+
+func newConfiguredTestVM(
+	t *testing.T,
+	dbManager manager.Manager,
+	config Config,
+	sender common.AppSender,
+) (*VM, chan common.Message) {
+	vm := New(&testApp{}, config)
+	toEngine := make(chan common.Message, 1)
+	err := vm.Initialize(
+		snow.DefaultContextTest(),
+		dbManager,
+		[]byte("genesis"),
+		nil,
+		nil,
+		toEngine,
+		nil,
+		sender,
+	)
+	assert.NoError(t, err)
+	return vm, toEngine
+}
+
+func TestVMBuildAndAcceptBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	dbManager := manager.NewMemDB(version.Semantic1_0_0)
+	vm, toEngine := newTestVM(t, dbManager)
+
+	genesisID, err := vm.LastAccepted()
+	assert.NoError(err)
+
+	_, err = vm.BuildBlock()
+	assert.ErrorIs(err, errNoPendingTxs)
+
+	assert.NoError(vm.IssueTx([]byte("tx0")))
+	assert.NoError(vm.IssueTx(nil))
+	assert.Equal(common.PendingTxs, <-toEngine)
+
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.Equal(genesisID, blk.Parent())
+	assert.EqualValues(1, blk.Height())
+	assert.Equal(choices.Processing, blk.Status())
+
+	// The invalid tx was dropped
+	internalBlk, err := vm.GetBlockInternal(blk.ID())
+	assert.NoError(err)
+	assert.Equal([][]byte{[]byte("tx0")}, internalBlk.(*Block).Txs)
+	assert.Zero(vm.mempool.Len())
+
+	// The block's changes aren't visible until it's accepted
+	assert.NoError(blk.Verify())
+	assert.NoError(vm.SetPreference(blk.ID()))
+	has, err := vm.AcceptedState().Has([]byte("tx0"))
+	assert.NoError(err)
+	assert.False(has)
+
+	// A child can be built on top of the processing block
+	assert.NoError(vm.IssueTx([]byte("tx1")))
+	child, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.Equal(blk.ID(), child.Parent())
+	assert.NoError(child.Verify())
+
+	assert.NoError(blk.Accept())
+	assert.NoError(child.Accept())
+
+	for _, key := range []string{"tx0", "tx1"} {
+		value, err := vm.AcceptedState().Get([]byte(key))
+		assert.NoError(err)
+		assert.Equal([]byte("genesis"), value)
+	}
+
+	blkID, err := vm.GetBlockIDAtHeight(1)
+	assert.NoError(err)
+	assert.Equal(blk.ID(), blkID)
+
+	lastAcceptedID, err := vm.LastAccepted()
+	assert.NoError(err)
+	assert.Equal(child.ID(), lastAcceptedID)
+	assert.NoError(vm.Shutdown())
+
+	// The accepted blocks are loaded after a restart
+	vm, _ = newTestVM(t, dbManager)
+	lastAcceptedID, err = vm.LastAccepted()
+	assert.NoError(err)
+	assert.Equal(child.ID(), lastAcceptedID)
+
+	fetched, err := vm.GetBlock(blk.ID())
+	assert.NoError(err)
+	assert.Equal(choices.Accepted, fetched.Status())
+	assert.Equal(blk.Bytes(), fetched.Bytes())
+	assert.NoError(vm.Shutdown())
+}
+
+func TestVMRejectBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _ := newTestVM(t, manager.NewMemDB(version.Semantic1_0_0))
+	defer func() {
+		assert.NoError(vm.Shutdown())
+	}()
+
+	assert.NoError(vm.IssueTx([]byte("tx0")))
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+
+	// A conflicting block is built on top of the genesis block
+	assert.NoError(vm.IssueTx([]byte("tx1")))
+	conflict, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(conflict.Verify())
+
+	assert.NoError(conflict.Accept())
+	assert.NoError(blk.Reject())
+	assert.Equal(choices.Rejected, blk.Status())
+
+	has, err := vm.AcceptedState().Has([]byte("tx0"))
+	assert.NoError(err)
+	assert.False(has)
+
+	// Blocks can't be built on top of rejected blocks
+	invalidBlk := &Block{
+		PrntID: blk.ID(),
+		Hght:   2,
+		Txs:    [][]byte{[]byte("tx2")},
+	}
+	bytes, err := c.Marshal(codecVersion, invalidBlk)
+	assert.NoError(err)
+	parsed, err := vm.ParseBlock(bytes)
+	assert.NoError(err)
+	assert.ErrorIs(parsed.Verify(), errParentDecided)
+}
+
+func TestVMVerifyInvalidBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _ := newTestVM(t, manager.NewMemDB(version.Semantic1_0_0))
+	defer func() {
+		assert.NoError(vm.Shutdown())
+	}()
+
+	genesisID, err := vm.LastAccepted()
+	assert.NoError(err)
+
+	tests := []struct {
+		name        string
+		blk         *Block
+		expectedErr error
+	}{
+		{
+			name: "no txs",
+			blk: &Block{
+				PrntID: genesisID,
+				Hght:   1,
+			},
+			expectedErr: errNoTxs,
+		},
+		{
+			name: "wrong height",
+			blk: &Block{
+				PrntID: genesisID,
+				Hght:   2,
+				Txs:    [][]byte{[]byte("tx0")},
+			},
+			expectedErr: errWrongHeight,
+		},
+		{
+			name: "invalid tx",
+			blk: &Block{
+				PrntID: genesisID,
+				Hght:   1,
+				Txs:    [][]byte{{}},
+			},
+			expectedErr: errInvalidTx,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bytes, err := c.Marshal(codecVersion, test.blk)
+			assert.NoError(err)
+			blk, err := vm.ParseBlock(bytes)
+			assert.NoError(err)
+			assert.ErrorIs(blk.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestVMGossip(t *testing.T) {
+	assert := assert.New(t)
+
+	gossiped := [][]byte(nil)
+	sender := &common.SenderTest{
+		SendAppGossipF: func(msg []byte) error {
+			gossiped = append(gossiped, msg)
+			return nil
+		},
+	}
+	vm, _ := newConfiguredTestVM(t, manager.NewMemDB(version.Semantic1_0_0), DefaultConfig, sender)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+	}()
+
+	// Issued txs are gossiped once
+	assert.NoError(vm.IssueTx([]byte("tx0")))
+	assert.NoError(vm.IssueTx([]byte("tx0")))
+	assert.Equal([][]byte{[]byte("tx0")}, gossiped)
+
+	// New valid gossiped txs are added to the mempool and gossiped onwards
+	nodeID := ids.GenerateTestNodeID()
+	assert.NoError(vm.AppGossip(nodeID, []byte("tx1")))
+	assert.Equal(2, vm.mempool.Len())
+	assert.Equal([][]byte{[]byte("tx0"), []byte("tx1")}, gossiped)
+
+	// Invalid and known txs are dropped
+	assert.NoError(vm.AppGossip(nodeID, nil))
+	assert.NoError(vm.AppGossip(nodeID, []byte("tx1")))
+	assert.Equal(2, vm.mempool.Len())
+	assert.Len(gossiped, 2)
+}
+
+func TestVMCongestion(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig
+	config.MaxBlockTxs = 2
+	sender := &common.SenderTest{
+		SendAppGossipF: func([]byte) error { return nil },
+	}
+	vm, _ := newConfiguredTestVM(t, manager.NewMemDB(version.Semantic1_0_0), config, sender)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+	}()
+
+	for i, expected := range []float64{.5, 1, 1} {
+		assert.NoError(vm.IssueTx([]byte{byte(i)}))
+		congestion, err := vm.Congestion()
+		assert.NoError(err)
+		assert.Equal(expected, congestion)
+	}
+}
+
+func TestVMStateSync(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig
+	config.StateSyncInterval = 2
+	sender := &common.SenderTest{
+		SendAppGossipF: func([]byte) error { return nil },
+	}
+	server, _ := newConfiguredTestVM(t, manager.NewMemDB(version.Semantic1_0_0), config, sender)
+	defer func() {
+		assert.NoError(server.Shutdown())
+	}()
+
+	enabled, err := server.StateSyncEnabled()
+	assert.NoError(err)
+	assert.True(enabled)
+
+	_, err = server.GetLastStateSummary()
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	acceptTx := func(tx string) *Block {
+		assert.NoError(server.IssueTx([]byte(tx)))
+		blk, err := server.BuildBlock()
+		assert.NoError(err)
+		assert.NoError(blk.Verify())
+		assert.NoError(server.SetPreference(blk.ID()))
+		assert.NoError(blk.Accept())
+
+		internalBlk, err := server.GetBlockInternal(blk.ID())
+		assert.NoError(err)
+		return internalBlk.(*Block)
+	}
+	acceptTx("tx0")
+	summaryBlk := acceptTx("tx1")
+	acceptTx("tx2")
+
+	// A summary was created at height 2
+	summary, err := server.GetLastStateSummary()
+	assert.NoError(err)
+	assert.EqualValues(2, summary.Height())
+	fetched, err := server.GetStateSummary(2)
+	assert.NoError(err)
+	assert.Equal(summary.ID(), fetched.ID())
+	_, err = server.GetStateSummary(3)
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	// A new node syncs to the summary
+	client, toEngine := newConfiguredTestVM(t, manager.NewMemDB(version.Semantic1_0_0), config, sender)
+	defer func() {
+		assert.NoError(client.Shutdown())
+	}()
+
+	parsed, err := client.ParseStateSummary(summary.Bytes())
+	assert.NoError(err)
+	assert.Equal(summary.ID(), parsed.ID())
+	assert.EqualValues(2, parsed.Height())
+
+	synced, err := parsed.Accept()
+	assert.NoError(err)
+	assert.True(synced)
+	assert.Equal(common.StateSyncDone, <-toEngine)
+
+	lastAcceptedID, err := client.LastAccepted()
+	assert.NoError(err)
+	assert.Equal(summaryBlk.ID(), lastAcceptedID)
+	for _, key := range []string{"tx0", "tx1"} {
+		has, err := client.AcceptedState().Has([]byte(key))
+		assert.NoError(err)
+		assert.True(has)
+	}
+	has, err := client.AcceptedState().Has([]byte("tx2"))
+	assert.NoError(err)
+	assert.False(has)
+
+	// The synced node serves the summary
+	clientSummary, err := client.GetLastStateSummary()
+	assert.NoError(err)
+	assert.Equal(summary.ID(), clientSummary.ID())
+
+	// The synced node executes the blocks after the summary
+	nextBlkID, err := server.GetBlockIDAtHeight(3)
+	assert.NoError(err)
+	nextBlk, err := server.GetBlock(nextBlkID)
+	assert.NoError(err)
+	blk, err := client.ParseBlock(nextBlk.Bytes())
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+	has, err = client.AcceptedState().Has([]byte("tx2"))
+	assert.NoError(err)
+	assert.True(has)
+
+	// Summaries that aren't ahead of the last accepted block are skipped
+	synced, err = parsed.Accept()
+	assert.NoError(err)
+	assert.False(synced)
+}
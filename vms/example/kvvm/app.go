@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package kvvm
+
+import (
+	"encoding/json"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms/components/snowmanvm"
+)
+
+// Version of the key-value VM
+const Version = "v0.0.1"
+
+var _ snowmanvm.App = &app{}
+
+// Genesis is the initial state of a key-value chain
+type Genesis struct {
+	Values map[string]string `json:"values"`
+}
+
+// app is a key-value store. Each tx sets the value of a single key.
+type app struct{}
+
+// NewVM returns a key-value VM
+func NewVM() *snowmanvm.VM {
+	config := snowmanvm.DefaultConfig
+	config.Version = Version
+	return snowmanvm.New(&app{}, config)
+}
+
+func (*app) Initialize(*snow.Context, []byte) error { return nil }
+
+func (*app) Genesis(db database.Database, genesisBytes []byte) error {
+	genesis := Genesis{}
+	if err := json.Unmarshal(genesisBytes, &genesis); err != nil {
+		return err
+	}
+	for key, value := range genesis.Values {
+		tx := &Tx{
+			Key:   []byte(key),
+			Value: []byte(value),
+		}
+		if err := tx.Verify(); err != nil {
+			return err
+		}
+		if err := db.Put(tx.Key, tx.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*app) ExecuteTx(db database.Database, txBytes []byte) error {
+	tx := &Tx{}
+	if _, err := c.Unmarshal(txBytes, tx); err != nil {
+		return err
+	}
+	if err := tx.Verify(); err != nil {
+		return err
+	}
+	return db.Put(tx.Key, tx.Value)
+}
+
+func (*app) CreateHandlers(vm *snowmanvm.VM) (map[string]*common.HTTPHandler, error) {
+	handler, err := snowmanvm.NewJSONHandler("kvvm", &Service{vm: vm})
+	return map[string]*common.HTTPHandler{
+		"": handler,
+	}, err
+}
@@ -0,0 +1,18 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package kvvm
+
+import (
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms"
+)
+
+var _ vms.Factory = &Factory{}
+
+// Factory creates key-value VMs
+type Factory struct{}
+
+func (*Factory) New(*snow.Context) (interface{}, error) {
+	return NewVM(), nil
+}
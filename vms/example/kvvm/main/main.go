@@ -0,0 +1,14 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"github.com/ava-labs/avalanchego/vms/example/kvvm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+)
+
+// main serves the key-value VM as a plugin
+func main() {
+	rpcchainvm.Serve(kvvm.NewVM())
+}
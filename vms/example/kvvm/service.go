@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package kvvm
+
+import (
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/snowmanvm"
+)
+
+// Service is the API service of the key-value VM
+type Service struct{ vm *snowmanvm.VM }
+
+// PutArgs are the arguments to Put
+type PutArgs struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Put issues a tx that sets the value of [args.Key] to [args.Value]
+func (s *Service) Put(_ *http.Request, args *PutArgs, reply *api.JSONTxID) error {
+	tx := &Tx{
+		Key:   []byte(args.Key),
+		Value: []byte(args.Value),
+	}
+	if err := tx.Verify(); err != nil {
+		return err
+	}
+
+	txBytes, err := c.Marshal(codecVersion, tx)
+	if err != nil {
+		return err
+	}
+	if err := s.vm.IssueTx(txBytes); err != nil {
+		return err
+	}
+
+	reply.TxID = hashing.ComputeHash256Array(txBytes)
+	return nil
+}
+
+// GetArgs are the arguments to Get
+type GetArgs struct {
+	Key string `json:"key"`
+}
+
+// GetReply is the response from Get
+type GetReply struct {
+	Value string `json:"value"`
+	Found bool   `json:"found"`
+}
+
+// Get returns the accepted value of [args.Key]
+func (s *Service) Get(_ *http.Request, args *GetArgs, reply *GetReply) error {
+	value, err := s.vm.AcceptedState().Get([]byte(args.Key))
+	switch err {
+	case nil:
+		reply.Value = string(value)
+		reply.Found = true
+		return nil
+	case database.ErrNotFound:
+		return nil
+	default:
+		return err
+	}
+}
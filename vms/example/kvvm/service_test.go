@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package kvvm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestService(t *testing.T) {
+	assert := assert.New(t)
+
+	vm := NewVM()
+	err := vm.Initialize(
+		snow.DefaultContextTest(),
+		manager.NewMemDB(version.Semantic1_0_0),
+		[]byte(`{"values":{"genesis key":"genesis value"}}`),
+		nil,
+		nil,
+		make(chan common.Message, 1),
+		nil,
+		&common.SenderTest{
+			SendAppGossipF: func([]byte) error { return nil },
+		},
+	)
+	assert.NoError(err)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+	}()
+
+	s := &Service{vm: vm}
+
+	getReply := GetReply{}
+	assert.NoError(s.Get(nil, &GetArgs{Key: "genesis key"}, &getReply))
+	assert.True(getReply.Found)
+	assert.Equal("genesis value", getReply.Value)
+
+	assert.ErrorIs(s.Put(nil, &PutArgs{}, &api.JSONTxID{}), errEmptyKey)
+	assert.NoError(s.Put(nil, &PutArgs{Key: "key", Value: "value"}, &api.JSONTxID{}))
+
+	// The value isn't set until the tx is accepted
+	getReply = GetReply{}
+	assert.NoError(s.Get(nil, &GetArgs{Key: "key"}, &getReply))
+	assert.False(getReply.Found)
+
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+
+	assert.NoError(s.Get(nil, &GetArgs{Key: "key"}, &getReply))
+	assert.True(getReply.Found)
+	assert.Equal("value", getReply.Value)
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package kvvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	codecVersion = 0
+
+	maxKeySize   = 256
+	maxValueSize = 4 * units.KiB
+)
+
+var (
+	errEmptyKey      = errors.New("key is empty")
+	errKeyTooLarge   = errors.New("key is too large")
+	errValueTooLarge = errors.New("value is too large")
+
+	c codec.Manager
+)
+
+func init() {
+	lc := linearcodec.NewDefault()
+	c = codec.NewDefaultManager()
+
+	if err := c.RegisterCodec(codecVersion, lc); err != nil {
+		panic(err)
+	}
+}
+
+// Tx sets the value of a key
+type Tx struct {
+	Key   []byte `serialize:"true"`
+	Value []byte `serialize:"true"`
+}
+
+func (tx *Tx) Verify() error {
+	switch {
+	case len(tx.Key) == 0:
+		return errEmptyKey
+	case len(tx.Key) > maxKeySize:
+		return fmt.Errorf("%w: %d > %d", errKeyTooLarge, len(tx.Key), maxKeySize)
+	case len(tx.Value) > maxValueSize:
+		return fmt.Errorf("%w: %d > %d", errValueTooLarge, len(tx.Value), maxValueSize)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package xsvm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/snowmanvm"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	// Version of the transfer VM
+	Version = "v0.0.1"
+
+	// Number of blocks between state summaries
+	stateSyncInterval = 256
+)
+
+var (
+	errWrongChainID      = errors.New("tx was issued for a different chain")
+	errWrongNonce        = errors.New("wrong nonce")
+	errInsufficientFunds = errors.New("insufficient funds")
+
+	_ snowmanvm.App = &app{}
+)
+
+// Genesis is the initial state of a transfer chain
+type Genesis struct {
+	Allocations []Allocation `json:"allocations"`
+}
+
+// Allocation is the initial balance of an address
+type Allocation struct {
+	Address ids.ShortID  `json:"address"`
+	Balance cjson.Uint64 `json:"balance"`
+}
+
+// app is a minimal token ledger. Each tx transfers tokens from its signer to
+// another address.
+type app struct {
+	chainID ids.ID
+	factory crypto.FactorySECP256K1R
+}
+
+// NewVM returns a transfer VM. Issued txs are gossiped to other validators and
+// new nodes can state sync.
+func NewVM() *snowmanvm.VM {
+	config := snowmanvm.DefaultConfig
+	config.Version = Version
+	config.StateSyncInterval = stateSyncInterval
+	return snowmanvm.New(&app{}, config)
+}
+
+func (a *app) Initialize(ctx *snow.Context, _ []byte) error {
+	a.chainID = ctx.ChainID
+	return nil
+}
+
+func (*app) Genesis(db database.Database, genesisBytes []byte) error {
+	genesis := Genesis{}
+	if err := json.Unmarshal(genesisBytes, &genesis); err != nil {
+		return err
+	}
+	for _, allocation := range genesis.Allocations {
+		balance, err := GetBalance(db, allocation.Address)
+		if err != nil {
+			return err
+		}
+		balance, err = safemath.Add(balance, uint64(allocation.Balance))
+		if err != nil {
+			return err
+		}
+		if err := setBalance(db, allocation.Address, balance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *app) ExecuteTx(db database.Database, txBytes []byte) error {
+	tx, err := ParseTx(txBytes)
+	if err != nil {
+		return err
+	}
+
+	transfer := &tx.Transfer
+	if err := transfer.Verify(); err != nil {
+		return err
+	}
+	if transfer.ChainID != a.chainID {
+		return errWrongChainID
+	}
+
+	sender, err := tx.Sender(&a.factory)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := GetNonce(db, sender)
+	if err != nil {
+		return err
+	}
+	if transfer.Nonce != nonce {
+		return fmt.Errorf("%w: expected %d but got %d", errWrongNonce, nonce, transfer.Nonce)
+	}
+	nonce, err = safemath.Add(nonce, 1)
+	if err != nil {
+		return err
+	}
+	if err := setNonce(db, sender, nonce); err != nil {
+		return err
+	}
+
+	senderBalance, err := GetBalance(db, sender)
+	if err != nil {
+		return err
+	}
+	if senderBalance < transfer.Amount {
+		return fmt.Errorf("%w: %d < %d", errInsufficientFunds, senderBalance, transfer.Amount)
+	}
+	if err := setBalance(db, sender, senderBalance-transfer.Amount); err != nil {
+		return err
+	}
+
+	// The recipient's balance is read after the sender's balance is updated
+	// in case the sender is transferring to itself.
+	recipientBalance, err := GetBalance(db, transfer.To)
+	if err != nil {
+		return err
+	}
+	recipientBalance, err = safemath.Add(recipientBalance, transfer.Amount)
+	if err != nil {
+		return err
+	}
+	return setBalance(db, transfer.To, recipientBalance)
+}
+
+func (*app) CreateHandlers(vm *snowmanvm.VM) (map[string]*common.HTTPHandler, error) {
+	handler, err := snowmanvm.NewJSONHandler("xsvm", &Service{vm: vm})
+	return map[string]*common.HTTPHandler{
+		"": handler,
+	}, err
+}
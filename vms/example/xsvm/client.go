@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package xsvm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var _ Client = &client{}
+
+// Client for interacting with a transfer chain
+type Client interface {
+	// IssueTx issues a signed transfer and returns its ID
+	IssueTx(ctx context.Context, tx *Tx, options ...rpc.Option) (ids.ID, error)
+	// GetAccount returns the accepted balance and nonce of [addr]
+	GetAccount(ctx context.Context, addr ids.ShortID, options ...rpc.Option) (balance uint64, nonce uint64, err error)
+}
+
+type client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a client to interact with the transfer chain [chain] on
+// the node at [uri]
+func NewClient(uri, chain string) Client {
+	path := fmt.Sprintf(
+		"%s/ext/%s/%s",
+		uri,
+		constants.ChainAliasPrefix,
+		chain,
+	)
+	return &client{
+		requester: rpc.NewEndpointRequester(path, "xsvm"),
+	}
+}
+
+func (c *client) IssueTx(ctx context.Context, tx *Tx, options ...rpc.Option) (ids.ID, error) {
+	txBytes, err := tx.Bytes()
+	if err != nil {
+		return ids.Empty, err
+	}
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return ids.Empty, err
+	}
+	res := &api.JSONTxID{}
+	err = c.requester.SendRequest(ctx, "issueTx", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res.TxID, err
+}
+
+func (c *client) GetAccount(ctx context.Context, addr ids.ShortID, options ...rpc.Option) (uint64, uint64, error) {
+	res := &GetAccountReply{}
+	err := c.requester.SendRequest(ctx, "getAccount", &GetAccountArgs{
+		Address: addr,
+	}, res, options...)
+	return uint64(res.Balance), uint64(res.Nonce), err
+}
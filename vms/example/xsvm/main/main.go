@@ -0,0 +1,14 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"github.com/ava-labs/avalanchego/vms/example/xsvm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+)
+
+// main serves the transfer VM as a plugin
+func main() {
+	rpcchainvm.Serve(xsvm.NewVM())
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package xsvm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/components/snowmanvm"
+)
+
+// Service is the API service of the transfer VM
+type Service struct{ vm *snowmanvm.VM }
+
+// IssueTx issues a signed transfer
+func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, reply *api.JSONTxID) error {
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	tx, err := ParseTx(txBytes)
+	if err != nil {
+		return fmt.Errorf("problem parsing transaction: %w", err)
+	}
+	if err := tx.Transfer.Verify(); err != nil {
+		return err
+	}
+	if err := s.vm.IssueTx(txBytes); err != nil {
+		return err
+	}
+
+	reply.TxID = hashing.ComputeHash256Array(txBytes)
+	return nil
+}
+
+// GetAccountArgs are the arguments to GetAccount
+type GetAccountArgs struct {
+	Address ids.ShortID `json:"address"`
+}
+
+// GetAccountReply is the response from GetAccount
+type GetAccountReply struct {
+	Balance json.Uint64 `json:"balance"`
+	Nonce   json.Uint64 `json:"nonce"`
+}
+
+// GetAccount returns the accepted balance and nonce of [args.Address]
+func (s *Service) GetAccount(_ *http.Request, args *GetAccountArgs, reply *GetAccountReply) error {
+	db := s.vm.AcceptedState()
+	balance, err := GetBalance(db, args.Address)
+	if err != nil {
+		return err
+	}
+	nonce, err := GetNonce(db, args.Address)
+	if err != nil {
+		return err
+	}
+
+	reply.Balance = json.Uint64(balance)
+	reply.Nonce = json.Uint64(nonce)
+	return nil
+}
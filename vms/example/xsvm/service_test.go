@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package xsvm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/version"
+
+	cjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+func newTestKey(t *testing.T) *crypto.PrivateKeySECP256K1R {
+	factory := crypto.FactorySECP256K1R{}
+	key, err := factory.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key.(*crypto.PrivateKeySECP256K1R)
+}
+
+func newTestGenesis(t *testing.T, addr ids.ShortID, balance uint64) []byte {
+	genesisBytes, err := json.Marshal(&Genesis{
+		Allocations: []Allocation{{
+			Address: addr,
+			Balance: cjson.Uint64(balance),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return genesisBytes
+}
+
+func TestService(t *testing.T) {
+	assert := assert.New(t)
+
+	key := newTestKey(t)
+	sender := key.PublicKey().Address()
+	recipient := ids.GenerateTestShortID()
+
+	ctx := snow.DefaultContextTest()
+	vm := NewVM()
+	err := vm.Initialize(
+		ctx,
+		manager.NewMemDB(version.Semantic1_0_0),
+		newTestGenesis(t, sender, 100),
+		nil,
+		nil,
+		make(chan common.Message, 1),
+		nil,
+		&common.SenderTest{
+			SendAppGossipF: func([]byte) error { return nil },
+		},
+	)
+	assert.NoError(err)
+	defer func() {
+		assert.NoError(vm.Shutdown())
+	}()
+
+	s := &Service{vm: vm}
+
+	accountReply := GetAccountReply{}
+	assert.NoError(s.GetAccount(nil, &GetAccountArgs{Address: sender}, &accountReply))
+	assert.EqualValues(100, accountReply.Balance)
+	assert.EqualValues(0, accountReply.Nonce)
+
+	tx, err := Sign(&Transfer{
+		ChainID: ctx.ChainID,
+		Nonce:   0,
+		To:      recipient,
+		Amount:  40,
+	}, key)
+	assert.NoError(err)
+	txBytes, err := tx.Bytes()
+	assert.NoError(err)
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	assert.NoError(err)
+
+	assert.NoError(s.IssueTx(nil, &api.FormattedTx{Tx: txStr, Encoding: formatting.Hex}, &api.JSONTxID{}))
+
+	blk, err := vm.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+	assert.NoError(blk.Accept())
+
+	accountReply = GetAccountReply{}
+	assert.NoError(s.GetAccount(nil, &GetAccountArgs{Address: sender}, &accountReply))
+	assert.EqualValues(60, accountReply.Balance)
+	assert.EqualValues(1, accountReply.Nonce)
+
+	accountReply = GetAccountReply{}
+	assert.NoError(s.GetAccount(nil, &GetAccountArgs{Address: recipient}, &accountReply))
+	assert.EqualValues(40, accountReply.Balance)
+	assert.EqualValues(0, accountReply.Nonce)
+}
+
+func TestExecuteTx(t *testing.T) {
+	key := newTestKey(t)
+	sender := key.PublicKey().Address()
+	chainID := ids.GenerateTestID()
+	recipient := ids.GenerateTestShortID()
+
+	tests := []struct {
+		name        string
+		transfer    Transfer
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			transfer: Transfer{
+				ChainID: chainID,
+				Nonce:   1,
+				To:      recipient,
+				Amount:  10,
+			},
+		},
+		{
+			name: "zero amount",
+			transfer: Transfer{
+				ChainID: chainID,
+				Nonce:   1,
+				To:      recipient,
+			},
+			expectedErr: errZeroAmount,
+		},
+		{
+			name: "wrong chain",
+			transfer: Transfer{
+				ChainID: ids.GenerateTestID(),
+				Nonce:   1,
+				To:      recipient,
+				Amount:  10,
+			},
+			expectedErr: errWrongChainID,
+		},
+		{
+			name: "replayed nonce",
+			transfer: Transfer{
+				ChainID: chainID,
+				Nonce:   0,
+				To:      recipient,
+				Amount:  10,
+			},
+			expectedErr: errWrongNonce,
+		},
+		{
+			name: "insufficient funds",
+			transfer: Transfer{
+				ChainID: chainID,
+				Nonce:   1,
+				To:      recipient,
+				Amount:  101,
+			},
+			expectedErr: errInsufficientFunds,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			a := &app{chainID: chainID}
+			db := memdb.New()
+			assert.NoError(a.Genesis(db, newTestGenesis(t, sender, 100)))
+			assert.NoError(setNonce(db, sender, 1))
+
+			tx, err := Sign(&test.transfer, key)
+			assert.NoError(err)
+			txBytes, err := tx.Bytes()
+			assert.NoError(err)
+
+			err = a.ExecuteTx(db, txBytes)
+			assert.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
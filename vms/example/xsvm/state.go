@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package xsvm
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	balancePrefix = []byte("balance")
+	noncePrefix   = []byte("nonce")
+)
+
+// GetBalance returns the balance of [addr] in [db]
+func GetBalance(db database.KeyValueReader, addr ids.ShortID) (uint64, error) {
+	return getUInt64(db, balancePrefix, addr)
+}
+
+// GetNonce returns the number of txs issued by [addr] in [db]
+func GetNonce(db database.KeyValueReader, addr ids.ShortID) (uint64, error) {
+	return getUInt64(db, noncePrefix, addr)
+}
+
+func setBalance(db database.KeyValueWriterDeleter, addr ids.ShortID, balance uint64) error {
+	return setUInt64(db, balancePrefix, addr, balance)
+}
+
+func setNonce(db database.KeyValueWriterDeleter, addr ids.ShortID, nonce uint64) error {
+	return setUInt64(db, noncePrefix, addr, nonce)
+}
+
+// getUInt64 returns the value of [addr] under [prefix]. Missing values are 0.
+func getUInt64(db database.KeyValueReader, prefix []byte, addr ids.ShortID) (uint64, error) {
+	value, err := database.GetUInt64(db, stateKey(prefix, addr))
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return value, err
+}
+
+// setUInt64 sets the value of [addr] under [prefix]. Values of 0 are deleted.
+func setUInt64(db database.KeyValueWriterDeleter, prefix []byte, addr ids.ShortID, value uint64) error {
+	key := stateKey(prefix, addr)
+	if value == 0 {
+		return db.Delete(key)
+	}
+	return database.PutUInt64(db, key, value)
+}
+
+func stateKey(prefix []byte, addr ids.ShortID) []byte {
+	key := make([]byte, len(prefix)+len(addr))
+	copy(key, prefix)
+	copy(key[len(prefix):], addr[:])
+	return key
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package xsvm
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+const codecVersion = 0
+
+var (
+	errZeroAmount = errors.New("transfer amount is zero")
+
+	c codec.Manager
+)
+
+func init() {
+	lc := linearcodec.NewDefault()
+	c = codec.NewDefaultManager()
+
+	if err := c.RegisterCodec(codecVersion, lc); err != nil {
+		panic(err)
+	}
+}
+
+// Transfer moves [Amount] from the signer of the tx to [To]
+type Transfer struct {
+	// ChainID prevents a transfer from being replayed on another chain
+	ChainID ids.ID `serialize:"true" json:"chainID"`
+	// Nonce must equal the number of txs previously issued by the signer
+	Nonce  uint64      `serialize:"true" json:"nonce"`
+	To     ids.ShortID `serialize:"true" json:"to"`
+	Amount uint64      `serialize:"true" json:"amount"`
+}
+
+// Verify returns nil iff this transfer is well formed
+func (t *Transfer) Verify() error {
+	if t.Amount == 0 {
+		return errZeroAmount
+	}
+	return nil
+}
+
+// Tx is a signed transfer
+type Tx struct {
+	Transfer  Transfer                      `serialize:"true" json:"transfer"`
+	Signature [crypto.SECP256K1RSigLen]byte `serialize:"true" json:"signature"`
+}
+
+// Sign returns [transfer] signed by [key]
+func Sign(transfer *Transfer, key *crypto.PrivateKeySECP256K1R) (*Tx, error) {
+	transferBytes, err := c.Marshal(codecVersion, transfer)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := key.Sign(transferBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Tx{Transfer: *transfer}
+	copy(tx.Signature[:], sig)
+	return tx, nil
+}
+
+// Sender returns the address of the signer of this tx
+func (tx *Tx) Sender(factory *crypto.FactorySECP256K1R) (ids.ShortID, error) {
+	transferBytes, err := c.Marshal(codecVersion, &tx.Transfer)
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	pk, err := factory.RecoverHashPublicKey(hashing.ComputeHash256(transferBytes), tx.Signature[:])
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	return pk.Address(), nil
+}
+
+// Bytes returns the serialized form of this tx
+func (tx *Tx) Bytes() ([]byte, error) {
+	return c.Marshal(codecVersion, tx)
+}
+
+// ParseTx parses a tx from its serialized form
+func ParseTx(txBytes []byte) (*Tx, error) {
+	tx := &Tx{}
+	_, err := c.Unmarshal(txBytes, tx)
+	return tx, err
+}
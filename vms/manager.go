@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 )
 
 var (
@@ -23,6 +24,34 @@ type Factory interface {
 	New(*snow.Context) (interface{}, error)
 }
 
+// Features are the optional features that a VM supports
+type Features struct {
+	StateSync   bool `json:"stateSync"`
+	HeightIndex bool `json:"heightIndex"`
+}
+
+// Plugin is implemented by VMs that run in a separate process
+type Plugin interface {
+	// ProtocolVersion returns the version of the protocol the node uses to
+	// communicate with the VM
+	ProtocolVersion() uint
+
+	// Features returns the optional features that the VM supports. Returns
+	// nil if the VM doesn't report its features.
+	Features() (*Features, error)
+}
+
+// Info describes a registered VM
+type Info struct {
+	// Version reported by the VM
+	Version string
+	// Version of the protocol used to communicate with the VM. 0 if the VM
+	// isn't a Plugin.
+	ProtocolVersion uint
+	// Features that the VM supports. nil if they are unknown.
+	Features *Features
+}
+
 // Manager tracks a collection of VM factories, their aliases, and their
 // versions.
 // It has the following functionality:
@@ -33,6 +62,7 @@ type Factory interface {
 //      the factory that the ID is associated with.
 //   3) Manage the aliases of VMs
 //   3) Manage the versions of VMs
+//   4) Report the protocol versions and features of VMs
 type Manager interface {
 	ids.Aliaser
 
@@ -50,6 +80,10 @@ type Manager interface {
 	// Versions returns the primary alias of the VM mapped to the reported
 	// version of the VM for all the registered VMs that reported versions.
 	Versions() (map[string]string, error)
+
+	// Infos returns the information reported by each registered VM that
+	// implements common.VM
+	Infos() map[ids.ID]Info
 }
 
 type manager struct {
@@ -62,8 +96,8 @@ type manager struct {
 	factories map[ids.ID]Factory
 
 	// Key: A VM's ID
-	// Value: version the VM returned
-	versions map[ids.ID]string
+	// Value: version, protocol version and features the VM reported
+	infos map[ids.ID]Info
 }
 
 // NewManager returns an instance of a VM manager
@@ -71,7 +105,7 @@ func NewManager() Manager {
 	return &manager{
 		Aliaser:   ids.NewAliaser(),
 		factories: make(map[ids.ID]Factory),
-		versions:  make(map[ids.ID]string),
+		infos:     make(map[ids.ID]Info),
 	}
 }
 
@@ -102,17 +136,45 @@ func (m *manager) RegisterFactory(vmID ids.ID, factory Factory) error {
 		return nil
 	}
 
-	version, err := commonVM.Version()
+	info, err := getInfo(commonVM)
 	if err != nil {
 		// Drop the shutdown error to surface the original error
 		_ = commonVM.Shutdown()
 		return err
 	}
 
-	m.versions[vmID] = version
+	m.infos[vmID] = info
 	return commonVM.Shutdown()
 }
 
+// getInfo returns the information reported by an uninitialized [vm]
+func getInfo(vm common.VM) (Info, error) {
+	version, err := vm.Version()
+	if err != nil {
+		return Info{}, err
+	}
+
+	plugin, ok := vm.(Plugin)
+	if !ok {
+		_, stateSync := vm.(block.StateSyncableVM)
+		_, heightIndex := vm.(block.HeightIndexedChainVM)
+		return Info{
+			Version: version,
+			Features: &Features{
+				StateSync:   stateSync,
+				HeightIndex: heightIndex,
+			},
+		}, nil
+	}
+
+	features, err := plugin.Features()
+	return Info{
+		Version:         version,
+		ProtocolVersion: plugin.ProtocolVersion(),
+		Features:        features,
+	}, err
+}
+
 func (m *manager) ListFactories() ([]ids.ID, error) {
 	vmIDs := make([]ids.ID, 0, len(m.factories))
 	for vmID := range m.factories {
@@ -122,13 +184,21 @@ func (m *manager) ListFactories() ([]ids.ID, error) {
 }
 
 func (m *manager) Versions() (map[string]string, error) {
-	versions := make(map[string]string, len(m.versions))
-	for vmID, version := range m.versions {
+	versions := make(map[string]string, len(m.infos))
+	for vmID, info := range m.infos {
 		alias, err := m.PrimaryAlias(vmID)
 		if err != nil {
 			return nil, err
 		}
-		versions[alias] = version
+		versions[alias] = info.Version
 	}
 	return versions, nil
 }
+
+func (m *manager) Infos() map[ids.ID]Info {
+	infos := make(map[ids.ID]Info, len(m.infos))
+	for vmID, info := range m.infos {
+		infos[vmID] = info
+	}
+	return infos
+}
@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metervm
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
+)
+
+func (vm *vertexVM) TxAddresses(txBytes []byte) ([]ids.ShortID, error) {
+	if vm.aVM == nil {
+		return nil, vertex.ErrAddressIndexedVMNotImplemented
+	}
+
+	start := vm.clock.Time()
+	addrs, err := vm.aVM.TxAddresses(txBytes)
+	end := vm.clock.Time()
+	vm.vertexMetrics.txAddresses.Observe(float64(end.Sub(start)))
+	return addrs, err
+}
@@ -19,7 +19,8 @@ type vertexMetrics struct {
 	verify,
 	verifyErr,
 	accept,
-	reject metric.Averager
+	reject,
+	txAddresses metric.Averager
 }
 
 func (m *vertexMetrics) Initialize(
@@ -36,5 +37,6 @@ func (m *vertexMetrics) Initialize(
 	m.verifyErr = newAverager(namespace, "verify_tx_err", reg, &errs)
 	m.accept = newAverager(namespace, "accept", reg, &errs)
 	m.reject = newAverager(namespace, "reject", reg, &errs)
+	m.txAddresses = newAverager(namespace, "tx_addresses", reg, &errs)
 	return errs.Err
 }
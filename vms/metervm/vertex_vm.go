@@ -17,18 +17,22 @@ import (
 )
 
 var (
-	_ vertex.DAGVM = &vertexVM{}
-	_ snowstorm.Tx = &meterTx{}
+	_ vertex.DAGVM               = &vertexVM{}
+	_ vertex.AddressIndexedDAGVM = &vertexVM{}
+	_ snowstorm.Tx               = &meterTx{}
 )
 
 func NewVertexVM(vm vertex.DAGVM) vertex.DAGVM {
+	aVM, _ := vm.(vertex.AddressIndexedDAGVM)
 	return &vertexVM{
 		DAGVM: vm,
+		aVM:   aVM,
 	}
 }
 
 type vertexVM struct {
 	vertex.DAGVM
+	aVM vertex.AddressIndexedDAGVM
 	vertexMetrics
 	clock mockable.Clock
 }
@@ -50,6 +50,58 @@ func (mr *MockFactoryMockRecorder) New(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockFactory)(nil).New), arg0)
 }
 
+// MockPlugin is a mock of Plugin interface.
+type MockPlugin struct {
+	ctrl     *gomock.Controller
+	recorder *MockPluginMockRecorder
+}
+
+// MockPluginMockRecorder is the mock recorder for MockPlugin.
+type MockPluginMockRecorder struct {
+	mock *MockPlugin
+}
+
+// NewMockPlugin creates a new mock instance.
+func NewMockPlugin(ctrl *gomock.Controller) *MockPlugin {
+	mock := &MockPlugin{ctrl: ctrl}
+	mock.recorder = &MockPluginMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlugin) EXPECT() *MockPluginMockRecorder {
+	return m.recorder
+}
+
+// Features mocks base method.
+func (m *MockPlugin) Features() (*Features, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Features")
+	ret0, _ := ret[0].(*Features)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Features indicates an expected call of Features.
+func (mr *MockPluginMockRecorder) Features() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Features", reflect.TypeOf((*MockPlugin)(nil).Features))
+}
+
+// ProtocolVersion mocks base method.
+func (m *MockPlugin) ProtocolVersion() uint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProtocolVersion")
+	ret0, _ := ret[0].(uint)
+	return ret0
+}
+
+// ProtocolVersion indicates an expected call of ProtocolVersion.
+func (mr *MockPluginMockRecorder) ProtocolVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProtocolVersion", reflect.TypeOf((*MockPlugin)(nil).ProtocolVersion))
+}
+
 // MockManager is a mock of Manager interface.
 type MockManager struct {
 	ctrl     *gomock.Controller
@@ -117,6 +169,20 @@ func (mr *MockManagerMockRecorder) GetFactory(vmID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFactory", reflect.TypeOf((*MockManager)(nil).GetFactory), vmID)
 }
 
+// Infos mocks base method.
+func (m *MockManager) Infos() map[ids.ID]Info {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Infos")
+	ret0, _ := ret[0].(map[ids.ID]Info)
+	return ret0
+}
+
+// Infos indicates an expected call of Infos.
+func (mr *MockManagerMockRecorder) Infos() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Infos", reflect.TypeOf((*MockManager)(nil).Infos))
+}
+
 // ListFactories mocks base method.
 func (m *MockManager) ListFactories() ([]ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -190,6 +256,20 @@ func (mr *MockManagerMockRecorder) RegisterFactory(vmID, factory interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFactory", reflect.TypeOf((*MockManager)(nil).RegisterFactory), vmID, factory)
 }
 
+// RemoveAlias mocks base method.
+func (m *MockManager) RemoveAlias(alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAlias", alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAlias indicates an expected call of RemoveAlias.
+func (mr *MockManagerMockRecorder) RemoveAlias(alias interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAlias", reflect.TypeOf((*MockManager)(nil).RemoveAlias), alias)
+}
+
 // RemoveAliases mocks base method.
 func (m *MockManager) RemoveAliases(id ids.ID) {
 	m.ctrl.T.Helper()
@@ -82,6 +82,10 @@ type PrimaryValidator struct {
 	Uptime             *json.Float32 `json:"uptime,omitempty"`
 	Connected          bool          `json:"connected"`
 	Staked             []UTXO        `json:"staked,omitempty"`
+	// Number of delegators delegating to this validator, if known
+	DelegatorCount *json.Uint64 `json:"delegatorCount,omitempty"`
+	// Total weight delegated to this validator, if known
+	DelegatorWeight *json.Uint64 `json:"delegatorWeight,omitempty"`
 	// The delegators delegating to this validator
 	Delegators []PrimaryDelegator `json:"delegators"`
 }
@@ -235,7 +239,7 @@ func (ss *StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, r
 			}
 			stake[i] = utxo
 
-			newWeight, err := math.Add64(weight, uint64(apiUTXO.Amount))
+			newWeight, err := math.Add(weight, uint64(apiUTXO.Amount))
 			if err != nil {
 				return errStakeOverflow
 			}
@@ -162,6 +162,7 @@ func (ab *AtomicBlock) Accept() error {
 	if err = ab.vm.ctx.SharedMemory.Apply(ab.atomicRequests, batch); err != nil {
 		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
 	}
+	ab.vm.publishAccepted(ab, true)
 
 	for _, child := range ab.children {
 		child.setBaseState()
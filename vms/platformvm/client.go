@@ -11,10 +11,13 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
@@ -56,6 +59,10 @@ type Client interface {
 		startUTXOID ids.ID,
 		options ...rpc.Option,
 	) ([][]byte, ids.ShortID, ids.ID, error)
+	// GetUTXOsByID returns the byte representation and the status of each of
+	// the UTXOs in [utxoIDs]. The byte representation is nil unless the UTXO
+	// is unspent.
+	GetUTXOsByID(ctx context.Context, utxoIDs []*avax.UTXOID, options ...rpc.Option) ([][]byte, []string, error)
 	// GetSubnets returns information about the specified subnets
 	GetSubnets(context.Context, []ids.ID, ...rpc.Option) ([]ClientSubnet, error)
 	// GetStakingAssetID returns the assetID of the asset used for staking on
@@ -63,10 +70,40 @@ type Client interface {
 	GetStakingAssetID(context.Context, ids.ID, ...rpc.Option) (ids.ID, error)
 	// GetCurrentValidators returns the list of current validators for subnet with ID [subnetID]
 	GetCurrentValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]ClientPrimaryValidator, error)
+	// GetCurrentValidatorsPage returns at most [limit] current validators for
+	// subnet with ID [subnetID], in order of their node IDs, starting at
+	// [startNodeID]. If [omitDelegators], the delegators of each validator
+	// aren't returned. If the returned node ID is non-nil, the next page starts
+	// with it.
+	GetCurrentValidatorsPage(
+		ctx context.Context,
+		subnetID ids.ID,
+		startNodeID ids.NodeID,
+		limit uint32,
+		omitDelegators bool,
+		options ...rpc.Option,
+	) ([]ClientPrimaryValidator, *ids.NodeID, error)
 	// GetPendingValidators returns the list of pending validators for subnet with ID [subnetID]
 	GetPendingValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]interface{}, []interface{}, error)
+	// GetStakersByRewardAddress returns at most [limit] validators and
+	// delegators whose rewards are paid to [addr], starting after the staker
+	// added by [startTxID]. If [pending] is true, pending stakers are returned
+	// rather than current stakers. The ID of the last returned staker's
+	// transaction is returned to fetch the next page.
+	GetStakersByRewardAddress(
+		ctx context.Context,
+		addr ids.ShortID,
+		pending bool,
+		startTxID ids.ID,
+		limit uint32,
+		options ...rpc.Option,
+	) ([]interface{}, []interface{}, ids.ID, error)
 	// GetCurrentSupply returns an upper bound on the supply of AVAX in the system
 	GetCurrentSupply(ctx context.Context, options ...rpc.Option) (uint64, error)
+	// EstimateReward returns the reward that a staker of subnet [subnetID]
+	// staking [amount] from [startTime] until [endTime] would receive based on
+	// the current supply
+	EstimateReward(ctx context.Context, amount, startTime, endTime uint64, subnetID ids.ID, options ...rpc.Option) (uint64, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
 	SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error)
 	// AddValidator issues a transaction to add a validator to the primary network
@@ -112,6 +149,30 @@ type Client interface {
 		endTime uint64,
 		options ...rpc.Option,
 	) (ids.ID, error)
+	// RemoveSubnetValidator issues a transaction to remove validator [nodeID]
+	// from subnet with ID [subnetID] and returns the txID
+	RemoveSubnetValidator(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		subnetID ids.ID,
+		nodeID ids.NodeID,
+		options ...rpc.Option,
+	) (ids.ID, error)
+	// RegisterBLSKey issues a transaction to register the BLS key of node
+	// [nodeID], which the node authorized with [nodeAuth], and returns the
+	// txID
+	RegisterBLSKey(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		nodeID ids.NodeID,
+		pop *signer.ProofOfPossession,
+		nodeAuth *signer.NodeAuthorization,
+		options ...rpc.Option,
+	) (ids.ID, error)
 	// CreateSubnet issues a transaction to create [subnet] and returns the txID
 	CreateSubnet(
 		ctx context.Context,
@@ -166,6 +227,9 @@ type Client interface {
 	GetBlockchains(ctx context.Context, options ...rpc.Option) ([]APIBlockchain, error)
 	// IssueTx issues the transaction and returns its txID
 	IssueTx(ctx context.Context, tx []byte, options ...rpc.Option) (ids.ID, error)
+	// EstimateFee returns the fee, in nAVAX, that must be burned by the,
+	// possibly unsigned, tx [txBytes] if it were issued now
+	EstimateFee(ctx context.Context, txBytes []byte, options ...rpc.Option) (uint64, error)
 	// GetTx returns the byte representation of the transaction corresponding to [txID]
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
@@ -181,6 +245,17 @@ type Client interface {
 	// GetStake returns the amount of nAVAX that [addrs] have cumulatively
 	// staked on the Primary Network.
 	GetStake(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (uint64, [][]byte, error)
+	// GetStakePage returns the amount of nAVAX staked by at most [limit]
+	// stakers with stake owned by [addrs], in order of their tx IDs, starting
+	// at [startTxID]. If the returned tx ID is non-nil, the next page starts
+	// with it.
+	GetStakePage(
+		ctx context.Context,
+		addrs []ids.ShortID,
+		startTxID ids.ID,
+		limit uint32,
+		options ...rpc.Option,
+	) (uint64, [][]byte, *ids.ID, error)
 	// GetMinStake returns the minimum staking amount in nAVAX for validators
 	// and delegators respectively
 	GetMinStake(ctx context.Context, options ...rpc.Option) (uint64, uint64, error)
@@ -203,8 +278,13 @@ type Client interface {
 	// GetValidatorsAt returns the weights of the validator set of a provided subnet
 	// at the specified height.
 	GetValidatorsAt(ctx context.Context, subnetID ids.ID, height uint64, options ...rpc.Option) (map[ids.NodeID]uint64, error)
+	// GetBLSPublicKey returns the BLS public key registered by [nodeID]
+	GetBLSPublicKey(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) (*bls.PublicKey, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
+	// ExportGenesis returns the current state of the primary network in the
+	// genesis format and the height it was exported at.
+	ExportGenesis(ctx context.Context, options ...rpc.Option) (*platformapi.BuildGenesisArgs, uint64, error)
 }
 
 // Client implementation for interacting with the P Chain endpoint
@@ -324,6 +404,37 @@ func (c *client) GetAtomicUTXOs(
 	return utxos, endAddr, endUTXOID, err
 }
 
+func (c *client) GetUTXOsByID(ctx context.Context, utxoIDs []*avax.UTXOID, options ...rpc.Option) ([][]byte, []string, error) {
+	utxoIDStrs := make([]string, len(utxoIDs))
+	for i, utxoID := range utxoIDs {
+		utxoIDStrs[i] = utxoID.String()
+	}
+
+	res := &api.GetUTXOsByIDReply{}
+	err := c.requester.SendRequest(ctx, "getUTXOsByID", &api.GetUTXOsByIDArgs{
+		UTXOIDs:  utxoIDStrs,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	utxos := make([][]byte, len(res.UTXOs))
+	statuses := make([]string, len(res.UTXOs))
+	for i, utxo := range res.UTXOs {
+		statuses[i] = utxo.Status
+		if utxo.Status != api.UTXOUnspent {
+			continue
+		}
+		utxoBytes, err := formatting.Decode(res.Encoding, utxo.UTXO)
+		if err != nil {
+			return nil, nil, err
+		}
+		utxos[i] = utxoBytes
+	}
+	return utxos, statuses, nil
+}
+
 // ClientSubnet is a representation of a subnet used in client methods
 type ClientSubnet struct {
 	// ID of the subnet
@@ -384,6 +495,28 @@ func (c *client) GetCurrentValidators(
 	return getClientPrimaryValidators(res.Validators)
 }
 
+func (c *client) GetCurrentValidatorsPage(
+	ctx context.Context,
+	subnetID ids.ID,
+	startNodeID ids.NodeID,
+	limit uint32,
+	omitDelegators bool,
+	options ...rpc.Option,
+) ([]ClientPrimaryValidator, *ids.NodeID, error) {
+	res := &GetCurrentValidatorsReply{}
+	err := c.requester.SendRequest(ctx, "getCurrentValidators", &GetCurrentValidatorsArgs{
+		SubnetID:       subnetID,
+		StartNodeID:    startNodeID,
+		Limit:          json.Uint32(limit),
+		OmitDelegators: omitDelegators,
+	}, res, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	vdrs, err := getClientPrimaryValidators(res.Validators)
+	return vdrs, res.NextNodeID, err
+}
+
 func (c *client) GetPendingValidators(
 	ctx context.Context,
 	subnetID ids.ID,
@@ -398,12 +531,41 @@ func (c *client) GetPendingValidators(
 	return res.Validators, res.Delegators, err
 }
 
+func (c *client) GetStakersByRewardAddress(
+	ctx context.Context,
+	addr ids.ShortID,
+	pending bool,
+	startTxID ids.ID,
+	limit uint32,
+	options ...rpc.Option,
+) ([]interface{}, []interface{}, ids.ID, error) {
+	res := &GetStakersByRewardAddressReply{}
+	err := c.requester.SendRequest(ctx, "getStakersByRewardAddress", &GetStakersByRewardAddressArgs{
+		Address:   addr.String(),
+		Pending:   pending,
+		StartTxID: startTxID,
+		Limit:     json.Uint32(limit),
+	}, res, options...)
+	return res.Validators, res.Delegators, res.EndTxID, err
+}
+
 func (c *client) GetCurrentSupply(ctx context.Context, options ...rpc.Option) (uint64, error) {
 	res := &GetCurrentSupplyReply{}
 	err := c.requester.SendRequest(ctx, "getCurrentSupply", struct{}{}, res, options...)
 	return uint64(res.Supply), err
 }
 
+func (c *client) EstimateReward(ctx context.Context, amount, startTime, endTime uint64, subnetID ids.ID, options ...rpc.Option) (uint64, error) {
+	res := &EstimateRewardReply{}
+	err := c.requester.SendRequest(ctx, "estimateReward", &EstimateRewardArgs{
+		Amount:    json.Uint64(amount),
+		StartTime: json.Uint64(startTime),
+		EndTime:   json.Uint64(endTime),
+		SubnetID:  subnetID,
+	}, res, options...)
+	return uint64(res.Reward), err
+}
+
 func (c *client) SampleValidators(ctx context.Context, subnetID ids.ID, sampleSize uint16, options ...rpc.Option) ([]ids.NodeID, error) {
 	res := &SampleValidatorsReply{}
 	err := c.requester.SendRequest(ctx, "sampleValidators", &SampleValidatorsArgs{
@@ -508,6 +670,52 @@ func (c *client) AddSubnetValidator(
 	return res.TxID, err
 }
 
+func (c *client) RemoveSubnetValidator(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "removeSubnetValidator", &RemoveSubnetValidatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		NodeID:   nodeID,
+		SubnetID: subnetID.String(),
+	}, res, options...)
+	return res.TxID, err
+}
+
+func (c *client) RegisterBLSKey(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	nodeID ids.NodeID,
+	pop *signer.ProofOfPossession,
+	nodeAuth *signer.NodeAuthorization,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "registerBLSKey", &RegisterBLSKeyArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		NodeID:            nodeID,
+		Signer:            pop,
+		NodeAuthorization: nodeAuth,
+	}, res, options...)
+	return res.TxID, err
+}
+
 func (c *client) CreateSubnet(
 	ctx context.Context,
 	user api.UserPass,
@@ -656,6 +864,20 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) EstimateFee(ctx context.Context, txBytes []byte, options ...rpc.Option) (uint64, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	res := &api.EstimateFeeReply{}
+	err = c.requester.SendRequest(ctx, "estimateFee", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return uint64(res.Fee), err
+}
+
 func (c *client) GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedTx{}
 	err := c.requester.SendRequest(ctx, "getTx", &api.GetTxArgs{
@@ -704,26 +926,39 @@ func (c *client) AwaitTxDecided(ctx context.Context, txID ids.ID, freq time.Dura
 }
 
 func (c *client) GetStake(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (uint64, [][]byte, error) {
+	staked, outputs, _, err := c.GetStakePage(ctx, addrs, ids.Empty, 0, options...)
+	return staked, outputs, err
+}
+
+func (c *client) GetStakePage(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	startTxID ids.ID,
+	limit uint32,
+	options ...rpc.Option,
+) (uint64, [][]byte, *ids.ID, error) {
 	res := new(GetStakeReply)
 	err := c.requester.SendRequest(ctx, "getStake", &GetStakeArgs{
 		JSONAddresses: api.JSONAddresses{
 			Addresses: ids.ShortIDsToStrings(addrs),
 		},
-		Encoding: formatting.Hex,
+		Encoding:  formatting.Hex,
+		StartTxID: startTxID,
+		Limit:     json.Uint32(limit),
 	}, res, options...)
 	if err != nil {
-		return 0, nil, err
+		return 0, nil, nil, err
 	}
 
 	outputs := make([][]byte, len(res.Outputs))
 	for i, outputStr := range res.Outputs {
 		output, err := formatting.Decode(res.Encoding, outputStr)
 		if err != nil {
-			return 0, nil, err
+			return 0, nil, nil, err
 		}
 		outputs[i] = output
 	}
-	return uint64(res.Staked), outputs, err
+	return uint64(res.Staked), outputs, res.NextTxID, nil
 }
 
 func (c *client) GetMinStake(ctx context.Context, options ...rpc.Option) (uint64, uint64, error) {
@@ -789,6 +1024,22 @@ func (c *client) GetValidatorsAt(ctx context.Context, subnetID ids.ID, height ui
 	return res.Validators, err
 }
 
+func (c *client) GetBLSPublicKey(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) (*bls.PublicKey, error) {
+	res := &GetBLSPublicKeyReply{}
+	err := c.requester.SendRequest(ctx, "getBLSPublicKey", &GetBLSPublicKeyArgs{
+		NodeID: nodeID,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	pkBytes, err := formatting.Decode(formatting.HexNC, res.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return bls.PublicKeyFromBytes(pkBytes)
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	response := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "getBlock", &api.GetBlockArgs{
@@ -800,3 +1051,11 @@ func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Op
 
 	return formatting.Decode(response.Encoding, response.Block)
 }
+
+func (c *client) ExportGenesis(ctx context.Context, options ...rpc.Option) (*platformapi.BuildGenesisArgs, uint64, error) {
+	res := &ExportGenesisReply{}
+	err := c.requester.SendRequest(ctx, "exportGenesis", &ExportGenesisArgs{
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return &res.Genesis, uint64(res.Height), err
+}
@@ -43,6 +43,10 @@ type ClientPrimaryValidator struct {
 	DelegationFee   float32
 	Uptime          *float32
 	Connected       *bool
+	// Number of delegators delegating to this validator, if known
+	DelegatorCount *uint64
+	// Total weight delegated to this validator, if known
+	DelegatorWeight *uint64
 	// The delegators delegating to this validator
 	Delegators []ClientPrimaryDelegator
 }
@@ -118,6 +122,8 @@ func getClientPrimaryValidators(validatorsSliceIntf []interface{}) ([]ClientPrim
 			DelegationFee:   float32(apiValidator.DelegationFee),
 			Uptime:          (*float32)(apiValidator.Uptime),
 			Connected:       &apiValidator.Connected,
+			DelegatorCount:  (*uint64)(apiValidator.DelegatorCount),
+			DelegatorWeight: (*uint64)(apiValidator.DelegatorWeight),
 			Delegators:      clientDelegators,
 		}
 	}
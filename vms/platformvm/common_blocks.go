@@ -240,6 +240,9 @@ func (b *CommonBlock) Accept() error {
 	b.vm.internalState.SetHeight(b.Hght)
 	b.vm.lastAcceptedID = blkID
 	b.vm.recentlyAccepted.Add(blkID)
+	if err := b.vm.pruneState(b.Hght); err != nil {
+		return fmt.Errorf("failed to prune state: %w", err)
+	}
 	return b.vm.metrics.AcceptBlock(b.self)
 }
 
@@ -312,6 +315,11 @@ func (ddb *DoubleDecisionBlock) Accept() error {
 		return fmt.Errorf("failed to commit vm's state: %w", err)
 	}
 
+	// The tx of the proposal block is only accepted if it was committed
+	_, committed := ddb.self.(*CommitBlock)
+	ddb.vm.publishAccepted(parent, committed)
+	ddb.vm.publishAccepted(ddb.self, true)
+
 	for _, child := range ddb.children {
 		child.setBaseState()
 	}
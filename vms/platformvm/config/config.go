@@ -75,6 +75,19 @@ type Config struct {
 	// Config for the minting function
 	RewardConfig reward.Config
 
+	// RewardCalculator, if non-nil, calculates staking rewards instead of the
+	// default minting function configured by [RewardConfig]. This allows
+	// networks running the Platform Chain to define their own emission
+	// schedule. The node sets it to the calculator registered under the name
+	// configured in the network's staking config.
+	RewardCalculator reward.Calculator
+
+	// PruningHorizon is the number of blocks, behind the last accepted block,
+	// of validator weight diffs and reward UTXOs that are retained. Older
+	// state is pruned, which prevents validator sets from being calculated at
+	// heights older than the horizon. If 0, nothing is pruned.
+	PruningHorizon uint64
+
 	// Time of the AP3 network upgrade
 	ApricotPhase3Time time.Time
 
@@ -83,6 +96,13 @@ type Config struct {
 
 	// Time of the AP5 network upgrade
 	ApricotPhase5Time time.Time
+
+	// Time that subnet validators can start being removed before their end
+	// time
+	SubnetValidatorRemovalTime time.Time
+
+	// Time that primary network validators can start registering BLS keys
+	BLSKeyRegistrationTime time.Time
 }
 
 func (c *Config) GetCreateBlockchainTxFee(t time.Time) uint64 {
@@ -67,6 +67,13 @@ type InternalState interface {
  * | | '-. subnetValidator
  * | |   '-. list
  * | |     '-- txID -> nil
+ * | |-. rewardOwner
+ * | | |-. current
+ * | | | '-. address
+ * | | |   '-- txID -> nil
+ * | | '-. pending
+ * | |   '-. address
+ * | |     '-- txID -> nil
  * | '-. diffs
  * |   '-. height+subnet
  * |     '-. list
@@ -88,11 +95,15 @@ type InternalState interface {
  * | '-. subnetID
  * |   '-. list
  * |     '-- txID -> nil
- * '-. singletons
- *   |-- initializedKey -> nil
- *   |-- timestampKey -> timestamp
- *   |-- currentSupplyKey -> currentSupply
- *   '-- lastAcceptedKey -> lastAccepted
+ * |-. singletons
+ * | |-- initializedKey -> nil
+ * | |-- rewardOwnersIndexedKey -> nil
+ * | |-- timestampKey -> timestamp
+ * | |-- currentSupplyKey -> currentSupply
+ * | |-- lastAcceptedKey -> lastAccepted
+ * | '-- prunedHeightKey -> prunedHeight
+ * '-. pruneIndex
+ *   '-- height+kind+ID -> nil
  */
 type internalStateImpl struct {
 	state.State
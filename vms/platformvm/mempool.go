@@ -144,7 +144,7 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	switch tx.Unsigned.(type) {
 	case *txs.AddValidatorTx, *txs.AddDelegatorTx, *txs.AddSubnetValidatorTx:
 		m.AddProposalTx(tx)
-	case *txs.CreateChainTx, *txs.CreateSubnetTx, *txs.ImportTx, *txs.ExportTx:
+	case *txs.CreateChainTx, *txs.CreateSubnetTx, *txs.ImportTx, *txs.ExportTx, *txs.RemoveSubnetValidatorTx, *txs.RegisterBLSKeyTx:
 		m.AddDecisionTx(tx)
 	default:
 		m.unknownTxs.Inc()
@@ -40,12 +40,15 @@ type metrics struct {
 	numCreateSubnetTxs,
 	numExportTxs,
 	numImportTxs,
+	numRegisterBLSKeyTxs,
+	numRemoveSubnetValidatorTxs,
 	numRewardValidatorTxs prometheus.Counter
 
-	validatorSetsCached     prometheus.Counter
-	validatorSetsCreated    prometheus.Counter
-	validatorSetsHeightDiff prometheus.Gauge
-	validatorSetsDuration   prometheus.Gauge
+	validatorSetsCached      prometheus.Counter
+	validatorSetsCreated     prometheus.Counter
+	validatorSetsIncremental prometheus.Counter
+	validatorSetsHeightDiff  prometheus.Gauge
+	validatorSetsDuration    prometheus.Gauge
 
 	apiRequestMetrics metric.APIInterceptor
 }
@@ -121,6 +124,8 @@ func (m *metrics) Initialize(
 	m.numCreateSubnetTxs = newTxMetrics(namespace, "create_subnet")
 	m.numExportTxs = newTxMetrics(namespace, "export")
 	m.numImportTxs = newTxMetrics(namespace, "import")
+	m.numRegisterBLSKeyTxs = newTxMetrics(namespace, "register_bls_key")
+	m.numRemoveSubnetValidatorTxs = newTxMetrics(namespace, "remove_subnet_validator")
 	m.numRewardValidatorTxs = newTxMetrics(namespace, "reward_validator")
 
 	m.validatorSetsCached = prometheus.NewCounter(prometheus.CounterOpts{
@@ -133,6 +138,11 @@ func (m *metrics) Initialize(
 		Name:      "validator_sets_created",
 		Help:      "Total number of validator sets created from applying difflayers",
 	})
+	m.validatorSetsIncremental = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "validator_sets_incremental",
+		Help:      "Total number of validator sets created by applying difflayers to a cached validator set",
+	})
 	m.validatorSetsHeightDiff = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "validator_sets_height_diff_sum",
@@ -172,10 +182,13 @@ func (m *metrics) Initialize(
 		registerer.Register(m.numCreateSubnetTxs),
 		registerer.Register(m.numExportTxs),
 		registerer.Register(m.numImportTxs),
+		registerer.Register(m.numRegisterBLSKeyTxs),
+		registerer.Register(m.numRemoveSubnetValidatorTxs),
 		registerer.Register(m.numRewardValidatorTxs),
 
 		registerer.Register(m.validatorSetsCreated),
 		registerer.Register(m.validatorSetsCached),
+		registerer.Register(m.validatorSetsIncremental),
 		registerer.Register(m.validatorSetsHeightDiff),
 		registerer.Register(m.validatorSetsDuration),
 	)
@@ -231,6 +244,10 @@ func (m *metrics) AcceptTx(tx *txs.Tx) error {
 		m.numImportTxs.Inc()
 	case *txs.ExportTx:
 		m.numExportTxs.Inc()
+	case *txs.RegisterBLSKeyTx:
+		m.numRegisterBLSKeyTxs.Inc()
+	case *txs.RemoveSubnetValidatorTx:
+		m.numRemoveSubnetValidatorTxs.Inc()
 	case *txs.RewardValidatorTx:
 		m.numRewardValidatorTxs.Inc()
 	default:
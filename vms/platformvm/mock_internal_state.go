@@ -148,6 +148,21 @@ func (mr *MockInternalStateMockRecorder) AddUTXO(utxo interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUTXO", reflect.TypeOf((*MockInternalState)(nil).AddUTXO), utxo)
 }
 
+// AllUTXOIDs mocks base method.
+func (m *MockInternalState) AllUTXOIDs(previous ids.ID, limit int) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllUTXOIDs", previous, limit)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllUTXOIDs indicates an expected call of AllUTXOIDs.
+func (mr *MockInternalStateMockRecorder) AllUTXOIDs(previous, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllUTXOIDs", reflect.TypeOf((*MockInternalState)(nil).AllUTXOIDs), previous, limit)
+}
+
 // Close mocks base method.
 func (m *MockInternalState) Close() error {
 	m.ctrl.T.Helper()
@@ -205,6 +220,21 @@ func (mr *MockInternalStateMockRecorder) CurrentStakers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentStakers", reflect.TypeOf((*MockInternalState)(nil).CurrentStakers))
 }
 
+// CurrentStakerTxIDs mocks base method.
+func (m *MockInternalState) CurrentStakerTxIDs(addr ids.ShortID, start ids.ID, limit int) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CurrentStakerTxIDs", addr, start, limit)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CurrentStakerTxIDs indicates an expected call of CurrentStakerTxIDs.
+func (mr *MockInternalStateMockRecorder) CurrentStakerTxIDs(addr, start, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentStakerTxIDs", reflect.TypeOf((*MockInternalState)(nil).CurrentStakerTxIDs), addr, start, limit)
+}
+
 // DeleteCurrentStaker mocks base method.
 func (m *MockInternalState) DeleteCurrentStaker(tx *txs.Tx) {
 	m.ctrl.T.Helper()
@@ -255,6 +285,21 @@ func (mr *MockInternalStateMockRecorder) DoneInit() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoneInit", reflect.TypeOf((*MockInternalState)(nil).DoneInit))
 }
 
+// GetBLSKey mocks base method.
+func (m *MockInternalState) GetBLSKey(nodeID ids.NodeID) (*state.BLSKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBLSKey", nodeID)
+	ret0, _ := ret[0].(*state.BLSKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBLSKey indicates an expected call of GetBLSKey.
+func (mr *MockInternalStateMockRecorder) GetBLSKey(nodeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBLSKey", reflect.TypeOf((*MockInternalState)(nil).GetBLSKey), nodeID)
+}
+
 // GetBlock mocks base method.
 func (m *MockInternalState) GetBlock(blockID ids.ID) (Block, error) {
 	m.ctrl.T.Helper()
@@ -328,6 +373,20 @@ func (mr *MockInternalStateMockRecorder) GetNextStakerChangeTime() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextStakerChangeTime", reflect.TypeOf((*MockInternalState)(nil).GetNextStakerChangeTime))
 }
 
+// GetPrunedHeight mocks base method.
+func (m *MockInternalState) GetPrunedHeight() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrunedHeight")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetPrunedHeight indicates an expected call of GetPrunedHeight.
+func (mr *MockInternalStateMockRecorder) GetPrunedHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrunedHeight", reflect.TypeOf((*MockInternalState)(nil).GetPrunedHeight))
+}
+
 // GetRewardUTXOs mocks base method.
 func (m *MockInternalState) GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error) {
 	m.ctrl.T.Helper()
@@ -492,6 +551,47 @@ func (mr *MockInternalStateMockRecorder) PendingStakers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingStakers", reflect.TypeOf((*MockInternalState)(nil).PendingStakers))
 }
 
+// PendingStakerTxIDs mocks base method.
+func (m *MockInternalState) PendingStakerTxIDs(addr ids.ShortID, start ids.ID, limit int) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingStakerTxIDs", addr, start, limit)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingStakerTxIDs indicates an expected call of PendingStakerTxIDs.
+func (mr *MockInternalStateMockRecorder) PendingStakerTxIDs(addr, start, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingStakerTxIDs", reflect.TypeOf((*MockInternalState)(nil).PendingStakerTxIDs), addr, start, limit)
+}
+
+// Prune mocks base method.
+func (m *MockInternalState) Prune(height uint64, limit int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", height, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockInternalStateMockRecorder) Prune(height, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockInternalState)(nil).Prune), height, limit)
+}
+
+// SetBLSKey mocks base method.
+func (m *MockInternalState) SetBLSKey(nodeID ids.NodeID, key *state.BLSKey) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBLSKey", nodeID, key)
+}
+
+// SetBLSKey indicates an expected call of SetBLSKey.
+func (mr *MockInternalStateMockRecorder) SetBLSKey(nodeID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBLSKey", reflect.TypeOf((*MockInternalState)(nil).SetBLSKey), nodeID, key)
+}
+
 // SetCurrentStakers mocks base method.
 func (m *MockInternalState) SetCurrentStakers(cs state.CurrentStakers) {
 	m.ctrl.T.Helper()
@@ -53,6 +53,10 @@ func (*proposalTxExecutor) CreateChainTx(*txs.CreateChainTx) error   { return er
 func (*proposalTxExecutor) CreateSubnetTx(*txs.CreateSubnetTx) error { return errWrongTxType }
 func (*proposalTxExecutor) ImportTx(*txs.ImportTx) error             { return errWrongTxType }
 func (*proposalTxExecutor) ExportTx(*txs.ExportTx) error             { return errWrongTxType }
+func (*proposalTxExecutor) RemoveSubnetValidatorTx(*txs.RemoveSubnetValidatorTx) error {
+	return errWrongTxType
+}
+func (*proposalTxExecutor) RegisterBLSKeyTx(*txs.RegisterBLSKeyTx) error { return errWrongTxType }
 
 func (e *proposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	// Verify the tx is well-formed
@@ -431,12 +435,12 @@ func (e *proposalTxExecutor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
 		// Ensure that the period this delegator delegates wouldn't become over
 		// delegated.
 		vdrWeight := vdrTx.Weight()
-		currentWeight, err := math.Add64(vdrWeight, currentDelegatorWeight)
+		currentWeight, err := math.Add(vdrWeight, currentDelegatorWeight)
 		if err != nil {
 			return err
 		}
 
-		maximumWeight, err := math.Mul64(MaxValidatorWeightFactor, vdrWeight)
+		maximumWeight, err := math.Mul(MaxValidatorWeightFactor, vdrWeight)
 		if err != nil {
 			return errStakeOverflow
 		}
@@ -569,7 +573,7 @@ pendingStakerLoop:
 				staker.Validator.Wght,
 				currentSupply,
 			)
-			currentSupply, err = math.Add64(currentSupply, r)
+			currentSupply, err = math.Add(currentSupply, r)
 			if err != nil {
 				return err
 			}
@@ -589,7 +593,7 @@ pendingStakerLoop:
 				staker.Validator.Wght,
 				currentSupply,
 			)
-			currentSupply, err = math.Add64(currentSupply, r)
+			currentSupply, err = math.Add(currentSupply, r)
 			if err != nil {
 				return err
 			}
@@ -711,7 +715,7 @@ func (e *proposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 
 	// If the reward is aborted, then the current supply should be decreased.
 	currentSupply := e.onAbort.GetCurrentSupply()
-	newSupply, err := math.Sub64(currentSupply, stakerReward)
+	newSupply, err := math.Sub(currentSupply, stakerReward)
 	if err != nil {
 		return err
 	}
@@ -796,7 +800,7 @@ func (e *proposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		delegatorShares := reward.PercentDenominator - uint64(vdrTx.Shares)             // parentTx.Shares <= reward.PercentDenominator so no underflow
 		delegatorReward := delegatorShares * (stakerReward / reward.PercentDenominator) // delegatorShares <= reward.PercentDenominator so no overflow
 		// Delay rounding as long as possible for small numbers
-		if optimisticReward, err := math.Mul64(delegatorShares, stakerReward); err == nil {
+		if optimisticReward, err := math.Mul(delegatorShares, stakerReward); err == nil {
 			delegatorReward = optimisticReward / reward.PercentDenominator
 		}
 		delegateeReward := stakerReward - delegatorReward // delegatorReward <= reward so no underflow
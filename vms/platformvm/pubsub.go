@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/pubsub"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// BlockNotification is sent to subscribers of the accepted blocks topic.
+type BlockNotification struct {
+	BlockID  ids.ID      `json:"blockID"`
+	ParentID ids.ID      `json:"parentID"`
+	Height   json.Uint64 `json:"height"`
+	TxIDs    []ids.ID    `json:"txIDs"`
+	// AbortedTxIDs are the txs of an accepted proposal block whose proposal
+	// was aborted. They aren't included in [TxIDs].
+	AbortedTxIDs []ids.ID `json:"abortedTxIDs,omitempty"`
+}
+
+// publishAccepted notifies the pubsub subscribers that [blk] was accepted. If
+// [committed], the transactions of [blk] are notified as accepted. Otherwise,
+// they're only reported as aborted in the block notification.
+//
+// This must only be called once the block has been committed to the database.
+func (vm *VM) publishAccepted(blk Block, committed bool) {
+	notification := newBlockNotification(blk, committed)
+	vm.pubsub.Broadcast(pubsub.AcceptedBlocks, notification)
+	for _, txID := range notification.TxIDs {
+		vm.pubsub.Broadcast(pubsub.AcceptedTxs, api.JSONTxID{TxID: txID})
+	}
+}
+
+// newBlockNotification returns the notification of the acceptance of [blk].
+// If [committed] is false, the txs of [blk] are reported as aborted.
+func newBlockNotification(blk Block, committed bool) *BlockNotification {
+	blkTxs := blockTxs(blk)
+	txIDs := make([]ids.ID, len(blkTxs))
+	for i, tx := range blkTxs {
+		txIDs[i] = tx.ID()
+	}
+
+	notification := &BlockNotification{
+		BlockID:  blk.ID(),
+		ParentID: blk.Parent(),
+		Height:   json.Uint64(blk.Height()),
+	}
+	if committed {
+		notification.TxIDs = txIDs
+	} else {
+		notification.TxIDs = []ids.ID{}
+		notification.AbortedTxIDs = txIDs
+	}
+	return notification
+}
+
+// blockTxs returns the transactions included in [blk].
+func blockTxs(blk Block) []*txs.Tx {
+	switch blk := blk.(type) {
+	case *AtomicBlock:
+		return []*txs.Tx{blk.Tx}
+	case *ProposalBlock:
+		return []*txs.Tx{blk.Tx}
+	case *StandardBlock:
+		return blk.Txs
+	default:
+		return nil
+	}
+}
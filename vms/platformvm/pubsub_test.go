@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+func TestNewBlockNotification(t *testing.T) {
+	assert := assert.New(t)
+
+	tx := &txs.Tx{Unsigned: &txs.AdvanceTimeTx{Time: 1}}
+	assert.NoError(tx.Sign(txs.Codec, nil))
+
+	blk := &ProposalBlock{
+		CommonBlock: CommonBlock{
+			PrntID: ids.GenerateTestID(),
+			Hght:   5,
+		},
+		Tx: tx,
+	}
+
+	notification := newBlockNotification(blk, true)
+	assert.Equal(blk.Parent(), notification.ParentID)
+	assert.EqualValues(5, notification.Height)
+	assert.Equal([]ids.ID{tx.ID()}, notification.TxIDs)
+	assert.Empty(notification.AbortedTxIDs)
+
+	// The tx of an aborted proposal isn't reported as accepted
+	notification = newBlockNotification(blk, false)
+	assert.Empty(notification.TxIDs)
+	assert.Equal([]ids.ID{tx.ID()}, notification.AbortedTxIDs)
+}
@@ -0,0 +1,260 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"crypto"
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	avacrypto "github.com/ava-labs/avalanchego/utils/crypto"
+)
+
+func newTestProofOfPossession(t *testing.T) *signer.ProofOfPossession {
+	sk, err := bls.NewSecretKey()
+	assert.NoError(t, err)
+	return signer.NewProofOfPossession(sk)
+}
+
+// newTestNodeAuthorization returns the authorization of the node with staking
+// certificate [cert] to register the key of [pop] at [timestamp]
+func newTestNodeAuthorization(
+	t *testing.T,
+	networkID uint32,
+	cert *tls.Certificate,
+	pop *signer.ProofOfPossession,
+	timestamp uint64,
+) *signer.NodeAuthorization {
+	nodeAuth, err := signer.NewNodeAuthorization(
+		cert.Leaf,
+		cert.PrivateKey.(crypto.Signer),
+		networkID,
+		pop.Key(),
+		timestamp,
+	)
+	assert.NoError(t, err)
+	return nodeAuth
+}
+
+func TestRegisterBLSKeyTxSyntacticVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Case: tx is nil
+	var unsignedTx *txs.RegisterBLSKeyTx
+	assert.ErrorIs(unsignedTx.SyntacticVerify(vm.ctx), txs.ErrNilTx)
+
+	cert, err := staking.NewTLSCert()
+	assert.NoError(err)
+	nodeID := ids.NodeIDFromCert(cert.Leaf)
+	pop := newTestProofOfPossession(t)
+	nodeAuth := newTestNodeAuthorization(t, vm.ctx.NetworkID, cert, pop, 1)
+
+	tx, err := vm.txBuilder.NewRegisterBLSKeyTx(
+		nodeID,
+		pop,
+		nodeAuth,
+		[]*avacrypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	// The parsed tx must recover the verified key
+	parsedTx, err := txs.Parse(txs.Codec, tx.Bytes())
+	assert.NoError(err)
+	assert.NoError(parsedTx.SyntacticVerify(vm.ctx))
+	parsedPoP := parsedTx.Unsigned.(*txs.RegisterBLSKeyTx).Signer
+	assert.Equal(pop.PublicKey, parsedPoP.PublicKey)
+	assert.NotNil(parsedPoP.Key())
+
+	registerTx := tx.Unsigned.(*txs.RegisterBLSKeyTx)
+	verify := func() error {
+		// This tx was syntactically verified when it was created... pretend
+		// it wasn't so we don't use cache
+		registerTx.SyntacticallyVerified = false
+		return tx.SyntacticVerify(vm.ctx)
+	}
+
+	// Case: missing proof of possession
+	registerTx.Signer = nil
+	assert.ErrorIs(verify(), txs.ErrMissingProofOfPossession)
+
+	// Case: proof of possession doesn't match the key
+	otherPoP := newTestProofOfPossession(t)
+	registerTx.Signer = &signer.ProofOfPossession{
+		PublicKey:         pop.PublicKey,
+		ProofOfPossession: otherPoP.ProofOfPossession,
+	}
+	assert.Error(verify())
+	registerTx.Signer = pop
+
+	// Case: missing node authorization
+	registerTx.NodeAuth = nil
+	assert.ErrorIs(verify(), txs.ErrMissingNodeAuthorization)
+
+	// Case: the node authorized a different key
+	registerTx.NodeAuth = newTestNodeAuthorization(t, vm.ctx.NetworkID, cert, otherPoP, 1)
+	assert.Error(verify())
+
+	// Case: the key was authorized on a different network
+	registerTx.NodeAuth = newTestNodeAuthorization(t, vm.ctx.NetworkID+1, cert, pop, 1)
+	assert.Error(verify())
+
+	// Case: the key was authorized by a different node
+	otherCert, err := staking.NewTLSCert()
+	assert.NoError(err)
+	registerTx.NodeAuth = newTestNodeAuthorization(t, vm.ctx.NetworkID, otherCert, pop, 1)
+	assert.Error(verify())
+
+	// Case: the authorization was signed for a different timestamp
+	registerTx.NodeAuth = newTestNodeAuthorization(t, vm.ctx.NetworkID, cert, pop, 1)
+	registerTx.NodeAuth.Timestamp++
+	assert.Error(verify())
+
+	registerTx.NodeAuth = nodeAuth
+	assert.NoError(verify())
+}
+
+func TestRegisterBLSKeyTxExecute(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// The node doesn't need to be a validator to register its key
+	cert, err := staking.NewTLSCert()
+	assert.NoError(err)
+	nodeID := ids.NodeIDFromCert(cert.Leaf)
+
+	pop := newTestProofOfPossession(t)
+	nodeAuth := newTestNodeAuthorization(t, vm.ctx.NetworkID, cert, pop, 2)
+
+	newExecutor := func(tx *txs.Tx) *standardTxExecutor {
+		return &standardTxExecutor{
+			vm: vm,
+			state: state.NewDiff(
+				vm.internalState,
+				vm.internalState.CurrentStakers(),
+				vm.internalState.PendingStakers(),
+			),
+			tx: tx,
+		}
+	}
+
+	tx, err := vm.txBuilder.NewRegisterBLSKeyTx(
+		nodeID,
+		pop,
+		nodeAuth,
+		[]*avacrypto.PrivateKeySECP256K1R{keys[0]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	{
+		// Case: BLS keys can't be registered yet
+		vm.BLSKeyRegistrationTime = vm.internalState.GetTimestamp().Add(time.Second)
+
+		err := tx.Unsigned.Visit(newExecutor(tx))
+		assert.ErrorIs(err, errRegisterBLSKeyNotActive)
+
+		vm.BLSKeyRegistrationTime = time.Time{}
+	}
+
+	_, err = vm.internalState.GetBLSKey(nodeID)
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	executor := newExecutor(tx)
+	assert.NoError(tx.Unsigned.Visit(executor))
+
+	key, err := executor.state.GetBLSKey(nodeID)
+	assert.NoError(err)
+	assert.Equal(pop.PublicKey[:], bls.PublicKeyToBytes(key.PublicKey))
+	assert.Equal(nodeAuth.Timestamp, key.Timestamp)
+
+	// The key isn't registered until the diff is applied
+	_, err = vm.internalState.GetBLSKey(nodeID)
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	executor.state.Apply(vm.internalState)
+	assert.NoError(vm.internalState.Commit())
+
+	key, err = vm.internalState.GetBLSKey(nodeID)
+	assert.NoError(err)
+	assert.Equal(pop.PublicKey[:], bls.PublicKeyToBytes(key.PublicKey))
+	assert.Equal(nodeAuth.Timestamp, key.Timestamp)
+
+	// Case: the key can't be replaced by a key that the node authorized
+	// before or at the same time as its current key. keys[0]'s funds were
+	// spent, so the other keys pay the fees.
+	for _, timestamp := range []uint64{1, 2} {
+		otherPoP := newTestProofOfPossession(t)
+		staleTx, err := vm.txBuilder.NewRegisterBLSKeyTx(
+			nodeID,
+			otherPoP,
+			newTestNodeAuthorization(t, vm.ctx.NetworkID, cert, otherPoP, timestamp),
+			[]*avacrypto.PrivateKeySECP256K1R{keys[1]},
+			ids.ShortEmpty, // change addr
+		)
+		assert.NoError(err)
+
+		err = staleTx.Unsigned.Visit(newExecutor(staleTx))
+		assert.ErrorIs(err, errStaleBLSKeyAuthorization)
+	}
+
+	// The node rotates its key
+	rotatedPoP := newTestProofOfPossession(t)
+	rotatedNodeAuth := newTestNodeAuthorization(t, vm.ctx.NetworkID, cert, rotatedPoP, 3)
+	rotateTx, err := vm.txBuilder.NewRegisterBLSKeyTx(
+		nodeID,
+		rotatedPoP,
+		rotatedNodeAuth,
+		[]*avacrypto.PrivateKeySECP256K1R{keys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	executor = newExecutor(rotateTx)
+	assert.NoError(rotateTx.Unsigned.Visit(executor))
+	executor.state.Apply(vm.internalState)
+	assert.NoError(vm.internalState.Commit())
+
+	key, err = vm.internalState.GetBLSKey(nodeID)
+	assert.NoError(err)
+	assert.Equal(rotatedPoP.PublicKey[:], bls.PublicKeyToBytes(key.PublicKey))
+	assert.Equal(rotatedNodeAuth.Timestamp, key.Timestamp)
+
+	// Case: the registration of the rotated out key can't be replayed
+	replayTx, err := vm.txBuilder.NewRegisterBLSKeyTx(
+		nodeID,
+		pop,
+		nodeAuth,
+		[]*avacrypto.PrivateKeySECP256K1R{keys[2]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	err = replayTx.Unsigned.Visit(newExecutor(replayTx))
+	assert.ErrorIs(err, errStaleBLSKeyAuthorization)
+}
@@ -0,0 +1,362 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestRemoveSubnetValidatorTxSyntacticVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Case: tx is nil
+	var unsignedTx *txs.RemoveSubnetValidatorTx
+	assert.ErrorIs(unsignedTx.SyntacticVerify(vm.ctx), txs.ErrNilTx)
+
+	// Case: removing a primary network validator
+	tx, err := vm.txBuilder.NewRemoveSubnetValidatorTx(
+		ids.NodeID(keys[0].PublicKey().Address()),
+		testSubnet1.ID(),
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	removeTx := tx.Unsigned.(*txs.RemoveSubnetValidatorTx)
+	removeTx.Subnet = constants.PrimaryNetworkID
+	// This tx was syntactically verified when it was created... pretend it
+	// wasn't so we don't use cache
+	removeTx.SyntacticallyVerified = false
+	assert.ErrorIs(tx.SyntacticVerify(vm.ctx), txs.ErrRemovePrimaryNetworkValidator)
+}
+
+func TestRemoveSubnetValidatorTxExecute(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// keys[0] is a genesis validator, so it can validate the subnet
+	nodeID := ids.NodeID(keys[0].PublicKey().Address())
+	subnetID := testSubnet1.ID()
+	subnetKeys := []*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]}
+
+	currentTime := vm.internalState.GetTimestamp()
+	addCurrentTx, err := vm.txBuilder.NewAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(currentTime.Unix()),
+		uint64(currentTime.Add(defaultMinStakingDuration).Unix()),
+		nodeID,
+		subnetID,
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	startTime := currentTime.Add(time.Second)
+	addPendingTx, err := vm.txBuilder.NewAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(startTime.Unix()),
+		uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+		nodeID,
+		subnetID,
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	removeTx, err := vm.txBuilder.NewRemoveSubnetValidatorTx(
+		nodeID,
+		subnetID,
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	{
+		// Case: node isn't validating the subnet
+		executor := standardTxExecutor{
+			vm: vm,
+			state: state.NewDiff(
+				vm.internalState,
+				vm.internalState.CurrentStakers(),
+				vm.internalState.PendingStakers(),
+			),
+			tx: removeTx,
+		}
+		err := removeTx.Unsigned.Visit(&executor)
+		assert.ErrorIs(err, errNotSubnetValidator)
+	}
+
+	{
+		// Case: node is currently validating the subnet
+		currentStakers, err := vm.internalState.CurrentStakers().UpdateStakers(
+			nil,
+			nil,
+			[]*txs.Tx{addCurrentTx},
+			0,
+		)
+		assert.NoError(err)
+
+		executor := standardTxExecutor{
+			vm: vm,
+			state: state.NewDiff(
+				vm.internalState,
+				currentStakers,
+				vm.internalState.PendingStakers(),
+			),
+			tx: removeTx,
+		}
+		assert.NoError(removeTx.Unsigned.Visit(&executor))
+
+		vdr, err := executor.state.CurrentStakers().GetValidator(nodeID)
+		assert.NoError(err)
+		assert.NotContains(vdr.SubnetValidators(), subnetID)
+		assert.NotContains(executor.state.CurrentStakers().Stakers(), addCurrentTx)
+
+		// The original staker set must not have been modified
+		vdr, err = currentStakers.GetValidator(nodeID)
+		assert.NoError(err)
+		assert.Contains(vdr.SubnetValidators(), subnetID)
+
+		utxoID := avax.UTXOID{
+			TxID:        removeTx.ID(),
+			OutputIndex: 0,
+		}
+		_, err = executor.state.GetUTXO(utxoID.InputID())
+		assert.NoError(err)
+	}
+
+	{
+		// Case: node will validate the subnet in the future
+		pendingStakers := vm.internalState.PendingStakers().AddStaker(addPendingTx)
+
+		executor := standardTxExecutor{
+			vm: vm,
+			state: state.NewDiff(
+				vm.internalState,
+				vm.internalState.CurrentStakers(),
+				pendingStakers,
+			),
+			tx: removeTx,
+		}
+		assert.NoError(removeTx.Unsigned.Visit(&executor))
+
+		vdr := executor.state.PendingStakers().GetValidator(nodeID)
+		assert.NotContains(vdr.SubnetValidators(), subnetID)
+		assert.NotContains(executor.state.PendingStakers().Stakers(), addPendingTx)
+	}
+
+	{
+		// Case: subnet validators can't be removed yet
+		vm.SubnetValidatorRemovalTime = currentTime.Add(time.Second)
+
+		pendingStakers := vm.internalState.PendingStakers().AddStaker(addPendingTx)
+		executor := standardTxExecutor{
+			vm: vm,
+			state: state.NewDiff(
+				vm.internalState,
+				vm.internalState.CurrentStakers(),
+				pendingStakers,
+			),
+			tx: removeTx,
+		}
+		err := removeTx.Unsigned.Visit(&executor)
+		assert.ErrorIs(err, errRemoveSubnetValidatorNotActive)
+	}
+}
+
+func TestRemoveSubnetValidatorTxAccept(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	nodeID := ids.NodeID(keys[0].PublicKey().Address())
+	subnetID := testSubnet1.ID()
+	subnetKeys := []*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]}
+	vm.WhitelistedSubnets.Add(subnetID)
+
+	currentTime := vm.internalState.GetTimestamp()
+	addTx, err := vm.txBuilder.NewAddSubnetValidatorTx(
+		defaultWeight,
+		uint64(currentTime.Unix()),
+		uint64(currentTime.Add(defaultMinStakingDuration).Unix()),
+		nodeID,
+		subnetID,
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	currentStakers, err := vm.internalState.CurrentStakers().UpdateStakers(
+		nil,
+		nil,
+		[]*txs.Tx{addTx},
+		0,
+	)
+	assert.NoError(err)
+	currentStakers.Apply(vm.internalState)
+	assert.NoError(vm.internalState.Commit())
+	assert.True(vm.Validators.Contains(subnetID, nodeID))
+
+	removeTx, err := vm.txBuilder.NewRemoveSubnetValidatorTx(
+		nodeID,
+		subnetID,
+		subnetKeys,
+		ids.ShortEmpty, // change addr
+	)
+	assert.NoError(err)
+
+	executor := standardTxExecutor{
+		vm: vm,
+		state: state.NewDiff(
+			vm.internalState,
+			vm.internalState.CurrentStakers(),
+			vm.internalState.PendingStakers(),
+		),
+		tx: removeTx,
+	}
+	assert.NoError(removeTx.Unsigned.Visit(&executor))
+
+	executor.state.Apply(vm.internalState)
+	assert.NoError(vm.internalState.Commit())
+	assert.False(vm.Validators.Contains(subnetID, nodeID))
+
+	vdr, err := vm.internalState.CurrentStakers().GetValidator(nodeID)
+	assert.NoError(err)
+	assert.NotContains(vdr.SubnetValidators(), subnetID)
+}
+
+func TestRemoveMultipleSubnetValidatorsInOneBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	nodeIDs := []ids.NodeID{
+		ids.NodeID(keys[0].PublicKey().Address()),
+		ids.NodeID(keys[1].PublicKey().Address()),
+	}
+	subnetID := testSubnet1.ID()
+	subnetKeys := []*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]}
+	vm.WhitelistedSubnets.Add(subnetID)
+
+	// Each control key owns a single UTXO. The removals are signed by
+	// different control keys so that they don't consume the same UTXO.
+	removalKeys := [][]*crypto.PrivateKeySECP256K1R{
+		{testSubnet1ControlKeys[1], testSubnet1ControlKeys[2]},
+		{testSubnet1ControlKeys[0]},
+	}
+
+	currentTime := vm.internalState.GetTimestamp()
+	addTxs := make([]*txs.Tx, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		addTx, err := vm.txBuilder.NewAddSubnetValidatorTx(
+			defaultWeight,
+			uint64(currentTime.Unix()),
+			uint64(currentTime.Add(defaultMinStakingDuration).Unix()),
+			nodeID,
+			subnetID,
+			subnetKeys,
+			ids.ShortEmpty, // change addr
+		)
+		assert.NoError(err)
+		addTxs[i] = addTx
+	}
+
+	currentStakers, err := vm.internalState.CurrentStakers().UpdateStakers(
+		nil,
+		nil,
+		addTxs,
+		0,
+	)
+	assert.NoError(err)
+	currentStakers.Apply(vm.internalState)
+	assert.NoError(vm.internalState.Commit())
+
+	// Both removals are executed on the same diff, as they would be if they
+	// were in the same block
+	onAccept := state.NewDiff(
+		vm.internalState,
+		vm.internalState.CurrentStakers(),
+		vm.internalState.PendingStakers(),
+	)
+	for i, nodeID := range nodeIDs {
+		removeTx, err := vm.txBuilder.NewRemoveSubnetValidatorTx(
+			nodeID,
+			subnetID,
+			removalKeys[i],
+			ids.ShortEmpty, // change addr
+		)
+		assert.NoError(err)
+
+		if i == 0 {
+			// Sign the second removal with the control key whose UTXO
+			// wasn't consumed by the first one
+			in := removeTx.Unsigned.(*txs.RemoveSubnetValidatorTx).Ins[0]
+			utxo, err := vm.internalState.GetUTXO(in.InputID())
+			assert.NoError(err)
+			owner := utxo.Out.(*secp256k1fx.TransferOutput).Addrs[0]
+			for _, key := range removalKeys[0] {
+				if key.PublicKey().Address() != owner {
+					removalKeys[1] = append(removalKeys[1], key)
+				}
+			}
+		}
+
+		executor := standardTxExecutor{
+			vm:    vm,
+			state: onAccept,
+			tx:    removeTx,
+		}
+		assert.NoError(removeTx.Unsigned.Visit(&executor))
+	}
+
+	onAccept.Apply(vm.internalState)
+	assert.NoError(vm.internalState.Commit())
+
+	for _, nodeID := range nodeIDs {
+		assert.False(vm.Validators.Contains(subnetID, nodeID))
+
+		vdr, err := vm.internalState.CurrentStakers().GetValidator(nodeID)
+		assert.NoError(err)
+		assert.NotContains(vdr.SubnetValidators(), subnetID)
+	}
+	for _, addTx := range addTxs {
+		_, _, err := vm.internalState.CurrentStakers().GetStaker(addTx.ID())
+		assert.ErrorIs(err, database.ErrNotFound)
+	}
+}
@@ -10,7 +10,14 @@ import (
 
 var _ Calculator = &calculator{}
 
+// Calculator determines the reward of a staker when the staker starts
+// staking. The default implementation, returned by NewCalculator, mints a
+// portion of the supply remaining below the supply cap.
 type Calculator interface {
+	// Calculate returns the reward of a staker that stakes [stakedAmount] for
+	// [stakedDuration] when the supply is [currentSupply]. The supply is
+	// increased by the returned reward, so implementations that cap the supply
+	// must not return more than the remaining supply.
 	Calculate(stakedDuration time.Duration, stakedAmount, currentSupply uint64) uint64
 }
 
@@ -21,6 +28,7 @@ type calculator struct {
 	supplyCap                uint64
 }
 
+// NewCalculator returns the default reward calculator, configured by [c]
 func NewCalculator(c Config) Calculator {
 	return &calculator{
 		maxSubMinConsumptionRate: new(big.Int).SetUint64(c.MaxConsumptionRate - c.MinConsumptionRate),
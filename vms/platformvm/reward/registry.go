@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reward
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultCalculatorName is the name of the calculator returned by
+// NewCalculator
+const DefaultCalculatorName = "default"
+
+var (
+	errCalculatorAlreadyRegistered = errors.New("reward calculator is already registered")
+	errUnknownCalculator           = errors.New("unknown reward calculator")
+
+	factoriesLock sync.RWMutex
+	factories     = map[string]CalculatorFactory{
+		DefaultCalculatorName: NewCalculator,
+	}
+)
+
+// CalculatorFactory creates a reward calculator from the reward config of the
+// network
+type CalculatorFactory func(Config) Calculator
+
+// RegisterCalculator makes the calculator created by [factory] available to be
+// configured by [name]. Networks that define their own emission schedule
+// register their calculator before the node is started, and select it in
+// their staking config.
+func RegisterCalculator(name string, factory CalculatorFactory) error {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	if _, ok := factories[name]; ok {
+		return fmt.Errorf("%w: %q", errCalculatorAlreadyRegistered, name)
+	}
+	factories[name] = factory
+	return nil
+}
+
+// NewCalculatorByName returns the calculator registered as [name], configured
+// by [c]. If [name] is empty, the default calculator is returned.
+func NewCalculatorByName(name string, c Config) (Calculator, error) {
+	if name == "" {
+		name = DefaultCalculatorName
+	}
+
+	factoriesLock.RLock()
+	factory, ok := factories[name]
+	factoriesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownCalculator, name)
+	}
+	return factory(c), nil
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reward
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type constantCalculator uint64
+
+func (c constantCalculator) Calculate(time.Duration, uint64, uint64) uint64 { return uint64(c) }
+
+func TestRegisterCalculator(t *testing.T) {
+	assert := assert.New(t)
+
+	// The default calculator is used if no calculator is configured
+	for _, name := range []string{"", DefaultCalculatorName} {
+		c, err := NewCalculatorByName(name, defaultConfig)
+		assert.NoError(err)
+		assert.Equal(NewCalculator(defaultConfig), c)
+	}
+
+	_, err := NewCalculatorByName("constant", defaultConfig)
+	assert.True(errors.Is(err, errUnknownCalculator))
+
+	assert.NoError(RegisterCalculator("constant", func(Config) Calculator {
+		return constantCalculator(1)
+	}))
+	c, err := NewCalculatorByName("constant", defaultConfig)
+	assert.NoError(err)
+	assert.EqualValues(1, c.Calculate(defaultMinStakingDuration, defaultMinValidatorStake, 0))
+
+	err = RegisterCalculator(DefaultCalculatorName, NewCalculator)
+	assert.True(errors.Is(err, errCalculatorAlreadyRegistered))
+}
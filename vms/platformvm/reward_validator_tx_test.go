@@ -334,13 +334,13 @@ func TestRewardDelegatorTxExecuteOnCommit(t *testing.T) {
 	// and the delegator's reward should be greater because the delegatee's share is 25%
 	commitVdrBalance, err := avax.GetBalance(vm.internalState, vdrDestSet)
 	assert.NoError(err)
-	vdrReward, err := math.Sub64(commitVdrBalance, oldVdrBalance)
+	vdrReward, err := math.Sub(commitVdrBalance, oldVdrBalance)
 	assert.NoError(err)
 	assert.NotZero(vdrReward, "expected delegatee balance to increase because of reward")
 
 	commitDelBalance, err := avax.GetBalance(vm.internalState, delDestSet)
 	assert.NoError(err)
-	delReward, err := math.Sub64(commitDelBalance, oldDelBalance)
+	delReward, err := math.Sub(commitDelBalance, oldDelBalance)
 	assert.NoError(err)
 	assert.NotZero(delReward, "expected delegator balance to increase because of reward")
 
@@ -437,13 +437,13 @@ func TestRewardDelegatorTxExecuteOnAbort(t *testing.T) {
 	// If tx is aborted, delegator and delegatee shouldn't get reward
 	newVdrBalance, err := avax.GetBalance(vm.internalState, vdrDestSet)
 	assert.NoError(err)
-	vdrReward, err := math.Sub64(newVdrBalance, oldVdrBalance)
+	vdrReward, err := math.Sub(newVdrBalance, oldVdrBalance)
 	assert.NoError(err)
 	assert.Zero(vdrReward, "expected delegatee balance not to increase")
 
 	newDelBalance, err := avax.GetBalance(vm.internalState, delDestSet)
 	assert.NoError(err)
-	delReward, err := math.Sub64(newDelBalance, oldDelBalance)
+	delReward, err := math.Sub(newDelBalance, oldDelBalance)
 	assert.NoError(err)
 	assert.Zero(delReward, "expected delegator balance not to increase")
 
@@ -4,9 +4,11 @@
 package platformvm
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/ava-labs/avalanchego/api"
@@ -14,14 +16,20 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
@@ -37,6 +45,10 @@ const (
 	// Max number of addresses that can be passed in as argument to GetStake
 	maxGetStakeAddrs = 256
 
+	// Max number of UTXO IDs that can be passed in as argument to
+	// GetUTXOsByID
+	maxGetUTXOsByID = 1024
+
 	// Minimum amount of delay to allow a transaction to be issued through the
 	// API
 	minAddStakerDelay = 2 * syncBound
@@ -48,6 +60,7 @@ var (
 	errNoRewardAddress            = errors.New("argument 'rewardAddress' not provided")
 	errInvalidDelegationRate      = errors.New("argument 'delegationFeeRate' must be between 0 and 100, inclusive")
 	errNoAddresses                = errors.New("no addresses provided")
+	errNoUTXOIDs                  = errors.New("no UTXO IDs provided")
 	errNoKeys                     = errors.New("user has no keys or funds")
 	errNoPrimaryValidators        = errors.New("no default subnet validators")
 	errNoValidators               = errors.New("no subnet validators")
@@ -65,6 +78,8 @@ var (
 	errMissingVMID                = errors.New("argument 'vmID' not given")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
 	errMissingPrivateKey          = errors.New("argument 'privateKey' not given")
+	errUnsupportedGenesisOutput   = errors.New("output can't be represented in the genesis")
+	errStartAfterEndTime          = errors.New("argument 'startTime' must be before 'endTime'")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -207,13 +222,13 @@ utxoFor:
 		switch out := utxo.Out.(type) {
 		case *secp256k1fx.TransferOutput:
 			if out.Locktime <= currentTime {
-				newBalance, err := math.Add64(unlocked, out.Amount())
+				newBalance, err := math.Add(unlocked, out.Amount())
 				if err != nil {
 					return errUnlockedOverflow
 				}
 				unlocked = newBalance
 			} else {
-				newBalance, err := math.Add64(lockedNotStakeable, out.Amount())
+				newBalance, err := math.Add(lockedNotStakeable, out.Amount())
 				if err != nil {
 					return errNotStakeableOverflow
 				}
@@ -227,19 +242,19 @@ utxoFor:
 					out.TransferableOut)
 				continue utxoFor
 			case innerOut.Locktime > currentTime:
-				newBalance, err := math.Add64(lockedNotStakeable, out.Amount())
+				newBalance, err := math.Add(lockedNotStakeable, out.Amount())
 				if err != nil {
 					return errLockedNotStakeableOverflow
 				}
 				lockedNotStakeable = newBalance
 			case out.Locktime <= currentTime:
-				newBalance, err := math.Add64(unlocked, out.Amount())
+				newBalance, err := math.Add(unlocked, out.Amount())
 				if err != nil {
 					return errUnlockedOverflow
 				}
 				unlocked = newBalance
 			default:
-				newBalance, err := math.Add64(lockedStakeable, out.Amount())
+				newBalance, err := math.Add(lockedStakeable, out.Amount())
 				if err != nil {
 					return errUnlockedStakeableOverflow
 				}
@@ -252,11 +267,11 @@ utxoFor:
 		response.UTXOIDs = append(response.UTXOIDs, &utxo.UTXOID)
 	}
 
-	lockedBalance, err := math.Add64(lockedStakeable, lockedNotStakeable)
+	lockedBalance, err := math.Add(lockedStakeable, lockedNotStakeable)
 	if err != nil {
 		return errLockedOverflow
 	}
-	balance, err := math.Add64(unlocked, lockedBalance)
+	balance, err := math.Add(unlocked, lockedBalance)
 	if err != nil {
 		return errTotalOverflow
 	}
@@ -305,12 +320,9 @@ func (service *Service) ListAddresses(_ *http.Request, args *api.UserPass, respo
 	if err != nil {
 		return fmt.Errorf("couldn't get addresses: %w", err)
 	}
-	response.Addresses = make([]string, len(addresses))
-	for i, addr := range addresses {
-		response.Addresses[i], err = service.vm.FormatLocalAddress(addr)
-		if err != nil {
-			return fmt.Errorf("problem formatting address: %w", err)
-		}
+	response.Addresses, err = service.vm.FormatLocalAddresses(addresses)
+	if err != nil {
+		return fmt.Errorf("problem formatting address: %w", err)
 	}
 	return user.Close()
 }
@@ -416,6 +428,68 @@ func (service *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, respon
 	return nil
 }
 
+// GetUTXOsByID returns the UTXOs with the provided IDs, along with whether
+// each UTXO is unspent, spent, or unknown to this chain.
+//
+// Staked outputs and rewards are only produced when a staker is removed, so
+// they are reported as unknown once they have been spent.
+func (service *Service) GetUTXOsByID(_ *http.Request, args *api.GetUTXOsByIDArgs, response *api.GetUTXOsByIDReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetUTXOsByID called with %d UTXO IDs", len(args.UTXOIDs))
+
+	if len(args.UTXOIDs) == 0 {
+		return errNoUTXOIDs
+	}
+	if len(args.UTXOIDs) > maxGetUTXOsByID {
+		return fmt.Errorf("number of UTXO IDs given, %d, exceeds maximum, %d", len(args.UTXOIDs), maxGetUTXOsByID)
+	}
+
+	utxoIDs := make([]*avax.UTXOID, len(args.UTXOIDs))
+	for i, utxoIDStr := range args.UTXOIDs {
+		utxoID, err := avax.ParseUTXOID(utxoIDStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse UTXO ID %q: %w", utxoIDStr, err)
+		}
+		utxoIDs[i] = utxoID
+	}
+
+	response.UTXOs = make([]api.UTXOByID, len(utxoIDs))
+	for i, utxoID := range utxoIDs {
+		response.UTXOs[i].UTXOID = utxoID.String()
+
+		utxo, err := service.vm.internalState.GetUTXO(utxoID.InputID())
+		switch err {
+		case nil:
+			bytes, err := Codec.Marshal(txs.Version, utxo)
+			if err != nil {
+				return fmt.Errorf("couldn't serialize UTXO %q: %w", utxoID, err)
+			}
+			response.UTXOs[i].UTXO, err = formatting.Encode(args.Encoding, bytes)
+			if err != nil {
+				return fmt.Errorf("couldn't encode UTXO %s as string: %w", utxoID, err)
+			}
+			response.UTXOs[i].Status = api.UTXOUnspent
+			continue
+		case database.ErrNotFound:
+		default:
+			return fmt.Errorf("problem retrieving UTXO %s: %w", utxoID, err)
+		}
+
+		tx, txStatus, err := service.vm.internalState.GetTx(utxoID.TxID)
+		switch {
+		case err == database.ErrNotFound:
+			response.UTXOs[i].Status = api.UTXOUnknown
+		case err != nil:
+			return fmt.Errorf("problem retrieving the status of UTXO %s: %w", utxoID, err)
+		case txStatus == status.Committed && int(utxoID.OutputIndex) < len(tx.UTXOs()):
+			response.UTXOs[i].Status = api.UTXOSpent
+		default:
+			response.UTXOs[i].Status = api.UTXOUnknown
+		}
+	}
+	response.Encoding = args.Encoding
+	return nil
+}
+
 /*
  ******************************************************
  ******************* Get Subnets **********************
@@ -464,13 +538,9 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 		for i, subnet := range subnets {
 			unsignedTx := subnet.Unsigned.(*txs.CreateSubnetTx)
 			owner := unsignedTx.Owner.(*secp256k1fx.OutputOwners)
-			controlAddrs := []string{}
-			for _, controlKeyID := range owner.Addrs {
-				addr, err := service.vm.FormatLocalAddress(controlKeyID)
-				if err != nil {
-					return fmt.Errorf("problem formatting address: %w", err)
-				}
-				controlAddrs = append(controlAddrs, addr)
+			controlAddrs, err := service.vm.FormatLocalAddresses(owner.Addrs)
+			if err != nil {
+				return fmt.Errorf("problem formatting address: %w", err)
 			}
 			response.Subnets[i] = APISubnet{
 				ID:          subnet.ID(),
@@ -522,13 +592,9 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 			return fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnet.Owner)
 		}
 
-		controlAddrs := make([]string, len(owner.Addrs))
-		for i, controlKeyID := range owner.Addrs {
-			addr, err := service.vm.FormatLocalAddress(controlKeyID)
-			if err != nil {
-				return fmt.Errorf("problem formatting address: %w", err)
-			}
-			controlAddrs[i] = addr
+		controlAddrs, err := service.vm.FormatLocalAddresses(owner.Addrs)
+		if err != nil {
+			return fmt.Errorf("problem formatting address: %w", err)
 		}
 
 		response.Subnets = append(response.Subnets,
@@ -582,12 +648,23 @@ type GetCurrentValidatorsArgs struct {
 	// some nodeIDs are not currently validators, they
 	// will be omitted from the response.
 	NodeIDs []ids.NodeID `json:"nodeIDs"`
+	// Validators are returned in order of their node IDs. If provided, only
+	// validators with a node ID >= [StartNodeID] are returned.
+	StartNodeID ids.NodeID `json:"startNodeID"`
+	// Max number of validators to return. If 0, all validators are returned.
+	Limit json.Uint32 `json:"limit"`
+	// If true, the delegators of each validator aren't returned. Only their
+	// count and total weight are.
+	OmitDelegators bool `json:"omitDelegators"`
 }
 
 // GetCurrentValidatorsReply are the results from calling GetCurrentValidators.
 // Each validator contains a list of delegators to itself.
 type GetCurrentValidatorsReply struct {
 	Validators []interface{} `json:"validators"`
+	// If non-nil, there are more validators to fetch. The next page starts
+	// with this node ID.
+	NextNodeID *ids.NodeID `json:"nextNodeID,omitempty"`
 }
 
 // GetCurrentValidators returns current validators and delegators
@@ -595,152 +672,171 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 	service.vm.ctx.Log.Debug("Platform: GetCurrentValidators called")
 
 	reply.Validators = []interface{}{}
-
-	// Validator's node ID as string --> Delegators to them
-	vdrToDelegators := map[ids.NodeID][]platformapi.PrimaryDelegator{}
-
-	// Create set of nodeIDs
-	nodeIDs := ids.NodeIDSet{}
-	nodeIDs.Add(args.NodeIDs...)
-	includeAllNodes := nodeIDs.Len() == 0
+	reply.NextNodeID = nil
 
 	currentValidators := service.vm.internalState.CurrentStakers()
 
-	// TODO: do not iterate over all stakers when nodeIDs given. Use currentValidators.ValidatorSet for iteration
-	for _, tx := range currentValidators.Stakers() { // Iterates in order of increasing stop time
-		_, rewardAmount, err := currentValidators.GetStaker(tx.ID())
+	var nodeIDs []ids.NodeID
+	if len(args.NodeIDs) == 0 {
+		nodeIDs = currentValidators.ValidatorNodeIDs(args.SubnetID)
+	} else {
+		nodeIDSet := ids.NodeIDSet{}
+		nodeIDSet.Add(args.NodeIDs...)
+		nodeIDs = nodeIDSet.List()
+		ids.SortNodeIDs(nodeIDs)
+	}
+
+	// Skip the validators before the start of the requested page
+	startIndex := sort.Search(len(nodeIDs), func(i int) bool {
+		return bytes.Compare(nodeIDs[i][:], args.StartNodeID[:]) >= 0
+	})
+	for _, nodeID := range nodeIDs[startIndex:] {
+		vdr, err := currentValidators.GetValidator(nodeID)
+		if err == database.ErrNotFound {
+			continue
+		}
 		if err != nil {
 			return err
 		}
-		switch staker := tx.Unsigned.(type) {
-		case *txs.AddDelegatorTx:
-			if args.SubnetID != constants.PrimaryNetworkID {
-				continue
-			}
-			if !includeAllNodes && !nodeIDs.Contains(staker.Validator.ID()) {
-				continue
-			}
-
-			weight := json.Uint64(staker.Validator.Weight())
-
-			var rewardOwner *platformapi.Owner
-			owner, ok := staker.RewardsOwner.(*secp256k1fx.OutputOwners)
-			if ok {
-				rewardOwner = &platformapi.Owner{
-					Locktime:  json.Uint64(owner.Locktime),
-					Threshold: json.Uint32(owner.Threshold),
-				}
-				for _, addr := range owner.Addrs {
-					addrStr, err := service.vm.FormatLocalAddress(addr)
-					if err != nil {
-						return err
-					}
-					rewardOwner.Addresses = append(rewardOwner.Addresses, addrStr)
-				}
-			}
 
-			potentialReward := json.Uint64(rewardAmount)
-			delegator := platformapi.PrimaryDelegator{
-				Staker: platformapi.Staker{
-					TxID:        tx.ID(),
-					StartTime:   json.Uint64(staker.StartTime().Unix()),
-					EndTime:     json.Uint64(staker.EndTime().Unix()),
-					StakeAmount: &weight,
-					NodeID:      staker.Validator.ID(),
-				},
-				RewardOwner:     rewardOwner,
-				PotentialReward: &potentialReward,
-			}
-			vdrToDelegators[delegator.NodeID] = append(vdrToDelegators[delegator.NodeID], delegator)
-		case *txs.AddValidatorTx:
-			if args.SubnetID != constants.PrimaryNetworkID {
+		var apiVdr interface{}
+		if args.SubnetID == constants.PrimaryNetworkID {
+			apiVdr, err = service.getPrimaryValidator(currentValidators, vdr, args.OmitDelegators)
+		} else {
+			subnetVdr, exists := vdr.SubnetValidators()[args.SubnetID]
+			if !exists {
 				continue
 			}
-			if !includeAllNodes && !nodeIDs.Contains(staker.Validator.ID()) {
-				continue
-			}
-
-			nodeID := staker.Validator.ID()
-			startTime := staker.StartTime()
-			weight := json.Uint64(staker.Validator.Weight())
-			potentialReward := json.Uint64(rewardAmount)
-			delegationFee := json.Float32(100 * float32(staker.Shares) / float32(reward.PercentDenominator))
-			rawUptime, err := service.vm.uptimeManager.CalculateUptimePercentFrom(nodeID, startTime)
-			if err != nil {
-				return err
-			}
-			uptime := json.Float32(rawUptime)
-
-			connected := service.vm.uptimeManager.IsConnected(nodeID)
-
-			var rewardOwner *platformapi.Owner
-			owner, ok := staker.RewardsOwner.(*secp256k1fx.OutputOwners)
-			if ok {
-				rewardOwner = &platformapi.Owner{
-					Locktime:  json.Uint64(owner.Locktime),
-					Threshold: json.Uint32(owner.Threshold),
-				}
-				for _, addr := range owner.Addrs {
-					addrStr, err := service.vm.FormatLocalAddress(addr)
-					if err != nil {
-						return err
-					}
-					rewardOwner.Addresses = append(rewardOwner.Addresses, addrStr)
-				}
-			}
+			apiVdr = service.getSubnetValidator(subnetVdr)
+		}
+		if err != nil {
+			return err
+		}
 
-			reply.Validators = append(reply.Validators, platformapi.PrimaryValidator{
-				Staker: platformapi.Staker{
-					TxID:        tx.ID(),
-					NodeID:      nodeID,
-					StartTime:   json.Uint64(startTime.Unix()),
-					EndTime:     json.Uint64(staker.EndTime().Unix()),
-					StakeAmount: &weight,
-				},
-				Uptime:          &uptime,
-				Connected:       connected,
-				PotentialReward: &potentialReward,
-				RewardOwner:     rewardOwner,
-				DelegationFee:   delegationFee,
-			})
-		case *txs.AddSubnetValidatorTx:
-			if args.SubnetID != staker.Validator.Subnet {
-				continue
-			}
-			if !includeAllNodes && !nodeIDs.Contains(staker.Validator.ID()) {
-				continue
-			}
-			nodeID := staker.Validator.ID()
-			weight := json.Uint64(staker.Validator.Weight())
-			connected := service.vm.uptimeManager.IsConnected(nodeID)
-			tracksSubnet := service.vm.SubnetTracker.TracksSubnet(nodeID, args.SubnetID)
-			reply.Validators = append(reply.Validators, platformapi.SubnetValidator{
-				Staker: platformapi.Staker{
-					NodeID:    nodeID,
-					TxID:      tx.ID(),
-					StartTime: json.Uint64(staker.StartTime().Unix()),
-					EndTime:   json.Uint64(staker.EndTime().Unix()),
-					Weight:    &weight,
-				},
-				Connected: connected && tracksSubnet,
-			})
-		default:
-			return fmt.Errorf("expected validator but got %T", tx.Unsigned)
+		if args.Limit != 0 && len(reply.Validators) >= int(args.Limit) {
+			nextNodeID := nodeID
+			reply.NextNodeID = &nextNodeID
+			break
 		}
+		reply.Validators = append(reply.Validators, apiVdr)
 	}
+	return nil
+}
 
-	for i, vdrIntf := range reply.Validators {
-		vdr, ok := vdrIntf.(platformapi.PrimaryValidator)
-		if !ok {
-			continue
+// getPrimaryValidator returns the API representation of the primary network
+// validator [vdr]. If [omitDelegators] is true, only the count and total weight
+// of its delegators are reported.
+func (service *Service) getPrimaryValidator(
+	currentValidators state.CurrentStakers,
+	vdr state.CurrentValidator,
+	omitDelegators bool,
+) (platformapi.PrimaryValidator, error) {
+	staker, txID := vdr.AddValidatorTx()
+	nodeID := staker.Validator.ID()
+	startTime := staker.StartTime()
+	weight := json.Uint64(staker.Validator.Weight())
+	potentialReward := json.Uint64(vdr.PotentialReward())
+	delegationFee := json.Float32(100 * float32(staker.Shares) / float32(reward.PercentDenominator))
+	rawUptime, err := service.vm.uptimeManager.CalculateUptimePercentFrom(nodeID, startTime)
+	if err != nil {
+		return platformapi.PrimaryValidator{}, err
+	}
+	uptime := json.Float32(rawUptime)
+
+	rewardOwner, err := service.getAPIOwner(staker.RewardsOwner)
+	if err != nil {
+		return platformapi.PrimaryValidator{}, err
+	}
+
+	delegators := vdr.Delegators()
+	delegatorCount := json.Uint64(len(delegators))
+	delegatorWeight := json.Uint64(vdr.DelegatorWeight())
+	apiVdr := platformapi.PrimaryValidator{
+		Staker: platformapi.Staker{
+			TxID:        txID,
+			NodeID:      nodeID,
+			StartTime:   json.Uint64(startTime.Unix()),
+			EndTime:     json.Uint64(staker.EndTime().Unix()),
+			StakeAmount: &weight,
+		},
+		Uptime:          &uptime,
+		Connected:       service.vm.uptimeManager.IsConnected(nodeID),
+		PotentialReward: &potentialReward,
+		RewardOwner:     rewardOwner,
+		DelegationFee:   delegationFee,
+		DelegatorCount:  &delegatorCount,
+		DelegatorWeight: &delegatorWeight,
+	}
+	if omitDelegators {
+		return apiVdr, nil
+	}
+
+	for _, delegator := range delegators {
+		_, rewardAmount, err := currentValidators.GetStaker(delegator.TxID)
+		if err != nil {
+			return platformapi.PrimaryValidator{}, err
 		}
-		if delegators, ok := vdrToDelegators[vdr.NodeID]; ok {
-			vdr.Delegators = delegators
+		rewardOwner, err := service.getAPIOwner(delegator.Tx.RewardsOwner)
+		if err != nil {
+			return platformapi.PrimaryValidator{}, err
 		}
-		reply.Validators[i] = vdr
+
+		weight := json.Uint64(delegator.Tx.Validator.Weight())
+		potentialReward := json.Uint64(rewardAmount)
+		apiVdr.Delegators = append(apiVdr.Delegators, platformapi.PrimaryDelegator{
+			Staker: platformapi.Staker{
+				TxID:        delegator.TxID,
+				StartTime:   json.Uint64(delegator.Tx.StartTime().Unix()),
+				EndTime:     json.Uint64(delegator.Tx.EndTime().Unix()),
+				StakeAmount: &weight,
+				NodeID:      nodeID,
+			},
+			RewardOwner:     rewardOwner,
+			PotentialReward: &potentialReward,
+		})
 	}
+	return apiVdr, nil
+}
 
-	return nil
+// getSubnetValidator returns the API representation of the subnet validator
+// [vdr].
+func (service *Service) getSubnetValidator(vdr state.SubnetValidatorAndID) platformapi.SubnetValidator {
+	nodeID := vdr.Tx.Validator.ID()
+	weight := json.Uint64(vdr.Tx.Validator.Weight())
+	connected := service.vm.uptimeManager.IsConnected(nodeID)
+	tracksSubnet := service.vm.SubnetTracker.TracksSubnet(nodeID, vdr.Tx.Validator.Subnet)
+	return platformapi.SubnetValidator{
+		Staker: platformapi.Staker{
+			NodeID:    nodeID,
+			TxID:      vdr.TxID,
+			StartTime: json.Uint64(vdr.Tx.StartTime().Unix()),
+			EndTime:   json.Uint64(vdr.Tx.EndTime().Unix()),
+			Weight:    &weight,
+		},
+		Connected: connected && tracksSubnet,
+	}
+}
+
+// getAPIOwner returns the API representation of [owner]. If [owner] isn't a
+// *secp256k1fx.OutputOwners, nil is returned.
+func (service *Service) getAPIOwner(owner fx.Owner) (*platformapi.Owner, error) {
+	secpOwner, ok := owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, nil
+	}
+
+	apiOwner := &platformapi.Owner{
+		Locktime:  json.Uint64(secpOwner.Locktime),
+		Threshold: json.Uint32(secpOwner.Threshold),
+	}
+	for _, addr := range secpOwner.Addrs {
+		addrStr, err := service.vm.FormatLocalAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		apiOwner.Addresses = append(apiOwner.Addresses, addrStr)
+	}
+	return apiOwner, nil
 }
 
 // GetPendingValidatorsArgs are the arguments for calling GetPendingValidators
@@ -847,6 +943,135 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 	return nil
 }
 
+// GetStakersByRewardAddressArgs are the arguments for calling
+// GetStakersByRewardAddress
+type GetStakersByRewardAddressArgs struct {
+	// Address that the rewards of the returned stakers are paid to
+	Address string `json:"address"`
+	// If true, pending stakers are returned rather than current stakers
+	Pending bool `json:"pending"`
+	// Stakers are returned in order of their transaction IDs, starting after
+	// [StartTxID]
+	StartTxID ids.ID `json:"startTxID"`
+	// Maximum number of stakers to return. If 0 or greater than the maximum
+	// page size, the maximum page size is used.
+	Limit json.Uint32 `json:"limit"`
+}
+
+// GetStakersByRewardAddressReply are the results from calling
+// GetStakersByRewardAddress. Current validators only report the count and
+// total weight of their delegators.
+type GetStakersByRewardAddressReply struct {
+	Validators []interface{} `json:"validators"`
+	Delegators []interface{} `json:"delegators"`
+	// ID of the transaction of the last returned staker. The next page starts
+	// after it.
+	EndTxID ids.ID `json:"endTxID"`
+}
+
+// GetStakersByRewardAddress returns the primary network validators and
+// delegators whose rewards are paid to [args.Address]
+func (service *Service) GetStakersByRewardAddress(_ *http.Request, args *GetStakersByRewardAddressArgs, reply *GetStakersByRewardAddressReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetStakersByRewardAddress called")
+
+	addr, err := avax.ParseServiceAddress(service.vm, args.Address)
+	if err != nil {
+		return err
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || builder.MaxPageSize < limit {
+		limit = builder.MaxPageSize
+	}
+
+	var txIDs []ids.ID
+	if args.Pending {
+		txIDs, err = service.vm.internalState.PendingStakerTxIDs(addr, args.StartTxID, limit)
+	} else {
+		txIDs, err = service.vm.internalState.CurrentStakerTxIDs(addr, args.StartTxID, limit)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get stakers: %w", err)
+	}
+
+	reply.Validators = []interface{}{}
+	reply.Delegators = []interface{}{}
+	reply.EndTxID = args.StartTxID
+	currentStakers := service.vm.internalState.CurrentStakers()
+	for _, txID := range txIDs {
+		var (
+			tx              *txs.Tx
+			potentialReward *json.Uint64
+		)
+		if args.Pending {
+			tx, _, err = service.vm.internalState.GetTx(txID)
+		} else {
+			var rewardAmount uint64
+			tx, rewardAmount, err = currentStakers.GetStaker(txID)
+			potentialReward = (*json.Uint64)(&rewardAmount)
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't get staker %s: %w", txID, err)
+		}
+
+		switch staker := tx.Unsigned.(type) {
+		case *txs.AddValidatorTx:
+			if !args.Pending {
+				vdr, err := currentStakers.GetValidator(staker.Validator.ID())
+				if err != nil {
+					return err
+				}
+				apiVdr, err := service.getPrimaryValidator(currentStakers, vdr, true)
+				if err != nil {
+					return err
+				}
+				reply.Validators = append(reply.Validators, apiVdr)
+				break
+			}
+
+			rewardOwner, err := service.getAPIOwner(staker.RewardsOwner)
+			if err != nil {
+				return err
+			}
+			nodeID := staker.Validator.ID()
+			weight := json.Uint64(staker.Validator.Weight())
+			reply.Validators = append(reply.Validators, platformapi.PrimaryValidator{
+				Staker: platformapi.Staker{
+					TxID:        txID,
+					NodeID:      nodeID,
+					StartTime:   json.Uint64(staker.StartTime().Unix()),
+					EndTime:     json.Uint64(staker.EndTime().Unix()),
+					StakeAmount: &weight,
+				},
+				RewardOwner:   rewardOwner,
+				DelegationFee: json.Float32(100 * float32(staker.Shares) / float32(reward.PercentDenominator)),
+				Connected:     service.vm.uptimeManager.IsConnected(nodeID),
+			})
+		case *txs.AddDelegatorTx:
+			rewardOwner, err := service.getAPIOwner(staker.RewardsOwner)
+			if err != nil {
+				return err
+			}
+			weight := json.Uint64(staker.Validator.Weight())
+			reply.Delegators = append(reply.Delegators, platformapi.PrimaryDelegator{
+				Staker: platformapi.Staker{
+					TxID:        txID,
+					NodeID:      staker.Validator.ID(),
+					StartTime:   json.Uint64(staker.StartTime().Unix()),
+					EndTime:     json.Uint64(staker.EndTime().Unix()),
+					StakeAmount: &weight,
+				},
+				RewardOwner:     rewardOwner,
+				PotentialReward: potentialReward,
+			})
+		default:
+			return fmt.Errorf("expected validator or delegator but got %T", tx.Unsigned)
+		}
+		reply.EndTxID = txID
+	}
+	return nil
+}
+
 // GetCurrentSupplyReply are the results from calling GetCurrentSupply
 type GetCurrentSupplyReply struct {
 	Supply json.Uint64 `json:"supply"`
@@ -860,6 +1085,60 @@ func (service *Service) GetCurrentSupply(_ *http.Request, _ *struct{}, reply *Ge
 	return nil
 }
 
+// EstimateRewardArgs are the arguments for calling EstimateReward
+type EstimateRewardArgs struct {
+	// Amount of tokens that would be staked
+	Amount json.Uint64 `json:"amount"`
+	// Unix times that the staker would start and stop staking
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+	// ID of the subnet the staker would validate
+	// If omitted, defaults to the primary network
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// EstimateRewardReply are the results from calling EstimateReward
+type EstimateRewardReply struct {
+	// Reward that would be paid to the staker if it were added to the current
+	// staker set now
+	Reward json.Uint64 `json:"reward"`
+	// Supply the reward was calculated against
+	CurrentSupply json.Uint64 `json:"currentSupply"`
+}
+
+// EstimateReward returns the reward that a staker staking [args.Amount] from
+// [args.StartTime] until [args.EndTime] would receive, calculated with the
+// reward calculator used by the chain against the current supply. As the
+// supply grows as stakers are added, the actual reward may be slightly lower.
+func (service *Service) EstimateReward(_ *http.Request, args *EstimateRewardArgs, reply *EstimateRewardReply) error {
+	service.vm.ctx.Log.Debug("Platform: EstimateReward called")
+
+	if args.SubnetID != constants.PrimaryNetworkID {
+		return fmt.Errorf("stakers of subnet %s aren't rewarded", args.SubnetID)
+	}
+	if args.Amount == 0 {
+		return errNoAmount
+	}
+	if args.EndTime <= args.StartTime {
+		return errStartAfterEndTime
+	}
+
+	// The duration is checked in seconds so that it can't overflow
+	seconds := uint64(args.EndTime - args.StartTime)
+	switch {
+	case seconds < uint64(service.vm.MinStakeDuration/time.Second):
+		return errStakeTooShort
+	case seconds > uint64(service.vm.MaxStakeDuration/time.Second):
+		return errStakeTooLong
+	}
+	duration := time.Duration(seconds) * time.Second
+
+	currentSupply := service.vm.internalState.GetCurrentSupply()
+	reply.Reward = json.Uint64(service.vm.rewards.Calculate(duration, uint64(args.Amount), currentSupply))
+	reply.CurrentSupply = json.Uint64(currentSupply)
+	return nil
+}
+
 // SampleValidatorsArgs are the arguments for calling SampleValidators
 type SampleValidatorsArgs struct {
 	// Number of validators in the sample
@@ -1000,8 +1279,206 @@ func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, re
 		return fmt.Errorf("couldn't create tx: %w", err)
 	}
 
-	reply.TxID = tx.ID()
-	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	reply.TxID = tx.ID()
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.blockBuilder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
+// AddDelegatorArgs are the arguments to AddDelegator
+type AddDelegatorArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	platformapi.Staker
+	RewardAddress string `json:"rewardAddress"`
+}
+
+// AddDelegator creates and signs and issues a transaction to add a delegator to
+// the primary network
+func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, reply *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: AddDelegator called")
+
+	now := service.vm.clock.Time()
+	minAddStakerTime := now.Add(minAddStakerDelay)
+	minAddStakerUnix := json.Uint64(minAddStakerTime.Unix())
+	maxAddStakerTime := now.Add(maxFutureStartTime)
+	maxAddStakerUnix := json.Uint64(maxAddStakerTime.Unix())
+
+	if args.StartTime == 0 {
+		args.StartTime = minAddStakerUnix
+	}
+
+	switch {
+	case args.RewardAddress == "":
+		return errNoRewardAddress
+	case args.StartTime < minAddStakerUnix:
+		return errStartTimeTooSoon
+	case args.StartTime > maxAddStakerUnix:
+		return errStartTimeTooLate
+	}
+
+	var nodeID ids.NodeID
+	if args.NodeID == ids.EmptyNodeID { // If ID unspecified, use this node's ID
+		nodeID = service.vm.ctx.NodeID
+	} else {
+		nodeID = args.NodeID
+	}
+
+	// Parse the reward address
+	rewardAddress, err := avax.ParseServiceAddress(service.vm, args.RewardAddress)
+	if err != nil {
+		return fmt.Errorf("problem parsing 'rewardAddress': %w", err)
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := avax.ParseServiceAddresses(service.vm, args.From)
+	if err != nil {
+		return err
+	}
+
+	user, err := keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	privKeys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(privKeys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := privKeys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = avax.ParseServiceAddress(service.vm, args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.txBuilder.NewAddDelegatorTx(
+		args.GetWeight(),       // Stake amount
+		uint64(args.StartTime), // Start time
+		uint64(args.EndTime),   // End time
+		nodeID,                 // Node ID
+		rewardAddress,          // Reward Address
+		privKeys.Keys,          // Private keys
+		changeAddr,             // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.blockBuilder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
+// AddSubnetValidatorArgs are the arguments to AddSubnetValidator
+type AddSubnetValidatorArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	platformapi.Staker
+	// ID of subnet to validate
+	SubnetID string `json:"subnetID"`
+}
+
+// AddSubnetValidator creates and signs and issues a transaction to add a
+// validator to a subnet other than the primary network
+func (service *Service) AddSubnetValidator(_ *http.Request, args *AddSubnetValidatorArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: AddSubnetValidator called")
+
+	now := service.vm.clock.Time()
+	minAddStakerTime := now.Add(minAddStakerDelay)
+	minAddStakerUnix := json.Uint64(minAddStakerTime.Unix())
+	maxAddStakerTime := now.Add(maxFutureStartTime)
+	maxAddStakerUnix := json.Uint64(maxAddStakerTime.Unix())
+
+	if args.StartTime == 0 {
+		args.StartTime = minAddStakerUnix
+	}
+
+	switch {
+	case args.SubnetID == "":
+		return errNoSubnetID
+	case args.StartTime < minAddStakerUnix:
+		return errStartTimeTooSoon
+	case args.StartTime > maxAddStakerUnix:
+		return errStartTimeTooLate
+	}
+
+	// Parse the subnet ID
+	subnetID, err := ids.FromString(args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
+	}
+	if subnetID == constants.PrimaryNetworkID {
+		return errNamedSubnetCantBePrimary
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := avax.ParseServiceAddresses(service.vm, args.From)
+	if err != nil {
+		return err
+	}
+
+	user, err := keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	keys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address.
+	if len(keys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = avax.ParseServiceAddress(service.vm, args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.txBuilder.NewAddSubnetValidatorTx(
+		args.GetWeight(),       // Stake amount
+		uint64(args.StartTime), // Start time
+		uint64(args.EndTime),   // End time
+		args.NodeID,            // Node ID
+		subnetID,               // Subnet ID
+		keys.Keys,              // Keys
+		changeAddr,             // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
 
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -1012,49 +1489,32 @@ func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, re
 	return errs.Err
 }
 
-// AddDelegatorArgs are the arguments to AddDelegator
-type AddDelegatorArgs struct {
+// RemoveSubnetValidatorArgs are the arguments to RemoveSubnetValidator
+type RemoveSubnetValidatorArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
-	platformapi.Staker
-	RewardAddress string `json:"rewardAddress"`
+	// ID of the node to remove from the subnet
+	NodeID ids.NodeID `json:"nodeID"`
+	// ID of the subnet the node is validating
+	SubnetID string `json:"subnetID"`
 }
 
-// AddDelegator creates and signs and issues a transaction to add a delegator to
-// the primary network
-func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, reply *api.JSONTxIDChangeAddr) error {
-	service.vm.ctx.Log.Debug("Platform: AddDelegator called")
-
-	now := service.vm.clock.Time()
-	minAddStakerTime := now.Add(minAddStakerDelay)
-	minAddStakerUnix := json.Uint64(minAddStakerTime.Unix())
-	maxAddStakerTime := now.Add(maxFutureStartTime)
-	maxAddStakerUnix := json.Uint64(maxAddStakerTime.Unix())
-
-	if args.StartTime == 0 {
-		args.StartTime = minAddStakerUnix
-	}
-
-	switch {
-	case args.RewardAddress == "":
-		return errNoRewardAddress
-	case args.StartTime < minAddStakerUnix:
-		return errStartTimeTooSoon
-	case args.StartTime > maxAddStakerUnix:
-		return errStartTimeTooLate
-	}
+// RemoveSubnetValidator creates and signs and issues a transaction to remove a
+// validator from a subnet other than the primary network before its end time
+func (service *Service) RemoveSubnetValidator(_ *http.Request, args *RemoveSubnetValidatorArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: RemoveSubnetValidator called")
 
-	var nodeID ids.NodeID
-	if args.NodeID == ids.EmptyNodeID { // If ID unspecified, use this node's ID
-		nodeID = service.vm.ctx.NodeID
-	} else {
-		nodeID = args.NodeID
+	if args.SubnetID == "" {
+		return errNoSubnetID
 	}
 
-	// Parse the reward address
-	rewardAddress, err := avax.ParseServiceAddress(service.vm, args.RewardAddress)
+	// Parse the subnet ID
+	subnetID, err := ids.FromString(args.SubnetID)
 	if err != nil {
-		return fmt.Errorf("problem parsing 'rewardAddress': %w", err)
+		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
+	}
+	if subnetID == constants.PrimaryNetworkID {
+		return errNamedSubnetCantBePrimary
 	}
 
 	// Parse the from addresses
@@ -1069,17 +1529,16 @@ func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, re
 	}
 	defer user.Close()
 
-	privKeys, err := keystore.GetKeychain(user, fromAddrs)
+	keys, err := keystore.GetKeychain(user, fromAddrs)
 	if err != nil {
 		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
 	}
 
-	// Parse the change address. Assumes that if the user has no keys,
-	// this operation will fail so the change address can be anything.
-	if len(privKeys.Keys) == 0 {
+	// Parse the change address.
+	if len(keys.Keys) == 0 {
 		return errNoKeys
 	}
-	changeAddr := privKeys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	changeAddr := keys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
 	if args.ChangeAddr != "" {
 		changeAddr, err = avax.ParseServiceAddress(service.vm, args.ChangeAddr)
 		if err != nil {
@@ -1088,21 +1547,18 @@ func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, re
 	}
 
 	// Create the transaction
-	tx, err := service.vm.txBuilder.NewAddDelegatorTx(
-		args.GetWeight(),       // Stake amount
-		uint64(args.StartTime), // Start time
-		uint64(args.EndTime),   // End time
-		nodeID,                 // Node ID
-		rewardAddress,          // Reward Address
-		privKeys.Keys,          // Private keys
-		changeAddr,             // Change address
+	tx, err := service.vm.txBuilder.NewRemoveSubnetValidatorTx(
+		args.NodeID, // Node ID
+		subnetID,    // Subnet ID
+		keys.Keys,   // Keys
+		changeAddr,  // Change address
 	)
 	if err != nil {
 		return fmt.Errorf("couldn't create tx: %w", err)
 	}
 
-	reply.TxID = tx.ID()
-	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	response.TxID = tx.ID()
+	response.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
 
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -1113,46 +1569,29 @@ func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, re
 	return errs.Err
 }
 
-// AddSubnetValidatorArgs are the arguments to AddSubnetValidator
-type AddSubnetValidatorArgs struct {
+// RegisterBLSKeyArgs are the arguments to RegisterBLSKey
+type RegisterBLSKeyArgs struct {
 	// User, password, from addrs, change addr
 	api.JSONSpendHeader
-	platformapi.Staker
-	// ID of subnet to validate
-	SubnetID string `json:"subnetID"`
+	// ID of the node registering the key
+	NodeID ids.NodeID `json:"nodeID"`
+	// The BLS key and its proof of possession
+	Signer *signer.ProofOfPossession `json:"signer"`
+	// The node's authorization of the key, as returned by info.getNodeID
+	NodeAuthorization *signer.NodeAuthorization `json:"nodeAuthorization"`
 }
 
-// AddSubnetValidator creates and signs and issues a transaction to add a
-// validator to a subnet other than the primary network
-func (service *Service) AddSubnetValidator(_ *http.Request, args *AddSubnetValidatorArgs, response *api.JSONTxIDChangeAddr) error {
-	service.vm.ctx.Log.Debug("Platform: AddSubnetValidator called")
-
-	now := service.vm.clock.Time()
-	minAddStakerTime := now.Add(minAddStakerDelay)
-	minAddStakerUnix := json.Uint64(minAddStakerTime.Unix())
-	maxAddStakerTime := now.Add(maxFutureStartTime)
-	maxAddStakerUnix := json.Uint64(maxAddStakerTime.Unix())
-
-	if args.StartTime == 0 {
-		args.StartTime = minAddStakerUnix
-	}
+// RegisterBLSKey creates and signs and issues a transaction to register the
+// BLS key of a node. The key must be authorized by the node, and the user only
+// pays the fee.
+func (service *Service) RegisterBLSKey(_ *http.Request, args *RegisterBLSKeyArgs, response *api.JSONTxIDChangeAddr) error {
+	service.vm.ctx.Log.Debug("Platform: RegisterBLSKey called")
 
 	switch {
-	case args.SubnetID == "":
-		return errNoSubnetID
-	case args.StartTime < minAddStakerUnix:
-		return errStartTimeTooSoon
-	case args.StartTime > maxAddStakerUnix:
-		return errStartTimeTooLate
-	}
-
-	// Parse the subnet ID
-	subnetID, err := ids.FromString(args.SubnetID)
-	if err != nil {
-		return fmt.Errorf("problem parsing subnetID %q: %w", args.SubnetID, err)
-	}
-	if subnetID == constants.PrimaryNetworkID {
-		return errNamedSubnetCantBePrimary
+	case args.Signer == nil:
+		return txs.ErrMissingProofOfPossession
+	case args.NodeAuthorization == nil:
+		return txs.ErrMissingNodeAuthorization
 	}
 
 	// Parse the from addresses
@@ -1185,12 +1624,10 @@ func (service *Service) AddSubnetValidator(_ *http.Request, args *AddSubnetValid
 	}
 
 	// Create the transaction
-	tx, err := service.vm.txBuilder.NewAddSubnetValidatorTx(
-		args.GetWeight(),       // Stake amount
-		uint64(args.StartTime), // Start time
-		uint64(args.EndTime),   // End time
+	tx, err := service.vm.txBuilder.NewRegisterBLSKeyTx(
 		args.NodeID,            // Node ID
-		subnetID,               // Subnet ID
+		args.Signer,            // Proof of possession
+		args.NodeAuthorization, // Node authorization
 		keys.Keys,              // Keys
 		changeAddr,             // Change address
 	)
@@ -1854,6 +2291,47 @@ func (service *Service) IssueTx(_ *http.Request, args *api.FormattedTx, response
 	return nil
 }
 
+// EstimateFee returns the fee that must be burned by the provided tx if it were
+// issued at the current chain time. The tx may be either unsigned or signed.
+func (service *Service) EstimateFee(_ *http.Request, args *api.FormattedTx, response *api.EstimateFeeReply) error {
+	service.vm.ctx.Log.Debug("Platform: EstimateFee called")
+
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
+	if err != nil {
+		return fmt.Errorf("problem decoding transaction: %w", err)
+	}
+	unsignedTx, err := parseUnsignedTx(txBytes)
+	if err != nil {
+		return fmt.Errorf("couldn't parse tx: %w", err)
+	}
+
+	feeCalculator := txFeeCalculator{
+		vm:        service.vm,
+		timestamp: service.vm.internalState.GetTimestamp(),
+	}
+	if err := unsignedTx.Visit(&feeCalculator); err != nil {
+		return err
+	}
+
+	response.Fee = json.Uint64(feeCalculator.fee)
+	response.AssetID = service.vm.ctx.AVAXAssetID
+	return nil
+}
+
+// parseUnsignedTx parses [txBytes] as an unsigned tx, falling back to parsing
+// it as a signed tx
+func parseUnsignedTx(txBytes []byte) (txs.UnsignedTx, error) {
+	var unsignedTx txs.UnsignedTx
+	if _, err := Codec.Unmarshal(txBytes, &unsignedTx); err == nil {
+		return unsignedTx, nil
+	}
+	tx, err := txs.Parse(Codec, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Unsigned, nil
+}
+
 // GetTx gets a tx
 func (service *Service) GetTx(_ *http.Request, args *api.GetTxArgs, response *api.GetTxReply) error {
 	service.vm.ctx.Log.Debug("Platform: GetTx called")
@@ -1959,16 +2437,26 @@ func (service *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, resp
 type GetStakeArgs struct {
 	api.JSONAddresses
 	Encoding formatting.Encoding `json:"encoding"`
+	// Stakers are examined in order of their tx IDs. If provided, only stakers
+	// with a tx ID >= [StartTxID] are examined.
+	StartTxID ids.ID `json:"startTxID"`
+	// Max number of stakers with stake owned by [Addresses] to return. If 0,
+	// all of them are returned.
+	Limit json.Uint32 `json:"limit"`
 }
 
 // GetStakeReply is the response from calling GetStake.
 type GetStakeReply struct {
+	// Amount staked by the stakers returned in this page
 	Staked json.Uint64 `json:"staked"`
 	// String representation of staked outputs
 	// Each is of type avax.TransferableOutput
 	Outputs []string `json:"stakedOutputs"`
 	// Encoding of [Outputs]
 	Encoding formatting.Encoding `json:"encoding"`
+	// If non-nil, there are more stakers to fetch. The next page starts with
+	// the staker with this tx ID.
+	NextTxID *ids.ID `json:"nextTxID,omitempty"`
 }
 
 // Takes in a staker and a set of addresses
@@ -2022,7 +2510,7 @@ func (service *Service) getStakeHelper(tx *txs.Tx, addrs ids.ShortSet) (uint64,
 			// This output isn't owned by one of the given addresses. Ignore.
 			continue
 		}
-		totalAmountStaked, err = math.Add64(totalAmountStaked, stake.Out.Amount())
+		totalAmountStaked, err = math.Add(totalAmountStaked, stake.Out.Amount())
 		if err != nil {
 			return 0, stakedOuts, err
 		}
@@ -2035,7 +2523,8 @@ func (service *Service) getStakeHelper(tx *txs.Tx, addrs ids.ShortSet) (uint64,
 }
 
 // GetStake returns the amount of nAVAX that [args.Addresses] have cumulatively
-// staked on the Primary Network.
+// staked on the Primary Network. If [args.Limit] is non-zero, the stakers are
+// paginated and only the stake of the returned page is reported.
 //
 // This method assumes that each stake output has only owner
 // This method assumes only AVAX can be staked
@@ -2054,32 +2543,42 @@ func (service *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *
 		return err
 	}
 
-	currentStakers := service.vm.internalState.CurrentStakers()
-	stakers := currentStakers.Stakers()
+	// Skip the stakers before the start of the requested page
+	currentStakers := service.vm.internalState.CurrentStakers().StakersByTxID()
+	currentStakers = currentStakers[searchTxsByID(currentStakers, args.StartTxID):]
+	pendingStakers := service.vm.internalState.PendingStakers().StakersByTxID()
+	pendingStakers = pendingStakers[searchTxsByID(pendingStakers, args.StartTxID):]
 
 	var (
 		totalStake uint64
-		stakedOuts = make([]avax.TransferableOutput, 0, len(stakers))
+		numStakers int
+		stakedOuts []avax.TransferableOutput
 	)
-	for _, tx := range stakers { // Iterates over current stakers
-		stakedAmt, outs, err := service.getStakeHelper(tx, addrs)
-		if err != nil {
-			return err
-		}
-		totalStake, err = math.Add64(totalStake, stakedAmt)
-		if err != nil {
-			return err
+	response.NextTxID = nil
+	for len(currentStakers) > 0 || len(pendingStakers) > 0 {
+		// Visit the stakers of both sets in order of their tx IDs
+		var tx *txs.Tx
+		if len(pendingStakers) == 0 || (len(currentStakers) > 0 && txIDLess(currentStakers[0], pendingStakers[0])) {
+			tx, currentStakers = currentStakers[0], currentStakers[1:]
+		} else {
+			tx, pendingStakers = pendingStakers[0], pendingStakers[1:]
 		}
-		stakedOuts = append(stakedOuts, outs...)
-	}
 
-	pendingStakers := service.vm.internalState.PendingStakers()
-	for _, tx := range pendingStakers.Stakers() { // Iterates over pending stakers
 		stakedAmt, outs, err := service.getStakeHelper(tx, addrs)
 		if err != nil {
 			return err
 		}
-		totalStake, err = math.Add64(totalStake, stakedAmt)
+		if len(outs) == 0 {
+			continue
+		}
+		if args.Limit != 0 && numStakers >= int(args.Limit) {
+			nextTxID := tx.ID()
+			response.NextTxID = &nextTxID
+			break
+		}
+		numStakers++
+
+		totalStake, err = math.Add(totalStake, stakedAmt)
 		if err != nil {
 			return err
 		}
@@ -2256,6 +2755,33 @@ func (service *Service) GetValidatorsAt(_ *http.Request, args *GetValidatorsAtAr
 	return nil
 }
 
+// GetBLSPublicKeyArgs are the arguments to GetBLSPublicKey
+type GetBLSPublicKeyArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// GetBLSPublicKeyReply is the response from GetBLSPublicKey
+type GetBLSPublicKeyReply struct {
+	// Hex encoding of the compressed public key
+	PublicKey string `json:"publicKey"`
+}
+
+// GetBLSPublicKey returns the BLS public key registered by a node.
+func (service *Service) GetBLSPublicKey(_ *http.Request, args *GetBLSPublicKeyArgs, reply *GetBLSPublicKeyReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetBLSPublicKey called with NodeID %s", args.NodeID)
+
+	key, err := service.vm.internalState.GetBLSKey(args.NodeID)
+	if err == database.ErrNotFound {
+		return fmt.Errorf("%s hasn't registered a BLS key", args.NodeID)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get BLS key: %w", err)
+	}
+
+	reply.PublicKey, err = formatting.Encode(formatting.HexNC, bls.PublicKeyToBytes(key.PublicKey))
+	return err
+}
+
 func (service *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
 	service.vm.ctx.Log.Debug("Platform: GetBlock called with args %s", args)
 
@@ -2277,3 +2803,244 @@ func (service *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, respon
 
 	return nil
 }
+
+// ExportGenesisArgs are the arguments for calling ExportGenesis
+type ExportGenesisArgs struct {
+	// Encoding of the chains' genesis data in the reply
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// ExportGenesisReply is the response from calling ExportGenesis
+type ExportGenesisReply struct {
+	// Height of the last accepted block, whose state was exported
+	Height json.Uint64 `json:"height"`
+	// Genesis can be passed to platform.buildGenesis to create the genesis of
+	// a network that starts from the exported state
+	Genesis platformapi.BuildGenesisArgs `json:"genesis"`
+}
+
+// ExportGenesis exports the current validators, balances and chains of the
+// primary network in the genesis format, so that a fork or test network can be
+// started with the same state. The genesis format can't represent everything:
+//   - Subnets, and the chains and validators of subnets, are omitted.
+//   - Outputs with multiple owners or a threshold other than 1 can't be
+//     exported, so an error is returned if there are any.
+//   - Stake of delegators and of pending validators is exported as UTXOs that
+//     are locked until the end of the staking period.
+//   - Validators start validating at the exported timestamp.
+func (service *Service) ExportGenesis(_ *http.Request, args *ExportGenesisArgs, reply *ExportGenesisReply) error {
+	service.vm.ctx.Log.Debug("Platform: ExportGenesis called")
+
+	lastAcceptedID, err := service.vm.LastAccepted()
+	if err != nil {
+		return fmt.Errorf("couldn't get last accepted block ID: %w", err)
+	}
+	lastAccepted, err := service.vm.getBlock(lastAcceptedID)
+	if err != nil {
+		return fmt.Errorf("couldn't get last accepted block: %w", err)
+	}
+	emptyMessage, err := formatting.Encode(args.Encoding, nil)
+	if err != nil {
+		return fmt.Errorf("couldn't encode message: %w", err)
+	}
+
+	s := service.vm.internalState
+	reply.Height = json.Uint64(lastAccepted.Height())
+	reply.Genesis = platformapi.BuildGenesisArgs{
+		AvaxAssetID:   service.vm.ctx.AVAXAssetID,
+		NetworkID:     json.Uint32(service.vm.ctx.NetworkID),
+		UTXOs:         []platformapi.UTXO{},
+		Validators:    []platformapi.PrimaryValidator{},
+		Chains:        []platformapi.Chain{},
+		Time:          json.Uint64(s.GetTimestamp().Unix()),
+		InitialSupply: json.Uint64(s.GetCurrentSupply()),
+		Message:       emptyMessage,
+		Encoding:      args.Encoding,
+	}
+	genesis := &reply.Genesis
+
+	// addLockedStake exports [stake] as UTXOs that are locked until [endTime]
+	addLockedStake := func(stake []*avax.TransferableOutput, endTime time.Time) error {
+		for _, out := range stake {
+			utxo, ok, err := service.genesisUTXO(out.Out, uint64(endTime.Unix()), emptyMessage)
+			if err != nil {
+				return err
+			}
+			if ok {
+				genesis.UTXOs = append(genesis.UTXOs, utxo)
+			}
+		}
+		return nil
+	}
+
+	utxoID := ids.Empty
+	for {
+		utxoIDs, err := s.AllUTXOIDs(utxoID, builder.MaxPageSize)
+		if err != nil {
+			return fmt.Errorf("couldn't get UTXO IDs: %w", err)
+		}
+		for _, utxoID := range utxoIDs {
+			utxo, err := s.GetUTXO(utxoID)
+			if err != nil {
+				return fmt.Errorf("couldn't get UTXO %s: %w", utxoID, err)
+			}
+			if utxo.AssetID() != service.vm.ctx.AVAXAssetID {
+				continue
+			}
+			apiUTXO, ok, err := service.genesisUTXO(utxo.Out, 0, emptyMessage)
+			if err != nil {
+				return err
+			}
+			if ok {
+				genesis.UTXOs = append(genesis.UTXOs, apiUTXO)
+			}
+		}
+		if len(utxoIDs) < builder.MaxPageSize {
+			break
+		}
+		utxoID = utxoIDs[len(utxoIDs)-1]
+	}
+
+	for _, tx := range s.CurrentStakers().Stakers() {
+		switch staker := tx.Unsigned.(type) {
+		case *txs.AddValidatorTx:
+			vdr, err := service.genesisValidator(staker, emptyMessage)
+			if err != nil {
+				return err
+			}
+			genesis.Validators = append(genesis.Validators, vdr)
+		case *txs.AddDelegatorTx:
+			if err := addLockedStake(staker.Stake, staker.EndTime()); err != nil {
+				return err
+			}
+		}
+	}
+	for _, tx := range s.PendingStakers().Stakers() {
+		switch staker := tx.Unsigned.(type) {
+		case *txs.AddValidatorTx:
+			if err := addLockedStake(staker.Stake, staker.EndTime()); err != nil {
+				return err
+			}
+		case *txs.AddDelegatorTx:
+			if err := addLockedStake(staker.Stake, staker.EndTime()); err != nil {
+				return err
+			}
+		}
+	}
+
+	chains, err := s.GetChains(constants.PrimaryNetworkID)
+	if err != nil {
+		return fmt.Errorf("couldn't get chains: %w", err)
+	}
+	for _, tx := range chains {
+		chain, ok := tx.Unsigned.(*txs.CreateChainTx)
+		if !ok {
+			return errWrongTxType
+		}
+		genesisData, err := formatting.Encode(args.Encoding, chain.GenesisData)
+		if err != nil {
+			return fmt.Errorf("couldn't encode genesis data of chain %q: %w", chain.ChainName, err)
+		}
+		genesis.Chains = append(genesis.Chains, platformapi.Chain{
+			GenesisData: genesisData,
+			VMID:        chain.VMID,
+			FxIDs:       chain.FxIDs,
+			Name:        chain.ChainName,
+			SubnetID:    chain.SubnetID,
+		})
+	}
+	return nil
+}
+
+// genesisValidator returns the genesis representation of the current primary
+// network validator added by [staker]
+func (service *Service) genesisValidator(staker *txs.AddValidatorTx, message string) (platformapi.PrimaryValidator, error) {
+	rewardOwner := &platformapi.Owner{}
+	if secpOwner, ok := staker.RewardsOwner.(*secp256k1fx.OutputOwners); ok {
+		rewardOwner.Locktime = json.Uint64(secpOwner.Locktime)
+		rewardOwner.Threshold = json.Uint32(secpOwner.Threshold)
+		for _, addr := range secpOwner.Addrs {
+			addrStr, err := service.genesisAddress(addr)
+			if err != nil {
+				return platformapi.PrimaryValidator{}, err
+			}
+			rewardOwner.Addresses = append(rewardOwner.Addresses, addrStr)
+		}
+	}
+	delegationFee := json.Uint32(staker.Shares)
+	vdr := platformapi.PrimaryValidator{
+		Staker: platformapi.Staker{
+			NodeID:  staker.Validator.ID(),
+			EndTime: json.Uint64(staker.EndTime().Unix()),
+		},
+		RewardOwner:        rewardOwner,
+		ExactDelegationFee: &delegationFee,
+	}
+	for _, out := range staker.Stake {
+		utxo, ok, err := service.genesisUTXO(out.Out, 0, message)
+		if err != nil {
+			return platformapi.PrimaryValidator{}, err
+		}
+		if ok {
+			vdr.Staked = append(vdr.Staked, utxo)
+		}
+	}
+	return vdr, nil
+}
+
+// genesisUTXO returns the genesis representation of [out], which can't be spent
+// before [locktime]. Returns false if [out] can't be represented in the
+// genesis.
+func (service *Service) genesisUTXO(out verify.State, locktime uint64, message string) (platformapi.UTXO, bool, error) {
+	if lockOut, ok := out.(*stakeable.LockOut); ok {
+		locktime = math.Max64(locktime, lockOut.Locktime)
+		out = lockOut.TransferableOut
+	}
+	secpOut, ok := out.(*secp256k1fx.TransferOutput)
+	if !ok || secpOut.Amt == 0 || len(secpOut.Addrs) == 0 {
+		return platformapi.UTXO{}, false, nil
+	}
+	if len(secpOut.Addrs) > 1 || secpOut.Threshold != 1 {
+		return platformapi.UTXO{}, false, fmt.Errorf(
+			"%w: output with %d owners and threshold %d",
+			errUnsupportedGenesisOutput,
+			len(secpOut.Addrs),
+			secpOut.Threshold,
+		)
+	}
+	locktime = math.Max64(locktime, secpOut.Locktime)
+
+	addr, err := service.genesisAddress(secpOut.Addrs[0])
+	if err != nil {
+		return platformapi.UTXO{}, false, err
+	}
+	return platformapi.UTXO{
+		Locktime: json.Uint64(locktime),
+		Amount:   json.Uint64(secpOut.Amt),
+		Address:  addr,
+		Message:  message,
+	}, true, nil
+}
+
+// genesisAddress returns [addr] in the format used by the genesis, which
+// doesn't include the chain alias
+func (service *Service) genesisAddress(addr ids.ShortID) (string, error) {
+	hrp := constants.GetHRP(service.vm.ctx.NetworkID)
+	return address.FormatBech32(hrp, addr.Bytes())
+}
+
+// searchTxsByID returns the index of the first tx in [sorted], which is sorted
+// by tx ID, whose ID is >= [txID].
+func searchTxsByID(sorted []*txs.Tx, txID ids.ID) int {
+	return sort.Search(len(sorted), func(i int) bool {
+		iID := sorted[i].ID()
+		return bytes.Compare(iID[:], txID[:]) >= 0
+	})
+}
+
+// txIDLess returns true if the ID of [i] sorts before the ID of [j]
+func txIDLess(i, j *txs.Tx) bool {
+	iID := i.ID()
+	jID := j.ID()
+	return bytes.Compare(iID[:], jID[:]) < 0
+}
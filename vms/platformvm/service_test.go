@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"math/rand"
+	"sort"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/version"
@@ -436,6 +438,79 @@ func TestGetBalance(t *testing.T) {
 	}
 }
 
+func TestGetUTXOsByID(t *testing.T) {
+	assert := assert.New(t)
+
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	addrs := ids.ShortSet{}
+	addrs.Add(keys[0].PublicKey().Address())
+	genesisUTXOs, err := avax.GetAllUTXOs(service.vm.internalState, addrs)
+	assert.NoError(err)
+	assert.NotEmpty(genesisUTXOs)
+	genesisUTXO := genesisUTXOs[0]
+
+	// The outputs of a committed tx that aren't in the UTXO set were spent
+	tx, err := service.vm.txBuilder.NewExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+	assert.NotEmpty(tx.UTXOs())
+	service.vm.internalState.AddTx(tx, status.Committed)
+
+	spent := &avax.UTXOID{
+		TxID: tx.ID(),
+	}
+	unknownIndex := &avax.UTXOID{
+		TxID:        tx.ID(),
+		OutputIndex: uint32(len(tx.UTXOs())),
+	}
+	unknownTx := &avax.UTXOID{
+		TxID: ids.GenerateTestID(),
+	}
+
+	reply := api.GetUTXOsByIDReply{}
+	err = service.GetUTXOsByID(nil, &api.GetUTXOsByIDArgs{
+		UTXOIDs: []string{
+			genesisUTXO.UTXOID.String(),
+			spent.String(),
+			unknownIndex.String(),
+			unknownTx.String(),
+		},
+		Encoding: formatting.Hex,
+	}, &reply)
+	assert.NoError(err)
+	assert.Len(reply.UTXOs, 4)
+
+	// UTXOs are returned in the requested order
+	assert.Equal(genesisUTXO.UTXOID.String(), reply.UTXOs[0].UTXOID)
+	assert.Equal(api.UTXOUnspent, reply.UTXOs[0].Status)
+	utxoBytes, err := formatting.Decode(reply.Encoding, reply.UTXOs[0].UTXO)
+	assert.NoError(err)
+	expectedBytes, err := Codec.Marshal(txs.Version, genesisUTXO)
+	assert.NoError(err)
+	assert.Equal(expectedBytes, utxoBytes)
+
+	assert.Equal(api.UTXOSpent, reply.UTXOs[1].Status)
+	assert.Empty(reply.UTXOs[1].UTXO)
+	assert.Equal(api.UTXOUnknown, reply.UTXOs[2].Status)
+	assert.Empty(reply.UTXOs[2].UTXO)
+	assert.Equal(api.UTXOUnknown, reply.UTXOs[3].Status)
+	assert.Empty(reply.UTXOs[3].UTXO)
+
+	err = service.GetUTXOsByID(nil, &api.GetUTXOsByIDArgs{}, &reply)
+	assert.ErrorIs(err, errNoUTXOIDs)
+}
+
 // Test method GetStake
 func TestGetStake(t *testing.T) {
 	assert := assert.New(t)
@@ -455,10 +530,10 @@ func TestGetStake(t *testing.T) {
 		addr := fmt.Sprintf("P-%s", validator.RewardOwner.Addresses[0])
 		addrsStrs = append(addrsStrs, addr)
 		args := GetStakeArgs{
-			api.JSONAddresses{
+			JSONAddresses: api.JSONAddresses{
 				Addresses: []string{addr},
 			},
-			formatting.Hex,
+			Encoding: formatting.Hex,
 		}
 		response := GetStakeReply{}
 		err := service.GetStake(nil, &args, &response)
@@ -482,10 +557,10 @@ func TestGetStake(t *testing.T) {
 
 	// Make sure this works for multiple addresses
 	args := GetStakeArgs{
-		api.JSONAddresses{
+		JSONAddresses: api.JSONAddresses{
 			Addresses: addrsStrs,
 		},
-		formatting.Hex,
+		Encoding: formatting.Hex,
 	}
 	response := GetStakeReply{}
 	err := service.GetStake(nil, &args, &response)
@@ -652,6 +727,14 @@ func TestGetCurrentValidators(t *testing.T) {
 		}
 	}
 
+	// The reward owners must be parsable by the client
+	for _, vdrIntf := range response.Validators {
+		vdr := vdrIntf.(pchainapi.PrimaryValidator)
+		if _, err := address.ParseToIDs(vdr.RewardOwner.Addresses); err != nil {
+			t.Fatal(err)
+		}
+	}
+
 	// Add a delegator
 	stakeAmount := service.vm.MinDelegatorStake + 12345
 	validatorNodeID := ids.NodeID(keys[1].PublicKey().Address())
@@ -747,6 +830,146 @@ func TestGetTimestamp(t *testing.T) {
 	assert.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestEstimateReward(t *testing.T) {
+	assert := assert.New(t)
+
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	startTime := uint64(defaultValidateStartTime.Unix())
+	endTime := startTime + uint64(defaultMinStakingDuration/time.Second)
+	args := EstimateRewardArgs{
+		Amount:    json.Uint64(service.vm.MinValidatorStake),
+		StartTime: json.Uint64(startTime),
+		EndTime:   json.Uint64(endTime),
+	}
+	reply := EstimateRewardReply{}
+	err := service.EstimateReward(nil, &args, &reply)
+	assert.NoError(err)
+
+	currentSupply := service.vm.internalState.GetCurrentSupply()
+	expectedReward := service.vm.rewards.Calculate(
+		defaultMinStakingDuration,
+		service.vm.MinValidatorStake,
+		currentSupply,
+	)
+	assert.EqualValues(expectedReward, reply.Reward)
+	assert.EqualValues(currentSupply, reply.CurrentSupply)
+	assert.NotZero(reply.Reward)
+
+	// Staking for less than the minimum duration isn't rewarded
+	args.EndTime--
+	err = service.EstimateReward(nil, &args, &reply)
+	assert.ErrorIs(err, errStakeTooShort)
+
+	args.EndTime = json.Uint64(startTime) + json.Uint64(defaultMaxStakingDuration/time.Second) + 1
+	err = service.EstimateReward(nil, &args, &reply)
+	assert.ErrorIs(err, errStakeTooLong)
+
+	args.EndTime = args.StartTime
+	err = service.EstimateReward(nil, &args, &reply)
+	assert.ErrorIs(err, errStartAfterEndTime)
+
+	args.EndTime = json.Uint64(endTime)
+	args.SubnetID = ids.GenerateTestID()
+	err = service.EstimateReward(nil, &args, &reply)
+	assert.Error(err)
+}
+
+func TestGetStakersByRewardAddress(t *testing.T) {
+	assert := assert.New(t)
+
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	// Genesis validators are rewarded to their own key
+	genesisAddr, err := service.vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	assert.NoError(err)
+	args := GetStakersByRewardAddressArgs{Address: genesisAddr}
+	reply := GetStakersByRewardAddressReply{}
+	err = service.GetStakersByRewardAddress(nil, &args, &reply)
+	assert.NoError(err)
+	assert.Len(reply.Validators, 1)
+	assert.Empty(reply.Delegators)
+	vdr, ok := reply.Validators[0].(pchainapi.PrimaryValidator)
+	assert.True(ok)
+	assert.Equal(ids.NodeID(keys[0].PublicKey().Address()), vdr.NodeID)
+	assert.Equal(vdr.TxID, reply.EndTxID)
+
+	// There are no more stakers after the last page
+	args.StartTxID = reply.EndTxID
+	err = service.GetStakersByRewardAddress(nil, &args, &reply)
+	assert.NoError(err)
+	assert.Empty(reply.Validators)
+
+	rewardAddr := ids.GenerateTestShortID()
+	tx, err := service.vm.txBuilder.NewAddDelegatorTx(
+		service.vm.MinDelegatorStake,
+		uint64(defaultGenesisTime.Unix()),
+		uint64(defaultGenesisTime.Add(defaultMinStakingDuration).Unix()),
+		ids.NodeID(keys[0].PublicKey().Address()),
+		rewardAddr,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+
+	service.vm.internalState.AddPendingStaker(tx)
+	service.vm.internalState.AddTx(tx, status.Committed)
+	err = service.vm.internalState.Commit()
+	assert.NoError(err)
+	err = service.vm.internalState.Load()
+	assert.NoError(err)
+
+	args = GetStakersByRewardAddressArgs{
+		Address: rewardAddr.String(),
+		Pending: true,
+	}
+	err = service.GetStakersByRewardAddress(nil, &args, &reply)
+	assert.NoError(err)
+	assert.Empty(reply.Validators)
+	assert.Len(reply.Delegators, 1)
+	assert.Equal(tx.ID(), reply.EndTxID)
+
+	args.Pending = false
+	err = service.GetStakersByRewardAddress(nil, &args, &reply)
+	assert.NoError(err)
+	assert.Empty(reply.Delegators)
+
+	// The index follows the delegator when it starts staking
+	service.vm.internalState.DeletePendingStaker(tx)
+	service.vm.internalState.AddCurrentStaker(tx, 1234)
+	err = service.vm.internalState.Commit()
+	assert.NoError(err)
+	err = service.vm.internalState.Load()
+	assert.NoError(err)
+
+	err = service.GetStakersByRewardAddress(nil, &args, &reply)
+	assert.NoError(err)
+	assert.Len(reply.Delegators, 1)
+	delegator, ok := reply.Delegators[0].(pchainapi.PrimaryDelegator)
+	assert.True(ok)
+	assert.Equal(tx.ID(), delegator.TxID)
+	assert.EqualValues(1234, *delegator.PotentialReward)
+
+	args.Pending = true
+	err = service.GetStakersByRewardAddress(nil, &args, &reply)
+	assert.NoError(err)
+	assert.Empty(reply.Delegators)
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -798,3 +1021,230 @@ func TestGetBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCurrentValidatorsPagination(t *testing.T) {
+	assert := assert.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	genesis, _ := defaultGenesis()
+
+	// Fetch the validators one page at a time
+	args := GetCurrentValidatorsArgs{
+		SubnetID:       constants.PrimaryNetworkID,
+		Limit:          2,
+		OmitDelegators: true,
+	}
+	var nodeIDs []ids.NodeID
+	for {
+		response := GetCurrentValidatorsReply{}
+		err := service.GetCurrentValidators(nil, &args, &response)
+		assert.NoError(err)
+		assert.LessOrEqual(len(response.Validators), 2)
+
+		for _, vdrIntf := range response.Validators {
+			vdr, ok := vdrIntf.(pchainapi.PrimaryValidator)
+			assert.True(ok)
+			assert.Nil(vdr.Delegators)
+			assert.NotNil(vdr.DelegatorCount)
+			assert.EqualValues(0, *vdr.DelegatorCount)
+			nodeIDs = append(nodeIDs, vdr.NodeID)
+		}
+
+		if response.NextNodeID == nil {
+			break
+		}
+		args.StartNodeID = *response.NextNodeID
+	}
+
+	// Every validator should have been returned exactly once, in order
+	assert.Len(nodeIDs, len(genesis.Validators))
+	assert.True(sort.SliceIsSorted(nodeIDs, func(i, j int) bool {
+		return bytes.Compare(nodeIDs[i][:], nodeIDs[j][:]) < 0
+	}))
+	for _, vdr := range genesis.Validators {
+		assert.Contains(nodeIDs, vdr.NodeID)
+	}
+}
+
+func TestGetStakePagination(t *testing.T) {
+	assert := assert.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	genesis, _ := defaultGenesis()
+	addrsStrs := []string{}
+	for _, validator := range genesis.Validators {
+		addrsStrs = append(addrsStrs, fmt.Sprintf("P-%s", validator.RewardOwner.Addresses[0]))
+	}
+
+	// Fetch the stake one staker at a time
+	args := GetStakeArgs{
+		JSONAddresses: api.JSONAddresses{
+			Addresses: addrsStrs,
+		},
+		Encoding: formatting.Hex,
+		Limit:    1,
+	}
+	var (
+		totalStake uint64
+		numPages   int
+	)
+	for {
+		response := GetStakeReply{}
+		err := service.GetStake(nil, &args, &response)
+		assert.NoError(err)
+		assert.Len(response.Outputs, 1)
+		assert.EqualValues(defaultWeight, response.Staked)
+
+		totalStake += uint64(response.Staked)
+		numPages++
+
+		if response.NextTxID == nil {
+			break
+		}
+		args.StartTxID = *response.NextTxID
+	}
+	assert.Equal(len(genesis.Validators), numPages)
+	assert.EqualValues(len(genesis.Validators)*defaultWeight, totalStake)
+}
+
+func TestEstimateFee(t *testing.T) {
+	assert := assert.New(t)
+
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	service.vm.CreateAssetTxFee = 50
+
+	estimateFee := func(txIntf interface{}) (*api.EstimateFeeReply, error) {
+		txBytes, err := Codec.Marshal(txs.Version, txIntf)
+		assert.NoError(err)
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		assert.NoError(err)
+
+		reply := &api.EstimateFeeReply{}
+		err = service.EstimateFee(nil, &api.FormattedTx{
+			Tx:       txStr,
+			Encoding: formatting.Hex,
+		}, reply)
+		return reply, err
+	}
+
+	// Unsigned txs
+	var unsignedTx txs.UnsignedTx = &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    service.vm.ctx.NetworkID,
+			BlockchainID: service.vm.ctx.ChainID,
+		}},
+		Owner: &secp256k1fx.OutputOwners{},
+	}
+	reply, err := estimateFee(&unsignedTx)
+	assert.NoError(err)
+	// ApricotPhase3 hasn't activated yet
+	assert.EqualValues(50, reply.Fee)
+	assert.Equal(service.vm.ctx.AVAXAssetID, reply.AssetID)
+
+	// Signed txs
+	tx, err := service.vm.txBuilder.NewExportTx(
+		100,
+		service.vm.ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	assert.NoError(err)
+	reply, err = estimateFee(tx)
+	assert.NoError(err)
+	assert.EqualValues(defaultTxFee, reply.Fee)
+
+	// Txs issued by the VM don't have a fee
+	unsignedTx = &txs.AdvanceTimeTx{}
+	_, err = estimateFee(&unsignedTx)
+	assert.ErrorIs(err, errWrongTxType)
+}
+
+func TestExportGenesis(t *testing.T) {
+	assert := assert.New(t)
+
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	args := ExportGenesisArgs{Encoding: formatting.Hex}
+	reply := ExportGenesisReply{}
+	err := service.ExportGenesis(nil, &args, &reply)
+	assert.NoError(err)
+
+	heightReply := GetHeightResponse{}
+	err = service.GetHeight(nil, nil, &heightReply)
+	assert.NoError(err)
+	assert.Equal(heightReply.Height, reply.Height)
+
+	genesis, _ := defaultGenesis()
+	assert.Len(reply.Genesis.Validators, len(genesis.Validators))
+	assert.Len(reply.Genesis.UTXOs, len(genesis.UTXOs))
+	assert.Len(reply.Genesis.Chains, len(genesis.Chains))
+	assert.Equal(genesis.Time, reply.Genesis.Time)
+	for _, vdr := range reply.Genesis.Validators {
+		assert.Len(vdr.Staked, 1)
+		assert.EqualValues(defaultWeight, vdr.Staked[0].Amount)
+	}
+
+	// The exported genesis should be usable to start a new network
+	buildReply := pchainapi.BuildGenesisReply{}
+	err = (&pchainapi.StaticService{}).BuildGenesis(nil, &reply.Genesis, &buildReply)
+	assert.NoError(err)
+}
+
+func TestGenesisUTXOMultisig(t *testing.T) {
+	assert := assert.New(t)
+
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		err := service.vm.Shutdown()
+		assert.NoError(err)
+
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	out := &secp256k1fx.TransferOutput{
+		Amt: 1,
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+	}
+	_, ok, err := service.genesisUTXO(out, 0, "")
+	assert.NoError(err)
+	assert.True(ok)
+
+	out.Addrs = append(out.Addrs, ids.GenerateTestShortID())
+	_, _, err = service.genesisUTXO(out, 0, "")
+	assert.ErrorIs(err, errUnsupportedGenesisOutput)
+
+	out.Addrs = out.Addrs[:1]
+	out.Threshold = 0
+	_, _, err = service.genesisUTXO(out, 0, "")
+	assert.ErrorIs(err, errUnsupportedGenesisOutput)
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	stdjson "encoding/json"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// nodeAuthorizationPrefix separates the messages signed to authorize BLS keys
+// from the other messages that are signed with staking keys
+var nodeAuthorizationPrefix = []byte("avalanche bls key registration")
+
+var (
+	errMissingCertificate = errors.New("missing staking certificate")
+	errWrongNodeID        = errors.New("staking certificate doesn't belong to the node")
+)
+
+// NodeAuthorization is a node's authorization, signed with its staking key, to
+// register a BLS public key as the key the node signs with.
+type NodeAuthorization struct {
+	// Timestamp is the unix time at which the node authorized the key. A node
+	// can only replace its key with one that it authorized later, so that an
+	// authorization can't be replayed once the key was rotated.
+	Timestamp uint64 `serialize:"true"`
+	// Certificate is the DER encoded staking certificate of the node
+	Certificate []byte `serialize:"true"`
+	// Signature is the signature of the staking key over the network ID, the
+	// BLS public key and [Timestamp]
+	Signature []byte `serialize:"true"`
+}
+
+// NewNodeAuthorization returns the authorization of the node with staking
+// certificate [cert] and staking key [signer] to register [pk] on the network
+// [networkID].
+func NewNodeAuthorization(
+	cert *x509.Certificate,
+	signer crypto.Signer,
+	networkID uint32,
+	pk *bls.PublicKey,
+	timestamp uint64,
+) (*NodeAuthorization, error) {
+	msg := nodeAuthorizationMessage(networkID, bls.PublicKeyToBytes(pk), timestamp)
+	sig, err := staking.Sign(signer, msg)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeAuthorization{
+		Timestamp:   timestamp,
+		Certificate: cert.Raw,
+		Signature:   sig,
+	}, nil
+}
+
+// Verify returns nil iff [a] was signed by the staking key of [nodeID] to
+// register [pk] on the network [networkID]
+func (a *NodeAuthorization) Verify(networkID uint32, nodeID ids.NodeID, pk []byte) error {
+	if len(a.Certificate) == 0 {
+		return errMissingCertificate
+	}
+	cert, err := x509.ParseCertificate(a.Certificate)
+	if err != nil {
+		return fmt.Errorf("couldn't parse staking certificate: %w", err)
+	}
+	if certNodeID := ids.NodeIDFromCert(cert); certNodeID != nodeID {
+		return fmt.Errorf("%w: expected %s but got %s", errWrongNodeID, nodeID, certNodeID)
+	}
+	return cert.CheckSignature(
+		cert.SignatureAlgorithm,
+		nodeAuthorizationMessage(networkID, pk, a.Timestamp),
+		a.Signature,
+	)
+}
+
+func nodeAuthorizationMessage(networkID uint32, pk []byte, timestamp uint64) []byte {
+	p := wrappers.Packer{
+		Bytes: make([]byte, len(nodeAuthorizationPrefix)+wrappers.IntLen+len(pk)+wrappers.LongLen),
+	}
+	p.PackFixedBytes(nodeAuthorizationPrefix)
+	p.PackInt(networkID)
+	p.PackFixedBytes(pk)
+	p.PackLong(timestamp)
+	return p.Bytes
+}
+
+type jsonNodeAuthorization struct {
+	Timestamp   json.Uint64 `json:"timestamp"`
+	Certificate string      `json:"certificate"`
+	Signature   string      `json:"signature"`
+}
+
+func (a *NodeAuthorization) MarshalJSON() ([]byte, error) {
+	cert, err := formatting.Encode(formatting.HexNC, a.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := formatting.Encode(formatting.HexNC, a.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return stdjson.Marshal(jsonNodeAuthorization{
+		Timestamp:   json.Uint64(a.Timestamp),
+		Certificate: cert,
+		Signature:   sig,
+	})
+}
+
+func (a *NodeAuthorization) UnmarshalJSON(b []byte) error {
+	jsonAuth := jsonNodeAuthorization{}
+	if err := stdjson.Unmarshal(b, &jsonAuth); err != nil {
+		return err
+	}
+
+	cert, err := formatting.Decode(formatting.HexNC, jsonAuth.Certificate)
+	if err != nil {
+		return err
+	}
+	sig, err := formatting.Decode(formatting.HexNC, jsonAuth.Signature)
+	if err != nil {
+		return err
+	}
+
+	a.Timestamp = uint64(jsonAuth.Timestamp)
+	a.Certificate = cert
+	a.Signature = sig
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"crypto"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+func TestNodeAuthorization(t *testing.T) {
+	for _, keyType := range []staking.KeyType{staking.RSA, staking.ECDSA, staking.Ed25519} {
+		t.Run(keyType.String(), func(t *testing.T) {
+			assert := assert.New(t)
+
+			cert, err := staking.NewTLSCertWithKeyType(keyType)
+			assert.NoError(err)
+			nodeID := ids.NodeIDFromCert(cert.Leaf)
+
+			sk, err := bls.NewSecretKey()
+			assert.NoError(err)
+			pk := bls.PublicFromSecretKey(sk)
+			pkBytes := bls.PublicKeyToBytes(pk)
+
+			nodeAuth, err := NewNodeAuthorization(cert.Leaf, cert.PrivateKey.(crypto.Signer), 1, pk, 5)
+			assert.NoError(err)
+			assert.NoError(nodeAuth.Verify(1, nodeID, pkBytes))
+
+			// The authorization is only valid for the signed network, node
+			// and key
+			assert.Error(nodeAuth.Verify(2, nodeID, pkBytes))
+			assert.ErrorIs(nodeAuth.Verify(1, ids.GenerateTestNodeID(), pkBytes), errWrongNodeID)
+			otherSK, err := bls.NewSecretKey()
+			assert.NoError(err)
+			assert.Error(nodeAuth.Verify(1, nodeID, bls.PublicKeyToBytes(bls.PublicFromSecretKey(otherSK))))
+
+			// The timestamp is signed
+			nodeAuth.Timestamp++
+			assert.Error(nodeAuth.Verify(1, nodeID, pkBytes))
+			nodeAuth.Timestamp--
+
+			authJSON, err := json.Marshal(nodeAuth)
+			assert.NoError(err)
+			parsedAuth := &NodeAuthorization{}
+			assert.NoError(json.Unmarshal(authJSON, parsedAuth))
+			assert.Equal(nodeAuth, parsedAuth)
+			assert.NoError(parsedAuth.Verify(1, nodeID, pkBytes))
+
+			// The certificate is required
+			parsedAuth.Certificate = nil
+			assert.ErrorIs(parsedAuth.Verify(1, nodeID, pkBytes), errMissingCertificate)
+		})
+	}
+}
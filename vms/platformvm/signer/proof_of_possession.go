@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+)
+
+var errInvalidProofOfPossession = errors.New("invalid proof of possession")
+
+// ProofOfPossession binds a BLS public key to the holder of its secret key.
+// Without it, a rogue key could be registered that cancels out honest keys
+// once signatures are aggregated.
+type ProofOfPossession struct {
+	PublicKey [bls.PublicKeyLen]byte `serialize:"true"`
+	// ProofOfPossession is the signature of [PublicKey] by its secret key
+	ProofOfPossession [bls.SignatureLen]byte `serialize:"true"`
+
+	// publicKey is populated after a successful call to Verify
+	publicKey *bls.PublicKey
+}
+
+// NewProofOfPossession returns the proof that the caller holds [sk]
+func NewProofOfPossession(sk *bls.SecretKey) *ProofOfPossession {
+	pk := bls.PublicFromSecretKey(sk)
+	pkBytes := bls.PublicKeyToBytes(pk)
+	sig := bls.SignProofOfPossession(sk, pkBytes)
+	sigBytes := bls.SignatureToBytes(sig)
+
+	pop := &ProofOfPossession{publicKey: pk}
+	copy(pop.PublicKey[:], pkBytes)
+	copy(pop.ProofOfPossession[:], sigBytes)
+	return pop
+}
+
+// Verify returns nil iff the public key is valid and the proof of possession
+// was produced by its secret key
+func (p *ProofOfPossession) Verify() error {
+	publicKey, err := bls.PublicKeyFromBytes(p.PublicKey[:])
+	if err != nil {
+		return err
+	}
+	signature, err := bls.SignatureFromBytes(p.ProofOfPossession[:])
+	if err != nil {
+		return err
+	}
+	if !bls.VerifyProofOfPossession(publicKey, signature, p.PublicKey[:]) {
+		return errInvalidProofOfPossession
+	}
+
+	p.publicKey = publicKey
+	return nil
+}
+
+// Key returns the verified public key, or nil if Verify hasn't succeeded
+func (p *ProofOfPossession) Key() *bls.PublicKey {
+	return p.publicKey
+}
+
+type jsonProofOfPossession struct {
+	PublicKey         string `json:"publicKey"`
+	ProofOfPossession string `json:"proofOfPossession"`
+}
+
+func (p *ProofOfPossession) MarshalJSON() ([]byte, error) {
+	pk, err := formatting.Encode(formatting.HexNC, p.PublicKey[:])
+	if err != nil {
+		return nil, err
+	}
+	pop, err := formatting.Encode(formatting.HexNC, p.ProofOfPossession[:])
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonProofOfPossession{
+		PublicKey:         pk,
+		ProofOfPossession: pop,
+	})
+}
+
+func (p *ProofOfPossession) UnmarshalJSON(b []byte) error {
+	jsonBLS := jsonProofOfPossession{}
+	if err := json.Unmarshal(b, &jsonBLS); err != nil {
+		return err
+	}
+
+	pkBytes, err := formatting.Decode(formatting.HexNC, jsonBLS.PublicKey)
+	if err != nil {
+		return err
+	}
+	if len(pkBytes) != bls.PublicKeyLen {
+		return errInvalidProofOfPossession
+	}
+	popBytes, err := formatting.Decode(formatting.HexNC, jsonBLS.ProofOfPossession)
+	if err != nil {
+		return err
+	}
+	if len(popBytes) != bls.SignatureLen {
+		return errInvalidProofOfPossession
+	}
+
+	copy(p.PublicKey[:], pkBytes)
+	copy(p.ProofOfPossession[:], popBytes)
+	p.publicKey = nil
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+func TestProofOfPossession(t *testing.T) {
+	assert := assert.New(t)
+
+	sk, err := bls.NewSecretKey()
+	assert.NoError(err)
+
+	pop := NewProofOfPossession(sk)
+	assert.NoError(pop.Verify())
+	assert.Equal(bls.PublicKeyToBytes(bls.PublicFromSecretKey(sk)), bls.PublicKeyToBytes(pop.Key()))
+
+	// A proof for a different key must be rejected
+	otherSK, err := bls.NewSecretKey()
+	assert.NoError(err)
+	otherPoP := NewProofOfPossession(otherSK)
+
+	forged := &ProofOfPossession{
+		PublicKey:         pop.PublicKey,
+		ProofOfPossession: otherPoP.ProofOfPossession,
+	}
+	assert.ErrorIs(forged.Verify(), errInvalidProofOfPossession)
+	assert.Nil(forged.Key())
+
+	// A proof of possession isn't a signature over the key
+	sig := bls.Sign(sk, pop.PublicKey[:])
+	signed := &ProofOfPossession{PublicKey: pop.PublicKey}
+	copy(signed.ProofOfPossession[:], bls.SignatureToBytes(sig))
+	assert.ErrorIs(signed.Verify(), errInvalidProofOfPossession)
+}
+
+func TestProofOfPossessionJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	sk, err := bls.NewSecretKey()
+	assert.NoError(err)
+	pop := NewProofOfPossession(sk)
+
+	popBytes, err := json.Marshal(pop)
+	assert.NoError(err)
+
+	parsed := &ProofOfPossession{}
+	assert.NoError(json.Unmarshal(popBytes, parsed))
+	assert.Equal(pop.PublicKey, parsed.PublicKey)
+	assert.Equal(pop.ProofOfPossession, parsed.ProofOfPossession)
+	assert.NoError(parsed.Verify())
+
+	assert.Error(json.Unmarshal([]byte(`{"publicKey":"0x00","proofOfPossession":"0x00"}`), parsed))
+}
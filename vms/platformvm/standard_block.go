@@ -190,6 +190,7 @@ func (sb *StandardBlock) Accept() error {
 	if err := sb.vm.ctx.SharedMemory.Apply(sb.atomicRequests, batch); err != nil {
 		return fmt.Errorf("failed to apply vm's state to shared memory: %w", err)
 	}
+	sb.vm.publishAccepted(sb, true)
 
 	for _, child := range sb.children {
 		child.setBaseState()
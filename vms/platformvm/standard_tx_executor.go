@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/chains/atomic"
@@ -16,7 +17,14 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 )
 
-var _ txs.Visitor = &standardTxExecutor{}
+var (
+	_ txs.Visitor = &standardTxExecutor{}
+
+	errRemoveSubnetValidatorNotActive = errors.New("subnet validators can't be removed yet")
+	errNotSubnetValidator             = errors.New("node isn't a validator of the subnet")
+	errRegisterBLSKeyNotActive        = errors.New("BLS keys can't be registered yet")
+	errStaleBLSKeyAuthorization       = errors.New("BLS key wasn't authorized after the node's current key")
+)
 
 type standardTxExecutor struct {
 	// inputs
@@ -274,3 +282,170 @@ func (e *standardTxExecutor) ExportTx(tx *txs.ExportTx) error {
 	}
 	return nil
 }
+
+func (e *standardTxExecutor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	if err := e.tx.SyntacticVerify(e.vm.ctx); err != nil {
+		return err
+	}
+
+	timestamp := e.state.GetTimestamp()
+	if timestamp.Before(e.vm.SubnetValidatorRemovalTime) {
+		return errRemoveSubnetValidatorNotActive
+	}
+
+	// Make sure this transaction has at least one credential for the subnet
+	// authorization.
+	if len(e.tx.Creds) == 0 {
+		return errWrongNumberOfCredentials
+	}
+
+	// Select the credentials for each purpose
+	baseTxCredsLen := len(e.tx.Creds) - 1
+	baseTxCreds := e.tx.Creds[:baseTxCredsLen]
+	subnetCred := e.tx.Creds[baseTxCredsLen]
+
+	// Verify the flowcheck
+	if err := e.vm.utxoHandler.SemanticVerifySpend(
+		tx,
+		e.state,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		e.vm.TxFee,
+		e.vm.ctx.AVAXAssetID,
+	); err != nil {
+		return err
+	}
+
+	subnetIntf, _, err := e.state.GetTx(tx.Subnet)
+	if err == database.ErrNotFound {
+		return fmt.Errorf("%s isn't a known subnet", tx.Subnet)
+	}
+	if err != nil {
+		return err
+	}
+
+	subnet, ok := subnetIntf.Unsigned.(*txs.CreateSubnetTx)
+	if !ok {
+		return fmt.Errorf("%s isn't a subnet", tx.Subnet)
+	}
+
+	// Verify that this removal is authorized by the subnet
+	if err := e.vm.fx.VerifyPermission(tx, tx.SubnetAuth, subnetCred, subnet.Owner); err != nil {
+		return err
+	}
+
+	// The subnet validator is removed from the current staker set if it has
+	// started validating, and from the pending staker set otherwise. Subnet
+	// validators don't stake and aren't rewarded, so there is nothing to
+	// return to them.
+	if err := e.removeSubnetValidator(tx.NodeID, tx.Subnet); err != nil {
+		return err
+	}
+
+	txID := e.tx.ID()
+
+	// Consume the UTXOS
+	utxo.Consume(e.state, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.state, txID, e.vm.ctx.AVAXAssetID, tx.Outs)
+	return nil
+}
+
+func (e *standardTxExecutor) RegisterBLSKeyTx(tx *txs.RegisterBLSKeyTx) error {
+	// The proof of possession and the node's authorization of the key are
+	// verified syntactically
+	if err := e.tx.SyntacticVerify(e.vm.ctx); err != nil {
+		return err
+	}
+
+	timestamp := e.state.GetTimestamp()
+	if timestamp.Before(e.vm.BLSKeyRegistrationTime) {
+		return errRegisterBLSKeyNotActive
+	}
+
+	// Verify the flowcheck
+	if err := e.vm.utxoHandler.SemanticVerifySpend(
+		tx,
+		e.state,
+		tx.Ins,
+		tx.Outs,
+		e.tx.Creds,
+		e.vm.TxFee,
+		e.vm.ctx.AVAXAssetID,
+	); err != nil {
+		return err
+	}
+
+	// A key can only be replaced by a key that the node authorized later, so
+	// that the registration of a rotated out key can't be replayed.
+	currentKey, err := e.state.GetBLSKey(tx.NodeID)
+	switch {
+	case err == nil:
+		if tx.NodeAuth.Timestamp <= currentKey.Timestamp {
+			return fmt.Errorf(
+				"%w: %s authorized its current key at %d",
+				errStaleBLSKeyAuthorization,
+				tx.NodeID,
+				currentKey.Timestamp,
+			)
+		}
+	case err != database.ErrNotFound:
+		return err
+	}
+
+	e.state.SetBLSKey(tx.NodeID, &state.BLSKey{
+		PublicKey: tx.Signer.Key(),
+		Timestamp: tx.NodeAuth.Timestamp,
+	})
+
+	txID := e.tx.ID()
+
+	// Consume the UTXOS
+	utxo.Consume(e.state, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.state, txID, e.vm.ctx.AVAXAssetID, tx.Outs)
+	return nil
+}
+
+// removeSubnetValidator removes [nodeID] from the current or pending
+// validators of [subnetID]
+func (e *standardTxExecutor) removeSubnetValidator(nodeID ids.NodeID, subnetID ids.ID) error {
+	currentStakers := e.state.CurrentStakers()
+	currentValidator, err := currentStakers.GetValidator(nodeID)
+	if err != nil && err != database.ErrNotFound {
+		return fmt.Errorf(
+			"failed to find whether %s is a validator: %w",
+			nodeID,
+			err,
+		)
+	}
+	if err == nil {
+		if vdr, validates := currentValidator.SubnetValidators()[subnetID]; validates {
+			newlyCurrentStakers, err := currentStakers.DeleteSubnetValidator(vdr.TxID)
+			if err != nil {
+				return err
+			}
+			e.state.SetCurrentStakers(newlyCurrentStakers)
+			return nil
+		}
+	}
+
+	pendingStakers := e.state.PendingStakers()
+	pendingValidator := pendingStakers.GetValidator(nodeID)
+	vdr, validates := pendingValidator.SubnetValidators()[subnetID]
+	if !validates {
+		return fmt.Errorf(
+			"%w: %s isn't validating %s",
+			errNotSubnetValidator,
+			nodeID,
+			subnetID,
+		)
+	}
+	newlyPendingStakers, err := pendingStakers.DeleteSubnetValidator(vdr.TxID)
+	if err != nil {
+		return err
+	}
+	e.state.SetPendingStakers(newlyPendingStakers)
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var blsKeyPrefix = []byte("blsKey")
+
+// BLSKey is the BLS public key registered by a node
+type BLSKey struct {
+	PublicKey *bls.PublicKey
+	// Timestamp is the time at which the node authorized the key
+	Timestamp uint64
+}
+
+// BLSKeys tracks the BLS public key registered by each node.
+type BLSKeys interface {
+	// GetBLSKey returns the BLS key registered by [nodeID], or
+	// database.ErrNotFound if the node hasn't registered one.
+	GetBLSKey(nodeID ids.NodeID) (*BLSKey, error)
+	// SetBLSKey registers [key] as the BLS key of [nodeID], replacing the key
+	// that the node previously registered.
+	SetBLSKey(nodeID ids.NodeID, key *BLSKey)
+}
+
+func (s *state) GetBLSKey(nodeID ids.NodeID) (*BLSKey, error) {
+	if key, exists := s.addedBLSKeys[nodeID]; exists {
+		return key, nil
+	}
+	keyBytes, err := s.blsKeyDB.Get(nodeID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	p := wrappers.Packer{Bytes: keyBytes}
+	pkBytes := p.UnpackFixedBytes(bls.PublicKeyLen)
+	timestamp := p.UnpackLong()
+	if p.Errored() {
+		return nil, fmt.Errorf("failed to parse BLS key: %w", p.Err)
+	}
+	pk, err := bls.PublicKeyFromBytes(pkBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &BLSKey{
+		PublicKey: pk,
+		Timestamp: timestamp,
+	}, nil
+}
+
+func (s *state) SetBLSKey(nodeID ids.NodeID, key *BLSKey) {
+	s.addedBLSKeys[nodeID] = key
+}
+
+func (s *state) writeBLSKeys() error {
+	for nodeID, key := range s.addedBLSKeys {
+		nodeID := nodeID
+
+		delete(s.addedBLSKeys, nodeID)
+
+		p := wrappers.Packer{Bytes: make([]byte, bls.PublicKeyLen+wrappers.LongLen)}
+		p.PackFixedBytes(bls.PublicKeyToBytes(key.PublicKey))
+		p.PackLong(key.Timestamp)
+		if err := s.blsKeyDB.Put(nodeID[:], p.Bytes); err != nil {
+			return fmt.Errorf("failed to write BLS key: %w", err)
+		}
+	}
+	return nil
+}
+
+func (d *diff) GetBLSKey(nodeID ids.NodeID) (*BLSKey, error) {
+	if key, exists := d.addedBLSKeys[nodeID]; exists {
+		return key, nil
+	}
+	return d.parentState.GetBLSKey(nodeID)
+}
+
+func (d *diff) SetBLSKey(nodeID ids.NodeID, key *BLSKey) {
+	if d.addedBLSKeys == nil {
+		d.addedBLSKeys = make(map[ids.NodeID]*BLSKey)
+	}
+	d.addedBLSKeys[nodeID] = key
+}
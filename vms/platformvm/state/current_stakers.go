@@ -40,15 +40,27 @@ type CurrentStakers interface {
 		numTxsToRemove int,
 	) (CurrentStakers, error)
 	DeleteNextStaker() (CurrentStakers, error)
+	// DeleteSubnetValidator removes the subnet validator added by [txID]
+	// before its end time.
+	DeleteSubnetValidator(txID ids.ID) (CurrentStakers, error)
 
 	// Stakers returns the current stakers on the network sorted in order of the
 	// order of their future removal from the validator set.
 	Stakers() []*txs.Tx
 
+	// StakersByTxID returns the current stakers on the network sorted by their
+	// tx IDs. The returned slice must not be modified.
+	StakersByTxID() []*txs.Tx
+
 	Apply(State)
 
 	// Return the current validator set of [subnetID].
 	ValidatorSet(subnetID ids.ID) (validators.Set, error)
+
+	// ValidatorNodeIDs returns the node IDs of the current validators of
+	// [subnetID], sorted lexicographically. The returned slice must not be
+	// modified.
+	ValidatorNodeIDs(subnetID ids.ID) []ids.NodeID
 }
 
 // currentStakers is a copy on write implementation for versioning the validator
@@ -65,7 +77,17 @@ type currentStakers struct {
 	// set
 	validators []*txs.Tx
 
-	nextStaker     *ValidatorReward
+	// subnetID -> node IDs of the subnet's validators, sorted
+	nodeIDsBySubnet map[ids.ID][]ids.NodeID
+	// list of current stakers sorted by their tx IDs
+	stakersByTxID []*txs.Tx
+
+	nextStaker *ValidatorReward
+
+	// If non-nil, the staker set this one was derived from. Its changes that
+	// haven't been applied yet are applied before those of this staker set, so
+	// that multiple removals can be made in a single block.
+	base           *currentStakers
 	addedStakers   []*ValidatorReward
 	deletedStakers []*txs.Tx
 }
@@ -108,6 +130,28 @@ func (c *currentStakers) UpdateStakers(
 		deletedStakers: c.validators[:numTxsToRemove],
 	}
 
+	var (
+		nodeIDs   nodeIDsDiff
+		addedTxs  = make([]*txs.Tx, 0, len(addValidatorTxs)+len(addDelegatorTxs)+len(addSubnetValidatorTxs))
+		removedTx = ids.NewSet(numTxsToRemove)
+	)
+	for _, vdr := range addValidatorTxs {
+		addedTxs = append(addedTxs, vdr.AddStakerTx)
+	}
+	for _, vdr := range addDelegatorTxs {
+		addedTxs = append(addedTxs, vdr.AddStakerTx)
+	}
+	addedTxs = append(addedTxs, addSubnetValidatorTxs...)
+	for _, tx := range addedTxs {
+		nodeIDs.addStaker(tx)
+	}
+	for _, tx := range c.validators[:numTxsToRemove] {
+		nodeIDs.removeStaker(tx)
+		removedTx.Add(tx.ID())
+	}
+	newCS.nodeIDsBySubnet = nodeIDs.apply(c.nodeIDsBySubnet)
+	newCS.stakersByTxID = mergeTxsByID(c.stakersByTxID, addedTxs, removedTx)
+
 	for nodeID, vdr := range c.validatorsByNodeID {
 		newCS.validatorsByNodeID[nodeID] = vdr
 	}
@@ -237,10 +281,16 @@ func (c *currentStakers) DeleteNextStaker() (CurrentStakers, error) {
 		validatorsByNodeID: make(map[ids.NodeID]*currentValidator, len(c.validatorsByNodeID)),
 		validatorsByTxID:   make(map[ids.ID]*ValidatorReward, len(c.validatorsByTxID)-1),
 		validators:         c.validators[1:], // sorted in order of removal
+		stakersByTxID:      mergeTxsByID(c.stakersByTxID, nil, ids.Set{removedTxID: struct{}{}}),
 
+		base:           c,
 		deletedStakers: []*txs.Tx{removedTx},
 	}
 
+	var nodeIDs nodeIDsDiff
+	nodeIDs.removeStaker(removedTx)
+	newCS.nodeIDsBySubnet = nodeIDs.apply(c.nodeIDsBySubnet)
+
 	switch tx := removedTx.Unsigned.(type) {
 	case *txs.AddValidatorTx:
 		for nodeID, vdr := range c.validatorsByNodeID {
@@ -281,18 +331,84 @@ func (c *currentStakers) DeleteNextStaker() (CurrentStakers, error) {
 	return newCS, nil
 }
 
+func (c *currentStakers) DeleteSubnetValidator(txID ids.ID) (CurrentStakers, error) {
+	removed, exists := c.validatorsByTxID[txID]
+	if !exists {
+		return nil, database.ErrNotFound
+	}
+	tx, ok := removed.AddStakerTx.Unsigned.(*txs.AddSubnetValidatorTx)
+	if !ok {
+		return nil, fmt.Errorf("expected tx type *txs.AddSubnetValidatorTx but got %T", removed.AddStakerTx.Unsigned)
+	}
+
+	newCS := &currentStakers{
+		validatorsByNodeID: make(map[ids.NodeID]*currentValidator, len(c.validatorsByNodeID)),
+		validatorsByTxID:   make(map[ids.ID]*ValidatorReward, len(c.validatorsByTxID)-1),
+		validators:         make([]*txs.Tx, 0, len(c.validators)-1), // sorted in order of removal
+		stakersByTxID:      mergeTxsByID(c.stakersByTxID, nil, ids.Set{txID: struct{}{}}),
+
+		base:           c,
+		deletedStakers: []*txs.Tx{removed.AddStakerTx},
+	}
+
+	var nodeIDs nodeIDsDiff
+	nodeIDs.removeStaker(removed.AddStakerTx)
+	newCS.nodeIDsBySubnet = nodeIDs.apply(c.nodeIDsBySubnet)
+
+	for _, vdr := range c.validators {
+		if vdr.ID() != txID {
+			newCS.validators = append(newCS.validators, vdr)
+		}
+	}
+
+	for txID, vdr := range c.validatorsByTxID {
+		newCS.validatorsByTxID[txID] = vdr
+	}
+	delete(newCS.validatorsByTxID, txID)
+
+	for nodeID, vdr := range c.validatorsByNodeID {
+		newCS.validatorsByNodeID[nodeID] = vdr
+	}
+	oldVdr := newCS.validatorsByNodeID[tx.Validator.NodeID]
+	newVdr := *oldVdr
+	newVdr.subnets = make(map[ids.ID]SubnetValidatorAndID, len(oldVdr.subnets)-1)
+	for subnetID, addTx := range oldVdr.subnets {
+		if addTx.TxID != txID {
+			newVdr.subnets[subnetID] = addTx
+		}
+	}
+	newCS.validatorsByNodeID[tx.Validator.NodeID] = &newVdr
+
+	newCS.SetNextStaker()
+	return newCS, nil
+}
+
 func (c *currentStakers) Stakers() []*txs.Tx {
 	return c.validators
 }
 
+func (c *currentStakers) StakersByTxID() []*txs.Tx {
+	return c.stakersByTxID
+}
+
 func (c *currentStakers) Apply(baseState State) {
+	c.applyChanges(baseState)
+	baseState.SetCurrentStakers(c)
+}
+
+// applyChanges applies the changes of [c], and of the staker sets it was
+// derived from, that haven't been applied yet.
+func (c *currentStakers) applyChanges(baseState State) {
+	if c.base != nil {
+		c.base.applyChanges(baseState)
+		c.base = nil
+	}
 	for _, added := range c.addedStakers {
 		baseState.AddCurrentStaker(added.AddStakerTx, added.PotentialReward)
 	}
 	for _, deleted := range c.deletedStakers {
 		baseState.DeleteCurrentStaker(deleted)
 	}
-	baseState.SetCurrentStakers(c)
 
 	// Validator changes should only be applied once.
 	c.addedStakers = nil
@@ -312,7 +428,7 @@ func (c *currentStakers) primaryValidatorSet() (validators.Set, error) {
 	var err error
 	for nodeID, vdr := range c.validatorsByNodeID {
 		vdrWeight := vdr.addValidator.Tx.Validator.Wght
-		vdrWeight, err = math.Add64(vdrWeight, vdr.delegatorWeight)
+		vdrWeight, err = math.Add(vdrWeight, vdr.delegatorWeight)
 		if err != nil {
 			return nil, err
 		}
@@ -340,6 +456,21 @@ func (c *currentStakers) subnetValidatorSet(subnetID ids.ID) (validators.Set, er
 	return vdrs, nil
 }
 
+func (c *currentStakers) ValidatorNodeIDs(subnetID ids.ID) []ids.NodeID {
+	return c.nodeIDsBySubnet[subnetID]
+}
+
+// buildIndices populates [nodeIDsBySubnet] and [stakersByTxID] from
+// [validators].
+func (c *currentStakers) buildIndices() {
+	var nodeIDs nodeIDsDiff
+	for _, tx := range c.validators {
+		nodeIDs.addStaker(tx)
+	}
+	c.nodeIDsBySubnet = nodeIDs.apply(nil)
+	c.stakersByTxID = mergeTxsByID(nil, append([]*txs.Tx(nil), c.validators...), nil)
+}
+
 func (c *currentStakers) GetStaker(txID ids.ID) (tx *txs.Tx, reward uint64, err error) {
 	staker, exists := c.validatorsByTxID[txID]
 	if !exists {
@@ -215,3 +215,77 @@ func TestSubnetValidatorSet(t *testing.T) {
 		assert.EqualValues(t, node2Weight, gotNode2Weight)
 	}
 }
+
+func TestValidatorNodeIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	subnetID := ids.GenerateTestID()
+
+	nodeID0 := ids.NodeID{2}
+	nodeID1 := ids.NodeID{1}
+	nodeID2 := ids.NodeID{3}
+
+	newValidatorTx := func(nodeID ids.NodeID) *ValidatorReward {
+		tx := &txs.Tx{Unsigned: &txs.AddValidatorTx{
+			Validator: validator.Validator{
+				NodeID: nodeID,
+				End:    uint64(nodeID[0]),
+			},
+		}}
+		tx.Initialize(nil, []byte{nodeID[0]})
+		return &ValidatorReward{AddStakerTx: tx}
+	}
+	newSubnetValidatorTx := func(nodeID ids.NodeID) *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.AddSubnetValidatorTx{
+			Validator: validator.SubnetValidator{
+				Validator: validator.Validator{
+					NodeID: nodeID,
+				},
+				Subnet: subnetID,
+			},
+		}}
+		tx.Initialize(nil, []byte{nodeID[0], 1})
+		return tx
+	}
+
+	var cs CurrentStakers = &currentStakers{}
+	cs, err := cs.UpdateStakers(
+		[]*ValidatorReward{
+			newValidatorTx(nodeID0),
+			newValidatorTx(nodeID1),
+			newValidatorTx(nodeID2),
+		},
+		nil,
+		[]*txs.Tx{
+			newSubnetValidatorTx(nodeID0),
+			newSubnetValidatorTx(nodeID2),
+		},
+		0,
+	)
+	assert.NoError(err)
+	assert.Equal([]ids.NodeID{nodeID1, nodeID0, nodeID2}, cs.ValidatorNodeIDs(constants.PrimaryNetworkID))
+	assert.Equal([]ids.NodeID{nodeID0, nodeID2}, cs.ValidatorNodeIDs(subnetID))
+	assert.Len(cs.StakersByTxID(), 5)
+	for i := 1; i < len(cs.StakersByTxID()); i++ {
+		prevID := cs.StakersByTxID()[i-1].ID()
+		txID := cs.StakersByTxID()[i].ID()
+		assert.True(prevID.Less(txID))
+	}
+
+	// The subnet validators are removed first
+	cs, err = cs.UpdateStakers(nil, nil, nil, 1)
+	assert.NoError(err)
+	assert.Len(cs.ValidatorNodeIDs(subnetID), 1)
+	assert.Len(cs.StakersByTxID(), 4)
+
+	cs, err = cs.UpdateStakers(nil, nil, nil, 1)
+	assert.NoError(err)
+	assert.Empty(cs.ValidatorNodeIDs(subnetID))
+
+	// The validator with the earliest end time is nodeID1
+	cs, err = cs.DeleteNextStaker()
+	assert.NoError(err)
+	assert.Equal([]ids.NodeID{nodeID0, nodeID2}, cs.ValidatorNodeIDs(constants.PrimaryNetworkID))
+	assert.Len(cs.StakersByTxID(), 2)
+	assert.Empty(cs.ValidatorNodeIDs(ids.GenerateTestID()))
+}
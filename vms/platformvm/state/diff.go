@@ -45,6 +45,9 @@ type diff struct {
 
 	// map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
 	modifiedUTXOs map[ids.ID]*utxoModification
+
+	// map of nodeID -> newly registered BLS key
+	addedBLSKeys map[ids.NodeID]*BLSKey
 }
 
 type utxoModification struct {
@@ -264,6 +267,9 @@ func (d *diff) Apply(baseState State) {
 			baseState.DeleteUTXO(utxo.utxoID)
 		}
 	}
+	for nodeID, key := range d.addedBLSKeys {
+		baseState.SetBLSKey(nodeID, key)
+	}
 	d.CurrentStakers().Apply(baseState)
 	d.PendingStakers().Apply(baseState)
 }
@@ -24,11 +24,18 @@ type PendingStakers interface {
 
 	AddStaker(addStakerTx *txs.Tx) PendingStakers
 	DeleteStakers(numToRemove int) PendingStakers
+	// DeleteSubnetValidator removes the subnet validator added by [txID]
+	// before its start time.
+	DeleteSubnetValidator(txID ids.ID) (PendingStakers, error)
 
 	// Stakers returns the list of pending validators in order of their removal
 	// from the pending staker set
 	Stakers() []*txs.Tx
 
+	// StakersByTxID returns the pending stakers sorted by their tx IDs. The
+	// returned slice must not be modified.
+	StakersByTxID() []*txs.Tx
+
 	Apply(State)
 }
 
@@ -43,7 +50,13 @@ type pendingStakers struct {
 	// list of pending validators in order of their removal from the pending
 	// staker set
 	validators []*txs.Tx
+	// list of pending stakers sorted by their tx IDs
+	stakersByTxID []*txs.Tx
 
+	// If non-nil, the staker set this one was derived from. Its changes that
+	// haven't been applied yet are applied before those of this staker set, so
+	// that multiple removals can be made in a single block.
+	base           *pendingStakers
 	addedStakers   []*txs.Tx
 	deletedStakers []*txs.Tx
 }
@@ -69,8 +82,9 @@ func (p *pendingStakers) GetValidator(nodeID ids.NodeID) ValidatorModifications
 
 func (p *pendingStakers) AddStaker(addStakerTx *txs.Tx) PendingStakers {
 	newPS := &pendingStakers{
-		validators:   make([]*txs.Tx, len(p.validators)+1),
-		addedStakers: []*txs.Tx{addStakerTx},
+		validators:    make([]*txs.Tx, len(p.validators)+1),
+		stakersByTxID: mergeTxsByID(p.stakersByTxID, []*txs.Tx{addStakerTx}, nil),
+		addedStakers:  []*txs.Tx{addStakerTx},
 	}
 	copy(newPS.validators, p.validators)
 	newPS.validators[len(p.validators)] = addStakerTx
@@ -169,6 +183,12 @@ func (p *pendingStakers) DeleteStakers(numToRemove int) PendingStakers {
 		deletedStakers: p.validators[:numToRemove],
 	}
 
+	removed := ids.NewSet(numToRemove)
+	for _, tx := range p.validators[:numToRemove] {
+		removed.Add(tx.ID())
+	}
+	newPS.stakersByTxID = mergeTxsByID(p.stakersByTxID, nil, removed)
+
 	for nodeID, vdr := range p.validatorsByNodeID {
 		newPS.validatorsByNodeID[nodeID] = vdr
 	}
@@ -214,18 +234,81 @@ func (p *pendingStakers) DeleteStakers(numToRemove int) PendingStakers {
 	return newPS
 }
 
+func (p *pendingStakers) DeleteSubnetValidator(txID ids.ID) (PendingStakers, error) {
+	var (
+		removed *txs.Tx
+		newPS   = &pendingStakers{
+			validatorsByNodeID:      p.validatorsByNodeID,
+			validatorExtrasByNodeID: make(map[ids.NodeID]*validatorModifications, len(p.validatorExtrasByNodeID)),
+			validators:              make([]*txs.Tx, 0, len(p.validators)),
+		}
+	)
+	for _, vdr := range p.validators {
+		if vdr.ID() == txID {
+			removed = vdr
+			continue
+		}
+		newPS.validators = append(newPS.validators, vdr)
+	}
+	if removed == nil {
+		return nil, database.ErrNotFound
+	}
+	tx, ok := removed.Unsigned.(*txs.AddSubnetValidatorTx)
+	if !ok {
+		return nil, fmt.Errorf("expected tx type *txs.AddSubnetValidatorTx but got %T", removed.Unsigned)
+	}
+	newPS.base = p
+	newPS.deletedStakers = []*txs.Tx{removed}
+	newPS.stakersByTxID = mergeTxsByID(p.stakersByTxID, nil, ids.Set{txID: struct{}{}})
+
+	for nodeID, vdr := range p.validatorExtrasByNodeID {
+		if nodeID != tx.Validator.NodeID {
+			newPS.validatorExtrasByNodeID[nodeID] = vdr
+		}
+	}
+	vdr := p.validatorExtrasByNodeID[tx.Validator.NodeID]
+	if len(vdr.delegators) == 0 && len(vdr.subnets) == 1 {
+		return newPS, nil
+	}
+	newSubnets := make(map[ids.ID]SubnetValidatorAndID, len(vdr.subnets)-1)
+	for subnetID, subnetTx := range vdr.subnets {
+		if subnetID != tx.Validator.Subnet {
+			newSubnets[subnetID] = subnetTx
+		}
+	}
+	newPS.validatorExtrasByNodeID[tx.Validator.NodeID] = &validatorModifications{
+		delegators: vdr.delegators,
+		subnets:    newSubnets,
+	}
+	return newPS, nil
+}
+
 func (p *pendingStakers) Stakers() []*txs.Tx {
 	return p.validators
 }
 
+func (p *pendingStakers) StakersByTxID() []*txs.Tx {
+	return p.stakersByTxID
+}
+
 func (p *pendingStakers) Apply(baseState State) {
+	p.applyChanges(baseState)
+	baseState.SetPendingStakers(p)
+}
+
+// applyChanges applies the changes of [p], and of the staker sets it was
+// derived from, that haven't been applied yet.
+func (p *pendingStakers) applyChanges(baseState State) {
+	if p.base != nil {
+		p.base.applyChanges(baseState)
+		p.base = nil
+	}
 	for _, added := range p.addedStakers {
 		baseState.AddPendingStaker(added)
 	}
 	for _, deleted := range p.deletedStakers {
 		baseState.DeletePendingStaker(deleted)
 	}
-	baseState.SetPendingStakers(p)
 
 	// Validator changes should only be applied once.
 	p.addedStakers = nil
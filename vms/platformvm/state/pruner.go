@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// pruneIndexKeyLen is the length of a key in the prune index:
+// height + kind + ID
+const pruneIndexKeyLen = wrappers.LongLen + wrappers.ByteLen + hashing.HashLen
+
+// Kinds of state in the prune index
+const (
+	validatorDiffsKind byte = iota
+	rewardUTXOsKind
+)
+
+var (
+	pruneIndexPrefix = []byte("pruneIndex")
+
+	prunedHeightKey = []byte("pruned height")
+)
+
+// Pruner removes historical state that isn't needed to verify new blocks.
+//
+// Every time validator weight diffs or reward UTXOs are written, they are
+// indexed by the height they were written at. Pruning deletes indexed state in
+// order of increasing height.
+type Pruner interface {
+	// Prune deletes up to [limit] sets of validator weight diffs and reward
+	// UTXOs that were written at or below [height].
+	Prune(height uint64, limit int) error
+
+	// GetPrunedHeight returns the greatest height at which validator weight
+	// diffs may have been pruned. Validator sets can only be recalculated at
+	// or above this height.
+	GetPrunedHeight() uint64
+}
+
+func (s *state) Prune(height uint64, limit int) error {
+	iter := s.pruneIndexDB.NewIterator()
+	defer iter.Release()
+
+	keys := [][]byte(nil)
+	for len(keys) < limit && iter.Next() {
+		key := iter.Key()
+		if len(key) != pruneIndexKeyLen {
+			return fmt.Errorf("expected prune index key of length %d but got %d", pruneIndexKeyLen, len(key))
+		}
+		if binary.BigEndian.Uint64(key) > height {
+			break
+		}
+		keys = append(keys, utils.CopyBytes(key))
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		entryHeight := binary.BigEndian.Uint64(key)
+		id, err := ids.ToID(key[wrappers.LongLen+wrappers.ByteLen:])
+		if err != nil {
+			return err
+		}
+
+		switch kind := key[wrappers.LongLen]; kind {
+		case validatorDiffsKind:
+			err = s.pruneValidatorWeightDiffs(entryHeight, id)
+		case rewardUTXOsKind:
+			err = s.pruneRewardUTXOs(id)
+		default:
+			err = fmt.Errorf("unknown prune index kind %d", kind)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := s.pruneIndexDB.Delete(key); err != nil {
+			return err
+		}
+		if entryHeight > s.prunedHeight {
+			s.prunedHeight = entryHeight
+		}
+	}
+	return database.PutUInt64(s.singletonDB, prunedHeightKey, s.prunedHeight)
+}
+
+func (s *state) GetPrunedHeight() uint64 { return s.prunedHeight }
+
+func (s *state) loadPrunedHeight() error {
+	prunedHeight, err := database.GetUInt64(s.singletonDB, prunedHeightKey)
+	if err == database.ErrNotFound {
+		return nil
+	}
+	s.prunedHeight = prunedHeight
+	return err
+}
+
+// pruneValidatorWeightDiffs deletes the weight diffs of the validators of
+// [subnetID] at [height]
+func (s *state) pruneValidatorWeightDiffs(height uint64, subnetID ids.ID) error {
+	prefixBytes, err := genesis.Codec.Marshal(txs.Version, heightWithSubnet{
+		Height:   height,
+		SubnetID: subnetID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create prefix bytes: %w", err)
+	}
+	s.validatorDiffsCache.Evict(string(prefixBytes))
+	return clearDB(prefixdb.New(prefixBytes, s.validatorDiffsDB))
+}
+
+// pruneRewardUTXOs deletes the reward UTXOs produced by the staker added by
+// [txID]
+func (s *state) pruneRewardUTXOs(txID ids.ID) error {
+	s.rewardUTXOsCache.Evict(txID)
+	return clearDB(prefixdb.New(txID[:], s.rewardUTXODB))
+}
+
+// addToPruneIndex records that state of [kind], identified by [id], was written
+// at [height]
+func (s *state) addToPruneIndex(height uint64, kind byte, id ids.ID) error {
+	key := make([]byte, pruneIndexKeyLen)
+	binary.BigEndian.PutUint64(key, height)
+	key[wrappers.LongLen] = kind
+	copy(key[wrappers.LongLen+wrappers.ByteLen:], id[:])
+	return s.pruneIndexDB.Put(key, nil)
+}
+
+// clearDB deletes every key in [db]
+func clearDB(db database.Database) error {
+	iter := db.NewIterator()
+	defer iter.Release()
+
+	keys := [][]byte(nil)
+	for iter.Next() {
+		keys = append(keys, utils.CopyBytes(iter.Key()))
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	rewardOwnerPrefix = []byte("rewardOwner")
+
+	rewardOwnersIndexedKey = []byte("reward owners indexed")
+)
+
+// RewardOwnerIndex indexes the primary network validators and delegators by
+// the addresses that their rewards are paid to.
+type RewardOwnerIndex interface {
+	// CurrentStakerTxIDs returns the IDs of at most [limit] transactions that
+	// added a current staker rewarding [addr]. The IDs are returned in order,
+	// starting after [start].
+	CurrentStakerTxIDs(addr ids.ShortID, start ids.ID, limit int) ([]ids.ID, error)
+
+	// PendingStakerTxIDs returns the IDs of at most [limit] transactions that
+	// added a pending staker rewarding [addr]. The IDs are returned in order,
+	// starting after [start].
+	PendingStakerTxIDs(addr ids.ShortID, start ids.ID, limit int) ([]ids.ID, error)
+}
+
+func (s *state) CurrentStakerTxIDs(addr ids.ShortID, start ids.ID, limit int) ([]ids.ID, error) {
+	return stakerTxIDs(s.currentRewardOwnerDB, addr, start, limit)
+}
+
+func (s *state) PendingStakerTxIDs(addr ids.ShortID, start ids.ID, limit int) ([]ids.ID, error) {
+	return stakerTxIDs(s.pendingRewardOwnerDB, addr, start, limit)
+}
+
+func stakerTxIDs(db database.Database, addr ids.ShortID, start ids.ID, limit int) ([]ids.ID, error) {
+	addrDB := prefixdb.NewNested(addr[:], db)
+	defer addrDB.Close()
+
+	iter := addrDB.NewIteratorWithStart(start[:])
+	defer iter.Release()
+
+	txIDs := []ids.ID(nil)
+	for len(txIDs) < limit && iter.Next() {
+		txID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if txID == start {
+			continue
+		}
+
+		start = ids.Empty
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, iter.Error()
+}
+
+// rewardOwnerAddrs returns the addresses that the rewards of the staker added
+// by [tx] are paid to. Subnet validators aren't rewarded, so they aren't
+// indexed.
+func rewardOwnerAddrs(tx *txs.Tx) [][]byte {
+	var owner fx.Owner
+	switch tx := tx.Unsigned.(type) {
+	case *txs.AddValidatorTx:
+		owner = tx.RewardsOwner
+	case *txs.AddDelegatorTx:
+		owner = tx.RewardsOwner
+	default:
+		return nil
+	}
+
+	addressable, ok := owner.(avax.Addressable)
+	if !ok {
+		return nil
+	}
+	return addressable.Addresses()
+}
+
+// putRewardOwners indexes the staker added by [tx] in [db]
+func putRewardOwners(db database.Database, tx *txs.Tx) error {
+	txID := tx.ID()
+	for _, addr := range rewardOwnerAddrs(tx) {
+		addrDB := prefixdb.NewNested(addr, db)
+		err := addrDB.Put(txID[:], nil)
+		_ = addrDB.Close()
+		if err != nil {
+			return fmt.Errorf("failed to index reward owner: %w", err)
+		}
+	}
+	return nil
+}
+
+// deleteRewardOwners removes the staker added by [tx] from [db]
+func deleteRewardOwners(db database.Database, tx *txs.Tx) error {
+	txID := tx.ID()
+	for _, addr := range rewardOwnerAddrs(tx) {
+		addrDB := prefixdb.NewNested(addr, db)
+		err := addrDB.Delete(txID[:])
+		_ = addrDB.Close()
+		if err != nil {
+			return fmt.Errorf("failed to delete reward owner index: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadRewardOwners indexes the loaded stakers if they were written before the
+// reward owner index existed
+func (s *state) loadRewardOwners() error {
+	indexed, err := s.singletonDB.Has(rewardOwnersIndexedKey)
+	if err != nil || indexed {
+		return err
+	}
+
+	s.ctx.Log.Info("indexing stakers by reward owner")
+	for _, tx := range s.CurrentStakers().Stakers() {
+		if err := putRewardOwners(s.currentRewardOwnerDB, tx); err != nil {
+			return err
+		}
+	}
+	for _, tx := range s.PendingStakers().Stakers() {
+		if err := putRewardOwners(s.pendingRewardOwnerDB, tx); err != nil {
+			return err
+		}
+	}
+	return s.singletonDB.Put(rewardOwnersIndexedKey, nil)
+}
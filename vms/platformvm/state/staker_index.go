@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// nodeIDsDiff is a set of changes to the sorted node IDs of the validators of
+// each subnet.
+type nodeIDsDiff struct {
+	added   map[ids.ID][]ids.NodeID
+	removed map[ids.ID]ids.NodeIDSet
+}
+
+func (d *nodeIDsDiff) add(subnetID ids.ID, nodeID ids.NodeID) {
+	if d.added == nil {
+		d.added = make(map[ids.ID][]ids.NodeID)
+	}
+	d.added[subnetID] = append(d.added[subnetID], nodeID)
+}
+
+func (d *nodeIDsDiff) remove(subnetID ids.ID, nodeID ids.NodeID) {
+	if d.removed == nil {
+		d.removed = make(map[ids.ID]ids.NodeIDSet)
+	}
+	removed := d.removed[subnetID]
+	removed.Add(nodeID)
+	d.removed[subnetID] = removed
+}
+
+// addStaker records that [tx] made its node a validator of a subnet. Adding a
+// delegator doesn't change the validator set.
+func (d *nodeIDsDiff) addStaker(tx *txs.Tx) {
+	switch tx := tx.Unsigned.(type) {
+	case *txs.AddValidatorTx:
+		d.add(constants.PrimaryNetworkID, tx.Validator.NodeID)
+	case *txs.AddSubnetValidatorTx:
+		d.add(tx.Validator.Subnet, tx.Validator.NodeID)
+	}
+}
+
+// removeStaker records that the validation added by [tx] ended.
+func (d *nodeIDsDiff) removeStaker(tx *txs.Tx) {
+	switch tx := tx.Unsigned.(type) {
+	case *txs.AddValidatorTx:
+		d.remove(constants.PrimaryNetworkID, tx.Validator.NodeID)
+	case *txs.AddSubnetValidatorTx:
+		d.remove(tx.Validator.Subnet, tx.Validator.NodeID)
+	}
+}
+
+// apply returns [nodeIDsBySubnet] with the changes of [d]. [nodeIDsBySubnet]
+// isn't modified, and the subnets that didn't change share their slices with
+// it.
+func (d *nodeIDsDiff) apply(nodeIDsBySubnet map[ids.ID][]ids.NodeID) map[ids.ID][]ids.NodeID {
+	if len(d.added) == 0 && len(d.removed) == 0 {
+		return nodeIDsBySubnet
+	}
+
+	newNodeIDsBySubnet := make(map[ids.ID][]ids.NodeID, len(nodeIDsBySubnet)+len(d.added))
+	for subnetID, nodeIDs := range nodeIDsBySubnet {
+		newNodeIDsBySubnet[subnetID] = nodeIDs
+	}
+	changed := ids.Set{}
+	for subnetID := range d.added {
+		changed.Add(subnetID)
+	}
+	for subnetID := range d.removed {
+		changed.Add(subnetID)
+	}
+	for subnetID := range changed {
+		nodeIDs := mergeNodeIDs(nodeIDsBySubnet[subnetID], d.added[subnetID], d.removed[subnetID])
+		if len(nodeIDs) == 0 {
+			delete(newNodeIDsBySubnet, subnetID)
+			continue
+		}
+		newNodeIDsBySubnet[subnetID] = nodeIDs
+	}
+	return newNodeIDsBySubnet
+}
+
+// mergeNodeIDs returns the sorted node IDs of [sorted] without [removed] and
+// with [added]. [sorted] isn't modified, but [added] is sorted.
+func mergeNodeIDs(sorted, added []ids.NodeID, removed ids.NodeIDSet) []ids.NodeID {
+	ids.SortNodeIDs(added)
+	merged := make([]ids.NodeID, 0, len(sorted)+len(added))
+	i := 0
+	for _, nodeID := range sorted {
+		if removed.Contains(nodeID) {
+			continue
+		}
+		for i < len(added) && bytes.Compare(added[i][:], nodeID[:]) < 0 {
+			merged = append(merged, added[i])
+			i++
+		}
+		merged = append(merged, nodeID)
+	}
+	return append(merged, added[i:]...)
+}
+
+// mergeTxsByID returns the txs of [sorted], which is sorted by tx ID, without
+// the txs in [removed] and with [added]. The result is sorted by tx ID.
+// [sorted] isn't modified, but [added] is sorted.
+func mergeTxsByID(sorted, added []*txs.Tx, removed ids.Set) []*txs.Tx {
+	if len(added) == 0 && removed.Len() == 0 {
+		return sorted
+	}
+
+	sortTxsByID(added)
+	merged := make([]*txs.Tx, 0, len(sorted)+len(added))
+	i := 0
+	for _, tx := range sorted {
+		txID := tx.ID()
+		if removed.Contains(txID) {
+			continue
+		}
+		for i < len(added) {
+			addedID := added[i].ID()
+			if bytes.Compare(addedID[:], txID[:]) >= 0 {
+				break
+			}
+			merged = append(merged, added[i])
+			i++
+		}
+		merged = append(merged, tx)
+	}
+	return append(merged, added[i:]...)
+}
+
+func sortTxsByID(s []*txs.Tx) {
+	sort.Slice(s, func(i, j int) bool {
+		iID := s[i].ID()
+		jID := s[j].ID()
+		return bytes.Compare(iID[:], jID[:]) < 0
+	})
+}
@@ -149,7 +149,7 @@ func (s *stakers) maxPrimarySubnetStakeAmount(
 		}
 
 		currentWeight := vdrTx.Weight()
-		currentWeight, err = math.Add64(currentWeight, currentValidator.DelegatorWeight())
+		currentWeight, err = math.Add(currentWeight, currentValidator.DelegatorWeight())
 		if err != nil {
 			return 0, err
 		}
@@ -204,7 +204,7 @@ func CanDelegate(
 	if err != nil {
 		return false, err
 	}
-	newMaxStake, err := math.Add64(maxStake, new.Validator.Wght)
+	newMaxStake, err := math.Add(maxStake, new.Validator.Wght)
 	if err != nil {
 		return false, err
 	}
@@ -230,9 +230,9 @@ func getMaxStakeAmount(
 ) (uint64, error) {
 	// Keep track of which delegators should be removed next so that we can
 	// efficiently remove delegators and keep the current stake updated.
-	toRemoveHeap := validator.EndTimeHeap{}
+	toRemoveHeap := validator.NewEndTimeHeap()
 	for _, currentDelegator := range current {
-		toRemoveHeap.Add(&currentDelegator.Tx.Validator)
+		toRemoveHeap.Push(&currentDelegator.Tx.Validator)
 	}
 
 	var (
@@ -276,7 +276,7 @@ func getMaxStakeAmount(
 				maxStake = currentStake
 			}
 
-			currentStake, err = math.Sub64(currentStake, toRemove.Wght)
+			currentStake, err = math.Sub(currentStake, toRemove.Wght)
 			if err != nil {
 				return 0, err
 			}
@@ -284,13 +284,13 @@ func getMaxStakeAmount(
 			// Changed in AP3:
 			// Remove the delegator from the heap and update the heap so that
 			// the top of the heap is the next delegator to remove.
-			toRemoveHeap.Remove()
+			toRemoveHeap.Pop()
 		}
 
 		// Add to [currentStake] the stake of this pending delegator to
 		// calculate what the stake will be when this pending delegation has
 		// started.
-		currentStake, err = math.Add64(currentStake, nextPending.Tx.Validator.Wght)
+		currentStake, err = math.Add(currentStake, nextPending.Tx.Validator.Wght)
 		if err != nil {
 			return 0, err
 		}
@@ -308,7 +308,7 @@ func getMaxStakeAmount(
 
 		// This pending delegator is a current delegator relative
 		// when considering later pending delegators that start late
-		toRemoveHeap.Add(&nextPending.Tx.Validator)
+		toRemoveHeap.Push(&nextPending.Tx.Validator)
 	}
 
 	// [currentStake] is now the amount staked before the next pending delegator
@@ -324,7 +324,7 @@ func getMaxStakeAmount(
 			break
 		}
 
-		currentStake, err = math.Sub64(currentStake, toRemove.Wght)
+		currentStake, err = math.Sub(currentStake, toRemove.Wght)
 		if err != nil {
 			return 0, err
 		}
@@ -332,7 +332,7 @@ func getMaxStakeAmount(
 		// Changed in AP3:
 		// Remove the delegator from the heap and update the heap so that the
 		// top of the heap is the next delegator to remove.
-		toRemoveHeap.Remove()
+		toRemoveHeap.Pop()
 	}
 
 	// We have advanced time to be inside the delegation window.
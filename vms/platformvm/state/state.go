@@ -87,12 +87,16 @@ type Chain interface {
 	AddChain(createChainTx *txs.Tx)
 	GetTx(txID ids.ID) (*txs.Tx, status.Status, error)
 	AddTx(tx *txs.Tx, status status.Status)
+	BLSKeys
 }
 
 type State interface {
 	Chain
 	uptime.State
 	avax.UTXOReader
+	avax.UTXOIterator
+	RewardOwnerIndex
+	Pruner
 
 	// TODO: remove ShouldInit and DoneInit and perform them in New
 	ShouldInit() (bool, error)
@@ -171,6 +175,9 @@ type state struct {
 	pendingDelegatorList         linkeddb.LinkedDB
 	pendingSubnetValidatorBaseDB database.Database
 	pendingSubnetValidatorList   linkeddb.LinkedDB
+	rewardOwnerDB                database.Database
+	currentRewardOwnerDB         database.Database
+	pendingRewardOwnerDB         database.Database
 
 	validatorDiffsCache cache.Cacher // cache of heightWithSubnet -> map[ids.ShortID]*ValidatorWeightDiff
 	validatorDiffsDB    database.Database
@@ -201,6 +208,12 @@ type state struct {
 	originalCurrentSupply, currentSupply uint64
 	originalLastAccepted, lastAccepted   ids.ID
 	singletonDB                          database.Database
+
+	prunedHeight uint64
+	pruneIndexDB database.Database
+
+	addedBLSKeys map[ids.NodeID]*BLSKey // map of nodeID -> newly registered BLS key
+	blsKeyDB     database.Database
 }
 
 type ValidatorWeightDiff struct {
@@ -241,6 +254,10 @@ func New(
 
 	validatorDiffsDB := prefixdb.New(validatorDiffsPrefix, validatorsDB)
 
+	rewardOwnerDB := prefixdb.New(rewardOwnerPrefix, validatorsDB)
+	currentRewardOwnerDB := prefixdb.New(currentPrefix, rewardOwnerDB)
+	pendingRewardOwnerDB := prefixdb.New(pendingPrefix, rewardOwnerDB)
+
 	validatorDiffsCache, err := metercacher.New(
 		"validator_diffs_cache",
 		metrics,
@@ -319,6 +336,9 @@ func New(
 		pendingDelegatorList:         linkeddb.NewDefault(pendingDelegatorBaseDB),
 		pendingSubnetValidatorBaseDB: pendingSubnetValidatorBaseDB,
 		pendingSubnetValidatorList:   linkeddb.NewDefault(pendingSubnetValidatorBaseDB),
+		rewardOwnerDB:                rewardOwnerDB,
+		currentRewardOwnerDB:         currentRewardOwnerDB,
+		pendingRewardOwnerDB:         pendingRewardOwnerDB,
 		validatorDiffsDB:             validatorDiffsDB,
 		validatorDiffsCache:          validatorDiffsCache,
 
@@ -343,6 +363,11 @@ func New(
 		chainDBCache: chainDBCache,
 
 		singletonDB: prefixdb.New(singletonPrefix, baseDB),
+
+		pruneIndexDB: prefixdb.New(pruneIndexPrefix, baseDB),
+
+		addedBLSKeys: make(map[ids.NodeID]*BLSKey),
+		blsKeyDB:     prefixdb.New(blsKeyPrefix, baseDB),
 	}, err
 }
 
@@ -536,6 +561,11 @@ func (s *state) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, error)
 	return s.utxoState.UTXOIDs(addr, start, limit)
 }
 
+// AllUTXOIDs only returns the IDs of UTXOs that have been written
+func (s *state) AllUTXOIDs(start ids.ID, limit int) ([]ids.ID, error) {
+	return s.utxoState.AllUTXOIDs(start, limit)
+}
+
 func (s *state) AddUTXO(utxo *avax.UTXO) {
 	s.modifiedUTXOs[utxo.InputID()] = utxo
 }
@@ -683,7 +713,7 @@ func (s *state) SyncGenesis(genesisBlkID ids.ID, genesis *genesis.State) error {
 			stakeAmount,
 			currentSupply,
 		)
-		newCurrentSupply, err := math.Add64(currentSupply, r)
+		newCurrentSupply, err := math.Add(currentSupply, r)
 		if err != nil {
 			return err
 		}
@@ -708,6 +738,12 @@ func (s *state) SyncGenesis(genesisBlkID ids.ID, genesis *genesis.State) error {
 		s.AddChain(chain)
 		s.AddTx(chain, status.Committed)
 	}
+
+	// The genesis validators are indexed by their reward owners when they are
+	// written
+	if err := s.singletonDB.Put(rewardOwnersIndexedKey, nil); err != nil {
+		return err
+	}
 	return s.Write(0)
 }
 
@@ -715,10 +751,14 @@ func (s *state) Load() error {
 	errs := wrappers.Errs{}
 	errs.Add(
 		s.loadMetadata(),
+		s.loadPrunedHeight(),
 		s.loadCurrentValidators(),
 		s.loadPendingValidators(),
 	)
-	return errs.Err
+	if errs.Errored() {
+		return errs.Err
+	}
+	return s.loadRewardOwners()
 }
 
 func (s *state) loadMetadata() error {
@@ -884,6 +924,7 @@ func (s *state) loadCurrentValidators() error {
 		sortDelegatorsByRemoval(vdr.delegators)
 	}
 	sortValidatorsByRemoval(cs.validators)
+	cs.buildIndices()
 	cs.SetNextStaker()
 
 	s.SetCurrentStakers(cs)
@@ -1007,6 +1048,7 @@ func (s *state) loadPendingValidators() error {
 		sortDelegatorsByAddition(vdr.delegators)
 	}
 	sortValidatorsByAddition(ps.validators)
+	ps.stakersByTxID = mergeTxsByID(nil, append([]*txs.Tx(nil), ps.validators...), nil)
 
 	s.SetPendingStakers(ps)
 	return nil
@@ -1019,10 +1061,11 @@ func (s *state) Write(height uint64) error {
 		s.writePendingStakers(),
 		s.writeUptimes(),
 		s.writeTXs(),
-		s.writeRewardUTXOs(),
+		s.writeRewardUTXOs(height),
 		s.writeUTXOs(),
 		s.writeSubnets(),
 		s.writeChains(),
+		s.writeBLSKeys(),
 		s.writeMetadata(),
 	)
 	return errs.Err
@@ -1035,6 +1078,9 @@ func (s *state) Close() error {
 		s.pendingDelegatorBaseDB.Close(),
 		s.pendingValidatorBaseDB.Close(),
 		s.pendingValidatorsDB.Close(),
+		s.pendingRewardOwnerDB.Close(),
+		s.currentRewardOwnerDB.Close(),
+		s.rewardOwnerDB.Close(),
 		s.currentSubnetValidatorBaseDB.Close(),
 		s.currentDelegatorBaseDB.Close(),
 		s.currentValidatorBaseDB.Close(),
@@ -1046,6 +1092,8 @@ func (s *state) Close() error {
 		s.subnetBaseDB.Close(),
 		s.chainDB.Close(),
 		s.singletonDB.Close(),
+		s.pruneIndexDB.Close(),
+		s.blsKeyDB.Close(),
 	)
 	return errs.Err
 }
@@ -1115,6 +1163,10 @@ func (s *state) writeCurrentStakers(height uint64) error {
 			return fmt.Errorf("expected tx type *txs.AddValidatorTx, *txs.AddDelegatorTx or *txs.AddSubnetValidatorTx but got %T", tx)
 		}
 
+		if err := putRewardOwners(s.currentRewardOwnerDB, currentStaker.AddStakerTx); err != nil {
+			return err
+		}
+
 		subnetDiffs, ok := weightDiffs[subnetID]
 		if !ok {
 			subnetDiffs = make(map[ids.NodeID]*ValidatorWeightDiff)
@@ -1127,7 +1179,7 @@ func (s *state) writeCurrentStakers(height uint64) error {
 			subnetDiffs[nodeID] = nodeDiff
 		}
 
-		newWeight, err := math.Add64(nodeDiff.Amount, weight)
+		newWeight, err := math.Add(nodeDiff.Amount, weight)
 		if err != nil {
 			return fmt.Errorf("failed to increase node weight diff: %w", err)
 		}
@@ -1172,6 +1224,9 @@ func (s *state) writeCurrentStakers(height uint64) error {
 		if err := db.Delete(txID[:]); err != nil {
 			return fmt.Errorf("failed to delete current staker: %w", err)
 		}
+		if err := deleteRewardOwners(s.currentRewardOwnerDB, tx); err != nil {
+			return err
+		}
 
 		subnetDiffs, ok := weightDiffs[subnetID]
 		if !ok {
@@ -1186,7 +1241,7 @@ func (s *state) writeCurrentStakers(height uint64) error {
 		}
 
 		if nodeDiff.Decrease {
-			newWeight, err := math.Add64(nodeDiff.Amount, weight)
+			newWeight, err := math.Add(nodeDiff.Amount, weight)
 			if err != nil {
 				return fmt.Errorf("failed to decrease node weight diff: %w", err)
 			}
@@ -1240,6 +1295,13 @@ func (s *state) writeCurrentStakers(height uint64) error {
 			}
 		}
 		s.validatorDiffsCache.Put(string(prefixBytes), nodeUpdates)
+
+		if len(nodeUpdates) == 0 {
+			continue
+		}
+		if err := s.addToPruneIndex(height, validatorDiffsKind, subnetID); err != nil {
+			return fmt.Errorf("failed to index validator weight diffs: %w", err)
+		}
 	}
 
 	// Attempt to update the stake metrics
@@ -1271,6 +1333,9 @@ func (s *state) writePendingStakers() error {
 		if err := db.Put(txID[:], nil); err != nil {
 			return fmt.Errorf("failed to add pending staker: %w", err)
 		}
+		if err := putRewardOwners(s.pendingRewardOwnerDB, tx); err != nil {
+			return err
+		}
 	}
 	s.addedPendingStakers = nil
 
@@ -1291,6 +1356,9 @@ func (s *state) writePendingStakers() error {
 		if err := db.Delete(txID[:]); err != nil {
 			return fmt.Errorf("failed to delete pending staker: %w", err)
 		}
+		if err := deleteRewardOwners(s.pendingRewardOwnerDB, tx); err != nil {
+			return err
+		}
 	}
 	s.deletedPendingStakers = nil
 	return nil
@@ -1338,10 +1406,13 @@ func (s *state) writeTXs() error {
 	return nil
 }
 
-func (s *state) writeRewardUTXOs() error {
+func (s *state) writeRewardUTXOs(height uint64) error {
 	for txID, utxos := range s.addedRewardUTXOs {
 		delete(s.addedRewardUTXOs, txID)
 		s.rewardUTXOsCache.Put(txID, utxos)
+		if err := s.addToPruneIndex(height, rewardUTXOsKind, txID); err != nil {
+			return fmt.Errorf("failed to index reward UTXOs: %w", err)
+		}
 		rawTxDB := prefixdb.New(txID[:], s.rewardUTXODB)
 		txDB := linkeddb.NewDefault(rawTxDB)
 
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var _ txs.Visitor = &txFeeCalculator{}
+
+// txFeeCalculator calculates the fee that must be burned by a tx issued at
+// [timestamp]
+type txFeeCalculator struct {
+	// inputs
+	vm        *VM
+	timestamp time.Time
+
+	// outputs
+	fee uint64
+}
+
+// AdvanceTimeTx and RewardValidatorTx are only issued by the VM and don't pay
+// a fee
+func (*txFeeCalculator) AdvanceTimeTx(*txs.AdvanceTimeTx) error         { return errWrongTxType }
+func (*txFeeCalculator) RewardValidatorTx(*txs.RewardValidatorTx) error { return errWrongTxType }
+
+func (f *txFeeCalculator) AddValidatorTx(*txs.AddValidatorTx) error {
+	f.fee = f.vm.AddStakerTxFee
+	return nil
+}
+
+func (f *txFeeCalculator) AddSubnetValidatorTx(*txs.AddSubnetValidatorTx) error {
+	f.fee = f.vm.TxFee
+	return nil
+}
+
+func (f *txFeeCalculator) AddDelegatorTx(*txs.AddDelegatorTx) error {
+	f.fee = f.vm.AddStakerTxFee
+	return nil
+}
+
+func (f *txFeeCalculator) CreateChainTx(*txs.CreateChainTx) error {
+	f.fee = f.vm.Config.GetCreateBlockchainTxFee(f.timestamp)
+	return nil
+}
+
+func (f *txFeeCalculator) CreateSubnetTx(*txs.CreateSubnetTx) error {
+	f.fee = f.vm.Config.GetCreateSubnetTxFee(f.timestamp)
+	return nil
+}
+
+func (f *txFeeCalculator) ImportTx(*txs.ImportTx) error {
+	f.fee = f.vm.TxFee
+	return nil
+}
+
+func (f *txFeeCalculator) ExportTx(*txs.ExportTx) error {
+	f.fee = f.vm.TxFee
+	return nil
+}
+
+func (f *txFeeCalculator) RemoveSubnetValidatorTx(*txs.RemoveSubnetValidatorTx) error {
+	f.fee = f.vm.TxFee
+	return nil
+}
+
+func (f *txFeeCalculator) RegisterBLSKeyTx(*txs.RegisterBLSKeyTx) error {
+	f.fee = f.vm.TxFee
+	return nil
+}
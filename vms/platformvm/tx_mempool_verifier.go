@@ -49,6 +49,14 @@ func (v *mempoolTxVerifier) ExportTx(tx *txs.ExportTx) error {
 	return v.standardTx(tx)
 }
 
+func (v *mempoolTxVerifier) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *mempoolTxVerifier) RegisterBLSKeyTx(tx *txs.RegisterBLSKeyTx) error {
+	return v.standardTx(tx)
+}
+
 func (v *mempoolTxVerifier) proposalTx(tx txs.StakerTx) error {
 	startTime := tx.StartTime()
 	maxLocalStartTime := v.vm.clock.Time().Add(maxFutureStartTime)
@@ -67,6 +67,9 @@ func RegisterUnsignedTxsTypes(targetCodec codec.Registry) error {
 
 		targetCodec.RegisterType(&stakeable.LockIn{}),
 		targetCodec.RegisterType(&stakeable.LockOut{}),
+
+		targetCodec.RegisterType(&RemoveSubnetValidatorTx{}),
+		targetCodec.RegisterType(&RegisterBLSKeyTx{}),
 	)
 	return errs.Err
 }
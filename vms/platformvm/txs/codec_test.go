@@ -0,0 +1,15 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/codec/codecfuzz"
+)
+
+func FuzzCodec(f *testing.F) {
+	codecfuzz.Fuzz(f, Codec, reflect.TypeOf(&Tx{}))
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	_ UnsignedTx             = &RegisterBLSKeyTx{}
+	_ secp256k1fx.UnsignedTx = &RegisterBLSKeyTx{}
+
+	ErrMissingProofOfPossession = errors.New("missing proof of possession")
+	ErrMissingNodeAuthorization = errors.New("missing node authorization")
+)
+
+// RegisterBLSKeyTx registers the BLS public key that a node will sign with. A
+// node can rotate its key by registering a key that it authorized later than
+// its current key.
+type RegisterBLSKeyTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// The node the key is registered for
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// The BLS key and the proof that the issuer holds its secret key
+	Signer *signer.ProofOfPossession `serialize:"true" json:"signer"`
+	// Proves that the node authorized the registration of the key
+	NodeAuth *signer.NodeAuthorization `serialize:"true" json:"nodeAuthorization"`
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *RegisterBLSKeyTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Signer == nil:
+		return ErrMissingProofOfPossession
+	case tx.NodeAuth == nil:
+		return ErrMissingNodeAuthorization
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.Signer.Verify(); err != nil {
+		return err
+	}
+	if err := tx.NodeAuth.Verify(ctx.NetworkID, tx.NodeID, tx.Signer.PublicKey[:]); err != nil {
+		return err
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *RegisterBLSKeyTx) Visit(visitor Visitor) error {
+	return visitor.RegisterBLSKeyTx(tx)
+}
@@ -14,4 +14,6 @@ type Visitor interface {
 	ExportTx(*ExportTx) error
 	AdvanceTimeTx(*AdvanceTimeTx) error
 	RewardValidatorTx(*RewardValidatorTx) error
+	RemoveSubnetValidatorTx(*RemoveSubnetValidatorTx) error
+	RegisterBLSKeyTx(*RegisterBLSKeyTx) error
 }
@@ -547,7 +547,7 @@ func (h *handler) SemanticVerifySpendUTXOs(
 		amount := in.Amount()
 
 		if now >= locktime {
-			newUnlockedConsumed, err := math.Add64(unlockedConsumed, amount)
+			newUnlockedConsumed, err := math.Add(unlockedConsumed, amount)
 			if err != nil {
 				return err
 			}
@@ -570,7 +570,7 @@ func (h *handler) SemanticVerifySpendUTXOs(
 			owners = make(map[ids.ID]uint64)
 			lockedConsumed[locktime] = owners
 		}
-		newAmount, err := math.Add64(owners[ownerID], amount)
+		newAmount, err := math.Add(owners[ownerID], amount)
 		if err != nil {
 			return err
 		}
@@ -593,7 +593,7 @@ func (h *handler) SemanticVerifySpendUTXOs(
 		amount := output.Amount()
 
 		if locktime == 0 {
-			newUnlockedProduced, err := math.Add64(unlockedProduced, amount)
+			newUnlockedProduced, err := math.Add(unlockedProduced, amount)
 			if err != nil {
 				return err
 			}
@@ -616,7 +616,7 @@ func (h *handler) SemanticVerifySpendUTXOs(
 			owners = make(map[ids.ID]uint64)
 			lockedProduced[locktime] = owners
 		}
-		newAmount, err := math.Add64(owners[ownerID], amount)
+		newAmount, err := math.Add(owners[ownerID], amount)
 		if err != nil {
 			return err
 		}
@@ -3,22 +3,12 @@
 
 package validator
 
-import "container/heap"
-
-// EndTimeHeap orders validators by EndTime from earliest to latest.
-type EndTimeHeap []*Validator
-
-func (h *EndTimeHeap) Len() int                 { return len(*h) }
-func (h *EndTimeHeap) Less(i, j int) bool       { return (*h)[i].EndTime().Before((*h)[j].EndTime()) }
-func (h *EndTimeHeap) Swap(i, j int)            { (*h)[i], (*h)[j] = (*h)[j], (*h)[i] }
-func (h *EndTimeHeap) Add(validator *Validator) { heap.Push(h, validator) }
-func (h *EndTimeHeap) Peek() *Validator         { return (*h)[0] }
-func (h *EndTimeHeap) Remove() *Validator       { return heap.Pop(h).(*Validator) }
-func (h *EndTimeHeap) Push(x interface{})       { *h = append(*h, x.(*Validator)) }
-func (h *EndTimeHeap) Pop() interface{} {
-	newLen := len(*h) - 1
-	val := (*h)[newLen]
-	(*h)[newLen] = nil
-	*h = (*h)[:newLen]
-	return val
+import "github.com/ava-labs/avalanchego/utils/heap"
+
+// NewEndTimeHeap returns a heap that orders validators by EndTime from
+// earliest to latest.
+func NewEndTimeHeap() heap.Heap[*Validator] {
+	return heap.New(func(a, b *Validator) bool {
+		return a.EndTime().Before(b.EndTime())
+	})
 }
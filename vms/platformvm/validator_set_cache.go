@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// validatorSetCache is a bounded cache of the validator sets of a subnet,
+// keyed by height. When full, the least recently used validator set is
+// evicted.
+//
+// Validator sets are returned without being copied, so they must not be
+// modified.
+type validatorSetCache struct {
+	size int
+
+	// Incremented on every access to track how recently each validator set
+	// was used
+	clock uint64
+
+	// height -> validator set
+	sets map[uint64]*cachedValidatorSet
+
+	// heights of the cached validator sets, sorted in increasing order
+	heights []uint64
+}
+
+type cachedValidatorSet struct {
+	validators map[ids.NodeID]uint64
+	lastUsed   uint64
+}
+
+func newValidatorSetCache(size int) *validatorSetCache {
+	return &validatorSetCache{
+		size: size,
+		sets: make(map[uint64]*cachedValidatorSet, size),
+	}
+}
+
+// Get returns the validator set at [height], if it is cached
+func (c *validatorSetCache) Get(height uint64) (map[ids.NodeID]uint64, bool) {
+	set, ok := c.sets[height]
+	if !ok {
+		return nil, false
+	}
+	c.clock++
+	set.lastUsed = c.clock
+	return set.validators, true
+}
+
+// Next returns the cached validator set with the lowest height that is
+// greater than [height]. Applying the diffs between the returned height and
+// [height] to the returned validator set produces the validator set at
+// [height].
+func (c *validatorSetCache) Next(height uint64) (uint64, map[ids.NodeID]uint64, bool) {
+	i := sort.Search(len(c.heights), func(i int) bool {
+		return c.heights[i] > height
+	})
+	if i == len(c.heights) {
+		return 0, nil, false
+	}
+	nextHeight := c.heights[i]
+	validators, _ := c.Get(nextHeight)
+	return nextHeight, validators, true
+}
+
+// Put caches [validators] as the validator set at [height]
+func (c *validatorSetCache) Put(height uint64, validators map[ids.NodeID]uint64) {
+	c.clock++
+	if set, ok := c.sets[height]; ok {
+		set.validators = validators
+		set.lastUsed = c.clock
+		return
+	}
+
+	if len(c.sets) >= c.size {
+		c.evict()
+	}
+
+	c.sets[height] = &cachedValidatorSet{
+		validators: validators,
+		lastUsed:   c.clock,
+	}
+	i := sort.Search(len(c.heights), func(i int) bool {
+		return c.heights[i] > height
+	})
+	c.heights = append(c.heights, 0)
+	copy(c.heights[i+1:], c.heights[i:])
+	c.heights[i] = height
+}
+
+// Len returns the number of cached validator sets
+func (c *validatorSetCache) Len() int {
+	return len(c.sets)
+}
+
+// evict removes the least recently used validator set
+func (c *validatorSetCache) evict() {
+	if len(c.heights) == 0 {
+		return
+	}
+
+	evictIndex := 0
+	for i, height := range c.heights {
+		if c.sets[height].lastUsed < c.sets[c.heights[evictIndex]].lastUsed {
+			evictIndex = i
+		}
+	}
+	delete(c.sets, c.heights[evictIndex])
+	c.heights = append(c.heights[:evictIndex], c.heights[evictIndex+1:]...)
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+)
+
+func TestValidatorSetCache(t *testing.T) {
+	assert := assert.New(t)
+
+	c := newValidatorSetCache(2)
+
+	_, ok := c.Get(1)
+	assert.False(ok)
+	_, _, ok = c.Next(0)
+	assert.False(ok)
+
+	set5 := map[ids.NodeID]uint64{{5}: 5}
+	set10 := map[ids.NodeID]uint64{{10}: 10}
+	c.Put(10, set10)
+	c.Put(5, set5)
+	assert.Equal(2, c.Len())
+
+	// The closest validator set above the requested height is returned
+	height, set, ok := c.Next(3)
+	assert.True(ok)
+	assert.EqualValues(5, height)
+	assert.Equal(set5, set)
+
+	height, set, ok = c.Next(5)
+	assert.True(ok)
+	assert.EqualValues(10, height)
+	assert.Equal(set10, set)
+
+	_, _, ok = c.Next(10)
+	assert.False(ok)
+
+	// Height 5 is now the least recently used validator set
+	set7 := map[ids.NodeID]uint64{{7}: 7}
+	c.Put(7, set7)
+	assert.Equal(2, c.Len())
+
+	_, ok = c.Get(5)
+	assert.False(ok)
+	set, ok = c.Get(7)
+	assert.True(ok)
+	assert.Equal(set7, set)
+	set, ok = c.Get(10)
+	assert.True(ok)
+	assert.Equal(set10, set)
+
+	height, _, ok = c.Next(0)
+	assert.True(ok)
+	assert.EqualValues(7, height)
+}
+
+func TestGetValidatorSetIncremental(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Accept a few blocks that don't modify the validator set
+	for i := 0; i < 3; i++ {
+		tx, err := vm.txBuilder.NewCreateSubnetTx(
+			1, // threshold
+			[]ids.ShortID{keys[0].PublicKey().Address()},
+			[]*crypto.PrivateKeySECP256K1R{keys[0]},
+			keys[0].PublicKey().Address(),
+		)
+		assert.NoError(err)
+		assert.NoError(vm.blockBuilder.AddUnverifiedTx(tx))
+
+		blk, err := vm.BuildBlock()
+		assert.NoError(err)
+		assert.NoError(blk.Verify())
+		assert.NoError(blk.Accept())
+		assert.NoError(vm.SetPreference(blk.ID()))
+	}
+
+	height, err := vm.GetCurrentHeight()
+	assert.NoError(err)
+	assert.EqualValues(3, height)
+
+	currentValidators, ok := vm.Validators.GetValidators(constants.PrimaryNetworkID)
+	assert.True(ok)
+	expectedSet := make(map[ids.NodeID]uint64)
+	for _, vdr := range currentValidators.List() {
+		expectedSet[vdr.ID()] = vdr.Weight()
+	}
+
+	// Replayed from the last accepted height
+	set, err := vm.GetValidatorSet(2, constants.PrimaryNetworkID)
+	assert.NoError(err)
+	assert.Equal(expectedSet, set)
+	assert.EqualValues(1, testutil.ToFloat64(vm.metrics.validatorSetsCreated))
+	assert.EqualValues(0, testutil.ToFloat64(vm.metrics.validatorSetsIncremental))
+	assert.EqualValues(1, testutil.ToFloat64(vm.metrics.validatorSetsHeightDiff))
+
+	// Replayed from the validator set cached at height 2
+	set, err = vm.GetValidatorSet(0, constants.PrimaryNetworkID)
+	assert.NoError(err)
+	assert.Equal(expectedSet, set)
+	assert.EqualValues(2, testutil.ToFloat64(vm.metrics.validatorSetsCreated))
+	assert.EqualValues(1, testutil.ToFloat64(vm.metrics.validatorSetsIncremental))
+	assert.EqualValues(3, testutil.ToFloat64(vm.metrics.validatorSetsHeightDiff))
+
+	// Served directly from the cache
+	_, err = vm.GetValidatorSet(0, constants.PrimaryNetworkID)
+	assert.NoError(err)
+	assert.EqualValues(1, testutil.ToFloat64(vm.metrics.validatorSetsCached))
+	assert.EqualValues(2, testutil.ToFloat64(vm.metrics.validatorSetsCreated))
+
+	// Heights that haven't been accepted yet are unknown
+	_, err = vm.GetValidatorSet(4, constants.PrimaryNetworkID)
+	assert.Error(err)
+}
@@ -8,16 +8,15 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/pubsub"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
@@ -57,6 +56,9 @@ const (
 
 	maxRecentlyAcceptedWindowSize = 256
 	recentlyAcceptedWindowTTL     = 5 * time.Minute
+
+	// Maximum number of sets of state pruned when a block is accepted
+	maxPrunedPerBlock = 128
 )
 
 var (
@@ -64,8 +66,10 @@ var (
 	_ secp256k1fx.VM   = &VM{}
 	_ validators.State = &VM{}
 
-	errInvalidID      = errors.New("invalid ID")
-	errWrongCacheType = errors.New("unexpectedly cached type")
+	_ chains.TrackedSubnetsHandler = &VM{}
+
+	errInvalidID    = errors.New("invalid ID")
+	errPrunedHeight = errors.New("validator set at height was pruned")
 )
 
 type VM struct {
@@ -106,7 +110,7 @@ type VM struct {
 	// Maps caches for each subnet that is currently whitelisted.
 	// Key: Subnet ID
 	// Value: cache mapping height -> validator set map
-	validatorSetCaches map[ids.ID]cache.Cacher
+	validatorSetCaches map[ids.ID]*validatorSetCache
 
 	// Key: block ID
 	// Value: the block
@@ -116,6 +120,8 @@ type VM struct {
 	recentlyAccepted *window.Window
 
 	txBuilder builder.TxBuilder
+
+	pubsub *pubsub.Server
 }
 
 // Initialize this blockchain.
@@ -152,13 +158,16 @@ func (vm *VM) Initialize(
 
 	vm.ctx = ctx
 	vm.dbManager = dbManager
+	// The P-chain doesn't serve the UTXOs topic because its UTXOs are
+	// consumed when a block's state diff is applied rather than per tx.
+	vm.pubsub = pubsub.New(ctx.NetworkID, ctx.Log, pubsub.AcceptedBlocks, pubsub.AcceptedTxs)
 
 	vm.codecRegistry = linearcodec.NewDefault()
 	if err := vm.fx.Initialize(vm); err != nil {
 		return err
 	}
 
-	vm.validatorSetCaches = make(map[ids.ID]cache.Cacher)
+	vm.validatorSetCaches = make(map[ids.ID]*validatorSetCache)
 	vm.currentBlocks = make(map[ids.ID]Block)
 
 	if err := vm.blockBuilder.Initialize(vm, toEngine, registerer); err != nil {
@@ -168,7 +177,10 @@ func (vm *VM) Initialize(
 		)
 	}
 	vm.network = newNetwork(vm.ApricotPhase4Time, appSender, vm)
-	vm.rewards = reward.NewCalculator(vm.RewardConfig)
+	vm.rewards = vm.RewardCalculator
+	if vm.rewards == nil {
+		vm.rewards = reward.NewCalculator(vm.RewardConfig)
+	}
 
 	is, err := NewState(vm, vm.dbManager.Current().Database, genesisBytes, registerer)
 	if err != nil {
@@ -196,6 +208,9 @@ func (vm *VM) Initialize(
 		)
 	}
 
+	// Create the chains of subnets that are tracked while the node is running
+	vm.Chains.SetTrackedSubnetsHandler(vm)
+
 	vm.recentlyAccepted = window.New(
 		window.Config{
 			Clock:   &vm.clock,
@@ -263,6 +278,45 @@ func (vm *VM) createSubnet(subnetID ids.ID) error {
 	return nil
 }
 
+// TrackSubnet starts maintaining the validator set of [subnetID] and creates
+// the chains of the subnet.
+func (vm *VM) TrackSubnet(subnetID ids.ID) error {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	subnetTx, _, err := vm.internalState.GetTx(subnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't find subnet %s: %w", subnetID, err)
+	}
+	if _, ok := subnetTx.Unsigned.(*txs.CreateSubnetTx); !ok {
+		return fmt.Errorf("%s is not a subnet", subnetID)
+	}
+
+	subnetValidators, err := vm.internalState.CurrentStakers().ValidatorSet(subnetID)
+	if err != nil {
+		return err
+	}
+	if err := vm.Validators.Set(subnetID, subnetValidators); err != nil {
+		return err
+	}
+
+	vm.WhitelistedSubnets.Add(subnetID)
+	vm.metrics.subnetPercentConnected.WithLabelValues(subnetID.String()).Set(0)
+	return vm.createSubnet(subnetID)
+}
+
+// UntrackSubnet stops maintaining the validator set of [subnetID]. The chains
+// of the subnet are shut down by the chain manager.
+func (vm *VM) UntrackSubnet(subnetID ids.ID) error {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	vm.WhitelistedSubnets.Remove(subnetID)
+	delete(vm.validatorSetCaches, subnetID)
+	vm.metrics.subnetPercentConnected.DeleteLabelValues(subnetID.String())
+	return vm.Validators.Set(subnetID, validators.NewSet())
+}
+
 // onBootstrapStarted marks this VM as bootstrapping
 func (vm *VM) onBootstrapStarted() error {
 	vm.bootstrapped.SetValue(false)
@@ -403,7 +457,7 @@ func (vm *VM) Version() (string, error) {
 // * keys are API endpoint extensions
 // * values are API handlers
 func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	server.RegisterInterceptFunc(vm.metrics.apiRequestMetrics.InterceptRequest)
@@ -416,6 +470,10 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 		"": {
 			Handler: server,
 		},
+		"/events": {
+			LockOptions: common.NoLock,
+			Handler:     vm.pubsub,
+		},
 	}, nil
 }
 
@@ -423,7 +481,7 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 // * keys are API endpoint extensions
 // * values are API handlers
 func (vm *VM) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
-	server := rpc.NewServer()
+	server := json.NewServer()
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	if err := server.RegisterService(&api.StaticService{}, "platform"); err != nil {
@@ -454,18 +512,14 @@ func (vm *VM) Disconnected(vdrID ids.NodeID) error {
 func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]uint64, error) {
 	validatorSetsCache, exists := vm.validatorSetCaches[subnetID]
 	if !exists {
-		validatorSetsCache = &cache.LRU{Size: validatorSetsCacheSize}
+		validatorSetsCache = newValidatorSetCache(validatorSetsCacheSize)
 		// Only cache whitelisted subnets
 		if vm.WhitelistedSubnets.Contains(subnetID) || subnetID == constants.PrimaryNetworkID {
 			vm.validatorSetCaches[subnetID] = validatorSetsCache
 		}
 	}
 
-	if validatorSetIntf, ok := validatorSetsCache.Get(height); ok {
-		validatorSet, ok := validatorSetIntf.(map[ids.NodeID]uint64)
-		if !ok {
-			return nil, errWrongCacheType
-		}
+	if validatorSet, ok := validatorSetsCache.Get(height); ok {
 		vm.metrics.validatorSetsCached.Inc()
 		return validatorSet, nil
 	}
@@ -477,22 +531,41 @@ func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]ui
 	if lastAcceptedHeight < height {
 		return nil, database.ErrNotFound
 	}
+	if prunedHeight := vm.internalState.GetPrunedHeight(); height < prunedHeight {
+		return nil, fmt.Errorf("%w: %d < %d", errPrunedHeight, height, prunedHeight)
+	}
 
 	// get the start time to track metrics
 	startTime := vm.Clock().Time()
 
-	currentValidators, ok := vm.Validators.GetValidators(subnetID)
-	if !ok {
-		return nil, state.ErrNotEnoughValidators
-	}
-	currentValidatorList := currentValidators.List()
+	// Rather than replaying the diffs from the last accepted height, start
+	// from the closest validator set above [height] that is already known.
+	startHeight, cachedSet, ok := validatorSetsCache.Next(height)
+	var vdrSet map[ids.NodeID]uint64
+	if ok && startHeight <= lastAcceptedHeight {
+		// The cached validator set must not be modified, so the diffs are
+		// applied to a copy.
+		vdrSet = make(map[ids.NodeID]uint64, len(cachedSet))
+		for nodeID, weight := range cachedSet {
+			vdrSet[nodeID] = weight
+		}
+		vm.metrics.validatorSetsIncremental.Inc()
+	} else {
+		startHeight = lastAcceptedHeight
 
-	vdrSet := make(map[ids.NodeID]uint64, len(currentValidatorList))
-	for _, vdr := range currentValidatorList {
-		vdrSet[vdr.ID()] = vdr.Weight()
+		currentValidators, ok := vm.Validators.GetValidators(subnetID)
+		if !ok {
+			return nil, state.ErrNotEnoughValidators
+		}
+		currentValidatorList := currentValidators.List()
+
+		vdrSet = make(map[ids.NodeID]uint64, len(currentValidatorList))
+		for _, vdr := range currentValidatorList {
+			vdrSet[vdr.ID()] = vdr.Weight()
+		}
 	}
 
-	for i := lastAcceptedHeight; i > height; i-- {
+	for i := startHeight; i > height; i-- {
 		diffs, err := vm.internalState.GetValidatorWeightDiffs(i, subnetID)
 		if err != nil {
 			return nil, err
@@ -503,11 +576,11 @@ func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]ui
 			if diff.Decrease {
 				// The validator's weight was decreased at this block, so in the
 				// prior block it was higher.
-				op = math.Add64
+				op = math.Add[uint64]
 			} else {
 				// The validator's weight was increased at this block, so in the
 				// prior block it was lower.
-				op = math.Sub64
+				op = math.Sub[uint64]
 			}
 
 			newWeight, err := op(vdrSet[nodeID], diff.Amount)
@@ -528,7 +601,7 @@ func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]ui
 	endTime := vm.Clock().Time()
 	vm.metrics.validatorSetsCreated.Inc()
 	vm.metrics.validatorSetsDuration.Add(float64(endTime.Sub(startTime)))
-	vm.metrics.validatorSetsHeightDiff.Add(float64(lastAcceptedHeight - height))
+	vm.metrics.validatorSetsHeightDiff.Add(float64(startHeight - height))
 	return vdrSet, nil
 }
 
@@ -568,6 +641,27 @@ func (vm *VM) GetCurrentHeight() (uint64, error) {
 	return lastAccepted.Height(), nil
 }
 
+// pruneState prunes state that was written more than [PruningHorizon] blocks
+// before [lastAcceptedHeight]. As a safety check, state at or above the
+// minimum height, which other chains may still reference, is never pruned.
+func (vm *VM) pruneState(lastAcceptedHeight uint64) error {
+	horizon := vm.PruningHorizon
+	if horizon == 0 || lastAcceptedHeight <= horizon {
+		return nil
+	}
+
+	minimumHeight, err := vm.GetMinimumHeight()
+	if err != nil {
+		return err
+	}
+	if minimumHeight == 0 {
+		return nil
+	}
+
+	height := math.Min64(lastAcceptedHeight-horizon, minimumHeight-1)
+	return vm.internalState.Prune(height, maxPrunedPerBlock)
+}
+
 func (vm *VM) updateValidators() error {
 	currentValidators := vm.internalState.CurrentStakers()
 	primaryValidators, err := currentValidators.ValidatorSet(constants.PrimaryNetworkID)
@@ -598,6 +692,17 @@ func (vm *VM) CodecRegistry() codec.Registry { return vm.codecRegistry }
 
 func (vm *VM) Clock() *mockable.Clock { return &vm.clock }
 
+// SetTime sets the local time of the VM to [t] and builds a block if the
+// validator set should be updated at [t]. Once it's set, the local time no
+// longer follows the wall clock. This allows networks that are run in a single
+// process to control when stakers start and stop validating.
+//
+// Must be called with the context lock held after the VM is initialized.
+func (vm *VM) SetTime(t time.Time) {
+	vm.clock.Set(t)
+	vm.blockBuilder.ResetTimer()
+}
+
 func (vm *VM) Logger() logging.Logger { return vm.ctx.Log }
 
 // Returns the percentage of the total stake of the subnet connected to this
@@ -621,7 +726,7 @@ func (vm *VM) getPercentConnected(subnetID ids.ID) (float64, error) {
 		if !vm.uptimeManager.IsConnected(vdr.ID()) {
 			continue // not connected to us --> don't include
 		}
-		connectedStake, err = math.Add64(connectedStake, vdr.Weight())
+		connectedStake, err = math.Add(connectedStake, vdr.Weight())
 		if err != nil {
 			return 0, err
 		}
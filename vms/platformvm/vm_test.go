@@ -53,6 +53,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	timetracker "github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -550,6 +551,64 @@ func TestGenesis(t *testing.T) {
 	}
 }
 
+type constantRewardCalculator uint64
+
+func (c constantRewardCalculator) Calculate(time.Duration, uint64, uint64) uint64 {
+	return uint64(c)
+}
+
+// Ensure that a custom reward calculator replaces the default minting function
+func TestCustomRewardCalculator(t *testing.T) {
+	assert := assert.New(t)
+
+	const expectedReward = 12345
+	_, genesisBytes := defaultGenesis()
+	vm := &VM{Factory: Factory{
+		Config: config.Config{
+			Chains:                 chains.MockManager{},
+			Validators:             validators.NewManager(),
+			UptimeLockedCalculator: uptime.NewLockedCalculator(),
+			TxFee:                  defaultTxFee,
+			MinValidatorStake:      defaultMinValidatorStake,
+			MaxValidatorStake:      defaultMaxValidatorStake,
+			MinDelegatorStake:      defaultMinDelegatorStake,
+			MinStakeDuration:       defaultMinStakingDuration,
+			MaxStakeDuration:       defaultMaxStakingDuration,
+			RewardConfig:           defaultRewardConfig,
+			RewardCalculator:       constantRewardCalculator(expectedReward),
+		},
+	}}
+	vm.clock.Set(defaultGenesisTime)
+
+	ctx := defaultContext()
+	ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		ctx.Lock.Unlock()
+	}()
+
+	dbManager := manager.NewMemDB(version.Semantic1_0_0)
+	msgChan := make(chan common.Message, 1)
+	err := vm.Initialize(ctx, dbManager, genesisBytes, nil, nil, msgChan, nil, nil)
+	assert.NoError(err)
+
+	// The genesis validators are rewarded by the custom calculator
+	_, potentialReward, err := vm.internalState.CurrentStakers().GetNextStaker()
+	assert.NoError(err)
+	assert.EqualValues(expectedReward, potentialReward)
+
+	service := &Service{vm: vm}
+	startTime := uint64(defaultValidateStartTime.Unix())
+	reply := EstimateRewardReply{}
+	err = service.EstimateReward(nil, &EstimateRewardArgs{
+		Amount:    json.Uint64(vm.MinValidatorStake),
+		StartTime: json.Uint64(startTime),
+		EndTime:   json.Uint64(startTime + uint64(defaultMinStakingDuration/time.Second)),
+	}, &reply)
+	assert.NoError(err)
+	assert.EqualValues(expectedReward, reply.Reward)
+}
+
 // accept proposal to add validator to primary network
 func TestAddValidatorCommit(t *testing.T) {
 	vm, _, _, _ := defaultVM()
@@ -3068,3 +3127,149 @@ func TestRejectedStateRegressionInvalidValidatorReward(t *testing.T) {
 		assert.Equal(newValidatorStartTime1.Unix(), currentTimestamp.Unix())
 	}
 }
+
+func TestPruneState(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Pruning is a no-op while the chain is within the horizon
+	vm.PruningHorizon = 10
+	assert.NoError(vm.pruneState(10))
+	assert.Zero(vm.internalState.GetPrunedHeight())
+
+	subnetID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	stakerTx := &txs.Tx{Unsigned: &txs.AddSubnetValidatorTx{
+		Validator: validator.SubnetValidator{
+			Validator: validator.Validator{
+				NodeID: nodeID,
+				Start:  uint64(defaultValidateStartTime.Unix()),
+				End:    uint64(defaultValidateEndTime.Unix()),
+				Wght:   1,
+			},
+			Subnet: subnetID,
+		},
+		SubnetAuth: &secp256k1fx.Input{},
+	}}
+	assert.NoError(stakerTx.Sign(txs.Codec, nil))
+	rewardedTxID := ids.GenerateTestID()
+	rewardUTXO := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: rewardedTxID},
+		Asset:  avax.Asset{ID: vm.ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+		},
+	}
+
+	// Write a validator weight diff and reward UTXOs at height 1
+	vm.internalState.SetHeight(1)
+	vm.internalState.AddCurrentStaker(stakerTx, 0)
+	vm.internalState.AddRewardUTXO(rewardedTxID, rewardUTXO)
+	assert.NoError(vm.internalState.Commit())
+
+	diffs, err := vm.internalState.GetValidatorWeightDiffs(1, subnetID)
+	assert.NoError(err)
+	assert.Len(diffs, 1)
+	rewardUTXOs, err := vm.internalState.GetRewardUTXOs(rewardedTxID)
+	assert.NoError(err)
+	assert.Len(rewardUTXOs, 1)
+
+	// Nothing was written at or below height 0
+	assert.NoError(vm.internalState.Prune(0, maxPrunedPerBlock))
+	assert.NoError(vm.internalState.Commit())
+	diffs, err = vm.internalState.GetValidatorWeightDiffs(1, subnetID)
+	assert.NoError(err)
+	assert.Len(diffs, 1)
+
+	assert.NoError(vm.internalState.Prune(1, maxPrunedPerBlock))
+	assert.NoError(vm.internalState.Commit())
+	assert.EqualValues(1, vm.internalState.GetPrunedHeight())
+
+	diffs, err = vm.internalState.GetValidatorWeightDiffs(1, subnetID)
+	assert.NoError(err)
+	assert.Empty(diffs)
+	rewardUTXOs, err = vm.internalState.GetRewardUTXOs(rewardedTxID)
+	assert.NoError(err)
+	assert.Empty(rewardUTXOs)
+
+	// Validator sets can't be recalculated below the pruned height
+	_, err = vm.GetValidatorSet(0, subnetID)
+	assert.ErrorIs(err, errPrunedHeight)
+}
+
+func TestTrackSubnet(t *testing.T) {
+	assert := assert.New(t)
+
+	vm, _, _, _ := defaultVM()
+	defer func() {
+		vm.ctx.Lock.Lock()
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Only subnets that exist can be tracked
+	err := vm.TrackSubnet(ids.GenerateTestID())
+	assert.ErrorIs(err, database.ErrNotFound)
+
+	subnetID := testSubnet1.ID()
+	assert.NoError(vm.TrackSubnet(subnetID))
+	assert.True(vm.WhitelistedSubnets.Contains(subnetID))
+	_, exists := vm.Validators.GetValidators(subnetID)
+	assert.True(exists)
+
+	vm.ctx.Lock.Lock()
+	_, err = vm.GetValidatorSet(1, subnetID)
+	vm.ctx.Lock.Unlock()
+	assert.NoError(err)
+	assert.Contains(vm.validatorSetCaches, subnetID)
+
+	assert.NoError(vm.UntrackSubnet(subnetID))
+	assert.False(vm.WhitelistedSubnets.Contains(subnetID))
+	assert.NotContains(vm.validatorSetCaches, subnetID)
+	subnetValidators, exists := vm.Validators.GetValidators(subnetID)
+	assert.True(exists)
+	assert.Zero(subnetValidators.Len())
+}
+
+func TestSetTime(t *testing.T) {
+	assert := assert.New(t)
+
+	_, msgChan, vm, _ := GenesisVMWithArgs(t, nil)
+	vm.ctx.Lock.Lock()
+	defer func() {
+		assert.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Drain any notification sent while the VM was created
+	select {
+	case <-msgChan:
+	default:
+	}
+
+	// The genesis validators aren't due to be removed yet
+	vm.SetTime(defaultValidateStartTime)
+	assert.Equal(defaultValidateStartTime, vm.Clock().Time())
+	select {
+	case msg := <-msgChan:
+		t.Fatalf("unexpected message %s", msg)
+	default:
+	}
+
+	// Once the genesis validators' end time is reached, a block is built to
+	// reward them
+	vm.SetTime(defaultValidateEndTime)
+	assert.Equal(defaultValidateEndTime, vm.Clock().Time())
+	select {
+	case msg := <-msgChan:
+		assert.Equal(common.PendingTxs, msg)
+	default:
+		t.Fatal("expected a block to be built")
+	}
+}
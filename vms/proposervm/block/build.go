@@ -5,11 +5,11 @@ package block
 
 import (
 	"crypto"
-	"crypto/rand"
 	"crypto/x509"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
@@ -79,8 +79,7 @@ func Build(
 		return nil, err
 	}
 
-	headerHash := hashing.ComputeHash256(header.Bytes())
-	block.Signature, err = key.Sign(rand.Reader, headerHash, crypto.SHA256)
+	block.Signature, err = staking.Sign(key, header.Bytes())
 	if err != nil {
 		return nil, err
 	}
@@ -52,6 +52,34 @@ func TestBuild(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestBuildEd25519(t *testing.T) {
+	assert := assert.New(t)
+
+	chainID := ids.ID{4}
+
+	tlsCert, err := staking.NewTLSCertWithKeyType(staking.Ed25519)
+	assert.NoError(err)
+
+	builtBlock, err := Build(
+		ids.ID{1},
+		time.Unix(123, 0),
+		2,
+		tlsCert.Leaf,
+		[]byte{3},
+		chainID,
+		tlsCert.PrivateKey.(crypto.Signer),
+	)
+	assert.NoError(err)
+
+	parsedBlock, err := Parse(builtBlock.Bytes())
+	assert.NoError(err)
+	assert.Equal(builtBlock.ID(), parsedBlock.ID())
+	assert.Equal(ids.NodeIDFromCert(tlsCert.Leaf), parsedBlock.(SignedBlock).Proposer())
+
+	err = parsedBlock.(SignedBlock).Verify(true, chainID)
+	assert.NoError(err)
+}
+
 func TestBuildUnsigned(t *testing.T) {
 	parentID := ids.ID{1}
 	timestamp := time.Unix(123, 0)
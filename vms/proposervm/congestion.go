@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"math"
+	"time"
+)
+
+// blockDelay returns the minimum time, after the preferred block's timestamp,
+// that this node waits before building a block.
+//
+// If the inner VM reports its congestion, the delay is scaled from
+// [maxBlockDelay] when the inner VM isn't congested down to
+// [congestedBlockDelay] when it is fully congested, so that a loaded inner VM
+// gets its txs included sooner and an idle one doesn't build empty blocks.
+// Otherwise, or if the report is invalid, [minBlockDelay] is used.
+// The delay is only a local scheduling decision; the proposer windows still
+// bound when a block may be built.
+func (vm *VM) blockDelay() time.Duration {
+	if vm.cVM == nil {
+		return minBlockDelay
+	}
+
+	congestion, err := vm.cVM.Congestion()
+	if err != nil {
+		vm.ctx.Log.Debug("failed to fetch the inner VM's congestion due to: %s", err)
+		return minBlockDelay
+	}
+	if math.IsNaN(congestion) {
+		vm.ctx.Log.Debug("inner VM reported an invalid congestion of %f", congestion)
+		return minBlockDelay
+	}
+
+	congestion = math.Min(math.Max(congestion, 0), 1)
+	delay := maxBlockDelay - time.Duration(congestion*float64(maxBlockDelay-congestedBlockDelay))
+	return delay.Round(blockDelayGranularity)
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func TestBlockDelay(t *testing.T) {
+	tests := []struct {
+		name          string
+		congestion    float64
+		err           error
+		expectedDelay time.Duration
+	}{
+		{
+			name:          "idle",
+			congestion:    0,
+			expectedDelay: maxBlockDelay,
+		},
+		{
+			name:          "slightly congested",
+			congestion:    .4,
+			expectedDelay: 1300 * time.Millisecond,
+		},
+		{
+			name:          "congested",
+			congestion:    .8,
+			expectedDelay: 600 * time.Millisecond,
+		},
+		{
+			name:          "fully congested",
+			congestion:    1,
+			expectedDelay: congestedBlockDelay,
+		},
+		{
+			name:          "negative congestion",
+			congestion:    -1,
+			expectedDelay: maxBlockDelay,
+		},
+		{
+			name:          "excessive congestion",
+			congestion:    2,
+			expectedDelay: congestedBlockDelay,
+		},
+		{
+			name:          "NaN congestion",
+			congestion:    math.NaN(),
+			expectedDelay: minBlockDelay,
+		},
+		{
+			name:          "error",
+			err:           errors.New("failed to report congestion"),
+			expectedDelay: minBlockDelay,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			vm := &VM{
+				ctx: snow.DefaultContextTest(),
+				cVM: &block.TestCongestionReportingVM{
+					T: t,
+					CongestionF: func() (float64, error) {
+						return test.congestion, test.err
+					},
+				},
+			}
+			assert.Equal(test.expectedDelay, vm.blockDelay())
+		})
+	}
+}
+
+func TestBlockDelayWithoutCongestionReporting(t *testing.T) {
+	vm := &VM{ctx: snow.DefaultContextTest()}
+	assert.Equal(t, minBlockDelay, vm.blockDelay())
+}
+
+func TestBlockDelayShorterWhenCongested(t *testing.T) {
+	assert := assert.New(t)
+
+	congestion := 0.
+	vm := &VM{
+		ctx: snow.DefaultContextTest(),
+		cVM: &block.TestCongestionReportingVM{
+			T: t,
+			CongestionF: func() (float64, error) {
+				return congestion, nil
+			},
+		},
+	}
+
+	// A loaded inner VM builds blocks sooner than an inner VM that doesn't
+	// report its congestion
+	previousDelay := vm.blockDelay()
+	for congestion = .1; congestion <= 1; congestion += .1 {
+		delay := vm.blockDelay()
+		assert.Less(delay, previousDelay)
+		previousDelay = delay
+	}
+	assert.Less(previousDelay, minBlockDelay)
+}
@@ -4,13 +4,13 @@
 package state
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/cache/metercacher"
+	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
@@ -21,11 +21,7 @@ const (
 	blockCacheSize = 8192
 )
 
-var (
-	errBlockWrongVersion = errors.New("wrong version")
-
-	_ BlockState = &blockState{}
-)
+var _ BlockState = &blockState{}
 
 type BlockState interface {
 	GetBlock(blkID ids.ID) (block.Block, choices.Status, error)
@@ -88,13 +84,21 @@ func (s *blockState) GetBlock(blkID ids.ID) (block.Block, choices.Status, error)
 		return nil, choices.Unknown, err
 	}
 
+	storedVersion, err := codec.Version(blkWrapperBytes)
+	if err != nil {
+		return nil, choices.Unknown, err
+	}
 	blkWrapper := blockWrapper{}
-	parsedVersion, err := c.Unmarshal(blkWrapperBytes, &blkWrapper)
+	migratedBytes, err := codec.Migrate(c, version, blkWrapperBytes, &blkWrapper)
 	if err != nil {
 		return nil, choices.Unknown, err
 	}
-	if parsedVersion != version {
-		return nil, choices.Unknown, errBlockWrongVersion
+	if storedVersion != version {
+		// Blocks stored with the fixed width encoding are re-stored with the
+		// varint encoding the first time they're read.
+		if err := s.db.Put(blkID[:], migratedBytes); err != nil {
+			return nil, choices.Unknown, err
+		}
 	}
 
 	// The key was in the database
@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
@@ -81,3 +82,50 @@ func TestMeteredBlockState(t *testing.T) {
 
 	testBlockState(a, bs)
 }
+
+func TestBlockStateMigratesFixedVersion(t *testing.T) {
+	a := assert.New(t)
+
+	tlsCert, err := staking.NewTLSCert()
+	a.NoError(err)
+
+	b, err := block.Build(
+		ids.ID{1},
+		time.Unix(123, 0),
+		2,
+		tlsCert.Leaf,
+		[]byte{3},
+		ids.ID{4},
+		tlsCert.PrivateKey.(crypto.Signer),
+	)
+	a.NoError(err)
+
+	// Store the block the way it was stored before the varint encoding
+	db := memdb.New()
+	blkID := b.ID()
+	fixedBytes, err := c.Marshal(fixedVersion, &blockWrapper{
+		Block:  b.Bytes(),
+		Status: choices.Accepted,
+	})
+	a.NoError(err)
+	a.NoError(db.Put(blkID[:], fixedBytes))
+
+	bs := NewBlockState(db)
+	fetchedBlock, fetchedStatus, err := bs.GetBlock(blkID)
+	a.NoError(err)
+	a.Equal(choices.Accepted, fetchedStatus)
+	a.Equal(b.Bytes(), fetchedBlock.Bytes())
+
+	// The block was re-stored with the smaller varint encoding
+	migratedBytes, err := db.Get(blkID[:])
+	a.NoError(err)
+	a.Less(len(migratedBytes), len(fixedBytes))
+	migratedVersion, err := codec.Version(migratedBytes)
+	a.NoError(err)
+	a.EqualValues(version, migratedVersion)
+
+	fetchedBlock, fetchedStatus, err = NewBlockState(db).GetBlock(blkID)
+	a.NoError(err)
+	a.Equal(choices.Accepted, fetchedStatus)
+	a.Equal(b.Bytes(), fetchedBlock.Bytes())
+}
@@ -8,18 +8,32 @@ import (
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/codec/reflectcodec"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
-const version = 0
+const (
+	// fixedVersion packs integers and lengths with fixed widths. Blocks that
+	// were stored before [version] was introduced use it.
+	fixedVersion = 0
+	// version packs integers and lengths as varints. Blocks are stored with
+	// it.
+	version = 1
+)
 
 var c codec.Manager
 
 func init() {
 	lc := linearcodec.NewCustomMaxLength(math.MaxUint32)
+	vc := linearcodec.NewVarint([]string{reflectcodec.DefaultTagName}, math.MaxUint32)
 	c = codec.NewManager(math.MaxInt32)
 
-	err := c.RegisterCodec(version, lc)
-	if err != nil {
-		panic(err)
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterCodec(fixedVersion, lc),
+		c.RegisterCodec(version, vc),
+	)
+	if errs.Errored() {
+		panic(errs.Err)
 	}
 }
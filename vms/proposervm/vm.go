@@ -33,7 +33,17 @@ import (
 const (
 	// minBlockDelay should be kept as whole seconds because block timestamps
 	// are only specific to the second.
-	minBlockDelay         = time.Second
+	minBlockDelay = time.Second
+	// maxBlockDelay is used instead of [minBlockDelay] when the inner VM
+	// reports that it isn't congested. It should also be kept as whole seconds.
+	maxBlockDelay = 2 * time.Second
+	// congestedBlockDelay is used instead of [minBlockDelay] when the inner VM
+	// reports that it's fully congested. Blocks built less than a second apart
+	// may have the same timestamp.
+	congestedBlockDelay = 250 * time.Millisecond
+	// blockDelayGranularity is the precision of the delays derived from the
+	// inner VM's congestion.
+	blockDelayGranularity = 50 * time.Millisecond
 	checkIndexedFrequency = 10 * time.Second
 )
 
@@ -51,6 +61,7 @@ type VM struct {
 	bVM  block.BatchedChainVM
 	hVM  block.HeightIndexedChainVM
 	ssVM block.StateSyncableVM
+	cVM  block.CongestionReportingVM
 
 	activationTime      time.Time
 	minimumPChainHeight uint64
@@ -94,11 +105,13 @@ func New(
 	bVM, _ := vm.(block.BatchedChainVM)
 	hVM, _ := vm.(block.HeightIndexedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	cVM, _ := vm.(block.CongestionReportingVM)
 	return &VM{
 		ChainVM: vm,
 		bVM:     bVM,
 		hVM:     hVM,
 		ssVM:    ssVM,
+		cVM:     cVM,
 
 		activationTime:      activationTime,
 		minimumPChainHeight: minimumPChainHeight,
@@ -157,6 +170,9 @@ func (vm *VM) Initialize(
 	if err := vm.repair(indexerState); err != nil {
 		return err
 	}
+	if err := vm.verifyAcceptedChain(); err != nil {
+		return err
+	}
 
 	return vm.setLastAcceptedMetadata()
 }
@@ -242,8 +258,8 @@ func (vm *VM) SetPreference(preferred ids.ID) error {
 		// until the P-chain's height has advanced.
 		return nil
 	}
-	if minDelay < minBlockDelay {
-		minDelay = minBlockDelay
+	if blockDelay := vm.blockDelay(); minDelay < blockDelay {
+		minDelay = blockDelay
 	}
 
 	preferredTime := blk.Timestamp()
@@ -519,6 +535,81 @@ func (vm *VM) repairAcceptedChainByHeight() error {
 	return vm.db.Commit()
 }
 
+// verifyAcceptedChain checks that, once repaired, the last accepted block of
+// the proposervm wraps the last accepted block of the inner VM and that the
+// height index agrees with it. Height index entries that disagree are
+// rewritten. The proposervm commits its blocks before the inner VM accepts
+// them, so any other inconsistency can't be caused by an unclean shutdown and
+// can't be repaired.
+func (vm *VM) verifyAcceptedChain() error {
+	proLastAcceptedID, err := vm.State.GetLastAccepted()
+	if err == database.ErrNotFound {
+		// If the last accepted block isn't indexed yet, then the underlying
+		// chain is the only chain and there is nothing to verify.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	proLastAccepted, err := vm.getPostForkBlock(proLastAcceptedID)
+	if err != nil {
+		return fmt.Errorf("couldn't load last accepted proposervm block %s: %w", proLastAcceptedID, err)
+	}
+
+	innerLastAcceptedID, err := vm.ChainVM.LastAccepted()
+	if err != nil {
+		return err
+	}
+	innerBlk := proLastAccepted.getInnerBlk()
+	if innerBlkID := innerBlk.ID(); innerBlkID != innerLastAcceptedID {
+		return fmt.Errorf(
+			"last accepted proposervm block %s at height %d wraps block %s but the inner VM's last accepted block is %s",
+			proLastAcceptedID,
+			proLastAccepted.Height(),
+			innerBlkID,
+			innerLastAcceptedID,
+		)
+	}
+	if status := innerBlk.Status(); status != choices.Accepted {
+		return fmt.Errorf("inner VM reports its last accepted block %s as %s", innerLastAcceptedID, status)
+	}
+
+	// The height index is only verified if it's complete. Otherwise, it's
+	// being rebuilt.
+	if !vm.hIndexer.IsRepaired() {
+		return nil
+	}
+
+	// Walk back from the last accepted block until the height index agrees
+	// with the accepted chain.
+	var blk PostForkBlock = proLastAccepted
+	for {
+		height := blk.Height()
+		blkID := blk.ID()
+		indexedID, err := vm.State.GetBlockIDAtHeight(height)
+		if err != nil && err != database.ErrNotFound {
+			return err
+		}
+		if err == nil && indexedID == blkID {
+			return vm.db.Commit()
+		}
+
+		vm.ctx.Log.Warn("repairing height index entry at height %d from %s to %s", height, indexedID, blkID)
+		if err := vm.State.SetBlockIDAtHeight(height, blkID); err != nil {
+			return err
+		}
+
+		blk, err = vm.getPostForkBlock(blk.Parent())
+		if err == database.ErrNotFound {
+			// We reached the fork, below which the inner VM indexes blocks.
+			return vm.db.Commit()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func (vm *VM) setLastAcceptedMetadata() error {
 	lastAcceptedID, err := vm.GetLastAccepted()
 	if err == database.ErrNotFound {
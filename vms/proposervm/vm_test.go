@@ -2109,3 +2109,112 @@ func TestRejectedOptionHeightNotIndexed(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(bBlock.ID(), blkID)
 }
+
+// restartWithAcceptedBlock accepts a proposervm block wrapping a new core
+// block and returns the proposervm block, the core block and a function that
+// reinitializes the proposervm on the same database
+func restartWithAcceptedBlock(t *testing.T) (snowman.Block, *snowman.TestBlock, *fullVM, func() (*VM, error)) {
+	assert := assert.New(t)
+
+	coreVM, _, proVM, coreGenBlk, dbManager := initTestProposerVM(t, time.Time{}, 0)
+	proVM.Set(coreGenBlk.Timestamp())
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreVM.BuildBlockF = func() (snowman.Block, error) { return coreBlk, nil }
+	coreVM.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case coreGenBlk.ID():
+			return coreGenBlk, nil
+		case coreBlk.ID():
+			return coreBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+	coreVM.ParseBlockF = func(b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, coreGenBlk.Bytes()):
+			return coreGenBlk, nil
+		case bytes.Equal(b, coreBlk.Bytes()):
+			return coreBlk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	proBlk, err := proVM.BuildBlock()
+	assert.NoError(err)
+	assert.NoError(proBlk.Verify())
+	assert.NoError(proVM.SetPreference(proBlk.ID()))
+	assert.NoError(proBlk.Accept())
+	coreVM.LastAcceptedF = func() (ids.ID, error) { return coreBlk.ID(), nil }
+
+	ctx := proVM.ctx
+	restart := func() (*VM, error) {
+		coreVM.InitializeF = func(*snow.Context, manager.Manager,
+			[]byte, []byte, []byte, chan<- common.Message,
+			[]*common.Fx, common.AppSender,
+		) error {
+			return nil
+		}
+		proVM = New(coreVM, time.Time{}, 0)
+		return proVM, proVM.Initialize(ctx, dbManager, nil, nil, nil, nil, nil, nil)
+	}
+	return proBlk, coreBlk, coreVM, restart
+}
+
+func TestInitializeRepairsHeightIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	proBlk, _, _, restart := restartWithAcceptedBlock(t)
+	proVM, err := restart()
+	assert.NoError(err)
+
+	// Corrupt the height index entry of the last accepted block
+	assert.NoError(proVM.State.SetBlockIDAtHeight(proBlk.Height(), ids.GenerateTestID()))
+	assert.NoError(proVM.db.Commit())
+
+	proVM, err = restart()
+	assert.NoError(err)
+	blkID, err := proVM.GetBlockIDAtHeight(proBlk.Height())
+	assert.NoError(err)
+	assert.Equal(proBlk.ID(), blkID)
+}
+
+func TestInitializeFailsOnConflictingLastAccepted(t *testing.T) {
+	assert := assert.New(t)
+
+	_, coreBlk, coreVM, restart := restartWithAcceptedBlock(t)
+
+	// The inner VM reports a different block at the same height as accepted
+	conflictingBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		BytesV:     []byte{2},
+		ParentV:    coreBlk.Parent(),
+		HeightV:    coreBlk.Height(),
+		TimestampV: coreBlk.Timestamp(),
+	}
+	getBlock := coreVM.GetBlockF
+	coreVM.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		if blkID == conflictingBlk.ID() {
+			return conflictingBlk, nil
+		}
+		return getBlock(blkID)
+	}
+	coreVM.LastAcceptedF = func() (ids.ID, error) { return conflictingBlk.ID(), nil }
+
+	_, err := restart()
+	assert.Error(err)
+}
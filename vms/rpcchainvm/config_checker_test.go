@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/mocks"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+var (
+	errInvalidTestGenesis = errors.New("invalid genesis")
+
+	_ common.ConfigChecker = &configCheckerVM{}
+)
+
+type configCheckerVM struct {
+	*mocks.MockChainVM
+}
+
+func (*configCheckerVM) CheckConfig(genesisBytes []byte, _ []byte) error {
+	if !bytes.Equal(genesisBytes, []byte("genesis")) {
+		return errInvalidTestGenesis
+	}
+	return nil
+}
+
+func configCheckerTestPlugin(t *testing.T, _ bool) (plugin.Plugin, *gomock.Controller) {
+	// test key is "configCheckerTestKey"
+
+	// create mock
+	ctrl := gomock.NewController(t)
+	vm := &configCheckerVM{
+		MockChainVM: mocks.NewMockChainVM(ctrl),
+	}
+	return New(vm), ctrl
+}
+
+func TestConfigChecker(t *testing.T) {
+	tests := []struct {
+		name          string
+		pluginVersion string
+		supported     bool
+	}{
+		{
+			name:      "latest plugin",
+			supported: true,
+		},
+		{
+			name:          "plugin predates config checks",
+			pluginVersion: strconv.Itoa(configCheckProtocolVersion - 1),
+			supported:     false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+			testKey := configCheckerTestKey
+
+			mockedPlugin, ctrl := configCheckerTestPlugin(t, false /*loadExpectations*/)
+			defer ctrl.Finish()
+
+			if test.pluginVersion != "" {
+				t.Setenv(protocolVersionEnvKey, test.pluginVersion)
+			}
+
+			process := helperProcess(testKey)
+			c := plugin.NewClient(&plugin.ClientConfig{
+				Cmd:              process,
+				HandshakeConfig:  TestHandshake,
+				VersionedPlugins: versionedPluginSets(plugin.PluginSet{testKey: mockedPlugin}),
+				AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+			})
+			defer c.Kill()
+
+			client, err := c.Client()
+			assert.NoError(err)
+
+			raw, err := client.Dispense(testKey)
+			assert.NoError(err)
+			vm := raw.(*VMClient)
+			// SetProcess records the negotiated version the same way
+			vm.protocolVersion = uint(c.NegotiatedVersion())
+
+			err = vm.CheckConfig([]byte("genesis"), nil)
+			if !test.supported {
+				assert.Equal(common.ErrConfigCheckerNotImplemented, err)
+				return
+			}
+			assert.NoError(err)
+
+			// The reason the genesis was rejected is reported by the plugin
+			err = vm.CheckConfig([]byte("other genesis"), nil)
+			assert.Error(err)
+			assert.Equal(errInvalidTestGenesis.Error(), err.Error())
+		})
+	}
+}
+
+func TestConfigCheckerNotImplemented(t *testing.T) {
+	assert := assert.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := NewServer(mocks.NewMockChainVM(ctrl))
+	resp, err := server.CheckConfig(context.Background(), &vmpb.CheckConfigRequest{})
+	assert.NoError(err)
+	assert.Equal(common.ErrConfigCheckerNotImplemented, errCodeToError[resp.Err])
+}
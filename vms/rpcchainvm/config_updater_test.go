@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/mocks"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+var (
+	_ common.ConfigUpdater = &configUpdaterVM{}
+
+	validConfig = []byte(`{"valid":true}`)
+
+	errInvalidConfig = errors.New("invalid config")
+)
+
+type configUpdaterVM struct {
+	*mocks.MockChainVM
+}
+
+func (*configUpdaterVM) UpdateConfig(configBytes []byte) error {
+	if !bytes.Equal(configBytes, validConfig) {
+		return errInvalidConfig
+	}
+	return nil
+}
+
+func configUpdaterTestPlugin(t *testing.T, _ bool) (plugin.Plugin, *gomock.Controller) {
+	// test key is "configUpdaterTestKey"
+
+	// create mock
+	ctrl := gomock.NewController(t)
+	vm := &configUpdaterVM{
+		MockChainVM: mocks.NewMockChainVM(ctrl),
+	}
+	return New(vm), ctrl
+}
+
+func TestUpdateConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		pluginVersion string
+		supported     bool
+	}{
+		{
+			name:      "latest plugin",
+			supported: true,
+		},
+		{
+			name:          "plugin predates config updates",
+			pluginVersion: strconv.Itoa(configUpdateProtocolVersion - 1),
+			supported:     false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+			testKey := configUpdaterTestKey
+
+			mockedPlugin, ctrl := configUpdaterTestPlugin(t, false /*loadExpectations*/)
+			defer ctrl.Finish()
+
+			if test.pluginVersion != "" {
+				t.Setenv(protocolVersionEnvKey, test.pluginVersion)
+			}
+
+			process := helperProcess(testKey)
+			c := plugin.NewClient(&plugin.ClientConfig{
+				Cmd:              process,
+				HandshakeConfig:  TestHandshake,
+				VersionedPlugins: versionedPluginSets(plugin.PluginSet{testKey: mockedPlugin}),
+				AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+			})
+			defer c.Kill()
+
+			client, err := c.Client()
+			assert.NoError(err)
+
+			raw, err := client.Dispense(testKey)
+			assert.NoError(err)
+			vm := raw.(*VMClient)
+			// SetProcess records the negotiated version the same way
+			vm.protocolVersion = uint(c.NegotiatedVersion())
+
+			if !test.supported {
+				assert.Equal(common.ErrConfigUpdaterNotImplemented, vm.UpdateConfig(validConfig))
+				return
+			}
+
+			assert.NoError(vm.UpdateConfig(validConfig))
+			err = vm.UpdateConfig([]byte("{}"))
+			assert.Error(err)
+			assert.Contains(err.Error(), errInvalidConfig.Error())
+		})
+	}
+}
+
+func TestUpdateConfigNotImplemented(t *testing.T) {
+	assert := assert.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The VM doesn't implement ConfigUpdater, which is reported to the client
+	// as an error code rather than as an RPC error
+	server := NewServer(mocks.NewMockChainVM(ctrl))
+	resp, err := server.UpdateConfig(context.Background(), &vmpb.UpdateConfigRequest{
+		ConfigBytes: validConfig,
+	})
+	assert.NoError(err)
+	assert.Equal(common.ErrConfigUpdaterNotImplemented, errCodeToError[resp.Err])
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/mocks"
+)
+
+const testCongestion = .25
+
+var _ block.CongestionReportingVM = &congestionReportingVM{}
+
+type congestionReportingVM struct {
+	*mocks.MockChainVM
+}
+
+func (*congestionReportingVM) Congestion() (float64, error) {
+	return testCongestion, nil
+}
+
+func congestionTestPlugin(t *testing.T, _ bool) (plugin.Plugin, *gomock.Controller) {
+	// test key is "congestionTestKey"
+
+	// create mock
+	ctrl := gomock.NewController(t)
+	vm := &congestionReportingVM{
+		MockChainVM: mocks.NewMockChainVM(ctrl),
+	}
+	return New(vm), ctrl
+}
+
+func TestCongestion(t *testing.T) {
+	tests := []struct {
+		name          string
+		pluginVersion string
+		supported     bool
+	}{
+		{
+			name:      "latest plugin",
+			supported: true,
+		},
+		{
+			name:          "plugin predates congestion reports",
+			pluginVersion: strconv.Itoa(congestionProtocolVersion - 1),
+			supported:     false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+			testKey := congestionTestKey
+
+			mockedPlugin, ctrl := congestionTestPlugin(t, false /*loadExpectations*/)
+			defer ctrl.Finish()
+
+			if test.pluginVersion != "" {
+				t.Setenv(protocolVersionEnvKey, test.pluginVersion)
+			}
+
+			process := helperProcess(testKey)
+			c := plugin.NewClient(&plugin.ClientConfig{
+				Cmd:              process,
+				HandshakeConfig:  TestHandshake,
+				VersionedPlugins: versionedPluginSets(plugin.PluginSet{testKey: mockedPlugin}),
+				AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+			})
+			defer c.Kill()
+
+			client, err := c.Client()
+			assert.NoError(err)
+
+			raw, err := client.Dispense(testKey)
+			assert.NoError(err)
+			vm := raw.(*VMClient)
+			// SetProcess records the negotiated version the same way
+			vm.protocolVersion = uint(c.NegotiatedVersion())
+
+			congestion, err := vm.Congestion()
+			if !test.supported {
+				assert.Equal(block.ErrCongestionReportingVMNotImplemented, err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(testCongestion, congestion)
+		})
+	}
+}
+
+func TestCongestionNotImplemented(t *testing.T) {
+	assert := assert.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The VM doesn't report its congestion, which is reported to the client as
+	// an error code rather than as an RPC error
+	server := NewServer(mocks.NewMockChainVM(ctrl))
+	resp, err := server.Congestion(context.Background(), &emptypb.Empty{})
+	assert.NoError(err)
+	assert.Equal(block.ErrCongestionReportingVMNotImplemented, errCodeToError[resp.Err])
+}
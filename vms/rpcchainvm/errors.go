@@ -5,6 +5,7 @@ package rpcchainvm
 
 import (
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 )
 
@@ -15,13 +16,19 @@ var (
 		3: block.ErrHeightIndexedVMNotImplemented,
 		4: block.ErrIndexIncomplete,
 		5: block.ErrStateSyncableVMNotImplemented,
+		6: common.ErrConfigUpdaterNotImplemented,
+		7: block.ErrCongestionReportingVMNotImplemented,
+		8: common.ErrConfigCheckerNotImplemented,
 	}
 	errorToErrCode = map[error]uint32{
-		database.ErrClosed:                     1,
-		database.ErrNotFound:                   2,
-		block.ErrHeightIndexedVMNotImplemented: 3,
-		block.ErrIndexIncomplete:               4,
-		block.ErrStateSyncableVMNotImplemented: 5,
+		database.ErrClosed:                           1,
+		database.ErrNotFound:                         2,
+		block.ErrHeightIndexedVMNotImplemented:       3,
+		block.ErrIndexIncomplete:                     4,
+		block.ErrStateSyncableVMNotImplemented:       5,
+		common.ErrConfigUpdaterNotImplemented:        6,
+		block.ErrCongestionReportingVMNotImplemented: 7,
+		common.ErrConfigCheckerNotImplemented:        8,
 	}
 )
 
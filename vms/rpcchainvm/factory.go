@@ -18,6 +18,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/subprocess"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
 )
 
 var (
@@ -39,10 +41,29 @@ func NewFactory(path string, processTracker resource.ProcessTracker) vms.Factory
 }
 
 func (f *factory) New(ctx *snow.Context) (interface{}, error) {
+	client, rpcClient, vm, err := f.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vm.SetProcess(ctx, client, f.processTracker)
+	vm.rpcClient = rpcClient
+	vm.startPlugin = func() (*plugin.Client, plugin.ClientProtocol, vmpb.VMClient, error) {
+		client, rpcClient, vm, err := f.start(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return client, rpcClient, vm.client, nil
+	}
+	return vm, nil
+}
+
+// start launches a new plugin process and connects to the VM it serves
+func (f *factory) start(ctx *snow.Context) (*plugin.Client, plugin.ClientProtocol, *VMClient, error) {
 	config := &plugin.ClientConfig{
-		HandshakeConfig: Handshake,
-		Plugins:         PluginMap,
-		Cmd:             subprocess.New(f.path),
+		HandshakeConfig:  Handshake,
+		VersionedPlugins: VersionedPluginMap,
+		Cmd:              subprocess.New(f.path),
 		AllowedProtocols: []plugin.Protocol{
 			plugin.ProtocolGRPC,
 		},
@@ -83,21 +104,19 @@ func (f *factory) New(ctx *snow.Context) (interface{}, error) {
 	rpcClient, err := client.Client()
 	if err != nil {
 		client.Kill()
-		return nil, pluginErr(err)
+		return nil, nil, nil, pluginErr(err)
 	}
 
 	raw, err := rpcClient.Dispense("vm")
 	if err != nil {
 		client.Kill()
-		return nil, pluginErr(err)
+		return nil, nil, nil, pluginErr(err)
 	}
 
 	vm, ok := raw.(*VMClient)
 	if !ok {
 		client.Kill()
-		return nil, pluginErr(errWrongVM)
+		return nil, nil, nil, pluginErr(errWrongVM)
 	}
-
-	vm.SetProcess(ctx, client, f.processTracker)
-	return vm, nil
+	return client, rpcClient, vm, nil
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"testing"
 
 	gomock "github.com/golang/mock/gomock"
@@ -27,6 +28,16 @@ const (
 	getStateSummaryTestKey                         = "getStateSummaryTest"
 	acceptStateSummaryTestKey                      = "acceptStateSummaryTest"
 	lastAcceptedBlockPostStateSummaryAcceptTestKey = "lastAcceptedBlockPostStateSummaryAcceptTest"
+	featuresTestKey                                = "featuresTest"
+	streamingTestKey                               = "streamingTest"
+	configUpdaterTestKey                           = "configUpdaterTest"
+	restartTestKey                                 = "restartTest"
+	congestionTestKey                              = "congestionTest"
+	configCheckerTestKey                           = "configCheckerTest"
+
+	// protocolVersionEnvKey is the environment variable that sets the only
+	// protocol version the test plugin process serves
+	protocolVersionEnvKey = "TEST_PROTOCOL_VERSION"
 )
 
 var (
@@ -49,6 +60,12 @@ var (
 		getStateSummaryTestKey:                         getStateSummaryTestPlugin,
 		acceptStateSummaryTestKey:                      acceptStateSummaryTestPlugin,
 		lastAcceptedBlockPostStateSummaryAcceptTestKey: lastAcceptedBlockPostStateSummaryAcceptTestPlugin,
+		featuresTestKey:                                featuresTestPlugin,
+		streamingTestKey:                               streamingTestPlugin,
+		configUpdaterTestKey:                           configUpdaterTestPlugin,
+		restartTestKey:                                 restartTestPlugin,
+		congestionTestKey:                              congestionTestPlugin,
+		configCheckerTestKey:                           configCheckerTestPlugin,
 	}
 )
 
@@ -94,13 +111,26 @@ func TestHelperProcess(t *testing.T) {
 		plugins[testKey] = mockedPlugin
 	}
 
-	plugin.Serve(&plugin.ServeConfig{
+	config := &plugin.ServeConfig{
 		HandshakeConfig: TestHandshake,
 		Plugins:         plugins,
 
 		// A non-nil value here enables gRPC serving for this plugin.
 		GRPCServer: grpcutils.NewDefaultServer,
-	})
+	}
+	// Serve only the requested protocol version, to emulate an older plugin
+	if versionStr := os.Getenv(protocolVersionEnvKey); versionStr != "" {
+		version, err := strconv.Atoi(versionStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to parse protocol version: %s\n", err)
+			os.Exit(2)
+		}
+		config.Plugins = nil
+		config.VersionedPlugins = map[int]plugin.PluginSet{
+			version: plugins,
+		}
+	}
+	plugin.Serve(config)
 
 	for _, ctrl := range controllersList {
 		ctrl.Finish()
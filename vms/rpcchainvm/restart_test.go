@@ -0,0 +1,234 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/mocks"
+	"github.com/ava-labs/avalanchego/version"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+var (
+	_ block.ChainVM = &restartTestVM{}
+	_ snowman.Block = &restartTestBlock{}
+
+	restartGenesisBlk = &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.ID{'g', 'e', 'n', 'e', 's', 'i', 's'},
+			StatusV: choices.Accepted,
+		},
+		BytesV: []byte("genesis"),
+	}
+	restartChildBlk = &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.ID{'c', 'h', 'i', 'l', 'd'},
+			StatusV: choices.Processing,
+		},
+		ParentV: restartGenesisBlk.IDV,
+		HeightV: 1,
+		BytesV:  []byte("child"),
+	}
+
+	errUnknownTestBlock = errors.New("unknown block")
+)
+
+// restartTestVM only knows about the blocks that were verified since the
+// plugin process started, like a VM that doesn't persist processing blocks
+type restartTestVM struct {
+	*mocks.MockChainVM
+
+	verified map[ids.ID]snowman.Block
+}
+
+func (*restartTestVM) Initialize(
+	*snow.Context,
+	manager.Manager,
+	[]byte,
+	[]byte,
+	[]byte,
+	chan<- common.Message,
+	[]*common.Fx,
+	common.AppSender,
+) error {
+	return nil
+}
+
+func (*restartTestVM) Shutdown() error { return nil }
+
+func (*restartTestVM) LastAccepted() (ids.ID, error) { return restartGenesisBlk.ID(), nil }
+
+func (vm *restartTestVM) GetBlock(blkID ids.ID) (snowman.Block, error) {
+	if blkID == restartGenesisBlk.ID() {
+		return restartGenesisBlk, nil
+	}
+	if blk, ok := vm.verified[blkID]; ok {
+		return blk, nil
+	}
+	return nil, database.ErrNotFound
+}
+
+func (vm *restartTestVM) ParseBlock(b []byte) (snowman.Block, error) {
+	if !bytes.Equal(b, restartChildBlk.Bytes()) {
+		return nil, errUnknownTestBlock
+	}
+	return &restartTestBlock{
+		TestBlock: restartChildBlk,
+		vm:        vm,
+	}, nil
+}
+
+type restartTestBlock struct {
+	*snowman.TestBlock
+
+	vm *restartTestVM
+}
+
+func (b *restartTestBlock) Verify() error {
+	b.vm.verified[b.ID()] = b
+	return nil
+}
+
+type noProcessTracker struct{}
+
+func (noProcessTracker) TrackProcess(int)   {}
+func (noProcessTracker) UntrackProcess(int) {}
+
+func restartTestPlugin(t *testing.T, _ bool) (plugin.Plugin, *gomock.Controller) {
+	// test key is "restartTestKey"
+
+	ctrl := gomock.NewController(t)
+	return New(&restartTestVM{
+		MockChainVM: mocks.NewMockChainVM(ctrl),
+		verified:    make(map[ids.ID]snowman.Block),
+	}), ctrl
+}
+
+func startRestartTestPlugin(assert *assert.Assertions, mockedPlugin plugin.Plugin) (*plugin.Client, plugin.ClientProtocol, *VMClient) {
+	c := plugin.NewClient(&plugin.ClientConfig{
+		Cmd:              helperProcess(restartTestKey),
+		HandshakeConfig:  TestHandshake,
+		VersionedPlugins: versionedPluginSets(plugin.PluginSet{restartTestKey: mockedPlugin}),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+	})
+
+	rpcClient, err := c.Client()
+	assert.NoError(err)
+
+	raw, err := rpcClient.Dispense(restartTestKey)
+	assert.NoError(err)
+	return c, rpcClient, raw.(*VMClient)
+}
+
+func TestRestartPluginReverifiesProcessingBlocks(t *testing.T) {
+	assert := assert.New(t)
+
+	mockedPlugin, ctrl := restartTestPlugin(t, false /*loadExpectations*/)
+	defer ctrl.Finish()
+
+	c, rpcClient, vm := startRestartTestPlugin(assert, mockedPlugin)
+	ctx := snow.DefaultContextTest()
+	vm.SetProcess(ctx, c, noProcessTracker{})
+	vm.rpcClient = rpcClient
+
+	dbManager := manager.NewMemDB(version.Semantic1_0_0).NewPrefixDBManager([]byte{})
+	assert.NoError(vm.Initialize(ctx, dbManager, nil, nil, nil, nil, nil, nil))
+	assert.Positive(vm.pluginMemoryUsage())
+
+	var restartedProc *plugin.Client
+	vm.startPlugin = func() (*plugin.Client, plugin.ClientProtocol, vmpb.VMClient, error) {
+		c, rpcClient, vm := startRestartTestPlugin(assert, mockedPlugin)
+		restartedProc = c
+		return c, rpcClient, vm.client, nil
+	}
+	defer func() {
+		if restartedProc != nil {
+			restartedProc.Kill()
+		}
+	}()
+
+	blk, err := vm.ParseBlock(restartChildBlk.Bytes())
+	assert.NoError(err)
+	assert.NoError(blk.Verify())
+
+	// The crashed plugin loses the processing block
+	c.Kill()
+	assert.Error(vm.checkPlugin())
+	assert.NoError(vm.restartPlugin())
+	assert.Equal(restartedProc.ReattachConfig().Pid, vm.pid)
+	assert.Positive(vm.pluginMemoryUsage())
+
+	// The restarted plugin was told about the processing block, so it can be
+	// decided
+	assert.NoError(blk.Accept())
+
+	lastAcceptedID, err := vm.LastAccepted()
+	assert.NoError(err)
+	assert.Equal(restartChildBlk.ID(), lastAcceptedID)
+}
+
+func TestRestartPluginStopsRunningPlugin(t *testing.T) {
+	assert := assert.New(t)
+
+	mockedPlugin, ctrl := restartTestPlugin(t, false /*loadExpectations*/)
+	defer ctrl.Finish()
+
+	c, rpcClient, vm := startRestartTestPlugin(assert, mockedPlugin)
+	ctx := snow.DefaultContextTest()
+	vm.SetProcess(ctx, c, noProcessTracker{})
+	vm.rpcClient = rpcClient
+
+	dbManager := manager.NewMemDB(version.Semantic1_0_0).NewPrefixDBManager([]byte{})
+	assert.NoError(vm.Initialize(ctx, dbManager, nil, nil, nil, nil, nil, nil))
+
+	var restartedProc *plugin.Client
+	vm.startPlugin = func() (*plugin.Client, plugin.ClientProtocol, vmpb.VMClient, error) {
+		// The plugin that is being replaced was stopped before the new one is
+		// started, and calls fail rather than waiting on it
+		assert.True(c.Exited())
+		_, err := vm.Gather()
+		assert.ErrorIs(err, errPluginRestarting)
+		_, err = vm.HealthCheck()
+		assert.ErrorIs(err, errPluginRestarting)
+		_, err = vm.Version()
+		assert.Error(err)
+
+		c, rpcClient, vm := startRestartTestPlugin(assert, mockedPlugin)
+		restartedProc = c
+		return c, rpcClient, vm.client, nil
+	}
+	defer func() {
+		if restartedProc != nil {
+			restartedProc.Kill()
+		}
+	}()
+
+	// The plugin is still running, as if it failed its health check because
+	// it stopped responding
+	assert.False(c.Exited())
+	assert.NoError(vm.restartPlugin())
+	assert.Equal(restartedProc.ReattachConfig().Pid, vm.pid)
+	assert.False(vm.restarting.GetValue())
+
+	_, err := vm.Gather()
+	assert.NoError(err)
+}
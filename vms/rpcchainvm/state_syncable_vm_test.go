@@ -5,6 +5,7 @@ package rpcchainvm
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -21,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/mocks"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms"
 )
 
 var (
@@ -513,3 +515,105 @@ func TestLastAcceptedBlockPostStateSummaryAccept(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(summary.Height(), lastBlk.Height())
 }
+
+func featuresTestPlugin(t *testing.T, loadExpectations bool) (plugin.Plugin, *gomock.Controller) {
+	// test key is "featuresTestKey"
+
+	// create mock
+	ctrl := gomock.NewController(t)
+	ssVM := StateSyncEnabledMock{
+		MockChainVM:         mocks.NewMockChainVM(ctrl),
+		MockStateSyncableVM: mocks.NewMockStateSyncableVM(ctrl),
+	}
+
+	if loadExpectations {
+		ssVM.MockChainVM.EXPECT().Version().Return("v1.2.3", nil).Times(2)
+	}
+
+	return New(ssVM), ctrl
+}
+
+func TestFeatures(t *testing.T) {
+	assert := assert.New(t)
+	testKey := featuresTestKey
+
+	mockedPlugin, ctrl := featuresTestPlugin(t, false /*loadExpectations*/)
+	defer ctrl.Finish()
+
+	// Create and start the plugin
+	vm, c := buildClientHelper(assert, testKey, mockedPlugin)
+	defer c.Kill()
+
+	version, err := vm.Version()
+	assert.NoError(err)
+	assert.Equal("v1.2.3", version)
+
+	// The mocked VM implements state sync but not the height index
+	features, err := vm.Features()
+	assert.NoError(err)
+	assert.Equal(&vms.Features{StateSync: true}, features)
+	assert.EqualValues(protocolVersion, vm.ProtocolVersion())
+}
+
+func TestProtocolVersionNegotiation(t *testing.T) {
+	tests := []struct {
+		name                    string
+		pluginVersion           string
+		expectedProtocolVersion uint
+	}{
+		{
+			name:                    "latest plugin",
+			expectedProtocolVersion: protocolVersion,
+		},
+		{
+			name:                    "plugin predates state sync",
+			pluginVersion:           strconv.Itoa(stateSyncProtocolVersion - 1),
+			expectedProtocolVersion: stateSyncProtocolVersion - 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+			testKey := stateSyncEnabledTestKey
+
+			mockedPlugin, ctrl := stateSyncEnabledTestPlugin(t, false /*loadExpectations*/)
+			defer ctrl.Finish()
+
+			if test.pluginVersion != "" {
+				t.Setenv(protocolVersionEnvKey, test.pluginVersion)
+			}
+
+			process := helperProcess(testKey)
+			c := plugin.NewClient(&plugin.ClientConfig{
+				Cmd:              process,
+				HandshakeConfig:  TestHandshake,
+				VersionedPlugins: versionedPluginSets(plugin.PluginSet{testKey: mockedPlugin}),
+				AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+			})
+			defer c.Kill()
+
+			client, err := c.Client()
+			assert.NoError(err)
+			assert.Equal(int(test.expectedProtocolVersion), c.NegotiatedVersion())
+
+			raw, err := client.Dispense(testKey)
+			assert.NoError(err)
+			vm := raw.(*VMClient)
+			// SetProcess records the negotiated version the same way
+			vm.protocolVersion = uint(c.NegotiatedVersion())
+			assert.Equal(test.expectedProtocolVersion, vm.ProtocolVersion())
+
+			if vm.supportsStateSync() {
+				return
+			}
+
+			// The plugin must not be called if it predates state sync
+			enabled, err := vm.StateSyncEnabled()
+			assert.NoError(err)
+			assert.False(enabled)
+
+			_, err = vm.GetLastStateSummary()
+			assert.Equal(block.ErrStateSyncableVMNotImplemented, err)
+		})
+	}
+}
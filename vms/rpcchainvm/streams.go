@@ -0,0 +1,211 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+var (
+	errParseBlockStreamFailed       = errors.New("ParseBlock stream failed")
+	errUnexpectedParseBlockResponse = errors.New("received a ParseBlock response without a pending request")
+)
+
+// streams are the long-lived gRPC streams that are used, rather than unary
+// RPCs, for frequent and small messages to the plugin. Each stream is opened
+// the first time it is needed and is re-opened after it fails.
+//
+// Messages sent over a stream are subject to the stream's flow control, so
+// senders block, rather than queue messages, if the plugin falls behind.
+type streams struct {
+	parseBlockLock sync.Mutex
+	parseBlock     *parseBlockStream
+
+	appGossipLock sync.Mutex
+	appGossip     vmpb.VM_AppGossipStreamClient
+}
+
+// ParseBlock sends [req] over the ParseBlock stream of [client] and waits for
+// its response. Requests from concurrent callers are pipelined: only sending a
+// request is serialized, so a caller doesn't wait for the responses to the
+// requests that were sent before its own.
+func (s *streams) ParseBlock(client vmpb.VMClient, req *vmpb.ParseBlockRequest) (*vmpb.ParseBlockResponse, error) {
+	result := make(chan parseBlockResult, 1)
+
+	s.parseBlockLock.Lock()
+	stream := s.parseBlock
+	if stream == nil || stream.failed() {
+		rawStream, err := client.ParseBlockStream(context.Background())
+		if err != nil {
+			s.parseBlockLock.Unlock()
+			return nil, err
+		}
+		stream = &parseBlockStream{stream: rawStream}
+		s.parseBlock = stream
+		go stream.receive()
+	}
+	if err := stream.send(req, result); err != nil {
+		// If the request was queued, the reason the stream failed is reported
+		// to it by the receiver.
+		s.parseBlock = nil
+		if err != errParseBlockStreamFailed {
+			s.parseBlockLock.Unlock()
+			return nil, err
+		}
+	}
+	s.parseBlockLock.Unlock()
+
+	res := <-result
+	if res.err != nil {
+		return nil, res.err
+	}
+	if res.resp.Error != "" {
+		return nil, errors.New(res.resp.Error)
+	}
+	return res.resp.Response, nil
+}
+
+// AppGossip sends [msg] over the AppGossip stream of [client] without waiting
+// for the plugin to handle it. The plugin logs the messages it fails to
+// handle, so an error is only returned if [msg] couldn't be sent.
+func (s *streams) AppGossip(client vmpb.VMClient, msg *vmpb.AppGossipMsg) error {
+	s.appGossipLock.Lock()
+	defer s.appGossipLock.Unlock()
+
+	if s.appGossip == nil {
+		stream, err := client.AppGossipStream(context.Background())
+		if err != nil {
+			return err
+		}
+		s.appGossip = stream
+	}
+
+	if err := s.appGossip.Send(msg); err != nil {
+		// If the stream was terminated by the plugin, the reason is reported
+		// by CloseAndRecv.
+		if err == io.EOF {
+			_, err = s.appGossip.CloseAndRecv()
+		}
+		s.appGossip = nil
+		return err
+	}
+	return nil
+}
+
+// Close closes any open streams. Close waits for the plugin to handle all of
+// the gossip messages that were sent.
+func (s *streams) Close() error {
+	errs := wrappers.Errs{}
+
+	s.parseBlockLock.Lock()
+	if s.parseBlock != nil {
+		errs.Add(s.parseBlock.stream.CloseSend())
+		s.parseBlock = nil
+	}
+	s.parseBlockLock.Unlock()
+
+	s.appGossipLock.Lock()
+	if s.appGossip != nil {
+		_, err := s.appGossip.CloseAndRecv()
+		errs.Add(err)
+		s.appGossip = nil
+	}
+	s.appGossipLock.Unlock()
+
+	return errs.Err
+}
+
+type parseBlockResult struct {
+	resp *vmpb.ParseBlockStreamResponse
+	err  error
+}
+
+// parseBlockStream matches the responses received over a ParseBlock stream to
+// the requests that are waiting for them. The plugin handles the requests of
+// a stream in order, so the responses are received in the order the requests
+// were sent.
+type parseBlockStream struct {
+	stream vmpb.VM_ParseBlockStreamClient
+
+	lock sync.Mutex
+	// pending are the requests that are waiting for a response, in the order
+	// that they were sent
+	pending []chan<- parseBlockResult
+	// err is the reason the stream failed, or nil if it is still usable
+	err error
+}
+
+// send queues [result] to receive the response to [req] and sends [req]. If
+// errParseBlockStreamFailed is returned, [result] was queued and will receive
+// the reason the stream failed. Any other error means that [result] wasn't
+// queued. send must not be called concurrently.
+func (p *parseBlockStream) send(req *vmpb.ParseBlockRequest, result chan<- parseBlockResult) error {
+	p.lock.Lock()
+	if p.err != nil {
+		err := p.err
+		p.lock.Unlock()
+		return err
+	}
+	p.pending = append(p.pending, result)
+	p.lock.Unlock()
+
+	// If Send fails, the stream is done and the receiver is notified by Recv.
+	if err := p.stream.Send(req); err != nil {
+		return errParseBlockStreamFailed
+	}
+	return nil
+}
+
+// receive delivers the responses received over the stream until it fails
+func (p *parseBlockStream) receive() {
+	for {
+		resp, err := p.stream.Recv()
+		if err != nil {
+			p.fail(err)
+			return
+		}
+
+		p.lock.Lock()
+		if len(p.pending) == 0 {
+			p.lock.Unlock()
+			p.fail(errUnexpectedParseBlockResponse)
+			return
+		}
+		result := p.pending[0]
+		p.pending[0] = nil
+		p.pending = p.pending[1:]
+		p.lock.Unlock()
+
+		result <- parseBlockResult{resp: resp}
+	}
+}
+
+// fail reports [err] to every request that is waiting for a response and
+// prevents any more requests from being sent
+func (p *parseBlockStream) fail(err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.err == nil {
+		p.err = err
+	}
+	for _, result := range p.pending {
+		result <- parseBlockResult{err: p.err}
+	}
+	p.pending = nil
+}
+
+func (p *parseBlockStream) failed() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.err != nil
+}
@@ -0,0 +1,237 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/grpc"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/mocks"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+var (
+	streamedBlk = &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.ID{'s', 't', 'r', 'e', 'a', 'm', 'e', 'd'},
+			StatusV: choices.Processing,
+		},
+		HeightV:    1,
+		ParentV:    ids.ID{'p', 'a', 'r', 'e', 'n', 't', 'B', 'l', 'k'},
+		TimestampV: time.Unix(1, 0),
+		BytesV:     []byte("valid block"),
+	}
+	invalidBlkBytes = []byte("invalid block")
+
+	gossipMsg        = []byte("gossip")
+	invalidGossipMsg = []byte("invalid gossip")
+
+	errInvalidBlock  = errors.New("invalid block")
+	errInvalidGossip = errors.New("invalid gossip")
+)
+
+func streamingTestPlugin(t *testing.T, loadExpectations bool) (plugin.Plugin, *gomock.Controller) {
+	// test key is "streamingTestKey"
+
+	// create mock
+	ctrl := gomock.NewController(t)
+	vm := mocks.NewMockChainVM(ctrl)
+
+	if loadExpectations {
+		gomock.InOrder(
+			vm.EXPECT().ParseBlock(streamedBlk.BytesV).Return(streamedBlk, nil).Times(1),
+			vm.EXPECT().ParseBlock(invalidBlkBytes).Return(nil, errInvalidBlock).Times(1),
+			vm.EXPECT().ParseBlock(streamedBlk.BytesV).Return(streamedBlk, nil).Times(1),
+			vm.EXPECT().AppGossip(gomock.Any(), gossipMsg).Return(nil).Times(1),
+			vm.EXPECT().AppGossip(gomock.Any(), invalidGossipMsg).Return(errInvalidGossip).Times(1),
+			vm.EXPECT().AppGossip(gomock.Any(), gossipMsg).Return(nil).Times(1),
+		)
+	}
+
+	return New(vm), ctrl
+}
+
+func TestStreams(t *testing.T) {
+	tests := []struct {
+		name          string
+		pluginVersion string
+		streaming     bool
+	}{
+		{
+			name:      "latest plugin",
+			streaming: true,
+		},
+		{
+			name:          "plugin predates streaming",
+			pluginVersion: strconv.Itoa(streamingProtocolVersion - 1),
+			streaming:     false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+			testKey := streamingTestKey
+
+			mockedPlugin, ctrl := streamingTestPlugin(t, false /*loadExpectations*/)
+			defer ctrl.Finish()
+
+			if test.pluginVersion != "" {
+				t.Setenv(protocolVersionEnvKey, test.pluginVersion)
+			}
+
+			process := helperProcess(testKey)
+			c := plugin.NewClient(&plugin.ClientConfig{
+				Cmd:              process,
+				HandshakeConfig:  TestHandshake,
+				VersionedPlugins: versionedPluginSets(plugin.PluginSet{testKey: mockedPlugin}),
+				AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+			})
+			defer c.Kill()
+
+			client, err := c.Client()
+			assert.NoError(err)
+
+			raw, err := client.Dispense(testKey)
+			assert.NoError(err)
+			vm := raw.(*VMClient)
+			// SetProcess records the negotiated version the same way
+			vm.protocolVersion = uint(c.NegotiatedVersion())
+			assert.Equal(test.streaming, vm.supportsStreaming())
+
+			blk, err := vm.parseBlock(streamedBlk.BytesV)
+			assert.NoError(err)
+			assert.Equal(streamedBlk.ID(), blk.ID())
+			assert.Equal(streamedBlk.Parent(), blk.Parent())
+			assert.Equal(streamedBlk.Height(), blk.Height())
+			assert.Equal(streamedBlk.Timestamp().Unix(), blk.Timestamp().Unix())
+
+			// A block failing to parse doesn't prevent later blocks from
+			// being parsed
+			_, err = vm.parseBlock(invalidBlkBytes)
+			assert.Error(err)
+			assert.Contains(err.Error(), errInvalidBlock.Error())
+
+			blk, err = vm.parseBlock(streamedBlk.BytesV)
+			assert.NoError(err)
+			assert.Equal(streamedBlk.ID(), blk.ID())
+
+			assert.NoError(vm.AppGossip(ids.GenerateTestNodeID(), gossipMsg))
+
+			// Streamed gossip failures are logged by the plugin and don't
+			// prevent later messages from being handled
+			err = vm.AppGossip(ids.GenerateTestNodeID(), invalidGossipMsg)
+			if test.streaming {
+				assert.NoError(err)
+			} else {
+				assert.Error(err)
+				assert.Contains(err.Error(), errInvalidGossip.Error())
+			}
+
+			assert.NoError(vm.AppGossip(ids.GenerateTestNodeID(), gossipMsg))
+			assert.NoError(vm.streams.Close())
+		})
+	}
+}
+
+// pipelinedVMClient serves ParseBlock streams whose requests are only
+// answered once [numRequests] requests were sent
+type pipelinedVMClient struct {
+	vmpb.VMClient
+
+	stream *pipelinedStream
+}
+
+func (c *pipelinedVMClient) ParseBlockStream(context.Context, ...grpc.CallOption) (vmpb.VM_ParseBlockStreamClient, error) {
+	return c.stream, nil
+}
+
+type pipelinedStream struct {
+	grpc.ClientStream
+
+	requests  chan *vmpb.ParseBlockRequest
+	responses chan *vmpb.ParseBlockStreamResponse
+}
+
+func (s *pipelinedStream) Send(req *vmpb.ParseBlockRequest) error {
+	s.requests <- req
+	return nil
+}
+
+func (s *pipelinedStream) Recv() (*vmpb.ParseBlockStreamResponse, error) {
+	resp, ok := <-s.responses
+	if !ok {
+		return nil, io.EOF
+	}
+	return resp, nil
+}
+
+func (s *pipelinedStream) CloseSend() error {
+	close(s.responses)
+	return nil
+}
+
+func TestParseBlockStreamPipelinesRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	const numRequests = 4
+	client := &pipelinedVMClient{
+		stream: &pipelinedStream{
+			requests:  make(chan *vmpb.ParseBlockRequest, numRequests),
+			responses: make(chan *vmpb.ParseBlockStreamResponse, numRequests),
+		},
+	}
+	s := streams{}
+
+	type result struct {
+		bytes []byte
+		resp  *vmpb.ParseBlockResponse
+		err   error
+	}
+	results := make(chan result, numRequests)
+	for i := 0; i < numRequests; i++ {
+		go func(bytes []byte) {
+			resp, err := s.ParseBlock(client, &vmpb.ParseBlockRequest{Bytes: bytes})
+			results <- result{
+				bytes: bytes,
+				resp:  resp,
+				err:   err,
+			}
+		}([]byte{byte(i)})
+	}
+
+	// Every request is sent before any of them is answered. If the requests
+	// weren't pipelined, only the first request would be sent.
+	requests := make([]*vmpb.ParseBlockRequest, numRequests)
+	for i := range requests {
+		requests[i] = <-client.stream.requests
+	}
+	for _, req := range requests {
+		client.stream.responses <- &vmpb.ParseBlockStreamResponse{
+			Response: &vmpb.ParseBlockResponse{Id: req.Bytes},
+		}
+	}
+
+	for i := 0; i < numRequests; i++ {
+		result := <-results
+		assert.NoError(result.err)
+		assert.Equal(result.bytes, result.resp.Id)
+	}
+	assert.NoError(s.Close())
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// Time between health checks of a VM plugin
+	defaultHealthCheckInterval = 10 * time.Second
+
+	// Time to wait before retrying a failed plugin restart. This is doubled
+	// after every failed restart, up to [defaultMaxRestartBackoff].
+	defaultInitialRestartBackoff = time.Second
+	defaultMaxRestartBackoff     = time.Minute
+)
+
+// supervisor periodically checks the health of a VM plugin process. If a check
+// fails, the plugin is restarted, with an exponential backoff between failed
+// restarts, until a restart succeeds.
+type supervisor struct {
+	log logging.Logger
+
+	// check returns an error if the plugin isn't healthy
+	check func() error
+	// restart replaces the plugin with a new process and re-initializes it
+	restart func() error
+
+	healthCheckInterval   time.Duration
+	initialRestartBackoff time.Duration
+	maxRestartBackoff     time.Duration
+
+	restarts       prometheus.Counter
+	failedRestarts prometheus.Counter
+
+	// healthy is false from when a health check fails until the plugin is
+	// successfully restarted
+	healthy utils.AtomicBool
+
+	closeOnce sync.Once
+	closer    chan struct{}
+	done      chan struct{}
+}
+
+func newSupervisor(
+	log logging.Logger,
+	check func() error,
+	restart func() error,
+	registerer prometheus.Registerer,
+) (*supervisor, error) {
+	s := &supervisor{
+		log:                   log,
+		check:                 check,
+		restart:               restart,
+		healthCheckInterval:   defaultHealthCheckInterval,
+		initialRestartBackoff: defaultInitialRestartBackoff,
+		maxRestartBackoff:     defaultMaxRestartBackoff,
+		restarts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "plugin_restarts",
+			Help: "Number of times the VM plugin was restarted after failing a health check",
+		}),
+		failedRestarts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "plugin_failed_restarts",
+			Help: "Number of attempts to restart the VM plugin that failed",
+		}),
+		closer: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	s.healthy.SetValue(true)
+
+	if err := registerer.Register(s.restarts); err != nil {
+		return nil, err
+	}
+	return s, registerer.Register(s.failedRestarts)
+}
+
+// Healthy returns false if the plugin failed its last health check and hasn't
+// been restarted yet
+func (s *supervisor) Healthy() bool {
+	return s.healthy.GetValue()
+}
+
+// Dispatch checks the health of the plugin until Stop is called. This function
+// blocks, so it should be called in its own goroutine.
+func (s *supervisor) Dispatch() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.closer:
+			return
+		}
+
+		err := s.check()
+		if err == nil {
+			continue
+		}
+
+		s.log.Warn("VM plugin failed its health check: %s", err)
+		s.healthy.SetValue(false)
+		if !s.restartWithBackoff() {
+			return
+		}
+		s.healthy.SetValue(true)
+	}
+}
+
+// Stop stops checking the health of the plugin. Stop doesn't wait for an
+// ongoing restart to finish.
+func (s *supervisor) Stop() {
+	s.closeOnce.Do(func() {
+		close(s.closer)
+	})
+}
+
+// restartWithBackoff restarts the plugin until a restart succeeds. Returns
+// false if the supervisor was stopped before the plugin was restarted.
+func (s *supervisor) restartWithBackoff() bool {
+	backoff := s.initialRestartBackoff
+	for {
+		s.log.Info("restarting VM plugin")
+
+		err := s.restart()
+		if err == nil {
+			s.restarts.Inc()
+			s.log.Info("restarted VM plugin")
+			return true
+		}
+
+		s.failedRestarts.Inc()
+		s.log.Warn("failed to restart VM plugin, retrying in %s: %s", backoff, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-s.closer:
+			return false
+		}
+
+		backoff *= 2
+		if backoff > s.maxRestartBackoff {
+			backoff = s.maxRestartBackoff
+		}
+	}
+}
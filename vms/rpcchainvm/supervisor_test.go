@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errTest = errors.New("non-nil error")
+
+func TestSupervisorRestartsCrashedPlugin(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		lock         sync.Mutex
+		crashed      = true
+		restartErrs  = []error{errTest, errTest, nil}
+		restartCalls int
+		restarted    = make(chan struct{})
+	)
+	check := func() error {
+		lock.Lock()
+		defer lock.Unlock()
+		if crashed {
+			return errPluginExited
+		}
+		return nil
+	}
+	restart := func() error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		err := restartErrs[restartCalls]
+		restartCalls++
+		if err == nil {
+			crashed = false
+			close(restarted)
+		}
+		return err
+	}
+
+	s, err := newSupervisor(logging.NoLog{}, check, restart, prometheus.NewRegistry())
+	assert.NoError(err)
+	s.healthCheckInterval = time.Millisecond
+	s.initialRestartBackoff = time.Millisecond
+	s.maxRestartBackoff = 2 * time.Millisecond
+	assert.True(s.Healthy())
+
+	go s.Dispatch()
+
+	select {
+	case <-restarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("plugin wasn't restarted")
+	}
+	s.Stop()
+	<-s.done
+
+	assert.True(s.Healthy())
+	assert.Equal(3, restartCalls)
+	assert.Equal(float64(1), testutil.ToFloat64(s.restarts))
+	assert.Equal(float64(2), testutil.ToFloat64(s.failedRestarts))
+}
+
+func TestSupervisorStopDuringRestart(t *testing.T) {
+	assert := assert.New(t)
+
+	failedRestart := make(chan struct{}, 1)
+	check := func() error { return errPluginExited }
+	restart := func() error {
+		select {
+		case failedRestart <- struct{}{}:
+		default:
+		}
+		return errTest
+	}
+
+	s, err := newSupervisor(logging.NoLog{}, check, restart, prometheus.NewRegistry())
+	assert.NoError(err)
+	s.healthCheckInterval = time.Millisecond
+	s.initialRestartBackoff = time.Hour
+
+	go s.Dispatch()
+
+	select {
+	case <-failedRestart:
+	case <-time.After(5 * time.Second):
+		t.Fatal("plugin restart wasn't attempted")
+	}
+
+	// The supervisor must stop while waiting to retry the restart
+	s.Stop()
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("supervisor didn't stop")
+	}
+	assert.False(s.Healthy())
+	assert.Equal(float64(0), testutil.ToFloat64(s.restarts))
+}
@@ -16,9 +16,44 @@ import (
 	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
 )
 
-// protocolVersion should be bumped anytime changes are made which require
-// the plugin vm to upgrade to latest avalanchego release to be compatible.
-const protocolVersion = 15
+const (
+	// protocolVersion should be bumped anytime changes are made which require
+	// the plugin vm to upgrade to latest avalanchego release to be compatible.
+	protocolVersion = 19
+
+	// minProtocolVersion is the oldest protocol version that is still
+	// supported. The node and the plugin negotiate the highest protocol
+	// version that they both support, so plugins built against any version
+	// in [minProtocolVersion, protocolVersion] can be run.
+	minProtocolVersion = 14
+
+	// stateSyncProtocolVersion is the first protocol version in which the
+	// plugin implements the state sync RPCs
+	stateSyncProtocolVersion = 15
+
+	// streamingProtocolVersion is the first protocol version in which the
+	// plugin implements the ParseBlockStream and AppGossipStream RPCs
+	streamingProtocolVersion = 16
+
+	// configUpdateProtocolVersion is the first protocol version in which the
+	// plugin implements the UpdateConfig RPC
+	configUpdateProtocolVersion = 17
+
+	// congestionProtocolVersion is the first protocol version in which the
+	// plugin implements the Congestion RPC
+	congestionProtocolVersion = 18
+
+	// configCheckProtocolVersion is the first protocol version in which the
+	// plugin implements the CheckConfig RPC
+	configCheckProtocolVersion = 19
+)
+
+// The gRPC headers that the plugin reports whether its VM supports the optional
+// features in, in response to a Version request
+const (
+	stateSyncHeader   = "vm-feature-state-sync"
+	heightIndexHeader = "vm-feature-height-index"
+)
 
 var (
 	// Handshake is a common handshake that is shared by plugin and host.
@@ -33,6 +68,10 @@ var (
 		"vm": &vmPlugin{},
 	}
 
+	// VersionedPluginMap is the map of plugins we can dispense with each
+	// supported protocol version.
+	VersionedPluginMap = versionedPluginSets(PluginMap)
+
 	_ plugin.Plugin     = &vmPlugin{}
 	_ plugin.GRPCPlugin = &vmPlugin{}
 )
@@ -61,13 +100,24 @@ func (p *vmPlugin) GRPCClient(ctx context.Context, _ *plugin.GRPCBroker, c *grpc
 	return NewClient(vmpb.NewVMClient(c)), nil
 }
 
-// Serve serves a ChainVM plugin using sane gRPC server defaults.
+// versionedPluginSets returns [plugins] for each supported protocol version
+func versionedPluginSets(plugins plugin.PluginSet) map[int]plugin.PluginSet {
+	sets := make(map[int]plugin.PluginSet, protocolVersion-minProtocolVersion+1)
+	for version := minProtocolVersion; version <= protocolVersion; version++ {
+		sets[version] = plugins
+	}
+	return sets
+}
+
+// Serve serves a ChainVM plugin using sane gRPC server defaults. The plugin is
+// served using the highest protocol version that is supported by both the
+// plugin and the node.
 func Serve(vm block.ChainVM) {
 	plugin.Serve(&plugin.ServeConfig{
 		HandshakeConfig: Handshake,
-		Plugins: map[string]plugin.Plugin{
+		VersionedPlugins: versionedPluginSets(plugin.PluginSet{
 			"vm": New(vm),
-		},
+		}),
 		// ensure proper defaults
 		GRPCServer: grpcutils.NewDefaultServer,
 	})
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-plugin"
@@ -16,10 +17,13 @@ import (
 
 	dto "github.com/prometheus/client_model/go"
 
+	"github.com/shirou/gopsutil/process"
+
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
@@ -37,9 +41,11 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/appsender"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/components/chain"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/ghttp"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
@@ -60,12 +66,20 @@ import (
 var (
 	errUnsupportedFXs                       = errors.New("unsupported feature extensions")
 	errBatchedParseBlockWrongNumberOfBlocks = errors.New("BatchedParseBlock returned different number of blocks than expected")
-
-	_ block.ChainVM              = &VMClient{}
-	_ block.BatchedChainVM       = &VMClient{}
-	_ block.HeightIndexedChainVM = &VMClient{}
-	_ block.StateSyncableVM      = &VMClient{}
-	_ prometheus.Gatherer        = &VMClient{}
+	errPluginExited                         = errors.New("plugin process exited")
+	errPluginRestarting                     = errors.New("plugin is restarting")
+	errPluginLastAcceptedMismatch           = errors.New("plugin's last accepted block doesn't match the chain's")
+	errShutdown                             = errors.New("vm was shutdown")
+
+	_ block.ChainVM               = &VMClient{}
+	_ block.BatchedChainVM        = &VMClient{}
+	_ block.HeightIndexedChainVM  = &VMClient{}
+	_ block.StateSyncableVM       = &VMClient{}
+	_ block.CongestionReportingVM = &VMClient{}
+	_ common.ConfigChecker        = &VMClient{}
+	_ common.ConfigUpdater        = &VMClient{}
+	_ prometheus.Gatherer         = &VMClient{}
+	_ vms.Plugin                  = &VMClient{}
 
 	_ snowman.Block = &blockClient{}
 
@@ -82,10 +96,39 @@ const (
 // VMClient is an implementation of a VM that talks over RPC.
 type VMClient struct {
 	*chain.State
+	// clientLock must be held to replace [client] and [pid]. Calls that may be
+	// made without holding the context lock must hold [clientLock] to read
+	// them.
+	clientLock     sync.RWMutex
 	client         vmpb.VMClient
 	proc           *plugin.Client
+	rpcClient      plugin.ClientProtocol
 	pid            int
 	processTracker resource.ProcessTracker
+	// protocolVersion is the protocol version negotiated with the plugin
+	protocolVersion uint
+	// streams are used instead of unary RPCs if the plugin supports them
+	streams streams
+
+	// startPlugin launches a new plugin process. If nil, the plugin isn't
+	// restarted when it crashes.
+	startPlugin func() (*plugin.Client, plugin.ClientProtocol, vmpb.VMClient, error)
+	supervisor  *supervisor
+	// restarting is true from when the plugin is stopped to be restarted until
+	// it has been replaced. Calls that don't hold the context lock fail with
+	// errPluginRestarting in the meantime, rather than waiting on the stopped
+	// plugin. Calls to the stopped plugin fail as soon as its connection is
+	// closed.
+	restarting utils.AtomicBool
+	// pluginStopped is true if [proc] was killed to be replaced. It's only
+	// accessed by the supervisor.
+	pluginStopped bool
+	// The request that the plugin was initialized with, and the last state
+	// it was set to, so that a restarted plugin can be re-initialized
+	initRequest *vmpb.InitializeRequest
+	state       snow.State
+	stateSet    bool
+	shutdown    bool
 
 	messenger    *messenger.Server
 	keystore     *gkeystore.Server
@@ -105,7 +148,8 @@ type VMClient struct {
 // NewClient returns a VM connected to a remote VM
 func NewClient(client vmpb.VMClient) *VMClient {
 	return &VMClient{
-		client: client,
+		client:          client,
+		protocolVersion: protocolVersion,
 	}
 }
 
@@ -115,6 +159,7 @@ func (vm *VMClient) SetProcess(ctx *snow.Context, proc *plugin.Client, processTr
 	vm.proc = proc
 	vm.processTracker = processTracker
 	vm.pid = proc.ReattachConfig().Pid
+	vm.protocolVersion = uint(proc.NegotiatedVersion())
 	processTracker.TrackProcess(vm.pid)
 }
 
@@ -141,6 +186,14 @@ func (vm *VMClient) Initialize(
 	if err := registerer.Register(vm.grpcServerMetrics); err != nil {
 		return err
 	}
+	// The memory of the plugin's process is only used by this chain
+	pluginMemoryMetric := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "plugin_memory_usage",
+		Help: "Resident memory (bytes) of the plugin process that runs the VM",
+	}, vm.pluginMemoryUsage)
+	if err := registerer.Register(pluginMemoryMetric); err != nil {
+		return err
+	}
 	if err := multiGatherer.Register("rpcchainvm", registerer); err != nil {
 		return err
 	}
@@ -186,7 +239,7 @@ func (vm *VMClient) Initialize(
 	go grpcutils.Serve(serverListener, vm.getInitServer)
 	vm.ctx.Log.Info("grpc: serving vm services on: %s", serverAddr)
 
-	resp, err := vm.client.Initialize(context.Background(), &vmpb.InitializeRequest{
+	vm.initRequest = &vmpb.InitializeRequest{
 		NetworkId:    ctx.NetworkID,
 		SubnetId:     ctx.SubnetID[:],
 		ChainId:      ctx.ChainID[:],
@@ -198,35 +251,17 @@ func (vm *VMClient) Initialize(
 		ConfigBytes:  configBytes,
 		DbServers:    versionedDBServers,
 		ServerAddr:   serverAddr,
-	})
-	if err != nil {
-		return err
 	}
-
-	id, err := ids.ToID(resp.LastAcceptedId)
-	if err != nil {
-		return err
-	}
-	parentID, err := ids.ToID(resp.LastAcceptedParentId)
+	resp, err := vm.client.Initialize(context.Background(), vm.initRequest)
 	if err != nil {
 		return err
 	}
 
-	time, err := grpcutils.TimestampAsTime(resp.Timestamp)
+	lastAcceptedBlk, err := vm.newLastAcceptedBlock(resp)
 	if err != nil {
 		return err
 	}
 
-	lastAcceptedBlk := &blockClient{
-		vm:       vm,
-		id:       id,
-		parentID: parentID,
-		status:   choices.Accepted,
-		bytes:    resp.Bytes,
-		height:   resp.Height,
-		time:     time,
-	}
-
 	chainState, err := chain.NewMeteredState(
 		registerer,
 		&chain.Config{
@@ -245,9 +280,188 @@ func (vm *VMClient) Initialize(
 	}
 	vm.State = chainState
 
+	if vm.startPlugin != nil {
+		vm.supervisor, err = newSupervisor(ctx.Log, vm.checkPlugin, vm.restartPlugin, registerer)
+		if err != nil {
+			return err
+		}
+		go ctx.Log.RecoverAndPanic(vm.supervisor.Dispatch)
+	}
+
 	return vm.ctx.Metrics.Register(multiGatherer)
 }
 
+func (vm *VMClient) newLastAcceptedBlock(resp *vmpb.InitializeResponse) (*blockClient, error) {
+	id, err := ids.ToID(resp.LastAcceptedId)
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := ids.ToID(resp.LastAcceptedParentId)
+	if err != nil {
+		return nil, err
+	}
+
+	time, err := grpcutils.TimestampAsTime(resp.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockClient{
+		vm:       vm,
+		id:       id,
+		parentID: parentID,
+		status:   choices.Accepted,
+		bytes:    resp.Bytes,
+		height:   resp.Height,
+		time:     time,
+	}, nil
+}
+
+// checkPlugin returns an error if the plugin process has exited or stopped
+// responding
+func (vm *VMClient) checkPlugin() error {
+	if vm.proc.Exited() {
+		return errPluginExited
+	}
+	return vm.rpcClient.Ping()
+}
+
+// restartPlugin replaces the plugin process with a new one and re-initializes
+// it with the same arguments the VM was originally initialized with. The
+// services served to the plugin by this process, such as the databases, are
+// re-used.
+//
+// A plugin that stopped responding may still be running, so it's killed, and
+// waited for, before the new plugin is started. Otherwise, both plugins could
+// write to the chain's databases at the same time. Killing the plugin also
+// fails the calls that were waiting on it, which releases the context lock if
+// a call made while holding it was stuck.
+//
+// The new plugin is started and initialized without holding the context lock,
+// so the chain keeps handling messages in the meantime. The context lock is
+// only held to replace the plugin and to re-verify the blocks that were
+// processing in the crashed plugin, so that they can still be decided.
+func (vm *VMClient) restartPlugin() error {
+	vm.restarting.SetValue(true)
+	if !vm.pluginStopped {
+		// Kill blocks until the process has exited
+		vm.proc.Kill()
+		vm.processTracker.UntrackProcess(vm.pid)
+		vm.pluginStopped = true
+	}
+
+	proc, rpcClient, client, err := vm.startPlugin()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Initialize(context.Background(), vm.initRequest)
+	if err != nil {
+		proc.Kill()
+		return fmt.Errorf("failed to re-initialize plugin: %w", err)
+	}
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	if vm.shutdown {
+		proc.Kill()
+		return errShutdown
+	}
+
+	// If the plugin can't be brought up to date, the restart fails and the
+	// plugin is replaced again by the next restart.
+	return vm.replacePlugin(proc, rpcClient, client, resp)
+}
+
+// pluginMemoryUsage returns the number of bytes of resident memory used by the
+// plugin's process, or 0 if it can't be read.
+func (vm *VMClient) pluginMemoryUsage() float64 {
+	vm.clientLock.RLock()
+	pid := vm.pid
+	vm.clientLock.RUnlock()
+
+	p, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return 0
+	}
+	mem, err := p.MemoryInfo()
+	if err != nil {
+		return 0
+	}
+	return float64(mem.RSS)
+}
+
+// replacePlugin replaces the plugin process with [proc], which was initialized
+// with the response [resp], and brings it up to date with the chain.
+//
+// Assumes the context lock is held.
+func (vm *VMClient) replacePlugin(
+	proc *plugin.Client,
+	rpcClient plugin.ClientProtocol,
+	client vmpb.VMClient,
+	resp *vmpb.InitializeResponse,
+) error {
+	// The streams were opened to the killed plugin, so they can only return
+	// errors.
+	_ = vm.streams.Close()
+
+	vm.clientLock.Lock()
+	vm.client = client
+	vm.pid = proc.ReattachConfig().Pid
+	vm.clientLock.Unlock()
+
+	vm.proc = proc
+	vm.rpcClient = rpcClient
+	vm.protocolVersion = uint(proc.NegotiatedVersion())
+	vm.processTracker.TrackProcess(vm.pid)
+	vm.pluginStopped = false
+	vm.restarting.SetValue(false)
+
+	if vm.stateSet {
+		if _, err := client.SetState(context.Background(), &vmpb.SetStateRequest{
+			State: uint32(vm.state),
+		}); err != nil {
+			return fmt.Errorf("failed to set the state of the plugin: %w", err)
+		}
+	}
+
+	lastAcceptedBlk, err := vm.newLastAcceptedBlock(resp)
+	if err != nil {
+		return err
+	}
+	processingBlks := vm.State.VerifiedBlocksInternal()
+	if len(processingBlks) == 0 {
+		vm.State.Flush()
+		return vm.State.SetLastAcceptedBlock(lastAcceptedBlk)
+	}
+
+	// The processing blocks were built on the last accepted block known to
+	// the chain, so they can only be re-verified if the plugin still agrees
+	// on it.
+	lastAcceptedID, err := vm.State.LastAccepted()
+	if err != nil {
+		return err
+	}
+	if lastAcceptedBlk.id != lastAcceptedID {
+		return fmt.Errorf(
+			"%w: plugin's last accepted block %s doesn't match %s",
+			errPluginLastAcceptedMismatch,
+			lastAcceptedBlk.id,
+			lastAcceptedID,
+		)
+	}
+
+	// The blocks are sorted by height, so every block is verified after its
+	// parent.
+	for _, blk := range processingBlks {
+		if err := blk.Verify(); err != nil {
+			return fmt.Errorf("failed to re-verify processing block %s: %w", blk.ID(), err)
+		}
+	}
+	return nil
+}
+
 func (vm *VMClient) getDBServerFunc(db rpcdbpb.DatabaseServer) func(opts []grpc.ServerOption) *grpc.Server { // #nolint
 	return func(opts []grpc.ServerOption) *grpc.Server {
 		if len(opts) == 0 {
@@ -327,6 +541,8 @@ func (vm *VMClient) SetState(state snow.State) error {
 	if err != nil {
 		return err
 	}
+	vm.state = state
+	vm.stateSet = true
 
 	id, err := ids.ToID(resp.LastAcceptedId)
 	if err != nil {
@@ -355,7 +571,13 @@ func (vm *VMClient) SetState(state snow.State) error {
 }
 
 func (vm *VMClient) Shutdown() error {
+	vm.shutdown = true
+	if vm.supervisor != nil {
+		vm.supervisor.Stop()
+	}
+
 	errs := wrappers.Errs{}
+	errs.Add(vm.streams.Close())
 	_, err := vm.client.Shutdown(context.Background(), &emptypb.Empty{})
 	errs.Add(err)
 
@@ -457,9 +679,18 @@ func (vm *VMClient) buildBlock() (snowman.Block, error) {
 }
 
 func (vm *VMClient) parseBlock(bytes []byte) (snowman.Block, error) {
-	resp, err := vm.client.ParseBlock(context.Background(), &vmpb.ParseBlockRequest{
+	req := &vmpb.ParseBlockRequest{
 		Bytes: bytes,
-	})
+	}
+	var (
+		resp *vmpb.ParseBlockResponse
+		err  error
+	)
+	if vm.supportsStreaming() {
+		resp, err = vm.streams.ParseBlock(vm.client, req)
+	} else {
+		resp, err = vm.client.ParseBlock(context.Background(), req)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -532,7 +763,15 @@ func (vm *VMClient) SetPreference(id ids.ID) error {
 }
 
 func (vm *VMClient) HealthCheck() (interface{}, error) {
-	health, err := vm.client.Health(context.Background(), &emptypb.Empty{})
+	if vm.restarting.GetValue() || (vm.supervisor != nil && !vm.supervisor.Healthy()) {
+		return nil, fmt.Errorf("health check failed: %w", errPluginRestarting)
+	}
+
+	vm.clientLock.RLock()
+	client := vm.client
+	vm.clientLock.RUnlock()
+
+	health, err := client.Health(context.Background(), &emptypb.Empty{})
 	if err != nil {
 		return nil, fmt.Errorf("health check failed: %w", err)
 	}
@@ -551,6 +790,61 @@ func (vm *VMClient) Version() (string, error) {
 	return resp.Version, nil
 }
 
+// ProtocolVersion returns the protocol version negotiated with the plugin
+func (vm *VMClient) ProtocolVersion() uint {
+	return vm.protocolVersion
+}
+
+// supportsStateSync returns false if the plugin predates the state sync RPCs
+func (vm *VMClient) supportsStateSync() bool {
+	return vm.protocolVersion >= stateSyncProtocolVersion
+}
+
+// supportsStreaming returns false if the plugin predates the streaming RPCs
+func (vm *VMClient) supportsStreaming() bool {
+	return vm.protocolVersion >= streamingProtocolVersion
+}
+
+// supportsConfigUpdate returns false if the plugin predates the UpdateConfig
+// RPC
+func (vm *VMClient) supportsConfigUpdate() bool {
+	return vm.protocolVersion >= configUpdateProtocolVersion
+}
+
+// supportsCongestion returns false if the plugin predates the Congestion RPC
+func (vm *VMClient) supportsCongestion() bool {
+	return vm.protocolVersion >= congestionProtocolVersion
+}
+
+// supportsConfigCheck returns false if the plugin predates the CheckConfig
+// RPC
+func (vm *VMClient) supportsConfigCheck() bool {
+	return vm.protocolVersion >= configCheckProtocolVersion
+}
+
+func (vm *VMClient) Features() (*vms.Features, error) {
+	var header metadata.MD
+	_, err := vm.client.Version(
+		context.Background(),
+		&emptypb.Empty{},
+		grpc.Header(&header),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stateSync := header.Get(stateSyncHeader)
+	heightIndex := header.Get(heightIndexHeader)
+	if len(stateSync) == 0 || len(heightIndex) == 0 {
+		// The plugin predates feature reporting
+		return nil, nil
+	}
+	return &vms.Features{
+		StateSync:   stateSync[0] == "true",
+		HeightIndex: heightIndex[0] == "true",
+	}, nil
+}
+
 func (vm *VMClient) AppRequest(nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
 	_, err := vm.client.AppRequest(
 		context.Background(),
@@ -588,18 +882,27 @@ func (vm *VMClient) AppRequestFailed(nodeID ids.NodeID, requestID uint32) error
 }
 
 func (vm *VMClient) AppGossip(nodeID ids.NodeID, msg []byte) error {
-	_, err := vm.client.AppGossip(
-		context.Background(),
-		&vmpb.AppGossipMsg{
-			NodeId: nodeID[:],
-			Msg:    msg,
-		},
-	)
+	req := &vmpb.AppGossipMsg{
+		NodeId: nodeID[:],
+		Msg:    msg,
+	}
+	if vm.supportsStreaming() {
+		return vm.streams.AppGossip(vm.client, req)
+	}
+	_, err := vm.client.AppGossip(context.Background(), req)
 	return err
 }
 
 func (vm *VMClient) Gather() ([]*dto.MetricFamily, error) {
-	resp, err := vm.client.Gather(context.Background(), &emptypb.Empty{})
+	if vm.restarting.GetValue() {
+		return nil, errPluginRestarting
+	}
+
+	vm.clientLock.RLock()
+	client := vm.client
+	vm.clientLock.RUnlock()
+
+	resp, err := client.Gather(context.Background(), &emptypb.Empty{})
 	if err != nil {
 		return nil, err
 	}
@@ -697,6 +1000,10 @@ func (vm *VMClient) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
 }
 
 func (vm *VMClient) StateSyncEnabled() (bool, error) {
+	if !vm.supportsStateSync() {
+		return false, nil
+	}
+
 	resp, err := vm.client.StateSyncEnabled(
 		context.Background(),
 		&emptypb.Empty{},
@@ -712,6 +1019,10 @@ func (vm *VMClient) StateSyncEnabled() (bool, error) {
 }
 
 func (vm *VMClient) GetOngoingSyncStateSummary() (block.StateSummary, error) {
+	if !vm.supportsStateSync() {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+
 	resp, err := vm.client.GetOngoingSyncStateSummary(context.Background(), &emptypb.Empty{})
 	if err != nil {
 		return nil, err
@@ -730,6 +1041,10 @@ func (vm *VMClient) GetOngoingSyncStateSummary() (block.StateSummary, error) {
 }
 
 func (vm *VMClient) GetLastStateSummary() (block.StateSummary, error) {
+	if !vm.supportsStateSync() {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+
 	resp, err := vm.client.GetLastStateSummary(context.Background(), &emptypb.Empty{})
 	if err != nil {
 		return nil, err
@@ -748,6 +1063,10 @@ func (vm *VMClient) GetLastStateSummary() (block.StateSummary, error) {
 }
 
 func (vm *VMClient) ParseStateSummary(summaryBytes []byte) (block.StateSummary, error) {
+	if !vm.supportsStateSync() {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+
 	resp, err := vm.client.ParseStateSummary(
 		context.Background(),
 		&vmpb.ParseStateSummaryRequest{
@@ -771,6 +1090,10 @@ func (vm *VMClient) ParseStateSummary(summaryBytes []byte) (block.StateSummary,
 }
 
 func (vm *VMClient) GetStateSummary(summaryHeight uint64) (block.StateSummary, error) {
+	if !vm.supportsStateSync() {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+
 	resp, err := vm.client.GetStateSummary(
 		context.Background(),
 		&vmpb.GetStateSummaryRequest{
@@ -793,6 +1116,59 @@ func (vm *VMClient) GetStateSummary(summaryHeight uint64) (block.StateSummary, e
 	}, err
 }
 
+func (vm *VMClient) UpdateConfig(configBytes []byte) error {
+	if !vm.supportsConfigUpdate() {
+		return common.ErrConfigUpdaterNotImplemented
+	}
+
+	resp, err := vm.client.UpdateConfig(
+		context.Background(),
+		&vmpb.UpdateConfigRequest{
+			ConfigBytes: configBytes,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return errCodeToError[resp.Err]
+}
+
+func (vm *VMClient) Congestion() (float64, error) {
+	if !vm.supportsCongestion() {
+		return 0, block.ErrCongestionReportingVMNotImplemented
+	}
+
+	resp, err := vm.client.Congestion(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Congestion, errCodeToError[resp.Err]
+}
+
+func (vm *VMClient) CheckConfig(genesisBytes []byte, configBytes []byte) error {
+	if !vm.supportsConfigCheck() {
+		return common.ErrConfigCheckerNotImplemented
+	}
+
+	resp, err := vm.client.CheckConfig(
+		context.Background(),
+		&vmpb.CheckConfigRequest{
+			GenesisBytes: genesisBytes,
+			ConfigBytes:  configBytes,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if errCode := resp.Err; errCode != 0 {
+		return errCodeToError[errCode]
+	}
+	if len(resp.Message) != 0 {
+		return errors.New(resp.Message)
+	}
+	return nil
+}
+
 type blockClient struct {
 	vm *VMClient
 
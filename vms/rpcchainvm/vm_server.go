@@ -7,9 +7,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -55,6 +59,9 @@ type VMServer struct {
 	vm   block.ChainVM
 	hVM  block.HeightIndexedChainVM
 	ssVM block.StateSyncableVM
+	cuVM common.ConfigUpdater
+	cVM  block.CongestionReportingVM
+	ccVM common.ConfigChecker
 
 	processMetrics prometheus.Gatherer
 	dbManager      manager.Manager
@@ -62,6 +69,10 @@ type VMServer struct {
 	serverCloser grpcutils.ServerCloser
 	connCloser   wrappers.Closer
 
+	// log writes to the stderr of the plugin, which is forwarded to the log
+	// of the chain
+	log logging.Logger
+
 	ctx    *snow.Context
 	closed chan struct{}
 }
@@ -70,10 +81,21 @@ type VMServer struct {
 func NewServer(vm block.ChainVM) *VMServer {
 	hVM, _ := vm.(block.HeightIndexedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	cuVM, _ := vm.(common.ConfigUpdater)
+	cVM, _ := vm.(block.CongestionReportingVM)
+	ccVM, _ := vm.(common.ConfigChecker)
 	return &VMServer{
 		vm:   vm,
 		hVM:  hVM,
 		ssVM: ssVM,
+		cuVM: cuVM,
+		cVM:  cVM,
+		ccVM: ccVM,
+		log: logging.NewLogger(
+			false,
+			"",
+			logging.NewWrappedCore(logging.Info, os.Stderr, logging.Plain.ConsoleEncoder()),
+		),
 	}
 }
 
@@ -395,6 +417,31 @@ func (vm *VMServer) ParseBlock(_ context.Context, req *vmpb.ParseBlockRequest) (
 	}, nil
 }
 
+func (vm *VMServer) ParseBlockStream(stream vmpb.VM_ParseBlockStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// A block failing to parse is reported in the response, rather than
+		// by terminating the stream, so that the stream can be re-used.
+		resp, err := vm.ParseBlock(stream.Context(), req)
+		streamResp := &vmpb.ParseBlockStreamResponse{
+			Response: resp,
+		}
+		if err != nil {
+			streamResp.Error = err.Error()
+		}
+		if err := stream.Send(streamResp); err != nil {
+			return err
+		}
+	}
+}
+
 func (vm *VMServer) GetBlock(_ context.Context, req *vmpb.GetBlockRequest) (*vmpb.GetBlockResponse, error) {
 	id, err := ids.ToID(req.Id)
 	if err != nil {
@@ -461,7 +508,15 @@ func (vm *VMServer) dbHealthChecks() (interface{}, error) {
 	return details, nil
 }
 
-func (vm *VMServer) Version(context.Context, *emptypb.Empty) (*vmpb.VersionResponse, error) {
+func (vm *VMServer) Version(ctx context.Context, _ *emptypb.Empty) (*vmpb.VersionResponse, error) {
+	header := metadata.Pairs(
+		stateSyncHeader, strconv.FormatBool(vm.ssVM != nil),
+		heightIndexHeader, strconv.FormatBool(vm.hVM != nil),
+	)
+	if err := grpc.SetHeader(ctx, header); err != nil {
+		return nil, err
+	}
+
 	version, err := vm.vm.Version()
 	return &vmpb.VersionResponse{
 		Version: version,
@@ -504,6 +559,24 @@ func (vm *VMServer) AppGossip(_ context.Context, req *vmpb.AppGossipMsg) (*empty
 	return &emptypb.Empty{}, vm.vm.AppGossip(nodeID, req.Msg)
 }
 
+func (vm *VMServer) AppGossipStream(stream vmpb.VM_AppGossipStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&emptypb.Empty{})
+		}
+		if err != nil {
+			return err
+		}
+
+		// A message failing to be handled is logged, rather than terminating
+		// the stream, so that later messages are still delivered.
+		if _, err := vm.AppGossip(stream.Context(), req); err != nil {
+			vm.log.Warn("failed to handle streamed AppGossip message: %s", err)
+		}
+	}
+}
+
 func (vm *VMServer) Gather(context.Context, *emptypb.Empty) (*vmpb.GatherResponse, error) {
 	// Gather metrics registered to snow context Gatherer. These
 	// metrics are defined by the underlying vm implementation.
@@ -784,3 +857,49 @@ func (vm *VMServer) StateSummaryAccept(
 		Err:      errorToErrCode[err],
 	}, errorToRPCError(err)
 }
+
+func (vm *VMServer) UpdateConfig(_ context.Context, req *vmpb.UpdateConfigRequest) (*vmpb.UpdateConfigResponse, error) {
+	var err error
+	if vm.cuVM != nil {
+		err = vm.cuVM.UpdateConfig(req.ConfigBytes)
+	} else {
+		err = common.ErrConfigUpdaterNotImplemented
+	}
+
+	return &vmpb.UpdateConfigResponse{
+		Err: errorToErrCode[err],
+	}, errorToRPCError(err)
+}
+
+func (vm *VMServer) Congestion(context.Context, *emptypb.Empty) (*vmpb.CongestionResponse, error) {
+	var (
+		congestion float64
+		err        error
+	)
+	if vm.cVM != nil {
+		congestion, err = vm.cVM.Congestion()
+	} else {
+		err = block.ErrCongestionReportingVMNotImplemented
+	}
+
+	return &vmpb.CongestionResponse{
+		Congestion: congestion,
+		Err:        errorToErrCode[err],
+	}, errorToRPCError(err)
+}
+
+func (vm *VMServer) CheckConfig(_ context.Context, req *vmpb.CheckConfigRequest) (*vmpb.CheckConfigResponse, error) {
+	if vm.ccVM == nil {
+		return &vmpb.CheckConfigResponse{
+			Err: errorToErrCode[common.ErrConfigCheckerNotImplemented],
+		}, nil
+	}
+
+	// A rejected genesis or config is the result of the check rather than a
+	// failure of the RPC, so the reason is returned in the response.
+	resp := &vmpb.CheckConfigResponse{}
+	if err := vm.ccVM.CheckConfig(req.GenesisBytes, req.ConfigBytes); err != nil {
+		resp.Message = err.Error()
+	}
+	return resp, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+)
+
+var emptySig [crypto.SECP256K1RSigLen]byte
+
+// NewOutputOwners returns the owners of an output that can be spent after
+// [locktime] with signatures from [threshold] of [addrs]. [addrs] may be
+// provided in any order and may contain duplicates.
+func NewOutputOwners(locktime uint64, threshold uint32, addrs []ids.ShortID) (*OutputOwners, error) {
+	addrSet := ids.NewShortSet(len(addrs))
+	addrSet.Add(addrs...)
+
+	owners := &OutputOwners{
+		Locktime:  locktime,
+		Threshold: threshold,
+		Addrs:     addrSet.List(),
+	}
+	owners.Sort()
+	return owners, owners.Verify()
+}
+
+// SignatureStatus describes the signatures of an input that spends an output
+type SignatureStatus struct {
+	// Signed are the addresses that have provided a valid signature
+	Signed []ids.ShortID
+	// Missing are the addresses that the input names as signers, but that
+	// haven't provided a valid signature
+	Missing []ids.ShortID
+}
+
+// Complete returns true if every signer named by the input has signed
+func (s *SignatureStatus) Complete() bool { return len(s.Missing) == 0 }
+
+// GetSignatureStatus returns which of the signers that [in] names to spend
+// [out] have signed [txHash] in [cred]. [cred] may be nil or partially
+// populated, as is the case for a tx that has only been signed by some of the
+// owners of a multisig output. Signatures that are present, but weren't
+// produced by the expected address, are reported as missing.
+func GetSignatureStatus(
+	factory *crypto.FactorySECP256K1R,
+	txHash []byte,
+	in *Input,
+	cred *Credential,
+	out *OutputOwners,
+) (*SignatureStatus, error) {
+	switch numSigs := len(in.SigIndices); {
+	case out.Threshold < uint32(numSigs):
+		return nil, errTooManySigners
+	case out.Threshold > uint32(numSigs):
+		return nil, errTooFewSigners
+	case cred != nil && len(cred.Sigs) > numSigs:
+		return nil, errInputCredentialSignersMismatch
+	}
+
+	status := &SignatureStatus{}
+	for i, index := range in.SigIndices {
+		if index >= uint32(len(out.Addrs)) {
+			return nil, errInputOutputIndexOutOfBounds
+		}
+		expectedAddress := out.Addrs[index]
+
+		if cred == nil || i >= len(cred.Sigs) || cred.Sigs[i] == emptySig {
+			status.Missing = append(status.Missing, expectedAddress)
+			continue
+		}
+
+		sig := cred.Sigs[i]
+		pk, err := factory.RecoverHashPublicKey(txHash, sig[:])
+		if err != nil || pk.Address() != expectedAddress {
+			status.Missing = append(status.Missing, expectedAddress)
+			continue
+		}
+		status.Signed = append(status.Signed, expectedAddress)
+	}
+	return status, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2021, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+func TestNewOutputOwners(t *testing.T) {
+	assert := assert.New(t)
+
+	otherAddr := ids.GenerateTestShortID()
+	owners, err := NewOutputOwners(5, 2, []ids.ShortID{addr2, otherAddr, addr, addr2})
+	assert.NoError(err)
+	assert.EqualValues(5, owners.Locktime)
+	assert.EqualValues(2, owners.Threshold)
+	assert.Len(owners.Addrs, 3)
+	assert.True(ids.IsSortedAndUniqueShortIDs(owners.Addrs))
+
+	_, err = NewOutputOwners(0, 3, []ids.ShortID{addr, addr2, addr})
+	assert.ErrorIs(err, errOutputUnspendable)
+
+	_, err = NewOutputOwners(0, 0, []ids.ShortID{addr})
+	assert.ErrorIs(err, errOutputUnoptimized)
+}
+
+func TestGetSignatureStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	owners, err := NewOutputOwners(0, 2, []ids.ShortID{addr, addr2, ids.GenerateTestShortID()})
+	assert.NoError(err)
+
+	in := &Input{}
+	for i, owner := range owners.Addrs {
+		if owner == addr || owner == addr2 {
+			in.SigIndices = append(in.SigIndices, uint32(i))
+		}
+	}
+	sigs := map[ids.ShortID][crypto.SECP256K1RSigLen]byte{
+		addr:  sigBytes,
+		addr2: sig2Bytes,
+	}
+	firstSigner := owners.Addrs[in.SigIndices[0]]
+	secondSigner := owners.Addrs[in.SigIndices[1]]
+
+	factory := &crypto.FactorySECP256K1R{}
+	txHash := hashing.ComputeHash256(txBytes)
+
+	// Nothing has been signed yet
+	status, err := GetSignatureStatus(factory, txHash, in, nil, owners)
+	assert.NoError(err)
+	assert.False(status.Complete())
+	assert.Empty(status.Signed)
+	assert.Equal([]ids.ShortID{firstSigner, secondSigner}, status.Missing)
+
+	// Partially signed
+	cred := &Credential{
+		Sigs: [][crypto.SECP256K1RSigLen]byte{
+			sigs[firstSigner],
+			{},
+		},
+	}
+	status, err = GetSignatureStatus(factory, txHash, in, cred, owners)
+	assert.NoError(err)
+	assert.False(status.Complete())
+	assert.Equal([]ids.ShortID{firstSigner}, status.Signed)
+	assert.Equal([]ids.ShortID{secondSigner}, status.Missing)
+
+	// A signature from the wrong signer is still missing
+	cred.Sigs[1] = sigs[firstSigner]
+	status, err = GetSignatureStatus(factory, txHash, in, cred, owners)
+	assert.NoError(err)
+	assert.Equal([]ids.ShortID{secondSigner}, status.Missing)
+
+	// Fully signed
+	cred.Sigs[1] = sigs[secondSigner]
+	status, err = GetSignatureStatus(factory, txHash, in, cred, owners)
+	assert.NoError(err)
+	assert.True(status.Complete())
+	assert.Equal([]ids.ShortID{firstSigner, secondSigner}, status.Signed)
+
+	// The input must name exactly [Threshold] signers
+	_, err = GetSignatureStatus(factory, txHash, &Input{SigIndices: []uint32{0}}, nil, owners)
+	assert.ErrorIs(err, errTooFewSigners)
+
+	_, err = GetSignatureStatus(factory, txHash, &Input{SigIndices: []uint32{0, 3}}, nil, owners)
+	assert.ErrorIs(err, errInputOutputIndexOutOfBounds)
+}
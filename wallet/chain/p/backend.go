@@ -62,6 +62,10 @@ func (b *backend) AcceptTx(ctx stdcontext.Context, tx *txs.Tx) error {
 		baseTx = &utx.BaseTx
 	case *txs.AddValidatorTx:
 		baseTx = &utx.BaseTx
+	case *txs.RemoveSubnetValidatorTx:
+		baseTx = &utx.BaseTx
+	case *txs.RegisterBLSKeyTx:
+		baseTx = &utx.BaseTx
 	case *txs.ExportTx:
 		baseTx = &utx.BaseTx
 
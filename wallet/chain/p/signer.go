@@ -79,6 +79,8 @@ func (s *signer) Sign(ctx stdcontext.Context, tx *txs.Tx) error {
 		return s.signImportTx(ctx, tx, utx)
 	case *txs.ExportTx:
 		return s.signExportTx(ctx, tx, utx)
+	case *txs.RemoveSubnetValidatorTx:
+		return s.signRemoveSubnetValidatorTx(ctx, tx, utx)
 	default:
 		return fmt.Errorf("%w: %T", errUnknownTxType, tx.Unsigned)
 	}
@@ -105,6 +107,19 @@ func (s *signer) signAddSubnetValidatorTx(ctx stdcontext.Context, tx *txs.Tx, ut
 	return s.sign(tx, txSigners)
 }
 
+func (s *signer) signRemoveSubnetValidatorTx(ctx stdcontext.Context, tx *txs.Tx, utx *txs.RemoveSubnetValidatorTx) error {
+	txSigners, err := s.getSigners(ctx, constants.PlatformChainID, utx.Ins)
+	if err != nil {
+		return err
+	}
+	subnetAuthSigners, err := s.getSubnetSigners(ctx, utx.Subnet, utx.SubnetAuth)
+	if err != nil {
+		return err
+	}
+	txSigners = append(txSigners, subnetAuthSigners)
+	return s.sign(tx, txSigners)
+}
+
 func (s *signer) signAddDelegatorTx(ctx stdcontext.Context, tx *txs.Tx, utx *txs.AddDelegatorTx) error {
 	txSigners, err := s.getSigners(ctx, constants.PlatformChainID, utx.Ins)
 	if err != nil {